@@ -0,0 +1,100 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// AmethystCluster is a crystal that grows on budding amethyst in four stages, from a small bud to a fully grown
+// cluster. Fully grown clusters drop amethyst shards when mined.
+type AmethystCluster struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Growth is the growth stage of the cluster.
+	Growth AmethystGrowth
+	// Facing is the direction that the cluster grows towards, away from the block it is attached to.
+	Facing cube.Face
+}
+
+// Model ...
+func (a AmethystCluster) Model() world.BlockModel {
+	return model.AmethystCluster{Facing: a.Facing, Height: [...]float64{0.1875, 0.25, 0.3125, 0.4375}[a.Growth.Uint8()]}
+}
+
+// LightEmissionLevel ...
+func (a AmethystCluster) LightEmissionLevel() uint8 {
+	return [...]uint8{1, 2, 4, 5}[a.Growth.Uint8()]
+}
+
+// SideClosed ...
+func (AmethystCluster) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// HasLiquidDrops ...
+func (AmethystCluster) HasLiquidDrops() bool {
+	return true
+}
+
+// UseOnBlock ...
+func (a AmethystCluster) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(tx, pos, face, a)
+	if !used {
+		return false
+	}
+	if !tx.Block(pos.Side(face.Opposite())).Model().FaceSolid(pos.Side(face.Opposite()), face, tx) {
+		return false
+	}
+	a.Facing = face
+
+	place(tx, pos, a, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (a AmethystCluster) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	support := pos.Side(a.Facing.Opposite())
+	if !tx.Block(support).Model().FaceSolid(support, a.Facing, tx) {
+		breakBlock(a, pos, tx)
+	}
+}
+
+// BreakInfo ...
+func (a AmethystCluster) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, pickaxeEffective, func(t item.Tool, enchantments []item.Enchantment) []item.Stack {
+		if hasSilkTouch(enchantments) {
+			return []item.Stack{item.NewStack(AmethystCluster{Growth: a.Growth}, 1)}
+		}
+		if a.Growth != FullAmethystCluster() {
+			return nil
+		}
+		if t.ToolType() != item.TypePickaxe {
+			return []item.Stack{item.NewStack(item.AmethystShard{}, 2)}
+		}
+		return []item.Stack{item.NewStack(item.AmethystShard{}, fortuneOreCount(4, enchantments))}
+	})
+}
+
+// EncodeItem ...
+func (a AmethystCluster) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + a.Growth.String(), 0
+}
+
+// EncodeBlock ...
+func (a AmethystCluster) EncodeBlock() (string, map[string]any) {
+	return "minecraft:" + a.Growth.String(), map[string]any{"minecraft:block_face": a.Facing.String()}
+}
+
+// allAmethystClusters ...
+func allAmethystClusters() (clusters []world.Block) {
+	for _, g := range AmethystGrowths() {
+		for _, f := range cube.Faces() {
+			clusters = append(clusters, AmethystCluster{Growth: g, Facing: f})
+		}
+	}
+	return
+}
@@ -0,0 +1,68 @@
+package block
+
+// AmethystGrowth represents a growth stage of an amethyst cluster, from a small bud to a fully grown cluster.
+type AmethystGrowth struct {
+	amethystGrowth
+}
+
+type amethystGrowth uint8
+
+// SmallAmethystBud is the first growth stage of an amethyst cluster.
+func SmallAmethystBud() AmethystGrowth {
+	return AmethystGrowth{0}
+}
+
+// MediumAmethystBud is the second growth stage of an amethyst cluster.
+func MediumAmethystBud() AmethystGrowth {
+	return AmethystGrowth{1}
+}
+
+// LargeAmethystBud is the third growth stage of an amethyst cluster.
+func LargeAmethystBud() AmethystGrowth {
+	return AmethystGrowth{2}
+}
+
+// FullAmethystCluster is the final growth stage of an amethyst cluster, which drops amethyst shards when mined.
+func FullAmethystCluster() AmethystGrowth {
+	return AmethystGrowth{3}
+}
+
+// Uint8 returns the growth stage as a uint8.
+func (a amethystGrowth) Uint8() uint8 {
+	return uint8(a)
+}
+
+// Name ...
+func (a amethystGrowth) Name() string {
+	switch a {
+	case 0:
+		return "Small Amethyst Bud"
+	case 1:
+		return "Medium Amethyst Bud"
+	case 2:
+		return "Large Amethyst Bud"
+	case 3:
+		return "Amethyst Cluster"
+	}
+	panic("unknown amethyst growth")
+}
+
+// String ...
+func (a amethystGrowth) String() string {
+	switch a {
+	case 0:
+		return "small_amethyst_bud"
+	case 1:
+		return "medium_amethyst_bud"
+	case 2:
+		return "large_amethyst_bud"
+	case 3:
+		return "amethyst_cluster"
+	}
+	panic("unknown amethyst growth")
+}
+
+// AmethystGrowths ...
+func AmethystGrowths() []AmethystGrowth {
+	return []AmethystGrowth{SmallAmethystBud(), MediumAmethystBud(), LargeAmethystBud(), FullAmethystCluster()}
+}
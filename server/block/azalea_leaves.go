@@ -0,0 +1,134 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// AzaleaLeaves are the leaves of azalea trees, which grow above lush caves.
+// Some of them carry pink flowers.
+type AzaleaLeaves struct {
+	leaves
+	sourceWaterDisplacer
+
+	// Flowering specifies if the leaves carry flowers.
+	Flowering bool
+	// Persistent specifies if the leaves are persistent, meaning they will not
+	// decay as a result of no wood being nearby.
+	Persistent bool
+
+	ShouldUpdate bool
+}
+
+// UseOnBlock makes leaves persistent when they are placed so that they don't decay.
+func (l AzaleaLeaves) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(tx, pos, face, l)
+	if !used {
+		return
+	}
+	l.Persistent = true
+
+	place(tx, pos, l, user, ctx)
+	return placed(ctx)
+}
+
+// RandomTick ...
+func (l AzaleaLeaves) RandomTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if !l.Persistent && l.ShouldUpdate {
+		if findLog(pos, tx, &[]cube.Pos{}, 0) {
+			l.ShouldUpdate = false
+			tx.SetBlock(pos, l, nil)
+			return
+		}
+		ctx := event.C(tx)
+		if tx.World().Handler().HandleLeavesDecay(ctx, pos); ctx.Cancelled() {
+			// Prevent immediate re-updating.
+			l.ShouldUpdate = false
+			tx.SetBlock(pos, l, nil)
+			return
+		}
+		tx.SetBlock(pos, nil, nil)
+		if !tileDrops(tx) {
+			return
+		}
+		for _, drop := range Drops(l, loot.Context{Tx: tx, Pos: pos}) {
+			dropItem(tx, drop, pos.Vec3Centre())
+		}
+	}
+}
+
+// NeighbourUpdateTick ...
+func (l AzaleaLeaves) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !l.Persistent && !l.ShouldUpdate {
+		l.ShouldUpdate = true
+		tx.SetBlock(pos, l, nil)
+	}
+}
+
+// FlammabilityInfo ...
+func (AzaleaLeaves) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(30, 60, true)
+}
+
+// BreakInfo ...
+func (l AzaleaLeaves) BreakInfo() BreakInfo {
+	return newBreakInfo(0.2, alwaysHarvestable, func(t item.Tool) bool {
+		return t.ToolType() == item.TypeShears || t.ToolType() == item.TypeHoe
+	}, func(t item.Tool, enchantments []item.Enchantment) []item.Stack {
+		if t.ToolType() == item.TypeShears || hasSilkTouch(enchantments) {
+			return []item.Stack{item.NewStack(l, 1)}
+		}
+		// TODO: Drop azaleas.
+
+		stickChances := []float64{0.02, 0.022222222, 0.025, 0.033333333}
+		if rand.Float64() < stickChances[min(fortuneLevel(enchantments), 3)] {
+			return []item.Stack{item.NewStack(item.Stick{}, rand.IntN(2)+1)}
+		}
+		return nil
+	})
+}
+
+// CompostChance ...
+func (AzaleaLeaves) CompostChance() float64 {
+	return 0.3
+}
+
+// LightDiffusionLevel ...
+func (AzaleaLeaves) LightDiffusionLevel() uint8 {
+	return 1
+}
+
+// SideClosed ...
+func (AzaleaLeaves) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// EncodeItem ...
+func (l AzaleaLeaves) EncodeItem() (name string, meta int16) {
+	if l.Flowering {
+		return "minecraft:azalea_leaves_flowered", 0
+	}
+	return "minecraft:azalea_leaves", 0
+}
+
+// EncodeBlock ...
+func (l AzaleaLeaves) EncodeBlock() (name string, properties map[string]any) {
+	name, _ = l.EncodeItem()
+	return name, map[string]any{"persistent_bit": l.Persistent, "update_bit": l.ShouldUpdate}
+}
+
+// allAzaleaLeaves returns a list of all possible azalea leaves states.
+func allAzaleaLeaves() (leaves []world.Block) {
+	for _, flowering := range []bool{false, true} {
+		for _, persistent := range []bool{false, true} {
+			leaves = append(leaves, AzaleaLeaves{Flowering: flowering, Persistent: persistent}, AzaleaLeaves{Flowering: flowering, Persistent: persistent, ShouldUpdate: true})
+		}
+	}
+	return
+}
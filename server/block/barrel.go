@@ -28,6 +28,12 @@ type Barrel struct {
 	// CustomName is the custom name of the barrel. This name is displayed when the barrel is opened, and may
 	// include colour codes.
 	CustomName string
+	// LootTable is the loot table used to fill the barrel when it is first
+	// opened. If empty, no loot is generated.
+	LootTable string
+	// LootTableSeed is the seed of the loot table of the barrel. A seed of 0
+	// means that the loot is generated with a random seed.
+	LootTableSeed int64
 
 	inventory *inventory.Inventory
 	viewerMu  *sync.RWMutex
@@ -100,8 +106,24 @@ func (b Barrel) RemoveViewer(v ContainerViewer, tx *world.Tx, pos cube.Pos) {
 	}
 }
 
+// generateLoot fills the barrel using its loot table and clears the loot table
+// afterwards. The loot table is kept if the loot could not be generated.
+func (b Barrel) generateLoot(tx *world.Tx, pos cube.Pos, u item.User) Barrel {
+	stacks, ok := populateLoot(b.LootTable, b.LootTableSeed, tx, pos, u)
+	if !ok {
+		return b
+	}
+	scatterLoot(b.inventory, stacks, b.LootTableSeed)
+	b.LootTable, b.LootTableSeed = "", 0
+	tx.SetBlock(pos, b, nil)
+	return b
+}
+
 // Activate ...
 func (b Barrel) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	if b.LootTable != "" {
+		b = b.generateLoot(tx, pos, u)
+	}
 	if opener, ok := u.(ContainerOpener); ok {
 		opener.OpenBlockContainer(pos, tx)
 		return true
@@ -126,6 +148,9 @@ func (b Barrel) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world
 // BreakInfo ...
 func (b Barrel) BreakInfo() BreakInfo {
 	return newBreakInfo(2.5, alwaysHarvestable, axeEffective, oneOf(b)).withBreakHandler(func(pos cube.Pos, tx *world.Tx, u item.User) {
+		if b.LootTable != "" {
+			b.generateLoot(tx, pos, u)
+		}
 		for _, i := range b.Inventory(tx, pos).Clear() {
 			dropItem(tx, i, pos.Vec3())
 		}
@@ -149,6 +174,8 @@ func (b Barrel) DecodeNBT(data map[string]any) any {
 	b = NewBarrel()
 	b.Facing = facing
 	b.CustomName = nbtconv.String(data, "CustomName")
+	b.LootTable = nbtconv.String(data, "LootTable")
+	b.LootTableSeed = nbtconv.Int64(data, "LootTableSeed")
 	nbtconv.InvFromNBT(b.inventory, nbtconv.Slice(data, "Items"))
 	return b
 }
@@ -156,15 +183,18 @@ func (b Barrel) DecodeNBT(data map[string]any) any {
 // EncodeNBT ...
 func (b Barrel) EncodeNBT() map[string]any {
 	if b.inventory == nil {
-		facing, customName := b.Facing, b.CustomName
+		facing, customName, lootTable, seed := b.Facing, b.CustomName, b.LootTable, b.LootTableSeed
 		//noinspection GoAssignmentToReceiver
 		b = NewBarrel()
-		b.Facing, b.CustomName = facing, customName
+		b.Facing, b.CustomName, b.LootTable, b.LootTableSeed = facing, customName, lootTable, seed
 	}
 	m := map[string]any{
 		"Items": nbtconv.InvToNBT(b.inventory),
 		"id":    "Barrel",
 	}
+	if b.LootTable != "" {
+		m["LootTable"], m["LootTableSeed"] = b.LootTable, b.LootTableSeed
+	}
 	if b.CustomName != "" {
 		m["CustomName"] = b.CustomName
 	}
@@ -12,6 +12,18 @@ type BeeNest struct {
 	solid
 	// Facing represents the direction the bee nest is facing.
 	Facing cube.Direction
+	// HoneyLevel is the amount of honey in the bee nest, ranging from 0 to 5. A bee nest with a HoneyLevel of 5 may
+	// be harvested using a glass bottle.
+	HoneyLevel int
+}
+
+// FillBottle fills a glass bottle with honey if the bee nest is full, emptying the bee nest.
+func (b BeeNest) FillBottle() (world.Block, item.Stack, bool) {
+	if b.HoneyLevel < 5 {
+		return b, item.Stack{}, false
+	}
+	b.HoneyLevel = 0
+	return b, item.NewStack(item.HoneyBottle{}, 1), true
 }
 
 // UseOnBlock handles the placement of the bee nest.
@@ -31,7 +43,7 @@ func (b BeeNest) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *worl
 
 // BreakInfo defines the hardness and harvest tool for the bee nest.
 func (b BeeNest) BreakInfo() BreakInfo {
-	return newBreakInfo(0.3, alwaysHarvestable, axeEffective, oneOf(b)).withBlastResistance(1.5)
+	return newBreakInfo(0.3, alwaysHarvestable, axeEffective, oneOf(BeeNest{})).withBlastResistance(1.5)
 }
 
 // FlammabilityInfo defines how the block burns.
@@ -44,12 +56,13 @@ func (BeeNest) EncodeItem() (name string, meta int16) {
 	return "minecraft:bee_nest", 0
 }
 
-// EncodeAll returns all 4 possible directional states for the Bee Nest.
+// EncodeAll returns all possible directional states and honey levels for the Bee Nest.
 func (BeeNest) EncodeAll() []world.Block {
 	var all []world.Block
-	// This ensures every direction is registered with honey_level 0
 	for _, d := range cube.Directions() {
-		all = append(all, BeeNest{Facing: d})
+		for level := 0; level <= 5; level++ {
+			all = append(all, BeeNest{Facing: d, HoneyLevel: level})
+		}
 	}
 	return all
 }
@@ -70,8 +83,8 @@ func (b BeeNest) EncodeBlock() (string, map[string]any) {
 	}
 
 	return "minecraft:bee_nest", map[string]any{
-		"direction":   dir,      // Corrected mapping
-		"honey_level": int32(0), // Keeps it empty and non-functional
+		"direction":   dir,
+		"honey_level": int32(b.HoneyLevel),
 	}
 }
 
@@ -0,0 +1,206 @@
+package behaviourpack
+
+import (
+	"image"
+	"image/color"
+	"maps"
+	"strconv"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/customblock"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/category"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+)
+
+// Block is a block defined in a behaviour pack. A Block is registered for
+// every combination of the states of the definition it was loaded from.
+// Block implements world.CustomBlock and world.CustomItem, so that it may be
+// placed by players and obtained from the creative inventory. The textures and
+// geometry of the block are expected to be provided by a resource pack that
+// accompanies the behaviour pack.
+type Block struct {
+	def *definition
+	// state is the index of the state of the Block in the states of its
+	// definition.
+	state int
+}
+
+// definition is the definition of a block as loaded from a behaviour pack.
+type definition struct {
+	identifier string
+	name       string
+	category   category.Category
+	hash       uint64
+
+	properties   customblock.Properties
+	permutations []customblock.Permutation
+	stateValues  map[string][]any
+	states       []map[string]any
+
+	collision       cube.BBox
+	lightEmission   uint8
+	lightDampening  uint8
+	hardness        float64
+	blastResistance float64
+	friction        float64
+	flammability    block.FlammabilityInfo
+	loot            *loot.LootTable
+}
+
+// Identifier returns the identifier of the block, such as "example:marble".
+func (b Block) Identifier() string {
+	return b.def.identifier
+}
+
+// State returns the value of the state with the name passed. False is
+// returned if the block has no such state.
+func (b Block) State(name string) (any, bool) {
+	v, ok := b.def.states[b.state][name]
+	return v, ok
+}
+
+// WithState returns the block with the state with the name passed changed to
+// the value passed. False is returned if the block has no such state or if
+// the value is not one of the values of the state.
+func (b Block) WithState(name string, value any) (Block, bool) {
+	if _, ok := b.def.stateValues[name]; !ok {
+		return b, false
+	}
+	want := maps.Clone(b.def.states[b.state])
+	want[name] = value
+	for i, state := range b.def.states {
+		if maps.Equal(state, want) {
+			b.state = i
+			return b, true
+		}
+	}
+	return b, false
+}
+
+// Properties ...
+func (b Block) Properties() customblock.Properties {
+	return b.def.properties
+}
+
+// States ...
+func (b Block) States() map[string][]any {
+	return b.def.stateValues
+}
+
+// Permutations ...
+func (b Block) Permutations() []customblock.Permutation {
+	return b.def.permutations
+}
+
+// Name ...
+func (b Block) Name() string {
+	return b.def.name
+}
+
+// Texture returns a plain texture in the map colour of the block. The texture
+// is only used for the item of the block in the resource pack built by the
+// server, as clients render the block itself in the inventory.
+func (b Block) Texture() image.Image {
+	c := color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}
+	if hex := b.def.properties.MapColour; len(hex) == 7 && hex[0] == '#' {
+		if v, err := strconv.ParseUint(hex[1:], 16, 32); err == nil {
+			c = color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+		}
+	}
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for x := range 16 {
+		for y := range 16 {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// Category ...
+func (b Block) Category() category.Category {
+	return b.def.category
+}
+
+// Model ...
+func (b Block) Model() world.BlockModel {
+	return boxModel{box: b.def.collision}
+}
+
+// BreakInfo ...
+func (b Block) BreakInfo() block.BreakInfo {
+	return block.BreakInfo{
+		Hardness:        b.def.hardness,
+		BlastResistance: b.def.blastResistance,
+		Harvestable:     func(item.Tool) bool { return true },
+		Effective:       func(item.Tool) bool { return false },
+		Drops: func(t item.Tool, enchantments []item.Enchantment) []item.Stack {
+			if b.def.loot != nil {
+				var ctx loot.Context
+				if it, ok := t.(world.Item); ok {
+					// The tool is passed so that tables may use match_tool
+					// conditions, for example for Silk Touch.
+					ctx.Tool = item.NewStack(it, 1).WithEnchantments(enchantments...)
+				}
+				return b.def.loot.GenerateWithContext(ctx)
+			}
+			return []item.Stack{item.NewStack(Block{def: b.def}, 1)}
+		},
+	}
+}
+
+// LightEmissionLevel ...
+func (b Block) LightEmissionLevel() uint8 {
+	return b.def.lightEmission
+}
+
+// LightDiffusionLevel ...
+func (b Block) LightDiffusionLevel() uint8 {
+	return b.def.lightDampening
+}
+
+// FlammabilityInfo ...
+func (b Block) FlammabilityInfo() block.FlammabilityInfo {
+	return b.def.flammability
+}
+
+// Friction ...
+func (b Block) Friction() float64 {
+	return b.def.friction
+}
+
+// EncodeItem ...
+func (b Block) EncodeItem() (name string, meta int16) {
+	return b.def.identifier, 0
+}
+
+// EncodeBlock ...
+func (b Block) EncodeBlock() (string, map[string]any) {
+	return b.def.identifier, b.def.states[b.state]
+}
+
+// Hash ...
+func (b Block) Hash() (uint64, uint64) {
+	return b.def.hash, uint64(b.state)
+}
+
+// boxModel is the model of a Block, which has a single collision box that may
+// be empty.
+type boxModel struct {
+	box cube.BBox
+}
+
+// BBox ...
+func (m boxModel) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	if m.box == (cube.BBox{}) {
+		return nil
+	}
+	return []cube.BBox{m.box}
+}
+
+// FaceSolid ...
+func (m boxModel) FaceSolid(cube.Pos, cube.Face, world.BlockSource) bool {
+	return m.box == cube.Box(0, 0, 0, 1, 1, 1)
+}
@@ -0,0 +1,430 @@
+// Package behaviourpack implements the loading of custom blocks from Bedrock
+// Edition behaviour packs. Blocks defined in the blocks directory of a
+// behaviour pack are registered as server-side blocks, so that content
+// creators may add blocks without writing Go. Blocks must be loaded before the
+// server is created:
+//
+//	if _, err := behaviourpack.LoadBlocks("packs/my_pack"); err != nil {
+//		panic(err)
+//	}
+//	srv := conf.New()
+//
+// The textures and geometry of the blocks are not part of a behaviour pack and
+// must be provided to players through the accompanying resource pack.
+package behaviourpack
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/customblock"
+	"github.com/df-mc/dragonfly/server/item/category"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// LoadBlocks loads the block definitions found in the blocks directory of the
+// behaviour pack in the directory passed and registers a Block for every
+// state of every definition, together with an item for each block. The
+// default states of the blocks registered are returned. The following
+// components are supported:
+//
+//	minecraft:geometry, minecraft:material_instances, minecraft:transformation,
+//	minecraft:collision_box, minecraft:selection_box, minecraft:map_color,
+//	minecraft:light_emission, minecraft:light_dampening,
+//	minecraft:destructible_by_mining, minecraft:destructible_by_explosion,
+//	minecraft:friction, minecraft:flammable, minecraft:display_name and
+//	minecraft:loot
+//
+// The components of permutations are sent to clients, but only the collision,
+// selection box, geometry, material instance, map colour and transformation
+// components are supported in permutations. The server applies the base
+// components of a block regardless of its state. LoadBlocks panics if it is
+// called after the server was created.
+func LoadBlocks(dir string) ([]world.Block, error) {
+	return loadBlocks(os.DirFS(dir))
+}
+
+// loadBlocks loads and registers the block definitions in the fs.FS passed.
+func loadBlocks(fsys fs.FS) ([]world.Block, error) {
+	var defs []*definition
+	err := fs.WalkDir(fsys, "blocks", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(p, ".json") {
+			return err
+		}
+		b, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		def, err := parseDefinition(fsys, b)
+		if err != nil {
+			return fmt.Errorf("load block %v: %w", p, err)
+		}
+		defs = append(defs, def)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([]world.Block, 0, len(defs))
+	for _, def := range defs {
+		def.hash = block.NextHash()
+		for i := range def.states {
+			world.RegisterBlock(Block{def: def, state: i})
+		}
+		world.RegisterItem(Block{def: def})
+		blocks = append(blocks, Block{def: def})
+	}
+	return blocks, nil
+}
+
+// blockFile is the JSON layout of a block definition in a behaviour pack.
+type blockFile struct {
+	Block struct {
+		Description struct {
+			Identifier   string `json:"identifier"`
+			MenuCategory struct {
+				Category string `json:"category"`
+				Group    string `json:"group"`
+			} `json:"menu_category"`
+			States     map[string]json.RawMessage `json:"states"`
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"description"`
+		Components   map[string]json.RawMessage `json:"components"`
+		Permutations []struct {
+			Condition  string                     `json:"condition"`
+			Components map[string]json.RawMessage `json:"components"`
+		} `json:"permutations"`
+	} `json:"minecraft:block"`
+}
+
+// parseDefinition parses a block definition from the JSON data passed. Loot
+// tables referenced are read from the fs.FS passed.
+func parseDefinition(fsys fs.FS, data []byte) (*definition, error) {
+	var f blockFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	desc := f.Block.Description
+	namespace, name, ok := strings.Cut(desc.Identifier, ":")
+	if !ok || namespace == "" || name == "" || namespace == "minecraft" {
+		return nil, fmt.Errorf("invalid identifier %q", desc.Identifier)
+	}
+	def := &definition{
+		identifier:      desc.Identifier,
+		name:            name,
+		category:        menuCategory(desc.MenuCategory.Category),
+		properties:      customblock.Properties{Cube: true},
+		collision:       cube.Box(0, 0, 0, 1, 1, 1),
+		lightDampening:  15,
+		hardness:        1,
+		blastResistance: 5,
+		friction:        0.6,
+	}
+	if desc.MenuCategory.Group != "" {
+		def.category = def.category.WithGroup(desc.MenuCategory.Group)
+	}
+
+	states := desc.States
+	if states == nil {
+		// Older formats define states as properties.
+		states = desc.Properties
+	}
+	def.stateValues = make(map[string][]any, len(states))
+	for k, raw := range states {
+		values, err := parseStateValues(raw)
+		if err != nil {
+			return nil, fmt.Errorf("state %v: %w", k, err)
+		}
+		def.stateValues[k] = values
+	}
+	def.states = stateCombinations(def.stateValues)
+
+	if err := def.applyComponents(fsys, &def.properties, f.Block.Components, true); err != nil {
+		return nil, err
+	}
+	for _, perm := range f.Block.Permutations {
+		p := customblock.Permutation{Condition: perm.Condition}
+		if err := def.applyComponents(fsys, &p.Properties, perm.Components, false); err != nil {
+			return nil, fmt.Errorf("permutation %q: %w", perm.Condition, err)
+		}
+		def.permutations = append(def.permutations, p)
+	}
+	return def, nil
+}
+
+// applyComponents applies the components passed to the customblock.Properties
+// passed. If base is true, the components that only apply to the block as a
+// whole, such as its light emission, are applied to the definition too.
+func (def *definition) applyComponents(fsys fs.FS, props *customblock.Properties, components map[string]json.RawMessage, base bool) error {
+	for k, raw := range components {
+		var err error
+		switch strings.TrimPrefix(k, "minecraft:") {
+		case "geometry":
+			var geo struct {
+				Identifier string `json:"identifier"`
+			}
+			if err = json.Unmarshal(raw, &geo.Identifier); err != nil {
+				err = json.Unmarshal(raw, &geo)
+			}
+			props.Geometry = geo.Identifier
+		case "material_instances":
+			props.Textures, err = parseMaterials(raw)
+		case "map_color":
+			err = json.Unmarshal(raw, &props.MapColour)
+		case "transformation":
+			err = parseTransformation(raw, props)
+		case "collision_box":
+			var box cube.BBox
+			if box, err = parseBox(raw); err == nil {
+				props.CollisionBox = box
+				if base {
+					def.collision = box
+					if box != cube.Box(0, 0, 0, 1, 1, 1) {
+						def.lightDampening = 0
+					}
+				}
+			}
+		case "selection_box":
+			props.SelectionBox, err = parseBox(raw)
+		}
+		if err != nil {
+			return fmt.Errorf("component %v: %w", k, err)
+		}
+		if !base {
+			continue
+		}
+		switch strings.TrimPrefix(k, "minecraft:") {
+		case "light_emission":
+			err = json.Unmarshal(raw, &def.lightEmission)
+		case "light_dampening":
+			err = json.Unmarshal(raw, &def.lightDampening)
+		case "destructible_by_mining":
+			var v struct {
+				Seconds float64 `json:"seconds_to_destroy"`
+			}
+			if string(raw) == "false" {
+				// Blocks that cannot be mined get a hardness high enough for
+				// them to never break in practice.
+				def.hardness = 1e6
+			} else if err = json.Unmarshal(raw, &v); err == nil {
+				// Blocks are always harvestable, so the time to break them by
+				// hand is 1.5 times their hardness.
+				def.hardness = v.Seconds / 1.5
+			}
+		case "destructible_by_explosion":
+			var v struct {
+				Resistance float64 `json:"explosion_resistance"`
+			}
+			if string(raw) == "false" {
+				def.blastResistance = 1e6
+			} else if err = json.Unmarshal(raw, &v); err == nil {
+				def.blastResistance = v.Resistance
+			}
+		case "friction":
+			err = json.Unmarshal(raw, &def.friction)
+		case "flammable":
+			v := struct {
+				CatchChance   int `json:"catch_chance_modifier"`
+				DestroyChance int `json:"destroy_chance_modifier"`
+			}{CatchChance: 5, DestroyChance: 20}
+			if string(raw) != "false" && string(raw) != "true" {
+				err = json.Unmarshal(raw, &v)
+			}
+			if string(raw) != "false" {
+				def.flammability = block.FlammabilityInfo{Encouragement: v.CatchChance, Flammability: v.DestroyChance}
+			}
+		case "display_name":
+			err = json.Unmarshal(raw, &def.name)
+		case "loot":
+			var p string
+			if err = json.Unmarshal(raw, &p); err == nil {
+				def.loot, err = loadLootTable(fsys, p)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("component %v: %w", k, err)
+		}
+	}
+	if props.Geometry != "" {
+		props.Cube = false
+	}
+	return nil
+}
+
+// parseStateValues parses the values of a block state, which are either a list
+// of values or a range of integers.
+func parseStateValues(raw json.RawMessage) ([]any, error) {
+	var r struct {
+		Values struct {
+			Min int32 `json:"min"`
+			Max int32 `json:"max"`
+		} `json:"values"`
+	}
+	if err := json.Unmarshal(raw, &r); err == nil {
+		if r.Values.Max < r.Values.Min {
+			return nil, fmt.Errorf("invalid range %v-%v", r.Values.Min, r.Values.Max)
+		}
+		values := make([]any, 0, r.Values.Max-r.Values.Min+1)
+		for v := r.Values.Min; v <= r.Values.Max; v++ {
+			values = append(values, v)
+		}
+		return values, nil
+	}
+	var list []any
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("state has no values")
+	}
+	values := make([]any, 0, len(list))
+	for _, v := range list {
+		switch v := v.(type) {
+		case float64:
+			values = append(values, int32(v))
+		case bool, string:
+			values = append(values, v)
+		default:
+			return nil, fmt.Errorf("unsupported value %v", v)
+		}
+	}
+	return values, nil
+}
+
+// stateCombinations returns all combinations of the values of the states
+// passed. The first combination holds the first value of every state.
+func stateCombinations(values map[string][]any) []map[string]any {
+	combinations := []map[string]any{{}}
+	for _, k := range slices.Sorted(maps.Keys(values)) {
+		next := make([]map[string]any, 0, len(combinations)*len(values[k]))
+		for _, c := range combinations {
+			for _, v := range values[k] {
+				m := maps.Clone(c)
+				m[k] = v
+				next = append(next, m)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// parseBox parses a collision or selection box, which is either a bool or an
+// origin and size in pixels, where the origin is relative to the bottom
+// centre of the block.
+func parseBox(raw json.RawMessage) (cube.BBox, error) {
+	switch string(raw) {
+	case "true":
+		return cube.Box(0, 0, 0, 1, 1, 1), nil
+	case "false":
+		return cube.BBox{}, nil
+	}
+	var v struct {
+		Origin [3]float64 `json:"origin"`
+		Size   [3]float64 `json:"size"`
+	}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return cube.BBox{}, err
+	}
+	minX, minY, minZ := (v.Origin[0]+8)/16, v.Origin[1]/16, (v.Origin[2]+8)/16
+	return cube.Box(minX, minY, minZ, minX+v.Size[0]/16, minY+v.Size[1]/16, minZ+v.Size[2]/16), nil
+}
+
+// parseMaterials parses the material instances of a block.
+func parseMaterials(raw json.RawMessage) (map[string]customblock.Material, error) {
+	var instances map[string]struct {
+		Texture          string `json:"texture"`
+		RenderMethod     string `json:"render_method"`
+		FaceDimming      *bool  `json:"face_dimming"`
+		AmbientOcclusion *bool  `json:"ambient_occlusion"`
+	}
+	if err := json.Unmarshal(raw, &instances); err != nil {
+		return nil, err
+	}
+	materials := make(map[string]customblock.Material, len(instances))
+	for target, inst := range instances {
+		method := customblock.OpaqueRenderMethod()
+		switch inst.RenderMethod {
+		case "alpha_test":
+			method = customblock.AlphaTestRenderMethod()
+		case "blend":
+			method = customblock.BlendRenderMethod()
+		case "double_sided":
+			method = customblock.DoubleSidedRenderMethod()
+		}
+		m := customblock.NewMaterial(inst.Texture, method)
+		if inst.FaceDimming != nil && !*inst.FaceDimming {
+			m = m.WithoutFaceDimming()
+		}
+		if inst.AmbientOcclusion != nil {
+			if *inst.AmbientOcclusion {
+				m = m.WithAmbientOcclusion()
+			} else {
+				m = m.WithoutAmbientOcclusion()
+			}
+		}
+		materials[target] = m
+	}
+	return materials, nil
+}
+
+// parseTransformation parses the transformation component of a block into the
+// customblock.Properties passed. Rotations are rounded to steps of 90 degrees.
+func parseTransformation(raw json.RawMessage, props *customblock.Properties) error {
+	var v struct {
+		Rotation    *[3]float64 `json:"rotation"`
+		Translation *[3]float64 `json:"translation"`
+		Scale       *[3]float64 `json:"scale"`
+	}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return err
+	}
+	if r := v.Rotation; r != nil {
+		props.Rotation = cube.Pos{int(r[0] / 90), int(r[1] / 90), int(r[2] / 90)}
+	}
+	if t := v.Translation; t != nil {
+		props.Translation = mgl64.Vec3{t[0], t[1], t[2]}
+	}
+	if s := v.Scale; s != nil {
+		props.Scale = mgl64.Vec3{s[0], s[1], s[2]}
+	}
+	return nil
+}
+
+// loadLootTable reads the loot table at the path passed, relative to the root
+// of the behaviour pack.
+func loadLootTable(fsys fs.FS, p string) (*loot.LootTable, error) {
+	b, err := fs.ReadFile(fsys, path.Clean(p))
+	if err != nil {
+		return nil, err
+	}
+	var t loot.LootTable
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, fmt.Errorf("loot table %v: %w", p, err)
+	}
+	return &t, nil
+}
+
+// menuCategory returns the category.Category with the name passed, as found in
+// the menu_category of a block definition.
+func menuCategory(name string) category.Category {
+	switch name {
+	case "nature":
+		return category.Nature()
+	case "equipment":
+		return category.Equipment()
+	case "items":
+		return category.Items()
+	}
+	return category.Construction()
+}
@@ -0,0 +1,180 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Bell is a block that rings when used. Ringing a bell reveals raiders
+// nearby. Bells are found in villages and serve as their meeting point.
+type Bell struct {
+	transparent
+
+	// Attach represents the attachment type of the Bell.
+	Attach BellAttachment
+	// Facing represents the direction the Bell is facing.
+	Facing cube.Direction
+	// Ringing specifies if the Bell is currently ringing.
+	Ringing bool
+}
+
+// BellListener represents an entity that reacts to bells being rung near it,
+// such as a raider.
+type BellListener interface {
+	world.Entity
+	// BellRung is called when a bell within 48 blocks of the entity is rung.
+	BellRung(pos cube.Pos, tx *world.Tx)
+}
+
+// BreakInfo ...
+func (b Bell) BreakInfo() BreakInfo {
+	return newBreakInfo(5, alwaysHarvestable, pickaxeEffective, oneOf(Bell{})).withBlastResistance(25)
+}
+
+// Activate ...
+func (b Bell) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
+	b.Ring(pos, tx)
+	return true
+}
+
+// Ring rings the Bell at the position passed and notifies all BellListeners
+// within 48 blocks of it.
+func (b Bell) Ring(pos cube.Pos, tx *world.Tx) {
+	tx.PlaySound(pos.Vec3Centre(), sound.BellRing{})
+	b.Ringing = true
+	tx.SetBlock(pos, b, &world.SetOpts{DisableBlockUpdates: true, DisableLiquidDisplacement: true})
+	tx.ScheduleBlockUpdate(pos, b, time.Second)
+
+	centre := pos.Vec3Centre()
+	for e := range tx.EntitiesWithin(cube.Box(-48, -48, -48, 48, 48, 48).Translate(centre)) {
+		if l, ok := e.(BellListener); ok && e.Position().Sub(centre).Len() <= 48 {
+			l.BellRung(pos, tx)
+		}
+	}
+}
+
+// ScheduledTick ...
+func (b Bell) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if b.Ringing {
+		b.Ringing = false
+		tx.SetBlock(pos, b, &world.SetOpts{DisableBlockUpdates: true, DisableLiquidDisplacement: true})
+	}
+}
+
+// UseOnBlock ...
+func (b Bell) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
+	pos, face, used = firstReplaceable(tx, pos, face, b)
+	if !used {
+		return false
+	}
+	b.Facing = user.Rotation().Direction().Opposite()
+	if face == cube.FaceDown {
+		b.Attach = HangingBellAttachment()
+	} else if face != cube.FaceUp {
+		b.Attach = WallBellAttachment()
+		b.Facing = face.Direction()
+		if b.supported(pos, face, tx) {
+			b.Attach = MultipleBellAttachment()
+		}
+	}
+	place(tx, pos, b, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (b Bell) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	switch b.Attach {
+	case StandingBellAttachment():
+		if b.supported(pos, cube.FaceDown, tx) {
+			return
+		}
+	case HangingBellAttachment():
+		if b.supported(pos, cube.FaceUp, tx) {
+			return
+		}
+	case WallBellAttachment():
+		if b.supported(pos, b.Facing.Face().Opposite(), tx) {
+			return
+		}
+	case MultipleBellAttachment():
+		front, back := b.supported(pos, b.Facing.Face(), tx), b.supported(pos, b.Facing.Face().Opposite(), tx)
+		if front && back {
+			return
+		} else if front || back {
+			// One of the walls is gone, so the bell is now only attached to
+			// the other wall.
+			b.Attach = WallBellAttachment()
+			if front {
+				b.Facing = b.Facing.Opposite()
+			}
+			tx.SetBlock(pos, b, nil)
+			return
+		}
+	}
+	// Bell is alwaysHarvestable, but drops should respect the doTileDrops
+	// game rule.
+	breakBlockNoDrops(b, pos, tx)
+	if tileDrops(tx) {
+		dropItem(tx, item.NewStack(Bell{}, 1), pos.Vec3Centre())
+	}
+}
+
+// supported checks if the block on the face passed of the Bell can support
+// it.
+func (b Bell) supported(pos cube.Pos, face cube.Face, tx *world.Tx) bool {
+	_, empty := tx.Block(pos.Side(face)).Model().(model.Empty)
+	return !empty
+}
+
+// Model ...
+func (b Bell) Model() world.BlockModel {
+	return model.Bell{Standing: b.Attach == StandingBellAttachment()}
+}
+
+// EncodeNBT ...
+func (b Bell) EncodeNBT() map[string]any {
+	return map[string]any{
+		"id":        "Bell",
+		"Ringing":   boolByte(b.Ringing),
+		"Ticks":     int32(0),
+		"Direction": int32(horizontalDirection(b.Facing)),
+	}
+}
+
+// DecodeNBT ...
+func (b Bell) DecodeNBT(m map[string]any) any {
+	b.Ringing = nbtconv.Bool(m, "Ringing")
+	return b
+}
+
+// EncodeItem ...
+func (b Bell) EncodeItem() (name string, meta int16) {
+	return "minecraft:bell", 0
+}
+
+// EncodeBlock ...
+func (b Bell) EncodeBlock() (string, map[string]any) {
+	return "minecraft:bell", map[string]any{
+		"attachment": b.Attach.String(),
+		"direction":  int32(horizontalDirection(b.Facing)),
+		"toggle_bit": uint8(0),
+	}
+}
+
+// allBells ...
+func allBells() (bells []world.Block) {
+	for _, a := range BellAttachments() {
+		for _, d := range cube.Directions() {
+			bells = append(bells, Bell{Attach: a, Facing: d})
+		}
+	}
+	return
+}
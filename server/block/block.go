@@ -79,6 +79,14 @@ type ProjectileHitter interface {
 	ProjectileHit(pos cube.Pos, tx *world.Tx, e world.Entity, face cube.Face)
 }
 
+// WindChargeAffected represents a block that reacts to a wind charge bursting
+// near it, such as a door or a lever.
+type WindChargeAffected interface {
+	// WindChargeBurst is called when a wind charge bursts within range of the
+	// block.
+	WindChargeBurst(pos cube.Pos, tx *world.Tx)
+}
+
 // Frictional represents a block that may have a custom friction value. Friction is used for entity drag when the
 // entity is on ground. If a block does not implement this interface, it should be assumed that its friction is 0.6.
 type Frictional interface {
@@ -172,6 +180,7 @@ func place(tx *world.Tx, pos cube.Pos, b world.Block, user item.User, ctx *item.
 	}
 	tx.SetBlock(pos, b, nil)
 	tx.PlaySound(pos.Vec3(), sound.BlockPlace{Block: b})
+	tx.EmitVibration(world.Vibration{Type: world.VibrationBlockPlace, Pos: pos.Vec3Centre(), Source: user})
 }
 
 // horizontalDirection returns the horizontal direction of the given direction. This is a legacy type still used in
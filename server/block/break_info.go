@@ -10,6 +10,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/enchantment"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
 	"github.com/df-mc/dragonfly/server/world/particle"
 )
 
@@ -353,12 +354,34 @@ func cropSeedDrops(seed, crop world.Item, growth int) func(item.Tool, []item.Enc
 	}
 }
 
+// Drops returns the drops of the block passed when it is broken using the tool
+// in the loot.Context passed, which may be empty. If the block has a loot
+// table, as returned by loot.BlockTable, the drops are generated from the
+// table using the loot.Context, so that servers may change the drops of blocks
+// without changing their BreakInfo. Otherwise, the Drops function of the
+// BreakInfo of the block is used. Blocks that are not Breakable drop nothing.
+func Drops(b world.Block, ctx loot.Context) []item.Stack {
+	name, _ := b.EncodeBlock()
+	if drops, ok := loot.GenerateBlockDrops(name, ctx); ok {
+		return drops
+	}
+	breakable, ok := b.(Breakable)
+	if !ok {
+		return nil
+	}
+	t, ok := ctx.Tool.Item().(item.Tool)
+	if !ok {
+		t = item.ToolNone{}
+	}
+	return breakable.BreakInfo().Drops(t, ctx.Tool.Enchantments())
+}
+
 // breakBlock removes a block, shows breaking particles and drops the drops of
-// the block as items.
+// the block as items, unless the doTileDrops game rule is disabled.
 func breakBlock(b world.Block, pos cube.Pos, tx *world.Tx) {
 	breakBlockNoDrops(b, pos, tx)
-	if breakable, ok := b.(Breakable); ok {
-		for _, drop := range breakable.BreakInfo().Drops(item.ToolNone{}, nil) {
+	if tileDrops(tx) {
+		for _, drop := range Drops(b, loot.Context{Tx: tx, Pos: pos}) {
 			dropItem(tx, drop, pos.Vec3Centre())
 		}
 	}
@@ -373,4 +396,11 @@ func breakBlockNoDrops(b world.Block, pos cube.Pos, tx *world.Tx) {
 	}
 	tx.SetBlock(pos, nil, nil)
 	tx.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: b})
+	tx.EmitVibration(world.Vibration{Type: world.VibrationBlockDestroy, Pos: pos.Vec3Centre()})
+}
+
+// tileDrops checks if blocks broken in the world of the transaction passed
+// drop items.
+func tileDrops(tx *world.Tx) bool {
+	return tx.World().GameRule(world.GameRuleDoTileDrops)
 }
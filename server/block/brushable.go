@@ -0,0 +1,26 @@
+package block
+
+import "time"
+
+const (
+	// brushesRequired is the number of times a suspicious block must be
+	// brushed to uncover the item hidden inside it.
+	brushesRequired = 10
+	// brushDecayDelay is the time after the last brush after which a
+	// suspicious block starts losing its brushing progress.
+	brushDecayDelay = time.Second * 2
+)
+
+// brushedProgress returns the brushed progress, from 0-3, shown by a
+// suspicious block brushed the number of times passed.
+func brushedProgress(brushCount int) int {
+	switch {
+	case brushCount == 0:
+		return 0
+	case brushCount < 3:
+		return 1
+	case brushCount < 6:
+		return 2
+	}
+	return 3
+}
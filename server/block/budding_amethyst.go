@@ -0,0 +1,52 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// BuddingAmethyst is a block found in amethyst geodes that slowly grows amethyst buds on its sides, which
+// eventually become amethyst clusters. Budding amethyst never drops itself, not even with silk touch.
+type BuddingAmethyst struct {
+	solid
+}
+
+// RandomTick ...
+func (b BuddingAmethyst) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	if r.IntN(5) != 0 {
+		return
+	}
+	face := cube.Faces()[r.IntN(6)]
+	side := pos.Side(face)
+	switch existing := tx.Block(side).(type) {
+	case Air:
+		tx.SetBlock(side, AmethystCluster{Growth: SmallAmethystBud(), Facing: face}, nil)
+	case Water:
+		if existing.Depth == 8 && !existing.Falling {
+			tx.SetBlock(side, AmethystCluster{Growth: SmallAmethystBud(), Facing: face}, nil)
+			tx.SetLiquid(side, existing)
+		}
+	case AmethystCluster:
+		if existing.Facing == face && existing.Growth != FullAmethystCluster() {
+			existing.Growth = AmethystGrowth{existing.Growth.amethystGrowth + 1}
+			tx.SetBlock(side, existing, nil)
+		}
+	}
+}
+
+// BreakInfo ...
+func (b BuddingAmethyst) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, pickaxeHarvestable, pickaxeEffective, simpleDrops())
+}
+
+// EncodeItem ...
+func (BuddingAmethyst) EncodeItem() (name string, meta int16) {
+	return "minecraft:budding_amethyst", 0
+}
+
+// EncodeBlock ...
+func (BuddingAmethyst) EncodeBlock() (string, map[string]any) {
+	return "minecraft:budding_amethyst", nil
+}
@@ -0,0 +1,156 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// CalibratedSculkSensor is a variant of the sculk sensor that detects
+// vibrations within 16 blocks of it and that may be tuned to detect only
+// vibrations of a single frequency. In vanilla, the frequency is set by the
+// redstone signal going into the back of the sensor. Because redstone is not
+// implemented, it is set through the Frequency field instead.
+type CalibratedSculkSensor struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Facing is the direction that the sensor is facing.
+	Facing cube.Direction
+	// Phase is the phase of the sensor. 0 is inactive, in which the sensor
+	// detects vibrations, 1 is active, right after detecting a vibration, and
+	// 2 is cooldown, after which the sensor becomes inactive again.
+	Phase int
+	// Frequency is the frequency, from 1-15, of the only vibrations that the
+	// sensor detects. If 0, the sensor detects vibrations of all frequencies.
+	Frequency int
+	// Power is the redstone power, from 1-15, that the sensor outputs while
+	// active. The closer the vibration detected, the higher the power.
+	Power int
+	// LastFrequency is the frequency of the last vibration detected by the
+	// sensor, from 1-15, or 0 if it never detected a vibration.
+	LastFrequency int
+}
+
+// VibrationRange ...
+func (CalibratedSculkSensor) VibrationRange() int {
+	return 16
+}
+
+// ListenVibration activates the sensor if it is inactive and the frequency of
+// the vibration matches the Frequency of the sensor.
+func (s CalibratedSculkSensor) ListenVibration(pos cube.Pos, tx *world.Tx, v world.Vibration) {
+	if s.Phase != 0 || cube.PosFromVec3(v.Pos) == pos || (s.Frequency != 0 && s.Frequency != v.Type.Frequency()) {
+		return
+	}
+	s.Phase, s.LastFrequency = 1, v.Type.Frequency()
+	s.Power = sculkSensorPower(pos, v, s.VibrationRange())
+	activateSculkSensor(pos, s, time.Second/2, tx, v.Source)
+}
+
+// ScheduledTick moves the sensor from the active phase to the cooldown phase
+// and from the cooldown phase back to the inactive phase.
+func (s CalibratedSculkSensor) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	switch s.Phase {
+	case 1:
+		s.Phase = 2
+		cooldownSculkSensor(pos, s, tx)
+	case 2:
+		s.Phase = 0
+		tx.SetBlock(pos, s, &world.SetOpts{DisableBlockUpdates: true, DisableLiquidDisplacement: true})
+	}
+}
+
+// RedstonePower returns the redstone power output by the sensor, which is
+// Power while the sensor is active and 0 otherwise.
+func (s CalibratedSculkSensor) RedstonePower() int {
+	if s.Phase != 1 {
+		return 0
+	}
+	return s.Power
+}
+
+// ComparatorSignal returns the signal that a comparator reads from the
+// sensor, which is the frequency of the last vibration it detected.
+func (s CalibratedSculkSensor) ComparatorSignal() int {
+	return s.LastFrequency
+}
+
+// UseOnBlock ...
+func (s CalibratedSculkSensor) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, s)
+	if !used {
+		return false
+	}
+	place(tx, pos, CalibratedSculkSensor{Facing: user.Rotation().Direction()}, user, ctx)
+	return placed(ctx)
+}
+
+// Model ...
+func (CalibratedSculkSensor) Model() world.BlockModel {
+	return model.Slab{}
+}
+
+// SideClosed ...
+func (CalibratedSculkSensor) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// LightEmissionLevel ...
+func (s CalibratedSculkSensor) LightEmissionLevel() uint8 {
+	if s.Phase == 1 {
+		return 1
+	}
+	return 0
+}
+
+// BreakInfo ...
+func (s CalibratedSculkSensor) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, hoeEffective, oneOf(CalibratedSculkSensor{})).withXPDropRange(5, 5)
+}
+
+// EncodeItem ...
+func (CalibratedSculkSensor) EncodeItem() (name string, meta int16) {
+	return "minecraft:calibrated_sculk_sensor", 0
+}
+
+// EncodeBlock ...
+func (s CalibratedSculkSensor) EncodeBlock() (string, map[string]any) {
+	return "minecraft:calibrated_sculk_sensor", map[string]any{
+		"minecraft:cardinal_direction": s.Facing.String(),
+		"sculk_sensor_phase":           int32(s.Phase),
+	}
+}
+
+// EncodeNBT ...
+func (s CalibratedSculkSensor) EncodeNBT() map[string]any {
+	return map[string]any{
+		"id":            "CalibratedSculkSensor",
+		"Frequency":     int32(s.Frequency),
+		"Power":         int32(s.Power),
+		"LastFrequency": int32(s.LastFrequency),
+	}
+}
+
+// DecodeNBT ...
+func (s CalibratedSculkSensor) DecodeNBT(data map[string]any) any {
+	s.Frequency = int(nbtconv.Int32(data, "Frequency"))
+	s.Power, s.LastFrequency = int(nbtconv.Int32(data, "Power")), int(nbtconv.Int32(data, "LastFrequency"))
+	return s
+}
+
+// allCalibratedSculkSensors returns all states of the calibrated sculk sensor.
+func allCalibratedSculkSensors() (b []world.Block) {
+	for _, d := range cube.Directions() {
+		for phase := 0; phase <= 2; phase++ {
+			b = append(b, CalibratedSculkSensor{Facing: d, Phase: phase})
+		}
+	}
+	return
+}
@@ -0,0 +1,44 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+// CartographyTable is a utility block used to zoom out, clone and lock maps. It is also used as a cartographer's job
+// site block that is found in villages.
+type CartographyTable struct {
+	bass
+	solid
+}
+
+// EncodeItem ...
+func (CartographyTable) EncodeItem() (name string, meta int16) {
+	return "minecraft:cartography_table", 0
+}
+
+// EncodeBlock ...
+func (CartographyTable) EncodeBlock() (name string, properties map[string]interface{}) {
+	return "minecraft:cartography_table", nil
+}
+
+// BreakInfo ...
+func (c CartographyTable) BreakInfo() BreakInfo {
+	return newBreakInfo(2.5, alwaysHarvestable, axeEffective, oneOf(c))
+}
+
+// FuelInfo ...
+func (CartographyTable) FuelInfo() item.FuelInfo {
+	return newFuelInfo(time.Second * 15)
+}
+
+// Activate ...
+func (CartographyTable) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos, tx)
+		return true
+	}
+	return false
+}
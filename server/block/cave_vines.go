@@ -0,0 +1,198 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// CaveVines are vines that hang from the ceilings of lush caves. Some of them
+// carry glow berries, which emit light and may be picked. As an item, cave
+// vines are glow berries, which may be eaten or planted below a ceiling.
+type CaveVines struct {
+	empty
+	transparent
+	sourceWaterDisplacer
+
+	// Age is the age of the cave vines, which can be 0-25. If the age is 25,
+	// the vines won't grow any further.
+	Age int
+	// Berries specifies if the vines carry glow berries.
+	Berries bool
+	// Head specifies if the vines are the bottom block of a column of vines.
+	// It is only relevant for vines that carry berries.
+	Head bool
+}
+
+// AlwaysConsumable ...
+func (CaveVines) AlwaysConsumable() bool {
+	return false
+}
+
+// ConsumeDuration ...
+func (CaveVines) ConsumeDuration() time.Duration {
+	return item.DefaultConsumeDuration
+}
+
+// Consume ...
+func (CaveVines) Consume(_ *world.Tx, c item.Consumer) item.Stack {
+	c.Saturate(2, 0.4)
+	return item.Stack{}
+}
+
+// LightEmissionLevel ...
+func (c CaveVines) LightEmissionLevel() uint8 {
+	if c.Berries {
+		return 14
+	}
+	return 0
+}
+
+// Activate picks the glow berries of the vines, if they carry any.
+func (c CaveVines) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
+	if !c.Berries {
+		return false
+	}
+	c.Berries = false
+	tx.SetBlock(pos, c, nil)
+	dropItem(tx, item.NewStack(CaveVines{}, 1), pos.Vec3Centre())
+	return true
+}
+
+// BoneMeal makes the vines grow glow berries.
+func (c CaveVines) BoneMeal(pos cube.Pos, tx *world.Tx) bool {
+	if c.Berries {
+		return false
+	}
+	c.Berries, c.Head = true, c.head(pos, tx)
+	tx.SetBlock(pos, c, nil)
+	return true
+}
+
+// RandomTick ...
+func (c CaveVines) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	below := pos.Side(cube.FaceDown)
+	if c.Age >= 25 || r.IntN(10) != 0 {
+		return
+	}
+	if _, ok := tx.Block(below).(Air); !ok {
+		return
+	}
+	if c.Berries && c.Head {
+		// The vines no longer form the bottom of the column once they grow.
+		c.Head = false
+		tx.SetBlock(pos, c, nil)
+	}
+	// Newly grown vines have an 11% chance to carry glow berries.
+	berries := r.IntN(100) < 11
+	tx.SetBlock(below, CaveVines{Age: c.Age + 1, Berries: berries, Head: berries}, nil)
+}
+
+// NeighbourUpdateTick ...
+func (c CaveVines) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !c.supported(pos, tx) {
+		breakBlock(c, pos, tx)
+		return
+	}
+	if c.Berries && c.Head != c.head(pos, tx) {
+		c.Head = !c.Head
+		tx.SetBlock(pos, c, nil)
+	}
+}
+
+// UseOnBlock ...
+func (c CaveVines) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, c)
+	if !used {
+		return false
+	}
+	if !c.supported(pos, tx) {
+		return false
+	}
+	// When first placed, cave vines get a random age between 0 and 24.
+	place(tx, pos, CaveVines{Age: rand.IntN(25)}, user, ctx)
+	return placed(ctx)
+}
+
+// supported checks if the vines at the position passed hang from a solid
+// ceiling or from other cave vines.
+func (CaveVines) supported(pos cube.Pos, tx *world.Tx) bool {
+	above := pos.Side(cube.FaceUp)
+	if _, ok := tx.Block(above).(CaveVines); ok {
+		return true
+	}
+	return tx.Block(above).Model().FaceSolid(above, cube.FaceDown, tx)
+}
+
+// head checks if the vines at the position passed are the bottom block of a
+// column of vines.
+func (CaveVines) head(pos cube.Pos, tx *world.Tx) bool {
+	_, ok := tx.Block(pos.Side(cube.FaceDown)).(CaveVines)
+	return !ok
+}
+
+// EntityInside ...
+func (CaveVines) EntityInside(_ cube.Pos, _ *world.Tx, e world.Entity) {
+	if fallEntity, ok := e.(fallDistanceEntity); ok {
+		fallEntity.ResetFallDistance()
+	}
+}
+
+// HasLiquidDrops ...
+func (CaveVines) HasLiquidDrops() bool {
+	return true
+}
+
+// SideClosed ...
+func (CaveVines) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// FlammabilityInfo ...
+func (CaveVines) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(15, 60, true)
+}
+
+// BreakInfo ...
+func (c CaveVines) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(item.Tool, []item.Enchantment) []item.Stack {
+		if c.Berries {
+			return []item.Stack{item.NewStack(CaveVines{}, 1)}
+		}
+		return nil
+	})
+}
+
+// CompostChance ...
+func (CaveVines) CompostChance() float64 {
+	return 0.3
+}
+
+// EncodeItem ...
+func (CaveVines) EncodeItem() (name string, meta int16) {
+	return "minecraft:glow_berries", 0
+}
+
+// EncodeBlock ...
+func (c CaveVines) EncodeBlock() (string, map[string]any) {
+	name := "minecraft:cave_vines"
+	switch {
+	case c.Berries && c.Head:
+		name = "minecraft:cave_vines_head_with_berries"
+	case c.Berries:
+		name = "minecraft:cave_vines_body_with_berries"
+	}
+	return name, map[string]any{"growing_plant_age": int32(c.Age)}
+}
+
+// allCaveVines returns all possible states of cave vines.
+func allCaveVines() (b []world.Block) {
+	for age := 0; age < 26; age++ {
+		b = append(b, CaveVines{Age: age}, CaveVines{Age: age, Berries: true}, CaveVines{Age: age, Berries: true, Head: true})
+	}
+	return
+}
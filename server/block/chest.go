@@ -2,7 +2,6 @@ package block
 
 import (
 	"fmt"
-	"math/rand"
 	"strings"
 	"sync"
 	"time"
@@ -12,7 +11,6 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/world"
-	"github.com/df-mc/dragonfly/server/world/loot"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
 )
@@ -31,7 +29,12 @@ type Chest struct {
 	// CustomName is the custom name of the chest. This name is displayed when the chest is opened, and may
 	// include colour codes.
 	CustomName string
-	LootTable  string
+	// LootTable is the loot table used to fill the chest when it is first
+	// opened. If empty, no loot is generated.
+	LootTable string
+	// LootTableSeed is the seed of the loot table of the chest. A seed of 0
+	// means that the loot is generated with a random seed.
+	LootTableSeed int64
 
 	paired       bool
 	pairX, pairZ int
@@ -149,38 +152,22 @@ func (c Chest) RemoveViewer(v ContainerViewer, tx *world.Tx, pos cube.Pos) {
 	}
 }
 
-// generateLoot handles the calling of the loot system.
-func (c Chest) generateLoot(tx *world.Tx, pos cube.Pos) {
-	stacks, ok := loot.Generate(c.LootTable)
+// generateLoot fills the chest using its loot table and clears the loot table
+// afterwards. The loot table is kept if the loot could not be generated.
+func (c Chest) generateLoot(tx *world.Tx, pos cube.Pos, u item.User) {
+	stacks, ok := populateLoot(c.LootTable, c.LootTableSeed, tx, pos, u)
 	if !ok {
-		// If ok is false, an error was logged to console.
-		// We DO NOT clear the LootTable string, allowing the admin to fix the file and try again.
 		return
 	}
-
-	if len(stacks) == 0 {
-		// Table loaded but produced no items (bad RNG or empty table).
-		// We treat this as a success and clear the tag so it doesn't try again.
-		c.LootTable = ""
-		tx.SetBlock(pos, c, nil)
-		return
-	}
-
-	inv := c.Inventory(tx, pos)
-	for _, s := range stacks {
-		slot := rand.Intn(inv.Size())
-		_ = inv.SetItem(slot, s)
-	}
-
-	// Loot generated successfully, clear the tag.
-	c.LootTable = ""
+	scatterLoot(c.Inventory(tx, pos), stacks, c.LootTableSeed)
+	c.LootTable, c.LootTableSeed = "", 0
 	tx.SetBlock(pos, c, nil)
 }
 
 // Activate ...
 func (c Chest) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
 	if c.LootTable != "" {
-		c.generateLoot(tx, pos)
+		c.generateLoot(tx, pos, u)
 		// Refresh the chest variable after modification
 		c = tx.Block(pos).(Chest)
 	}
@@ -232,6 +219,10 @@ func (c Chest) BreakInfo() BreakInfo {
 			}
 		}
 
+		if c.LootTable != "" {
+			// Containers broken before being opened still drop their loot.
+			c.generateLoot(tx, pos, u)
+		}
 		for _, i := range c.Inventory(tx, pos).Clear() {
 			dropItem(tx, i, pos.Vec3Centre())
 		}
@@ -339,6 +330,7 @@ func (c Chest) DecodeNBT(data map[string]any) any {
 	c.Facing = facing
 	c.CustomName = nbtconv.String(data, "CustomName")
 	c.LootTable = nbtconv.String(data, "LootTable")
+	c.LootTableSeed = nbtconv.Int64(data, "LootTableSeed")
 
 	pairX, ok := data["pairx"]
 	pairZ, ok2 := data["pairz"]
@@ -358,17 +350,17 @@ func (c Chest) DecodeNBT(data map[string]any) any {
 // EncodeNBT ...
 func (c Chest) EncodeNBT() map[string]any {
 	if c.inventory == nil {
-		facing, customName := c.Facing, c.CustomName
+		facing, customName, lootTable, seed := c.Facing, c.CustomName, c.LootTable, c.LootTableSeed
 		//noinspection GoAssignmentToReceiver
 		c = NewChest()
-		c.Facing, c.CustomName = facing, customName
+		c.Facing, c.CustomName, c.LootTable, c.LootTableSeed = facing, customName, lootTable, seed
 	}
 	m := map[string]any{
 		"Items": nbtconv.InvToNBT(c.inventory),
 		"id":    "Chest",
 	}
 	if c.LootTable != "" {
-		m["LootTable"] = c.LootTable
+		m["LootTable"], m["LootTableSeed"] = c.LootTable, c.LootTableSeed
 	}
 	if c.CustomName != "" {
 		m["CustomName"] = c.CustomName
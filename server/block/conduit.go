@@ -0,0 +1,172 @@
+package block
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Conduit is a block crafted from a heart of the sea and nautilus shells. When placed underwater and surrounded by
+// a frame of prismarine and sea lanterns, it provides Conduit Power to nearby players that are in water or rain.
+type Conduit struct {
+	transparent
+	sourceWaterDisplacer
+
+	// frames is the amount of blocks in the frame around the conduit. The conduit is active if it is
+	// surrounded by water and at least 16 frame blocks.
+	frames int
+}
+
+// ConduitFrame represents a block which is capable of being part of the frame that activates a conduit.
+type ConduitFrame interface {
+	// PowersConduit returns a bool which indicates whether this block can be part of the frame of a conduit.
+	PowersConduit() bool
+}
+
+// Model ...
+func (Conduit) Model() world.BlockModel {
+	return model.Conduit{}
+}
+
+// SideClosed ...
+func (Conduit) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// LightEmissionLevel ...
+func (Conduit) LightEmissionLevel() uint8 {
+	return 15
+}
+
+// BreakInfo ...
+func (c Conduit) BreakInfo() BreakInfo {
+	return newBreakInfo(3, alwaysHarvestable, pickaxeEffective, oneOf(Conduit{}))
+}
+
+// Active checks if the conduit is active, meaning that it is surrounded by water and a frame of at least 16
+// blocks.
+func (c Conduit) Active() bool {
+	return c.frames >= 16
+}
+
+// Range returns the distance in blocks within which the conduit provides Conduit Power. It grows by 16 blocks
+// for every 7 blocks in the frame of the conduit and is 0 if the conduit is not active.
+func (c Conduit) Range() int {
+	if !c.Active() {
+		return 0
+	}
+	return c.frames / 7 * 16
+}
+
+// Tick recalculates the frame of the conduit and provides Conduit Power to players in range once every 40
+// ticks (2 seconds).
+func (c Conduit) Tick(currentTick int64, pos cube.Pos, tx *world.Tx) {
+	if currentTick%40 != 0 {
+		return
+	}
+	before := c.frames
+	c.frames = 0
+	if c.submerged(pos, tx) {
+		c.frames = c.countFrames(pos, tx)
+	}
+	if before != c.frames {
+		tx.SetBlock(pos, c, nil)
+	}
+	if c.Active() {
+		c.broadcastConduitPower(pos, tx)
+	}
+}
+
+// submerged checks if all blocks in the 3x3x3 area around the conduit are water.
+func (c Conduit) submerged(pos cube.Pos, tx *world.Tx) bool {
+	for x := -1; x <= 1; x++ {
+		for y := -1; y <= 1; y++ {
+			for z := -1; z <= 1; z++ {
+				if x == 0 && y == 0 && z == 0 {
+					continue
+				}
+				if l, ok := tx.Liquid(pos.Add(cube.Pos{x, y, z})); !ok || l.LiquidType() != "water" {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// countFrames counts the blocks of the frame around the conduit. The frame consists of the outer rings of the
+// three 5x5 planes crossing the conduit, making for at most 42 blocks.
+func (c Conduit) countFrames(pos cube.Pos, tx *world.Tx) (n int) {
+	for x := -2; x <= 2; x++ {
+		for y := -2; y <= 2; y++ {
+			for z := -2; z <= 2; z++ {
+				ax, ay, az := abs(x), abs(y), abs(z)
+				if onFrame := x == 0 && (ay == 2 || az == 2) || y == 0 && (ax == 2 || az == 2) || z == 0 && (ax == 2 || ay == 2); !onFrame {
+					continue
+				}
+				if f, ok := tx.Block(pos.Add(cube.Pos{x, y, z})).(ConduitFrame); ok && f.PowersConduit() {
+					n++
+				}
+			}
+		}
+	}
+	return n
+}
+
+// broadcastConduitPower gives Conduit Power to all conduitAffected entities within the range of the conduit
+// that are in water or rain.
+func (c Conduit) broadcastConduitPower(pos cube.Pos, tx *world.Tx) {
+	r := float64(c.Range())
+	centre := pos.Vec3Centre()
+	for e := range tx.EntitiesWithin(cube.Box(centre[0]-r, centre[1]-r, centre[2]-r, centre[0]+r, centre[1]+r, centre[2]+r)) {
+		p, ok := e.(conduitAffected)
+		if !ok || !p.ConduitAffected() || e.Position().Sub(centre).Len() > r {
+			continue
+		}
+		if entityPos := cube.PosFromVec3(e.Position()); wet(entityPos, tx) || tx.RainingAt(entityPos) {
+			p.AddEffect(effect.NewAmbient(effect.ConduitPower, 1, time.Second*13))
+		}
+	}
+}
+
+// wet checks if the block at the position passed contains water.
+func wet(pos cube.Pos, tx *world.Tx) bool {
+	l, ok := tx.Liquid(pos)
+	return ok && l.LiquidType() == "water"
+}
+
+// conduitAffected represents an entity that can be given Conduit Power by a conduit. Only players will
+// implement this.
+type conduitAffected interface {
+	// AddEffect adds a specific effect to the entity that implements this interface.
+	AddEffect(e effect.Effect)
+	// ConduitAffected returns whether this entity can be given Conduit Power by a conduit.
+	ConduitAffected() bool
+}
+
+// DecodeNBT ...
+func (c Conduit) DecodeNBT(data map[string]any) any {
+	if nbtconv.Bool(data, "Active") {
+		c.frames = 16
+	}
+	return c
+}
+
+// EncodeNBT ...
+func (c Conduit) EncodeNBT() map[string]any {
+	return map[string]any{"id": "Conduit", "Active": boolByte(c.Active()), "Target": int64(-1)}
+}
+
+// EncodeItem ...
+func (Conduit) EncodeItem() (name string, meta int16) {
+	return "minecraft:conduit", 0
+}
+
+// EncodeBlock ...
+func (Conduit) EncodeBlock() (string, map[string]any) {
+	return "minecraft:conduit", nil
+}
@@ -1,10 +1,13 @@
 package block
 
 import (
+	"math/rand/v2"
+
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
 )
 
 // ContainerViewer represents a viewer that is able to view a container and its inventory.
@@ -21,6 +24,98 @@ type ContainerOpener interface {
 	OpenBlockContainer(pos cube.Pos, tx *world.Tx)
 }
 
+// LootOpener represents an entity that is able to open a container holding a
+// loot table that has not yet been generated. Besides containers such as
+// chests, barrels and dispensers, this includes brushing suspicious sand and
+// gravel and opening or breaking decorated pots.
+type LootOpener interface {
+	// PopulateLoot is called when the LootOpener opens the container at the
+	// position passed, before the container is filled with the stacks
+	// generated from the loot table and seed passed. The stacks may be
+	// changed to fill the container with different items. If the table or
+	// seed is changed instead, the stacks passed are discarded and the loot is
+	// generated again from the new table and seed. Containers holding a single
+	// item, such as suspicious sand and decorated pots, only hold the first
+	// stack. If false is returned, the container is not filled and its loot
+	// table stays pending.
+	PopulateLoot(pos cube.Pos, table *string, seed *int64, stacks *[]item.Stack, tx *world.Tx) bool
+}
+
+// populateLoot generates the loot of the table passed for the container at the
+// position passed and passes it to the item.User if it is a LootOpener. The
+// item.User is passed to the loot table as the player opening the container. A
+// non-zero seed generates the same loot every time. False
+// is returned if the loot table could not be generated or if the LootOpener
+// prevented the container from being filled.
+func populateLoot(table string, seed int64, tx *world.Tx, pos cube.Pos, u item.User) ([]item.Stack, bool) {
+	stacks, ok := loot.GenerateWithContext(table, loot.Context{Tx: tx, Pos: pos, Seed: seed, Player: u})
+	if !ok {
+		// An error was logged already. The loot table is kept so that the
+		// table may be fixed and the loot generated again.
+		return nil, false
+	}
+	opener, ok := u.(LootOpener)
+	if !ok {
+		return stacks, true
+	}
+	newTable, newSeed := table, seed
+	if !opener.PopulateLoot(pos, &newTable, &newSeed, &stacks, tx) {
+		return nil, false
+	}
+	if newTable != table || newSeed != seed {
+		return loot.GenerateWithContext(newTable, loot.Context{Tx: tx, Pos: pos, Seed: newSeed, Player: u})
+	}
+	return stacks, true
+}
+
+// singleLoot returns the item held by a container that holds a single item,
+// such as a suspicious block or a decorated pot, and the loot table it still
+// holds. If the container still has a loot table, the item is generated from
+// it first, with the item.User passed as the player opening the container, and
+// the loot table is cleared. Loot tables may generate more than one stack, but
+// only the first is held by the container. The loot table is kept if the loot
+// could not be generated.
+func singleLoot(held item.Stack, table string, seed int64, tx *world.Tx, pos cube.Pos, u item.User) (item.Stack, string) {
+	if table == "" {
+		return held, ""
+	}
+	stacks, ok := populateLoot(table, seed, tx, pos, u)
+	if !ok {
+		return held, table
+	}
+	if len(stacks) > 0 {
+		return stacks[0], ""
+	}
+	return item.Stack{}, ""
+}
+
+// scatterLoot sets the stacks passed in random empty slots of the inventory
+// passed, like the loot of containers in vanilla. Stacks left over after all
+// empty slots were filled are added to the inventory if they fit. A non-zero
+// seed places the stacks in the same slots every time.
+func scatterLoot(inv *inventory.Inventory, stacks []item.Stack, seed int64) {
+	r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	if seed != 0 {
+		r = rand.New(rand.NewPCG(uint64(seed), uint64(seed)))
+	}
+	empty := make([]int, 0, inv.Size())
+	for slot, it := range inv.Slots() {
+		if it.Empty() {
+			empty = append(empty, slot)
+		}
+	}
+	r.Shuffle(len(empty), func(i, j int) {
+		empty[i], empty[j] = empty[j], empty[i]
+	})
+	for i, s := range stacks {
+		if i < len(empty) {
+			_ = inv.SetItem(empty[i], s)
+			continue
+		}
+		_, _ = inv.AddItem(s)
+	}
+}
+
 // Container represents a container of items, typically a block such as a chest. Containers may have their
 // inventory opened by viewers.
 type Container interface {
@@ -86,7 +86,9 @@ func (d CopperDoor) NeighbourUpdateTick(pos, changedNeighbour cube.Pos, tx *worl
 	} else if solid := tx.Block(pos.Side(cube.FaceDown)).Model().FaceSolid(pos.Side(cube.FaceDown), cube.FaceUp, tx); !solid {
 		// CopperDoor is pickaxeHarvestable, so don't use breakBlock() here.
 		breakBlockNoDrops(d, pos, tx)
-		dropItem(tx, item.NewStack(d, 1), pos.Vec3Centre())
+		if tileDrops(tx) {
+			dropItem(tx, item.NewStack(d, 1), pos.Vec3Centre())
+		}
 	} else if b, ok := tx.Block(pos.Side(cube.FaceUp)).(CopperDoor); !ok {
 		breakBlockNoDrops(d, pos, tx)
 	} else if d.Oxidation != b.Oxidation || d.Waxed != b.Waxed {
@@ -150,6 +152,14 @@ func (d CopperDoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.Use
 	return true
 }
 
+// WindChargeBurst opens or closes the door. Only the bottom half of the door
+// reacts, so that a burst reaching both halves does not toggle it twice.
+func (d CopperDoor) WindChargeBurst(pos cube.Pos, tx *world.Tx) {
+	if !d.Top {
+		d.Activate(pos, cube.FaceUp, tx, nil, nil)
+	}
+}
+
 func (d CopperDoor) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 	attemptOxidation(pos, tx, r, d)
 }
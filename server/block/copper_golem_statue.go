@@ -28,6 +28,11 @@ type CopperGolemStatue struct {
 	Waxed bool
 }
 
+// SideClosed ...
+func (CopperGolemStatue) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
 // BreakInfo ...
 func (c CopperGolemStatue) BreakInfo() BreakInfo {
 	return newBreakInfo(3, alwaysHarvestable, pickaxeEffective, oneOf(c)).withBlastResistance(30)
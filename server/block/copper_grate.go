@@ -23,6 +23,11 @@ type CopperGrate struct {
 	Waxed bool
 }
 
+// SideClosed ...
+func (CopperGrate) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
 // BreakInfo ...
 func (c CopperGrate) BreakInfo() BreakInfo {
 	return newBreakInfo(3, func(t item.Tool) bool {
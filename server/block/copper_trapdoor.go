@@ -93,6 +93,11 @@ func (t CopperTrapdoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item
 	return true
 }
 
+// WindChargeBurst opens or closes the trapdoor.
+func (t CopperTrapdoor) WindChargeBurst(pos cube.Pos, tx *world.Tx) {
+	t.Activate(pos, cube.FaceUp, tx, nil, nil)
+}
+
 func (t CopperTrapdoor) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 	attemptOxidation(pos, tx, r, t)
 }
@@ -26,6 +26,11 @@ type DecoratedPot struct {
 
 	// Item is the item being stored in the decorated pot.
 	Item item.Stack
+	// LootTable is the loot table used to generate the item stored in the decorated pot. The item is generated
+	// when the decorated pot is first used, broken or emptied by a hopper, after which LootTable is cleared.
+	LootTable string
+	// LootTableSeed is the seed of the loot table of the decorated pot. A seed of 0 generates a random item.
+	LootTableSeed int64
 	// Facing is the direction the pot is facing. The first decoration will be facing opposite of this direction.
 	Facing cube.Direction
 	// Decorations are the four decorations displayed on the sides of the pot. If a decoration is a brick or nil,
@@ -55,8 +60,21 @@ func (p DecoratedPot) Pick() item.Stack {
 	return item.NewStack(DecoratedPot{Decorations: p.Decorations}, 1)
 }
 
+// generateLoot sets the item stored in the decorated pot to the item generated from its loot table and clears
+// the loot table afterwards. The loot table is kept if the loot could not be generated.
+func (p DecoratedPot) generateLoot(tx *world.Tx, pos cube.Pos, u item.User) DecoratedPot {
+	if p.Item, p.LootTable = singleLoot(p.Item, p.LootTable, p.LootTableSeed, tx, pos, u); p.LootTable == "" {
+		p.LootTableSeed = 0
+		tx.SetBlock(pos, p, nil)
+	}
+	return p
+}
+
 // ExtractItem ...
 func (p DecoratedPot) ExtractItem(h Hopper, pos cube.Pos, tx *world.Tx) bool {
+	if p.LootTable != "" {
+		p = p.generateLoot(tx, pos, nil)
+	}
 	if p.Item.Empty() {
 		return false
 	}
@@ -101,6 +119,9 @@ func (p DecoratedPot) wobble(pos cube.Pos, tx *world.Tx, success bool) {
 
 // Activate ...
 func (p DecoratedPot) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, ctx *item.UseContext) bool {
+	if p.LootTable != "" {
+		p = p.generateLoot(tx, pos, u)
+	}
 	held, _ := u.HeldItems()
 	if held.Empty() || !p.Item.Comparable(held) || p.Item.Count() == p.Item.MaxCount() {
 		p.wobble(pos, tx, false)
@@ -121,6 +142,9 @@ func (p DecoratedPot) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.U
 // BreakInfo ...
 func (p DecoratedPot) BreakInfo() BreakInfo {
 	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(DecoratedPot{Decorations: p.Decorations})).withBreakHandler(func(pos cube.Pos, tx *world.Tx, u item.User) {
+		if p.LootTable != "" {
+			p = p.generateLoot(tx, pos, u)
+		}
 		if !p.Item.Empty() {
 			dropItem(tx, p.Item, pos.Vec3Centre())
 		}
@@ -173,12 +197,17 @@ func (p DecoratedPot) EncodeNBT() map[string]any {
 	if !p.Item.Empty() {
 		m["item"] = nbtconv.WriteItem(p.Item, true)
 	}
+	if p.LootTable != "" {
+		m["LootTable"], m["LootTableSeed"] = p.LootTable, p.LootTableSeed
+	}
 	return m
 }
 
 // DecodeNBT ...
 func (p DecoratedPot) DecodeNBT(data map[string]any) any {
 	p.Item = nbtconv.MapItem(data, "item")
+	p.LootTable = nbtconv.String(data, "LootTable")
+	p.LootTableSeed = nbtconv.Int64(data, "LootTableSeed")
 	p.Decorations = [4]PotDecoration{}
 	if sherds := nbtconv.Slice(data, "sherds"); sherds != nil {
 		for i, name := range sherds {
@@ -0,0 +1,186 @@
+package block
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Dispenser is a block that holds up to nine stacks of items. Because redstone is not implemented, dispensers
+// currently only function as containers and never dispense their items.
+// The empty value of Dispenser is not valid. It must be created using block.NewDispenser().
+type Dispenser struct {
+	solid
+	bassDrum
+
+	// Facing is the direction that the dispenser is facing.
+	Facing cube.Face
+	// Triggered specifies if the dispenser is currently activated.
+	Triggered bool
+	// CustomName is the custom name of the dispenser. This name is displayed when the dispenser is opened, and
+	// may include colour codes.
+	CustomName string
+	// LootTable is the loot table used to fill the dispenser when it is first opened. If empty, no loot is
+	// generated.
+	LootTable string
+	// LootTableSeed is the seed of the loot table of the dispenser. A seed of 0 means that the loot is generated
+	// with a random seed.
+	LootTableSeed int64
+
+	inventory *inventory.Inventory
+	viewerMu  *sync.RWMutex
+	viewers   map[ContainerViewer]struct{}
+}
+
+// NewDispenser creates a new initialised dispenser. The inventory is properly initialised.
+func NewDispenser() Dispenser {
+	m := new(sync.RWMutex)
+	v := make(map[ContainerViewer]struct{}, 1)
+	return Dispenser{
+		inventory: inventory.New(9, func(slot int, _, item item.Stack) {
+			m.RLock()
+			defer m.RUnlock()
+			for viewer := range v {
+				viewer.ViewSlotChange(slot, item)
+			}
+		}),
+		viewerMu: m,
+		viewers:  v,
+	}
+}
+
+// Inventory returns the inventory of the dispenser. The size of the inventory will be 9.
+func (d Dispenser) Inventory(*world.Tx, cube.Pos) *inventory.Inventory {
+	return d.inventory
+}
+
+// WithName returns the dispenser after applying a specific name to the block.
+func (d Dispenser) WithName(a ...any) world.Item {
+	d.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	return d
+}
+
+// AddViewer adds a viewer to the dispenser, so that it is updated whenever the inventory of the dispenser is
+// changed.
+func (d Dispenser) AddViewer(v ContainerViewer, _ *world.Tx, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	d.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the dispenser, so that slot updates in the inventory are no longer sent
+// to it.
+func (d Dispenser) RemoveViewer(v ContainerViewer, _ *world.Tx, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	delete(d.viewers, v)
+}
+
+// generateLoot fills the dispenser using its loot table and clears the loot table afterwards.
+func (d Dispenser) generateLoot(tx *world.Tx, pos cube.Pos, u item.User) Dispenser {
+	stacks, ok := populateLoot(d.LootTable, d.LootTableSeed, tx, pos, u)
+	if !ok {
+		return d
+	}
+	scatterLoot(d.inventory, stacks, d.LootTableSeed)
+	d.LootTable, d.LootTableSeed = "", 0
+	tx.SetBlock(pos, d, nil)
+	return d
+}
+
+// Activate ...
+func (d Dispenser) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	if d.LootTable != "" {
+		d = d.generateLoot(tx, pos, u)
+	}
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos, tx)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (d Dispenser) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(tx, pos, face, d)
+	if !used {
+		return
+	}
+	//noinspection GoAssignmentToReceiver
+	d = NewDispenser()
+	d.Facing = calculateFace(user, pos)
+
+	place(tx, pos, d, user, ctx)
+	return placed(ctx)
+}
+
+// BreakInfo ...
+func (d Dispenser) BreakInfo() BreakInfo {
+	return newBreakInfo(3.5, pickaxeHarvestable, pickaxeEffective, oneOf(Dispenser{})).withBreakHandler(func(pos cube.Pos, tx *world.Tx, u item.User) {
+		if d.LootTable != "" {
+			d.generateLoot(tx, pos, u)
+		}
+		for _, i := range d.Inventory(tx, pos).Clear() {
+			dropItem(tx, i, pos.Vec3())
+		}
+	})
+}
+
+// DecodeNBT ...
+func (d Dispenser) DecodeNBT(data map[string]any) any {
+	facing, triggered := d.Facing, d.Triggered
+	//noinspection GoAssignmentToReceiver
+	d = NewDispenser()
+	d.Facing, d.Triggered = facing, triggered
+	d.CustomName = nbtconv.String(data, "CustomName")
+	d.LootTable = nbtconv.String(data, "LootTable")
+	d.LootTableSeed = nbtconv.Int64(data, "LootTableSeed")
+	nbtconv.InvFromNBT(d.inventory, nbtconv.Slice(data, "Items"))
+	return d
+}
+
+// EncodeNBT ...
+func (d Dispenser) EncodeNBT() map[string]any {
+	if d.inventory == nil {
+		facing, triggered, customName, lootTable, seed := d.Facing, d.Triggered, d.CustomName, d.LootTable, d.LootTableSeed
+		//noinspection GoAssignmentToReceiver
+		d = NewDispenser()
+		d.Facing, d.Triggered, d.CustomName, d.LootTable, d.LootTableSeed = facing, triggered, customName, lootTable, seed
+	}
+	m := map[string]any{
+		"Items": nbtconv.InvToNBT(d.inventory),
+		"id":    "Dispenser",
+	}
+	if d.CustomName != "" {
+		m["CustomName"] = d.CustomName
+	}
+	if d.LootTable != "" {
+		m["LootTable"], m["LootTableSeed"] = d.LootTable, d.LootTableSeed
+	}
+	return m
+}
+
+// EncodeItem ...
+func (Dispenser) EncodeItem() (name string, meta int16) {
+	return "minecraft:dispenser", 0
+}
+
+// EncodeBlock ...
+func (d Dispenser) EncodeBlock() (string, map[string]any) {
+	return "minecraft:dispenser", map[string]any{"facing_direction": int32(d.Facing), "triggered_bit": d.Triggered}
+}
+
+// allDispensers ...
+func allDispensers() (b []world.Block) {
+	for _, f := range cube.Faces() {
+		b = append(b, Dispenser{Facing: f}, Dispenser{Facing: f, Triggered: true})
+	}
+	return
+}
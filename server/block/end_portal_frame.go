@@ -0,0 +1,54 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// EndPortalFrame is an indestructible block found in the portal room of strongholds. Twelve frames surround the
+// end portal, which is activated once every frame holds an eye of ender.
+type EndPortalFrame struct {
+	solid
+	transparent
+
+	// Facing is the direction the frame is facing.
+	Facing cube.Direction
+	// Eye specifies if the frame holds an eye of ender.
+	Eye bool
+}
+
+// LightEmissionLevel ...
+func (EndPortalFrame) LightEmissionLevel() uint8 {
+	return 1
+}
+
+// UseOnBlock ...
+func (f EndPortalFrame) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, f)
+	if !used {
+		return false
+	}
+	f.Facing = user.Rotation().Direction().Opposite()
+	place(tx, pos, f, user, ctx)
+	return placed(ctx)
+}
+
+// EncodeItem ...
+func (EndPortalFrame) EncodeItem() (name string, meta int16) {
+	return "minecraft:end_portal_frame", 0
+}
+
+// EncodeBlock ...
+func (f EndPortalFrame) EncodeBlock() (string, map[string]any) {
+	return "minecraft:end_portal_frame", map[string]any{"minecraft:cardinal_direction": f.Facing.String(), "end_portal_eye_bit": f.Eye}
+}
+
+// allEndPortalFrames ...
+func allEndPortalFrames() (frames []world.Block) {
+	for _, d := range cube.Directions() {
+		frames = append(frames, EndPortalFrame{Facing: d}, EndPortalFrame{Facing: d, Eye: true})
+	}
+	return frames
+}
@@ -8,8 +8,8 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/block/cube/trace"
 	"github.com/df-mc/dragonfly/server/event"
-	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
 	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
@@ -158,8 +158,8 @@ func (c ExplosionConfig) Explode(tx *world.Tx, explosionPos mgl64.Vec3) {
 				breakHandler(pos, tx, nil)
 			}
 			tx.SetBlock(pos, nil, nil)
-			if itemDropChance > r.Float64() {
-				for _, drop := range breakable.BreakInfo().Drops(item.ToolNone{}, nil) {
+			if itemDropChance > r.Float64() && tileDrops(tx) {
+				for _, drop := range Drops(bl, loot.Context{Tx: tx, Pos: pos}) {
 					dropItem(tx, drop, pos.Vec3Centre())
 				}
 			}
@@ -0,0 +1,73 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Frogspawn is a block laid by frogs on the surface of water after breeding.
+// After some time, frogspawn hatches into two to six tadpoles.
+type Frogspawn struct {
+	empty
+	transparent
+}
+
+// NeighbourUpdateTick ...
+func (f Frogspawn) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !frogspawnSupported(pos, tx) {
+		breakBlock(f, pos, tx)
+	}
+}
+
+// RandomTick ...
+func (f Frogspawn) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	if r.IntN(6) != 0 {
+		return
+	}
+	breakBlockNoDrops(f, pos, tx)
+
+	create := tx.World().EntityRegistry().Config().Tadpole
+	if create == nil {
+		return
+	}
+	for range 2 + r.IntN(5) {
+		opts := world.EntitySpawnOpts{Position: pos.Vec3().Add(mgl64.Vec3{0.2 + r.Float64()*0.6, 0, 0.2 + r.Float64()*0.6})}
+		tx.AddEntity(create(opts))
+	}
+}
+
+// UseOnBlock ...
+func (f Frogspawn) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, f)
+	if !used || !frogspawnSupported(pos, tx) {
+		return false
+	}
+	place(tx, pos, f, user, ctx)
+	return placed(ctx)
+}
+
+// frogspawnSupported checks if frogspawn at the position passed rests on the
+// surface of still water.
+func frogspawnSupported(pos cube.Pos, tx *world.Tx) bool {
+	liq, ok := tx.Liquid(pos.Side(cube.FaceDown))
+	return ok && liq.LiquidType() == "water" && liq.LiquidDepth() == 8 && !liq.LiquidFalling()
+}
+
+// BreakInfo ...
+func (f Frogspawn) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, simpleDrops())
+}
+
+// EncodeItem ...
+func (Frogspawn) EncodeItem() (name string, meta int16) {
+	return "minecraft:frog_spawn", 0
+}
+
+// EncodeBlock ...
+func (Frogspawn) EncodeBlock() (string, map[string]any) {
+	return "minecraft:frog_spawn", nil
+}
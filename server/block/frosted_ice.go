@@ -0,0 +1,98 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// FrostedIce is a variant of ice that is created when a player wearing boots enchanted with Frost Walker walks over
+// still water. It slowly melts back into water over time, quicker in bright light.
+type FrostedIce struct {
+	solid
+	transparent
+
+	// Age is the age of the frosted ice, ranging from 0 to 3. The ice melts into water once it is aged past 3.
+	Age int
+}
+
+// Instrument ...
+func (FrostedIce) Instrument() sound.Instrument {
+	return sound.Chimes()
+}
+
+// Friction ...
+func (FrostedIce) Friction() float64 {
+	return 0.98
+}
+
+// BreakInfo ...
+func (f FrostedIce) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, pickaxeEffective, simpleDrops()).withBreakHandler(func(pos cube.Pos, tx *world.Tx, _ item.User) {
+		meltIce(pos, tx)
+	})
+}
+
+// RandomTick ...
+func (f FrostedIce) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	f.ScheduledTick(pos, tx, r)
+}
+
+// ScheduledTick ages the frosted ice if it is lit well enough or if it does not have enough frosted ice neighbours,
+// eventually melting it into water. Frosted ice that melts speeds up the ageing of frosted ice next to it.
+func (f FrostedIce) ScheduledTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	if (r.IntN(3) == 0 || f.neighbours(pos, tx) < 4) && int(tx.Light(pos)) > 11-f.Age && f.age(pos, tx, r) {
+		for _, face := range cube.Faces() {
+			if n, ok := tx.Block(pos.Side(face)).(FrostedIce); ok {
+				n.age(pos.Side(face), tx, r)
+			}
+		}
+		return
+	}
+	tx.ScheduleBlockUpdate(pos, f, frostedIceTickDelay(r))
+}
+
+// age increases the age of the frosted ice by one, or melts it if it is already at its maximum age. True is returned
+// if the ice melted.
+func (f FrostedIce) age(pos cube.Pos, tx *world.Tx, r *rand.Rand) bool {
+	if f.Age < 3 {
+		f.Age++
+		tx.SetBlock(pos, f, nil)
+		tx.ScheduleBlockUpdate(pos, f, frostedIceTickDelay(r))
+		return false
+	}
+	meltIce(pos, tx)
+	return true
+}
+
+// neighbours returns the amount of frosted ice blocks horizontally and vertically adjacent to the position passed.
+func (FrostedIce) neighbours(pos cube.Pos, tx *world.Tx) (n int) {
+	for _, face := range cube.Faces() {
+		if _, ok := tx.Block(pos.Side(face)).(FrostedIce); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// frostedIceTickDelay returns a random delay of 1-2 seconds after which frosted ice is ticked again.
+func frostedIceTickDelay(r *rand.Rand) time.Duration {
+	return time.Second + time.Duration(r.IntN(20))*time.Second/20
+}
+
+// EncodeBlock ...
+func (f FrostedIce) EncodeBlock() (string, map[string]any) {
+	return "minecraft:frosted_ice", map[string]any{"age": int32(f.Age)}
+}
+
+// allFrostedIce returns all possible states of frosted ice.
+func allFrostedIce() (b []world.Block) {
+	for age := 0; age <= 3; age++ {
+		b = append(b, FrostedIce{Age: age})
+	}
+	return b
+}
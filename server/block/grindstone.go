@@ -61,7 +61,9 @@ func (g Grindstone) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
 	if _, ok := tx.Block(pos.Side(supportFace)).Model().(model.Empty); ok {
 		// Grindstone is pickaxeHarvestable, so don't use breakBlock() here.
 		breakBlockNoDrops(g, pos, tx)
-		dropItem(tx, item.NewStack(g, 1), pos.Vec3Centre())
+		if tileDrops(tx) {
+			dropItem(tx, item.NewStack(g, 1), pos.Vec3Centre())
+		}
 	}
 }
 
@@ -11,6 +11,12 @@ import (
 // HangingRoots is a hanging root found under azailia trees
 type HangingRoots struct {
 	transparent
+	sourceWaterDisplacer
+}
+
+// SideClosed ...
+func (HangingRoots) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
 }
 
 // EncodeBlock ...
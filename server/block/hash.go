@@ -7,9 +7,11 @@ import "github.com/df-mc/dragonfly/server/world"
 const (
 	hashAir = iota
 	hashAmethyst
+	hashAmethystCluster
 	hashAncientDebris
 	hashAndesite
 	hashAnvil
+	hashAzaleaLeaves
 	hashBanner
 	hashBarrel
 	hashBarrier
@@ -19,6 +21,7 @@ const (
 	hashBedrock
 	hashBeeNest
 	hashBeetrootSeeds
+	hashBell
 	hashBlackstone
 	hashBlastFurnace
 	hashBlueIce
@@ -27,13 +30,17 @@ const (
 	hashBrewingStand
 	hashBricks
 	hashBrownMushroom
+	hashBuddingAmethyst
 	hashBush
 	hashCactus
 	hashCake
 	hashCalcite
+	hashCalibratedSculkSensor
 	hashCampfire
 	hashCarpet
 	hashCarrot
+	hashCartographyTable
+	hashCaveVines
 	hashChest
 	hashChiseledQuartz
 	hashClay
@@ -44,6 +51,7 @@ const (
 	hashComposter
 	hashConcrete
 	hashConcretePowder
+	hashConduit
 	hashCopper
 	hashCopperBars
 	hashCopperChain
@@ -68,6 +76,7 @@ const (
 	hashDiorite
 	hashDirt
 	hashDirtPath
+	hashDispenser
 	hashDoubleFlower
 	hashDoubleTallGrass
 	hashDragonEgg
@@ -77,6 +86,7 @@ const (
 	hashEmeraldOre
 	hashEnchantingTable
 	hashEndBricks
+	hashEndPortalFrame
 	hashEndRod
 	hashEndStone
 	hashEnderChest
@@ -87,6 +97,8 @@ const (
 	hashFletchingTable
 	hashFlower
 	hashFroglight
+	hashFrogspawn
+	hashFrostedIce
 	hashFurnace
 	hashGlass
 	hashGlassPane
@@ -101,8 +113,11 @@ const (
 	hashGrindstone
 	hashHangingRoots
 	hashHayBale
+	hashHeavyCore
+	hashHoney
 	hashHoneycomb
 	hashHopper
+	hashIce
 	hashInvisibleBedrock
 	hashIron
 	hashIronBars
@@ -119,7 +134,9 @@ const (
 	hashLeafLitter
 	hashLeaves
 	hashLectern
+	hashLever
 	hashLight
+	hashLightningRod
 	hashLilyPad
 	hashLitPumpkin
 	hashLog
@@ -160,6 +177,7 @@ const (
 	hashQuartz
 	hashQuartzBricks
 	hashQuartzPillar
+	hashRail
 	hashRawCopper
 	hashRawGold
 	hashRawIron
@@ -169,9 +187,13 @@ const (
 	hashReinforcedDeepslate
 	hashResin
 	hashResinBricks
+	hashRootedDirt
 	hashSand
 	hashSandstone
 	hashSculk
+	hashSculkCatalyst
+	hashSculkSensor
+	hashSculkShrieker
 	hashSculkVein
 	hashSeaLantern
 	hashSeaPickle
@@ -184,6 +206,7 @@ const (
 	hashSmithingTable
 	hashSmoker
 	hashSmoothBasalt
+	hashSnifferEgg
 	hashSnow
 	hashSnowLayer
 	hashSoulSand
@@ -197,11 +220,15 @@ const (
 	hashStone
 	hashStoneBricks
 	hashStonecutter
+	hashStructureBlock
 	hashSugarCane
+	hashSuspiciousGravel
+	hashSuspiciousSand
 	hashTNT
 	hashTallDryGrass
 	hashTerracotta
 	hashTorch
+	hashTrialSpawner
 	hashTuff
 	hashTuffBricks
 	hashUnknown
@@ -237,6 +264,10 @@ func (Amethyst) Hash() (uint64, uint64) {
 	return hashAmethyst, 0
 }
 
+func (a AmethystCluster) Hash() (uint64, uint64) {
+	return hashAmethystCluster, uint64(a.Growth.Uint8()) | uint64(a.Facing)<<2
+}
+
 func (AncientDebris) Hash() (uint64, uint64) {
 	return hashAncientDebris, 0
 }
@@ -249,6 +280,10 @@ func (a Anvil) Hash() (uint64, uint64) {
 	return hashAnvil, uint64(a.Type.Uint8()) | uint64(a.Facing)<<2
 }
 
+func (l AzaleaLeaves) Hash() (uint64, uint64) {
+	return hashAzaleaLeaves, uint64(boolByte(l.Flowering)) | uint64(boolByte(l.Persistent))<<1 | uint64(boolByte(l.ShouldUpdate))<<2
+}
+
 func (b Banner) Hash() (uint64, uint64) {
 	return hashBanner, uint64(b.Attach.Uint8())
 }
@@ -278,13 +313,17 @@ func (b Bedrock) Hash() (uint64, uint64) {
 }
 
 func (b BeeNest) Hash() (uint64, uint64) {
-	return hashBeeNest, uint64(b.Facing)
+	return hashBeeNest, uint64(b.Facing) | uint64(b.HoneyLevel)<<2
 }
 
 func (b BeetrootSeeds) Hash() (uint64, uint64) {
 	return hashBeetrootSeeds, uint64(b.Growth)
 }
 
+func (b Bell) Hash() (uint64, uint64) {
+	return hashBell, uint64(b.Attach.Uint8()) | uint64(b.Facing)<<2
+}
+
 func (b Blackstone) Hash() (uint64, uint64) {
 	return hashBlackstone, uint64(b.Type.Uint8())
 }
@@ -317,6 +356,10 @@ func (BrownMushroom) Hash() (uint64, uint64) {
 	return hashBrownMushroom, 0
 }
 
+func (BuddingAmethyst) Hash() (uint64, uint64) {
+	return hashBuddingAmethyst, 0
+}
+
 func (Bush) Hash() (uint64, uint64) {
 	return hashBush, 0
 }
@@ -333,6 +376,10 @@ func (Calcite) Hash() (uint64, uint64) {
 	return hashCalcite, 0
 }
 
+func (s CalibratedSculkSensor) Hash() (uint64, uint64) {
+	return hashCalibratedSculkSensor, uint64(s.Facing) | uint64(s.Phase)<<2
+}
+
 func (c Campfire) Hash() (uint64, uint64) {
 	return hashCampfire, uint64(c.Facing) | uint64(boolByte(c.Extinguished))<<2 | uint64(c.Type.Uint8())<<3
 }
@@ -345,6 +392,14 @@ func (c Carrot) Hash() (uint64, uint64) {
 	return hashCarrot, uint64(c.Growth)
 }
 
+func (CartographyTable) Hash() (uint64, uint64) {
+	return hashCartographyTable, 0
+}
+
+func (c CaveVines) Hash() (uint64, uint64) {
+	return hashCaveVines, uint64(c.Age) | uint64(boolByte(c.Berries))<<5 | uint64(boolByte(c.Berries && c.Head))<<6
+}
+
 func (c Chest) Hash() (uint64, uint64) {
 	return hashChest, uint64(c.Facing)
 }
@@ -385,6 +440,10 @@ func (c ConcretePowder) Hash() (uint64, uint64) {
 	return hashConcretePowder, uint64(c.Colour.Uint8())
 }
 
+func (Conduit) Hash() (uint64, uint64) {
+	return hashConduit, 0
+}
+
 func (c Copper) Hash() (uint64, uint64) {
 	return hashCopper, uint64(c.Type.Uint8()) | uint64(c.Oxidation.Uint8())<<2 | uint64(boolByte(c.Waxed))<<4
 }
@@ -481,6 +540,10 @@ func (DirtPath) Hash() (uint64, uint64) {
 	return hashDirtPath, 0
 }
 
+func (d Dispenser) Hash() (uint64, uint64) {
+	return hashDispenser, uint64(d.Facing) | uint64(boolByte(d.Triggered))<<3
+}
+
 func (d DoubleFlower) Hash() (uint64, uint64) {
 	return hashDoubleFlower, uint64(boolByte(d.UpperPart)) | uint64(d.Type.Uint8())<<1
 }
@@ -517,6 +580,10 @@ func (EndBricks) Hash() (uint64, uint64) {
 	return hashEndBricks, 0
 }
 
+func (f EndPortalFrame) Hash() (uint64, uint64) {
+	return hashEndPortalFrame, uint64(f.Facing) | uint64(boolByte(f.Eye))<<2
+}
+
 func (e EndRod) Hash() (uint64, uint64) {
 	return hashEndRod, uint64(e.Facing)
 }
@@ -557,6 +624,14 @@ func (f Froglight) Hash() (uint64, uint64) {
 	return hashFroglight, uint64(f.Type.Uint8()) | uint64(f.Axis)<<2
 }
 
+func (Frogspawn) Hash() (uint64, uint64) {
+	return hashFrogspawn, 0
+}
+
+func (f FrostedIce) Hash() (uint64, uint64) {
+	return hashFrostedIce, uint64(f.Age)
+}
+
 func (f Furnace) Hash() (uint64, uint64) {
 	return hashFurnace, uint64(f.Facing) | uint64(boolByte(f.Lit))<<2
 }
@@ -613,6 +688,14 @@ func (h HayBale) Hash() (uint64, uint64) {
 	return hashHayBale, uint64(h.Axis)
 }
 
+func (HeavyCore) Hash() (uint64, uint64) {
+	return hashHeavyCore, 0
+}
+
+func (Honey) Hash() (uint64, uint64) {
+	return hashHoney, 0
+}
+
 func (Honeycomb) Hash() (uint64, uint64) {
 	return hashHoneycomb, 0
 }
@@ -621,6 +704,10 @@ func (h Hopper) Hash() (uint64, uint64) {
 	return hashHopper, uint64(h.Facing) | uint64(boolByte(h.Powered))<<3
 }
 
+func (Ice) Hash() (uint64, uint64) {
+	return hashIce, 0
+}
+
 func (InvisibleBedrock) Hash() (uint64, uint64) {
 	return hashInvisibleBedrock, 0
 }
@@ -685,10 +772,18 @@ func (l Lectern) Hash() (uint64, uint64) {
 	return hashLectern, uint64(l.Facing)
 }
 
+func (l Lever) Hash() (uint64, uint64) {
+	return hashLever, uint64(l.Facing) | uint64(boolByte(l.eastWest()))<<3 | uint64(boolByte(l.Powered))<<4
+}
+
 func (l Light) Hash() (uint64, uint64) {
 	return hashLight, uint64(l.Level)
 }
 
+func (l LightningRod) Hash() (uint64, uint64) {
+	return hashLightningRod, uint64(l.Facing) | uint64(boolByte(l.Powered))<<3
+}
+
 func (LilyPad) Hash() (uint64, uint64) {
 	return hashLilyPad, 0
 }
@@ -849,6 +944,10 @@ func (q QuartzPillar) Hash() (uint64, uint64) {
 	return hashQuartzPillar, uint64(q.Axis)
 }
 
+func (r Rail) Hash() (uint64, uint64) {
+	return hashRail, uint64(boolByte(r.eastWest()))
+}
+
 func (RawCopper) Hash() (uint64, uint64) {
 	return hashRawCopper, 0
 }
@@ -885,6 +984,10 @@ func (r ResinBricks) Hash() (uint64, uint64) {
 	return hashResinBricks, uint64(boolByte(r.Chiseled))
 }
 
+func (RootedDirt) Hash() (uint64, uint64) {
+	return hashRootedDirt, 0
+}
+
 func (s Sand) Hash() (uint64, uint64) {
 	return hashSand, uint64(boolByte(s.Red))
 }
@@ -897,6 +1000,18 @@ func (Sculk) Hash() (uint64, uint64) {
 	return hashSculk, 0
 }
 
+func (c SculkCatalyst) Hash() (uint64, uint64) {
+	return hashSculkCatalyst, uint64(boolByte(c.Bloom))
+}
+
+func (s SculkSensor) Hash() (uint64, uint64) {
+	return hashSculkSensor, uint64(s.Phase)
+}
+
+func (s SculkShrieker) Hash() (uint64, uint64) {
+	return hashSculkShrieker, uint64(boolByte(s.Active)) | uint64(boolByte(s.CanSummon))<<1
+}
+
 func (s SculkVein) Hash() (uint64, uint64) {
 	return hashSculkVein, uint64(boolByte(s.Down)) | uint64(boolByte(s.Up))<<1 | uint64(boolByte(s.North))<<2 | uint64(boolByte(s.South))<<3 | uint64(boolByte(s.West))<<4 | uint64(boolByte(s.East))<<5
 }
@@ -945,6 +1060,10 @@ func (SmoothBasalt) Hash() (uint64, uint64) {
 	return hashSmoothBasalt, 0
 }
 
+func (e SnifferEgg) Hash() (uint64, uint64) {
+	return hashSnifferEgg, uint64(e.Cracks)
+}
+
 func (Snow) Hash() (uint64, uint64) {
 	return hashSnow, 0
 }
@@ -997,10 +1116,22 @@ func (s Stonecutter) Hash() (uint64, uint64) {
 	return hashStonecutter, uint64(s.Facing)
 }
 
+func (s StructureBlock) Hash() (uint64, uint64) {
+	return hashStructureBlock, uint64(s.Mode)
+}
+
 func (c SugarCane) Hash() (uint64, uint64) {
 	return hashSugarCane, uint64(c.Age)
 }
 
+func (s SuspiciousGravel) Hash() (uint64, uint64) {
+	return hashSuspiciousGravel, uint64(s.BrushedProgress) | uint64(boolByte(s.Hanging))<<2
+}
+
+func (s SuspiciousSand) Hash() (uint64, uint64) {
+	return hashSuspiciousSand, uint64(s.BrushedProgress) | uint64(boolByte(s.Hanging))<<2
+}
+
 func (TNT) Hash() (uint64, uint64) {
 	return hashTNT, 0
 }
@@ -1017,6 +1148,10 @@ func (t Torch) Hash() (uint64, uint64) {
 	return hashTorch, uint64(t.Facing) | uint64(t.Type.Uint8())<<3
 }
 
+func (s TrialSpawner) Hash() (uint64, uint64) {
+	return hashTrialSpawner, uint64(s.state)
+}
+
 func (t Tuff) Hash() (uint64, uint64) {
 	return hashTuff, uint64(boolByte(t.Chiseled))
 }
@@ -0,0 +1,39 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// HeavyCore is a dense block found in ominous vaults. It is combined with a
+// breeze rod to craft a mace.
+type HeavyCore struct {
+	transparent
+	sourceWaterDisplacer
+}
+
+// Model ...
+func (HeavyCore) Model() world.BlockModel {
+	return model.HeavyCore{}
+}
+
+// SideClosed ...
+func (HeavyCore) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// BreakInfo ...
+func (h HeavyCore) BreakInfo() BreakInfo {
+	return newBreakInfo(10, alwaysHarvestable, pickaxeEffective, oneOf(h)).withBlastResistance(30)
+}
+
+// EncodeItem ...
+func (HeavyCore) EncodeItem() (name string, meta int16) {
+	return "minecraft:heavy_core", 0
+}
+
+// EncodeBlock ...
+func (HeavyCore) EncodeBlock() (string, map[string]any) {
+	return "minecraft:heavy_core", nil
+}
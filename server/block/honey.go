@@ -0,0 +1,34 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Honey is a sticky block crafted from honey bottles. Entities landing on it take greatly reduced fall damage.
+type Honey struct {
+	solid
+	transparent
+}
+
+// EntityLand ...
+func (Honey) EntityLand(_ cube.Pos, _ *world.Tx, e world.Entity, distance *float64) {
+	if _, ok := e.(fallDistanceEntity); ok {
+		*distance *= 0.2
+	}
+}
+
+// BreakInfo ...
+func (h Honey) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(h))
+}
+
+// EncodeItem ...
+func (Honey) EncodeItem() (name string, meta int16) {
+	return "minecraft:honey_block", 0
+}
+
+// EncodeBlock ...
+func (Honey) EncodeBlock() (name string, properties map[string]any) {
+	return "minecraft:honey_block", nil
+}
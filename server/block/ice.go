@@ -0,0 +1,71 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// Ice is a transparent, slippery block that forms on the surface of water in cold biomes. Breaking it without silk
+// touch turns it back into water if the block below it is solid or a liquid.
+type Ice struct {
+	solid
+	transparent
+}
+
+// Instrument ...
+func (Ice) Instrument() sound.Instrument {
+	return sound.Chimes()
+}
+
+// Friction ...
+func (Ice) Friction() float64 {
+	return 0.98
+}
+
+// BreakInfo ...
+func (i Ice) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, pickaxeEffective, silkTouchOnlyDrop(i)).withBreakHandler(func(pos cube.Pos, tx *world.Tx, u item.User) {
+		if held, _ := u.HeldItems(); hasSilkTouch(held.Enchantments()) {
+			return
+		}
+		if tx.World().Dimension().WaterEvaporates() {
+			return
+		}
+		below := pos.Side(cube.FaceDown)
+		_, liquid := tx.Liquid(below)
+		if liquid || tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+			tx.SetLiquid(pos, Water{Depth: 8, Still: true})
+		}
+	})
+}
+
+// RandomTick melts the ice if a light source, such as a torch, is placed close to it.
+func (Ice) RandomTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if tx.BlockLight(pos) > 11 {
+		meltIce(pos, tx)
+	}
+}
+
+// meltIce turns the ice at the position passed into a water source block, or into air if water evaporates in the
+// dimension of the world.
+func meltIce(pos cube.Pos, tx *world.Tx) {
+	if tx.World().Dimension().WaterEvaporates() {
+		tx.SetBlock(pos, nil, nil)
+		return
+	}
+	tx.SetBlock(pos, Water{Depth: 8, Still: true}, nil)
+}
+
+// EncodeItem ...
+func (Ice) EncodeItem() (name string, meta int16) {
+	return "minecraft:ice", 0
+}
+
+// EncodeBlock ...
+func (Ice) EncodeBlock() (string, map[string]any) {
+	return "minecraft:ice", nil
+}
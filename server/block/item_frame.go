@@ -33,8 +33,12 @@ type ItemFrame struct {
 // Activate ...
 func (i ItemFrame) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, ctx *item.UseContext) bool {
 	if !i.Item.Empty() {
-		// TODO: Item frames with maps can only be rotated four times.
-		i.Rotations = (i.Rotations + 1) % 8
+		rotations := 8
+		if _, ok := i.Item.Item().(item.FilledMap); ok {
+			// Maps fill the entire frame, so they can only be rotated four times.
+			rotations = 4
+		}
+		i.Rotations = (i.Rotations + 1) % rotations
 		tx.PlaySound(pos.Vec3Centre(), sound.ItemFrameRotate{})
 	} else if held, _ := u.HeldItems(); !held.Empty() {
 		i.Item = held.Grow(-held.Count() + 1)
@@ -58,7 +62,7 @@ func (i ItemFrame) Punch(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User) {
 	if g, ok := u.(interface {
 		GameMode() world.GameMode
 	}); ok {
-		if rand.Float64() <= i.DropChance && !g.GameMode().CreativeInventory() {
+		if rand.Float64() <= i.DropChance && !g.GameMode().CreativeInventory() && tx.World().GameRule(world.GameRuleDoEntityDrops) {
 			dropItem(tx, i.Item, pos.Vec3Centre())
 		}
 	}
@@ -87,7 +91,7 @@ func (i ItemFrame) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *wo
 // BreakInfo ...
 func (i ItemFrame) BreakInfo() BreakInfo {
 	return newBreakInfo(0.25, alwaysHarvestable, nothingEffective, oneOf(ItemFrame{Glowing: i.Glowing})).withBreakHandler(func(pos cube.Pos, tx *world.Tx, _ item.User) {
-		if !i.Item.Empty() {
+		if !i.Item.Empty() && tx.World().GameRule(world.GameRuleDoEntityDrops) {
 			dropItem(tx, i.Item, pos.Vec3Centre())
 		}
 	})
@@ -19,6 +19,15 @@ type Jukebox struct {
 	Item item.Stack
 }
 
+// JukeboxListener represents an entity that reacts to a jukebox starting to
+// play a music disc near it, such as an allay.
+type JukeboxListener interface {
+	world.Entity
+	// JukeboxPlayed is called when a jukebox within 10 blocks of the entity
+	// starts playing a music disc.
+	JukeboxPlayed(pos cube.Pos, tx *world.Tx)
+}
+
 // InsertItem ...
 func (j Jukebox) InsertItem(h Hopper, pos cube.Pos, tx *world.Tx) bool {
 	if !j.Item.Empty() {
@@ -35,6 +44,7 @@ func (j Jukebox) InsertItem(h Hopper, pos cube.Pos, tx *world.Tx) bool {
 			tx.SetBlock(pos, j, nil)
 			_ = h.inventory.SetItem(sourceSlot, sourceStack.Grow(-1))
 			tx.PlaySound(pos.Vec3Centre(), sound.MusicDiscPlay{DiscType: m.DiscType})
+			j.notifyListeners(pos, tx)
 			return true
 		}
 	}
@@ -87,6 +97,7 @@ func (j Jukebox) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User,
 			ctx.SubtractFromCount(1)
 
 			tx.PlaySound(pos.Vec3Centre(), sound.MusicDiscPlay{DiscType: m.DiscType})
+			j.notifyListeners(pos, tx)
 			if u, ok := u.(jukeboxUser); ok {
 				u.SendJukeboxPopup(fmt.Sprintf("Now playing: %v - %v", m.DiscType.Author(), m.DiscType.DisplayName()))
 			}
@@ -95,6 +106,17 @@ func (j Jukebox) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User,
 	return true
 }
 
+// notifyListeners calls JukeboxPlayed on all JukeboxListener entities within
+// 10 blocks of the jukebox.
+func (j Jukebox) notifyListeners(pos cube.Pos, tx *world.Tx) {
+	centre := pos.Vec3Centre()
+	for e := range tx.EntitiesWithin(cube.Box(-10, -10, -10, 10, 10, 10).Translate(centre)) {
+		if l, ok := e.(JukeboxListener); ok && e.Position().Sub(centre).Len() <= 10 {
+			l.JukeboxPlayed(pos, tx)
+		}
+	}
+}
+
 // Disc returns the currently playing music disc
 func (j Jukebox) Disc() (sound.DiscType, bool) {
 	if !j.Item.Empty() {
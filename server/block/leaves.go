@@ -7,6 +7,7 @@ import (
 	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
 	"github.com/go-gl/mathgl/mgl64"
 )
 
@@ -49,7 +50,12 @@ func findLog(pos cube.Pos, tx *world.Tx, visited *[]cube.Pos, distance int) bool
 	if log, ok := tx.Block(pos).(Log); ok && !log.Stripped {
 		return true
 	}
-	if _, ok := tx.Block(pos).(Leaves); !ok || distance > 6 {
+	switch tx.Block(pos).(type) {
+	case Leaves, AzaleaLeaves:
+	default:
+		return false
+	}
+	if distance > 6 {
 		return false
 	}
 	logFound := false
@@ -77,7 +83,10 @@ func (l Leaves) RandomTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
 			return
 		}
 		tx.SetBlock(pos, nil, nil)
-		for _, drop := range l.BreakInfo().Drops(item.ToolNone{}, nil) {
+		if !tileDrops(tx) {
+			return
+		}
+		for _, drop := range Drops(l, loot.Context{Tx: tx, Pos: pos}) {
 			dropItem(tx, drop, pos.Vec3Centre())
 		}
 	}
@@ -0,0 +1,115 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Lever is a switch that may be attached to the top, bottom or sides of a block and is flipped by using it.
+// Because redstone is not implemented, flipping a lever has no effect other than changing its state.
+type Lever struct {
+	transparent
+	empty
+
+	// Facing is the direction from the lever to the block it is attached to.
+	Facing cube.Face
+	// Axis is the axis the lever is aligned with if it is attached to the top or bottom of a block. It is
+	// either cube.X or cube.Z.
+	Axis cube.Axis
+	// Powered specifies if the lever is flipped on.
+	Powered bool
+}
+
+// Activate ...
+func (l Lever) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
+	l.Powered = !l.Powered
+	tx.SetBlock(pos, l, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.Click{})
+	return true
+}
+
+// WindChargeBurst flips the lever.
+func (l Lever) WindChargeBurst(pos cube.Pos, tx *world.Tx) {
+	l.Activate(pos, cube.FaceUp, tx, nil, nil)
+}
+
+// UseOnBlock ...
+func (l Lever) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(tx, pos, face, l)
+	if !used {
+		return false
+	}
+	if !tx.Block(pos.Side(face.Opposite())).Model().FaceSolid(pos.Side(face.Opposite()), face, tx) {
+		return false
+	}
+	l.Facing, l.Axis = face.Opposite(), cube.Z
+	if face.Axis() == cube.Y && user.Rotation().Direction().Face().Axis() == cube.X {
+		l.Axis = cube.X
+	}
+
+	place(tx, pos, l, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (l Lever) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !tx.Block(pos.Side(l.Facing)).Model().FaceSolid(pos.Side(l.Facing), l.Facing.Opposite(), tx) {
+		breakBlock(l, pos, tx)
+	}
+}
+
+// HasLiquidDrops ...
+func (l Lever) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (l Lever) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, nothingEffective, oneOf(Lever{}))
+}
+
+// EncodeItem ...
+func (l Lever) EncodeItem() (name string, meta int16) {
+	return "minecraft:lever", 0
+}
+
+// eastWest checks if the lever is attached to the top or bottom of a block and aligned with the X axis.
+func (l Lever) eastWest() bool {
+	return l.Axis == cube.X && l.Facing.Axis() == cube.Y
+}
+
+// EncodeBlock ...
+func (l Lever) EncodeBlock() (name string, properties map[string]any) {
+	var direction string
+	switch l.Facing {
+	case cube.FaceDown, cube.FaceUp:
+		direction = "up_"
+		if l.Facing == cube.FaceUp {
+			direction = "down_"
+		}
+		if l.eastWest() {
+			direction += "east_west"
+		} else {
+			direction += "north_south"
+		}
+	default:
+		direction = l.Facing.Opposite().String()
+	}
+	return "minecraft:lever", map[string]any{"lever_direction": direction, "open_bit": l.Powered}
+}
+
+// allLevers ...
+func allLevers() (levers []world.Block) {
+	for _, f := range cube.Faces() {
+		for _, powered := range []bool{false, true} {
+			if f.Axis() == cube.Y {
+				levers = append(levers, Lever{Facing: f, Axis: cube.X, Powered: powered})
+			}
+			levers = append(levers, Lever{Facing: f, Axis: cube.Z, Powered: powered})
+		}
+	}
+	return
+}
@@ -0,0 +1,88 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// LightningRod is a block that attracts lightning striking nearby during
+// thunderstorms, redirecting it to strike the rod instead. Lightning striking a
+// lightning rod does not set blocks around it on fire.
+type LightningRod struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Facing is the direction that the tip of the lightning rod is facing.
+	Facing cube.Face
+	// Powered is true for a short duration after the lightning rod is struck
+	// by lightning.
+	Powered bool
+}
+
+// UseOnBlock ...
+func (l LightningRod) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(tx, pos, face, l)
+	if !used {
+		return false
+	}
+	l.Facing = face
+	if other, ok := tx.Block(pos.Side(face.Opposite())).(LightningRod); ok && other.Facing == face {
+		l.Facing = face.Opposite()
+	}
+	place(tx, pos, l, user, ctx)
+	return placed(ctx)
+}
+
+// StruckByLightning powers the lightning rod for 8 ticks.
+func (l LightningRod) StruckByLightning(pos cube.Pos, tx *world.Tx) {
+	l.Powered = true
+	tx.SetBlock(pos, l, nil)
+	tx.ScheduleBlockUpdate(pos, l, time.Millisecond*400)
+}
+
+// ScheduledTick ...
+func (l LightningRod) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if l.Powered {
+		l.Powered = false
+		tx.SetBlock(pos, l, nil)
+	}
+}
+
+// SideClosed ...
+func (LightningRod) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// Model ...
+func (l LightningRod) Model() world.BlockModel {
+	return model.EndRod{Axis: l.Facing.Axis()}
+}
+
+// BreakInfo ...
+func (l LightningRod) BreakInfo() BreakInfo {
+	return newBreakInfo(3, pickaxeHarvestable, pickaxeEffective, oneOf(LightningRod{})).withBlastResistance(6)
+}
+
+// EncodeItem ...
+func (LightningRod) EncodeItem() (name string, meta int16) {
+	return "minecraft:lightning_rod", 0
+}
+
+// EncodeBlock ...
+func (l LightningRod) EncodeBlock() (string, map[string]any) {
+	return "minecraft:lightning_rod", map[string]any{"facing_direction": int32(l.Facing), "powered_bit": l.Powered}
+}
+
+// allLightningRods ...
+func allLightningRods() (b []world.Block) {
+	for _, f := range cube.Faces() {
+		b = append(b, LightningRod{Facing: f}, LightningRod{Facing: f, Powered: true})
+	}
+	return
+}
@@ -6,8 +6,8 @@ import (
 
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/event"
-	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
 )
 
 // LiquidRemovable represents a block that may be removed by a liquid flowing into it. When this happens, the
@@ -170,9 +170,9 @@ func flowInto(b world.Liquid, src, pos cube.Pos, tx *world.Tx, falling bool) boo
 		if _, air := existing.(Air); !air {
 			tx.SetBlock(pos, nil, nil)
 		}
-		if removable.HasLiquidDrops() {
-			if b, ok := existing.(Breakable); ok {
-				for _, d := range b.BreakInfo().Drops(item.ToolNone{}, nil) {
+		if removable.HasLiquidDrops() && tileDrops(tx) {
+			if _, ok := existing.(Breakable); ok {
+				for _, d := range Drops(existing, loot.Context{Tx: tx, Pos: pos}) {
 					dropItem(tx, d, pos.Vec3Centre())
 				}
 			} else {
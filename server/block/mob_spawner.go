@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
 )
 
 // MobSpawner is a decorative block that looks like a spawner.
@@ -20,6 +21,16 @@ func NewSpawner(entityType string) MobSpawner {
 	return MobSpawner{EntityIdentifier: entityType}
 }
 
+// SetEntity changes the entity displayed in the spawner to the world.EntityType passed. If the spawner already
+// displays this entity, false is returned.
+func (s MobSpawner) SetEntity(t world.EntityType) (world.Block, bool) {
+	if s.EntityIdentifier == t.EncodeEntity() {
+		return s, false
+	}
+	s.EntityIdentifier = t.EncodeEntity()
+	return s, true
+}
+
 // EncodeNBT tells the client how to render the block and the entity inside.
 func (s MobSpawner) EncodeNBT() map[string]any {
 	data := map[string]any{
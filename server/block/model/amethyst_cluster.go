@@ -0,0 +1,31 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// AmethystCluster is a model used by amethyst buds and clusters. It is a thin box growing out of the block it
+// is attached to.
+type AmethystCluster struct {
+	// Facing is the direction that the cluster grows towards.
+	Facing cube.Face
+	// Height is the height of the cluster in blocks.
+	Height float64
+}
+
+// BBox ...
+func (a AmethystCluster) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	box := full.ExtendTowards(a.Facing, a.Height-1)
+	for _, axis := range cube.Axes() {
+		if axis != a.Facing.Axis() {
+			box = box.Stretch(axis, -0.1875)
+		}
+	}
+	return []cube.BBox{box}
+}
+
+// FaceSolid ...
+func (AmethystCluster) FaceSolid(cube.Pos, cube.Face, world.BlockSource) bool {
+	return false
+}
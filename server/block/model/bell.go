@@ -0,0 +1,26 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Bell is a model used by bells.
+type Bell struct {
+	// Standing specifies if the bell is placed on the ground, in which case it
+	// is held up by a frame that reaches down to the bottom of the block.
+	Standing bool
+}
+
+// BBox ...
+func (b Bell) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	if b.Standing {
+		return []cube.BBox{cube.Box(0.25, 0, 0.25, 0.75, 1, 0.75)}
+	}
+	return []cube.BBox{cube.Box(0.25, 0.25, 0.25, 0.75, 1, 0.75)}
+}
+
+// FaceSolid always returns false.
+func (b Bell) FaceSolid(cube.Pos, cube.Face, world.BlockSource) bool {
+	return false
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Conduit is a model used by conduits. It is a small cube in the centre of the block.
+type Conduit struct{}
+
+// BBox ...
+func (Conduit) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	return []cube.BBox{cube.Box(0.3125, 0.3125, 0.3125, 0.6875, 0.6875, 0.6875)}
+}
+
+// FaceSolid ...
+func (Conduit) FaceSolid(cube.Pos, cube.Face, world.BlockSource) bool {
+	return false
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// SnifferEgg is the model for a SnifferEgg. It is slightly smaller than a full
+// block.
+type SnifferEgg struct{}
+
+// BBox returns a physics.BBox that is slightly smaller than a full block.
+func (SnifferEgg) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	return []cube.BBox{cube.Box(0.0625, 0, 0.125, 0.9375, 1, 0.875)}
+}
+
+// FaceSolid always returns false.
+func (SnifferEgg) FaceSolid(cube.Pos, cube.Face, world.BlockSource) bool {
+	return false
+}
@@ -0,0 +1,289 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// multiFace holds the faces of the space it occupies that a block, such as
+// glow lichen or a sculk vein, is attached to. A single block may be attached
+// to multiple faces at once.
+type multiFace struct {
+	// Down, Up, North, South, West and East specify if the block is attached
+	// to the respective face.
+	Down, Up, North, South, West, East bool
+}
+
+// multiFaceBlock is a block that may be attached to any of the six faces of
+// the space it occupies. Functions handling these blocks use it to place,
+// merge and break them in the same way for all of them.
+type multiFaceBlock interface {
+	world.Block
+	world.LiquidDisplacer
+	// faces returns the faces that the block is attached to.
+	faces() multiFace
+	// withFaces returns the block attached to the faces passed.
+	withFaces(m multiFace) multiFaceBlock
+}
+
+// hasFace checks if the block is attached to the face passed.
+func (m multiFace) hasFace(f cube.Face) bool {
+	switch f {
+	case cube.FaceUp:
+		return m.Up
+	case cube.FaceDown:
+		return m.Down
+	case cube.FaceNorth:
+		return m.North
+	case cube.FaceSouth:
+		return m.South
+	case cube.FaceWest:
+		return m.West
+	case cube.FaceEast:
+		return m.East
+	}
+	return false
+}
+
+// withFace returns the multiFace with the face passed attached or detached.
+func (m multiFace) withFace(f cube.Face, attached bool) multiFace {
+	switch f {
+	case cube.FaceUp:
+		m.Up = attached
+	case cube.FaceDown:
+		m.Down = attached
+	case cube.FaceNorth:
+		m.North = attached
+	case cube.FaceSouth:
+		m.South = attached
+	case cube.FaceWest:
+		m.West = attached
+	case cube.FaceEast:
+		m.East = attached
+	}
+	return m
+}
+
+// count returns the number of faces that the block is attached to.
+func (m multiFace) count() int {
+	n := 0
+	for _, f := range cube.Faces() {
+		if m.hasFace(f) {
+			n++
+		}
+	}
+	return n
+}
+
+// properties returns the block properties of the multiFace, holding the
+// faces in the "multi_face_direction_bits" property.
+func (m multiFace) properties() map[string]any {
+	var bits int32
+	for i, attached := range [...]bool{m.Down, m.Up, m.South, m.West, m.North, m.East} {
+		if attached {
+			bits |= 1 << i
+		}
+	}
+	return map[string]any{"multi_face_direction_bits": bits}
+}
+
+// encodeNBT encodes the multiFace to NBT data, holding the faces in the
+// "multi_face_direction_bits" tag.
+func (m multiFace) encodeNBT() map[string]any {
+	var bits int32
+	for i, attached := range [...]bool{m.Down, m.Up, m.North, m.South, m.West, m.East} {
+		if attached {
+			bits |= 1 << i
+		}
+	}
+	return map[string]any{"multi_face_direction_bits": bits}
+}
+
+// decodeNBT returns the multiFace decoded from the NBT data passed, which
+// was encoded using encodeNBT.
+func (m multiFace) decodeNBT(data map[string]any) multiFace {
+	if bits, ok := data["multi_face_direction_bits"].(int32); ok {
+		m = multiFace{
+			Down:  bits&0x1 != 0,
+			Up:    bits&0x2 != 0,
+			North: bits&0x4 != 0,
+			South: bits&0x8 != 0,
+			West:  bits&0x10 != 0,
+			East:  bits&0x20 != 0,
+		}
+	}
+	return m
+}
+
+// multiFacePermutations returns the blocks returned by f for all 64
+// combinations of faces.
+func multiFacePermutations(f func(m multiFace) world.Block) []world.Block {
+	return bitmaskPermutations(6, func(mask int) world.Block {
+		return f(multiFace{
+			Down:  mask&1 != 0,
+			Up:    mask&2 != 0,
+			South: mask&4 != 0,
+			West:  mask&8 != 0,
+			North: mask&16 != 0,
+			East:  mask&32 != 0,
+		})
+	})
+}
+
+// multiFaceSupported checks if a multi-face block at the position passed could
+// be attached to the face passed, which requires the block on that side to
+// have a solid face towards it.
+func multiFaceSupported(pos cube.Pos, face cube.Face, tx *world.Tx) bool {
+	supportPos := pos.Side(face)
+	return tx.Block(supportPos).Model().FaceSolid(supportPos, face.Opposite(), tx)
+}
+
+// sameMultiFace checks if the multi-face blocks passed are of the same kind,
+// regardless of the faces they are attached to.
+func sameMultiFace(a, b multiFaceBlock) bool {
+	return a.withFaces(multiFace{}) == b.withFaces(multiFace{})
+}
+
+// multiFaceBreakInfo returns the BreakInfo of the multi-face block passed,
+// which drops an item for every face it is attached to if mined with silk
+// touch.
+func multiFaceBreakInfo(b multiFaceBlock) BreakInfo {
+	return newBreakInfo(0.2, alwaysHarvestable, hoeEffective, func(t item.Tool, enchantments []item.Enchantment) []item.Stack {
+		if hasSilkTouch(enchantments) {
+			return []item.Stack{item.NewStack(b.withFaces(multiFace{}).(world.Item), b.faces().count())}
+		}
+		return nil
+	})
+}
+
+// placeMultiFace places the multi-face block b when it is used on the face of
+// the block at the position passed. Using it on a block of the same kind adds
+// the face clicked to that block, or any other face of it that is supported if
+// the face clicked is already attached. Otherwise, b is placed against the
+// face clicked, or merged into a block of the same kind in that position.
+func placeMultiFace(b multiFaceBlock, pos cube.Pos, face cube.Face, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	if existing, ok := tx.Block(pos).(multiFaceBlock); ok && sameMultiFace(b, existing) {
+		if mergeMultiFace(pos, existing, face.Opposite(), tx, ctx) || mergeAnyMultiFace(pos, existing, tx, ctx) {
+			return true
+		}
+		// Every supported face of the block clicked is already attached, so
+		// the block is placed next to it instead.
+		pos = pos.Side(face)
+	}
+
+	pos, face, used := firstReplaceable(tx, pos, face, b)
+	if !used {
+		return false
+	}
+	if existing, ok := tx.Block(pos).(multiFaceBlock); ok && sameMultiFace(b, existing) {
+		return mergeMultiFace(pos, existing, face.Opposite(), tx, ctx) || mergeAnyMultiFace(pos, existing, tx, ctx)
+	}
+	if !multiFaceSupported(pos, face.Opposite(), tx) {
+		return false
+	}
+	place(tx, pos, b.withFaces(multiFace{}.withFace(face.Opposite(), true)), user, ctx)
+	return placed(ctx)
+}
+
+// mergeMultiFace attaches the existing multi-face block at the position passed
+// to the face passed, if it is not yet attached to it and the face is
+// supported.
+func mergeMultiFace(pos cube.Pos, existing multiFaceBlock, face cube.Face, tx *world.Tx, ctx *item.UseContext) bool {
+	m := existing.faces()
+	if m.hasFace(face) || !multiFaceSupported(pos, face, tx) {
+		return false
+	}
+	tx.SetBlock(pos, existing.withFaces(m.withFace(face, true)), nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.BlockPlace{Block: existing.withFaces(multiFace{})})
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// mergeAnyMultiFace attaches the existing multi-face block at the position
+// passed to the first face that it is not yet attached to and that is
+// supported.
+func mergeAnyMultiFace(pos cube.Pos, existing multiFaceBlock, tx *world.Tx, ctx *item.UseContext) bool {
+	for _, f := range cube.Faces() {
+		if mergeMultiFace(pos, existing, f, tx, ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateMultiFaceSupport detaches the multi-face block at the position passed
+// from all faces that are no longer supported. The block is broken if it is no
+// longer attached to any face.
+func updateMultiFaceSupport(b multiFaceBlock, pos cube.Pos, tx *world.Tx) {
+	m := b.faces()
+	for _, f := range cube.Faces() {
+		if m.hasFace(f) && !multiFaceSupported(pos, f, tx) {
+			m = m.withFace(f, false)
+		}
+	}
+	if m == b.faces() {
+		return
+	}
+	if m.count() == 0 {
+		breakBlock(b, pos, tx)
+		return
+	}
+	tx.SetBlock(pos, b.withFaces(m), nil)
+}
+
+// spreadMultiFace spreads the multi-face block at the position passed from a
+// random face it is attached to towards a random direction along that face.
+// Like in vanilla, the block first tries to spread to another face of the same
+// space, then to the same face of the neighbouring space and finally around
+// the corner of the block it is attached to.
+func spreadMultiFace(b multiFaceBlock, pos cube.Pos, tx *world.Tx) bool {
+	faces, m := cube.Faces(), b.faces()
+	for _, i := range rand.Perm(len(faces)) {
+		face := faces[i]
+		if !m.hasFace(face) {
+			continue
+		}
+		for _, j := range rand.Perm(len(faces)) {
+			dir := faces[j]
+			if dir.Axis() == face.Axis() {
+				continue
+			}
+			if spreadMultiFaceTo(b, pos, dir, tx) || spreadMultiFaceTo(b, pos.Side(dir), face, tx) || spreadMultiFaceTo(b, pos.Side(dir).Side(face), dir.Opposite(), tx) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// spreadMultiFaceTo attaches a multi-face block of the same kind as b to the
+// face passed at the position passed, either by placing a new block in air or
+// a water source, which it is then waterlogged by, or by merging the face into
+// an existing block of the same kind. It returns false if the space at the
+// position cannot hold the face.
+func spreadMultiFaceTo(b multiFaceBlock, pos cube.Pos, face cube.Face, tx *world.Tx) bool {
+	var m multiFace
+	switch existing := tx.Block(pos).(type) {
+	case Air:
+	case Water:
+		if !b.CanDisplace(existing) {
+			return false
+		}
+	case multiFaceBlock:
+		if !sameMultiFace(b, existing) || existing.faces().hasFace(face) {
+			return false
+		}
+		m = existing.faces()
+	default:
+		return false
+	}
+	if !multiFaceSupported(pos, face, tx) {
+		return false
+	}
+	tx.SetBlock(pos, b.withFaces(m.withFace(face, true)), nil)
+	return true
+}
@@ -19,10 +19,26 @@ type Note struct {
 	Pitch int
 }
 
+// NoteListener represents an entity that reacts to note blocks being played
+// near it, such as an allay.
+type NoteListener interface {
+	world.Entity
+	// NotePlayed is called when a note block within 16 blocks of the entity
+	// is played.
+	NotePlayed(pos cube.Pos, tx *world.Tx)
+}
+
 // playNote ...
 func (n Note) playNote(pos cube.Pos, tx *world.Tx) {
 	tx.PlaySound(pos.Vec3(), sound.Note{Instrument: n.instrument(pos, tx), Pitch: n.Pitch})
 	tx.AddParticle(pos.Vec3(), particle.Note{Instrument: n.Instrument(), Pitch: n.Pitch})
+
+	centre := pos.Vec3Centre()
+	for e := range tx.EntitiesWithin(cube.Box(-16, -16, -16, 16, 16, 16).Translate(centre)) {
+		if l, ok := e.(NoteListener); ok && e.Position().Sub(centre).Len() <= 16 {
+			l.NotePlayed(pos, tx)
+		}
+	}
 }
 
 // updateInstrument ...
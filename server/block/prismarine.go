@@ -18,6 +18,11 @@ func (p Prismarine) BreakInfo() BreakInfo {
 	return newBreakInfo(1.5, pickaxeHarvestable, pickaxeEffective, oneOf(p)).withBlastResistance(30)
 }
 
+// PowersConduit ...
+func (Prismarine) PowersConduit() bool {
+	return true
+}
+
 // EncodeItem ...
 func (p Prismarine) EncodeItem() (id string, meta int16) {
 	return "minecraft:" + p.Type.String(), 0
@@ -0,0 +1,79 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Rail is a block that minecarts may ride on. Rails are placed straight along the direction that the placer is
+// facing and must be supported by a solid block below.
+type Rail struct {
+	carpet
+	transparent
+
+	// Axis is the axis along which the rail runs. Rails with an axis other than cube.X run along cube.Z.
+	Axis cube.Axis
+}
+
+// UseOnBlock ...
+func (r Rail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(tx, pos, face, r)
+	if !used {
+		return
+	}
+	below := pos.Side(cube.FaceDown)
+	if !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+		return
+	}
+	r.Axis = user.Rotation().Direction().Face().Axis()
+	place(tx, pos, r, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r Rail) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	below := pos.Side(cube.FaceDown)
+	if !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+		breakBlock(r, pos, tx)
+	}
+}
+
+// SideClosed ...
+func (Rail) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// HasLiquidDrops ...
+func (Rail) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (r Rail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(Rail{}))
+}
+
+// EncodeItem ...
+func (Rail) EncodeItem() (name string, meta int16) {
+	return "minecraft:rail", 0
+}
+
+// eastWest checks if the rail runs from east to west, which is the case if its axis is cube.X.
+func (r Rail) eastWest() bool {
+	return r.Axis == cube.X
+}
+
+// EncodeBlock ...
+func (r Rail) EncodeBlock() (string, map[string]any) {
+	if r.eastWest() {
+		return "minecraft:rail", map[string]any{"rail_direction": int32(1)}
+	}
+	return "minecraft:rail", map[string]any{"rail_direction": int32(0)}
+}
+
+// allRails ...
+func allRails() []world.Block {
+	return []world.Block{Rail{Axis: cube.Z}, Rail{Axis: cube.X}}
+}
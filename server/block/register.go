@@ -24,14 +24,17 @@ func init() {
 	world.RegisterBlock(Bookshelf{})
 	world.RegisterBlock(Bricks{})
 	world.RegisterBlock(BrownMushroom{})
+	world.RegisterBlock(BuddingAmethyst{})
 	world.RegisterBlock(Bush{})
 	world.RegisterBlock(Calcite{})
+	world.RegisterBlock(CartographyTable{})
 	world.RegisterBlock(Clay{})
 	world.RegisterBlock(Coal{})
 	world.RegisterBlock(Web{})
 	world.RegisterBlock(MobSpawner{})
 	world.RegisterBlock(Cobblestone{Mossy: true})
 	world.RegisterBlock(Cobblestone{})
+	world.RegisterBlock(Conduit{})
 	world.RegisterBlock(CraftingTable{})
 	world.RegisterBlock(DeadBush{})
 	world.RegisterBlock(DeepslateBricks{Cracked: true})
@@ -54,6 +57,7 @@ func init() {
 	world.RegisterBlock(EndStone{})
 	world.RegisterBlock(FireflyBush{})
 	world.RegisterBlock(FletchingTable{})
+	world.RegisterBlock(Frogspawn{})
 	world.RegisterBlock(GlassPane{})
 	world.RegisterBlock(Glass{})
 	world.RegisterBlock(Glowstone{})
@@ -62,6 +66,8 @@ func init() {
 	world.RegisterBlock(Granite{})
 	world.RegisterBlock(Grass{})
 	world.RegisterBlock(Gravel{})
+	world.RegisterBlock(HeavyCore{})
+	world.RegisterBlock(Honey{})
 	world.RegisterBlock(Honeycomb{})
 	world.RegisterBlock(InvisibleBedrock{})
 	world.RegisterBlock(IronBars{})
@@ -84,6 +90,7 @@ func init() {
 	world.RegisterBlock(Note{})
 	world.RegisterBlock(Obsidian{Crying: true})
 	world.RegisterBlock(Obsidian{})
+	world.RegisterBlock(Ice{})
 	world.RegisterBlock(PackedIce{})
 	world.RegisterBlock(PackedMud{})
 	world.RegisterBlock(Podzol{})
@@ -100,6 +107,7 @@ func init() {
 	world.RegisterBlock(ResinBricks{Chiseled: true})
 	world.RegisterBlock(ResinBricks{})
 	world.RegisterBlock(Resin{})
+	world.RegisterBlock(RootedDirt{})
 	world.RegisterBlock(Sand{Red: true})
 	world.RegisterBlock(Sand{})
 	world.RegisterBlock(Sculk{})
@@ -139,21 +147,26 @@ func init() {
 		world.RegisterBlock(RedstoneOre{Type: ore})
 	}
 
+	registerAll(allAmethystClusters())
 	registerAll(allAnvils())
+	registerAll(allAzaleaLeaves())
 	registerAll(allBanners())
 	registerAll(allBarrels())
 	registerAll(allBasalt())
 	registerAll(allBeds())
 	registerAll(allBeetroot())
+	registerAll(allBells())
 	registerAll(allBlackstone())
 	registerAll(allBlastFurnaces())
 	registerAll(allBoneBlock())
 	registerAll(allBrewingStands())
 	registerAll(allCactus())
 	registerAll(allCake())
+	registerAll(allCalibratedSculkSensors())
 	registerAll(allCampfires())
 	registerAll(allCarpet())
 	registerAll(allCarrots())
+	registerAll(allCaveVines())
 	registerAll(allIronChains())
 	registerAll(allChests())
 	registerAll(allCocoaBeans())
@@ -163,9 +176,11 @@ func init() {
 	registerAll(allCoral())
 	registerAll(allCoralBlocks())
 	registerAll(allDeepslate())
+	registerAll(allDispensers())
 	registerAll(allDoors())
 	registerAll(allDoubleFlowers())
 	registerAll(allDoubleTallGrass())
+	registerAll(allEndPortalFrames())
 	registerAll(allEndRods())
 	registerAll(allEnderChests())
 	registerAll(allFarmland())
@@ -174,6 +189,7 @@ func init() {
 	registerAll(allFire())
 	registerAll(allFlowers())
 	registerAll(allFroglight())
+	registerAll(allFrostedIce())
 	registerAll(allFurnaces())
 	registerAll(allGlazedTerracotta())
 	registerAll(allGrindstones())
@@ -187,7 +203,9 @@ func init() {
 	registerAll(allLeafLitter())
 	registerAll(allLeaves())
 	registerAll(allLecterns())
+	registerAll(allLevers())
 	registerAll(allLight())
+	registerAll(allLightningRods())
 	registerAll(allLitPumpkins())
 	registerAll(allLogs())
 	registerAll(allLooms())
@@ -203,22 +221,31 @@ func init() {
 	registerAll(allPumpkins())
 	registerAll(allPurpurs())
 	registerAll(allQuartz())
+	registerAll(allRails())
 	registerAll(allSandstones())
+	registerAll(allSculkCatalysts())
+	registerAll(allSculkSensors())
+	registerAll(allSculkShriekers())
 	registerAll(allSculkVeins())
 	registerAll(allSeaPickles())
 	registerAll(allSigns())
 	registerAll(allSkulls())
 	registerAll(allSlabs())
 	registerAll(allSmokers())
+	registerAll(allSnifferEggs())
 	registerAll(allStainedGlass())
 	registerAll(allStainedGlassPane())
 	registerAll(allStainedTerracotta())
 	registerAll(allStairs())
 	registerAll(allStoneBricks())
 	registerAll(allStonecutters())
+	registerAll(allStructureBlocks())
 	registerAll(allSugarCane())
+	registerAll(allSuspiciousGravel())
+	registerAll(allSuspiciousSand())
 	registerAll(allTorches())
 	registerAll(allTrapdoors())
+	registerAll(allTrialSpawners())
 	registerAll(allVines())
 	registerAll(allWalls())
 	registerAll(allWater())
@@ -257,6 +284,7 @@ func init() {
 	world.RegisterItem(Basalt{})
 	world.RegisterItem(Beacon{})
 	world.RegisterItem(Bedrock{})
+	world.RegisterItem(Bell{})
 	world.RegisterItem(Deny{})
 	world.RegisterItem(BeetrootSeeds{})
 	world.RegisterItem(BlastFurnace{})
@@ -267,10 +295,12 @@ func init() {
 	world.RegisterItem(BrewingStand{})
 	world.RegisterItem(Bricks{})
 	world.RegisterItem(BrownMushroom{})
+	world.RegisterItem(BuddingAmethyst{})
 	world.RegisterItem(Bush{})
 	world.RegisterItem(Cactus{})
 	world.RegisterItem(Cake{})
 	world.RegisterItem(Calcite{})
+	world.RegisterItem(CalibratedSculkSensor{})
 	world.RegisterItem(Carrot{})
 	world.RegisterItem(IronChain{})
 	world.RegisterItem(Chest{})
@@ -282,7 +312,10 @@ func init() {
 	world.RegisterItem(Cobblestone{})
 	world.RegisterItem(CocoaBean{})
 	world.RegisterItem(Composter{})
+	world.RegisterItem(Conduit{})
 	world.RegisterItem(CopperTorch{})
+	world.RegisterItem(CartographyTable{})
+	world.RegisterItem(CaveVines{})
 	world.RegisterItem(CraftingTable{})
 	world.RegisterItem(DeadBush{})
 	world.RegisterItem(BeeNest{})
@@ -296,18 +329,21 @@ func init() {
 	world.RegisterItem(DirtPath{})
 	world.RegisterItem(Dirt{Coarse: true})
 	world.RegisterItem(Dirt{})
+	world.RegisterItem(Dispenser{})
 	world.RegisterItem(DragonEgg{})
 	world.RegisterItem(DriedKelp{})
 	world.RegisterItem(Dripstone{})
 	world.RegisterItem(Emerald{})
 	world.RegisterItem(EnchantingTable{})
 	world.RegisterItem(EndBricks{})
+	world.RegisterItem(EndPortalFrame{})
 	world.RegisterItem(EndRod{})
 	world.RegisterItem(EndStone{})
 	world.RegisterItem(EnderChest{})
 	world.RegisterItem(Farmland{})
 	world.RegisterItem(FireflyBush{})
 	world.RegisterItem(FletchingTable{})
+	world.RegisterItem(Frogspawn{})
 	world.RegisterItem(Furnace{})
 	world.RegisterItem(GlassPane{})
 	world.RegisterItem(Glass{})
@@ -320,6 +356,8 @@ func init() {
 	world.RegisterItem(Gravel{})
 	world.RegisterItem(Grindstone{})
 	world.RegisterItem(HayBale{})
+	world.RegisterItem(HeavyCore{})
+	world.RegisterItem(Honey{})
 	world.RegisterItem(Honeycomb{})
 	world.RegisterItem(Hopper{})
 	world.RegisterItem(InvisibleBedrock{})
@@ -333,6 +371,8 @@ func init() {
 	world.RegisterItem(Lapis{})
 	world.RegisterItem(LeafLitter{})
 	world.RegisterItem(Lectern{})
+	world.RegisterItem(Lever{})
+	world.RegisterItem(LightningRod{})
 	world.RegisterItem(LilyPad{})
 	world.RegisterItem(LitPumpkin{})
 	world.RegisterItem(Loom{})
@@ -354,6 +394,7 @@ func init() {
 	world.RegisterItem(Note{Pitch: 24})
 	world.RegisterItem(Obsidian{Crying: true})
 	world.RegisterItem(Obsidian{})
+	world.RegisterItem(Ice{})
 	world.RegisterItem(PackedIce{})
 	world.RegisterItem(PackedMud{})
 	world.RegisterItem(PinkPetals{})
@@ -370,6 +411,7 @@ func init() {
 	world.RegisterItem(QuartzPillar{})
 	world.RegisterItem(Quartz{Smooth: true})
 	world.RegisterItem(Quartz{})
+	world.RegisterItem(Rail{})
 	world.RegisterItem(RawCopper{})
 	world.RegisterItem(RawGold{})
 	world.RegisterItem(RawIron{})
@@ -379,10 +421,14 @@ func init() {
 	world.RegisterItem(ResinBricks{Chiseled: true})
 	world.RegisterItem(ResinBricks{})
 	world.RegisterItem(Resin{})
+	world.RegisterItem(RootedDirt{})
 	world.RegisterItem(Sand{Red: true})
 	world.RegisterItem(Sand{})
 	world.RegisterItem(SmoothBasalt{})
 	world.RegisterItem(Sculk{})
+	world.RegisterItem(SculkCatalyst{})
+	world.RegisterItem(SculkSensor{})
+	world.RegisterItem(SculkShrieker{})
 	world.RegisterItem(SculkVein{})
 	world.RegisterItem(SeaLantern{})
 	world.RegisterItem(ShortDryGrass{})
@@ -390,6 +436,7 @@ func init() {
 	world.RegisterItem(Shroomlight{})
 	world.RegisterItem(SmithingTable{})
 	world.RegisterItem(Smoker{})
+	world.RegisterItem(SnifferEgg{})
 	world.RegisterItem(Snow{})
 	world.RegisterItem(SoulSand{})
 	world.RegisterItem(SoulSoil{})
@@ -397,12 +444,16 @@ func init() {
 	world.RegisterItem(Sponge{})
 	world.RegisterItem(SporeBlossom{})
 	world.RegisterItem(Stonecutter{})
+	world.RegisterItem(StructureBlock{})
 	world.RegisterItem(Stone{Smooth: true})
 	world.RegisterItem(Stone{})
 	world.RegisterItem(SugarCane{})
+	world.RegisterItem(SuspiciousGravel{})
+	world.RegisterItem(SuspiciousSand{})
 	world.RegisterItem(TallDryGrass{})
 	world.RegisterItem(TNT{})
 	world.RegisterItem(Terracotta{})
+	world.RegisterItem(TrialSpawner{})
 	world.RegisterItem(Tuff{})
 	world.RegisterItem(Tuff{Chiseled: true})
 	world.RegisterItem(TuffBricks{})
@@ -415,6 +466,7 @@ func init() {
 	world.RegisterItem(ShortGrass{})
 	world.RegisterItem(Fern{})
 	world.RegisterItem(MossBlock{})
+	world.RegisterItem(item.Bucket{Content: item.EntityBucketContent(Water{}, "minecraft:axolotl", nil)})
 	world.RegisterItem(item.Bucket{Content: item.LiquidBucketContent(Lava{})})
 	world.RegisterItem(item.Bucket{Content: item.LiquidBucketContent(Water{})})
 	world.RegisterItem(item.Bucket{Content: item.MilkBucketContent()})
@@ -438,6 +490,11 @@ func init() {
 	for _, t := range AnvilTypes() {
 		world.RegisterItem(Anvil{Type: t})
 	}
+	world.RegisterItem(AzaleaLeaves{Persistent: true})
+	world.RegisterItem(AzaleaLeaves{Flowering: true, Persistent: true})
+	for _, g := range AmethystGrowths() {
+		world.RegisterItem(AmethystCluster{Growth: g})
+	}
 	for _, c := range item.Colours() {
 		world.RegisterItem(Banner{Colour: c})
 		world.RegisterItem(Bed{Colour: c})
@@ -544,3 +601,40 @@ func registerAll(blocks []world.Block) {
 		world.RegisterBlock(b)
 	}
 }
+
+// permutations returns the blocks returned by f for every combination of the
+// values of a number of states, where the number of values of every state is
+// passed in sizes. The values passed to f hold a value in the range
+// [0, sizes[i]) for every state i. The slice passed to f is reused between
+// calls and must not be retained.
+func permutations(f func(values []int) world.Block, sizes ...int) []world.Block {
+	total := 1
+	for _, size := range sizes {
+		total *= size
+	}
+	blocks := make([]world.Block, 0, total)
+	values := make([]int, len(sizes))
+	for range total {
+		blocks = append(blocks, f(values))
+		// Advance to the next combination, with the first state changing
+		// most often.
+		for i := range values {
+			if values[i]++; values[i] < sizes[i] {
+				break
+			}
+			values[i] = 0
+		}
+	}
+	return blocks
+}
+
+// bitmaskPermutations returns the blocks returned by f for every value of a
+// bitmask with the number of bits passed, such as the faces of a multi-face
+// block like glow lichen.
+func bitmaskPermutations(bits int, f func(mask int) world.Block) []world.Block {
+	blocks := make([]world.Block, 0, 1<<bits)
+	for mask := range 1 << bits {
+		blocks = append(blocks, f(mask))
+	}
+	return blocks
+}
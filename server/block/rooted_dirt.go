@@ -0,0 +1,51 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// RootedDirt is a variant of dirt found below azalea trees, from which hanging
+// roots grow into the lush caves below.
+type RootedDirt struct {
+	solid
+}
+
+// SoilFor ...
+func (RootedDirt) SoilFor(block world.Block) bool {
+	switch block.(type) {
+	case ShortGrass, Fern, DoubleTallGrass, DeadBush, Flower, DoubleFlower, PinkPetals, SugarCane:
+		return true
+	}
+	return false
+}
+
+// BoneMeal grows hanging roots below the rooted dirt.
+func (RootedDirt) BoneMeal(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	if _, ok := tx.Block(below).(Air); !ok {
+		return false
+	}
+	tx.SetBlock(below, HangingRoots{}, nil)
+	return true
+}
+
+// Till ...
+func (RootedDirt) Till() (world.Block, bool) {
+	return Dirt{}, true
+}
+
+// BreakInfo ...
+func (r RootedDirt) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, shovelEffective, oneOf(r))
+}
+
+// EncodeItem ...
+func (RootedDirt) EncodeItem() (name string, meta int16) {
+	return "minecraft:dirt_with_roots", 0
+}
+
+// EncodeBlock ...
+func (RootedDirt) EncodeBlock() (string, map[string]any) {
+	return "minecraft:dirt_with_roots", nil
+}
@@ -0,0 +1,109 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// sculkCatalystRange is the distance in blocks within which a sculk catalyst
+// absorbs the experience of mobs that die.
+const sculkCatalystRange = 8
+
+// SculkCatalyst is a block found in the deep dark. When a mob dies near it,
+// the catalyst blooms and spreads sculk around the place of death, using up the
+// experience that the mob would have dropped.
+type SculkCatalyst struct {
+	solid
+
+	// Bloom specifies if the catalyst is blooming, which it does for a short
+	// time after absorbing experience.
+	Bloom bool
+}
+
+// AbsorbDeathExperience makes the sculk catalyst closest to the position
+// passed, if any is within 8 blocks, absorb the experience dropped by a mob
+// that died at that position. The catalyst blooms and spreads sculk with a
+// charge equal to the amount of experience. AbsorbDeathExperience returns
+// false if no catalyst was found, in which case the experience should be
+// dropped as usual.
+func AbsorbDeathExperience(tx *world.Tx, pos mgl64.Vec3, xp int) bool {
+	if xp <= 0 {
+		return false
+	}
+	catalystPos, c, ok := nearestSculkCatalyst(tx, pos)
+	if !ok {
+		return false
+	}
+	c.Bloom = true
+	tx.SetBlock(catalystPos, c, nil)
+	tx.ScheduleBlockUpdate(catalystPos, c, time.Second*2)
+	tx.PlaySound(catalystPos.Vec3Centre(), sound.SculkCatalystBloom{})
+
+	spreadSculk(tx, cube.PosFromVec3(pos), catalystPos, xp, rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())))
+	return true
+}
+
+// nearestSculkCatalyst returns the sculk catalyst closest to the position
+// passed within sculkCatalystRange.
+func nearestSculkCatalyst(tx *world.Tx, pos mgl64.Vec3) (cube.Pos, SculkCatalyst, bool) {
+	var (
+		found   bool
+		nearest cube.Pos
+		c       SculkCatalyst
+		dist    = float64(sculkCatalystRange)
+	)
+	centre := cube.PosFromVec3(pos)
+	for x := -sculkCatalystRange; x <= sculkCatalystRange; x++ {
+		for y := -sculkCatalystRange; y <= sculkCatalystRange; y++ {
+			for z := -sculkCatalystRange; z <= sculkCatalystRange; z++ {
+				p := centre.Add(cube.Pos{x, y, z})
+				catalyst, ok := tx.Block(p).(SculkCatalyst)
+				if !ok {
+					continue
+				}
+				if d := p.Vec3Centre().Sub(pos).Len(); d <= dist {
+					found, nearest, c, dist = true, p, catalyst, d
+				}
+			}
+		}
+	}
+	return nearest, c, found
+}
+
+// ScheduledTick stops the catalyst from blooming.
+func (c SculkCatalyst) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if c.Bloom {
+		c.Bloom = false
+		tx.SetBlock(pos, c, nil)
+	}
+}
+
+// LightEmissionLevel ...
+func (SculkCatalyst) LightEmissionLevel() uint8 {
+	return 6
+}
+
+// BreakInfo ...
+func (c SculkCatalyst) BreakInfo() BreakInfo {
+	return newBreakInfo(3, alwaysHarvestable, hoeEffective, silkTouchOnlyDrop(SculkCatalyst{})).withXPDropRange(5, 5)
+}
+
+// EncodeItem ...
+func (SculkCatalyst) EncodeItem() (name string, meta int16) {
+	return "minecraft:sculk_catalyst", 0
+}
+
+// EncodeBlock ...
+func (c SculkCatalyst) EncodeBlock() (string, map[string]any) {
+	return "minecraft:sculk_catalyst", map[string]any{"bloom": boolByte(c.Bloom)}
+}
+
+// allSculkCatalysts returns all states of the sculk catalyst.
+func allSculkCatalysts() (b []world.Block) {
+	return []world.Block{SculkCatalyst{}, SculkCatalyst{Bloom: true}}
+}
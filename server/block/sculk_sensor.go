@@ -0,0 +1,196 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// SculkSensor is a block that detects vibrations, such as blocks being placed
+// or broken, within 8 blocks of it. Vibrations caused by players make the
+// sensor activate sculk shriekers nearby. Because redstone is not
+// implemented, the redstone signal output by the sensor is only available
+// through its RedstonePower and ComparatorSignal methods.
+type SculkSensor struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Phase is the phase of the sensor. 0 is inactive, in which the sensor
+	// detects vibrations, 1 is active, right after detecting a vibration, and
+	// 2 is cooldown, after which the sensor becomes inactive again.
+	Phase int
+	// Power is the redstone power, from 1-15, that the sensor outputs while
+	// active. The closer the vibration detected, the higher the power.
+	Power int
+	// LastFrequency is the frequency of the last vibration detected by the
+	// sensor, from 1-15, or 0 if it never detected a vibration.
+	LastFrequency int
+}
+
+// VibrationRange ...
+func (SculkSensor) VibrationRange() int {
+	return 8
+}
+
+// ListenVibration activates the sensor if it is inactive.
+func (s SculkSensor) ListenVibration(pos cube.Pos, tx *world.Tx, v world.Vibration) {
+	if s.Phase != 0 || cube.PosFromVec3(v.Pos) == pos {
+		return
+	}
+	s.Phase, s.LastFrequency = 1, v.Type.Frequency()
+	s.Power = sculkSensorPower(pos, v, s.VibrationRange())
+	activateSculkSensor(pos, s, time.Second*3/2, tx, v.Source)
+}
+
+// ScheduledTick moves the sensor from the active phase to the cooldown phase
+// and from the cooldown phase back to the inactive phase.
+func (s SculkSensor) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	switch s.Phase {
+	case 1:
+		s.Phase = 2
+		cooldownSculkSensor(pos, s, tx)
+	case 2:
+		s.Phase = 0
+		tx.SetBlock(pos, s, &world.SetOpts{DisableBlockUpdates: true, DisableLiquidDisplacement: true})
+	}
+}
+
+// EntityInside activates the sensor when an entity steps on it, unless the
+// entity is sneaking.
+func (s SculkSensor) EntityInside(pos cube.Pos, tx *world.Tx, e world.Entity) {
+	if s.Phase != 0 || sneaking(e) {
+		return
+	}
+	s.Phase, s.Power, s.LastFrequency = 1, 15, world.VibrationStep.Frequency()
+	activateSculkSensor(pos, s, time.Second*3/2, tx, e)
+}
+
+// RedstonePower returns the redstone power output by the sensor, which is
+// Power while the sensor is active and 0 otherwise.
+func (s SculkSensor) RedstonePower() int {
+	if s.Phase != 1 {
+		return 0
+	}
+	return s.Power
+}
+
+// ComparatorSignal returns the signal that a comparator reads from the
+// sensor, which is the frequency of the last vibration it detected.
+func (s SculkSensor) ComparatorSignal() int {
+	return s.LastFrequency
+}
+
+// UseOnBlock ...
+func (s SculkSensor) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, s)
+	if !used {
+		return false
+	}
+	place(tx, pos, SculkSensor{}, user, ctx)
+	return placed(ctx)
+}
+
+// Model ...
+func (SculkSensor) Model() world.BlockModel {
+	return model.Slab{}
+}
+
+// SideClosed ...
+func (SculkSensor) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// LightEmissionLevel ...
+func (s SculkSensor) LightEmissionLevel() uint8 {
+	if s.Phase == 1 {
+		return 1
+	}
+	return 0
+}
+
+// BreakInfo ...
+func (s SculkSensor) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, hoeEffective, oneOf(SculkSensor{})).withXPDropRange(5, 5)
+}
+
+// EncodeItem ...
+func (SculkSensor) EncodeItem() (name string, meta int16) {
+	return "minecraft:sculk_sensor", 0
+}
+
+// EncodeBlock ...
+func (s SculkSensor) EncodeBlock() (string, map[string]any) {
+	return "minecraft:sculk_sensor", map[string]any{"sculk_sensor_phase": int32(s.Phase)}
+}
+
+// EncodeNBT ...
+func (s SculkSensor) EncodeNBT() map[string]any {
+	return map[string]any{"id": "SculkSensor", "Power": int32(s.Power), "LastFrequency": int32(s.LastFrequency)}
+}
+
+// DecodeNBT ...
+func (s SculkSensor) DecodeNBT(data map[string]any) any {
+	s.Power, s.LastFrequency = int(nbtconv.Int32(data, "Power")), int(nbtconv.Int32(data, "LastFrequency"))
+	return s
+}
+
+// allSculkSensors returns all states of the sculk sensor.
+func allSculkSensors() (b []world.Block) {
+	for phase := 0; phase <= 2; phase++ {
+		b = append(b, SculkSensor{Phase: phase})
+	}
+	return
+}
+
+// sculkSensorPower returns the redstone power output by a sculk sensor at the
+// position passed with the range passed after detecting the vibration passed.
+// The power decreases linearly from 15 to 1 with the distance.
+func sculkSensorPower(pos cube.Pos, v world.Vibration, r int) int {
+	return max(1, 15-int(pos.Vec3Centre().Sub(v.Pos).Len()/float64(r)*15))
+}
+
+// activateSculkSensor sets the active sculk sensor b at the position passed
+// and schedules it to enter its cooldown phase after the duration passed. If
+// the entity that caused the vibration is a player, sculk shriekers within 8
+// blocks shriek.
+func activateSculkSensor(pos cube.Pos, b world.Block, active time.Duration, tx *world.Tx, source world.Entity) {
+	tx.SetBlock(pos, b, &world.SetOpts{DisableBlockUpdates: true, DisableLiquidDisplacement: true})
+	tx.ScheduleBlockUpdate(pos, b, active)
+	tx.PlaySound(pos.Vec3Centre(), sound.SculkSensorActivate{})
+
+	if _, ok := source.(wardenWarnable); !ok {
+		return
+	}
+	for x := -8; x <= 8; x++ {
+		for y := -8; y <= 8; y++ {
+			for z := -8; z <= 8; z++ {
+				p := pos.Add(cube.Pos{x, y, z})
+				if shrieker, ok := tx.Block(p).(SculkShrieker); ok {
+					shrieker.Shriek(p, tx, source)
+				}
+			}
+		}
+	}
+}
+
+// cooldownSculkSensor sets the sculk sensor b at the position passed, which
+// entered its cooldown phase, and schedules it to become inactive again after
+// half a second.
+func cooldownSculkSensor(pos cube.Pos, b world.Block, tx *world.Tx) {
+	tx.SetBlock(pos, b, &world.SetOpts{DisableBlockUpdates: true, DisableLiquidDisplacement: true})
+	tx.ScheduleBlockUpdate(pos, b, time.Second/2)
+	tx.PlaySound(pos.Vec3Centre(), sound.SculkSensorDeactivate{})
+}
+
+// sneaking checks if the entity passed is sneaking.
+func sneaking(e world.Entity) bool {
+	s, ok := e.(interface{ Sneaking() bool })
+	return ok && s.Sneaking()
+}
@@ -0,0 +1,218 @@
+package block
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxWardenWarningLevel is the warning level at which a sculk shrieker
+	// that can summon wardens summons one.
+	maxWardenWarningLevel = 4
+	// wardenWarningCooldown is the minimum time between two shrieks that
+	// increase the warning level of a player.
+	wardenWarningCooldown = time.Second * 10
+	// wardenWarningDecay is the time after which the warning level of a
+	// player decreases by one if it was not increased.
+	wardenWarningDecay = time.Minute * 10
+)
+
+// SculkShrieker is a block that shrieks when a player steps on it or when a
+// nearby sculk sensor detects a vibration caused by a player. Every shriek
+// increases the warning level of the player. Shriekers that can summon
+// wardens, such as those found in ancient cities, darken the vision of nearby
+// players and summon a warden once the warning level reaches 4.
+type SculkShrieker struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Active specifies if the shrieker is currently shrieking.
+	Active bool
+	// CanSummon specifies if the shrieker can summon wardens. Shriekers placed
+	// by players or grown by sculk catalysts cannot.
+	CanSummon bool
+}
+
+// WardenSummoner summons a warden near the sculk shrieker at the position
+// passed, targeting the entity passed. It returns true if a warden was
+// summoned.
+type WardenSummoner func(tx *world.Tx, pos cube.Pos, target world.Entity) bool
+
+var (
+	wardenSummonerMu sync.RWMutex
+	wardenSummoner   WardenSummoner
+
+	wardenWarningsMu sync.Mutex
+	wardenWarnings   = map[uuid.UUID]wardenWarning{}
+)
+
+// SetWardenSummoner sets the WardenSummoner that sculk shriekers use to summon
+// a warden once the warning level of a player reaches its maximum. Because
+// wardens are not implemented by default, no warden is summoned unless a
+// summoner is set. Passing nil removes the summoner.
+func SetWardenSummoner(s WardenSummoner) {
+	wardenSummonerMu.Lock()
+	defer wardenSummonerMu.Unlock()
+	wardenSummoner = s
+}
+
+// wardenWarnable is an entity that sculk shriekers warn, which is only the
+// case for players.
+type wardenWarnable interface {
+	world.Entity
+	UUID() uuid.UUID
+}
+
+// darknessAffected is a player that sculk shriekers that can summon wardens
+// give Darkness when they shriek.
+type darknessAffected interface {
+	wardenWarnable
+	AddEffect(e effect.Effect)
+}
+
+// wardenWarning holds the warning level of a player and the time at which it
+// was last increased.
+type wardenWarning struct {
+	level int
+	last  time.Time
+}
+
+// decayed returns the wardenWarning with its level decreased for the time
+// passed since it was last increased.
+func (w wardenWarning) decayed(now time.Time) wardenWarning {
+	w.level = max(0, w.level-int(now.Sub(w.last)/wardenWarningDecay))
+	return w
+}
+
+// WardenWarningLevel returns the warning level, from 0-4, of the player with
+// the UUID passed.
+func WardenWarningLevel(id uuid.UUID) int {
+	wardenWarningsMu.Lock()
+	defer wardenWarningsMu.Unlock()
+	return wardenWarnings[id].decayed(time.Now()).level
+}
+
+// increaseWardenWarning increases the warning level of the player with the
+// UUID passed and returns the new level. False is returned if the level was
+// increased less than 10 seconds ago.
+func increaseWardenWarning(id uuid.UUID) (int, bool) {
+	wardenWarningsMu.Lock()
+	defer wardenWarningsMu.Unlock()
+
+	now := time.Now()
+	w, ok := wardenWarnings[id]
+	if ok && now.Sub(w.last) < wardenWarningCooldown {
+		return 0, false
+	}
+	w = w.decayed(now)
+	w.level, w.last = min(w.level+1, maxWardenWarningLevel), now
+	wardenWarnings[id] = w
+	return w.level, true
+}
+
+// Shriek makes the sculk shrieker at the position passed shriek in response to
+// the player passed. Nothing happens if the shrieker is already active, if
+// the entity is not a player or if the player was warned less than 10 seconds
+// ago. Shriek returns true if the shrieker shrieked.
+func (s SculkShrieker) Shriek(pos cube.Pos, tx *world.Tx, e world.Entity) bool {
+	target, ok := e.(wardenWarnable)
+	if s.Active || !ok {
+		return false
+	}
+	level, ok := increaseWardenWarning(target.UUID())
+	if !ok {
+		return false
+	}
+	s.Active = true
+	tx.SetBlock(pos, s, &world.SetOpts{DisableBlockUpdates: true, DisableLiquidDisplacement: true})
+	tx.ScheduleBlockUpdate(pos, s, time.Second*9/2)
+	tx.PlaySound(pos.Vec3Centre(), sound.SculkShriek{})
+	if !s.CanSummon {
+		return true
+	}
+
+	centre := pos.Vec3Centre()
+	for e := range tx.EntitiesWithin(cube.Box(-40, -40, -40, 40, 40, 40).Translate(centre)) {
+		if l, ok := e.(darknessAffected); ok && e.Position().Sub(centre).Len() <= 40 {
+			l.AddEffect(effect.New(effect.Darkness, 1, time.Second*12))
+		}
+	}
+	if level >= maxWardenWarningLevel {
+		wardenSummonerMu.RLock()
+		summon := wardenSummoner
+		wardenSummonerMu.RUnlock()
+		if summon != nil {
+			summon(tx, pos, target)
+		}
+	}
+	return true
+}
+
+// ScheduledTick stops the shrieker from shrieking.
+func (s SculkShrieker) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if s.Active {
+		s.Active = false
+		tx.SetBlock(pos, s, &world.SetOpts{DisableBlockUpdates: true, DisableLiquidDisplacement: true})
+	}
+}
+
+// EntityInside makes the shrieker shriek when a player steps on it, unless the
+// player is sneaking.
+func (s SculkShrieker) EntityInside(pos cube.Pos, tx *world.Tx, e world.Entity) {
+	if !sneaking(e) {
+		s.Shriek(pos, tx, e)
+	}
+}
+
+// UseOnBlock ...
+func (s SculkShrieker) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, s)
+	if !used {
+		return false
+	}
+	place(tx, pos, SculkShrieker{}, user, ctx)
+	return placed(ctx)
+}
+
+// Model ...
+func (SculkShrieker) Model() world.BlockModel {
+	return model.Slab{}
+}
+
+// SideClosed ...
+func (SculkShrieker) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// BreakInfo ...
+func (s SculkShrieker) BreakInfo() BreakInfo {
+	return newBreakInfo(3, alwaysHarvestable, hoeEffective, oneOf(SculkShrieker{})).withXPDropRange(5, 5)
+}
+
+// EncodeItem ...
+func (SculkShrieker) EncodeItem() (name string, meta int16) {
+	return "minecraft:sculk_shrieker", 0
+}
+
+// EncodeBlock ...
+func (s SculkShrieker) EncodeBlock() (string, map[string]any) {
+	return "minecraft:sculk_shrieker", map[string]any{"active": boolByte(s.Active), "can_summon": boolByte(s.CanSummon)}
+}
+
+// allSculkShriekers returns all states of the sculk shrieker.
+func allSculkShriekers() (b []world.Block) {
+	for _, active := range []bool{false, true} {
+		b = append(b, SculkShrieker{Active: active}, SculkShrieker{Active: active, CanSummon: true})
+	}
+	return
+}
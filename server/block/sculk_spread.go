@@ -0,0 +1,178 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+const (
+	// sculkGrowthCost is the charge used up by growing a sculk sensor or
+	// shrieker while spreading sculk.
+	sculkGrowthCost = 10
+	// sculkGrowthDistance is the minimum distance from the catalyst at which
+	// sculk sensors and shriekers may grow.
+	sculkGrowthDistance = 4
+)
+
+// spreadSculk spreads sculk from the position passed, where a mob died, using
+// up the charge passed. A cursor moves over the surface of the blocks around
+// the position, turning every block it passes that sculk may replace into
+// sculk at the cost of one charge. Sculk veins grow on the blocks next to the
+// sculk and, with enough charge left, sculk sensors and shriekers may grow on
+// top of it at some distance from the catalyst that the charge came from.
+func spreadSculk(tx *world.Tx, pos, catalyst cube.Pos, charge int, r *rand.Rand) {
+	cursor, ok := sculkCursorStart(tx, pos)
+	for steps := charge * 4; ok && charge > 0 && steps > 0; steps-- {
+		if sculkReplaceable(tx.Block(cursor)) {
+			convertToSculk(cursor, tx)
+			charge--
+		}
+		if charge >= sculkGrowthCost && r.IntN(10) == 0 && growSculk(cursor, catalyst, tx, r) {
+			charge -= sculkGrowthCost
+		}
+		cursor, ok = nextSculkCursor(tx, cursor, r)
+	}
+}
+
+// sculkCursorStart returns the position from which sculk starts spreading for
+// a mob that died at the position passed, which is the first block below it
+// that is either sculk or may be replaced by sculk.
+func sculkCursorStart(tx *world.Tx, pos cube.Pos) (cube.Pos, bool) {
+	for range 4 {
+		b := tx.Block(pos)
+		if _, ok := b.(Sculk); ok || sculkReplaceable(b) {
+			return pos, true
+		}
+		pos = pos.Side(cube.FaceDown)
+	}
+	return pos, false
+}
+
+// nextSculkCursor returns the position that the sculk cursor at the position
+// passed moves to. It prefers exposed blocks that may still be replaced by
+// sculk and otherwise moves over sculk that was spread before.
+func nextSculkCursor(tx *world.Tx, pos cube.Pos, r *rand.Rand) (cube.Pos, bool) {
+	var replaceable, sculk []cube.Pos
+	for x := -1; x <= 1; x++ {
+		for y := -1; y <= 1; y++ {
+			for z := -1; z <= 1; z++ {
+				p := pos.Add(cube.Pos{x, y, z})
+				if p == pos || !sculkExposed(p, tx) {
+					continue
+				}
+				switch b := tx.Block(p); b.(type) {
+				case Sculk:
+					sculk = append(sculk, p)
+				default:
+					if sculkReplaceable(b) {
+						replaceable = append(replaceable, p)
+					}
+				}
+			}
+		}
+	}
+	if len(replaceable) > 0 {
+		return replaceable[r.IntN(len(replaceable))], true
+	}
+	if len(sculk) > 0 {
+		return sculk[r.IntN(len(sculk))], true
+	}
+	return pos, false
+}
+
+// sculkExposed checks if any side of the block at the position passed is not
+// covered by a solid face of the block next to it.
+func sculkExposed(pos cube.Pos, tx *world.Tx) bool {
+	for _, f := range cube.Faces() {
+		side := pos.Side(f)
+		if !tx.Block(side).Model().FaceSolid(side, f.Opposite(), tx) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertToSculk turns the block at the position passed into sculk. Sculk
+// veins attached to the block are removed, while the exposed sides of the
+// blocks next to it that sculk may replace are covered with sculk veins.
+func convertToSculk(pos cube.Pos, tx *world.Tx) {
+	tx.SetBlock(pos, Sculk{}, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.SculkSpread{})
+	for _, f := range cube.Faces() {
+		side := pos.Side(f)
+		if v, ok := tx.Block(side).(SculkVein); ok && v.faces().hasFace(f.Opposite()) {
+			if m := v.faces().withFace(f.Opposite(), false); m.count() > 0 {
+				tx.SetBlock(side, v.withFaces(m), nil)
+			} else {
+				tx.SetBlock(side, nil, nil)
+			}
+		}
+		if !sculkReplaceable(tx.Block(side)) {
+			continue
+		}
+		for _, g := range cube.Faces() {
+			spreadMultiFaceTo(SculkVein{}, side.Side(g), g.Opposite(), tx)
+		}
+	}
+}
+
+// growSculk grows a sculk sensor or, less commonly, a sculk shrieker on top of
+// the sculk at the position passed. Nothing grows too close to the catalyst
+// or to other sensors and shriekers. Shriekers grown by a catalyst cannot
+// summon wardens.
+func growSculk(pos, catalyst cube.Pos, tx *world.Tx, r *rand.Rand) bool {
+	if _, ok := tx.Block(pos).(Sculk); !ok || pos.Vec3().Sub(catalyst.Vec3()).Len() < sculkGrowthDistance {
+		return false
+	}
+	above := pos.Side(cube.FaceUp)
+	switch tx.Block(above).(type) {
+	case Air, SculkVein:
+	default:
+		return false
+	}
+	for x := -2; x <= 2; x++ {
+		for y := -2; y <= 2; y++ {
+			for z := -2; z <= 2; z++ {
+				switch tx.Block(above.Add(cube.Pos{x, y, z})).(type) {
+				case SculkSensor, SculkShrieker:
+					return false
+				}
+			}
+		}
+	}
+	var b world.Block = SculkSensor{}
+	if r.IntN(11) == 0 {
+		b = SculkShrieker{}
+	}
+	tx.SetBlock(above, b, nil)
+	tx.PlaySound(above.Vec3Centre(), sound.SculkSpread{})
+	return true
+}
+
+// sculkReplaceable checks if the block passed may be replaced by sculk when
+// sculk spreads.
+func sculkReplaceable(b world.Block) bool {
+	switch b := b.(type) {
+	case Stone:
+		return !b.Smooth
+	case Granite:
+		return !b.Polished
+	case Diorite:
+		return !b.Polished
+	case Andesite:
+		return !b.Polished
+	case Basalt:
+		return !b.Polished
+	case Blackstone:
+		return b.Type == NormalBlackstone()
+	case Deepslate:
+		return b.Type == NormalDeepslate()
+	case Dirt, Grass, Podzol, RootedDirt, Mud, Clay, MossBlock, Sand, Gravel, Sandstone, Tuff, Calcite, Dripstone,
+		Netherrack, SoulSand, SoulSoil, SmoothBasalt, EndStone, Terracotta, StainedTerracotta:
+		return true
+	}
+	return false
+}
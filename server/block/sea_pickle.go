@@ -173,10 +173,8 @@ func (s SeaPickle) EncodeBlock() (string, map[string]any) {
 }
 
 // allSeaPickles ...
-func allSeaPickles() (b []world.Block) {
-	for i := 0; i <= 3; i++ {
-		b = append(b, SeaPickle{AdditionalCount: i})
-		b = append(b, SeaPickle{AdditionalCount: i, Dead: true})
-	}
-	return
+func allSeaPickles() []world.Block {
+	return permutations(func(v []int) world.Block {
+		return SeaPickle{AdditionalCount: v[0], Dead: v[1] == 1}
+	}, 4, 2)
 }
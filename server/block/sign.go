@@ -79,8 +79,11 @@ func (s Sign) BreakInfo() BreakInfo {
 	return newBreakInfo(1, alwaysHarvestable, axeEffective, oneOf(Sign{Wood: s.Wood}))
 }
 
-// Dye dyes the Sign, changing its base colour to that of the colour passed.
+// Dye dyes the Sign, changing its base colour to that of the colour passed. Waxed signs cannot be dyed.
 func (s Sign) Dye(pos cube.Pos, userPos mgl64.Vec3, c item.Colour) (world.Block, bool) {
+	if s.Waxed {
+		return s, false
+	}
 	if s.EditingFrontSide(pos, userPos) {
 		if s.Front.BaseColour == c.SignRGBA() {
 			return s, false
@@ -95,8 +98,11 @@ func (s Sign) Dye(pos cube.Pos, userPos mgl64.Vec3, c item.Colour) (world.Block,
 	return s, true
 }
 
-// Ink inks the sign either glowing or non-glowing.
+// Ink inks the sign either glowing or non-glowing. Waxed signs cannot be inked.
 func (s Sign) Ink(pos cube.Pos, userPos mgl64.Vec3, glowing bool) (world.Block, bool) {
+	if s.Waxed {
+		return s, false
+	}
 	if s.EditingFrontSide(pos, userPos) {
 		if s.Front.Glowing == glowing {
 			return s, false
@@ -188,6 +194,7 @@ func (s Sign) EncodeBlock() (name string, properties map[string]any) {
 
 // DecodeNBT ...
 func (s Sign) DecodeNBT(data map[string]any) any {
+	s.Waxed = nbtconv.Bool(data, "IsWaxed")
 	if nbtconv.String(data, "Text") != "" {
 		// The NBT format changed in 1.19.80 to have separate data for each side of the sign. The old format must still
 		// be supported for backwards compatibility.
@@ -199,18 +206,12 @@ func (s Sign) DecodeNBT(data map[string]any) any {
 
 	front, ok := data["FrontText"].(map[string]any)
 	if ok {
-		s.Front.BaseColour = nbtconv.RGBAFromInt32(nbtconv.Int32(front, "Color"))
-		s.Front.Glowing = nbtconv.Bool(front, "GlowingText")
-		s.Front.Text = nbtconv.String(front, "Text")
-		s.Front.Owner = nbtconv.String(front, "Owner")
+		s.Front = decodeSignText(front)
 	}
 
 	back, ok := data["BackText"].(map[string]any)
 	if ok {
-		s.Back.BaseColour = nbtconv.RGBAFromInt32(nbtconv.Int32(back, "Color"))
-		s.Back.Glowing = nbtconv.Bool(back, "GlowingText")
-		s.Back.Text = nbtconv.String(back, "Text")
-		s.Back.Owner = nbtconv.String(back, "Owner")
+		s.Back = decodeSignText(back)
 	}
 
 	return s
@@ -219,24 +220,50 @@ func (s Sign) DecodeNBT(data map[string]any) any {
 // EncodeNBT ...
 func (s Sign) EncodeNBT() map[string]any {
 	m := map[string]any{
-		"id":      "Sign",
-		"IsWaxed": boolByte(s.Waxed),
-		"FrontText": map[string]any{
-			"SignTextColor":  nbtconv.Int32FromRGBA(s.Front.BaseColour),
-			"IgnoreLighting": boolByte(s.Front.Glowing),
-			"Text":           s.Front.Text,
-			"TextOwner":      s.Front.Owner,
-		},
-		"BackText": map[string]any{
-			"SignTextColor":  nbtconv.Int32FromRGBA(s.Back.BaseColour),
-			"IgnoreLighting": boolByte(s.Back.Glowing),
-			"Text":           s.Back.Text,
-			"TextOwner":      s.Back.Owner,
-		},
+		"id":        "Sign",
+		"IsWaxed":   boolByte(s.Waxed),
+		"FrontText": encodeSignText(s.Front),
+		"BackText":  encodeSignText(s.Back),
 	}
 	return m
 }
 
+// decodeSignText decodes the data of one side of a sign. Older versions of
+// the format stored the colour, glowing state and owner under different keys,
+// which are used as a fallback.
+func decodeSignText(data map[string]any) SignText {
+	t := SignText{
+		Text:       nbtconv.String(data, "Text"),
+		BaseColour: nbtconv.RGBAFromInt32(nbtconv.Int32(data, "SignTextColor")),
+		Glowing:    nbtconv.Bool(data, "IgnoreLighting"),
+		Owner:      nbtconv.String(data, "TextOwner"),
+	}
+	if _, ok := data["SignTextColor"]; !ok {
+		t.BaseColour = nbtconv.RGBAFromInt32(nbtconv.Int32(data, "Color"))
+	}
+	if _, ok := data["IgnoreLighting"]; !ok {
+		t.Glowing = nbtconv.Bool(data, "GlowingText")
+	}
+	if _, ok := data["TextOwner"]; !ok {
+		t.Owner = nbtconv.String(data, "Owner")
+	}
+	return t
+}
+
+// encodeSignText encodes the data of one side of a sign. HideGlowOutline is
+// always unset, so that glowing text is rendered with an outline like in
+// vanilla.
+func encodeSignText(t SignText) map[string]any {
+	return map[string]any{
+		"SignTextColor":     nbtconv.Int32FromRGBA(t.BaseColour),
+		"IgnoreLighting":    boolByte(t.Glowing),
+		"HideGlowOutline":   uint8(0),
+		"PersistFormatting": uint8(1),
+		"Text":              t.Text,
+		"TextOwner":         t.Owner,
+	}
+}
+
 // allSigns ...
 func allSigns() (signs []world.Block) {
 	for _, w := range WoodTypes() {
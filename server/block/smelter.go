@@ -191,8 +191,8 @@ func (s *smelter) tickSmelting(requirement, decrement time.Duration, lit bool, s
 
 	// Initialize some default smelt info, and update it if we can smelt the item.
 	var inputInfo item.SmeltInfo
-	if i, ok := input.Item().(item.Smeltable); ok && supported(i.SmeltInfo()) {
-		inputInfo = i.SmeltInfo()
+	if info, ok := item.SmeltResult(input.Item()); ok && supported(info) {
+		inputInfo = info
 	}
 
 	// Initialize some default fuel info, and update it if it can be used as fuel.
@@ -0,0 +1,95 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// SnifferEgg is an egg laid by sniffers after breeding. Over time, the egg
+// cracks and eventually hatches into a baby sniffer. Sniffer eggs placed on
+// moss blocks hatch twice as fast.
+type SnifferEgg struct {
+	transparent
+
+	// Cracks is the number of cracks in the egg, ranging from 0 to 2. An egg
+	// with two cracks hatches the next time it cracks.
+	Cracks int
+}
+
+// RandomTick ...
+func (e SnifferEgg) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	chance := 6
+	if _, ok := tx.Block(pos.Side(cube.FaceDown)).(MossBlock); ok {
+		chance = 3
+	}
+	if r.IntN(chance) != 0 {
+		return
+	}
+	if e.Cracks < 2 {
+		e.Cracks++
+		tx.SetBlock(pos, e, nil)
+		tx.PlaySound(pos.Vec3Centre(), sound.SnifferEggCrack{})
+		return
+	}
+	breakBlockNoDrops(e, pos, tx)
+	tx.PlaySound(pos.Vec3Centre(), sound.SnifferEggHatch{})
+	if create := tx.World().EntityRegistry().Config().Sniffer; create != nil {
+		tx.AddEntity(create(world.EntitySpawnOpts{Position: pos.Vec3Middle()}))
+	}
+}
+
+// UseOnBlock ...
+func (e SnifferEgg) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, e)
+	if !used {
+		return false
+	}
+	place(tx, pos, e, user, ctx)
+	return placed(ctx)
+}
+
+// Model ...
+func (SnifferEgg) Model() world.BlockModel {
+	return model.SnifferEgg{}
+}
+
+// SideClosed ...
+func (SnifferEgg) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// BreakInfo ...
+func (e SnifferEgg) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, nothingEffective, oneOf(SnifferEgg{}))
+}
+
+// EncodeItem ...
+func (SnifferEgg) EncodeItem() (name string, meta int16) {
+	return "minecraft:sniffer_egg", 0
+}
+
+// EncodeBlock ...
+func (e SnifferEgg) EncodeBlock() (string, map[string]any) {
+	state := "no_cracks"
+	switch e.Cracks {
+	case 1:
+		state = "cracked"
+	case 2:
+		state = "max_cracked"
+	}
+	return "minecraft:sniffer_egg", map[string]any{"cracked_state": state}
+}
+
+// allSnifferEggs returns sniffer eggs with all possible numbers of cracks.
+func allSnifferEggs() (eggs []world.Block) {
+	for cracks := 0; cracks < 3; cracks++ {
+		eggs = append(eggs, SnifferEgg{Cracks: cracks})
+	}
+	return
+}
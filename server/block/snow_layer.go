@@ -144,10 +144,8 @@ func (s SnowLayer) EncodeItem() (name string, meta int16) {
 }
 
 // allSnowLayers ...
-func allSnowLayers() (b []world.Block) {
-	for i := 0; i < 8; i++ {
-		b = append(b, SnowLayer{Height: i, Covered: false})
-		b = append(b, SnowLayer{Height: i, Covered: true})
-	}
-	return
+func allSnowLayers() []world.Block {
+	return permutations(func(v []int) world.Block {
+		return SnowLayer{Height: v[0], Covered: v[1] == 1}
+	}, 8, 2)
 }
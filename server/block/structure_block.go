@@ -0,0 +1,262 @@
+package block
+
+import (
+	"fmt"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/structure"
+)
+
+const (
+	// structureBlockMaxSize is the maximum width and length of the area saved
+	// by a structure block. The height is limited by the height of the world.
+	structureBlockMaxSize = 64
+	// structureBlockCornerRange is the range in blocks around a structure
+	// block in which corner structure blocks are detected.
+	structureBlockCornerRange = 32
+)
+
+// StructureBlock is a block used by builders to save areas of a world as
+// structures and to load them again. Structures are saved to and loaded from
+// .mcstructure files in the directory set using structure.SetDirectory, so
+// that structures saved in-game may be used by the structure package, for
+// example in world generation. A structure block is only obtainable and usable
+// in creative mode.
+type StructureBlock struct {
+	solid
+	bassDrum
+
+	// Mode is the mode of the structure block, which controls what happens
+	// when it is triggered.
+	Mode StructureBlockMode
+	// Name is the name of the structure saved or loaded, in the form
+	// "namespace:name". Corner structure blocks mark the corners of the area
+	// saved by the save structure block with the same Name.
+	Name string
+	// DataField is a custom string held by data structure blocks. It has no
+	// behaviour of its own.
+	DataField string
+	// Offset is the offset of the origin of the structure from the position of
+	// the structure block.
+	Offset cube.Pos
+	// Size is the width, height and length of the area saved by a save
+	// structure block. Size is updated to the size of the structure when a
+	// structure is loaded.
+	Size [3]int
+	// Rotation is the clockwise rotation applied to structures loaded.
+	Rotation structure.Rotation
+	// Mirror is the mirror applied to structures loaded.
+	Mirror structure.Mirror
+	// ShowBoundingBox specifies if the client shows the outline of the area of
+	// the structure.
+	ShowBoundingBox bool
+}
+
+// StructureBlockMode is the mode of a StructureBlock.
+type StructureBlockMode uint8
+
+const (
+	// StructureBlockData marks a position in a structure with a custom
+	// string, held in the DataField of the StructureBlock.
+	StructureBlockData StructureBlockMode = iota
+	// StructureBlockSave saves the area of the StructureBlock as a structure.
+	StructureBlockSave
+	// StructureBlockLoad loads a saved structure at the position of the
+	// StructureBlock.
+	StructureBlockLoad
+	// StructureBlockCorner marks a corner of the area saved by a save
+	// StructureBlock with the same name.
+	StructureBlockCorner
+)
+
+// String ...
+func (m StructureBlockMode) String() string {
+	switch m {
+	case StructureBlockData:
+		return "data"
+	case StructureBlockSave:
+		return "save"
+	case StructureBlockLoad:
+		return "load"
+	case StructureBlockCorner:
+		return "corner"
+	}
+	panic("unknown structure block mode")
+}
+
+// Area returns the origin and size of the area of the structure block at the
+// position passed.
+func (s StructureBlock) Area(pos cube.Pos) (cube.Pos, [3]int) {
+	return pos.Add(s.Offset), s.Size
+}
+
+// Save saves the area of the save structure block at the position passed as a
+// structure with the Name of the structure block. If the structure block has
+// no size, its area is first fitted to the corner structure blocks with the
+// same Name nearby, and the structure block is updated with the area found.
+func (s StructureBlock) Save(pos cube.Pos, tx *world.Tx) error {
+	if s.Mode != StructureBlockSave {
+		return fmt.Errorf("save structure: structure block at %v is not in save mode", pos)
+	}
+	if s.Name == "" {
+		return fmt.Errorf("save structure: structure block at %v has no name", pos)
+	}
+	if s.Size == [3]int{} {
+		fitted, ok := s.detectCorners(pos, tx)
+		if !ok {
+			return fmt.Errorf("save structure: structure block at %v has no size and no corners", pos)
+		}
+		s = fitted
+		tx.SetBlock(pos, s, nil)
+	}
+	origin, size := s.Area(pos)
+	if size[0] <= 0 || size[1] <= 0 || size[2] <= 0 || size[0] > structureBlockMaxSize || size[2] > structureBlockMaxSize || size[1] > tx.Range().Height() {
+		return fmt.Errorf("save structure: invalid size %v", size)
+	}
+	return structure.Save(s.Name, structure.Capture(tx, origin, size))
+}
+
+// Load loads the structure with the Name of the load structure block at the
+// position passed and places it at the origin of the structure block, with
+// the Rotation and Mirror of the structure block applied. The Size of the
+// structure block is updated to the size of the structure.
+func (s StructureBlock) Load(pos cube.Pos, tx *world.Tx) error {
+	if s.Mode != StructureBlockLoad {
+		return fmt.Errorf("load structure: structure block at %v is not in load mode", pos)
+	}
+	st, err := structure.Load(s.Name)
+	if err != nil {
+		return err
+	}
+	st = st.Mirror(s.Mirror).Rotate(s.Rotation)
+	if s.Size != st.Dimensions() {
+		s.Size = st.Dimensions()
+		tx.SetBlock(pos, s, nil)
+	}
+	st.Place(tx, pos.Add(s.Offset))
+	return nil
+}
+
+// detectCorners looks for corner structure blocks with the same Name as the
+// structure block within range and returns the structure block with its
+// Offset and Size changed to the area spanned by the corners, excluding the
+// corners themselves. False is returned if no corners were found.
+func (s StructureBlock) detectCorners(pos cube.Pos, tx *world.Tx) (StructureBlock, bool) {
+	minPos, maxPos, found := pos, pos, false
+	r := tx.Range()
+	for x := -structureBlockCornerRange; x <= structureBlockCornerRange; x++ {
+		for y := max(-structureBlockCornerRange, r.Min()-pos.Y()); y <= min(structureBlockCornerRange, r.Max()-pos.Y()); y++ {
+			for z := -structureBlockCornerRange; z <= structureBlockCornerRange; z++ {
+				p := pos.Add(cube.Pos{x, y, z})
+				if corner, ok := tx.Block(p).(StructureBlock); !ok || corner.Mode != StructureBlockCorner || corner.Name != s.Name {
+					continue
+				}
+				if !found {
+					minPos, maxPos, found = p, p, true
+					continue
+				}
+				minPos = cube.Pos{min(minPos[0], p[0]), min(minPos[1], p[1]), min(minPos[2], p[2])}
+				maxPos = cube.Pos{max(maxPos[0], p[0]), max(maxPos[1], p[1]), max(maxPos[2], p[2])}
+			}
+		}
+	}
+	if !found {
+		return s, false
+	}
+	// The corners themselves are not part of the area, unless they share an
+	// axis, in which case the area is one block thick on that axis.
+	for i := range 3 {
+		if maxPos[i]-minPos[i] > 1 {
+			minPos[i], maxPos[i] = minPos[i]+1, maxPos[i]-1
+		}
+	}
+	s.Offset = minPos.Sub(pos)
+	s.Size = [3]int{maxPos[0] - minPos[0] + 1, maxPos[1] - minPos[1] + 1, maxPos[2] - minPos[2] + 1}
+	return s, true
+}
+
+// Activate ...
+func (StructureBlock) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	if gm, ok := u.(interface{ GameMode() world.GameMode }); !ok || !gm.GameMode().CreativeInventory() {
+		return false
+	}
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos, tx)
+		return true
+	}
+	return false
+}
+
+// EncodeItem ...
+func (StructureBlock) EncodeItem() (name string, meta int16) {
+	return "minecraft:structure_block", 0
+}
+
+// EncodeBlock ...
+func (s StructureBlock) EncodeBlock() (string, map[string]any) {
+	return "minecraft:structure_block", map[string]any{"structure_block_type": s.Mode.String()}
+}
+
+// EncodeNBT ...
+func (s StructureBlock) EncodeNBT() map[string]any {
+	return map[string]any{
+		"id":               "StructureBlock",
+		"data":             int32(s.Mode),
+		"structureName":    s.Name,
+		"dataField":        s.DataField,
+		"xStructureOffset": int32(s.Offset[0]),
+		"yStructureOffset": int32(s.Offset[1]),
+		"zStructureOffset": int32(s.Offset[2]),
+		"xStructureSize":   int32(s.Size[0]),
+		"yStructureSize":   int32(s.Size[1]),
+		"zStructureSize":   int32(s.Size[2]),
+		"rotation":         uint8(s.Rotation),
+		"mirror":           uint8(s.Mirror),
+		"showBoundingBox":  boolByte(s.ShowBoundingBox),
+		"ignoreEntities":   uint8(1),
+		"includePlayers":   uint8(0),
+		"removeBlocks":     uint8(0),
+		"integrity":        float32(100),
+		"seed":             int64(0),
+		"redstoneSaveMode": int32(0),
+		"isPowered":        uint8(0),
+		"animationMode":    uint8(0),
+		"animationSeconds": float32(0),
+	}
+}
+
+// DecodeNBT ...
+func (s StructureBlock) DecodeNBT(data map[string]any) any {
+	if v, ok := data["data"].(int32); ok && v >= int32(StructureBlockData) && v <= int32(StructureBlockCorner) {
+		s.Mode = StructureBlockMode(v)
+	}
+	s.Name, _ = data["structureName"].(string)
+	s.DataField, _ = data["dataField"].(string)
+	for i, axis := range []string{"x", "y", "z"} {
+		if v, ok := data[axis+"StructureOffset"].(int32); ok {
+			s.Offset[i] = int(v)
+		}
+		if v, ok := data[axis+"StructureSize"].(int32); ok {
+			s.Size[i] = int(v)
+		}
+	}
+	if v, ok := data["rotation"].(uint8); ok && v <= uint8(structure.Rotation270) {
+		s.Rotation = structure.Rotation(v)
+	}
+	if v, ok := data["mirror"].(uint8); ok && v <= uint8(structure.MirrorZ) {
+		s.Mirror = structure.Mirror(v)
+	}
+	s.ShowBoundingBox = nbtconv.Bool(data, "showBoundingBox")
+	return s
+}
+
+// allStructureBlocks returns structure blocks in all modes.
+func allStructureBlocks() (blocks []world.Block) {
+	for mode := StructureBlockData; mode <= StructureBlockCorner; mode++ {
+		blocks = append(blocks, StructureBlock{Mode: mode})
+	}
+	return
+}
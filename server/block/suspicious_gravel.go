@@ -0,0 +1,124 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// SuspiciousGravel is a variant of gravel found in ocean ruins and trail ruins, hiding an item that may be
+// uncovered by brushing it.
+type SuspiciousGravel struct {
+	solid
+	snare
+
+	// BrushedProgress is the progress of brushing the suspicious gravel, from 0-3. Once brushed past 3, the
+	// item inside is uncovered and the suspicious gravel turns into gravel.
+	BrushedProgress int
+	// Hanging specifies if the suspicious gravel has no block below it.
+	Hanging bool
+	// LootTable is the loot table used to generate the item hidden inside the suspicious gravel. The item is
+	// generated when the suspicious gravel is first brushed, after which LootTable is cleared.
+	LootTable string
+	// LootTableSeed is the seed of the loot table of the suspicious gravel. A seed of 0 generates a random
+	// item.
+	LootTableSeed int64
+	// Item is the item hidden inside the suspicious gravel, which is generated from the LootTable when it is
+	// first brushed.
+	Item item.Stack
+
+	// brushCount is the number of times the suspicious gravel was brushed and brushedAt is the time it was
+	// last brushed at.
+	brushCount int
+	brushedAt  time.Time
+}
+
+// Brush brushes the suspicious gravel. After being brushed enough times, the item hidden inside it is
+// dropped and the suspicious gravel turns into gravel, in which case Brush returns true.
+func (s SuspiciousGravel) Brush(pos cube.Pos, tx *world.Tx, u item.User) bool {
+	tx.PlaySound(pos.Vec3Centre(), sound.ItemUseOn{Block: s})
+	s.Item, s.LootTable = singleLoot(s.Item, s.LootTable, s.LootTableSeed, tx, pos, u)
+	if s.brushCount++; s.brushCount < brushesRequired {
+		s.BrushedProgress, s.brushedAt = brushedProgress(s.brushCount), time.Now()
+		tx.SetBlock(pos, s, nil)
+		tx.ScheduleBlockUpdate(pos, s, brushDecayDelay)
+		return false
+	}
+	if !s.Item.Empty() {
+		dropItem(tx, s.Item, pos.Side(cube.FaceUp).Vec3Centre())
+	}
+	tx.SetBlock(pos, Gravel{}, nil)
+	return true
+}
+
+// ScheduledTick makes the suspicious gravel slowly lose its brushing progress if it was not brushed
+// recently.
+func (s SuspiciousGravel) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if s.brushCount == 0 || time.Since(s.brushedAt) < brushDecayDelay {
+		return
+	}
+	s.brushCount = max(s.brushCount-2, 0)
+	s.BrushedProgress = brushedProgress(s.brushCount)
+	tx.SetBlock(pos, s, nil)
+	if s.brushCount > 0 {
+		tx.ScheduleBlockUpdate(pos, s, time.Second/5)
+	}
+}
+
+// NeighbourUpdateTick ...
+func (s SuspiciousGravel) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if _, air := tx.Block(pos.Side(cube.FaceDown)).(Air); air != s.Hanging {
+		s.Hanging = air
+		tx.SetBlock(pos, s, nil)
+	}
+}
+
+// BreakInfo ...
+func (s SuspiciousGravel) BreakInfo() BreakInfo {
+	// Suspicious gravel never drops anything when broken, not even the item hidden inside it.
+	return newBreakInfo(0.25, alwaysHarvestable, shovelEffective, simpleDrops())
+}
+
+// DecodeNBT ...
+func (s SuspiciousGravel) DecodeNBT(data map[string]any) any {
+	s.LootTable = nbtconv.String(data, "LootTable")
+	s.LootTableSeed = nbtconv.Int64(data, "LootTableSeed")
+	s.Item = nbtconv.MapItem(data, "item")
+	s.brushCount = int(nbtconv.Int32(data, "brush_count"))
+	return s
+}
+
+// EncodeNBT ...
+func (s SuspiciousGravel) EncodeNBT() map[string]any {
+	m := map[string]any{"id": "BrushableBlock", "brush_count": int32(s.brushCount)}
+	if s.LootTable != "" {
+		m["LootTable"], m["LootTableSeed"] = s.LootTable, s.LootTableSeed
+	}
+	if !s.Item.Empty() {
+		m["item"] = nbtconv.WriteItem(s.Item, true)
+	}
+	return m
+}
+
+// EncodeItem ...
+func (SuspiciousGravel) EncodeItem() (name string, meta int16) {
+	return "minecraft:suspicious_gravel", 0
+}
+
+// EncodeBlock ...
+func (s SuspiciousGravel) EncodeBlock() (string, map[string]any) {
+	return "minecraft:suspicious_gravel", map[string]any{"brushed_progress": int32(s.BrushedProgress), "hanging": s.Hanging}
+}
+
+// allSuspiciousGravel ...
+func allSuspiciousGravel() (b []world.Block) {
+	for progress := 0; progress <= 3; progress++ {
+		b = append(b, SuspiciousGravel{BrushedProgress: progress}, SuspiciousGravel{BrushedProgress: progress, Hanging: true})
+	}
+	return
+}
@@ -0,0 +1,124 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// SuspiciousSand is a variant of sand found in desert pyramids and other structures, hiding an item that may be
+// uncovered by brushing it.
+type SuspiciousSand struct {
+	solid
+	snare
+
+	// BrushedProgress is the progress of brushing the suspicious sand, from 0-3. Once brushed past 3, the
+	// item inside is uncovered and the suspicious sand turns into sand.
+	BrushedProgress int
+	// Hanging specifies if the suspicious sand has no block below it.
+	Hanging bool
+	// LootTable is the loot table used to generate the item hidden inside the suspicious sand. The item is
+	// generated when the suspicious sand is first brushed, after which LootTable is cleared.
+	LootTable string
+	// LootTableSeed is the seed of the loot table of the suspicious sand. A seed of 0 generates a random
+	// item.
+	LootTableSeed int64
+	// Item is the item hidden inside the suspicious sand, which is generated from the LootTable when it is
+	// first brushed.
+	Item item.Stack
+
+	// brushCount is the number of times the suspicious sand was brushed and brushedAt is the time it was
+	// last brushed at.
+	brushCount int
+	brushedAt  time.Time
+}
+
+// Brush brushes the suspicious sand. After being brushed enough times, the item hidden inside it is
+// dropped and the suspicious sand turns into sand, in which case Brush returns true.
+func (s SuspiciousSand) Brush(pos cube.Pos, tx *world.Tx, u item.User) bool {
+	tx.PlaySound(pos.Vec3Centre(), sound.ItemUseOn{Block: s})
+	s.Item, s.LootTable = singleLoot(s.Item, s.LootTable, s.LootTableSeed, tx, pos, u)
+	if s.brushCount++; s.brushCount < brushesRequired {
+		s.BrushedProgress, s.brushedAt = brushedProgress(s.brushCount), time.Now()
+		tx.SetBlock(pos, s, nil)
+		tx.ScheduleBlockUpdate(pos, s, brushDecayDelay)
+		return false
+	}
+	if !s.Item.Empty() {
+		dropItem(tx, s.Item, pos.Side(cube.FaceUp).Vec3Centre())
+	}
+	tx.SetBlock(pos, Sand{}, nil)
+	return true
+}
+
+// ScheduledTick makes the suspicious sand slowly lose its brushing progress if it was not brushed
+// recently.
+func (s SuspiciousSand) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if s.brushCount == 0 || time.Since(s.brushedAt) < brushDecayDelay {
+		return
+	}
+	s.brushCount = max(s.brushCount-2, 0)
+	s.BrushedProgress = brushedProgress(s.brushCount)
+	tx.SetBlock(pos, s, nil)
+	if s.brushCount > 0 {
+		tx.ScheduleBlockUpdate(pos, s, time.Second/5)
+	}
+}
+
+// NeighbourUpdateTick ...
+func (s SuspiciousSand) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if _, air := tx.Block(pos.Side(cube.FaceDown)).(Air); air != s.Hanging {
+		s.Hanging = air
+		tx.SetBlock(pos, s, nil)
+	}
+}
+
+// BreakInfo ...
+func (s SuspiciousSand) BreakInfo() BreakInfo {
+	// Suspicious sand never drops anything when broken, not even the item hidden inside it.
+	return newBreakInfo(0.25, alwaysHarvestable, shovelEffective, simpleDrops())
+}
+
+// DecodeNBT ...
+func (s SuspiciousSand) DecodeNBT(data map[string]any) any {
+	s.LootTable = nbtconv.String(data, "LootTable")
+	s.LootTableSeed = nbtconv.Int64(data, "LootTableSeed")
+	s.Item = nbtconv.MapItem(data, "item")
+	s.brushCount = int(nbtconv.Int32(data, "brush_count"))
+	return s
+}
+
+// EncodeNBT ...
+func (s SuspiciousSand) EncodeNBT() map[string]any {
+	m := map[string]any{"id": "BrushableBlock", "brush_count": int32(s.brushCount)}
+	if s.LootTable != "" {
+		m["LootTable"], m["LootTableSeed"] = s.LootTable, s.LootTableSeed
+	}
+	if !s.Item.Empty() {
+		m["item"] = nbtconv.WriteItem(s.Item, true)
+	}
+	return m
+}
+
+// EncodeItem ...
+func (SuspiciousSand) EncodeItem() (name string, meta int16) {
+	return "minecraft:suspicious_sand", 0
+}
+
+// EncodeBlock ...
+func (s SuspiciousSand) EncodeBlock() (string, map[string]any) {
+	return "minecraft:suspicious_sand", map[string]any{"brushed_progress": int32(s.BrushedProgress), "hanging": s.Hanging}
+}
+
+// allSuspiciousSand ...
+func allSuspiciousSand() (b []world.Block) {
+	for progress := 0; progress <= 3; progress++ {
+		b = append(b, SuspiciousSand{BrushedProgress: progress}, SuspiciousSand{BrushedProgress: progress, Hanging: true})
+	}
+	return
+}
@@ -0,0 +1,227 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+)
+
+const (
+	// trialSpawnerRange is the range in blocks within which a trial spawner
+	// detects players and counts the mobs it spawned.
+	trialSpawnerRange = 14
+	// trialSpawnerCooldownTicks is the number of ticks that a trial spawner
+	// stays in cooldown after ejecting its rewards.
+	trialSpawnerCooldownTicks = 36000
+)
+
+// TrialSpawner is a spawner found in trial chambers. Once a player comes near,
+// it spawns a limited number of mobs. After all of them were defeated, the
+// spawner ejects a reward for every player that took part in the fight and
+// goes into cooldown for 30 minutes.
+type TrialSpawner struct {
+	transparent
+	solid
+
+	// EntityIdentifier is the text ID of the mob spawned, e.g.,
+	// "minecraft:breeze".
+	EntityIdentifier string
+
+	// state is the state that the trial spawner is in.
+	state trialSpawnerState
+	// players is the number of players that took part in the current fight.
+	// spawned is the number of mobs spawned since the fight started.
+	players, spawned int
+	// cooldownEnd is the world tick at which the cooldown of the spawner ends.
+	cooldownEnd int64
+}
+
+// trialSpawnerState is the state of a TrialSpawner, as encoded in its block
+// state.
+type trialSpawnerState uint8
+
+const (
+	trialSpawnerInactive trialSpawnerState = iota
+	trialSpawnerWaitingForPlayers
+	trialSpawnerActive
+	trialSpawnerWaitingForRewardEjection
+	trialSpawnerEjectingReward
+	trialSpawnerCooldown
+)
+
+// NewTrialSpawner creates a trial spawner that spawns the world.EntityType
+// passed.
+func NewTrialSpawner(t world.EntityType) TrialSpawner {
+	return TrialSpawner{EntityIdentifier: t.EncodeEntity(), state: trialSpawnerWaitingForPlayers}
+}
+
+// SetEntity changes the entity spawned by the trial spawner to the
+// world.EntityType passed. If the spawner already spawns this entity, false is
+// returned.
+func (s TrialSpawner) SetEntity(t world.EntityType) (world.Block, bool) {
+	if s.EntityIdentifier == t.EncodeEntity() {
+		return s, false
+	}
+	s.EntityIdentifier, s.state = t.EncodeEntity(), trialSpawnerWaitingForPlayers
+	return s, true
+}
+
+// Tick waits for players to come near, spawns mobs while a fight is going on
+// and ejects rewards once all mobs were defeated.
+func (s TrialSpawner) Tick(currentTick int64, pos cube.Pos, tx *world.Tx) {
+	if currentTick%20 != 0 {
+		return
+	}
+	before := s
+	t, ok := tx.World().EntityRegistry().Lookup(s.EntityIdentifier)
+	switch {
+	case !ok:
+		s.state = trialSpawnerInactive
+	case s.state == trialSpawnerCooldown:
+		if currentTick >= s.cooldownEnd {
+			s.state = trialSpawnerWaitingForPlayers
+		}
+	case s.state == trialSpawnerActive:
+		s.players = max(s.players, s.nearbyPlayers(pos, tx))
+		alive := s.nearbyMobs(t, pos, tx)
+		if s.spawned >= 6+(s.players-1)*2 {
+			if alive == 0 {
+				s.ejectRewards(pos, tx)
+				s.state, s.cooldownEnd = trialSpawnerCooldown, currentTick+trialSpawnerCooldownTicks
+			}
+			break
+		}
+		if alive < 2+(s.players-1) && currentTick%40 == 0 {
+			if s.spawn(t, pos, tx) {
+				s.spawned++
+			}
+		}
+	default:
+		s.state = trialSpawnerWaitingForPlayers
+		if players := s.nearbyPlayers(pos, tx); players > 0 {
+			s.state, s.players, s.spawned = trialSpawnerActive, players, 0
+		}
+	}
+	if s != before {
+		tx.SetBlock(pos, s, nil)
+	}
+}
+
+// nearbyPlayers returns the number of players within range of the trial
+// spawner that are able to take part in a fight.
+func (s TrialSpawner) nearbyPlayers(pos cube.Pos, tx *world.Tx) (n int) {
+	centre := pos.Vec3Centre()
+	for p := range tx.Players() {
+		if g, ok := p.(interface{ GameMode() world.GameMode }); ok && !g.GameMode().AllowsTakingDamage() {
+			continue
+		}
+		if p.Position().Sub(centre).Len() <= trialSpawnerRange {
+			n++
+		}
+	}
+	return n
+}
+
+// nearbyMobs returns the number of entities of the world.EntityType passed
+// within range of the trial spawner.
+func (s TrialSpawner) nearbyMobs(t world.EntityType, pos cube.Pos, tx *world.Tx) (n int) {
+	for e := range tx.EntitiesWithin(cube.Box(-trialSpawnerRange, -trialSpawnerRange, -trialSpawnerRange, trialSpawnerRange, trialSpawnerRange, trialSpawnerRange).Translate(pos.Vec3Centre())) {
+		if e.H().Type() == t {
+			n++
+		}
+	}
+	return n
+}
+
+// spawn attempts to spawn an entity of the world.EntityType passed on a free
+// position around the trial spawner. False is returned if no free position
+// was found.
+func (s TrialSpawner) spawn(t world.EntityType, pos cube.Pos, tx *world.Tx) bool {
+	for i := 0; i < 10; i++ {
+		spawnPos := pos.Add(cube.Pos{rand.IntN(9) - 4, rand.IntN(3) - 1, rand.IntN(9) - 4})
+		if _, ok := tx.Block(spawnPos).(Air); !ok {
+			continue
+		}
+		if _, ok := tx.Block(spawnPos.Side(cube.FaceUp)).(Air); !ok {
+			continue
+		}
+		if below := spawnPos.Side(cube.FaceDown); !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+			continue
+		}
+		opts := world.EntitySpawnOpts{Position: spawnPos.Vec3Middle(), Rotation: cube.Rotation{rand.Float64()*360 - 180, 0}}
+		tx.AddEntity(opts.NewFromNBT(t, nil))
+		return true
+	}
+	return false
+}
+
+// ejectRewards drops a reward for every player that took part in the fight on
+// top of the trial spawner.
+func (s TrialSpawner) ejectRewards(pos cube.Pos, tx *world.Tx) {
+	for i := 0; i < s.players; i++ {
+		table := "spawners/trial_chamber/consumables.json"
+		if rand.IntN(2) == 0 {
+			table = "spawners/trial_chamber/key.json"
+		}
+		stacks, _ := loot.GenerateAt(table, tx, pos)
+		for _, stack := range stacks {
+			dropItem(tx, stack, pos.Side(cube.FaceUp).Vec3Middle())
+		}
+	}
+}
+
+// EncodeNBT ...
+func (s TrialSpawner) EncodeNBT() map[string]any {
+	data := map[string]any{
+		"id":            "TrialSpawner",
+		"players":       int32(s.players),
+		"spawned":       int32(s.spawned),
+		"cooldown_ends": s.cooldownEnd,
+	}
+	if s.EntityIdentifier != "" {
+		data["EntityIdentifier"] = s.EntityIdentifier
+	}
+	return data
+}
+
+// DecodeNBT ...
+func (s TrialSpawner) DecodeNBT(data map[string]any) any {
+	if v, ok := data["EntityIdentifier"].(string); ok {
+		s.EntityIdentifier = v
+	}
+	if v, ok := data["players"].(int32); ok {
+		s.players = int(v)
+	}
+	if v, ok := data["spawned"].(int32); ok {
+		s.spawned = int(v)
+	}
+	if v, ok := data["cooldown_ends"].(int64); ok {
+		s.cooldownEnd = v
+	}
+	return s
+}
+
+// BreakInfo ...
+func (s TrialSpawner) BreakInfo() BreakInfo {
+	return newBreakInfo(50, alwaysHarvestable, pickaxeEffective, simpleDrops())
+}
+
+// EncodeItem ...
+func (TrialSpawner) EncodeItem() (name string, meta int16) {
+	return "minecraft:trial_spawner", 0
+}
+
+// EncodeBlock ...
+func (s TrialSpawner) EncodeBlock() (string, map[string]any) {
+	return "minecraft:trial_spawner", map[string]any{"ominous": uint8(0), "trial_spawner_state": int32(s.state)}
+}
+
+// allTrialSpawners returns trial spawners in all possible states.
+func allTrialSpawners() (spawners []world.Block) {
+	for state := trialSpawnerInactive; state <= trialSpawnerCooldown; state++ {
+		spawners = append(spawners, TrialSpawner{state: state})
+	}
+	return
+}
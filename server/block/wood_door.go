@@ -119,6 +119,14 @@ func (d WoodDoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User,
 	return true
 }
 
+// WindChargeBurst opens or closes the door. Only the bottom half of the door
+// reacts, so that a burst reaching both halves does not toggle it twice.
+func (d WoodDoor) WindChargeBurst(pos cube.Pos, tx *world.Tx) {
+	if !d.Top {
+		d.Activate(pos, cube.FaceUp, tx, nil, nil)
+	}
+}
+
 // BreakInfo ...
 func (d WoodDoor) BreakInfo() BreakInfo {
 	return newBreakInfo(3, alwaysHarvestable, axeEffective, oneOf(d))
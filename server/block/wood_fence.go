@@ -30,6 +30,43 @@ func (WoodFence) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
 	return false
 }
 
+// Activate attaches any entities leashed to the user to the fence.
+func (WoodFence) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	return attachLeashes(pos, tx, u)
+}
+
+// attachLeashes attaches all entities leashed to the user passed to a leash
+// knot on the fence at the position passed. If no leash knot exists yet, one is
+// created. False is returned if the user had no entities leashed.
+func attachLeashes(pos cube.Pos, tx *world.Tx, u item.User) bool {
+	var (
+		leashed []item.Leashable
+		knot    world.Entity
+	)
+	for e := range tx.EntitiesWithin(cube.Box(-7, -7, -7, 8, 8, 8).Translate(pos.Vec3())) {
+		if name := e.H().Type().EncodeEntity(); name == "minecraft:leash_knot" && cube.PosFromVec3(e.Position()) == pos {
+			knot = e
+			continue
+		}
+		if l, ok := e.(item.Leashable); ok {
+			if holder, ok := l.LeashHolder(); ok && holder == u.H() {
+				leashed = append(leashed, l)
+			}
+		}
+	}
+	if len(leashed) == 0 {
+		return false
+	}
+	if knot == nil {
+		create := tx.World().EntityRegistry().Config().LeashKnot
+		knot = tx.AddEntity(create(world.EntitySpawnOpts{Position: pos.Vec3Middle()}))
+	}
+	for _, l := range leashed {
+		l.Leash(knot)
+	}
+	return true
+}
+
 // FlammabilityInfo ...
 func (w WoodFence) FlammabilityInfo() FlammabilityInfo {
 	if !w.Wood.Flammable() {
@@ -92,6 +92,17 @@ func (f WoodFenceGate) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.
 	return true
 }
 
+// WindChargeBurst opens or closes the fence gate.
+func (f WoodFenceGate) WindChargeBurst(pos cube.Pos, tx *world.Tx) {
+	f.Open = !f.Open
+	tx.SetBlock(pos, f, nil)
+	if f.Open {
+		tx.PlaySound(pos.Vec3Centre(), sound.FenceGateOpen{Block: f})
+		return
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.FenceGateClose{Block: f})
+}
+
 // SideClosed ...
 func (f WoodFenceGate) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
 	return false
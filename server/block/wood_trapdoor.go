@@ -67,6 +67,11 @@ func (t WoodTrapdoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.U
 	return true
 }
 
+// WindChargeBurst opens or closes the trapdoor.
+func (t WoodTrapdoor) WindChargeBurst(pos cube.Pos, tx *world.Tx) {
+	t.Activate(pos, cube.FaceUp, tx, nil, nil)
+}
+
 // BreakInfo ...
 func (t WoodTrapdoor) BreakInfo() BreakInfo {
 	return newBreakInfo(3, alwaysHarvestable, axeEffective, oneOf(t))
@@ -24,6 +24,11 @@ func (w Wool) FlammabilityInfo() FlammabilityInfo {
 	return newFlammabilityInfo(30, 60, true)
 }
 
+// OccludesVibrations ...
+func (Wool) OccludesVibrations() bool {
+	return true
+}
+
 // BreakInfo ...
 func (w Wool) BreakInfo() BreakInfo {
 	return newBreakInfo(0.8, alwaysHarvestable, shearsEffective, oneOf(w))
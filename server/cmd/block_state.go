@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// BlockState is a Parameter that parses a block name followed by an optional
+// list of block states, for example:
+//
+//	minecraft:snow_layer["height"=4]
+//	glow_lichen["multi_face_direction_bits"=3]
+//	oak_log["pillar_axis"="x"]
+//
+// The minecraft: prefix may be omitted and states may be separated from their
+// values with either = or :. States that are not passed keep the value of the
+// first state registered for the block. The block state parsed is resolved to
+// a registered block using world.BlockByName.
+type BlockState struct {
+	// Block is the block that the block state resolved to.
+	Block world.Block
+	// States holds the block states that were passed explicitly, by their
+	// names.
+	States map[string]any
+}
+
+// Type ...
+func (BlockState) Type() string {
+	return "Block"
+}
+
+// Parse ...
+func (BlockState) Parse(line *Line, v reflect.Value) error {
+	arg, ok := line.Next()
+	if !ok {
+		return line.UsageError()
+	}
+	// A list of states may contain spaces, so we consume arguments until the
+	// list is closed.
+	n := 1
+	for strings.Contains(arg, "[") && !strings.Contains(arg, "]") {
+		args, ok := line.NextN(n + 1)
+		if !ok {
+			return line.SyntaxError()
+		}
+		arg, n = strings.Join(args, " "), n+1
+	}
+	b, states, err := parseBlockState(arg)
+	if err != nil {
+		return MessageParameterInvalid.F(arg)
+	}
+	// The caller removes the last argument consumed.
+	line.RemoveN(n - 1)
+	v.Set(reflect.ValueOf(BlockState{Block: b, States: states}))
+	return nil
+}
+
+// String returns the name of the block state.
+func (b BlockState) String() string {
+	if b.Block == nil {
+		return "minecraft:air"
+	}
+	name, _ := b.Block.EncodeBlock()
+	return name
+}
+
+// Matches checks if the world.Block passed has the same name as the block
+// state and the same values for all states passed explicitly. States that were
+// not passed may have any value.
+func (b BlockState) Matches(other world.Block) bool {
+	name, properties := other.EncodeBlock()
+	if name != b.String() {
+		return false
+	}
+	for k, v := range b.States {
+		if properties[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// parseBlockState parses a block state in the format name["state"=value,...]
+// and resolves it to a registered block. The states passed explicitly are
+// returned along with the block.
+func parseBlockState(s string) (world.Block, map[string]any, error) {
+	name, states, hasStates := strings.Cut(strings.TrimSpace(s), "[")
+	if !strings.Contains(name, ":") {
+		name = "minecraft:" + name
+	}
+	properties, ok := world.BlockProperties(name)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown block %v", name)
+	}
+	explicit := map[string]any{}
+	if hasStates {
+		states, ok := strings.CutSuffix(strings.TrimSpace(states), "]")
+		if !ok {
+			return nil, nil, fmt.Errorf("block states of %v are not closed", name)
+		}
+		for _, state := range strings.Split(states, ",") {
+			if state = strings.TrimSpace(state); state == "" {
+				continue
+			}
+			key, val, ok := strings.Cut(state, "=")
+			if !ok {
+				key, val, ok = strings.Cut(state, ":")
+			}
+			if !ok {
+				return nil, nil, fmt.Errorf("block state %v has no value", state)
+			}
+			key, val = unquote(key), unquote(val)
+			current, ok := properties[key]
+			if !ok {
+				return nil, nil, fmt.Errorf("block %v has no state %v", name, key)
+			}
+			value, err := blockStateValue(current, val)
+			if err != nil {
+				return nil, nil, fmt.Errorf("block state %v: %w", key, err)
+			}
+			properties[key], explicit[key] = value, value
+		}
+	}
+	b, ok := world.BlockByName(name, properties)
+	if !ok {
+		return nil, nil, fmt.Errorf("block %v has no state %v", name, properties)
+	}
+	return b, explicit, nil
+}
+
+// blockStateValue converts the string value of a block state to the type of
+// the current value of the state.
+func blockStateValue(current any, val string) (any, error) {
+	switch current.(type) {
+	case uint8:
+		switch strings.ToLower(val) {
+		case "true":
+			return uint8(1), nil
+		case "false":
+			return uint8(0), nil
+		}
+		n, err := strconv.ParseUint(val, 10, 8)
+		return uint8(n), err
+	case int32:
+		n, err := strconv.ParseInt(val, 10, 32)
+		return int32(n), err
+	case bool:
+		return strconv.ParseBool(val)
+	}
+	return val, nil
+}
+
+// unquote trims whitespace and surrounding double quotes from the string
+// passed.
+func unquote(s string) string {
+	return strings.Trim(strings.TrimSpace(s), `"`)
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/structure"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// CloneCommand implements the /clone command. It copies the blocks in the area
+// between two positions to the area starting at a destination position,
+// including block entity data such as the contents of chests and the text on
+// signs. With the masked mask mode, air is not copied. The clone mode
+// specifies how the source area is handled: normal fails if the source and
+// destination overlap, force allows them to overlap and move replaces the
+// blocks copied in the source area with air. Like SetBlockCommand, both
+// clone commands may only be run by operators. They may be registered as one
+// command:
+//
+//	cmd.Register(cmd.New("clone", "Clones blocks from one region to another.", nil,
+//		cmd.CloneCommand{}, cmd.CloneFilteredCommand{}))
+//
+// Areas may also be copied without a command, including to other worlds,
+// using structure.Capture and Structure.Place.
+type CloneCommand struct {
+	Begin       mgl64.Vec3          `cmd:"begin"`
+	End         mgl64.Vec3          `cmd:"end"`
+	Destination mgl64.Vec3          `cmd:"destination"`
+	Mask        Optional[cloneMask] `cmd:"maskMode"`
+	Mode        Optional[cloneMode] `cmd:"cloneMode"`
+}
+
+// Run ...
+func (c CloneCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	var keep func(b world.Block) bool
+	if c.Mask.LoadOr("replace") == "masked" {
+		keep = func(b world.Block) bool {
+			_, air := b.(block.Air)
+			return !air
+		}
+	}
+	cloneArea(tx, o, c.Begin, c.End, c.Destination, keep, c.Mode.LoadOr("normal"))
+}
+
+// Allow ...
+func (CloneCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// CloneFilteredCommand implements the /clone command with the filtered mask
+// mode. Only blocks that match a block state, as parsed by BlockState, are
+// copied. Block states that are not passed may have any value.
+type CloneFilteredCommand struct {
+	Begin       mgl64.Vec3 `cmd:"begin"`
+	End         mgl64.Vec3 `cmd:"end"`
+	Destination mgl64.Vec3 `cmd:"destination"`
+	Filtered    SubCommand `cmd:"filtered"`
+	Mode        cloneMode  `cmd:"cloneMode"`
+	Block       BlockState `cmd:"tileName"`
+}
+
+// Run ...
+func (c CloneFilteredCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	cloneArea(tx, o, c.Begin, c.End, c.Destination, c.Block.Matches, c.Mode)
+}
+
+// Allow ...
+func (CloneFilteredCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// cloneArea copies the blocks between begin and end for which keep returns
+// true to the area starting at dest. If keep is nil, all blocks are copied.
+func cloneArea(tx *world.Tx, o *Output, begin, end, dest mgl64.Vec3, keep func(b world.Block) bool, mode cloneMode) {
+	from, to := cube.PosFromVec3(begin), cube.PosFromVec3(end)
+	minPos := cube.Pos{min(from[0], to[0]), min(from[1], to[1]), min(from[2], to[2])}
+	maxPos := cube.Pos{max(from[0], to[0]), max(from[1], to[1]), max(from[2], to[2])}
+	size := [3]int{maxPos[0] - minPos[0] + 1, maxPos[1] - minPos[1] + 1, maxPos[2] - minPos[2] + 1}
+	destMin := cube.PosFromVec3(dest)
+	destMax := destMin.Add(maxPos.Sub(minPos))
+	if minPos.OutOfBounds(tx.Range()) || maxPos.OutOfBounds(tx.Range()) || destMin.OutOfBounds(tx.Range()) || destMax.OutOfBounds(tx.Range()) {
+		o.Errort(messageCloneOutOfWorld)
+		return
+	}
+	if n := size[0] * size[1] * size[2]; n > fillLimit {
+		o.Errort(messageCloneTooManyBlocks, n, fillLimit)
+		return
+	}
+	overlap := minPos[0] <= destMax[0] && destMin[0] <= maxPos[0] && minPos[1] <= destMax[1] && destMin[1] <= maxPos[1] && minPos[2] <= destMax[2] && destMin[2] <= maxPos[2]
+	if overlap && mode == "normal" {
+		o.Errort(messageCloneNoOverlap)
+		return
+	}
+
+	s := structure.Capture(tx, minPos, size)
+	if keep != nil {
+		s = s.Filter(keep)
+	}
+	n := s.BlockCount()
+	if n == 0 {
+		o.Errort(messageCloneFailed)
+		return
+	}
+	if mode == "move" {
+		for x := range size[0] {
+			for y := range size[1] {
+				for z := range size[2] {
+					if b, _ := s.At(x, y, z, nil); b != nil {
+						tx.SetBlock(minPos.Add(cube.Pos{x, y, z}), nil, nil)
+					}
+				}
+			}
+		}
+	}
+	s.Place(tx, destMin)
+	o.Printt(messageCloneSuccess, n)
+}
+
+// cloneMask is an Enum holding the mask modes of /clone that do not need a
+// block to filter by.
+type cloneMask string
+
+// Type ...
+func (cloneMask) Type() string {
+	return "MaskMode"
+}
+
+// Options ...
+func (cloneMask) Options(Source) []string {
+	return []string{"replace", "masked"}
+}
+
+// cloneMode is an Enum holding the ways in which /clone handles the source
+// area.
+type cloneMode string
+
+// Type ...
+func (cloneMode) Type() string {
+	return "CloneMode"
+}
+
+// Options ...
+func (cloneMode) Options(Source) []string {
+	return []string{"normal", "force", "move"}
+}
+
+var messageCloneSuccess = chat.Translate(str("%commands.clone.success"), 1, `%v blocks cloned`)
+var messageCloneFailed = chat.Translate(str("%commands.clone.failed"), 0, `No blocks cloned`).Enc("<red>%v</red>")
+var messageCloneNoOverlap = chat.Translate(str("%commands.clone.noOverlap"), 0, `Source and destination can not overlap`).Enc("<red>%v</red>")
+var messageCloneOutOfWorld = chat.Translate(str("%commands.clone.outOfWorld"), 0, `Cannot access blocks outside of the world`).Enc("<red>%v</red>")
+var messageCloneTooManyBlocks = chat.Translate(str("%commands.clone.tooManyBlocks"), 2, `Too many blocks in the specified area (%v > %v)`).Enc("<red>%v</red>")
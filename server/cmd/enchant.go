@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// EnchantCommand implements the /enchant command. It adds an enchantment to
+// the item held in the main hand of the targets passed. Enchantments are
+// looked up by the names they were registered with, as returned by
+// item.EnchantmentName, so that enchantments registered by plugins may be
+// applied too. Like in vanilla, the level may not exceed the maximum level of
+// the enchantment and the enchantment must be compatible with the item and its
+// other enchantments. EnchantCommand may only be run by operators and sources
+// without a permission level, such as the console. It may be registered like
+// any other command:
+//
+//	cmd.Register(cmd.New("enchant", "Adds an enchantment to a player's selected item.", nil, cmd.EnchantCommand{}))
+type EnchantCommand struct {
+	Targets     []Target        `cmd:"player"`
+	Enchantment enchantmentName `cmd:"enchantmentName"`
+	Level       Optional[int]   `cmd:"level"`
+}
+
+// Run ...
+func (e EnchantCommand) Run(_ Source, o *Output, _ *world.Tx) {
+	t, ok := item.EnchantmentByName(string(e.Enchantment))
+	if !ok {
+		o.Errort(messageEnchantNotFound, e.Enchantment)
+		return
+	}
+	lvl := e.Level.LoadOr(1)
+	if lvl < 1 || lvl > t.MaxLevel() {
+		o.Errort(messageEnchantInvalidLevel, lvl)
+		return
+	}
+
+	for _, target := range e.Targets {
+		holder, ok := target.(interface {
+			Name() string
+			HeldItems() (item.Stack, item.Stack)
+			SetHeldItems(mainHand, offHand item.Stack)
+		})
+		if !ok {
+			continue
+		}
+		held, left := holder.HeldItems()
+		if held.Empty() {
+			o.Errort(messageEnchantNoItem, holder.Name())
+			continue
+		}
+		if !t.CompatibleWithItem(held.Item()) {
+			o.Errort(messageEnchantCantEnchant, e.Enchantment)
+			continue
+		}
+		if slices.ContainsFunc(held.Enchantments(), func(other item.Enchantment) bool {
+			return other.Type() != t && (!t.CompatibleWithEnchantment(other.Type()) || !other.Type().CompatibleWithEnchantment(t))
+		}) {
+			o.Errort(messageEnchantCantCombine, e.Enchantment)
+			continue
+		}
+		holder.SetHeldItems(held.WithEnchantments(item.NewEnchantment(t, lvl)), left)
+		o.Printt(messageEnchantSuccess, holder.Name())
+	}
+	if len(e.Targets) == 0 {
+		o.Errort(MessageNoTargets)
+	}
+}
+
+// Allow ...
+func (EnchantCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// enchantmentName is an Enum holding the names of all registered
+// enchantments.
+type enchantmentName string
+
+// Type ...
+func (enchantmentName) Type() string {
+	return "Enchant"
+}
+
+// Options ...
+func (enchantmentName) Options(Source) []string {
+	var names []string
+	for _, t := range item.Enchantments() {
+		if name, ok := item.EnchantmentName(t); ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+var messageEnchantSuccess = chat.Translate(str("%commands.enchant.success"), 1, `Enchanting succeeded for %v`)
+var messageEnchantNotFound = chat.Translate(str("%commands.enchant.notFound"), 1, `There is no such enchantment with ID %v`).Enc("<red>%v</red>")
+var messageEnchantInvalidLevel = chat.Translate(str("%commands.enchant.invalidLevel"), 1, `Level %v is not supported by this enchantment`).Enc("<red>%v</red>")
+var messageEnchantNoItem = chat.Translate(str("%commands.enchant.noItem"), 1, `%v is not holding an item`).Enc("<red>%v</red>")
+var messageEnchantCantEnchant = chat.Translate(str("%commands.enchant.cantEnchant"), 1, `The selected enchantment %v can't be added to the target item`).Enc("<red>%v</red>")
+var messageEnchantCantCombine = chat.Translate(str("%commands.enchant.cantCombine"), 1, `%v can't be combined with the existing enchantments`).Enc("<red>%v</red>")
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// fillLimit is the maximum number of blocks that may be changed by a single
+// /fill command.
+const fillLimit = 32768
+
+// FillCommand implements the /fill command. It fills the area between two
+// positions with a block with an optional list of block states, as parsed by
+// BlockState. The mode specifies how the blocks in the area are handled: In
+// addition to the modes of SetBlockCommand, hollow fills only the outer layer
+// of the area and replaces the inside with air, while outline fills only the
+// outer layer and leaves the inside as is. Like SetBlockCommand, FillCommand
+// may only be run by operators. It may be registered like any other command:
+//
+//	cmd.Register(cmd.New("fill", "Fills all or parts of a region with a specific block.", nil, cmd.FillCommand{}))
+type FillCommand struct {
+	From  mgl64.Vec3         `cmd:"from"`
+	To    mgl64.Vec3         `cmd:"to"`
+	Block BlockState         `cmd:"tileName"`
+	Mode  Optional[fillMode] `cmd:"oldBlockHandling"`
+}
+
+// Run ...
+func (f FillCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	from, to := cube.PosFromVec3(f.From), cube.PosFromVec3(f.To)
+	minPos := cube.Pos{min(from[0], to[0]), min(from[1], to[1]), min(from[2], to[2])}
+	maxPos := cube.Pos{max(from[0], to[0]), max(from[1], to[1]), max(from[2], to[2])}
+	if minPos.OutOfBounds(tx.Range()) || maxPos.OutOfBounds(tx.Range()) {
+		o.Errort(messageFillOutOfWorld)
+		return
+	}
+	size := (maxPos[0] - minPos[0] + 1) * (maxPos[1] - minPos[1] + 1) * (maxPos[2] - minPos[2] + 1)
+	if size > fillLimit {
+		o.Errort(messageFillTooManyBlocks, size, fillLimit)
+		return
+	}
+
+	mode, n := string(f.Mode.LoadOr("replace")), 0
+	for x := minPos[0]; x <= maxPos[0]; x++ {
+		for y := minPos[1]; y <= maxPos[1]; y++ {
+			for z := minPos[2]; z <= maxPos[2]; z++ {
+				pos := cube.Pos{x, y, z}
+				b, setMode := f.Block.Block, mode
+				if mode == "hollow" || mode == "outline" {
+					edge := x == minPos[0] || x == maxPos[0] || y == minPos[1] || y == maxPos[1] || z == minPos[2] || z == maxPos[2]
+					if !edge && mode == "outline" {
+						continue
+					} else if !edge {
+						b = nil
+					}
+					setMode = "replace"
+				}
+				if placeBlock(tx, pos, b, setMode) {
+					n++
+				}
+			}
+		}
+	}
+	if n == 0 {
+		o.Errort(messageFillFailed)
+		return
+	}
+	o.Printt(messageFillSuccess, n)
+}
+
+// Allow ...
+func (FillCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// fillMode is an Enum holding the ways in which /fill handles the blocks in
+// the area filled.
+type fillMode string
+
+// Type ...
+func (fillMode) Type() string {
+	return "FillMode"
+}
+
+// Options ...
+func (fillMode) Options(Source) []string {
+	return []string{"replace", "destroy", "keep", "hollow", "outline"}
+}
+
+var messageFillSuccess = chat.Translate(str("%commands.fill.success"), 1, `%v blocks filled`)
+var messageFillFailed = chat.Translate(str("%commands.fill.failed"), 0, `No blocks were filled`).Enc("<red>%v</red>")
+var messageFillOutOfWorld = chat.Translate(str("%commands.fill.outOfWorld"), 0, `Cannot place blocks outside of the world`).Enc("<red>%v</red>")
+var messageFillTooManyBlocks = chat.Translate(str("%commands.fill.tooManyBlocks"), 2, `Too many blocks in the specified area (%v > %v)`).Enc("<red>%v</red>")
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// GameRuleCommand implements the /gamerule command. If only a rule is passed,
+// the current value of the world.GameRule in the world of the Source is
+// printed. If a value is passed too, the game rule is changed to that value.
+// GameRuleCommand may only be run by operators and sources without a
+// permission level, such as the console. It may be registered like any other
+// command:
+//
+//	cmd.Register(cmd.New("gamerule", "Sets or queries a game rule value.", nil, cmd.GameRuleCommand{}))
+type GameRuleCommand struct {
+	Rule  gameRule       `cmd:"rule"`
+	Value Optional[bool] `cmd:"value"`
+}
+
+// Run ...
+func (g GameRuleCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	r, ok := world.GameRuleByName(string(g.Rule))
+	if !ok {
+		o.Errort(MessageParameterInvalid, g.Rule)
+		return
+	}
+	v, ok := g.Value.Load()
+	if !ok {
+		o.Printt(messageGameRuleQuery, r.Name(), tx.World().GameRule(r))
+		return
+	}
+	tx.World().SetGameRule(r, v)
+	o.Printt(messageGameRuleSuccess, r.Name(), v)
+}
+
+// Allow ...
+func (GameRuleCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// gameRule is an Enum holding the names of all game rules.
+type gameRule string
+
+// Type ...
+func (gameRule) Type() string {
+	return "BoolGameRule"
+}
+
+// Options ...
+func (gameRule) Options(Source) []string {
+	rules := world.GameRules()
+	names := make([]string, 0, len(rules))
+	for _, r := range rules {
+		names = append(names, r.Name())
+	}
+	return names
+}
+
+var messageGameRuleQuery = chat.Translate(str("%commands.gamerule.query"), 2, `%v = %v`)
+var messageGameRuleSuccess = chat.Translate(str("%commands.gamerule.success"), 2, `Game rule %v has been updated to %v`)
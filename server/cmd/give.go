@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// GiveCommand implements the /give command. It adds an item to the
+// inventories of the targets passed. Items that do not fit in the inventory of
+// a target are dropped at its position. Components of the item, such as its
+// enchantments, may be passed as stringified NBT after the amount, for
+// example:
+//
+//	/give @s diamond_sword 1 {name:"Excalibur",lore:["Pulled from a stone"],ench:[{id:sharpness,lvl:5}]}
+//
+// See ApplyItemComponents for the components supported. GiveCommand may
+// only be run by operators and sources without a permission level, such as
+// the console. It may be registered like any other command:
+//
+//	cmd.Register(cmd.New("give", "Gives an item to a player.", nil, cmd.GiveCommand{}))
+type GiveCommand struct {
+	Targets    []Target          `cmd:"player"`
+	Item       itemName          `cmd:"item"`
+	Amount     Optional[int]     `cmd:"amount"`
+	Components Optional[Varargs] `cmd:"components"`
+}
+
+// Run ...
+func (g GiveCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	it, ok := world.ItemByName("minecraft:"+string(g.Item), 0)
+	if !ok {
+		o.Errort(MessageParameterInvalid, g.Item)
+		return
+	}
+	amount := g.Amount.LoadOr(1)
+	if amount < 1 {
+		o.Errort(MessageNumberInvalid, amount)
+		return
+	}
+	s := item.NewStack(it, amount)
+	if components, ok := g.Components.Load(); ok {
+		var err error
+		if s, err = ApplyItemComponents(s, string(components)); err != nil {
+			o.Errorf("Invalid item components: %v", err)
+			return
+		}
+	}
+
+	n := 0
+	for _, t := range g.Targets {
+		holder, ok := t.(interface{ Inventory() *inventory.Inventory })
+		if !ok {
+			continue
+		}
+		if added, _ := holder.Inventory().AddItem(s); added < s.Count() {
+			dropLoot(tx, t.Position(), s.Grow(added-s.Count()))
+		}
+		n++
+	}
+	if n == 0 {
+		o.Errort(MessageNoTargets)
+		return
+	}
+	o.Printf("Gave %v * %v to %v targets", g.Item, amount, n)
+}
+
+// Allow ...
+func (GiveCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// ApplyItemComponents applies the components written in stringified NBT to
+// the item.Stack passed, so that items may be built by hand in the same way
+// loot tables build them. The following components are supported:
+//
+//	name: "Name"                           custom name of the item
+//	lore: ["Line 1", "Line 2"]             lore of the item
+//	damage: 10                             damage taken by a durable item
+//	unbreakable: true                      prevents a durable item from breaking
+//	potion: strong_healing                 potion type of a potion, splash potion, lingering potion or arrow
+//	ench: [{id: sharpness, lvl: 5}]        enchantments of the item
+//	enchantments: {sharpness: 5}           enchantments of the item
+//
+// An error is returned if the components could not be parsed, if a component
+// is unknown or does not apply to the item, or if the damage passed would
+// break the item.
+func ApplyItemComponents(s item.Stack, components string) (item.Stack, error) {
+	m, err := parseSNBT(components)
+	if err != nil {
+		return s, err
+	}
+	// The potion is applied first, as it changes the item of the stack, and
+	// the keys are otherwise applied in a stable order.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		if pa, pb := strings.EqualFold(a, "potion"), strings.EqualFold(b, "potion"); pa != pb {
+			if pa {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	for _, k := range keys {
+		if s, err = applyItemComponent(s, k, m[k]); err != nil {
+			return s, err
+		}
+	}
+	return s, nil
+}
+
+// applyItemComponent applies a single component with the key and value passed
+// to the item.Stack passed.
+func applyItemComponent(s item.Stack, key string, v any) (item.Stack, error) {
+	switch strings.ToLower(key) {
+	case "name":
+		name, ok := v.(string)
+		if !ok {
+			return s, fmt.Errorf("name must be a string")
+		}
+		return s.WithCustomName(name), nil
+	case "lore":
+		l, ok := v.([]any)
+		if !ok {
+			return s, fmt.Errorf("lore must be a list of strings")
+		}
+		lines := make([]string, 0, len(l))
+		for _, line := range l {
+			lines = append(lines, fmt.Sprint(line))
+		}
+		return s.WithLore(lines...), nil
+	case "damage":
+		dmg, ok := v.(int64)
+		if !ok || dmg < 0 {
+			return s, fmt.Errorf("damage must be a number of at least 0")
+		}
+		if _, ok := s.Item().(item.Durable); !ok {
+			return s, fmt.Errorf("item %v has no durability", stackName(s))
+		}
+		if int(dmg) >= s.MaxDurability() {
+			return s, fmt.Errorf("damage of item %v must be less than %v", stackName(s), s.MaxDurability())
+		}
+		return s.WithDurability(s.MaxDurability() - int(dmg)), nil
+	case "unbreakable":
+		if b, ok := v.(bool); (ok && b) || v == int64(1) {
+			return s.AsUnbreakable(), nil
+		}
+		return s.AsBreakable(), nil
+	case "potion":
+		name, ok := v.(string)
+		if !ok {
+			return s, fmt.Errorf("potion must be a string")
+		}
+		pot, ok := potion.ByName(name)
+		if !ok {
+			return s, fmt.Errorf("unknown potion %v", name)
+		}
+		switch s.Item().(type) {
+		case item.Potion:
+			return s.WithItem(item.Potion{Type: pot}), nil
+		case item.SplashPotion:
+			return s.WithItem(item.SplashPotion{Type: pot}), nil
+		case item.LingeringPotion:
+			return s.WithItem(item.LingeringPotion{Type: pot}), nil
+		case item.Arrow:
+			return s.WithItem(item.Arrow{Tip: pot}), nil
+		}
+		return s, fmt.Errorf("item %v cannot hold a potion", stackName(s))
+	case "ench", "enchantments":
+		return applyEnchantComponent(s, v)
+	}
+	return s, fmt.Errorf("unknown component %v", key)
+}
+
+// applyEnchantComponent adds the enchantments passed to the item.Stack. The
+// enchantments may either be a list of compounds with an id and lvl, or a
+// compound mapping enchantment names to their levels.
+func applyEnchantComponent(s item.Stack, v any) (item.Stack, error) {
+	levels := map[string]any{}
+	switch v := v.(type) {
+	case map[string]any:
+		levels = v
+	case []any:
+		for _, e := range v {
+			m, ok := e.(map[string]any)
+			if !ok {
+				return s, fmt.Errorf("enchantments must be compounds with an id and lvl")
+			}
+			lvl, ok := m["lvl"]
+			if !ok {
+				lvl = m["level"]
+			}
+			levels[fmt.Sprint(m["id"])] = lvl
+		}
+	default:
+		return s, fmt.Errorf("enchantments must be a list or a compound")
+	}
+	for name, lvl := range levels {
+		t, ok := item.EnchantmentByName(name)
+		if !ok {
+			return s, fmt.Errorf("unknown enchantment %v", name)
+		}
+		n, ok := lvl.(int64)
+		if !ok || n < 1 {
+			return s, fmt.Errorf("level of enchantment %v must be a positive number", name)
+		}
+		s = s.WithEnchantments(item.NewEnchantment(t, int(n)))
+	}
+	return s, nil
+}
+
+// stackName returns the name of the item of the item.Stack passed.
+func stackName(s item.Stack) string {
+	name, _ := s.Item().EncodeItem()
+	return name
+}
+
+// itemName is an Enum holding the names of all registered items, without the
+// minecraft: prefix.
+type itemName string
+
+// Type ...
+func (itemName) Type() string {
+	return "Item"
+}
+
+// Options ...
+func (itemName) Options(Source) []string {
+	return itemNames()
+}
+
+// itemNames returns the names of all registered items without the minecraft:
+// prefix.
+var itemNames = sync.OnceValue(func() []string {
+	var names []string
+	for _, it := range world.Items() {
+		name, _ := it.EncodeItem()
+		if name, ok := strings.CutPrefix(name, "minecraft:"); ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+})
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// LootGiveCommand implements the /loot give command. It rolls a loot table
+// and adds the items generated to the inventories of the targets passed.
+// Items that do not fit in the inventory of a target are dropped at its
+// position. Like LootReloadCommand, the loot commands may only be run by
+// operators and sources without a permission level, such as the console. All
+// loot commands may be registered as one command:
+//
+//	cmd.Register(cmd.New("loot", "Drops a loot table into an inventory or the world.", nil,
+//		cmd.LootGiveCommand{}, cmd.LootSpawnCommand{}, cmd.LootInsertCommand{},
+//		cmd.LootReplaceBlockCommand{}, cmd.LootReplaceEntityCommand{}))
+type LootGiveCommand struct {
+	Give    SubCommand `cmd:"give"`
+	Targets []Target   `cmd:"players"`
+	Loot    SubCommand `cmd:"loot"`
+	Table   lootTable  `cmd:"loot_table"`
+}
+
+// Run ...
+func (l LootGiveCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	n := 0
+	for _, t := range l.Targets {
+		holder, ok := t.(interface{ Inventory() *inventory.Inventory })
+		if !ok {
+			continue
+		}
+		stacks, ok := l.Table.generate(tx, cube.PosFromVec3(t.Position()))
+		if !ok {
+			o.Errort(MessageParameterInvalid, l.Table)
+			return
+		}
+		for _, s := range stacks {
+			added, _ := holder.Inventory().AddItem(s)
+			if added < s.Count() {
+				dropLoot(tx, t.Position(), s.Grow(added-s.Count()))
+			}
+			n += s.Count()
+		}
+	}
+	o.Printf("Gave %v items from loot table %v to %v targets", n, l.Table, len(l.Targets))
+}
+
+// Allow ...
+func (LootGiveCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// LootSpawnCommand implements the /loot spawn command. It rolls a loot table
+// and drops the items generated at the position passed.
+type LootSpawnCommand struct {
+	Spawn    SubCommand `cmd:"spawn"`
+	Position mgl64.Vec3 `cmd:"position"`
+	Loot     SubCommand `cmd:"loot"`
+	Table    lootTable  `cmd:"loot_table"`
+}
+
+// Run ...
+func (l LootSpawnCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	stacks, ok := l.Table.generate(tx, cube.PosFromVec3(l.Position))
+	if !ok {
+		o.Errort(MessageParameterInvalid, l.Table)
+		return
+	}
+	for _, s := range stacks {
+		dropLoot(tx, l.Position, s)
+	}
+	o.Printf("Dropped %v items from loot table %v", countStacks(stacks), l.Table)
+}
+
+// Allow ...
+func (LootSpawnCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// LootInsertCommand implements the /loot insert command. It rolls a loot
+// table and adds the items generated to the container block at the position
+// passed. Items that do not fit in the container are dropped on top of it.
+type LootInsertCommand struct {
+	Insert   SubCommand `cmd:"insert"`
+	Position mgl64.Vec3 `cmd:"position"`
+	Loot     SubCommand `cmd:"loot"`
+	Table    lootTable  `cmd:"loot_table"`
+}
+
+// Run ...
+func (l LootInsertCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	pos := cube.PosFromVec3(l.Position)
+	inv, ok := containerInventory(tx, pos)
+	if !ok {
+		o.Errorf("The block at %v is not a container", pos)
+		return
+	}
+	stacks, ok := l.Table.generate(tx, pos)
+	if !ok {
+		o.Errort(MessageParameterInvalid, l.Table)
+		return
+	}
+	for _, s := range stacks {
+		if added, _ := inv.AddItem(s); added < s.Count() {
+			dropLoot(tx, pos.Side(cube.FaceUp).Vec3Centre(), s.Grow(added-s.Count()))
+		}
+	}
+	o.Printf("Inserted %v items from loot table %v into the container at %v", countStacks(stacks), l.Table, pos)
+}
+
+// Allow ...
+func (LootInsertCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// LootReplaceBlockCommand implements the /loot replace block command. It
+// rolls a loot table and places the items generated in the slots of the
+// container block at the position passed, starting at the slot passed. Items
+// that would be placed past the last slot of the container are discarded.
+type LootReplaceBlockCommand struct {
+	Replace  SubCommand `cmd:"replace"`
+	Block    SubCommand `cmd:"block"`
+	Position mgl64.Vec3 `cmd:"position"`
+	Slot     int        `cmd:"slot"`
+	Loot     SubCommand `cmd:"loot"`
+	Table    lootTable  `cmd:"loot_table"`
+}
+
+// Run ...
+func (l LootReplaceBlockCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	pos := cube.PosFromVec3(l.Position)
+	inv, ok := containerInventory(tx, pos)
+	if !ok {
+		o.Errorf("The block at %v is not a container", pos)
+		return
+	}
+	stacks, ok := l.Table.generate(tx, pos)
+	if !ok {
+		o.Errort(MessageParameterInvalid, l.Table)
+		return
+	}
+	if l.Slot < 0 || l.Slot >= inv.Size() {
+		o.Errort(MessageNumberInvalid, l.Slot)
+		return
+	}
+	n := replaceSlots(inv, l.Slot, stacks)
+	o.Printf("Replaced %v slots of the container at %v with loot table %v", n, pos, l.Table)
+}
+
+// Allow ...
+func (LootReplaceBlockCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// LootReplaceEntityCommand implements the /loot replace entity command. It
+// rolls a loot table for every target passed and places the items generated
+// in the slots of its inventory, starting at the slot passed. Items that would
+// be placed past the last slot of the inventory are discarded.
+type LootReplaceEntityCommand struct {
+	Replace SubCommand `cmd:"replace"`
+	Entity  SubCommand `cmd:"entity"`
+	Targets []Target   `cmd:"entities"`
+	Slot    int        `cmd:"slot"`
+	Loot    SubCommand `cmd:"loot"`
+	Table   lootTable  `cmd:"loot_table"`
+}
+
+// Run ...
+func (l LootReplaceEntityCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	n := 0
+	for _, t := range l.Targets {
+		holder, ok := t.(interface{ Inventory() *inventory.Inventory })
+		if !ok || l.Slot < 0 || l.Slot >= holder.Inventory().Size() {
+			continue
+		}
+		stacks, ok := l.Table.generate(tx, cube.PosFromVec3(t.Position()))
+		if !ok {
+			o.Errort(MessageParameterInvalid, l.Table)
+			return
+		}
+		replaceSlots(holder.Inventory(), l.Slot, stacks)
+		n++
+	}
+	if n == 0 {
+		o.Errort(MessageNoTargets)
+		return
+	}
+	o.Printf("Replaced slots of %v targets with loot table %v", n, l.Table)
+}
+
+// Allow ...
+func (LootReplaceEntityCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// containerInventory returns the inventory of the container block at the
+// position passed, if the block is a container.
+func containerInventory(tx *world.Tx, pos cube.Pos) (*inventory.Inventory, bool) {
+	c, ok := tx.Block(pos).(block.Container)
+	if !ok {
+		return nil, false
+	}
+	return c.Inventory(tx, pos), true
+}
+
+// replaceSlots sets the stacks passed to the slots of the inventory starting at
+// slot start. Stacks that would be placed past the last slot are discarded.
+// replaceSlots returns the number of slots replaced.
+func replaceSlots(inv *inventory.Inventory, start int, stacks []item.Stack) int {
+	n := 0
+	for i, s := range stacks {
+		if start+i >= inv.Size() {
+			break
+		}
+		_ = inv.SetItem(start+i, s)
+		n++
+	}
+	return n
+}
+
+// dropLoot drops a stack of loot at the position passed.
+func dropLoot(tx *world.Tx, pos mgl64.Vec3, s item.Stack) {
+	if s.Empty() {
+		return
+	}
+	tx.AddEntity(entity.NewItem(world.EntitySpawnOpts{Position: pos}, s))
+}
+
+// countStacks returns the total number of items in the stacks passed.
+func countStacks(stacks []item.Stack) (n int) {
+	for _, s := range stacks {
+		n += s.Count()
+	}
+	return n
+}
+
+// lootTable is an Enum holding the paths of all loot tables, without the
+// .json extension.
+type lootTable string
+
+// Type ...
+func (lootTable) Type() string {
+	return "LootTable"
+}
+
+// Options ...
+func (lootTable) Options(Source) []string {
+	return lootTableNames()
+}
+
+// generate rolls the loot table for the position passed.
+func (t lootTable) generate(tx *world.Tx, pos cube.Pos) ([]item.Stack, bool) {
+	return loot.GenerateAt(string(t)+".json", tx, pos)
+}
+
+// lootTableNames returns the names of all loot tables, which are their paths
+// without the .json extension. The names are not cached, as the tables may
+// change when they are reloaded using LootReloadCommand.
+func lootTableNames() []string {
+	tables := loot.Tables()
+	names := make([]string, 0, len(tables))
+	for _, t := range tables {
+		if name, ok := strings.CutSuffix(t, ".json"); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
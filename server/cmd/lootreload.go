@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+)
+
+// LootReloadCommand implements the /lootreload command. It reloads all loot
+// tables using loot.Reload, so that changes to loot tables in the directory set
+// using loot.SetSource take effect without restarting the server. Errors
+// found while loading and validating the tables are reported to the Source.
+// LootReloadCommand may only be run by operators and sources without a
+// permission level, such as the console. It may be registered like any other
+// command:
+//
+//	cmd.Register(cmd.New("lootreload", "Reloads all loot tables.", nil, cmd.LootReloadCommand{}))
+type LootReloadCommand struct{}
+
+// Run ...
+func (LootReloadCommand) Run(_ Source, o *Output, _ *world.Tx) {
+	n, errs := loot.Reload()
+	for _, err := range errs {
+		o.Error(err)
+	}
+	o.Printf("Reloaded %v loot tables with %v errors", n, len(errs))
+}
+
+// Allow ...
+func (LootReloadCommand) Allow(src Source) bool {
+	return operator(src)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// SetBlockCommand implements the /setblock command. It changes the block at a
+// position to a block with an optional list of block states, as parsed by
+// BlockState. The old block is replaced, unless the mode is keep, in which
+// case only air is replaced, or destroy, in which case the old block is
+// broken and drops its items. SetBlockCommand may only be run by operators and
+// sources without a permission level, such as the console. It may be
+// registered like any other command:
+//
+//	cmd.Register(cmd.New("setblock", "Changes a block to another block.", nil, cmd.SetBlockCommand{}))
+type SetBlockCommand struct {
+	Position mgl64.Vec3             `cmd:"position"`
+	Block    BlockState             `cmd:"tileName"`
+	Mode     Optional[setBlockMode] `cmd:"oldBlockHandling"`
+}
+
+// Run ...
+func (s SetBlockCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	pos := cube.PosFromVec3(s.Position)
+	if pos.OutOfBounds(tx.Range()) {
+		o.Errort(messageSetBlockOutOfWorld)
+		return
+	}
+	if !placeBlock(tx, pos, s.Block.Block, string(s.Mode.LoadOr("replace"))) {
+		o.Errort(messageSetBlockNoChange)
+		return
+	}
+	o.Printt(messageSetBlockSuccess)
+}
+
+// Allow ...
+func (SetBlockCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// placeBlock places a block at the position passed using the mode passed,
+// which is one of replace, destroy or keep. False is returned if the block was
+// not placed.
+func placeBlock(tx *world.Tx, pos cube.Pos, b world.Block, mode string) bool {
+	old := tx.Block(pos)
+	switch mode {
+	case "keep":
+		if _, ok := old.(block.Air); !ok {
+			return false
+		}
+	case "destroy":
+		destroyBlock(tx, pos, old)
+	}
+	if world.BlockRuntimeID(tx.Block(pos)) == world.BlockRuntimeID(b) {
+		return false
+	}
+	tx.SetBlock(pos, b, nil)
+	return true
+}
+
+// destroyBlock breaks the block at the position passed, showing breaking
+// particles and dropping the drops of the block if the doTileDrops game rule
+// is enabled.
+func destroyBlock(tx *world.Tx, pos cube.Pos, b world.Block) {
+	if _, ok := b.(block.Air); ok {
+		return
+	}
+	tx.SetBlock(pos, nil, nil)
+	tx.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: b})
+	if tx.World().GameRule(world.GameRuleDoTileDrops) {
+		for _, drop := range block.Drops(b, loot.Context{Tx: tx, Pos: pos}) {
+			dropLoot(tx, pos.Vec3Centre(), drop)
+		}
+	}
+}
+
+// setBlockMode is an Enum holding the ways in which /setblock handles the
+// block that is replaced.
+type setBlockMode string
+
+// Type ...
+func (setBlockMode) Type() string {
+	return "SetBlockMode"
+}
+
+// Options ...
+func (setBlockMode) Options(Source) []string {
+	return []string{"replace", "destroy", "keep"}
+}
+
+var messageSetBlockSuccess = chat.Translate(str("%commands.setblock.success"), 0, `Block placed`)
+var messageSetBlockNoChange = chat.Translate(str("%commands.setblock.noChange"), 0, `The block couldn't be placed`).Enc("<red>%v</red>")
+var messageSetBlockOutOfWorld = chat.Translate(str("%commands.setblock.outOfWorld"), 0, `Cannot place block outside of the world`).Enc("<red>%v</red>")
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseSNBT parses a compound written in stringified NBT, such as
+// {name:"Sword",ench:[{id:sharpness,lvl:5}]}. Compounds are returned as
+// map[string]any and lists as []any. Numbers are returned as int64 or float64,
+// regardless of their type suffix, and true and false are returned as bool.
+// Any other unquoted value is returned as string.
+func parseSNBT(s string) (map[string]any, error) {
+	r := &snbtReader{s: strings.TrimSpace(s)}
+	if !r.consume('{') {
+		return nil, fmt.Errorf("expected '{' at start of components")
+	}
+	m, err := r.compound()
+	if err != nil {
+		return nil, err
+	}
+	if r.skipSpace(); r.pos != len(r.s) {
+		return nil, fmt.Errorf("unexpected trailing data at position %v", r.pos)
+	}
+	return m, nil
+}
+
+// snbtReader reads stringified NBT from a string.
+type snbtReader struct {
+	s   string
+	pos int
+}
+
+// compound reads the entries of a compound up to and including its closing
+// brace. The opening brace must already have been consumed.
+func (r *snbtReader) compound() (map[string]any, error) {
+	m := map[string]any{}
+	if r.consume('}') {
+		return m, nil
+	}
+	for {
+		key, err := r.str()
+		if err != nil {
+			return nil, err
+		}
+		if !r.consume(':') {
+			return nil, fmt.Errorf("expected ':' after key %v at position %v", key, r.pos)
+		}
+		if m[key], err = r.value(); err != nil {
+			return nil, err
+		}
+		if r.consume('}') {
+			return m, nil
+		}
+		if !r.consume(',') {
+			return nil, fmt.Errorf("expected ',' or '}' at position %v", r.pos)
+		}
+	}
+}
+
+// list reads the values of a list up to and including its closing bracket.
+// The opening bracket must already have been consumed.
+func (r *snbtReader) list() ([]any, error) {
+	var l []any
+	if r.consume(']') {
+		return l, nil
+	}
+	for {
+		v, err := r.value()
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, v)
+		if r.consume(']') {
+			return l, nil
+		}
+		if !r.consume(',') {
+			return nil, fmt.Errorf("expected ',' or ']' at position %v", r.pos)
+		}
+	}
+}
+
+// value reads any value: A compound, list, quoted string or unquoted word.
+func (r *snbtReader) value() (any, error) {
+	switch {
+	case r.consume('{'):
+		return r.compound()
+	case r.consume('['):
+		return r.list()
+	}
+	if r.skipSpace(); r.pos < len(r.s) && (r.s[r.pos] == '"' || r.s[r.pos] == '\'') {
+		return r.str()
+	}
+	word, err := r.str()
+	if err != nil {
+		return nil, err
+	}
+	return snbtWord(word), nil
+}
+
+// str reads a quoted string or an unquoted word.
+func (r *snbtReader) str() (string, error) {
+	r.skipSpace()
+	if r.pos >= len(r.s) {
+		return "", fmt.Errorf("unexpected end of components")
+	}
+	if q := r.s[r.pos]; q == '"' || q == '\'' {
+		var b strings.Builder
+		for r.pos++; r.pos < len(r.s); r.pos++ {
+			switch c := r.s[r.pos]; {
+			case c == '\\' && r.pos+1 < len(r.s):
+				r.pos++
+				b.WriteByte(r.s[r.pos])
+			case c == q:
+				r.pos++
+				return b.String(), nil
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", fmt.Errorf("unterminated string in components")
+	}
+	start := r.pos
+	for r.pos < len(r.s) && !strings.ContainsRune("{}[]:,\"' ", rune(r.s[r.pos])) {
+		r.pos++
+	}
+	if start == r.pos {
+		return "", fmt.Errorf("unexpected '%c' at position %v", r.s[r.pos], r.pos)
+	}
+	return r.s[start:r.pos], nil
+}
+
+// consume skips whitespace and consumes the byte passed if it is next.
+func (r *snbtReader) consume(c byte) bool {
+	if r.skipSpace(); r.pos < len(r.s) && r.s[r.pos] == c {
+		r.pos++
+		return true
+	}
+	return false
+}
+
+// skipSpace skips whitespace at the current position of the reader.
+func (r *snbtReader) skipSpace() {
+	for r.pos < len(r.s) && r.s[r.pos] == ' ' {
+		r.pos++
+	}
+}
+
+// snbtWord converts an unquoted word to a bool, int64 or float64 if possible.
+// Type suffixes such as the b in 1b are ignored.
+func snbtWord(word string) any {
+	switch strings.ToLower(word) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	num := strings.TrimRight(word, "bBsSlLfFdD")
+	if n, err := strconv.ParseInt(num, 10, 64); err == nil {
+		if strings.ContainsAny(word, "fFdD") {
+			return float64(n)
+		}
+		return n
+	}
+	if f, err := strconv.ParseFloat(num, 64); err == nil {
+		return f
+	}
+	return word
+}
@@ -10,3 +10,11 @@ type Source interface {
 	// SendCommandOutput is called by a Command automatically after being run.
 	SendCommandOutput(o *Output)
 }
+
+// operator checks if the Source passed may run commands that are limited to
+// operators. This is the case for sources with a permission level of at least
+// 2 and for sources without a permission level, such as the console.
+func operator(src Source) bool {
+	p, ok := src.(interface{ PermissionLevel() uint32 })
+	return !ok || p.PermissionLevel() >= 2
+}
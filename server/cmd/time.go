@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// TimeSetCommand implements the /time set command with a number of ticks. It
+// sets the time of the world of the Source. The time drives the day/night
+// cycle of the world, so changing it affects everything that depends on it,
+// such as sleeping and the light level at which mobs spawn. Like the other
+// commands that change the time, TimeSetCommand may only be run by operators
+// and sources without a permission level, such as the console, while
+// TimeQueryCommand may be run by anyone. All time commands may be registered
+// as one command:
+//
+//	cmd.Register(cmd.New("time", "Changes or queries the world's game time.", nil,
+//		cmd.TimeSetCommand{}, cmd.TimeSetPresetCommand{}, cmd.TimeAddCommand{}, cmd.TimeQueryCommand{}))
+type TimeSetCommand struct {
+	Set  SubCommand `cmd:"set"`
+	Time int        `cmd:"amount"`
+}
+
+// Run ...
+func (c TimeSetCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	if c.Time < 0 {
+		o.Errort(MessageNumberInvalid, c.Time)
+		return
+	}
+	tx.World().SetTime(c.Time)
+	o.Printt(messageTimeSet, c.Time)
+}
+
+// Allow ...
+func (TimeSetCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// TimeSetPresetCommand implements the /time set command with a named time of
+// day, such as day or midnight.
+type TimeSetPresetCommand struct {
+	Set  SubCommand `cmd:"set"`
+	Time timePreset `cmd:"time"`
+}
+
+// Run ...
+func (c TimeSetPresetCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	t := timePresets[c.Time]
+	tx.World().SetTime(t)
+	o.Printt(messageTimeSet, t)
+}
+
+// Allow ...
+func (TimeSetPresetCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// TimeAddCommand implements the /time add command. It adds a number of ticks
+// to the time of the world of the Source.
+type TimeAddCommand struct {
+	Add    SubCommand `cmd:"add"`
+	Amount int        `cmd:"amount"`
+}
+
+// Run ...
+func (c TimeAddCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	w := tx.World()
+	w.SetTime(max(w.Time()+c.Amount, 0))
+	o.Printt(messageTimeAdded, c.Amount)
+}
+
+// Allow ...
+func (TimeAddCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// TimeQueryCommand implements the /time query command. It prints the time of
+// day in ticks or the number of days that have passed in the world of the
+// Source.
+type TimeQueryCommand struct {
+	Query SubCommand `cmd:"query"`
+	Time  timeQuery  `cmd:"time"`
+}
+
+// Run ...
+func (c TimeQueryCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	t := tx.World().Time()
+	switch c.Time {
+	case "daytime":
+		o.Printt(messageTimeQueryDaytime, t%24000)
+	case "day":
+		o.Printt(messageTimeQueryDay, t/24000)
+	}
+}
+
+// timePresets holds the times in ticks of the named times of day that may be
+// passed to /time set.
+var timePresets = map[timePreset]int{
+	"day":      1000,
+	"noon":     6000,
+	"sunset":   12000,
+	"night":    13000,
+	"midnight": 18000,
+	"sunrise":  23000,
+}
+
+// timePreset is an Enum holding the named times of day that may be passed to
+// /time set.
+type timePreset string
+
+// Type ...
+func (timePreset) Type() string {
+	return "TimeSpec"
+}
+
+// Options ...
+func (timePreset) Options(Source) []string {
+	return []string{"day", "noon", "sunset", "night", "midnight", "sunrise"}
+}
+
+// timeQuery is an Enum holding the values that may be queried using /time
+// query.
+type timeQuery string
+
+// Type ...
+func (timeQuery) Type() string {
+	return "TimeQuery"
+}
+
+// Options ...
+func (timeQuery) Options(Source) []string {
+	return []string{"daytime", "day"}
+}
+
+var messageTimeSet = chat.Translate(str("%commands.time.set"), 1, `Set the time to %v`)
+var messageTimeAdded = chat.Translate(str("%commands.time.added"), 1, `Added %v to the time`)
+var messageTimeQueryDaytime = chat.Translate(str("%commands.time.query.daytime"), 1, `Daytime is %v`)
+var messageTimeQueryDay = chat.Translate(str("%commands.time.query.day"), 1, `Day is %v`)
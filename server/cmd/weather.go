@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// WeatherCommand implements the /weather command. It changes the weather of
+// the world of the Source to clear weather, rain or thunder for the duration
+// passed in ticks. If no duration is passed, a random duration of 5 to 10
+// minutes is used. The weather is changed through the weather cycle of the
+// world, so rain falls as snow and builds up snow layers in cold biomes, and
+// thunder makes lightning strike, as with natural weather. WeatherCommand
+// may only be run by operators and sources without a permission level, such
+// as the console, while WeatherQueryCommand may be run by anyone. Both forms
+// may be registered as one command:
+//
+//	cmd.Register(cmd.New("weather", "Sets the weather.", nil, cmd.WeatherCommand{}, cmd.WeatherQueryCommand{}))
+type WeatherCommand struct {
+	Type     weatherType   `cmd:"type"`
+	Duration Optional[int] `cmd:"duration"`
+}
+
+// Run ...
+func (c WeatherCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	ticks := c.Duration.LoadOr(6000 + rand.IntN(6000))
+	if ticks < 1 {
+		o.Errort(MessageNumberInvalid, ticks)
+		return
+	}
+	dur, w := time.Duration(ticks)*time.Second/20, tx.World()
+	switch c.Type {
+	case "clear":
+		w.ClearWeather(dur)
+		o.Printt(messageWeatherClear)
+	case "rain":
+		w.ClearWeather(dur)
+		w.StartRaining(dur)
+		o.Printt(messageWeatherRain)
+	case "thunder":
+		w.StartThundering(dur)
+		o.Printt(messageWeatherThunder)
+	}
+}
+
+// Allow ...
+func (WeatherCommand) Allow(src Source) bool {
+	return operator(src)
+}
+
+// WeatherQueryCommand implements the /weather query command. It prints the
+// current weather of the world of the Source.
+type WeatherQueryCommand struct {
+	Query SubCommand `cmd:"query"`
+}
+
+// Run ...
+func (WeatherQueryCommand) Run(_ Source, o *Output, tx *world.Tx) {
+	state := "clear"
+	if tx.Thundering() {
+		state = "thunder"
+	} else if tx.Raining() {
+		state = "rain"
+	}
+	o.Printt(messageWeatherQuery, state)
+}
+
+// weatherType is an Enum holding the types of weather that /weather may
+// change to.
+type weatherType string
+
+// Type ...
+func (weatherType) Type() string {
+	return "WeatherType"
+}
+
+// Options ...
+func (weatherType) Options(Source) []string {
+	return []string{"clear", "rain", "thunder"}
+}
+
+var messageWeatherClear = chat.Translate(str("%commands.weather.clear"), 0, `Changing to clear weather`)
+var messageWeatherRain = chat.Translate(str("%commands.weather.rain"), 0, `Changing to rainy weather`)
+var messageWeatherThunder = chat.Translate(str("%commands.weather.thunder"), 0, `Changing to rain and thunder`)
+var messageWeatherQuery = chat.Translate(str("%commands.weather.query"), 1, `Weather state is: %v`)
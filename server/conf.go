@@ -12,12 +12,15 @@ import (
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/internal/packbuilder"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/recipe"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/playerdb"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/biome"
 	"github.com/df-mc/dragonfly/server/world/generator"
+	"github.com/df-mc/dragonfly/server/world/loot"
 	"github.com/df-mc/dragonfly/server/world/mcdb"
 	"github.com/google/uuid"
 	"github.com/sandertv/gophertunnel/minecraft"
@@ -117,6 +120,29 @@ type Config struct {
 	// may be added to the Server's worlds. If no entity types are registered,
 	// Entities will be set to entity.DefaultRegistry.
 	Entities world.EntityRegistry
+	// RecipeFolder is a folder holding recipes in JSON files, in either the
+	// Bedrock Edition or the Java Edition format, that are registered in
+	// addition to the vanilla recipes when the Server is created. Recipes may
+	// also be loaded using recipe.LoadDirectory before players join. If left
+	// empty, no recipes are loaded from disk.
+	RecipeFolder string
+	// PreloadLootTables specifies if all loot tables should be parsed and
+	// validated when the Server is created, using loot.Reload. If true, no
+	// loot table is parsed when loot is first generated from it and broken
+	// loot tables are logged before players encounter them. Loot tables in
+	// the directory set using loot.SetSource are loaded too.
+	PreloadLootTables bool
+	// LootTableFolder is a folder holding loot tables in JSON files that are
+	// loaded in addition to the built-in loot tables when the Server is
+	// created, replacing built-in tables with the same path. Changes to the
+	// tables in the folder take effect after calling loot.Reload, for example
+	// through cmd.LootReloadCommand. An entity_tables.json file in the folder
+	// may assign loot tables to entity types, such as
+	// {"minecraft:zombie": "entities/zombie.json"}, to change the drops of
+	// entities when they die. Tables at blocks/<name>.json, such as
+	// blocks/diamond_ore.json, replace the drops of the block with that name.
+	// If left empty, no loot tables are loaded from disk.
+	LootTableFolder string
 }
 
 // New creates a Server using fields of conf. The Server's worlds are created
@@ -178,9 +204,29 @@ func (conf Config) New() *Server {
 		srv.listeners = append(srv.listeners, l)
 	}
 
+	item.RegisterSpawnEggs(conf.Entities)
 	creative_registerCreativeItems()
 	world_finaliseBlockRegistry()
 	recipe_registerVanilla()
+	if conf.RecipeFolder != "" {
+		n, errs := recipe.LoadDirectory(conf.RecipeFolder)
+		for _, err := range errs {
+			conf.Log.Error("load recipes: " + err.Error())
+		}
+		if n > 0 {
+			conf.Log.Debug("Loaded recipes.", "count", n, "folder", conf.RecipeFolder)
+		}
+	}
+	if conf.LootTableFolder != "" {
+		loot.SetSource(conf.LootTableFolder)
+	}
+	if conf.PreloadLootTables || conf.LootTableFolder != "" {
+		n, errs := loot.Reload()
+		for _, err := range errs {
+			conf.Log.Warn("load loot table: " + err.Error())
+		}
+		conf.Log.Debug("Loaded loot tables.", "count", n)
+	}
 
 	srv.world = srv.createWorld(world.Overworld, &srv.nether, &srv.end)
 	srv.nether = srv.createWorld(world.Nether, &srv.world, &srv.end)
@@ -221,6 +267,10 @@ type UserConfig struct {
 		SaveData bool
 		// Folder is the folder that the data of the world resides in.
 		Folder string
+		// PreloadLootTables specifies if all loot tables should be parsed and
+		// validated when the server starts instead of when they are first
+		// used.
+		PreloadLootTables bool
 	}
 	Players struct {
 		// MaxCount is the maximum amount of players allowed to join the server
@@ -251,6 +301,12 @@ type UserConfig struct {
 		// Required is a boolean to force the client to load the resource pack
 		// on join. If they do not accept, they'll have to leave the server.
 		Required bool
+		// RecipeFolder controls the location where JSON recipes will be
+		// loaded from, in addition to the vanilla recipes.
+		RecipeFolder string
+		// LootTableFolder controls the location where JSON loot tables will
+		// be loaded from, in addition to the built-in loot tables.
+		LootTableFolder string
 	}
 }
 
@@ -268,6 +324,9 @@ func (uc UserConfig) Config(log *slog.Logger) (Config, error) {
 		MaxPlayers:              uc.Players.MaxCount,
 		MaxChunkRadius:          uc.Players.MaximumChunkRadius,
 		DisableResourceBuilding: !uc.Resources.AutoBuildPack,
+		RecipeFolder:            uc.Resources.RecipeFolder,
+		LootTableFolder:         uc.Resources.LootTableFolder,
+		PreloadLootTables:       uc.World.PreloadLootTables,
 	}
 	if !uc.Server.DisableJoinQuitMessages {
 		conf.JoinMessage, conf.QuitMessage = chat.MessageJoin, chat.MessageQuit
@@ -333,12 +392,15 @@ func DefaultConfig() UserConfig {
 	c.Server.AuthEnabled = true
 	c.World.SaveData = true
 	c.World.Folder = "world"
+	c.World.PreloadLootTables = true
 	c.Players.MaximumChunkRadius = 32
 	c.Players.SaveData = true
 	c.Players.Folder = "players"
 	c.Resources.AutoBuildPack = true
 	c.Resources.Folder = "resources"
 	c.Resources.Required = false
+	c.Resources.RecipeFolder = "recipes"
+	c.Resources.LootTableFolder = "loot_tables"
 	return c
 }
 
@@ -53,12 +53,40 @@ type PickedUpAction struct {
 	action
 }
 
+// FishingHookBiteAction is a world.EntityAction that makes a fishing hook dip
+// into the water when a fish bites.
+type FishingHookBiteAction struct{ action }
+
 // FireworkExplosionAction is a world.EntityAction that makes a Firework rocket display an explosion particle.
 type FireworkExplosionAction struct{ action }
 
 // TotemUseAction is a world.EntityAction that displays the totem use particles and animation.
 type TotemUseAction struct{ action }
 
+// LoveAction is a world.EntityAction that makes hearts appear around an entity, for example when it is fed an
+// item that makes it ready to breed.
+type LoveAction struct{ action }
+
+// MountAction is a world.EntityAction that makes an entity start riding a
+// Rideable.
+type MountAction struct {
+	// Vehicle is the entity that is being ridden.
+	Vehicle world.Entity
+	// Driver specifies if the entity controls the movement of the Vehicle.
+	Driver bool
+
+	action
+}
+
+// DismountAction is a world.EntityAction that makes an entity stop riding a
+// Rideable.
+type DismountAction struct {
+	// Vehicle is the entity that was being ridden.
+	Vehicle world.Entity
+
+	action
+}
+
 // action implements the Action interface. Structures in this package may embed it to gets its functionality
 // out of the box.
 type action struct{}
@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+// NewAllay creates an allay that does not yet hold an item.
+func NewAllay(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(AllayType, allayConf)
+}
+
+var allayConf = AllayBehaviourConfig{
+	Health: 20,
+}
+
+// Allay is a passive flying mob that collects items matching the item given to
+// it by a player and brings them to that player, or to a note block played
+// nearby. Allays dance near jukeboxes playing a music disc and may be
+// duplicated by giving them an amethyst shard while they are dancing. Allay
+// implements the Living, Collector and Interactable interfaces.
+type Allay struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the AllayBehaviour of the Allay.
+func (a *Allay) behaviour() *AllayBehaviour {
+	return a.data.Data.(*AllayBehaviour)
+}
+
+// newAllay returns a Allay that wraps the *Ent passed.
+func newAllay(e *Ent) *Allay {
+	a := &Allay{Ent: e}
+	a.mobEntity = mobEntity{e: e, l: a}
+	return a
+}
+
+// modifyDamage prevents the Allay from being hurt by the player that gave it
+// its item.
+func (a *Allay) modifyDamage(dmg float64, src world.DamageSource) (float64, bool) {
+	return dmg, !a.behaviour().likedBy(src)
+}
+
+// kill drops the items that the allay was holding, along with the loot of
+// its loot table, if it has one.
+func (a *Allay) kill(src world.DamageSource) {
+	b := a.behaviour()
+	for _, it := range []item.Stack{b.item, b.inventory} {
+		if !it.Empty() {
+			a.tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: a.Position()}, it))
+		}
+	}
+	b.item, b.inventory = item.Stack{}, item.Stack{}
+	dropLoot(a, src, a.tx)
+}
+
+// HeldItems returns the item that the Allay was given by a player. The Allay
+// never holds an item in its off-hand.
+func (a *Allay) HeldItems() (mainHand, offHand item.Stack) {
+	return a.behaviour().item, item.Stack{}
+}
+
+// Inventory returns the items that the Allay has collected and not yet
+// delivered.
+func (a *Allay) Inventory() item.Stack {
+	return a.behaviour().inventory
+}
+
+// Dancing checks if the Allay is dancing to a jukebox playing nearby.
+func (a *Allay) Dancing() bool {
+	return a.behaviour().dancing
+}
+
+// Collect collects as much of the stack passed as fits in the inventory of
+// the Allay, provided the stack matches the item it was given.
+func (a *Allay) Collect(stack item.Stack) (int, bool) {
+	return a.behaviour().collect(a, stack)
+}
+
+// Interact gives the Allay the item held by the user if the Allay is not yet
+// holding an item, or gives the item held by the Allay back to the user if
+// the user's hand is empty. A dancing Allay given an amethyst shard is
+// duplicated.
+func (a *Allay) Interact(user item.User, tx *world.Tx, ctx *item.UseContext) bool {
+	if a.Dead() {
+		return false
+	}
+	return a.behaviour().interact(a, user, tx, ctx)
+}
+
+// NotePlayed makes the Allay deliver the items it collects to the note block
+// at the position passed for the next 30 seconds, if it was given an item by a
+// player.
+func (a *Allay) NotePlayed(pos cube.Pos, _ *world.Tx) {
+	if b := a.behaviour(); b.liked != uuid.Nil {
+		b.noteBlock, b.noteBlockTicks = pos, allayNoteBlockTicks
+	}
+}
+
+// JukeboxPlayed makes the Allay dance for as long as the jukebox at the
+// position passed plays a music disc.
+func (a *Allay) JukeboxPlayed(pos cube.Pos, _ *world.Tx) {
+	b := a.behaviour()
+	b.jukebox = pos
+	if !b.dancing {
+		b.setDancing(a, true)
+	}
+}
+
+// AllayType is a world.EntityType implementation for Allay.
+var AllayType allayType
+
+type allayType struct{}
+
+func (t allayType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newAllay(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (allayType) EncodeEntity() string { return "minecraft:allay" }
+func (allayType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.175, 0, -0.175, 0.175, 0.6, 0.175)
+}
+
+func (allayType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := allayConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	b := conf.New()
+	b.item = nbtconv.MapItem(m, "HandItem")
+	b.inventory = nbtconv.MapItem(m, "Inventory")
+	b.liked, _ = uuid.Parse(nbtconv.String(m, "LikedPlayer"))
+	b.duplicationCooldown = int(nbtconv.Int64(m, "DuplicationCooldown"))
+	data.Data = b
+}
+
+func (allayType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*AllayBehaviour)
+	m := map[string]any{
+		"Health":              float32(b.health.Health()),
+		"DuplicationCooldown": int64(b.duplicationCooldown),
+	}
+	if !b.item.Empty() {
+		m["HandItem"] = nbtconv.WriteItem(b.item, true)
+	}
+	if !b.inventory.Empty() {
+		m["Inventory"] = nbtconv.WriteItem(b.inventory, true)
+	}
+	if b.liked != uuid.Nil {
+		m["LikedPlayer"] = b.liked.String()
+	}
+	return m
+}
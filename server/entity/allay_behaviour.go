@@ -0,0 +1,297 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+)
+
+const (
+	// allayNoteBlockTicks is the number of ticks that an allay delivers its
+	// items to a note block after hearing it.
+	allayNoteBlockTicks = 600
+	// allayPickupCooldown is the number of ticks after delivering items
+	// during which an allay does not collect new items.
+	allayPickupCooldown = 60
+	// allayDuplicationCooldown is the number of ticks after duplicating
+	// during which an allay cannot be duplicated again.
+	allayDuplicationCooldown = 6000
+)
+
+// AllayBehaviourConfig holds optional parameters for an AllayBehaviour.
+type AllayBehaviourConfig struct {
+	// Health is the health that the allay has when it is created.
+	Health float64
+}
+
+func (conf AllayBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates an AllayBehaviour using the parameters in conf.
+func (conf AllayBehaviourConfig) New() *AllayBehaviour {
+	return &AllayBehaviour{
+		mc:  &MovementComputer{},
+		mob: newMob(conf.Health, 0.15),
+	}
+}
+
+// AllayBehaviour implements the behaviour of an Allay. An allay that was given
+// an item by a player looks for dropped items of the same type, collects them
+// and delivers them to the player, or to the note block it last heard.
+type AllayBehaviour struct {
+	mc *MovementComputer
+	mob
+
+	// item is the item that the allay was given by the player with the UUID
+	// liked. inventory holds the items it collected that match this item.
+	item, inventory item.Stack
+	liked           uuid.UUID
+	// target is the item entity that the allay is flying towards.
+	target *world.EntityHandle
+
+	noteBlock      cube.Pos
+	noteBlockTicks int
+	jukebox        cube.Pos
+	dancing        bool
+
+	pickupCooldown      int
+	duplicationCooldown int
+}
+
+// Tick moves the allay towards the items it collects or the target it
+// delivers them to.
+func (b *AllayBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	a := newAllay(e)
+	if !a.tickMob(tx) {
+		return nil
+	}
+	if b.pickupCooldown > 0 {
+		b.pickupCooldown--
+	}
+	if b.duplicationCooldown > 0 {
+		b.duplicationCooldown--
+	}
+	if b.noteBlockTicks > 0 {
+		if _, ok := tx.Block(b.noteBlock).(block.Note); !ok {
+			b.noteBlockTicks = 0
+		}
+		b.noteBlockTicks--
+	}
+	b.tickDancing(a, tx)
+
+	pos, vel := e.Position(), e.Velocity()
+	if dest, ok := b.destination(a, tx); ok {
+		// Slow down when close to the destination, so that the allay does not
+		// overshoot it.
+		dir := dest.Sub(pos)
+		if dist := dir.Len(); dist > mgl64.Epsilon {
+			vel = vel.Add(dir.Normalize().Mul(b.speed * math.Min(dist, 1)).Sub(vel).Mul(0.2))
+		}
+	} else {
+		vel = vel.Mul(0.9)
+	}
+	rot := e.Rotation()
+	if math.Hypot(vel[0], vel[2]) > 0.01 {
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])), 0}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// destination returns the position that the allay should fly to. If the allay
+// has nothing to do, false is returned and the allay hovers in place.
+func (b *AllayBehaviour) destination(a *Allay, tx *world.Tx) (mgl64.Vec3, bool) {
+	if b.dancing || b.item.Empty() {
+		return mgl64.Vec3{}, false
+	}
+	if it, ok := b.findItem(a, tx); ok {
+		return it.Position(), true
+	}
+	pos := a.Position()
+	if b.noteBlockTicks > 0 {
+		dest := b.noteBlock.Side(cube.FaceUp).Vec3Middle()
+		if !b.inventory.Empty() && dest.Sub(pos).Len() < 2.5 {
+			b.deliver(a, tx, dest)
+		}
+		return dest.Add(mgl64.Vec3{0, 1}), true
+	}
+	p, ok := b.likedPlayer(a, tx)
+	if !ok {
+		return mgl64.Vec3{}, false
+	}
+	dest := p.Position().Add(mgl64.Vec3{0, 1.5})
+	dist := dest.Sub(pos).Len()
+	if !b.inventory.Empty() {
+		if dist < 2.5 {
+			b.deliver(a, tx, dest)
+		}
+		return dest, true
+	}
+	// Keep some distance from the player when there are no items to deliver.
+	return dest, dist > 4
+}
+
+// findItem returns the item entity that the allay is flying towards to
+// collect. A new item entity is looked for every half second.
+func (b *AllayBehaviour) findItem(a *Allay, tx *world.Tx) (world.Entity, bool) {
+	if b.pickupCooldown > 0 || b.inventory.Count() >= b.item.MaxCount() {
+		b.target = nil
+		return nil, false
+	}
+	if b.target != nil {
+		if ent, ok := b.target.Entity(tx); ok && ent.Position().Sub(a.Position()).Len() <= 32 {
+			return ent, true
+		}
+		b.target = nil
+	}
+	if a.Age()%(time.Second/2) != 0 {
+		return nil, false
+	}
+	pos := a.Position()
+	var (
+		nearest world.Entity
+		dist    = 32.0
+	)
+	for ent := range tx.EntitiesWithin(cube.Box(-32, -32, -32, 32, 32, 32).Translate(pos)) {
+		if ent.H().Type() != ItemType {
+			continue
+		}
+		if it := ent.(*Ent).Behaviour().(*ItemBehaviour).Item(); !it.Comparable(b.item) {
+			continue
+		}
+		if d := ent.Position().Sub(pos).Len(); d <= dist {
+			nearest, dist = ent, d
+		}
+	}
+	if nearest == nil {
+		return nil, false
+	}
+	b.target = nearest.H()
+	return nearest, true
+}
+
+// likedPlayer returns the player that gave the allay its item, if they are in
+// the same world and within 64 blocks of the allay.
+func (b *AllayBehaviour) likedPlayer(a *Allay, tx *world.Tx) (world.Entity, bool) {
+	if b.liked == uuid.Nil {
+		return nil, false
+	}
+	for p := range tx.Players() {
+		if p.H().UUID() == b.liked && p.Position().Sub(a.Position()).Len() <= 64 {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// likedBy checks if the damage source passed was caused by the player that
+// gave the allay its item.
+func (b *AllayBehaviour) likedBy(src world.DamageSource) bool {
+	var attacker world.Entity
+	switch src := src.(type) {
+	case AttackDamageSource:
+		attacker = src.Attacker
+	case ProjectileDamageSource:
+		attacker = src.Owner
+	}
+	return attacker != nil && b.liked != uuid.Nil && attacker.H().UUID() == b.liked
+}
+
+// collect adds as much of the stack passed to the inventory of the allay as
+// possible and returns the count added.
+func (b *AllayBehaviour) collect(a *Allay, stack item.Stack) (int, bool) {
+	if a.Dead() || b.item.Empty() || b.pickupCooldown > 0 || !stack.Comparable(b.item) {
+		return 0, false
+	}
+	n := min(stack.Count(), b.item.MaxCount()-b.inventory.Count())
+	if n <= 0 {
+		return 0, true
+	}
+	if b.inventory.Empty() {
+		b.inventory = stack.Grow(n - stack.Count())
+	} else {
+		b.inventory = b.inventory.Grow(n)
+	}
+	return n, true
+}
+
+// deliver throws the items in the inventory of the allay towards the position
+// passed.
+func (b *AllayBehaviour) deliver(a *Allay, tx *world.Tx, to mgl64.Vec3) {
+	if b.inventory.Empty() {
+		return
+	}
+	pos := a.Position().Add(mgl64.Vec3{0, 0.3})
+	var vel mgl64.Vec3
+	if dir := to.Sub(pos); dir.Len() > mgl64.Epsilon {
+		vel = dir.Normalize().Mul(0.3)
+	}
+	tx.AddEntity(NewItemPickupDelay(world.EntitySpawnOpts{Position: pos, Velocity: vel}, b.inventory, time.Second/2))
+	b.inventory, b.pickupCooldown = item.Stack{}, allayPickupCooldown
+}
+
+// interact handles a user interacting with the allay.
+func (b *AllayBehaviour) interact(a *Allay, user item.User, tx *world.Tx, ctx *item.UseContext) bool {
+	held, _ := user.HeldItems()
+	if _, ok := held.Item().(item.AmethystShard); ok && b.dancing && b.duplicationCooldown <= 0 {
+		b.duplicate(a, tx)
+		ctx.SubtractFromCount(1)
+		return true
+	}
+	switch {
+	case b.item.Empty() && !held.Empty():
+		b.item, b.liked = held.Grow(1-held.Count()), user.H().UUID()
+		ctx.SubtractFromCount(1)
+	case !b.item.Empty() && held.Empty():
+		b.deliver(a, tx, user.Position())
+		ctx.NewItem = b.item
+		b.item, b.liked, b.target, b.noteBlockTicks = item.Stack{}, uuid.Nil, nil, 0
+	default:
+		return false
+	}
+	for _, v := range tx.Viewers(a.Position()) {
+		v.ViewEntityItems(a)
+	}
+	return true
+}
+
+// duplicate spawns a new allay at the position of the allay. Both allays
+// cannot be duplicated again for five minutes.
+func (b *AllayBehaviour) duplicate(a *Allay, tx *world.Tx) {
+	b.duplicationCooldown = allayDuplicationCooldown
+	if dup, ok := tx.AddEntity(NewAllay(world.EntitySpawnOpts{Position: a.Position()})).(*Allay); ok {
+		dup.behaviour().duplicationCooldown = allayDuplicationCooldown
+	}
+}
+
+// tickDancing stops the allay from dancing once the jukebox it is dancing to
+// stops playing or is too far away.
+func (b *AllayBehaviour) tickDancing(a *Allay, tx *world.Tx) {
+	if !b.dancing {
+		return
+	}
+	j, ok := tx.Block(b.jukebox).(block.Jukebox)
+	if ok {
+		_, ok = j.Disc()
+	}
+	if !ok || b.jukebox.Vec3Centre().Sub(a.Position()).Len() > 10 {
+		b.setDancing(a, false)
+	}
+}
+
+// setDancing changes if the allay is dancing and updates its state for
+// viewers.
+func (b *AllayBehaviour) setDancing(a *Allay, v bool) {
+	b.dancing = v
+	for _, viewer := range a.tx.Viewers(a.Position()) {
+		viewer.ViewEntityState(a)
+	}
+}
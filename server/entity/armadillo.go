@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// NewArmadillo creates an armadillo.
+func NewArmadillo(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(ArmadilloType, armadilloConf)
+}
+
+var armadilloConf = ArmadilloBehaviourConfig{
+	Health: 12,
+}
+
+// ArmadilloThreat represents an entity that may scare armadillos, such as a
+// sprinting player.
+type ArmadilloThreat interface {
+	world.Entity
+	// ScaresArmadillos checks if the entity currently scares armadillos near
+	// it, making them roll up.
+	ScaresArmadillos() bool
+}
+
+// Armadillo is a passive mob that rolls up into its shell when threatened,
+// protecting it from most damage. Armadillos periodically shed scutes, and
+// drop a scute when brushed. Armadillo implements the Living and Interactable
+// interfaces.
+type Armadillo struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the ArmadilloBehaviour of the Armadillo.
+func (a *Armadillo) behaviour() *ArmadilloBehaviour {
+	return a.data.Data.(*ArmadilloBehaviour)
+}
+
+// newArmadillo returns a Armadillo that wraps the *Ent passed.
+func newArmadillo(e *Ent) *Armadillo {
+	a := &Armadillo{Ent: e}
+	a.mobEntity = mobEntity{e: e, l: a}
+	return a
+}
+
+// RolledUp checks if the Armadillo is currently rolled up in its shell.
+func (a *Armadillo) RolledUp() bool {
+	return a.behaviour().rolled
+}
+
+// modifyDamage makes a rolled up Armadillo take less than half of the damage
+// dealt by sources reduced by armour.
+func (a *Armadillo) modifyDamage(dmg float64, src world.DamageSource) (float64, bool) {
+	if a.behaviour().rolled && src.ReducedByArmour() && dmg > 0 {
+		dmg = max(0, (dmg-1)/2)
+	}
+	return dmg, true
+}
+
+// afterHurt makes the Armadillo roll up.
+func (a *Armadillo) afterHurt(float64, world.DamageSource) {
+	a.behaviour().rollUp(a, a.tx)
+}
+
+// kill drops the loot and experience of the armadillo.
+func (a *Armadillo) kill(src world.DamageSource) {
+	dropLoot(a, src, a.tx)
+	dropExperience(a.Position(), 1+rand.IntN(3), a.tx)
+}
+
+// Interact makes the Armadillo drop a scute if the user brushes it. Brushing
+// an Armadillo costs 16 durability of the brush.
+func (a *Armadillo) Interact(user item.User, tx *world.Tx, ctx *item.UseContext) bool {
+	held, _ := user.HeldItems()
+	if _, ok := held.Item().(item.Brush); !ok || a.Dead() {
+		return false
+	}
+	a.behaviour().dropScute(a, tx)
+	tx.PlaySound(a.Position(), sound.ArmadilloBrush{})
+	ctx.DamageItem(16)
+	return true
+}
+
+// ArmadilloType is a world.EntityType implementation for Armadillo.
+var ArmadilloType armadilloType
+
+type armadilloType struct{}
+
+func (armadilloType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newArmadillo(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (armadilloType) EncodeEntity() string { return "minecraft:armadillo" }
+func (armadilloType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.35, 0, -0.35, 0.35, 0.65, 0.35)
+}
+
+func (armadilloType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := armadilloConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	b := conf.New()
+	if t := int(nbtconv.Int32(m, "ScuteTime")); t > 0 {
+		b.scuteTicks = t
+	}
+	data.Data = b
+}
+
+func (armadilloType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*ArmadilloBehaviour)
+	return map[string]any{"Health": float32(b.health.Health()), "ScuteTime": int32(b.scuteTicks)}
+}
@@ -0,0 +1,146 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// armadilloScaredTicks is the number of ticks that an armadillo stays
+	// rolled up for after the last threat has left.
+	armadilloScaredTicks = 60
+	// armadilloScuteLootTable is the loot table used for scutes dropped by
+	// armadillos.
+	armadilloScuteLootTable = "entities/armadillo_brush.json"
+)
+
+// ArmadilloBehaviourConfig holds optional parameters for an
+// ArmadilloBehaviour.
+type ArmadilloBehaviourConfig struct {
+	// Health is the health that the armadillo has when it is created.
+	Health float64
+}
+
+func (conf ArmadilloBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates an ArmadilloBehaviour using the parameters in conf.
+func (conf ArmadilloBehaviourConfig) New() *ArmadilloBehaviour {
+	return &ArmadilloBehaviour{
+		mc:         &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:        newMob(conf.Health, 0.14),
+		scuteTicks: nextScuteTicks(),
+	}
+}
+
+// ArmadilloBehaviour implements the behaviour of an Armadillo. Armadillos
+// wander around and roll up when they are hurt or an ArmadilloThreat comes
+// near. Every five to ten minutes, an armadillo sheds a scute.
+type ArmadilloBehaviour struct {
+	mc *MovementComputer
+	mob
+
+	// rolled is true while the armadillo is rolled up. scaredTicks is the
+	// number of ticks until the armadillo unrolls if it is not threatened.
+	rolled      bool
+	scaredTicks int
+	// scuteTicks is the number of ticks until the armadillo sheds a scute.
+	scuteTicks int
+
+	dest        mgl64.Vec3
+	wanderTicks int
+}
+
+// Tick makes the armadillo wander around, roll up when threatened and shed
+// scutes.
+func (b *ArmadilloBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	a := newArmadillo(e)
+	if !a.tickMob(tx) {
+		return nil
+	}
+	if b.scuteTicks--; b.scuteTicks <= 0 {
+		b.scuteTicks = nextScuteTicks()
+		b.dropScute(a, tx)
+	}
+
+	pos, vel := e.Position(), e.Velocity()
+	if a.Age()%(time.Second/2) == 0 && b.threatened(a, tx) {
+		b.rollUp(a, tx)
+	}
+	if b.rolled {
+		if b.scaredTicks--; b.scaredTicks <= 0 {
+			b.rolled = false
+			b.updateState(a, tx)
+		}
+	} else if b.mc.OnGround() {
+		if b.wanderTicks--; b.wanderTicks <= 0 {
+			b.wanderTicks = 80 + rand.IntN(120)
+			b.dest = pos.Add(mgl64.Vec3{rand.Float64()*12 - 6, 0, rand.Float64()*12 - 6})
+		}
+		if dir := (mgl64.Vec3{b.dest[0] - pos[0], 0, b.dest[2] - pos[2]}); dir.Len() > 1 {
+			vel = dir.Normalize().Mul(b.speed).Add(mgl64.Vec3{0, vel[1]})
+		}
+	}
+	rot := e.Rotation()
+	if math.Hypot(vel[0], vel[2]) > 0.01 {
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])), 0}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// threatened checks if an ArmadilloThreat that currently scares armadillos is
+// within 7 blocks of the armadillo.
+func (b *ArmadilloBehaviour) threatened(a *Armadillo, tx *world.Tx) bool {
+	pos := a.Position()
+	for ent := range tx.EntitiesWithin(cube.Box(-7, -2, -7, 7, 2, 7).Translate(pos)) {
+		if t, ok := ent.(ArmadilloThreat); ok && t.ScaresArmadillos() {
+			return true
+		}
+	}
+	return false
+}
+
+// rollUp makes the armadillo roll up, or keeps it rolled up for a while
+// longer if it already was.
+func (b *ArmadilloBehaviour) rollUp(a *Armadillo, tx *world.Tx) {
+	b.scaredTicks = armadilloScaredTicks
+	if b.rolled {
+		return
+	}
+	b.rolled = true
+	a.data.Vel = mgl64.Vec3{0, a.data.Vel[1]}
+	b.updateState(a, tx)
+}
+
+// dropScute makes the armadillo drop an armadillo scute.
+func (b *ArmadilloBehaviour) dropScute(a *Armadillo, tx *world.Tx) {
+	pos := a.Position()
+	stacks, _ := loot.Generate(armadilloScuteLootTable)
+	for _, stack := range stacks {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos.Add(mgl64.Vec3{0, 0.3})}, stack))
+	}
+	tx.PlaySound(pos, sound.ArmadilloScuteDrop{})
+}
+
+// updateState sends the state of the armadillo to its viewers.
+func (b *ArmadilloBehaviour) updateState(a *Armadillo, tx *world.Tx) {
+	for _, v := range tx.Viewers(a.Position()) {
+		v.ViewEntityState(a)
+	}
+}
+
+// nextScuteTicks returns a random number of ticks between five and ten
+// minutes until an armadillo sheds its next scute.
+func nextScuteTicks() int {
+	return 6000 + rand.IntN(6000)
+}
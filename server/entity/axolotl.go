@@ -0,0 +1,247 @@
+package entity
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// NewAxolotl creates an axolotl of the variant passed.
+func NewAxolotl(opts world.EntitySpawnOpts, variant AxolotlVariant) *world.EntityHandle {
+	conf := axolotlConf
+	conf.Variant = variant
+	return opts.New(AxolotlType, conf)
+}
+
+var axolotlConf = AxolotlBehaviourConfig{
+	Health:       14,
+	AttackDamage: 2,
+}
+
+// AxolotlPrey represents a Living aquatic entity that axolotls attack, such
+// as a drowned, guardian or tadpole.
+type AxolotlPrey interface {
+	Living
+	// AxolotlHostile checks if the entity is a hostile mob that axolotls
+	// always attack. Other prey is only hunted if the axolotl has not killed
+	// any prey in the last two minutes.
+	AxolotlHostile() bool
+}
+
+// KillSupporter represents an entity that supports others in combat. A
+// KillSupporter is notified when a Living entity kills another entity near
+// it.
+type KillSupporter interface {
+	world.Entity
+	// SupportKill is called when the killer passed kills the victim passed
+	// near the KillSupporter.
+	SupportKill(killer Living, victim world.Entity, tx *world.Tx)
+}
+
+// Axolotl is a passive aquatic mob that hunts AxolotlPrey underwater. When hurt
+// badly, an axolotl may play dead to regenerate, and players that kill the
+// prey an axolotl is fighting are granted Regeneration. Axolotls may be
+// captured in a water bucket. Axolotl implements the Living, Interactable and
+// KillSupporter interfaces.
+type Axolotl struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the AxolotlBehaviour of the Axolotl.
+func (a *Axolotl) behaviour() *AxolotlBehaviour {
+	return a.data.Data.(*AxolotlBehaviour)
+}
+
+// newAxolotl returns a Axolotl that wraps the *Ent passed.
+func newAxolotl(e *Ent) *Axolotl {
+	a := &Axolotl{Ent: e}
+	a.mobEntity = mobEntity{e: e, l: a}
+	return a
+}
+
+// Variant returns the AxolotlVariant of the Axolotl as an int32, as it is
+// shown to viewers.
+func (a *Axolotl) Variant() int32 {
+	return int32(a.behaviour().variant.Uint8())
+}
+
+// AxolotlVariant returns the AxolotlVariant of the Axolotl.
+func (a *Axolotl) AxolotlVariant() AxolotlVariant {
+	return a.behaviour().variant
+}
+
+// PlayingDead checks if the Axolotl is currently playing dead.
+func (a *Axolotl) PlayingDead() bool {
+	return a.behaviour().playDeadTicks > 0
+}
+
+// afterHurt might make an Axolotl hurt in water start playing dead.
+func (a *Axolotl) afterHurt(dmg float64, _ world.DamageSource) {
+	a.behaviour().maybePlayDead(a, dmg)
+}
+
+// kill drops the loot and experience of the axolotl.
+func (a *Axolotl) kill(src world.DamageSource) {
+	dropLoot(a, src, a.tx)
+	dropExperience(a.Position(), 1+rand.IntN(3), a.tx)
+}
+
+// Interact captures the Axolotl in a bucket if the user uses a water bucket on
+// it. The variant and health of the Axolotl are kept in the bucket.
+func (a *Axolotl) Interact(user item.User, tx *world.Tx, ctx *item.UseContext) bool {
+	held, _ := user.HeldItems()
+	bucket, ok := held.Item().(item.Bucket)
+	if !ok || a.Dead() {
+		return false
+	}
+	if liq, ok := bucket.Content.Liquid(); !ok || liq.LiquidType() != "water" {
+		return false
+	}
+	if _, _, ok := bucket.Content.Entity(); ok {
+		return false
+	}
+	data := AxolotlType.EncodeNBT(a.data)
+	if name := a.NameTag(); name != "" {
+		data["CustomName"] = name
+	}
+	ctx.NewItem = item.NewStack(item.Bucket{Content: item.EntityBucketContent(block.Water{}, AxolotlType.EncodeEntity(), data)}, 1)
+	ctx.SubtractFromCount(1)
+	tx.PlaySound(a.Position(), sound.BucketFill{Liquid: block.Water{}})
+	_ = a.Close()
+	return true
+}
+
+// SupportKill grants the killer passed Regeneration if the victim was the
+// target of the Axolotl and removes Mining Fatigue from it. The duration of
+// the Regeneration stacks up to two minutes.
+func (a *Axolotl) SupportKill(killer Living, victim world.Entity, _ *world.Tx) {
+	b := a.behaviour()
+	if b.target == nil || b.target != victim.H() {
+		return
+	}
+	if _, ok := killer.(*Axolotl); ok {
+		return
+	}
+	dur := time.Second * 5
+	for _, e := range killer.Effects() {
+		if e.Type() == effect.Regeneration {
+			dur += e.Duration()
+		}
+	}
+	killer.AddEffect(effect.New(effect.Regeneration, 1, min(dur, time.Minute*2)))
+	killer.RemoveEffect(effect.MiningFatigue)
+}
+
+// AxolotlVariant represents a variant of an Axolotl, determining its colour.
+type AxolotlVariant struct {
+	axolotlVariant
+}
+
+type axolotlVariant uint8
+
+// LucyAxolotl is the pink variant of an axolotl.
+func LucyAxolotl() AxolotlVariant {
+	return AxolotlVariant{0}
+}
+
+// CyanAxolotl is the cyan variant of an axolotl.
+func CyanAxolotl() AxolotlVariant {
+	return AxolotlVariant{1}
+}
+
+// GoldAxolotl is the gold variant of an axolotl.
+func GoldAxolotl() AxolotlVariant {
+	return AxolotlVariant{2}
+}
+
+// WildAxolotl is the brown variant of an axolotl.
+func WildAxolotl() AxolotlVariant {
+	return AxolotlVariant{3}
+}
+
+// BlueAxolotl is the rare blue variant of an axolotl.
+func BlueAxolotl() AxolotlVariant {
+	return AxolotlVariant{4}
+}
+
+// AxolotlVariants returns all axolotl variants.
+func AxolotlVariants() []AxolotlVariant {
+	return []AxolotlVariant{LucyAxolotl(), CyanAxolotl(), GoldAxolotl(), WildAxolotl(), BlueAxolotl()}
+}
+
+// RandomAxolotlVariant returns a random AxolotlVariant. The blue variant only
+// has a 1 in 1200 chance of being returned, while the other variants are
+// equally likely.
+func RandomAxolotlVariant() AxolotlVariant {
+	if rand.IntN(1200) == 0 {
+		return BlueAxolotl()
+	}
+	return AxolotlVariants()[rand.IntN(4)]
+}
+
+// Uint8 returns the axolotl variant as a uint8.
+func (v axolotlVariant) Uint8() uint8 {
+	return uint8(v)
+}
+
+// String ...
+func (v axolotlVariant) String() string {
+	switch v {
+	case 1:
+		return "cyan"
+	case 2:
+		return "gold"
+	case 3:
+		return "wild"
+	case 4:
+		return "blue"
+	}
+	return "lucy"
+}
+
+// AxolotlType is a world.EntityType implementation for Axolotl.
+var AxolotlType axolotlType
+
+type axolotlType struct{}
+
+func (axolotlType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newAxolotl(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (axolotlType) EncodeEntity() string { return "minecraft:axolotl" }
+func (axolotlType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.375, 0, -0.375, 0.375, 0.42, 0.375)
+}
+
+func (axolotlType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := axolotlConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	if v := nbtconv.Int32(m, "Variant"); v >= 0 && int(v) < len(AxolotlVariants()) {
+		conf.Variant = AxolotlVariants()[v]
+	}
+	if name := nbtconv.String(m, "CustomName"); name != "" {
+		data.Name = name
+	}
+	b := conf.New()
+	b.huntCooldown = int(nbtconv.Int32(m, "HuntCooldown"))
+	data.Data = b
+}
+
+func (axolotlType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*AxolotlBehaviour)
+	return map[string]any{
+		"Health":       float32(b.health.Health()),
+		"Variant":      int32(b.variant.Uint8()),
+		"HuntCooldown": int32(b.huntCooldown),
+	}
+}
@@ -0,0 +1,207 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// axolotlPlayDeadTicks is the number of ticks that an axolotl plays dead
+	// for.
+	axolotlPlayDeadTicks = 200
+	// axolotlHuntCooldown is the number of ticks after killing prey during
+	// which an axolotl only attacks hostile mobs.
+	axolotlHuntCooldown = 2400
+)
+
+// AxolotlBehaviourConfig holds optional parameters for an AxolotlBehaviour.
+type AxolotlBehaviourConfig struct {
+	// Variant is the variant of the axolotl.
+	Variant AxolotlVariant
+	// Health is the health that the axolotl has when it is created.
+	Health float64
+	// AttackDamage is the damage dealt by the axolotl to its prey.
+	AttackDamage float64
+}
+
+func (conf AxolotlBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates an AxolotlBehaviour using the parameters in conf.
+func (conf AxolotlBehaviourConfig) New() *AxolotlBehaviour {
+	return &AxolotlBehaviour{
+		conf:    conf,
+		mc:      &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:     newMob(conf.Health, 0.1),
+		variant: conf.Variant,
+	}
+}
+
+// AxolotlBehaviour implements the behaviour of an Axolotl. Axolotls swim
+// around in water, crawl slowly on land and attack AxolotlPrey nearby. An
+// axolotl that is hurt in water might play dead for ten seconds, during which
+// it regenerates health and is left alone by its attackers.
+type AxolotlBehaviour struct {
+	conf AxolotlBehaviourConfig
+	mc   *MovementComputer
+	mob
+	variant AxolotlVariant
+
+	dest        mgl64.Vec3
+	wanderTicks int
+
+	// target is the prey that the axolotl is attacking.
+	target         *world.EntityHandle
+	attackCooldown int
+	huntCooldown   int
+	playDeadTicks  int
+}
+
+// Tick makes the axolotl swim around and attack its prey.
+func (b *AxolotlBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	a := newAxolotl(e)
+	if !a.tickMob(tx) {
+		return nil
+	}
+	if b.attackCooldown > 0 {
+		b.attackCooldown--
+	}
+	if b.huntCooldown > 0 {
+		b.huntCooldown--
+	}
+
+	pos, vel := e.Position(), e.Velocity()
+	swimming := inWater(pos, tx)
+	b.mc.Gravity = 0.08
+	if swimming {
+		b.mc.Gravity = 0.005
+	}
+
+	if b.playDeadTicks > 0 {
+		if b.playDeadTicks--; b.playDeadTicks == 0 {
+			b.updateState(a, tx)
+		}
+	} else if dest, ok := b.destination(a, swimming, tx); ok {
+		dir := dest.Sub(pos)
+		if swimming {
+			vel = vel.Add(dir.Normalize().Mul(b.speed * 1.5).Sub(vel).Mul(0.1))
+		} else if b.mc.OnGround() {
+			// Axolotls crawl slowly when out of water.
+			dir[1] = 0
+			if dir.Len() > mgl64.Epsilon {
+				vel = dir.Normalize().Mul(b.speed * 0.3).Add(mgl64.Vec3{0, vel[1]})
+			}
+		}
+	}
+	rot := e.Rotation()
+	if math.Hypot(vel[0], vel[2]) > 0.01 {
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])), 0}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// destination returns the position that the axolotl should move to. If the
+// axolotl has nowhere to go, false is returned.
+func (b *AxolotlBehaviour) destination(a *Axolotl, swimming bool, tx *world.Tx) (mgl64.Vec3, bool) {
+	pos := a.Position()
+	if swimming {
+		if prey, ok := b.findPrey(a, tx); ok {
+			if prey.Position().Sub(pos).Len() < 1.5 && b.attackCooldown == 0 {
+				b.attack(a, prey, tx)
+			}
+			return prey.Position(), true
+		}
+	}
+	if b.wanderTicks--; b.wanderTicks <= 0 {
+		b.wanderTicks = 60 + rand.IntN(100)
+		b.dest = pos.Add(mgl64.Vec3{rand.Float64()*12 - 6, rand.Float64()*4 - 2, rand.Float64()*12 - 6})
+	}
+	if swimming && !inWater(b.dest, tx) {
+		// Don't swim out of the water.
+		b.dest = pos
+	}
+	return b.dest, b.dest.Sub(pos).Len() > 1
+}
+
+// findPrey returns the AxolotlPrey that the axolotl is attacking. A new prey
+// in water is looked for once every second within 8 blocks of the axolotl.
+func (b *AxolotlBehaviour) findPrey(a *Axolotl, tx *world.Tx) (AxolotlPrey, bool) {
+	pos := a.Position()
+	if b.target != nil {
+		if ent, ok := b.target.Entity(tx); ok {
+			if prey, ok := ent.(AxolotlPrey); ok && !prey.Dead() && ent.Position().Sub(pos).Len() <= 16 {
+				return prey, true
+			}
+		}
+		b.target = nil
+	}
+	if a.Age()%time.Second != 0 {
+		return nil, false
+	}
+	var (
+		nearest AxolotlPrey
+		dist    = 8.0
+	)
+	for ent := range tx.EntitiesWithin(cube.Box(-8, -4, -8, 8, 4, 8).Translate(pos)) {
+		prey, ok := ent.(AxolotlPrey)
+		if !ok || prey.Dead() || !inWater(ent.Position(), tx) || (!prey.AxolotlHostile() && b.huntCooldown > 0) {
+			continue
+		}
+		if d := ent.Position().Sub(pos).Len(); d <= dist {
+			nearest, dist = prey, d
+		}
+	}
+	if nearest == nil {
+		return nil, false
+	}
+	b.target = nearest.H()
+	return nearest, true
+}
+
+// attack makes the axolotl attack the prey passed. If the prey is killed and
+// was not a hostile mob, the axolotl stops hunting for two minutes.
+func (b *AxolotlBehaviour) attack(a *Axolotl, prey AxolotlPrey, tx *world.Tx) {
+	b.attackCooldown = 20
+	if _, ok := prey.Hurt(b.conf.AttackDamage, AttackDamageSource{Attacker: a}); !ok {
+		return
+	}
+	prey.KnockBack(a.Position(), 0.4, 0.1)
+	if prey.Dead() {
+		if !prey.AxolotlHostile() {
+			b.huntCooldown = axolotlHuntCooldown
+		}
+		b.target = nil
+	}
+}
+
+// maybePlayDead makes the axolotl start playing dead after being hurt for the
+// damage passed, with a chance of one in three. Axolotls only play dead in
+// water, when the damage was high or their health is low.
+func (b *AxolotlBehaviour) maybePlayDead(a *Axolotl, dmg float64) {
+	if b.playDeadTicks > 0 || !inWater(a.Position(), a.tx) || rand.IntN(3) != 0 {
+		return
+	}
+	if float64(rand.IntN(3)) >= dmg && a.Health()/a.MaxHealth() >= 0.5 {
+		return
+	}
+	b.playDeadTicks, b.target = axolotlPlayDeadTicks, nil
+	a.AddEffect(effect.New(effect.Regeneration, 1, time.Second*10))
+	b.updateState(a, a.tx)
+}
+
+// updateState sends the state of the axolotl to its viewers, updating whether
+// it is playing dead.
+func (b *AxolotlBehaviour) updateState(a *Axolotl, tx *world.Tx) {
+	for _, v := range tx.Viewers(a.Position()) {
+		v.ViewEntityState(a)
+	}
+}
@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+)
+
+// NewBogged creates a bogged.
+func NewBogged(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(BoggedType, boggedConf)
+}
+
+var boggedConf = BoggedBehaviourConfig{
+	Health:        16,
+	ArrowDamage:   2,
+	ShootCooldown: 70,
+}
+
+// Bogged is a hostile skeleton variant found in swamps and trial chambers.
+// Bogged shoot arrows that poison the entity hit, but shoot more slowly than
+// skeletons. A bogged may be sheared once, making it drop mushrooms. Bogged
+// implements the Living and Interactable interfaces.
+type Bogged struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the BoggedBehaviour of the Bogged.
+func (b *Bogged) behaviour() *BoggedBehaviour {
+	return b.data.Data.(*BoggedBehaviour)
+}
+
+// newBogged returns a Bogged that wraps the *Ent passed.
+func newBogged(e *Ent) *Bogged {
+	b := &Bogged{Ent: e}
+	b.mobEntity = mobEntity{e: e, l: b}
+	return b
+}
+
+// Sheared checks if the Bogged has been sheared.
+func (b *Bogged) Sheared() bool {
+	return b.behaviour().sheared
+}
+
+// HeldItems returns the bow held by the Bogged.
+func (b *Bogged) HeldItems() (mainHand, offHand item.Stack) {
+	return item.NewStack(item.Bow{}, 1), item.Stack{}
+}
+
+// afterHurt makes the Bogged target the entity that hurt it.
+func (b *Bogged) afterHurt(_ float64, src world.DamageSource) {
+	b.behaviour().retaliate(src)
+}
+
+// kill drops the loot and experience of the bogged.
+func (bg *Bogged) kill(src world.DamageSource) {
+	dropLoot(bg, src, bg.tx)
+	dropExperience(bg.Position(), 5, bg.tx)
+}
+
+// AddEffect adds an effect.Effect to the Bogged. Like other undead mobs, the
+// Bogged is immune to poison.
+func (b *Bogged) AddEffect(e effect.Effect) {
+	if e.Type() == effect.Poison {
+		return
+	}
+	b.mobEntity.AddEffect(e)
+}
+
+// Interact shears the Bogged if the user is holding shears and the Bogged was
+// not yet sheared, making it drop mushrooms.
+func (b *Bogged) Interact(user item.User, tx *world.Tx, ctx *item.UseContext) bool {
+	held, _ := user.HeldItems()
+	if _, ok := held.Item().(item.Shears); !ok || b.Dead() || b.Sheared() {
+		return false
+	}
+	b.behaviour().shear(b, tx)
+	ctx.DamageItem(1)
+	return true
+}
+
+// BoggedCanSpawn checks if a bogged may spawn naturally at the position
+// passed. Bogged only spawn in swamps and mangrove swamps, on top of a solid
+// block at a position that is not lit by blocks and not exposed to daylight.
+func BoggedCanSpawn(pos cube.Pos, tx *world.Tx) bool {
+	switch tx.Biome(pos).(type) {
+	case biome.Swamp, biome.SwampHills, biome.MangroveSwamp:
+	default:
+		return false
+	}
+	if tx.BlockLight(pos) != 0 {
+		return false
+	}
+	if t := tx.World().Time() % world.TimeFull; (t < world.TimeSleep || t > world.TimeWake) && tx.SkyLight(pos) > 0 {
+		return false
+	}
+	if _, ok := tx.Block(pos).(block.Air); !ok {
+		return false
+	}
+	if _, ok := tx.Block(pos.Side(cube.FaceUp)).(block.Air); !ok {
+		return false
+	}
+	below := pos.Side(cube.FaceDown)
+	return tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx)
+}
+
+// BoggedType is a world.EntityType implementation for Bogged.
+var BoggedType boggedType
+
+type boggedType struct{}
+
+func (boggedType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newBogged(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (boggedType) EncodeEntity() string { return "minecraft:bogged" }
+func (boggedType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.99, 0.3)
+}
+
+func (boggedType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := boggedConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	b := conf.New()
+	b.sheared = nbtconv.Bool(m, "Sheared")
+	data.Data = b
+}
+
+func (boggedType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*BoggedBehaviour)
+	return map[string]any{"Health": float32(b.health.Health()), "Sheared": boolByte(b.sheared)}
+}
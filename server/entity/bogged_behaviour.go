@@ -0,0 +1,217 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// boggedRange is the range in blocks within which a bogged finds its
+	// target. boggedShootRange is the distance from its target at which a
+	// bogged stops walking towards it and starts shooting.
+	boggedRange, boggedShootRange = 16.0, 12.0
+	// boggedShearLootTable is the loot table used for the drops of a bogged
+	// that is sheared.
+	boggedShearLootTable = "entities/bogged_shear.json"
+)
+
+// BoggedBehaviourConfig holds optional parameters for a BoggedBehaviour.
+type BoggedBehaviourConfig struct {
+	// Health is the health that the bogged has when it is created.
+	Health float64
+	// ArrowDamage is the base damage of the arrows shot by the bogged.
+	ArrowDamage float64
+	// ShootCooldown is the number of ticks between two arrows shot by the
+	// bogged.
+	ShootCooldown int
+}
+
+func (conf BoggedBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a BoggedBehaviour using the parameters in conf.
+func (conf BoggedBehaviourConfig) New() *BoggedBehaviour {
+	return &BoggedBehaviour{
+		conf:          conf,
+		mc:            &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:           newMob(conf.Health, 0.2),
+		shootCooldown: conf.ShootCooldown,
+	}
+}
+
+// BoggedBehaviour implements the behaviour of a Bogged. A bogged wanders
+// around until it finds a target, after which it approaches the target and
+// shoots poison arrows at it. Bogged burn in sunlight.
+type BoggedBehaviour struct {
+	conf BoggedBehaviourConfig
+	mc   *MovementComputer
+	mob
+
+	sheared bool
+
+	target        *world.EntityHandle
+	shootCooldown int
+
+	dest        mgl64.Vec3
+	wanderTicks int
+}
+
+// Tick makes the bogged wander around or approach and shoot at its target,
+// and burns it if it is in sunlight.
+func (b *BoggedBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	bg := newBogged(e)
+	if !bg.tickMob(tx) {
+		return nil
+	}
+	b.burn(bg, tx)
+	if bg.Dead() {
+		return nil
+	}
+
+	pos, vel, rot := e.Position(), e.Velocity(), e.Rotation()
+	if target, ok := b.findTarget(bg, tx); ok {
+		delta := target.Position().Sub(pos)
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-delta[0], delta[2])), 0}
+		if dist := math.Hypot(delta[0], delta[2]); dist > boggedShootRange && b.mc.OnGround() {
+			vel = mgl64.Vec3{delta[0] / dist * b.speed, vel[1], delta[2] / dist * b.speed}
+		}
+		if b.shootCooldown--; b.shootCooldown <= 0 {
+			b.shootCooldown = b.conf.ShootCooldown
+			b.shoot(bg, target, tx)
+		}
+	} else if b.mc.OnGround() {
+		if b.wanderTicks--; b.wanderTicks <= 0 {
+			b.wanderTicks = 80 + rand.IntN(120)
+			b.dest = pos.Add(mgl64.Vec3{rand.Float64()*12 - 6, 0, rand.Float64()*12 - 6})
+		}
+		if dir := (mgl64.Vec3{b.dest[0] - pos[0], 0, b.dest[2] - pos[2]}); dir.Len() > 1 {
+			vel = dir.Normalize().Mul(b.speed).Add(mgl64.Vec3{0, vel[1]})
+			rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-dir[0], dir[2])), 0}
+		}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// findTarget returns the target of the bogged. If the bogged has no valid
+// target, it looks for the nearest player within range once every second.
+func (b *BoggedBehaviour) findTarget(bg *Bogged, tx *world.Tx) (Living, bool) {
+	pos := bg.Position()
+	if b.target != nil {
+		if ent, ok := b.target.Entity(tx); ok {
+			if l, ok := ent.(Living); ok && boggedCanTarget(l) && ent.Position().Sub(pos).Len() <= boggedRange*1.5 {
+				return l, true
+			}
+		}
+		b.target = nil
+	}
+	if bg.Age()%time.Second != 0 {
+		return nil, false
+	}
+	var (
+		nearest Living
+		dist    = boggedRange
+	)
+	for ent := range tx.Players() {
+		l, ok := ent.(Living)
+		if !ok || !boggedCanTarget(l) {
+			continue
+		}
+		if d := ent.Position().Sub(pos).Len(); d <= dist {
+			nearest, dist = l, d
+		}
+	}
+	if nearest == nil {
+		return nil, false
+	}
+	b.target = nearest.H()
+	return nearest, true
+}
+
+// boggedCanTarget checks if a bogged may target the Living entity passed.
+// Entities in a game mode that does not allow taking damage are never
+// targeted.
+func boggedCanTarget(l Living) bool {
+	if g, ok := l.(interface{ GameMode() world.GameMode }); ok && !g.GameMode().AllowsTakingDamage() {
+		return false
+	}
+	return !l.Dead()
+}
+
+// retaliate makes the bogged target the entity responsible for the damage
+// source passed, if any.
+func (b *BoggedBehaviour) retaliate(src world.DamageSource) {
+	var attacker world.Entity
+	switch s := src.(type) {
+	case AttackDamageSource:
+		attacker = s.Attacker
+	case ProjectileDamageSource:
+		attacker = s.Owner
+	}
+	if l, ok := attacker.(Living); ok && boggedCanTarget(l) {
+		b.target = l.H()
+	}
+}
+
+// shoot makes the bogged shoot a poison arrow at its target. The arrow is
+// aimed slightly above the target to make up for gravity.
+func (b *BoggedBehaviour) shoot(bg *Bogged, target Living, tx *world.Tx) {
+	pos := bg.Position().Add(mgl64.Vec3{0, 1.6})
+	dir := target.Position().Add(mgl64.Vec3{0, target.H().Type().BBox(target).Height() / 3}).Sub(pos)
+	if dir.Len() > boggedRange {
+		return
+	}
+	dir[1] += math.Hypot(dir[0], dir[2]) * 0.2
+	if dir.Len() < mgl64.Epsilon {
+		return
+	}
+	dir = dir.Normalize()
+
+	conf := arrowConf
+	conf.Damage, conf.Potion, conf.Owner, conf.DisablePickup = b.conf.ArrowDamage, potion.Poison(), bg.H(), true
+	opts := world.EntitySpawnOpts{
+		Position: pos.Add(dir.Mul(0.5)),
+		Velocity: dir.Mul(1.6).Add(mgl64.Vec3{rand.NormFloat64(), rand.NormFloat64(), rand.NormFloat64()}.Mul(0.01)),
+		Rotation: bg.Rotation(),
+	}
+	tx.AddEntity(opts.New(ArrowType, conf))
+	tx.PlaySound(pos, sound.BowShoot{})
+}
+
+// shear makes the bogged drop mushrooms and marks it as sheared.
+func (b *BoggedBehaviour) shear(bg *Bogged, tx *world.Tx) {
+	pos := bg.Position()
+	b.sheared = true
+	stacks, _ := loot.Generate(boggedShearLootTable)
+	for _, stack := range stacks {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos.Add(mgl64.Vec3{0, 1})}, stack))
+	}
+	tx.PlaySound(pos, sound.Shear{})
+	for _, v := range tx.Viewers(pos) {
+		v.ViewEntityState(bg)
+	}
+}
+
+// burn sets the bogged on fire if it is exposed to sunlight and hurts it every
+// second while it is burning.
+func (b *BoggedBehaviour) burn(bg *Bogged, tx *world.Tx) {
+	pos := cube.PosFromVec3(bg.Position()).Side(cube.FaceUp)
+	t := tx.World().Time() % world.TimeFull
+	if bg.OnFireDuration() <= 0 && (t < world.TimeSleep || t > world.TimeWake) && tx.SkyLight(pos) == 15 && !tx.RainingAt(pos) {
+		bg.SetOnFire(time.Second * 8)
+	}
+	if bg.OnFireDuration() > 0 && bg.OnFireDuration()%time.Second == 0 {
+		bg.Hurt(1, block.FireDamageSource{})
+	}
+}
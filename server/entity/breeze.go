@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewBreeze creates a breeze.
+func NewBreeze(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(BreezeType, breezeConf)
+}
+
+var breezeConf = BreezeBehaviourConfig{
+	Health: 30,
+}
+
+// Breeze is a hostile mob found in trial chambers. A breeze jumps around its
+// target and shoots wind charges at it, which knock back entities and trigger
+// blocks such as doors and levers. Breezes deflect projectiles shot at them,
+// except for wind charges, and drop breeze rods when killed by a player.
+// Breeze implements the Living and ProjectileDeflector interfaces.
+type Breeze struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the BreezeBehaviour of the Breeze.
+func (b *Breeze) behaviour() *BreezeBehaviour {
+	return b.data.Data.(*BreezeBehaviour)
+}
+
+// newBreeze returns a Breeze that wraps the *Ent passed.
+func newBreeze(e *Ent) *Breeze {
+	b := &Breeze{Ent: e}
+	b.mobEntity = mobEntity{e: e, l: b}
+	return b
+}
+
+// DeflectsProjectile checks if the Breeze deflects the projectile passed.
+// Breezes deflect all projectiles other than wind charges.
+func (b *Breeze) DeflectsProjectile(projectile world.Entity) bool {
+	return !b.Dead() && projectile.H().Type() != BreezeWindChargeType
+}
+
+// modifyDamage prevents the Breeze from being hurt by wind charges.
+func (b *Breeze) modifyDamage(dmg float64, src world.DamageSource) (float64, bool) {
+	if s, ok := src.(ProjectileDamageSource); ok && s.Projectile.H().Type() == BreezeWindChargeType {
+		return 0, false
+	}
+	return dmg, true
+}
+
+// afterHurt makes the Breeze target the entity that hurt it.
+func (b *Breeze) afterHurt(_ float64, src world.DamageSource) {
+	b.behaviour().retaliate(src)
+}
+
+// kill drops the loot and experience of the breeze. Breeze rods are only
+// dropped if the breeze was killed by an entity.
+func (br *Breeze) kill(src world.DamageSource) {
+	switch src.(type) {
+	case AttackDamageSource, ProjectileDamageSource:
+		dropLoot(br, src, br.tx)
+	}
+	dropExperience(br.Position(), 10, br.tx)
+}
+
+// BreezeType is a world.EntityType implementation for Breeze.
+var BreezeType breezeType
+
+type breezeType struct{}
+
+func (breezeType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newBreeze(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (breezeType) EncodeEntity() string { return "minecraft:breeze" }
+func (breezeType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.77, 0.3)
+}
+
+func (breezeType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := breezeConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	data.Data = conf.New()
+}
+
+func (breezeType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*BreezeBehaviour)
+	return map[string]any{"Health": float32(b.health.Health())}
+}
@@ -0,0 +1,177 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// breezeRange is the range in blocks within which a breeze finds and
+	// shoots at its target.
+	breezeRange = 16.0
+	// breezeWindChargeSpeed is the speed in blocks per tick of the wind
+	// charges shot by a breeze.
+	breezeWindChargeSpeed = 0.7
+)
+
+// BreezeBehaviourConfig holds optional parameters for a BreezeBehaviour.
+type BreezeBehaviourConfig struct {
+	// Health is the health that the breeze has when it is created.
+	Health float64
+}
+
+func (conf BreezeBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a BreezeBehaviour using the parameters in conf.
+func (conf BreezeBehaviourConfig) New() *BreezeBehaviour {
+	return &BreezeBehaviour{
+		mc:            &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:           newMob(conf.Health, 0.35),
+		jumpCooldown:  20 + rand.IntN(40),
+		shootCooldown: breezeShootCooldown(),
+	}
+}
+
+// BreezeBehaviour implements the behaviour of a Breeze. Breezes move by
+// jumping high into the air. Once a breeze has found a target, it jumps around
+// it and periodically shoots wind charges at it.
+type BreezeBehaviour struct {
+	mc *MovementComputer
+	mob
+
+	target *world.EntityHandle
+	// jumpCooldown and shootCooldown are the number of ticks until the breeze
+	// jumps and shoots a wind charge respectively.
+	jumpCooldown, shootCooldown int
+}
+
+// Tick makes the breeze jump around and shoot wind charges at its target.
+func (b *BreezeBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	br := newBreeze(e)
+	if !br.tickMob(tx) {
+		return nil
+	}
+
+	pos, vel, rot := e.Position(), e.Velocity(), e.Rotation()
+	target, ok := b.findTarget(br, tx)
+	if ok {
+		delta := target.Position().Sub(pos)
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-delta[0], delta[2])), 0}
+		if b.shootCooldown--; b.shootCooldown <= 0 {
+			b.shootCooldown = breezeShootCooldown()
+			b.shoot(br, target, tx)
+		}
+	}
+	if b.mc.OnGround() {
+		if b.jumpCooldown--; b.jumpCooldown <= 0 {
+			vel = b.jump(br, target, ok)
+		}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// findTarget returns the target of the breeze. If the breeze has no valid
+// target, it looks for the nearest player within range once every second.
+func (b *BreezeBehaviour) findTarget(br *Breeze, tx *world.Tx) (Living, bool) {
+	pos := br.Position()
+	if b.target != nil {
+		if ent, ok := b.target.Entity(tx); ok {
+			if l, ok := ent.(Living); ok && breezeCanTarget(l) && ent.Position().Sub(pos).Len() <= breezeRange*1.5 {
+				return l, true
+			}
+		}
+		b.target = nil
+	}
+	if br.Age()%time.Second != 0 {
+		return nil, false
+	}
+	var (
+		nearest Living
+		dist    = breezeRange
+	)
+	for ent := range tx.Players() {
+		l, ok := ent.(Living)
+		if !ok || !breezeCanTarget(l) {
+			continue
+		}
+		if d := ent.Position().Sub(pos).Len(); d <= dist {
+			nearest, dist = l, d
+		}
+	}
+	if nearest == nil {
+		return nil, false
+	}
+	b.target = nearest.H()
+	return nearest, true
+}
+
+// breezeCanTarget checks if a breeze may target the Living entity passed.
+// Entities in a game mode that does not allow taking damage are never
+// targeted.
+func breezeCanTarget(l Living) bool {
+	if g, ok := l.(interface{ GameMode() world.GameMode }); ok && !g.GameMode().AllowsTakingDamage() {
+		return false
+	}
+	return !l.Dead()
+}
+
+// retaliate makes the breeze target the entity responsible for the damage
+// source passed, if any.
+func (b *BreezeBehaviour) retaliate(src world.DamageSource) {
+	var attacker world.Entity
+	switch s := src.(type) {
+	case AttackDamageSource:
+		attacker = s.Attacker
+	case ProjectileDamageSource:
+		attacker = s.Owner
+	}
+	if l, ok := attacker.(Living); ok && breezeCanTarget(l) {
+		b.target = l.H()
+	}
+}
+
+// jump returns the velocity of a jump of the breeze. With a target, the breeze
+// jumps to a random position near it. Without one, it makes a short jump in a
+// random direction.
+func (b *BreezeBehaviour) jump(br *Breeze, target Living, hasTarget bool) mgl64.Vec3 {
+	if !hasTarget {
+		b.jumpCooldown = 100 + rand.IntN(100)
+		angle := rand.Float64() * math.Pi * 2
+		return mgl64.Vec3{math.Cos(angle) * b.speed / 2, 0.5, math.Sin(angle) * b.speed / 2}
+	}
+	b.jumpCooldown = 20 + rand.IntN(40)
+	dest := target.Position().Add(mgl64.Vec3{rand.Float64()*8 - 4, 0, rand.Float64()*8 - 4})
+	dir := dest.Sub(br.Position())
+	dir[1] = 0
+	if dir.Len() > 1 {
+		dir = dir.Normalize()
+	}
+	return mgl64.Vec3{dir[0] * b.speed, 0.7 + rand.Float64()*0.2, dir[2] * b.speed}
+}
+
+// shoot makes the breeze shoot a wind charge at its target.
+func (b *BreezeBehaviour) shoot(br *Breeze, target Living, tx *world.Tx) {
+	pos := br.Position().Add(mgl64.Vec3{0, 1.3})
+	dir := target.Position().Add(mgl64.Vec3{0, target.H().Type().BBox(target).Height() / 2}).Sub(pos)
+	if dir.Len() > breezeRange || dir.Len() < mgl64.Epsilon {
+		return
+	}
+	dir = dir.Normalize()
+	opts := world.EntitySpawnOpts{Position: pos.Add(dir.Mul(0.5)), Velocity: dir.Mul(breezeWindChargeSpeed)}
+	tx.AddEntity(NewBreezeWindCharge(opts, br))
+}
+
+// breezeShootCooldown returns a random number of ticks between two wind
+// charges shot by a breeze.
+func breezeShootCooldown() int {
+	return 30 + rand.IntN(30)
+}
@@ -0,0 +1,252 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewCamel creates an adult camel.
+func NewCamel(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(CamelType, camelConf)
+}
+
+// NewBabyCamel creates a baby camel, which grows up into an adult after 20
+// minutes.
+func NewBabyCamel(opts world.EntitySpawnOpts) *world.EntityHandle {
+	conf := camelConf
+	conf.Baby = true
+	return opts.New(CamelType, conf)
+}
+
+var camelConf = CamelBehaviourConfig{
+	Health: 32,
+}
+
+// Camel is a passive mob found in deserts that may be ridden by two players
+// at once when saddled. The driver of a camel may make it dash forward, after
+// which it must rest for a few seconds. Camels are tall enough that most
+// hostile mobs cannot reach their riders. Camels fed cactus breed. Camel
+// implements the Living, Interactable, Drivable and RiderShield interfaces.
+type Camel struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the CamelBehaviour of the Camel.
+func (c *Camel) behaviour() *CamelBehaviour {
+	return c.data.Data.(*CamelBehaviour)
+}
+
+// newCamel returns a Camel that wraps the *Ent passed.
+func newCamel(e *Ent) *Camel {
+	c := &Camel{Ent: e}
+	c.mobEntity = mobEntity{e: e, l: c}
+	return c
+}
+
+// Baby checks if the Camel is a baby camel.
+func (c *Camel) Baby() bool {
+	return c.behaviour().age < 0
+}
+
+// Saddled checks if the Camel has a saddle equipped.
+func (c *Camel) Saddled() bool {
+	return c.behaviour().saddled
+}
+
+// Sitting checks if the Camel is currently sitting down.
+func (c *Camel) Sitting() bool {
+	return c.behaviour().sitting
+}
+
+// DashCoolingDown checks if the Camel recently dashed and is unable to dash
+// again until it has rested.
+func (c *Camel) DashCoolingDown() bool {
+	return c.behaviour().dashCooldown > 0
+}
+
+// InLove checks if the Camel was fed cactus and is looking for another camel
+// to breed with.
+func (c *Camel) InLove() bool {
+	return c.behaviour().loveTicks > 0
+}
+
+// afterHurt makes a sitting Camel stand up.
+func (c *Camel) afterHurt(float64, world.DamageSource) {
+	c.behaviour().standUp(c, c.tx)
+}
+
+// kill makes the riders of the camel dismount and drops its saddle, loot and
+// experience.
+func (c *Camel) kill(src world.DamageSource) {
+	b := c.behaviour()
+	pos := c.Position()
+	for _, h := range b.seats {
+		if h == nil {
+			continue
+		}
+		if ent, ok := h.Entity(c.tx); ok {
+			if r, ok := ent.(Rider); ok {
+				r.Dismount()
+			}
+		}
+	}
+	if b.saddled && c.tx.World().GameRule(world.GameRuleDoMobLoot) {
+		c.tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, item.NewStack(item.Saddle{}, 1)))
+	}
+	dropLoot(c, src, c.tx)
+	dropExperience(pos, 1+rand.IntN(3), c.tx)
+}
+
+// Interact feeds the Camel if the user is holding cactus, making adults ready
+// to breed and babies grow up faster. Adult camels may be saddled, after which
+// Riders interacting with them start riding them.
+func (c *Camel) Interact(user item.User, tx *world.Tx, ctx *item.UseContext) bool {
+	if c.Dead() {
+		return false
+	}
+	b := c.behaviour()
+	held, _ := user.HeldItems()
+	switch held.Item().(type) {
+	case block.Cactus:
+		return b.feed(c, tx, ctx)
+	case item.Saddle:
+		if b.saddled || c.Baby() {
+			return false
+		}
+		b.saddled = true
+		ctx.SubtractFromCount(1)
+		tx.PlaySound(c.Position(), sound.EquipItem{Item: held.Item()})
+		b.updateState(c, tx)
+		return true
+	}
+	r, ok := user.(Rider)
+	if !ok || !b.saddled || c.Baby() {
+		return false
+	}
+	if _, riding := r.Riding(); riding {
+		return false
+	}
+	return r.Mount(c)
+}
+
+// SeatPosition returns the position of the seat passed. The driver sits at
+// the front of the hump of the Camel, the passenger behind it.
+func (c *Camel) SeatPosition(seat int) mgl64.Vec3 {
+	height, offset := 1.905, 0.5
+	if c.Sitting() {
+		height = 0.845
+	}
+	if seat != 0 {
+		offset = -0.5
+	}
+	yaw := mgl64.DegToRad(c.Rotation().Yaw())
+	return c.Position().Add(mgl64.Vec3{-math.Sin(yaw) * offset, height, math.Cos(yaw) * offset})
+}
+
+// Riders returns the entity handles of the entities riding the Camel, indexed
+// by seat. Empty seats are nil.
+func (c *Camel) Riders() []*world.EntityHandle {
+	return slices.Clone(c.behaviour().seats[:])
+}
+
+// AddRider seats the Rider passed on the first free seat of the Camel. Camels
+// have two seats and the Rider in the first seat drives the Camel.
+func (c *Camel) AddRider(r Rider) (int, bool) {
+	b := c.behaviour()
+	if c.Dead() || c.Baby() || slices.Contains(b.seats[:], r.H()) {
+		return 0, false
+	}
+	for seat, h := range b.seats {
+		if h == nil {
+			b.seats[seat] = r.H()
+			return seat, true
+		}
+	}
+	return 0, false
+}
+
+// RemoveRider removes the Rider passed from its seat on the Camel.
+func (c *Camel) RemoveRider(r Rider) {
+	b := c.behaviour()
+	for seat, h := range b.seats {
+		if h == r.H() {
+			b.seats[seat] = nil
+		}
+	}
+}
+
+// Drive moves the Camel to the position passed and turns it to the yaw passed
+// if the Rider passed is seated in the first seat of the Camel.
+func (c *Camel) Drive(driver Rider, pos mgl64.Vec3, yaw float64) {
+	b := c.behaviour()
+	if b.seats[0] != driver.H() || c.Dead() {
+		return
+	}
+	b.drive(c, pos, yaw)
+}
+
+// Dash makes the Camel dash forward in the direction that it is facing if it
+// is not resting after a previous dash.
+func (c *Camel) Dash() {
+	c.behaviour().dash(c, c.tx)
+}
+
+// ShieldsRiders checks if the attacker passed is able to reach the riders of
+// the Camel. Attackers standing on the ground next to a Camel are too short to
+// reach its riders.
+func (c *Camel) ShieldsRiders(attacker world.Entity) bool {
+	return !c.Sitting() && attacker.Position()[1] < c.Position()[1]+1
+}
+
+// CamelType is a world.EntityType implementation for Camel.
+var CamelType camelType
+
+type camelType struct{}
+
+func (camelType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newCamel(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (camelType) EncodeEntity() string { return "minecraft:camel" }
+func (camelType) BBox(e world.Entity) cube.BBox {
+	if c, ok := e.(*Camel); ok && c.Baby() {
+		return cube.Box(-0.3825, 0, -0.3825, 0.3825, 1.06875, 0.3825)
+	}
+	return cube.Box(-0.85, 0, -0.85, 0.85, 2.375, 0.85)
+}
+
+func (camelType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := camelConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	b := conf.New()
+	b.age = int(nbtconv.Int32(m, "Age"))
+	b.saddled = nbtconv.Bool(m, "Saddled")
+	b.sitting = nbtconv.Bool(m, "Sitting")
+	b.loveTicks = int(nbtconv.Int32(m, "InLove"))
+	b.breedCooldown = int(nbtconv.Int32(m, "BreedCooldown"))
+	data.Data = b
+}
+
+func (camelType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*CamelBehaviour)
+	return map[string]any{
+		"Health":        float32(b.health.Health()),
+		"Age":           int32(b.age),
+		"Saddled":       boolByte(b.saddled),
+		"Sitting":       boolByte(b.sitting),
+		"InLove":        int32(b.loveTicks),
+		"BreedCooldown": int32(b.breedCooldown),
+	}
+}
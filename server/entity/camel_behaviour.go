@@ -0,0 +1,270 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// camelDashCooldown is the number of ticks that a camel must rest for
+	// after dashing before it can dash again.
+	camelDashCooldown = 55
+	// camelGrowUpTicks is the number of ticks that it takes for a baby camel
+	// to grow up.
+	camelGrowUpTicks = 24000
+	// camelLoveTicks is the number of ticks that a camel looks for another
+	// camel to breed with after being fed cactus.
+	camelLoveTicks = 600
+	// camelBreedCooldown is the number of ticks after breeding during which a
+	// camel cannot be fed cactus to breed.
+	camelBreedCooldown = 6000
+)
+
+// CamelBehaviourConfig holds optional parameters for a CamelBehaviour.
+type CamelBehaviourConfig struct {
+	// Health is the health that the camel has when it is created.
+	Health float64
+	// Baby specifies if the camel is created as a baby.
+	Baby bool
+}
+
+func (conf CamelBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a CamelBehaviour using the parameters in conf.
+func (conf CamelBehaviourConfig) New() *CamelBehaviour {
+	b := &CamelBehaviour{
+		mc:        &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:       newMob(conf.Health, 0.09),
+		poseTicks: 2400 + rand.IntN(3600),
+	}
+	if conf.Baby {
+		b.age = -camelGrowUpTicks
+	}
+	return b
+}
+
+// CamelBehaviour implements the behaviour of a Camel. Camels slowly wander
+// around and every now and then sit down for a while. A saddled camel seats
+// two riders, of which the first one controls its movement. Camels fed cactus
+// look for a mate and breed, producing a baby camel.
+type CamelBehaviour struct {
+	mc *MovementComputer
+	mob
+
+	// seats holds the entity handles of the riders of the camel. The rider in
+	// the first seat drives the camel.
+	seats   [2]*world.EntityHandle
+	saddled bool
+
+	// sitting is true if the camel is sitting down. poseTicks is the number of
+	// ticks until the camel sits down or stands up again.
+	sitting   bool
+	poseTicks int
+
+	dashCooldown int
+
+	dest        mgl64.Vec3
+	wanderTicks int
+
+	// age is negative while the camel is a baby and counts up to zero, at
+	// which point the camel grows up.
+	age           int
+	loveTicks     int
+	breedCooldown int
+}
+
+// Tick makes the camel wander around and sit down when it is not ridden.
+func (b *CamelBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	c := newCamel(e)
+	if !c.tickMob(tx) {
+		return nil
+	}
+	if b.loveTicks > 0 {
+		b.loveTicks--
+	}
+	if b.breedCooldown > 0 {
+		b.breedCooldown--
+	}
+	if b.dashCooldown > 0 {
+		if b.dashCooldown--; b.dashCooldown == 0 {
+			b.updateState(c, tx)
+		}
+	}
+	if b.age < 0 {
+		if b.age++; b.age == 0 {
+			b.updateState(c, tx)
+		}
+	}
+	b.checkRiders(c, tx)
+	if b.seats[0] != nil {
+		// The movement of the camel is controlled by its driver.
+		return nil
+	}
+
+	pos, vel := e.Position(), e.Velocity()
+	if b.poseTicks--; b.poseTicks <= 0 {
+		if b.sitting {
+			b.standUp(c, tx)
+		} else if b.mc.OnGround() && b.loveTicks == 0 && b.seats[1] == nil {
+			b.sitting, b.poseTicks = true, 600+rand.IntN(1200)
+			b.updateState(c, tx)
+		}
+	}
+	if !b.sitting && b.seats[1] == nil && b.mc.OnGround() {
+		if dest, ok := b.destination(c, tx); ok {
+			if dir := (mgl64.Vec3{dest[0] - pos[0], 0, dest[2] - pos[2]}); dir.Len() > mgl64.Epsilon {
+				vel = dir.Normalize().Mul(b.speed).Add(mgl64.Vec3{0, vel[1]})
+			}
+		}
+	}
+	rot := e.Rotation()
+	if math.Hypot(vel[0], vel[2]) > 0.01 {
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])), 0}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// destination returns the position that the camel should move to. If the
+// camel has nowhere to go, false is returned.
+func (b *CamelBehaviour) destination(c *Camel, tx *world.Tx) (mgl64.Vec3, bool) {
+	pos := c.Position()
+	if b.loveTicks > 0 {
+		if mate, ok := b.findMate(c, tx); ok {
+			if mate.Position().Sub(pos).Len() < 2.5 {
+				b.breed(c, mate, tx)
+			}
+			return mate.Position(), true
+		}
+	}
+	if b.wanderTicks--; b.wanderTicks <= 0 {
+		b.wanderTicks = 100 + rand.IntN(200)
+		b.dest = pos.Add(mgl64.Vec3{rand.Float64()*16 - 8, 0, rand.Float64()*16 - 8})
+	}
+	return b.dest, b.dest.Sub(pos).Len() > 1
+}
+
+// checkRiders frees the seats of riders that are no longer riding the camel,
+// for example because they left the world.
+func (b *CamelBehaviour) checkRiders(c *Camel, tx *world.Tx) {
+	for seat, h := range b.seats {
+		if h == nil {
+			continue
+		}
+		if ent, ok := h.Entity(tx); ok {
+			if r, ok := ent.(Rider); ok {
+				if vehicle, ok := r.Riding(); ok && vehicle == c.H() {
+					continue
+				}
+			}
+		}
+		b.seats[seat] = nil
+	}
+}
+
+// feed feeds the camel cactus. Baby camels grow up faster, while adult camels
+// start looking for a mate.
+func (b *CamelBehaviour) feed(c *Camel, tx *world.Tx, ctx *item.UseContext) bool {
+	if b.age < 0 {
+		// Feeding a baby camel makes it grow up 10% faster.
+		b.age = min(b.age+camelGrowUpTicks/10, 0)
+		if b.age == 0 {
+			b.updateState(c, tx)
+		}
+		ctx.SubtractFromCount(1)
+		return true
+	}
+	if b.loveTicks > 0 || b.breedCooldown > 0 {
+		return false
+	}
+	b.loveTicks = camelLoveTicks
+	b.standUp(c, tx)
+	ctx.SubtractFromCount(1)
+	for _, v := range tx.Viewers(c.Position()) {
+		v.ViewEntityAction(c, LoveAction{})
+	}
+	return true
+}
+
+// findMate returns the nearest adult camel within 8 blocks that is also in
+// love.
+func (b *CamelBehaviour) findMate(c *Camel, tx *world.Tx) (*Camel, bool) {
+	pos := c.Position()
+	var (
+		nearest *Camel
+		dist    = 8.0
+	)
+	for ent := range tx.EntitiesWithin(cube.Box(-8, -4, -8, 8, 4, 8).Translate(pos)) {
+		other, ok := ent.(*Camel)
+		if !ok || other.H() == c.H() || !other.InLove() || other.Baby() || other.Dead() {
+			continue
+		}
+		if d := ent.Position().Sub(pos).Len(); d <= dist {
+			nearest, dist = other, d
+		}
+	}
+	return nearest, nearest != nil
+}
+
+// breed makes the camel breed with the mate passed, spawning a baby camel
+// between them. Both camels cannot breed again for five minutes.
+func (b *CamelBehaviour) breed(c, mate *Camel, tx *world.Tx) {
+	other := mate.behaviour()
+	b.loveTicks, other.loveTicks = 0, 0
+	b.breedCooldown, other.breedCooldown = camelBreedCooldown, camelBreedCooldown
+
+	pos := c.Position().Add(mate.Position()).Mul(0.5)
+	tx.AddEntity(NewBabyCamel(world.EntitySpawnOpts{Position: pos, Rotation: c.Rotation()}))
+	if tx.World().GameRule(world.GameRuleDoMobLoot) {
+		for _, orb := range NewExperienceOrbs(pos, 1+rand.IntN(7)) {
+			tx.AddEntity(orb)
+		}
+	}
+}
+
+// drive moves the camel to the position passed and turns it to the yaw
+// passed. The camel stands up if it was sitting.
+func (b *CamelBehaviour) drive(c *Camel, pos mgl64.Vec3, yaw float64) {
+	b.standUp(c, c.tx)
+	rot := cube.Rotation{yaw, 0}
+	for _, v := range c.tx.Viewers(pos) {
+		v.ViewEntityMovement(c, pos, rot, false)
+	}
+	c.data.Vel, c.data.Pos, c.data.Rot = pos.Sub(c.data.Pos), pos, rot
+}
+
+// dash makes the camel dash if it is being driven and is not resting after a
+// previous dash. The movement of the dash itself is predicted by the driver,
+// so only the cooldown is tracked by the camel.
+func (b *CamelBehaviour) dash(c *Camel, tx *world.Tx) {
+	if b.dashCooldown > 0 || b.seats[0] == nil || b.sitting {
+		return
+	}
+	b.dashCooldown = camelDashCooldown
+	b.updateState(c, tx)
+}
+
+// standUp makes the camel stand up if it is sitting.
+func (b *CamelBehaviour) standUp(c *Camel, tx *world.Tx) {
+	if !b.sitting {
+		return
+	}
+	b.sitting, b.poseTicks = false, 2400+rand.IntN(3600)
+	b.updateState(c, tx)
+}
+
+// updateState sends the state of the camel to its viewers, updating whether
+// it is sitting, saddled and able to dash.
+func (b *CamelBehaviour) updateState(c *Camel, tx *world.Tx) {
+	for _, v := range tx.Viewers(c.Position()) {
+		v.ViewEntityState(c)
+	}
+}
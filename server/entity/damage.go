@@ -13,6 +13,10 @@ type (
 		// Attacker holds the attacking entity. The entity may be a player or
 		// any other entity.
 		Attacker world.Entity
+		// Penetration is the fraction of armour effectiveness, in a range of
+		// [0, 1], that is ignored by the attack. It is non-zero for attacks
+		// with a mace enchanted with Breach.
+		Penetration float64
 	}
 
 	// VoidDamageSource is used for damage caused by an entity being in the
@@ -46,6 +50,10 @@ type (
 
 	// ExplosionDamageSource is used for damage caused by an explosion.
 	ExplosionDamageSource struct{}
+
+	// BorderDamageSource is used for damage caused by an entity being outside
+	// the world.Border.
+	BorderDamageSource struct{}
 )
 
 func (FallDamageSource) ReducedByArmour() bool     { return false }
@@ -67,6 +75,7 @@ func (AttackDamageSource) ReducedByArmour() bool          { return true }
 func (AttackDamageSource) ReducedByResistance() bool      { return true }
 func (AttackDamageSource) Fire() bool                     { return false }
 func (AttackDamageSource) IgnoreTotem() bool              { return false }
+func (s AttackDamageSource) ArmourPenetration() float64   { return s.Penetration }
 func (VoidDamageSource) ReducedByResistance() bool        { return false }
 func (VoidDamageSource) ReducedByArmour() bool            { return false }
 func (VoidDamageSource) Fire() bool                       { return false }
@@ -92,4 +101,8 @@ func (ExplosionDamageSource) Fire() bool                { return false }
 func (ExplosionDamageSource) AffectedByEnchantment(e item.EnchantmentType) bool {
 	return e == enchantment.BlastProtection
 }
-func (ExplosionDamageSource) IgnoreTotem() bool { return false }
+func (ExplosionDamageSource) IgnoreTotem() bool      { return false }
+func (BorderDamageSource) ReducedByResistance() bool { return false }
+func (BorderDamageSource) ReducedByArmour() bool     { return false }
+func (BorderDamageSource) Fire() bool                { return false }
+func (BorderDamageSource) IgnoreTotem() bool         { return false }
@@ -0,0 +1,19 @@
+package effect
+
+import (
+	"image/color"
+)
+
+// BadOmen is a lasting effect that starts a raid when the affected entity
+// enters a village. The level of the effect determines the strength of the
+// raid.
+var BadOmen badOmen
+
+type badOmen struct {
+	nopLasting
+}
+
+// RGBA ...
+func (badOmen) RGBA() color.RGBA {
+	return color.RGBA{R: 0x0b, G: 0x61, B: 0x38, A: 0xff}
+}
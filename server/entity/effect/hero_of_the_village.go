@@ -0,0 +1,19 @@
+package effect
+
+import (
+	"image/color"
+)
+
+// HeroOfTheVillage is a lasting effect that lowers the prices of the trades
+// offered by villagers to the affected entity. The discount grows with the
+// level of the effect.
+var HeroOfTheVillage heroOfTheVillage
+
+type heroOfTheVillage struct {
+	nopLasting
+}
+
+// RGBA ...
+func (heroOfTheVillage) RGBA() color.RGBA {
+	return color.RGBA{R: 0x44, G: 0xff, B: 0x44, A: 0xff}
+}
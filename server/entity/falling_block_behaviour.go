@@ -84,7 +84,7 @@ func (f *FallingBlockBehaviour) solidify(e *Ent, pos mgl64.Vec3, tx *world.Tx) {
 
 	if r, ok := tx.Block(bpos).(replaceable); ok && r.ReplaceableBy(f.block) {
 		tx.SetBlock(bpos, f.block, nil)
-	} else if i, ok := f.block.(world.Item); ok {
+	} else if i, ok := f.block.(world.Item); ok && tx.World().GameRule(world.GameRuleDoEntityDrops) {
 		opts := world.EntitySpawnOpts{Position: bpos.Vec3Middle()}
 		tx.AddEntity(NewItem(opts, item.NewStack(i, 1)))
 	}
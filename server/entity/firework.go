@@ -26,8 +26,8 @@ func newFirework(opts world.EntitySpawnOpts, firework item.Firework, owner world
 	conf.UpwardsAcceleration = upwardsAcceleration
 	conf.Firework = firework
 	conf.ExistenceDuration = firework.RandomisedDuration()
-	conf.Attached = attached
-	if attached {
+	conf.Attached = attached && owner != nil
+	if owner != nil {
 		conf.Owner = owner.H()
 	}
 	return opts.New(FireworkType, conf)
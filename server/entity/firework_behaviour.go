@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
 	"iter"
 	"math"
 	"time"
@@ -67,11 +68,43 @@ func (f *FireworkBehaviour) Owner() *world.EntityHandle {
 }
 
 // Tick moves the firework and makes it explode when it reaches its maximum
-// duration.
+// duration. Fireworks that are not attached to their owner explode early if
+// they collide with a block or a living entity.
 func (f *FireworkBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !f.conf.Attached && !f.passive.close {
+		pos := e.Position()
+		end := pos.Add(e.Velocity())
+		if !mgl64.FloatEqual(end.Sub(pos).LenSqr(), 0) {
+			if hit, ok := trace.Perform(pos, end, tx, e.H().Type().BBox(e).Grow(0.25), f.ignores(e)); ok {
+				e.data.Pos = hit.Position()
+				f.passive.close = true
+				f.explode(e, tx)
+				return nil
+			}
+		}
+	}
 	return f.passive.Tick(e, tx)
 }
 
+// ignores returns a function to ignore entities in trace.Perform that are not
+// living, are spectators, or are the owner of the firework within the first
+// few ticks after launching.
+func (f *FireworkBehaviour) ignores(e *Ent) trace.EntityFilter {
+	return func(seq iter.Seq[world.Entity]) iter.Seq[world.Entity] {
+		return func(yield func(world.Entity) bool) {
+			for other := range filterLiving(seq) {
+				g, ok := other.(interface{ GameMode() world.GameMode })
+				if (ok && !g.GameMode().HasCollision()) || (e.data.Age < time.Second/4 && f.conf.Owner == other.H()) {
+					continue
+				}
+				if !yield(other) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // tick ticks the entity, updating its velocity either with a constant factor
 // or based on the owner's position and velocity if attached.
 func (f *FireworkBehaviour) tick(e *Ent, tx *world.Tx) {
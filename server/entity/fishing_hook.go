@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewFishingHook creates a fishing hook cast by the owner passed using the
+// fishing rod passed. The Lure and Luck of the Sea enchantments of the rod
+// reduce the time it takes for a fish to bite and improve the catch.
+func NewFishingHook(opts world.EntitySpawnOpts, owner world.Entity, rod item.Stack) *world.EntityHandle {
+	conf := fishingHookConf
+	conf.Owner, conf.Rod = owner.H(), rod
+	return opts.New(FishingHookType, conf)
+}
+
+var fishingHookConf = FishingHookBehaviourConfig{
+	Gravity: 0.03,
+	Drag:    0.08,
+}
+
+// FishingHook is the bobber cast by a fishing rod. It floats on water until a
+// fish bites, after which the owner may reel it in to catch loot from a loot
+// table.
+type FishingHook struct {
+	*Ent
+}
+
+// behaviour returns the FishingHookBehaviour of the FishingHook.
+func (h *FishingHook) behaviour() *FishingHookBehaviour {
+	return h.data.Data.(*FishingHookBehaviour)
+}
+
+// Owner returns the entity that cast the FishingHook.
+func (h *FishingHook) Owner() *world.EntityHandle {
+	return h.behaviour().conf.Owner
+}
+
+// Biting checks if a fish is currently biting the FishingHook, meaning reeling
+// it in results in a catch.
+func (h *FishingHook) Biting() bool {
+	return h.behaviour().biteTicks > 0
+}
+
+// Reel reels in the FishingHook. If a fish was biting, the catch is generated
+// from the loot table of the hook and thrown towards the owner. The hook is
+// closed and the damage that reeling it in deals to the fishing rod is
+// returned.
+func (h *FishingHook) Reel() int {
+	return h.behaviour().reel(h.Ent, h.tx)
+}
+
+// FishingHookType is a world.EntityType implementation for FishingHook.
+var FishingHookType fishingHookType
+
+type fishingHookType struct{}
+
+func (fishingHookType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &FishingHook{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (fishingHookType) EncodeEntity() string { return "minecraft:fishing_hook" }
+func (fishingHookType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.125, 0, -0.125, 0.125, 0.25, 0.125)
+}
+
+// DecodeNBT creates a hook without an owner, which is closed on its first tick:
+// Fishing hooks do not outlive the session they were cast in.
+func (fishingHookType) DecodeNBT(_ map[string]any, data *world.EntityData) {
+	data.Data = fishingHookConf.New()
+}
+func (fishingHookType) EncodeNBT(*world.EntityData) map[string]any { return nil }
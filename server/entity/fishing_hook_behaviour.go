@@ -0,0 +1,173 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// fishingHookMaxDistance is the distance from its owner at which a fishing
+// hook breaks.
+const fishingHookMaxDistance = 32
+
+// FishingHookBehaviourConfig holds optional parameters for a
+// FishingHookBehaviour.
+type FishingHookBehaviourConfig struct {
+	Owner *world.EntityHandle
+	// Rod is the fishing rod that the hook was cast with. Its enchantments
+	// affect the bite timing and the catch, and it is passed as the tool to
+	// the loot table.
+	Rod item.Stack
+	// LootTable is the path of the loot table that catches are generated
+	// from, relative to the loot_tables folder. If empty,
+	// "gameplay/fishing.json" is used.
+	LootTable string
+	// Gravity is the amount of Y velocity subtracted every tick.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is
+	// multiplied with (1-Drag) every tick.
+	Drag float64
+}
+
+func (conf FishingHookBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a FishingHookBehaviour using the parameters in conf.
+func (conf FishingHookBehaviourConfig) New() *FishingHookBehaviour {
+	if conf.LootTable == "" {
+		conf.LootTable = "gameplay/fishing.json"
+	}
+	b := &FishingHookBehaviour{conf: conf}
+	b.passive = PassiveBehaviourConfig{
+		Gravity: conf.Gravity,
+		Drag:    conf.Drag,
+		Tick:    b.tick,
+	}.New()
+	return b
+}
+
+// FishingHookBehaviour implements the behaviour of a FishingHook. The hook
+// floats on water, where a fish eventually approaches and bites. Reeling the
+// hook in while a fish bites catches loot from the loot table of the hook.
+type FishingHookBehaviour struct {
+	conf    FishingHookBehaviourConfig
+	passive *PassiveBehaviour
+
+	// waitTicks is the number of ticks left until a fish starts approaching
+	// the hook. lureTicks is the number of ticks left until the approaching
+	// fish bites, and biteTicks is the number of ticks left until the biting
+	// fish escapes.
+	waitTicks, lureTicks, biteTicks int
+}
+
+// Tick moves the hook and updates the fish approaching it.
+func (b *FishingHookBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	return b.passive.Tick(e, tx)
+}
+
+// tick closes the hook if its owner stopped fishing and otherwise keeps it
+// floating and updates the bite timing if it is in water.
+func (b *FishingHookBehaviour) tick(e *Ent, tx *world.Tx) {
+	owner, ok := b.conf.Owner.Entity(tx)
+	if !ok || !holdsFishingRod(owner) || owner.Position().Sub(e.Position()).Len() > fishingHookMaxDistance {
+		b.passive.close = true
+		return
+	}
+	if !inWater(e.Position(), tx) {
+		b.waitTicks, b.lureTicks, b.biteTicks = 0, 0, 0
+		return
+	}
+	// Push the hook up while it is submerged so that it bobs on the surface.
+	vel := e.Velocity()
+	e.data.Vel = mgl64.Vec3{vel[0] * 0.9, vel[1]*0.8 + 0.06, vel[2] * 0.9}
+
+	switch {
+	case b.biteTicks > 0:
+		b.biteTicks--
+	case b.lureTicks > 0:
+		if b.lureTicks--; b.lureTicks == 0 {
+			b.biteTicks = 20 + rand.IntN(21)
+			e.data.Vel[1] -= 0.2
+			for _, v := range tx.Viewers(e.Position()) {
+				v.ViewEntityAction(e, FishingHookBiteAction{})
+			}
+		}
+	case b.waitTicks > 0:
+		if b.waitTicks--; b.waitTicks == 0 {
+			b.lureTicks = 20 + rand.IntN(61)
+		}
+	default:
+		// Every level of Lure reduces the time until a fish approaches by five
+		// seconds.
+		b.waitTicks = max(100+rand.IntN(501)-b.enchantmentLevel(enchantment.Lure)*100, 1)
+	}
+}
+
+// reel reels in the hook and closes it. If a fish was biting, the catch is
+// thrown towards the owner along with some experience. The damage dealt to the
+// fishing rod is returned.
+func (b *FishingHookBehaviour) reel(e *Ent, tx *world.Tx) int {
+	b.passive.close = true
+	owner, ok := b.conf.Owner.Entity(tx)
+	if !ok {
+		return 0
+	}
+	if b.biteTicks == 0 {
+		if b.passive.mc.OnGround() {
+			return 2
+		}
+		return 0
+	}
+	pos := e.Position()
+	ctx := loot.Context{
+		Tx:     tx,
+		Pos:    cube.PosFromVec3(pos),
+		Entity: owner,
+		Tool:   b.conf.Rod,
+		Luck:   float64(b.enchantmentLevel(enchantment.LuckOfTheSea)),
+	}
+	if owner.H().Type().EncodeEntity() == "minecraft:player" {
+		ctx.Player = owner
+	}
+	stacks, _ := loot.GenerateWithContext(b.conf.LootTable, ctx)
+
+	d := owner.Position().Sub(pos)
+	vel := mgl64.Vec3{d[0] * 0.1, d[1]*0.1 + math.Sqrt(d.Len())*0.08, d[2] * 0.1}
+	for _, stack := range stacks {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos, Velocity: vel}, stack))
+	}
+	for _, orb := range NewExperienceOrbs(owner.Position(), 1+rand.IntN(6)) {
+		tx.AddEntity(orb)
+	}
+	return 1
+}
+
+// enchantmentLevel returns the level of the enchantment passed on the fishing
+// rod that the hook was cast with.
+func (b *FishingHookBehaviour) enchantmentLevel(t item.EnchantmentType) int {
+	if ench, ok := b.conf.Rod.Enchantment(t); ok {
+		return ench.Level()
+	}
+	return 0
+}
+
+// holdsFishingRod checks if the entity passed holds a fishing rod in its main
+// hand.
+func holdsFishingRod(e world.Entity) bool {
+	holder, ok := e.(interface {
+		HeldItems() (mainHand, offHand item.Stack)
+	})
+	if !ok {
+		return false
+	}
+	mainHand, _ := holder.HeldItems()
+	_, ok = mainHand.Item().(item.FishingRod)
+	return ok
+}
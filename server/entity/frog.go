@@ -0,0 +1,203 @@
+package entity
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewFrog creates a frog of the variant passed.
+func NewFrog(opts world.EntitySpawnOpts, variant FrogVariant) *world.EntityHandle {
+	conf := frogConf
+	conf.Variant = variant
+	return opts.New(FrogType, conf)
+}
+
+var frogConf = FrogBehaviourConfig{
+	Health: 10,
+}
+
+// FrogPrey represents a Living entity that frogs hunt and eat, such as a small
+// slime or magma cube.
+type FrogPrey interface {
+	Living
+	// FrogLoot returns the item dropped when the entity is eaten by a frog of
+	// the FrogVariant passed.
+	FrogLoot(variant FrogVariant) item.Stack
+}
+
+// Frog is a passive amphibious mob that hunts small slimes and magma cubes.
+// Frogs come in three variants depending on the climate that they grew up in.
+// Frogs fed slime balls breed and lay frogspawn on the surface of water.
+// Frog implements the Living and Interactable interfaces.
+type Frog struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the FrogBehaviour of the Frog.
+func (f *Frog) behaviour() *FrogBehaviour {
+	return f.data.Data.(*FrogBehaviour)
+}
+
+// newFrog returns a Frog that wraps the *Ent passed.
+func newFrog(e *Ent) *Frog {
+	f := &Frog{Ent: e}
+	f.mobEntity = mobEntity{e: e, l: f}
+	return f
+}
+
+// Variant returns the FrogVariant of the Frog as an int32, as it is shown to
+// viewers.
+func (f *Frog) Variant() int32 {
+	return int32(f.behaviour().variant.Uint8())
+}
+
+// FrogVariant returns the FrogVariant of the Frog.
+func (f *Frog) FrogVariant() FrogVariant {
+	return f.behaviour().variant
+}
+
+// kill drops the loot and experience of the frog.
+func (f *Frog) kill(src world.DamageSource) {
+	dropLoot(f, src, f.tx)
+	dropExperience(f.Position(), 1+rand.IntN(3), f.tx)
+}
+
+// InLove checks if the Frog was fed a slime ball and is looking for another
+// frog to breed with.
+func (f *Frog) InLove() bool {
+	return f.behaviour().loveTicks > 0
+}
+
+// Interact makes the Frog ready to breed if the user feeds it a slime ball.
+func (f *Frog) Interact(user item.User, tx *world.Tx, ctx *item.UseContext) bool {
+	held, _ := user.HeldItems()
+	if _, ok := held.Item().(item.Slimeball); !ok || f.Dead() {
+		return false
+	}
+	b := f.behaviour()
+	if b.loveTicks > 0 || b.breedCooldown > 0 {
+		return false
+	}
+	b.loveTicks = frogLoveTicks
+	ctx.SubtractFromCount(1)
+	for _, v := range tx.Viewers(f.Position()) {
+		v.ViewEntityAction(f, LoveAction{})
+	}
+	return true
+}
+
+// FrogVariant represents a variant of a Frog. The variant of a frog depends on
+// the biome that the tadpole it grew from was in when it grew up.
+type FrogVariant struct {
+	frogVariant
+}
+
+type frogVariant uint8
+
+// TemperateFrog is the orange variant of a frog, found in temperate biomes.
+func TemperateFrog() FrogVariant {
+	return FrogVariant{0}
+}
+
+// ColdFrog is the green variant of a frog, found in cold biomes.
+func ColdFrog() FrogVariant {
+	return FrogVariant{1}
+}
+
+// WarmFrog is the white variant of a frog, found in warm biomes.
+func WarmFrog() FrogVariant {
+	return FrogVariant{2}
+}
+
+// FrogVariants returns all frog variants.
+func FrogVariants() []FrogVariant {
+	return []FrogVariant{TemperateFrog(), ColdFrog(), WarmFrog()}
+}
+
+// Uint8 returns the frog variant as a uint8.
+func (v frogVariant) Uint8() uint8 {
+	return uint8(v)
+}
+
+// Froglight returns the type of froglight dropped when a frog of this variant
+// eats a small magma cube.
+func (v frogVariant) Froglight() block.FroglightType {
+	switch v {
+	case 1:
+		return block.Verdant()
+	case 2:
+		return block.Pearlescent()
+	}
+	return block.Ochre()
+}
+
+// String ...
+func (v frogVariant) String() string {
+	switch v {
+	case 1:
+		return "cold"
+	case 2:
+		return "warm"
+	}
+	return "temperate"
+}
+
+// frogVariantAt returns the FrogVariant of a frog growing up at the position
+// passed, based on the tags of the biome at that position.
+func frogVariantAt(pos cube.Pos, tx *world.Tx) FrogVariant {
+	tags := tx.Biome(pos).Tags()
+	switch {
+	case slices.Contains(tags, "spawns_warm_variant_frogs"):
+		return WarmFrog()
+	case slices.Contains(tags, "spawns_cold_variant_frogs"):
+		return ColdFrog()
+	}
+	return TemperateFrog()
+}
+
+// FrogType is a world.EntityType implementation for Frog.
+var FrogType frogType
+
+type frogType struct{}
+
+func (t frogType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newFrog(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (frogType) EncodeEntity() string { return "minecraft:frog" }
+func (frogType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.25, 0, -0.25, 0.25, 0.55, 0.25)
+}
+
+func (frogType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := frogConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	if v := nbtconv.Int32(m, "Variant"); v >= 0 && int(v) < len(FrogVariants()) {
+		conf.Variant = FrogVariants()[v]
+	}
+	b := conf.New()
+	b.loveTicks = int(nbtconv.Int32(m, "InLove"))
+	b.breedCooldown = int(nbtconv.Int32(m, "BreedCooldown"))
+	b.pregnant = nbtconv.Bool(m, "Pregnant")
+	data.Data = b
+}
+
+func (frogType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*FrogBehaviour)
+	return map[string]any{
+		"Health":        float32(b.health.Health()),
+		"Variant":       int32(b.variant.Uint8()),
+		"InLove":        int32(b.loveTicks),
+		"BreedCooldown": int32(b.breedCooldown),
+		"Pregnant":      boolByte(b.pregnant),
+	}
+}
@@ -0,0 +1,267 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// frogLoveTicks is the number of ticks that a frog looks for another frog
+	// to breed with after being fed a slime ball.
+	frogLoveTicks = 600
+	// frogBreedCooldown is the number of ticks after breeding during which a
+	// frog cannot be fed slime balls.
+	frogBreedCooldown = 6000
+)
+
+// FrogBehaviourConfig holds optional parameters for a FrogBehaviour.
+type FrogBehaviourConfig struct {
+	// Variant is the variant of the frog.
+	Variant FrogVariant
+	// Health is the health that the frog has when it is created.
+	Health float64
+}
+
+func (conf FrogBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a FrogBehaviour using the parameters in conf.
+func (conf FrogBehaviourConfig) New() *FrogBehaviour {
+	return &FrogBehaviour{
+		mc:      &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:     newMob(conf.Health, 0.1),
+		variant: conf.Variant,
+	}
+}
+
+// FrogBehaviour implements the behaviour of a Frog. Frogs hop around on land
+// and swim in water, eat FrogPrey entities nearby and look for a mate when
+// they are fed a slime ball. After breeding, one of the frogs lays frogspawn
+// on the surface of nearby water.
+type FrogBehaviour struct {
+	mc *MovementComputer
+	mob
+	variant FrogVariant
+
+	// dest is the position that the frog is wandering towards. wanderTicks is
+	// the number of ticks until the frog picks a new position to wander to.
+	dest        mgl64.Vec3
+	wanderTicks int
+	hopCooldown int
+
+	prey *world.EntityHandle
+
+	loveTicks     int
+	breedCooldown int
+	// pregnant is true if the frog has bred and is looking for water to lay
+	// frogspawn on. water is the position above the water that it found.
+	pregnant bool
+	water    cube.Pos
+	hasWater bool
+}
+
+// Tick moves the frog towards its prey, mate or the position it is wandering
+// to.
+func (b *FrogBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	f := newFrog(e)
+	if !f.tickMob(tx) {
+		return nil
+	}
+	if b.loveTicks > 0 {
+		b.loveTicks--
+	}
+	if b.breedCooldown > 0 {
+		b.breedCooldown--
+	}
+	if b.hopCooldown > 0 {
+		b.hopCooldown--
+	}
+
+	pos, vel := e.Position(), e.Velocity()
+	swimming := inWater(pos, tx)
+	b.mc.Gravity = 0.08
+	if swimming {
+		b.mc.Gravity = 0.005
+	}
+
+	if dest, ok := b.destination(f, tx); ok {
+		dir := dest.Sub(pos)
+		horizontal := mgl64.Vec3{dir[0], 0, dir[2]}
+		switch {
+		case swimming && dir.Len() > 0.5:
+			vel = vel.Add(dir.Normalize().Mul(b.speed * 1.5).Sub(vel).Mul(0.1))
+		case b.mc.OnGround() && b.hopCooldown == 0 && horizontal.Len() > 0.5:
+			// Frogs move around on land by hopping.
+			vel = horizontal.Normalize().Mul(b.speed * 2.5)
+			vel[1], b.hopCooldown = 0.42, 10
+		}
+	}
+	rot := e.Rotation()
+	if math.Hypot(vel[0], vel[2]) > 0.01 {
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])), 0}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// destination returns the position that the frog should move to. If the frog
+// has nowhere to go, false is returned.
+func (b *FrogBehaviour) destination(f *Frog, tx *world.Tx) (mgl64.Vec3, bool) {
+	pos := f.Position()
+	if b.pregnant {
+		if f.Age()%time.Second == 0 {
+			b.water, b.hasWater = b.findWaterSurface(f, tx)
+		}
+		if b.hasWater {
+			if b.water.Vec3Middle().Sub(pos).Len() < 1.5 {
+				if _, air := tx.Block(b.water).(block.Air); air {
+					tx.SetBlock(b.water, block.Frogspawn{}, nil)
+					b.pregnant, b.hasWater = false, false
+				}
+			}
+			return b.water.Vec3Middle(), true
+		}
+	}
+	if b.loveTicks > 0 {
+		if mate, ok := b.findMate(f, tx); ok {
+			if mate.Position().Sub(pos).Len() < 1.5 {
+				b.breed(f, mate, tx)
+			}
+			return mate.Position(), true
+		}
+	}
+	if prey, ok := b.findPrey(f, tx); ok {
+		if prey.Position().Sub(pos).Len() < 2 {
+			b.eat(prey, tx)
+		}
+		return prey.Position(), true
+	}
+	if b.wanderTicks--; b.wanderTicks <= 0 {
+		b.wanderTicks = 60 + rand.IntN(100)
+		b.dest = pos.Add(mgl64.Vec3{rand.Float64()*12 - 6, 0, rand.Float64()*12 - 6})
+	}
+	return b.dest, b.dest.Sub(pos).Len() > 1
+}
+
+// findPrey returns the FrogPrey entity that the frog is hunting. A new prey is
+// looked for once every second within 10 blocks of the frog.
+func (b *FrogBehaviour) findPrey(f *Frog, tx *world.Tx) (FrogPrey, bool) {
+	pos := f.Position()
+	if b.prey != nil {
+		if ent, ok := b.prey.Entity(tx); ok {
+			if prey, ok := ent.(FrogPrey); ok && !prey.Dead() && ent.Position().Sub(pos).Len() <= 10 {
+				return prey, true
+			}
+		}
+		b.prey = nil
+	}
+	if f.Age()%time.Second != 0 {
+		return nil, false
+	}
+	var (
+		nearest FrogPrey
+		dist    = 10.0
+	)
+	for ent := range tx.EntitiesWithin(cube.Box(-10, -10, -10, 10, 10, 10).Translate(pos)) {
+		prey, ok := ent.(FrogPrey)
+		if !ok || prey.Dead() {
+			continue
+		}
+		if d := ent.Position().Sub(pos).Len(); d <= dist {
+			nearest, dist = prey, d
+		}
+	}
+	if nearest == nil {
+		return nil, false
+	}
+	b.prey = nearest.H()
+	return nearest, true
+}
+
+// eat makes the frog eat the prey passed, removing it from the world and
+// dropping its FrogLoot.
+func (b *FrogBehaviour) eat(prey FrogPrey, tx *world.Tx) {
+	pos := prey.Position()
+	if loot := prey.FrogLoot(b.variant); !loot.Empty() && tx.World().GameRule(world.GameRuleDoMobLoot) {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, loot))
+	}
+	_ = prey.Close()
+	b.prey = nil
+}
+
+// findMate returns the nearest frog within 8 blocks that is also in love.
+func (b *FrogBehaviour) findMate(f *Frog, tx *world.Tx) (*Frog, bool) {
+	pos := f.Position()
+	var (
+		nearest *Frog
+		dist    = 8.0
+	)
+	for ent := range tx.EntitiesWithin(cube.Box(-8, -8, -8, 8, 8, 8).Translate(pos)) {
+		other, ok := ent.(*Frog)
+		if !ok || other.H() == f.H() || !other.InLove() || other.Dead() {
+			continue
+		}
+		if d := ent.Position().Sub(pos).Len(); d <= dist {
+			nearest, dist = other, d
+		}
+	}
+	return nearest, nearest != nil
+}
+
+// breed makes the frog breed with the mate passed. The frog becomes pregnant
+// and both frogs cannot breed again for five minutes.
+func (b *FrogBehaviour) breed(f, mate *Frog, tx *world.Tx) {
+	other := mate.behaviour()
+	b.loveTicks, other.loveTicks = 0, 0
+	b.breedCooldown, other.breedCooldown = frogBreedCooldown, frogBreedCooldown
+	b.pregnant = true
+
+	if tx.World().GameRule(world.GameRuleDoMobLoot) {
+		for _, orb := range NewExperienceOrbs(f.Position(), 1+rand.IntN(7)) {
+			tx.AddEntity(orb)
+		}
+	}
+}
+
+// findWaterSurface finds the nearest position within 8 blocks of the frog
+// that is directly above still water and where frogspawn may be laid. It is
+// called once every second while the frog is pregnant.
+func (b *FrogBehaviour) findWaterSurface(f *Frog, tx *world.Tx) (cube.Pos, bool) {
+	pos := cube.PosFromVec3(f.Position())
+	var (
+		nearest cube.Pos
+		dist    = math.MaxFloat64
+		found   bool
+	)
+	for x := -8; x <= 8; x++ {
+		for z := -8; z <= 8; z++ {
+			for y := -2; y <= 2; y++ {
+				p := pos.Add(cube.Pos{x, y, z})
+				if _, air := tx.Block(p).(block.Air); !air {
+					continue
+				}
+				if liq, ok := tx.Liquid(p.Side(cube.FaceDown)); !ok || liq.LiquidType() != "water" || liq.LiquidDepth() != 8 || liq.LiquidFalling() {
+					continue
+				}
+				if d := float64(x*x + y*y + z*z); d < dist {
+					nearest, dist, found = p, d, true
+				}
+			}
+		}
+	}
+	return nearest, found
+}
+
+// inWater checks if the position passed is in water.
+func inWater(pos mgl64.Vec3, tx *world.Tx) bool {
+	liq, ok := tx.Liquid(cube.PosFromVec3(pos))
+	return ok && liq.LiquidType() == "water"
+}
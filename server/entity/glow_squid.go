@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewGlowSquid creates a glow squid.
+func NewGlowSquid(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(GlowSquidType, glowSquidConf)
+}
+
+var glowSquidConf = GlowSquidBehaviourConfig{
+	Health: 10,
+}
+
+// GlowSquid is a passive aquatic mob that lives in dark water deep below sea
+// level. Glow squids glow in the dark, but stop glowing for a few seconds
+// after being hurt. When killed, they drop glow ink sacs, which may be used on
+// signs to make their text glow. GlowSquid implements the Living and
+// AxolotlPrey interfaces.
+type GlowSquid struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the GlowSquidBehaviour of the GlowSquid.
+func (s *GlowSquid) behaviour() *GlowSquidBehaviour {
+	return s.data.Data.(*GlowSquidBehaviour)
+}
+
+// newGlowSquid returns a GlowSquid that wraps the *Ent passed.
+func newGlowSquid(e *Ent) *GlowSquid {
+	s := &GlowSquid{Ent: e}
+	s.mobEntity = mobEntity{e: e, l: s}
+	return s
+}
+
+// Glowing checks if the GlowSquid is currently glowing. Glow squids stop
+// glowing for five seconds after being hurt.
+func (s *GlowSquid) Glowing() bool {
+	return s.behaviour().darkTicks == 0
+}
+
+// AxolotlHostile returns false: Axolotls only hunt glow squids if they have not
+// recently killed any prey.
+func (s *GlowSquid) AxolotlHostile() bool {
+	return false
+}
+
+// afterHurt makes the GlowSquid stop glowing and flee from its attacker.
+func (s *GlowSquid) afterHurt(_ float64, src world.DamageSource) {
+	s.behaviour().hurt(s, src)
+}
+
+// kill drops the loot and experience of the glow squid.
+func (s *GlowSquid) kill(src world.DamageSource) {
+	dropLoot(s, src, s.tx)
+	dropExperience(s.Position(), 1+rand.IntN(3), s.tx)
+}
+
+// GlowSquidCanSpawn checks if a glow squid may spawn naturally at the position
+// passed. Glow squids only spawn in water that is completely dark, at least 33
+// blocks below sea level.
+func GlowSquidCanSpawn(pos cube.Pos, tx *world.Tx) bool {
+	if pos[1] > 30 || tx.Light(pos) != 0 {
+		return false
+	}
+	return inWater(pos.Vec3Centre(), tx) && inWater(pos.Side(cube.FaceUp).Vec3Centre(), tx)
+}
+
+// GlowSquidType is a world.EntityType implementation for GlowSquid.
+var GlowSquidType glowSquidType
+
+type glowSquidType struct{}
+
+func (glowSquidType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newGlowSquid(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (glowSquidType) EncodeEntity() string { return "minecraft:glow_squid" }
+func (glowSquidType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.475, 0, -0.475, 0.475, 0.95, 0.475)
+}
+
+func (glowSquidType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := glowSquidConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	b := conf.New()
+	b.darkTicks = int(nbtconv.Int32(m, "DarkTicksRemaining"))
+	data.Data = b
+}
+
+func (glowSquidType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*GlowSquidBehaviour)
+	return map[string]any{"Health": float32(b.health.Health()), "DarkTicksRemaining": int32(b.darkTicks)}
+}
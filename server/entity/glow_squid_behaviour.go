@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// glowSquidDarkTicks is the number of ticks that a glow squid stops
+	// glowing for after being hurt.
+	glowSquidDarkTicks = 100
+	// glowSquidAirTicks is the number of ticks that a glow squid can survive
+	// out of water before it starts suffocating.
+	glowSquidAirTicks = 300
+)
+
+// GlowSquidBehaviourConfig holds optional parameters for a
+// GlowSquidBehaviour.
+type GlowSquidBehaviourConfig struct {
+	// Health is the health that the glow squid has when it is created.
+	Health float64
+}
+
+func (conf GlowSquidBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a GlowSquidBehaviour using the parameters in conf.
+func (conf GlowSquidBehaviourConfig) New() *GlowSquidBehaviour {
+	return &GlowSquidBehaviour{
+		mc:  &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob: newMob(conf.Health, 0.1),
+	}
+}
+
+// GlowSquidBehaviour implements the behaviour of a GlowSquid. Glow squids swim
+// around randomly in water and flee from entities that hurt them. Out of
+// water, they eventually suffocate.
+type GlowSquidBehaviour struct {
+	mc *MovementComputer
+	mob
+
+	// darkTicks is the number of ticks left until the glow squid starts
+	// glowing again.
+	darkTicks int
+	// airTicks is the number of ticks that the glow squid has been out of
+	// water.
+	airTicks int
+
+	dest        mgl64.Vec3
+	wanderTicks int
+	// fleeing is true while the glow squid swims away from its attacker.
+	fleeing bool
+}
+
+// Tick makes the glow squid swim around and suffocate when out of water.
+func (b *GlowSquidBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	s := newGlowSquid(e)
+	if !s.tickMob(tx) {
+		return nil
+	}
+	if b.darkTicks > 0 {
+		b.darkTicks--
+	}
+
+	pos, vel := e.Position(), e.Velocity()
+	if inWater(pos, tx) {
+		b.airTicks, b.mc.Gravity = 0, 0.005
+		if b.wanderTicks--; b.wanderTicks <= 0 || b.dest.Sub(pos).Len() < 0.5 {
+			b.wanderTicks, b.fleeing = 40+rand.IntN(60), false
+			b.dest = pos.Add(mgl64.Vec3{rand.Float64()*10 - 5, rand.Float64()*4 - 2, rand.Float64()*10 - 5})
+		}
+		if !inWater(b.dest, tx) {
+			// Don't swim out of the water.
+			b.dest = pos
+		}
+		speed := b.speed
+		if b.fleeing {
+			speed *= 3
+		}
+		if dir := b.dest.Sub(pos); dir.Len() > mgl64.Epsilon {
+			vel = vel.Add(dir.Normalize().Mul(speed).Sub(vel).Mul(0.1))
+		}
+	} else {
+		b.mc.Gravity = 0.08
+		if b.airTicks++; b.airTicks > glowSquidAirTicks && b.airTicks%20 == 0 {
+			s.Hurt(2, DrowningDamageSource{})
+		}
+	}
+	rot := e.Rotation()
+	if math.Hypot(vel[0], vel[2]) > 0.01 {
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])), 0}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// hurt makes the glow squid stop glowing. If it was hurt by another entity,
+// the glow squid flees away from it.
+func (b *GlowSquidBehaviour) hurt(s *GlowSquid, src world.DamageSource) {
+	b.darkTicks = glowSquidDarkTicks
+	attack, ok := src.(AttackDamageSource)
+	if !ok || attack.Attacker == nil {
+		return
+	}
+	pos := s.Position()
+	if away := pos.Sub(attack.Attacker.Position()); away.Len() > mgl64.Epsilon {
+		b.dest, b.wanderTicks, b.fleeing = pos.Add(away.Normalize().Mul(8)), 40, true
+	}
+}
@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewGoat creates a goat with both of its horns. One in fifty goats created is
+// a screaming goat.
+func NewGoat(opts world.EntitySpawnOpts) *world.EntityHandle {
+	conf := goatConf
+	conf.Screaming = rand.IntN(50) == 0
+	return opts.New(GoatType, conf)
+}
+
+var goatConf = GoatBehaviourConfig{
+	Health:       10,
+	RamDamage:    2,
+	LeftHorn:     true,
+	RightHorn:    true,
+	RamKnockBack: 1.6,
+}
+
+// Goat is a neutral mob found in the mountains. Goats long jump across gaps
+// and occasionally ram entities standing still near them, knocking them back
+// far. A goat that rams a hard block instead loses one of its horns, which is
+// dropped as a goat horn item. Screaming goats ram more often and drop
+// different goat horns. Goat implements the Living interface.
+type Goat struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the GoatBehaviour of the Goat.
+func (g *Goat) behaviour() *GoatBehaviour {
+	return g.data.Data.(*GoatBehaviour)
+}
+
+// newGoat returns a Goat that wraps the *Ent passed.
+func newGoat(e *Ent) *Goat {
+	g := &Goat{Ent: e}
+	g.mobEntity = mobEntity{e: e, l: g}
+	return g
+}
+
+// Screaming checks if the Goat is a screaming goat.
+func (g *Goat) Screaming() bool {
+	return g.behaviour().conf.Screaming
+}
+
+// HornCount returns the number of horns that the Goat has left.
+func (g *Goat) HornCount() int {
+	b := g.behaviour()
+	return int(boolByte(b.leftHorn) + boolByte(b.rightHorn))
+}
+
+// Ramming checks if the Goat is currently charging at a target.
+func (g *Goat) Ramming() bool {
+	return g.behaviour().ramming
+}
+
+// modifyDamage makes the Goat take 10 less fall damage than other entities.
+func (g *Goat) modifyDamage(dmg float64, src world.DamageSource) (float64, bool) {
+	if _, ok := src.(FallDamageSource); ok {
+		dmg -= 10
+	}
+	return dmg, dmg > 0
+}
+
+// kill drops the loot and experience of the goat.
+func (g *Goat) kill(src world.DamageSource) {
+	dropLoot(g, src, g.tx)
+	dropExperience(g.Position(), 1+rand.IntN(3), g.tx)
+}
+
+// GoatType is a world.EntityType implementation for Goat.
+var GoatType goatType
+
+type goatType struct{}
+
+func (goatType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newGoat(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (goatType) EncodeEntity() string { return "minecraft:goat" }
+func (goatType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.45, 0, -0.45, 0.45, 1.3, 0.45)
+}
+
+func (goatType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := goatConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	conf.Screaming = nbtconv.Bool(m, "Screaming")
+	conf.LeftHorn, conf.RightHorn = nbtconv.Bool(m, "LeftHorn"), nbtconv.Bool(m, "RightHorn")
+	data.Data = conf.New()
+}
+
+func (goatType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*GoatBehaviour)
+	return map[string]any{
+		"Health":    float32(b.health.Health()),
+		"Screaming": boolByte(b.conf.Screaming),
+		"LeftHorn":  boolByte(b.leftHorn),
+		"RightHorn": boolByte(b.rightHorn),
+	}
+}
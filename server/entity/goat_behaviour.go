@@ -0,0 +1,406 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// goatRamWindUp is the number of ticks that a goat lowers its head before
+	// charging. The target must stand still during this time.
+	goatRamWindUp = 20
+	// goatRamTicks is the maximum number of ticks that a goat charges for.
+	goatRamTicks = 40
+	// goatRamSpeed is the speed in blocks per tick that a goat charges at.
+	goatRamSpeed = 0.6
+)
+
+// GoatBehaviourConfig holds optional parameters for a GoatBehaviour.
+type GoatBehaviourConfig struct {
+	// Health is the health that the goat has when it is created.
+	Health float64
+	// Screaming specifies if the goat is a screaming goat. Screaming goats ram
+	// far more often than normal goats and drop different goat horns.
+	Screaming bool
+	// LeftHorn and RightHorn specify if the goat has its left and right horn.
+	LeftHorn, RightHorn bool
+	// RamDamage is the damage dealt to entities rammed by the goat.
+	RamDamage float64
+	// RamKnockBack is the horizontal force with which entities rammed by the
+	// goat are knocked back.
+	RamKnockBack float64
+}
+
+func (conf GoatBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a GoatBehaviour using the parameters in conf.
+func (conf GoatBehaviourConfig) New() *GoatBehaviour {
+	b := &GoatBehaviour{
+		conf:      conf,
+		mc:        &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:       newMob(conf.Health, 0.2),
+		leftHorn:  conf.LeftHorn,
+		rightHorn: conf.RightHorn,
+	}
+	b.ramCooldown, b.jumpCooldown = b.nextRamCooldown(), 600+rand.IntN(600)
+	return b
+}
+
+// GoatBehaviour implements the behaviour of a Goat. Goats wander around and
+// occasionally long jump to a position up to ten blocks away, crossing gaps.
+// Every so often, a goat picks an entity standing still nearby and charges at
+// it, dealing damage and knocking it back far. If the goat hits a hard block
+// instead, it loses one of its horns.
+type GoatBehaviour struct {
+	conf GoatBehaviourConfig
+	mc   *MovementComputer
+	mob
+
+	leftHorn, rightHorn bool
+
+	dest        mgl64.Vec3
+	wanderTicks int
+	// blocked is true if the goat walked into a block during the last tick.
+	blocked bool
+
+	jumpCooldown int
+	// jumping is true from the moment the goat leaves the ground for a long
+	// jump until it lands again.
+	jumping bool
+
+	ramCooldown int
+	// target is the entity that the goat is about to ram. targetPos is the
+	// position that the target was at when the goat picked it.
+	target    *world.EntityHandle
+	targetPos mgl64.Vec3
+	// windUp is the number of ticks left before the goat starts charging.
+	windUp int
+	// ramming is true while the goat charges in ramDir. ramTicks is the number
+	// of ticks that the goat has been charging for.
+	ramming  bool
+	ramDir   mgl64.Vec3
+	ramTicks int
+}
+
+// Tick makes the goat wander, long jump and ram entities and blocks in its
+// way.
+func (b *GoatBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	g := newGoat(e)
+	if !g.tickMob(tx) {
+		return nil
+	}
+	if b.ramCooldown > 0 {
+		b.ramCooldown--
+	}
+	if b.jumpCooldown > 0 {
+		b.jumpCooldown--
+	}
+
+	pos, vel := e.Position(), e.Velocity()
+	if b.jumping && b.mc.OnGround() && vel[1] <= 0 {
+		b.jumping = false
+	}
+	switch {
+	case b.ramming:
+		vel = b.ramDir.Mul(goatRamSpeed).Add(mgl64.Vec3{0, vel[1]})
+		if b.ramTicks++; b.ramTicks > goatRamTicks || b.hitEntity(g, tx) {
+			b.stopRamming(g, tx)
+		}
+	case b.target != nil:
+		b.tickWindUp(g, tx)
+	case b.jumping || !b.mc.OnGround():
+		// Goats can't change direction mid-air.
+	case b.ramCooldown == 0 && b.findTarget(g, tx):
+	case b.jumpCooldown == 0:
+		b.jumpCooldown = 600 + rand.IntN(600)
+		if v, ok := b.longJump(g, tx); ok {
+			vel, b.jumping = v, true
+		}
+	default:
+		vel = b.wander(g, vel)
+	}
+
+	rot := e.Rotation()
+	if math.Hypot(vel[0], vel[2]) > 0.01 {
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])), 0}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+
+	b.blocked = math.Hypot(vel[0], vel[2]) > 0.05 && math.Hypot(m.dpos[0], m.dpos[2]) < 0.01
+	if b.ramming && b.blocked {
+		b.ramBlock(g, tx)
+		b.stopRamming(g, tx)
+	}
+	return m
+}
+
+// wander makes the goat walk towards a random position nearby, stepping up a
+// block if it walks into one.
+func (b *GoatBehaviour) wander(g *Goat, vel mgl64.Vec3) mgl64.Vec3 {
+	pos := g.Position()
+	if b.wanderTicks--; b.wanderTicks <= 0 {
+		b.wanderTicks = 80 + rand.IntN(120)
+		b.dest = pos
+		if rand.IntN(3) != 0 {
+			b.dest = pos.Add(mgl64.Vec3{rand.Float64()*16 - 8, 0, rand.Float64()*16 - 8})
+		}
+	}
+	dir := b.dest.Sub(pos)
+	dir[1] = 0
+	if dir.Len() < 1 {
+		return vel
+	}
+	vel = dir.Normalize().Mul(b.speed).Add(mgl64.Vec3{0, vel[1]})
+	if b.blocked {
+		vel[1] = 0.42
+	}
+	return vel
+}
+
+// findTarget looks for a Living entity between 4 and 10 blocks away to ram. If
+// one is found, the goat starts lowering its head and true is returned.
+func (b *GoatBehaviour) findTarget(g *Goat, tx *world.Tx) bool {
+	if g.Age()%time.Second != 0 {
+		return false
+	}
+	pos := g.Position()
+	var targets []Living
+	for ent := range tx.EntitiesWithin(cube.Box(-10, -4, -10, 10, 4, 10).Translate(pos)) {
+		l, ok := ent.(Living)
+		if !ok || l.Dead() || !goatCanRam(l) {
+			continue
+		}
+		if d := ent.Position().Sub(pos).Len(); d >= 4 && d <= 10 {
+			targets = append(targets, l)
+		}
+	}
+	if len(targets) == 0 {
+		return false
+	}
+	target := targets[rand.IntN(len(targets))]
+	b.target, b.targetPos, b.windUp = target.H(), target.Position(), goatRamWindUp
+	tx.PlaySound(pos, sound.GoatPreRam{Screaming: b.conf.Screaming})
+	return true
+}
+
+// goatCanRam checks if a goat can ram the Living entity passed.
+func goatCanRam(l Living) bool {
+	if _, ok := l.(*Goat); ok {
+		return false
+	}
+	if g, ok := l.(interface{ GameMode() world.GameMode }); ok && !g.GameMode().AllowsTakingDamage() {
+		return false
+	}
+	return true
+}
+
+// tickWindUp makes the goat face its target while lowering its head. If the
+// target moves away from where it was standing, the goat gives up. Otherwise,
+// the goat starts charging once it is done winding up.
+func (b *GoatBehaviour) tickWindUp(g *Goat, tx *world.Tx) {
+	ent, ok := b.target.Entity(tx)
+	if !ok || ent.Position().Sub(b.targetPos).Len() > 0.5 {
+		b.target, b.ramCooldown = nil, 100
+		return
+	}
+	if l, ok := ent.(Living); ok && l.Dead() {
+		b.target, b.ramCooldown = nil, 100
+		return
+	}
+	dir := b.targetPos.Sub(g.Position())
+	dir[1] = 0
+	if dir.Len() < mgl64.Epsilon {
+		b.target = nil
+		return
+	}
+	b.ramDir = dir.Normalize()
+	g.data.Rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-dir[0], dir[2])), 0}
+	if b.windUp--; b.windUp <= 0 {
+		b.ramming, b.ramTicks = true, 0
+		b.updateState(g, tx)
+	}
+}
+
+// hitEntity checks if the goat ran into a Living entity while charging. If so,
+// the entity is damaged and knocked back and true is returned.
+func (b *GoatBehaviour) hitEntity(g *Goat, tx *world.Tx) bool {
+	pos := g.Position()
+	box := GoatType.BBox(g).Translate(pos).Grow(0.2)
+	for ent := range tx.EntitiesWithin(box) {
+		l, ok := ent.(Living)
+		if !ok || l.Dead() || !goatCanRam(l) {
+			continue
+		}
+		if !ent.H().Type().BBox(ent).Translate(ent.Position()).IntersectsWith(box) {
+			continue
+		}
+		l.Hurt(b.conf.RamDamage, AttackDamageSource{Attacker: g})
+		l.KnockBack(pos, b.conf.RamKnockBack, 0.6)
+		tx.PlaySound(pos, sound.GoatRamImpact{Screaming: b.conf.Screaming})
+		return true
+	}
+	return false
+}
+
+// ramBlock makes the goat ram the block in front of it. If the block is hard
+// enough, the goat loses one of its horns, which is dropped as a goat horn.
+func (b *GoatBehaviour) ramBlock(g *Goat, tx *world.Tx) {
+	pos := g.Position()
+	tx.PlaySound(pos, sound.GoatRamImpact{Screaming: b.conf.Screaming})
+	front := pos.Add(b.ramDir.Mul(0.8))
+	if !snapsGoatHorn(tx.Block(cube.PosFromVec3(front))) && !snapsGoatHorn(tx.Block(cube.PosFromVec3(front.Add(mgl64.Vec3{0, 1})))) {
+		return
+	}
+	switch {
+	case b.leftHorn && b.rightHorn:
+		if rand.IntN(2) == 0 {
+			b.leftHorn = false
+		} else {
+			b.rightHorn = false
+		}
+	case b.leftHorn:
+		b.leftHorn = false
+	case b.rightHorn:
+		b.rightHorn = false
+	default:
+		return
+	}
+	horns := sound.GoatHorns()
+	horn := horns[rand.IntN(4)]
+	if b.conf.Screaming {
+		horn = horns[4+rand.IntN(4)]
+	}
+	tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos.Add(mgl64.Vec3{0, 1})}, item.NewStack(item.GoatHorn{Type: horn}, 1)))
+	tx.PlaySound(pos, sound.GoatHornBreak{})
+}
+
+// snapsGoatHorn checks if a goat ramming the block passed loses one of its
+// horns.
+func snapsGoatHorn(b world.Block) bool {
+	switch b.(type) {
+	case block.Stone, block.Log, block.IronOre, block.CopperOre, block.EmeraldOre, block.PackedIce:
+		return true
+	}
+	return false
+}
+
+// stopRamming stops the charge of the goat and resets its ram cooldown.
+func (b *GoatBehaviour) stopRamming(g *Goat, tx *world.Tx) {
+	b.ramming, b.target = false, nil
+	b.ramCooldown = b.nextRamCooldown()
+	b.updateState(g, tx)
+}
+
+// nextRamCooldown returns a random number of ticks until the goat may ram
+// again. Screaming goats ram every 5 to 15 seconds, while normal goats ram
+// every 30 to 300 seconds.
+func (b *GoatBehaviour) nextRamCooldown() int {
+	if b.conf.Screaming {
+		return 100 + rand.IntN(200)
+	}
+	return 600 + rand.IntN(5400)
+}
+
+// longJump looks for a random position to jump to within 10 blocks
+// horizontally and 4 blocks vertically of the goat, of which the path is not
+// obstructed by blocks. The velocity needed to land on that position is
+// returned.
+func (b *GoatBehaviour) longJump(g *Goat, tx *world.Tx) (mgl64.Vec3, bool) {
+	pos := g.Position()
+	for range 20 {
+		dx, dz := rand.IntN(21)-10, rand.IntN(21)-10
+		dist := math.Hypot(float64(dx), float64(dz))
+		if dist < 4 || dist > 10 {
+			continue
+		}
+		for dy := 4; dy >= -4; dy-- {
+			dest := cube.PosFromVec3(pos).Add(cube.Pos{dx, dy, dz})
+			if !goatCanStand(dest, tx) {
+				continue
+			}
+			target := dest.Vec3Centre().Sub(mgl64.Vec3{0, 0.5})
+			vel := b.jumpVelocity(target.Sub(pos), 8+int(dist*1.2))
+			if vel[1] <= 1.3 && b.clearPath(g, vel, 8+int(dist*1.2), tx) {
+				return vel, true
+			}
+			break
+		}
+	}
+	return mgl64.Vec3{}, false
+}
+
+// jumpVelocity calculates the velocity with which the goat must jump to be
+// displaced by delta after the number of ticks passed. Collisions with blocks
+// are not taken into account.
+func (b *GoatBehaviour) jumpVelocity(delta mgl64.Vec3, ticks int) mgl64.Vec3 {
+	// The goat is on the ground during the first tick of the jump, so its
+	// horizontal velocity is reduced by ground friction once.
+	const groundFriction = 0.6
+	drag := 1 - b.mc.Drag
+	var horizontal, vertical, fall float64
+	for i, dragPow, vy := 0, 1.0, 0.0; i < ticks; i++ {
+		horizontal += groundFriction * dragPow * drag
+		dragPow *= drag
+		vertical += dragPow
+		vy = vy*drag - b.mc.Gravity
+		fall += vy
+	}
+	return mgl64.Vec3{delta[0] / horizontal, (delta[1] - fall) / vertical, delta[2] / horizontal}
+}
+
+// clearPath checks if a goat jumping with the velocity passed does not collide
+// with any blocks during the number of ticks passed.
+func (b *GoatBehaviour) clearPath(g *Goat, vel mgl64.Vec3, ticks int, tx *world.Tx) bool {
+	pos, box := g.Position(), GoatType.BBox(g)
+	drag := 1 - b.mc.Drag
+	vel[0], vel[2] = vel[0]*0.6, vel[2]*0.6
+	for i := 0; i < ticks-1; i++ {
+		vel = mgl64.Vec3{vel[0] * drag, vel[1]*drag - b.mc.Gravity, vel[2] * drag}
+		pos = pos.Add(vel)
+		entityBBox := box.Translate(pos).Grow(-0.05)
+		for _, bb := range blockBBoxsAround(tx, entityBBox) {
+			if bb.IntersectsWith(entityBBox) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// goatCanStand checks if a goat can stand at the position passed: The block
+// below must be solid, while the position itself and the block above must be
+// passable and free of liquid.
+func goatCanStand(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	if !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+		return false
+	}
+	for _, p := range []cube.Pos{pos, pos.Side(cube.FaceUp)} {
+		if len(tx.Block(p).Model().BBox(p, tx)) != 0 {
+			return false
+		}
+		if _, ok := tx.Liquid(p); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// updateState sends the state of the goat to its viewers, updating whether it
+// is ramming and the number of horns it has.
+func (b *GoatBehaviour) updateState(g *Goat, tx *world.Tx) {
+	for _, v := range tx.Viewers(g.Position()) {
+		v.ViewEntityState(g)
+	}
+}
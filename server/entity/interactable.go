@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Interactable represents an entity that reacts to being interacted with by a
+// user, regardless of the item that the user is holding.
+type Interactable interface {
+	world.Entity
+	// Interact is called when the user passed interacts with the entity. The
+	// UseContext may be used to subtract from the count of the item held or
+	// to give the user a new item. Interact returns true if the interaction
+	// was successful, in which case the item held is not used on the entity.
+	Interact(user item.User, tx *world.Tx, ctx *item.UseContext) bool
+}
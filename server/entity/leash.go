@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"math"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// leashPullDistance is the distance from its holder after which a leashed
+	// entity is pulled towards the holder.
+	leashPullDistance = 6.0
+	// leashBreakDistance is the distance from its holder after which the
+	// leash of an entity breaks.
+	leashBreakDistance = 10.0
+)
+
+// Leash holds the leash state of an entity. Behaviours of entities that may
+// be leashed embed a Leash and call TickLeash every tick to keep the entity
+// within range of its holder.
+type Leash struct {
+	holder *world.EntityHandle
+}
+
+// LeashHolder returns the handle of the entity holding the leash. False is
+// returned if the entity is not leashed.
+func (l *Leash) LeashHolder() (*world.EntityHandle, bool) {
+	return l.holder, l.holder != nil
+}
+
+// leash returns the Leash itself, so that an Ent can find the Leash embedded
+// in its Behaviour.
+func (l *Leash) leash() *Leash {
+	return l
+}
+
+// TickLeash pulls the entity towards its leash holder if it is too far away
+// and breaks the leash if it is stretched too far or if the holder no longer
+// exists.
+func (l *Leash) TickLeash(e *Ent, tx *world.Tx) {
+	if l.holder == nil {
+		return
+	}
+	holder, ok := l.holder.Entity(tx)
+	if !ok {
+		e.Unleash(true)
+		return
+	}
+	diff := holder.Position().Sub(e.Position())
+	dist := diff.Len()
+	if dist > leashBreakDistance {
+		e.Unleash(true)
+		return
+	}
+	if dist > leashPullDistance {
+		d := diff.Mul(1 / dist)
+		e.data.Vel = e.data.Vel.Add(mgl64.Vec3{
+			math.Copysign(d[0]*d[0]*0.4, d[0]),
+			math.Copysign(d[1]*d[1]*0.4, d[1]),
+			math.Copysign(d[2]*d[2]*0.4, d[2]),
+		})
+	}
+}
+
+// leashed is implemented by Behaviours that embed a Leash.
+type leashed interface {
+	leash() *Leash
+}
+
+// Leash leashes the Ent to the holder passed. False is returned if the
+// Behaviour of the Ent does not support leashing.
+func (e *Ent) Leash(holder world.Entity) bool {
+	l, ok := e.Behaviour().(leashed)
+	if !ok || holder.H() == e.H() {
+		return false
+	}
+	l.leash().holder = holder.H()
+	e.tx.PlaySound(e.Position(), sound.LeashKnotPlace{})
+	for _, v := range e.tx.Viewers(e.Position()) {
+		v.ViewEntityState(e)
+	}
+	return true
+}
+
+// Unleash removes the leash of the Ent, if it has one. If drop is true, a lead
+// is dropped at the position of the Ent.
+func (e *Ent) Unleash(drop bool) {
+	l, ok := e.Behaviour().(leashed)
+	if !ok || l.leash().holder == nil {
+		return
+	}
+	l.leash().holder = nil
+	if drop && e.tx.World().GameRule(world.GameRuleDoEntityDrops) {
+		e.tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: e.Position()}, item.NewStack(item.Lead{}, 1)))
+	}
+	for _, v := range e.tx.Viewers(e.Position()) {
+		v.ViewEntityState(e)
+	}
+}
+
+// LeashHolder returns the handle of the entity that holds the leash of the
+// Ent. False is returned if the Ent is not leashed or if its Behaviour does not
+// support leashing.
+func (e *Ent) LeashHolder() (*world.EntityHandle, bool) {
+	if l, ok := e.Behaviour().(leashed); ok {
+		return l.leash().LeashHolder()
+	}
+	return nil, false
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// NewLeashKnot creates a leash knot entity. A leash knot is created when a
+// leashed entity is attached to a fence and holds the leashes of all entities
+// attached to that fence.
+func NewLeashKnot(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(LeashKnotType, leashKnotConf)
+}
+
+var leashKnotConf = StationaryBehaviourConfig{
+	SpawnSounds: []world.Sound{sound.LeashKnotPlace{}},
+	Tick:        tickLeashKnot,
+}
+
+// tickLeashKnot closes the leash knot if the fence it is attached to is no
+// longer present or if no entities are leashed to it anymore. Entities still
+// leashed to the knot drop their leads when the fence is removed.
+func tickLeashKnot(e *Ent, tx *world.Tx) {
+	if e.Age() == 0 {
+		// Give entities a tick to be leashed to the knot after it is created.
+		return
+	}
+	pos := cube.PosFromVec3(e.Position())
+	_, woodFence := tx.Block(pos).(block.WoodFence)
+	_, netherFence := tx.Block(pos).(block.NetherBrickFence)
+	attached := woodFence || netherFence
+
+	empty := true
+	for other := range tx.EntitiesWithin(cube.Box(-leashBreakDistance, -leashBreakDistance, -leashBreakDistance, leashBreakDistance, leashBreakDistance, leashBreakDistance).Translate(e.Position())) {
+		l, ok := other.(interface {
+			LeashHolder() (*world.EntityHandle, bool)
+			Unleash(drop bool)
+		})
+		if !ok {
+			continue
+		}
+		if holder, ok := l.LeashHolder(); ok && holder == e.H() {
+			if !attached {
+				l.Unleash(true)
+				continue
+			}
+			empty = false
+		}
+	}
+	if empty || !attached {
+		tx.PlaySound(e.Position(), sound.LeashKnotBreak{})
+		_ = e.Close()
+	}
+}
+
+// LeashKnotType is a world.EntityType implementation for leash knots.
+var LeashKnotType leashKnotType
+
+type leashKnotType struct{}
+
+func (t leashKnotType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (leashKnotType) EncodeEntity() string { return "minecraft:leash_knot" }
+func (leashKnotType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.1875, -0.25, -0.1875, 0.1875, 0.25, 0.1875)
+}
+
+func (leashKnotType) DecodeNBT(_ map[string]any, data *world.EntityData) {
+	data.Data = leashKnotConf.New()
+}
+func (leashKnotType) EncodeNBT(*world.EntityData) map[string]any { return nil }
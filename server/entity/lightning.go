@@ -28,6 +28,7 @@ func NewLightningWithDamage(opts world.EntitySpawnOpts, dmg float64, blockFire b
 		BlockFire:          blockFire,
 		state:              2,
 		lifetime:           rand.IntN(4) + 1,
+		struck:             map[*world.EntityHandle]struct{}{},
 	}).tick
 	return opts.New(LightningType, conf)
 }
@@ -40,12 +41,29 @@ type lightningState struct {
 	EntityFireDuration time.Duration
 	BlockFire          bool
 	state, lifetime    int
+
+	conductorChecked bool
+	struck           map[*world.EntityHandle]struct{}
+}
+
+// LightningStrikeable represents an entity that is affected by being struck by
+// lightning beyond taking damage. Pigs, for example, turn into zombified
+// piglins when struck, and creepers become charged.
+type LightningStrikeable interface {
+	world.Entity
+	// StruckByLightning is called once when the entity is struck by the
+	// lightning entity passed. It is called before the entity takes damage.
+	StruckByLightning(tx *world.Tx, lightning world.Entity)
 }
 
 // tick carries out lightning logic, dealing damage and setting blocks/entities
 // on fire when appropriate.
 func (s *lightningState) tick(e *Ent, tx *world.Tx) {
 	pos := e.Position()
+	if !s.conductorChecked {
+		s.conductorChecked = true
+		s.strikeConductor(tx, cube.PosFromVec3(pos).Side(cube.FaceDown))
+	}
 
 	if s.state--; s.state < 0 {
 		if s.lifetime == 0 {
@@ -69,19 +87,35 @@ func (s *lightningState) tick(e *Ent, tx *world.Tx) {
 func (s *lightningState) dealDamage(e *Ent, tx *world.Tx) {
 	pos := e.Position()
 	bb := e.H().Type().BBox(e).GrowVec3(mgl64.Vec3{3, 6, 3}).Translate(pos.Add(mgl64.Vec3{0, 3}))
-	for e := range tx.EntitiesWithin(bb) {
+	for ent := range tx.EntitiesWithin(bb) {
+		if l, ok := ent.(LightningStrikeable); ok {
+			if _, ok := s.struck[ent.H()]; !ok {
+				s.struck[ent.H()] = struct{}{}
+				l.StruckByLightning(tx, e)
+			}
+		}
 		// Only damage entities that weren't already dead.
-		if l, ok := e.(Living); ok && l.Health() > 0 {
+		if l, ok := ent.(Living); ok && l.Health() > 0 {
 			if s.Damage > 0 {
 				l.Hurt(s.Damage, LightningDamageSource{})
 			}
-			if f, ok := e.(Flammable); ok && f.OnFireDuration() < s.EntityFireDuration {
+			if f, ok := ent.(Flammable); ok && f.OnFireDuration() < s.EntityFireDuration {
 				f.SetOnFire(s.EntityFireDuration)
 			}
 		}
 	}
 }
 
+// strikeConductor notifies the block at the position passed if it is a
+// world.LightningConductor. Lightning striking a conductor does not set blocks
+// on fire.
+func (s *lightningState) strikeConductor(tx *world.Tx, pos cube.Pos) {
+	if c, ok := tx.Block(pos).(world.LightningConductor); ok {
+		c.StruckByLightning(pos, tx)
+		s.BlockFire = false
+	}
+}
+
 // spreadFire attempts to place fire at the position of the lightning and does
 // 4 additional attempts to spread it around that position.
 func (s *lightningState) spreadFire(tx *world.Tx, pos cube.Pos) {
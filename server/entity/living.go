@@ -1,8 +1,14 @@
 package entity
 
 import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
 	"github.com/go-gl/mathgl/mgl64"
 )
 
@@ -52,3 +58,240 @@ type Living interface {
 	// SetSpeed sets the speed of an entity to a new value.
 	SetSpeed(float64)
 }
+
+// mob holds the state shared by the behaviours of all mobs, such as their
+// health, effects and speed. It is embedded in the behaviour of every mob and
+// used by the mobEntity embedded in its entity type.
+type mob struct {
+	health  *HealthManager
+	effects *EffectManager
+	speed   float64
+
+	immuneUntil time.Duration
+	lastDamage  float64
+	deathTicks  int
+}
+
+// newMob returns a mob with the health and the speed in blocks per tick
+// passed.
+func newMob(health, speed float64) mob {
+	return mob{health: NewHealthManager(health, health), effects: NewEffectManager(), speed: speed}
+}
+
+// mobState returns the mob itself. It allows the mobEntity of a mob to find
+// the mob state embedded in its behaviour.
+func (m *mob) mobState() *mob {
+	return m
+}
+
+// mobLiving is implemented by the entity types of all mobs.
+type mobLiving interface {
+	Living
+	// kill is called when the mob dies, after its death animation was shown
+	// to viewers. It drops the loot and experience of the mob, if any.
+	kill(src world.DamageSource)
+}
+
+// damageModifier is implemented by mobs that take more or less damage than
+// other mobs from some sources. modifyDamage returns the damage that the mob
+// should take and false if it should not take damage at all.
+type damageModifier interface {
+	modifyDamage(dmg float64, src world.DamageSource) (float64, bool)
+}
+
+// hurtReactor is implemented by mobs that react to being hurt. afterHurt is
+// called with the damage dealt after immunity if the mob survived the damage.
+type hurtReactor interface {
+	afterHurt(dmg float64, src world.DamageSource)
+}
+
+// mobEntity implements the methods of the Living interface that are the same
+// for all mobs. It is embedded in the entity type of every mob, next to the
+// *Ent of the mob, and stores its state in the mob embedded in the behaviour
+// of the mob.
+type mobEntity struct {
+	e *Ent
+	l mobLiving
+}
+
+// state returns the mob state embedded in the behaviour of the mob.
+func (m mobEntity) state() *mob {
+	return m.e.data.Data.(interface{ mobState() *mob }).mobState()
+}
+
+// Health returns the health of the mob.
+func (m mobEntity) Health() float64 {
+	return m.state().health.Health()
+}
+
+// MaxHealth returns the maximum health of the mob.
+func (m mobEntity) MaxHealth() float64 {
+	return m.state().health.MaxHealth()
+}
+
+// SetMaxHealth changes the maximum health of the mob.
+func (m mobEntity) SetMaxHealth(v float64) {
+	m.state().health.SetMaxHealth(v)
+}
+
+// Dead checks if the mob has no health left.
+func (m mobEntity) Dead() bool {
+	return m.Health() <= mgl64.Epsilon
+}
+
+// Hurt hurts the mob for the damage passed. After being hurt, the mob is
+// immune to damage for half a second, unless the damage dealt is higher than
+// the damage it was last hurt for. If the mob dies, its death animation is
+// shown to viewers and its loot and experience are dropped.
+func (m mobEntity) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
+	if mod, ok := m.l.(damageModifier); ok {
+		if dmg, ok = mod.modifyDamage(dmg, src); !ok {
+			return 0, false
+		}
+	}
+	if _, ok := m.Effect(effect.FireResistance); (ok && src.Fire()) || m.Dead() || dmg < 0 {
+		return 0, false
+	}
+	s := m.state()
+	damageLeft := dmg
+	if m.e.Age() < s.immuneUntil {
+		if damageLeft = damageLeft - s.lastDamage; damageLeft <= 0 {
+			return 0, false
+		}
+	}
+	s.immuneUntil, s.lastDamage = m.e.Age()+time.Second/2, dmg
+	s.health.AddHealth(-damageLeft)
+
+	for _, v := range m.e.tx.Viewers(m.e.Position()) {
+		v.ViewEntityAction(m.l, HurtAction{})
+	}
+	if m.Dead() {
+		for _, v := range m.e.tx.Viewers(m.e.Position()) {
+			v.ViewEntityAction(m.l, DeathAction{})
+		}
+		m.l.kill(src)
+		return dmg, true
+	}
+	if r, ok := m.l.(hurtReactor); ok {
+		r.afterHurt(damageLeft, src)
+	}
+	return dmg, true
+}
+
+// Heal heals the mob for the health passed.
+func (m mobEntity) Heal(health float64, _ world.HealingSource) {
+	if m.Dead() || health < 0 {
+		return
+	}
+	m.state().health.AddHealth(health)
+}
+
+// KnockBack knocks the mob back, away from the source passed.
+func (m mobEntity) KnockBack(src mgl64.Vec3, force, height float64) {
+	if m.Dead() {
+		return
+	}
+	velocity := m.e.Position().Sub(src)
+	velocity[1] = 0
+	if velocity.Len() != 0 {
+		velocity = velocity.Normalize().Mul(force)
+	}
+	velocity[1] = height
+	m.e.SetVelocity(velocity)
+}
+
+// AddEffect adds an effect.Effect to the mob.
+func (m mobEntity) AddEffect(e effect.Effect) {
+	m.state().effects.Add(e, m.l)
+}
+
+// RemoveEffect removes the effect.Type passed from the mob.
+func (m mobEntity) RemoveEffect(e effect.Type) {
+	m.state().effects.Remove(e, m.l)
+}
+
+// Effect returns the effect.Effect of the effect.Type passed currently
+// applied to the mob, and whether it was applied at all.
+func (m mobEntity) Effect(e effect.Type) (effect.Effect, bool) {
+	return m.state().effects.Effect(e)
+}
+
+// Effects returns the effects currently applied to the mob.
+func (m mobEntity) Effects() []effect.Effect {
+	return m.state().effects.Effects()
+}
+
+// Speed returns the speed of the mob in blocks per tick.
+func (m mobEntity) Speed() float64 {
+	return m.state().speed
+}
+
+// SetSpeed changes the speed of the mob in blocks per tick.
+func (m mobEntity) SetSpeed(v float64) {
+	m.state().speed = v
+}
+
+// tickMob ticks the effects of the mob. If the mob is dead, it is instead left
+// in the world for the duration of its death animation, after which it is
+// closed. tickMob returns false if the mob is dead, in which case the rest of
+// its behaviour should not be ticked.
+func (m mobEntity) tickMob(tx *world.Tx) bool {
+	s := m.state()
+	if m.Dead() {
+		if s.deathTicks++; s.deathTicks >= 20 {
+			_ = m.e.Close()
+		}
+		return false
+	}
+	s.effects.Tick(m.l, tx)
+	return true
+}
+
+// dropLoot drops the items generated from the loot table assigned to the type
+// of the Living entity passed, as registered using loot.RegisterEntityTable.
+// The world.DamageSource that killed the entity is used to evaluate the
+// conditions of the table, such as killed_by_player. No items are dropped if
+// no table is assigned to the type or if the world.GameRuleDoMobLoot game rule
+// is disabled.
+func dropLoot(l Living, src world.DamageSource, tx *world.Tx) {
+	if !tx.World().GameRule(world.GameRuleDoMobLoot) {
+		return
+	}
+	ctx := loot.Context{Tx: tx, Pos: cube.PosFromVec3(l.Position()), Entity: l}
+	var killer world.Entity
+	switch src := src.(type) {
+	case AttackDamageSource:
+		killer = src.Attacker
+		if holder, ok := killer.(interface {
+			HeldItems() (mainHand, offHand item.Stack)
+		}); ok {
+			ctx.Tool, _ = holder.HeldItems()
+		}
+	case ProjectileDamageSource:
+		killer = src.Owner
+	}
+	if killer != nil && killer.H().Type().EncodeEntity() == "minecraft:player" {
+		ctx.Player = killer
+	}
+
+	stacks, _ := loot.GenerateEntityDrops(l.H().Type().EncodeEntity(), ctx)
+	for _, stack := range stacks {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: l.Position()}, stack))
+	}
+}
+
+// dropExperience drops experience orbs worth the amount of experience passed
+// for a Living entity that died at the position passed. If a sculk catalyst
+// is nearby, it absorbs the experience to spread sculk instead. No experience
+// is dropped if the world.GameRuleDoMobLoot game rule is disabled.
+func dropExperience(pos mgl64.Vec3, xp int, tx *world.Tx) {
+	if !tx.World().GameRule(world.GameRuleDoMobLoot) {
+		return
+	}
+	if block.AbsorbDeathExperience(tx, pos, xp) {
+		return
+	}
+	for _, orb := range NewExperienceOrbs(pos, xp) {
+		tx.AddEntity(orb)
+	}
+}
@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewPhantom creates a phantom that circles above the target passed and
+// swoops down to attack it. If target is nil, the phantom targets the nearest
+// player below it.
+func NewPhantom(opts world.EntitySpawnOpts, target world.Entity) *world.EntityHandle {
+	conf := phantomConf
+	if target != nil {
+		conf.Target = target.H()
+	}
+	return opts.New(PhantomType, conf)
+}
+
+var phantomConf = PhantomBehaviourConfig{
+	Health:       20,
+	AttackDamage: 2,
+}
+
+// Phantom is a hostile flying mob that spawns at night above players that
+// have not slept for a while. It implements the Living interface.
+type Phantom struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the PhantomBehaviour of the Phantom.
+func (p *Phantom) behaviour() *PhantomBehaviour {
+	return p.data.Data.(*PhantomBehaviour)
+}
+
+// newPhantom returns a Phantom that wraps the *Ent passed.
+func newPhantom(e *Ent) *Phantom {
+	p := &Phantom{Ent: e}
+	p.mobEntity = mobEntity{e: e, l: p}
+	return p
+}
+
+// kill drops the loot and experience of the phantom. Phantom membranes are
+// only dropped if the phantom was killed by an entity.
+func (p *Phantom) kill(src world.DamageSource) {
+	switch src.(type) {
+	case AttackDamageSource, ProjectileDamageSource:
+		dropLoot(p, src, p.tx)
+	}
+	dropExperience(p.Position(), 5, p.tx)
+}
+
+// PhantomType is a world.EntityType implementation for Phantom.
+var PhantomType phantomType
+
+type phantomType struct{}
+
+func (t phantomType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newPhantom(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (phantomType) EncodeEntity() string { return "minecraft:phantom" }
+func (phantomType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.45, 0, -0.45, 0.45, 0.5, 0.45)
+}
+
+func (phantomType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := phantomConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	b := conf.New()
+	b.anchor = nbtconv.Vec3(m, "Anchor")
+	data.Data = b
+}
+
+func (phantomType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*PhantomBehaviour)
+	return map[string]any{"Health": float32(b.health.Health()), "Anchor": nbtconv.Vec3ToFloat32Slice(b.anchor)}
+}
@@ -0,0 +1,197 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// PhantomBehaviourConfig holds optional parameters for a PhantomBehaviour.
+type PhantomBehaviourConfig struct {
+	// Target is the entity that the phantom circles above and swoops at. If
+	// nil, the phantom targets the nearest player below it.
+	Target *world.EntityHandle
+	// Health is the health that the phantom has when it is created.
+	Health float64
+	// AttackDamage is the damage dealt to the target when the phantom swoops
+	// into it.
+	AttackDamage float64
+}
+
+func (conf PhantomBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a PhantomBehaviour using the parameters in conf.
+func (conf PhantomBehaviourConfig) New() *PhantomBehaviour {
+	return &PhantomBehaviour{
+		conf:     conf,
+		mc:       &MovementComputer{},
+		mob:      newMob(conf.Health, 0.3),
+		target:   conf.Target,
+		angle:    rand.Float64() * math.Pi * 2,
+		radius:   5 + rand.Float64()*10,
+		height:   20 + rand.Float64()*20,
+		cooldown: phantomSwoopCooldown(),
+	}
+}
+
+// PhantomBehaviour implements the behaviour of a Phantom. A phantom circles
+// high above its target and periodically swoops down to attack it, after which
+// it returns to circling. Phantoms burn in sunlight.
+type PhantomBehaviour struct {
+	conf PhantomBehaviourConfig
+	mc   *MovementComputer
+	mob
+
+	target *world.EntityHandle
+	// anchor is the position that the phantom circles around.
+	anchor mgl64.Vec3
+	// angle, radius and height describe the position of the phantom on the
+	// circle around its anchor, and the height of the anchor above the target.
+	angle, radius, height float64
+	// swooping is true while the phantom is diving towards its target.
+	// cooldown is the number of ticks left until the next swoop.
+	swooping  bool
+	swoopTick int
+	cooldown  int
+}
+
+// Tick moves the phantom around its anchor or towards its target and burns
+// it if it is in sunlight.
+func (b *PhantomBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	p := newPhantom(e)
+	if !p.tickMob(tx) {
+		return nil
+	}
+	b.burn(p, tx)
+	if p.Dead() {
+		return nil
+	}
+
+	pos := e.Position()
+	if b.anchor == (mgl64.Vec3{}) {
+		b.anchor = pos
+	}
+	target, ok := b.findTarget(e, tx)
+	var dest mgl64.Vec3
+	if ok && b.swooping {
+		dest = target.Position().Add(mgl64.Vec3{0, 0.5})
+		b.swoopTick++
+
+		box := PhantomType.BBox(e).Translate(pos).Grow(0.2)
+		if box.IntersectsWith(target.H().Type().BBox(target).Translate(target.Position())) {
+			b.attack(p, target)
+		} else if pos[1] < target.Position()[1] || b.swoopTick > 100 {
+			// The phantom missed its target.
+			b.stopSwooping()
+		}
+	} else {
+		if ok {
+			tpos := target.Position()
+			b.anchor = mgl64.Vec3{tpos[0], tpos[1] + b.height, tpos[2]}
+			if b.cooldown--; b.cooldown <= 0 {
+				b.swooping, b.swoopTick = true, 0
+			}
+		}
+		b.angle += b.speed / b.radius
+		dest = b.anchor.Add(mgl64.Vec3{math.Cos(b.angle) * b.radius, math.Sin(b.angle * 2), math.Sin(b.angle) * b.radius})
+	}
+
+	// Steer the phantom gradually towards its destination, so that it flies
+	// in smooth arcs and knock back is not immediately cancelled out.
+	vel := e.Velocity()
+	if dir := dest.Sub(pos); dir.Len() > mgl64.Epsilon {
+		vel = vel.Add(dir.Normalize().Mul(b.speed).Sub(vel).Mul(0.1))
+	}
+	rot := cube.Rotation{
+		mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])),
+		mgl64.RadToDeg(-math.Atan2(vel[1], math.Hypot(vel[0], vel[2]))),
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// findTarget returns the target of the phantom. If the phantom has no valid
+// target, it looks for the nearest player below it once every second.
+func (b *PhantomBehaviour) findTarget(e *Ent, tx *world.Tx) (Living, bool) {
+	pos := e.Position()
+	if b.target != nil {
+		if ent, ok := b.target.Entity(tx); ok {
+			if l, ok := ent.(Living); ok && phantomCanTarget(l) && ent.Position().Sub(pos).Len() <= 64 {
+				return l, true
+			}
+		}
+		b.target, b.swooping = nil, false
+	}
+	if e.Age()%time.Second != 0 {
+		return nil, false
+	}
+	var (
+		nearest Living
+		dist    = 64.0
+	)
+	for ent := range tx.Players() {
+		l, ok := ent.(Living)
+		if !ok || !phantomCanTarget(l) {
+			continue
+		}
+		if d := ent.Position().Sub(pos); d[1] <= 0 && d.Len() <= dist {
+			nearest, dist = l, d.Len()
+		}
+	}
+	if nearest == nil {
+		return nil, false
+	}
+	b.target = nearest.H()
+	return nearest, true
+}
+
+// phantomCanTarget checks if a phantom may target the Living entity passed.
+// Entities in a game mode that does not allow taking damage are never
+// targeted.
+func phantomCanTarget(l Living) bool {
+	if g, ok := l.(interface{ GameMode() world.GameMode }); ok && !g.GameMode().AllowsTakingDamage() {
+		return false
+	}
+	return !l.Dead()
+}
+
+// attack hurts the target of the phantom when it swoops into it and makes the
+// phantom return to circling.
+func (b *PhantomBehaviour) attack(p *Phantom, target Living) {
+	if _, vulnerable := target.Hurt(b.conf.AttackDamage, AttackDamageSource{Attacker: p}); vulnerable {
+		target.KnockBack(p.Position(), 0.4, 0.4)
+	}
+	b.stopSwooping()
+}
+
+// stopSwooping makes the phantom return to circling around its anchor.
+func (b *PhantomBehaviour) stopSwooping() {
+	b.swooping, b.cooldown = false, phantomSwoopCooldown()
+}
+
+// burn sets the phantom on fire if it is exposed to sunlight and hurts it
+// every second while it is burning.
+func (b *PhantomBehaviour) burn(p *Phantom, tx *world.Tx) {
+	pos := cube.PosFromVec3(p.Position())
+	t := tx.World().Time() % world.TimeFull
+	if p.OnFireDuration() <= 0 && (t < world.TimeSleep || t > world.TimeWake) && tx.SkyLight(pos) == 15 && !tx.RainingAt(pos) {
+		p.SetOnFire(time.Second * 8)
+	}
+	if p.OnFireDuration() > 0 && p.OnFireDuration()%time.Second == 0 {
+		p.Hurt(1, block.FireDamageSource{})
+	}
+}
+
+// phantomSwoopCooldown returns a random number of ticks between two swoops of
+// a phantom.
+func phantomSwoopCooldown() int {
+	return 60 + rand.IntN(60)
+}
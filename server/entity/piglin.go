@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewPiglin creates a piglin.
+func NewPiglin(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(PiglinType, piglinConf)
+}
+
+var piglinConf = PiglinBehaviourConfig{
+	Health: 16,
+}
+
+// Piglin is a mob from the Nether that barters with players. A Piglin given a
+// gold ingot, either by a player using it on the Piglin or by picking it up
+// from the ground, admires it for six seconds before throwing back items
+// generated from a loot table. Piglin implements the Living, Collector and
+// Interactable interfaces.
+type Piglin struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the PiglinBehaviour of the Piglin.
+func (p *Piglin) behaviour() *PiglinBehaviour {
+	return p.data.Data.(*PiglinBehaviour)
+}
+
+// newPiglin returns a Piglin that wraps the *Ent passed.
+func newPiglin(e *Ent) *Piglin {
+	p := &Piglin{Ent: e}
+	p.mobEntity = mobEntity{e: e, l: p}
+	return p
+}
+
+// Admiring checks if the Piglin is currently admiring a gold ingot that it
+// was given.
+func (p *Piglin) Admiring() bool {
+	return p.behaviour().admireTicks > 0
+}
+
+// HeldItems returns the gold ingot in the off hand of the Piglin while it is
+// admiring it.
+func (p *Piglin) HeldItems() (mainHand, offHand item.Stack) {
+	return item.Stack{}, p.behaviour().admired
+}
+
+// kill drops the gold ingot that the piglin was admiring, along with its loot
+// and experience.
+func (p *Piglin) kill(src world.DamageSource) {
+	b := p.behaviour()
+	pos := p.Position()
+	if !b.admired.Empty() && p.tx.World().GameRule(world.GameRuleDoMobLoot) {
+		p.tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, b.admired))
+		b.admired, b.admireTicks = item.Stack{}, 0
+	}
+	dropLoot(p, src, p.tx)
+	dropExperience(pos, 5, p.tx)
+}
+
+// Collect picks up a single gold ingot from the stack passed if the Piglin is
+// not already admiring one. The items bartered for it are thrown towards the
+// nearest player.
+func (p *Piglin) Collect(stack item.Stack) (int, bool) {
+	if p.Dead() || !p.behaviour().admire(p, p.tx, stack, nil) {
+		return 0, false
+	}
+	return 1, true
+}
+
+// Interact gives the Piglin the gold ingot held by the user if the Piglin is
+// not already admiring one. The items bartered for it are thrown towards the
+// user.
+func (p *Piglin) Interact(user item.User, tx *world.Tx, ctx *item.UseContext) bool {
+	held, _ := user.HeldItems()
+	if p.Dead() || !p.behaviour().admire(p, tx, held, user.H()) {
+		return false
+	}
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// PiglinType is a world.EntityType implementation for Piglin.
+var PiglinType piglinType
+
+type piglinType struct{}
+
+func (piglinType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newPiglin(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (piglinType) EncodeEntity() string { return "minecraft:piglin" }
+func (piglinType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.95, 0.3)
+}
+
+func (piglinType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := piglinConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	data.Data = conf.New()
+}
+
+func (piglinType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*PiglinBehaviour)
+	return map[string]any{"Health": float32(b.health.Health())}
+}
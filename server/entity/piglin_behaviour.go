@@ -0,0 +1,188 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// piglinAdmireTicks is the number of ticks that a piglin admires a gold
+	// ingot before bartering it.
+	piglinAdmireTicks = 120
+	// piglinBarterLootTable is the loot table used to generate the items that
+	// piglins barter for gold ingots.
+	piglinBarterLootTable = "gameplay/piglin_bartering.json"
+)
+
+// PiglinBehaviourConfig holds optional parameters for a PiglinBehaviour.
+type PiglinBehaviourConfig struct {
+	// Health is the health that the piglin has when it is created.
+	Health float64
+	// BarterLootTable is the path of the loot table that the items bartered
+	// for gold ingots are generated from, relative to the loot_tables folder.
+	// If empty, "gameplay/piglin_bartering.json" is used.
+	BarterLootTable string
+}
+
+func (conf PiglinBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a PiglinBehaviour using the parameters in conf.
+func (conf PiglinBehaviourConfig) New() *PiglinBehaviour {
+	if conf.BarterLootTable == "" {
+		conf.BarterLootTable = piglinBarterLootTable
+	}
+	return &PiglinBehaviour{
+		conf: conf,
+		mc:   &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:  newMob(conf.Health, 0.18),
+	}
+}
+
+// PiglinBehaviour implements the behaviour of a Piglin. Piglins wander around
+// and walk towards gold ingots dropped near them. A piglin that is given a
+// gold ingot stands still while admiring it and then throws the items bartered
+// for it towards the entity that gave it the ingot, or the nearest player.
+type PiglinBehaviour struct {
+	conf PiglinBehaviourConfig
+	mc   *MovementComputer
+	mob
+
+	// admired is the gold ingot that the piglin is admiring for admireTicks
+	// more ticks. barterer is the entity that gave it the ingot, if any.
+	admired     item.Stack
+	admireTicks int
+	barterer    *world.EntityHandle
+	// target is the gold ingot item entity that the piglin walks towards.
+	target *world.EntityHandle
+
+	dest        mgl64.Vec3
+	wanderTicks int
+}
+
+// Tick makes the piglin wander around, walk towards gold ingots and barter
+// the gold ingots it admired.
+func (b *PiglinBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	p := newPiglin(e)
+	if !p.tickMob(tx) {
+		return nil
+	}
+
+	pos, vel := e.Position(), e.Velocity()
+	if b.admireTicks > 0 {
+		if b.admireTicks--; b.admireTicks == 0 {
+			b.barter(p, tx)
+		}
+		vel = mgl64.Vec3{0, vel[1]}
+	} else if b.mc.OnGround() {
+		dest := b.dest
+		if it, ok := b.findGold(p, tx); ok {
+			dest = it.Position()
+		} else if b.wanderTicks--; b.wanderTicks <= 0 {
+			b.wanderTicks = 80 + rand.IntN(120)
+			b.dest = pos.Add(mgl64.Vec3{rand.Float64()*12 - 6, 0, rand.Float64()*12 - 6})
+			dest = b.dest
+		}
+		if dir := (mgl64.Vec3{dest[0] - pos[0], 0, dest[2] - pos[2]}); dir.Len() > 0.5 {
+			vel = dir.Normalize().Mul(b.speed).Add(mgl64.Vec3{0, vel[1]})
+		}
+	}
+	rot := e.Rotation()
+	if math.Hypot(vel[0], vel[2]) > 0.01 {
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])), 0}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// admire makes the piglin start admiring a single item of the stack passed if
+// it is a gold ingot and the piglin is not admiring one yet. The barterer
+// passed, which may be nil, is the entity that the bartered items are thrown
+// towards.
+func (b *PiglinBehaviour) admire(p *Piglin, tx *world.Tx, stack item.Stack, barterer *world.EntityHandle) bool {
+	if _, ok := stack.Item().(item.GoldIngot); !ok || b.admireTicks > 0 {
+		return false
+	}
+	b.admired, b.admireTicks, b.barterer, b.target = stack.Grow(1-stack.Count()), piglinAdmireTicks, barterer, nil
+	for _, v := range tx.Viewers(p.Position()) {
+		v.ViewEntityItems(p)
+	}
+	return true
+}
+
+// barter consumes the gold ingot admired by the piglin and throws the items
+// generated from its barter loot table towards the barterer, or the nearest
+// player if the barterer is not nearby.
+func (b *PiglinBehaviour) barter(p *Piglin, tx *world.Tx) {
+	pos := p.Position()
+	ctx := loot.Context{Tx: tx, Pos: cube.PosFromVec3(pos), Entity: p}
+	to, ok := b.barterer.Entity(tx)
+	if !ok || to.Position().Sub(pos).Len() > 16 {
+		to, ok = nearestPlayer(pos, tx, 16)
+	}
+	if ok && to.H().Type().EncodeEntity() == "minecraft:player" {
+		ctx.Player = to
+	}
+	stacks, _ := loot.GenerateWithContext(b.conf.BarterLootTable, ctx)
+
+	from := pos.Add(mgl64.Vec3{0, 1})
+	vel := mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1}
+	if ok {
+		if dir := to.Position().Sub(from); dir.Len() > mgl64.Epsilon {
+			vel = dir.Normalize().Mul(0.3).Add(mgl64.Vec3{0, 0.1})
+		}
+	}
+	for _, stack := range stacks {
+		tx.AddEntity(NewItemPickupDelay(world.EntitySpawnOpts{Position: from, Velocity: vel}, stack, time.Second))
+	}
+	b.admired, b.barterer = item.Stack{}, nil
+	for _, v := range tx.Viewers(pos) {
+		v.ViewEntityItems(p)
+	}
+}
+
+// findGold returns the gold ingot item entity that the piglin walks towards to
+// pick it up. A new item entity is looked for every second.
+func (b *PiglinBehaviour) findGold(p *Piglin, tx *world.Tx) (world.Entity, bool) {
+	if b.target != nil {
+		if ent, ok := b.target.Entity(tx); ok && ent.Position().Sub(p.Position()).Len() <= 10 {
+			return ent, true
+		}
+		b.target = nil
+	}
+	if p.Age()%time.Second != 0 {
+		return nil, false
+	}
+	pos := p.Position()
+	for ent := range tx.EntitiesWithin(cube.Box(-8, -4, -8, 8, 4, 8).Translate(pos)) {
+		if ent.H().Type() != ItemType {
+			continue
+		}
+		if _, ok := ent.(*Ent).Behaviour().(*ItemBehaviour).Item().Item().(item.GoldIngot); ok {
+			b.target = ent.H()
+			return ent, true
+		}
+	}
+	return nil, false
+}
+
+// nearestPlayer returns the player closest to the position passed within the
+// maximum distance passed.
+func nearestPlayer(pos mgl64.Vec3, tx *world.Tx, maxDist float64) (world.Entity, bool) {
+	var nearest world.Entity
+	for p := range tx.Players() {
+		if dist := p.Position().Sub(pos).Len(); dist <= maxDist {
+			nearest, maxDist = p, dist
+		}
+	}
+	return nearest, nearest != nil
+}
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewPillager creates a pillager. If captain is true, the pillager carries an
+// ominous banner.
+func NewPillager(opts world.EntitySpawnOpts, captain bool) *world.EntityHandle {
+	conf := pillagerConf
+	conf.Captain = captain
+	return opts.New(PillagerType, conf)
+}
+
+var pillagerConf = PillagerBehaviourConfig{
+	Health:        24,
+	ArrowDamage:   3,
+	ShootCooldown: 40,
+}
+
+// Pillager is a hostile illager that attacks players and villagers with a
+// crossbow. Pillagers take part in raids and patrol the world in groups led
+// by a captain. Pillager implements the Raider interface.
+type Pillager struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the PillagerBehaviour of the Pillager.
+func (p *Pillager) behaviour() *PillagerBehaviour {
+	return p.data.Data.(*PillagerBehaviour)
+}
+
+// newPillager returns a Pillager that wraps the *Ent passed.
+func newPillager(e *Ent) *Pillager {
+	p := &Pillager{Ent: e}
+	p.mobEntity = mobEntity{e: e, l: p}
+	return p
+}
+
+// raider returns the raid state of the Pillager.
+func (p *Pillager) raider() *raider {
+	return &p.behaviour().raider
+}
+
+// Raid returns the Raid that the Pillager takes part in, if any.
+func (p *Pillager) Raid() (*Raid, bool) {
+	return p.behaviour().Raid()
+}
+
+// Captain checks if the Pillager is a captain carrying an ominous banner.
+func (p *Pillager) Captain() bool {
+	return p.behaviour().Captain()
+}
+
+// BellRung reveals the Pillager if it takes part in a raid.
+func (p *Pillager) BellRung(cube.Pos, *world.Tx) {
+	p.behaviour().reveal()
+}
+
+// HeldItems returns the crossbow held by the Pillager.
+func (p *Pillager) HeldItems() (mainHand, offHand item.Stack) {
+	return item.NewStack(item.Crossbow{}, 1), item.Stack{}
+}
+
+// afterHurt makes the Pillager target the entity that hurt it.
+func (p *Pillager) afterHurt(_ float64, src world.DamageSource) {
+	p.behaviour().retaliate(src)
+}
+
+// kill handles the death of the pillager as a raider and drops its loot and
+// experience.
+func (p *Pillager) kill(src world.DamageSource) {
+	p.behaviour().die(p, src, p.tx)
+	dropLoot(p, src, p.tx)
+	dropExperience(p.Position(), 5, p.tx)
+}
+
+// PillagerType is a world.EntityType implementation for Pillager.
+var PillagerType pillagerType
+
+type pillagerType struct{}
+
+func (pillagerType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newPillager(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (pillagerType) EncodeEntity() string { return "minecraft:pillager" }
+func (pillagerType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.95, 0.3)
+}
+
+func (pillagerType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := pillagerConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	conf.Captain = nbtconv.Bool(m, "PatrolLeader")
+	data.Data = conf.New()
+}
+
+func (pillagerType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*PillagerBehaviour)
+	return map[string]any{"Health": float32(b.health.Health()), "PatrolLeader": boolByte(b.captain)}
+}
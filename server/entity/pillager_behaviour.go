@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// pillagerShootRange is the distance from its target at which a pillager
+// stops walking towards it and starts shooting.
+const pillagerShootRange = 8.0
+
+// PillagerBehaviourConfig holds optional parameters for a PillagerBehaviour.
+type PillagerBehaviourConfig struct {
+	// Health is the health that the pillager has when it is created.
+	Health float64
+	// ArrowDamage is the base damage of the arrows shot by the pillager.
+	ArrowDamage float64
+	// ShootCooldown is the number of ticks between two arrows shot by the
+	// pillager.
+	ShootCooldown int
+	// Captain specifies if the pillager is a captain carrying an ominous
+	// banner.
+	Captain bool
+}
+
+func (conf PillagerBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a PillagerBehaviour using the parameters in conf.
+func (conf PillagerBehaviourConfig) New() *PillagerBehaviour {
+	return &PillagerBehaviour{
+		raider:        raider{captain: conf.Captain},
+		conf:          conf,
+		mc:            &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:           newMob(conf.Health, 0.2),
+		shootCooldown: conf.ShootCooldown,
+	}
+}
+
+// PillagerBehaviour implements the behaviour of a Pillager. A pillager
+// wanders around, or walks towards the centre of its raid, until it finds a
+// target, after which it approaches the target and shoots arrows at it with
+// its crossbow.
+type PillagerBehaviour struct {
+	raider
+
+	conf PillagerBehaviourConfig
+	mc   *MovementComputer
+	mob
+
+	shootCooldown int
+}
+
+// Tick makes the pillager move around or approach and shoot at its target.
+func (b *PillagerBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	p := newPillager(e)
+	if !p.tickMob(tx) {
+		return nil
+	}
+	b.tickRaider(p, tx)
+
+	pos, vel, rot := e.Position(), e.Velocity(), e.Rotation()
+	target, ok := b.findTarget(p, tx, true)
+	if ok {
+		if b.shootCooldown--; b.shootCooldown <= 0 {
+			b.shootCooldown = b.conf.ShootCooldown
+			b.shoot(p, target, tx)
+		}
+	} else {
+		target = nil
+	}
+	vel, rot = b.move(b.mc, pos, vel, rot, b.speed, pillagerShootRange, target)
+
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// shoot makes the pillager shoot an arrow at its target with its crossbow.
+// The arrow is aimed slightly above the target to make up for gravity.
+func (b *PillagerBehaviour) shoot(p *Pillager, target Living, tx *world.Tx) {
+	pos := p.Position().Add(mgl64.Vec3{0, 1.5})
+	dir := target.Position().Add(mgl64.Vec3{0, target.H().Type().BBox(target).Height() / 3}).Sub(pos)
+	if dir.Len() > raiderRange {
+		return
+	}
+	dir[1] += math.Hypot(dir[0], dir[2]) * 0.2
+	if dir.Len() < mgl64.Epsilon {
+		return
+	}
+	dir = dir.Normalize()
+
+	conf := arrowConf
+	conf.Damage, conf.Owner, conf.DisablePickup = b.conf.ArrowDamage, p.H(), true
+	opts := world.EntitySpawnOpts{
+		Position: pos.Add(dir.Mul(0.5)),
+		Velocity: dir.Mul(1.6).Add(mgl64.Vec3{rand.NormFloat64(), rand.NormFloat64(), rand.NormFloat64()}.Mul(0.01)),
+		Rotation: p.Rotation(),
+	}
+	tx.AddEntity(opts.New(ArrowType, conf))
+	tx.PlaySound(pos, sound.CrossbowShoot{})
+}
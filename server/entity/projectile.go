@@ -80,6 +80,18 @@ type ProjectileBehaviourConfig struct {
 	// CollisionPosition specifies the position that the projectile is stuck
 	// in. If non-empty, the entity will not move.
 	CollisionPosition cube.Pos
+	// MaxAge is the duration after which the projectile is removed if it has
+	// not hit anything. If left as 0, the projectile is never removed because
+	// of its age.
+	MaxAge time.Duration
+}
+
+// ProjectileDeflector represents an entity that deflects projectiles that hit
+// it instead of being hurt by them.
+type ProjectileDeflector interface {
+	world.Entity
+	// DeflectsProjectile checks if the entity deflects the projectile passed.
+	DeflectsProjectile(projectile world.Entity) bool
 }
 
 func (conf ProjectileBehaviourConfig) Apply(data *world.EntityData) {
@@ -109,6 +121,10 @@ type ProjectileBehaviour struct {
 
 	collisionPos cube.Pos
 	collided     bool
+
+	// deflectedBy is the entity that last deflected the projectile. The
+	// projectile is not able to hit this entity again.
+	deflectedBy *world.EntityHandle
 }
 
 // Owner returns the owner of the projectile.
@@ -138,7 +154,7 @@ func (lt *ProjectileBehaviour) Critical() bool {
 // Movement within the tick. Tick handles the movement, collision and hitting
 // of a projectile.
 func (lt *ProjectileBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
-	if lt.close {
+	if lt.close || (lt.conf.MaxAge > 0 && e.Age() > lt.conf.MaxAge) {
 		_ = e.Close()
 		return nil
 	}
@@ -158,6 +174,12 @@ func (lt *ProjectileBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
 	if result == nil {
 		return m
 	}
+	if r, ok := result.(trace.EntityResult); ok {
+		if d, ok := r.Entity().(ProjectileDeflector); ok && d.DeflectsProjectile(e) {
+			lt.deflect(e, d, vel)
+			return m
+		}
+	}
 
 	for i := 0; i < lt.conf.ParticleCount; i++ {
 		tx.AddParticle(result.Position(), lt.conf.Particle)
@@ -176,6 +198,8 @@ func (lt *ProjectileBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
 		if h, ok := tx.Block(bpos).(block.ProjectileHitter); ok {
 			h.ProjectileHit(bpos, tx, e, r.Face())
 		}
+		owner, _ := lt.conf.Owner.Entity(tx)
+		tx.EmitVibration(world.Vibration{Type: world.VibrationProjectileLand, Pos: result.Position(), Source: owner})
 		if lt.conf.SurviveBlockCollision {
 			lt.hitBlockSurviving(e, r, m, tx)
 			return m
@@ -254,6 +278,16 @@ func (lt *ProjectileBehaviour) hitBlockSurviving(e *Ent, r trace.BlockResult, m
 	}
 }
 
+// deflect sends the projectile back in a random direction away from the
+// ProjectileDeflector that it hit, at half of its original speed.
+func (lt *ProjectileBehaviour) deflect(e *Ent, d ProjectileDeflector, vel mgl64.Vec3) {
+	dir := vel.Mul(-1).Add(mgl64.Vec3{rand.Float64() - 0.5, rand.Float64() * 0.5, rand.Float64() - 0.5}.Mul(vel.Len()))
+	if dir.Len() > mgl64.Epsilon {
+		e.data.Vel = dir.Normalize().Mul(vel.Len() / 2)
+	}
+	lt.deflectedBy = d.H()
+}
+
 // hitEntity is called when a projectile hits a Living. It deals damage to the
 // entity and knocks it back. Additionally, it applies any potion effects and
 // fire if applicable.
@@ -330,7 +364,7 @@ func (lt *ProjectileBehaviour) ignores(e *Ent) trace.EntityFilter {
 			for other := range seq {
 				g, ok := other.(interface{ GameMode() world.GameMode })
 				_, living := other.(Living)
-				if (ok && !g.GameMode().HasCollision()) || e.H() == other.H() || !living || (e.data.Age < time.Second/4 && lt.conf.Owner == other.H()) {
+				if (ok && !g.GameMode().HasCollision()) || e.H() == other.H() || other.H() == lt.deflectedBy || !living || (e.data.Age < time.Second/4 && lt.conf.Owner == other.H()) {
 					continue
 				}
 				if !yield(other) {
@@ -0,0 +1,478 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/player/bossbar"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// maxBadOmenLevel is the highest level of the Bad Omen effect and the
+	// highest omen level of a raid.
+	maxBadOmenLevel = 5
+	// raidRadius is the distance from the centre of a raid within which
+	// players are shown the raid boss bar and within which no other raid may
+	// start.
+	raidRadius = 96.0
+	// raiderLeaveDistance is the distance from the centre of a raid at which
+	// a raider leaves the raid.
+	raiderLeaveDistance = 112.0
+	// villageRadius is the distance from a position within which a living
+	// villager must be for the position to be part of a village.
+	villageRadius = 32.0
+	// raidCountdown is the number of ticks between two waves of a raid.
+	raidCountdown = 300
+	// raidEndTicks is the number of ticks that the result of a raid is shown
+	// in the boss bar after the raid ends.
+	raidEndTicks = 600
+	// raidTimeout is the number of ticks after which a raid stops if it has
+	// not ended yet.
+	raidTimeout = 48000
+	// heroOfTheVillageDuration is the duration of the Hero of the Village
+	// effect given to the heroes of a raid won.
+	heroOfTheVillageDuration = time.Minute * 40
+)
+
+// raidStatus is the status of a Raid.
+type raidStatus uint8
+
+const (
+	raidOngoing raidStatus = iota
+	raidVictory
+	raidDefeat
+	raidStopped
+)
+
+// raidWave holds how many raiders of one type spawn in each wave of a raid.
+type raidWave struct {
+	// counts holds the number of raiders spawned in each wave, indexed by the
+	// wave number. The count for the bonus wave is found at the index of the
+	// number of waves of the raid.
+	counts [8]int
+	// bonus returns the maximum number of extra raiders spawned in a wave
+	// depending on the difficulty ID.
+	bonus func(wave, difficulty int, bonusWave bool) int
+	// captain specifies if the raider may be the captain of a wave.
+	captain bool
+	spawn   func(opts world.EntitySpawnOpts, captain bool) *world.EntityHandle
+}
+
+// raidWaves holds the composition of the waves of a raid.
+var raidWaves = []raidWave{
+	{counts: [8]int{0, 4, 3, 3, 4, 4, 4, 2}, bonus: illagerBonusSpawns, captain: true, spawn: NewPillager},
+	{counts: [8]int{0, 0, 2, 0, 1, 4, 2, 5}, bonus: illagerBonusSpawns, captain: true, spawn: NewVindicator},
+	{counts: [8]int{0, 0, 0, 0, 3, 0, 0, 1}, bonus: func(wave, difficulty int, _ bool) int {
+		if difficulty == 1 || wave <= 2 || wave == 4 {
+			return 0
+		}
+		return 1
+	}, spawn: func(opts world.EntitySpawnOpts, _ bool) *world.EntityHandle {
+		return NewWitch(opts)
+	}},
+	{counts: [8]int{0, 0, 0, 1, 0, 1, 0, 2}, bonus: func(_, difficulty int, bonusWave bool) int {
+		if difficulty != 1 && bonusWave {
+			return 1
+		}
+		return 0
+	}, spawn: func(opts world.EntitySpawnOpts, _ bool) *world.EntityHandle {
+		return NewRavager(opts)
+	}},
+}
+
+// illagerBonusSpawns returns the maximum number of extra pillagers or
+// vindicators spawned in a wave.
+func illagerBonusSpawns(_, difficulty int, _ bool) int {
+	switch difficulty {
+	case 1:
+		return rand.IntN(2)
+	case 2:
+		return 1
+	}
+	return 2
+}
+
+// Raid is a series of waves of raiders attacking a village. A raid starts
+// when a player with the Bad Omen effect enters a village. The raid is won
+// once all waves of raiders are defeated, after which the players that killed
+// raiders are given the Hero of the Village effect. The raid is lost if no
+// villagers are left in the village.
+type Raid struct {
+	centre cube.Pos
+	omen   int
+	waves  int
+
+	status     raidStatus
+	wave       int
+	ticks      int64
+	countdown  int
+	statusTick int
+
+	raiders     []*world.EntityHandle
+	totalHealth float64
+
+	heroes map[*world.EntityHandle]struct{}
+	// viewers holds the players currently shown the boss bar of the Raid. It
+	// is nil once the Raid has ended and its boss bar was removed.
+	viewers map[*world.EntityHandle]struct{}
+	bar     bossbar.BossBar
+}
+
+// Centre returns the centre of the village that the Raid takes place in.
+func (r *Raid) Centre() cube.Pos {
+	return r.centre
+}
+
+// OmenLevel returns the level of Bad Omen absorbed by the Raid. The omen
+// level determines the level of Hero of the Village given after a raid is
+// won and whether the raid has a bonus wave.
+func (r *Raid) OmenLevel() int {
+	return r.omen
+}
+
+// Waves returns the number of waves of the Raid that have spawned so far and
+// the total number of waves of the Raid, including the bonus wave.
+func (r *Raid) Waves() (spawned, total int) {
+	return r.wave, r.totalWaves()
+}
+
+// Ongoing checks if the Raid has not yet ended.
+func (r *Raid) Ongoing() bool {
+	return r.status == raidOngoing
+}
+
+// totalWaves returns the total number of waves of the Raid. A raid with an
+// omen level of more than 1 has a bonus wave.
+func (r *Raid) totalWaves() int {
+	if r.omen > 1 {
+		return r.waves + 1
+	}
+	return r.waves
+}
+
+// addHero adds an entity to the heroes of the Raid.
+func (r *Raid) addHero(e world.Entity) {
+	r.heroes[e.H()] = struct{}{}
+}
+
+var (
+	raidMu sync.Mutex
+	// raids holds the raids of every world.
+	raids = map[*world.World][]*Raid{}
+	// raidTicks holds the last tick at which the raids of every world were
+	// ticked.
+	raidTicks = map[*world.World]int64{}
+)
+
+// InVillage checks if the position passed is part of a village. A position is
+// part of a village if a living villager is within 32 blocks of it.
+func InVillage(pos cube.Pos, tx *world.Tx) bool {
+	centre := pos.Vec3Centre()
+	for e := range tx.EntitiesWithin(cube.Box(-villageRadius, -villageRadius, -villageRadius, villageRadius, villageRadius, villageRadius).Translate(centre)) {
+		if v, ok := e.(*Villager); ok && !v.Dead() && v.Position().Sub(centre).Len() <= villageRadius {
+			return true
+		}
+	}
+	return false
+}
+
+// TriggerRaid starts a raid in the village that the Living entity passed is
+// in if it has the Bad Omen effect. The Bad Omen effect is removed and its
+// level is added to the omen level of the raid. If a raid is already ongoing
+// near the entity, the omen level of that raid is increased instead. Raids
+// only start in the overworld on a difficulty other than peaceful.
+func TriggerRaid(l Living, tx *world.Tx) (*Raid, bool) {
+	omen, ok := livingEffect(l, effect.BadOmen)
+	w := tx.World()
+	if !ok || w.Dimension() != world.Overworld || w.Difficulty() == world.DifficultyPeaceful {
+		return nil, false
+	}
+	pos := cube.PosFromVec3(l.Position())
+	if !InVillage(pos, tx) {
+		return nil, false
+	}
+	l.RemoveEffect(effect.BadOmen)
+
+	raidMu.Lock()
+	defer raidMu.Unlock()
+	for _, r := range raids[w] {
+		if r.Ongoing() && r.centre.Vec3Centre().Sub(pos.Vec3Centre()).Len() <= raidRadius {
+			r.omen = min(r.omen+omen.Level(), maxBadOmenLevel)
+			return r, true
+		}
+	}
+	difficulty, _ := world.DifficultyID(w.Difficulty())
+	r := &Raid{
+		centre:    pos,
+		omen:      min(omen.Level(), maxBadOmenLevel),
+		waves:     1 + difficulty*2,
+		countdown: raidCountdown,
+		heroes:    map[*world.EntityHandle]struct{}{},
+		viewers:   map[*world.EntityHandle]struct{}{},
+	}
+	raids[w] = append(raids[w], r)
+	return r, true
+}
+
+// TickRaids ticks all raids of the world of the transaction passed. TickRaids
+// may be called multiple times in the same tick, but only ticks the raids
+// once for every value of current.
+func TickRaids(tx *world.Tx, current int64) {
+	w := tx.World()
+	raidMu.Lock()
+	if last, ok := raidTicks[w]; (ok && last == current) || len(raids[w]) == 0 {
+		raidMu.Unlock()
+		return
+	}
+	raidTicks[w] = current
+	ticking := slices.Clone(raids[w])
+	raidMu.Unlock()
+
+	for _, r := range ticking {
+		r.tick(tx)
+	}
+
+	raidMu.Lock()
+	defer raidMu.Unlock()
+	raids[w] = slices.DeleteFunc(raids[w], func(r *Raid) bool {
+		return r.status != raidOngoing && r.viewers == nil
+	})
+	if len(raids[w]) == 0 {
+		delete(raids, w)
+		delete(raidTicks, w)
+	}
+}
+
+// tick ticks the Raid, spawning new waves of raiders and updating the boss bar
+// shown to players near the raid.
+func (r *Raid) tick(tx *world.Tx) {
+	if r.viewers == nil {
+		return
+	}
+	if r.status != raidOngoing {
+		if r.statusTick++; r.statusTick >= raidEndTicks {
+			r.removeBossBar(tx)
+			return
+		}
+		r.updateBossBar(tx)
+		return
+	}
+	if r.ticks++; r.ticks >= raidTimeout {
+		r.end(tx, raidStopped)
+		return
+	}
+	if r.ticks%20 == 0 && !InVillage(r.centre, tx) {
+		if r.wave > 0 {
+			r.end(tx, raidDefeat)
+		} else {
+			r.end(tx, raidStopped)
+		}
+		return
+	}
+	r.updateRaiders(tx)
+	if len(r.raiders) == 0 {
+		if r.wave >= r.totalWaves() {
+			r.end(tx, raidVictory)
+			return
+		}
+		if r.countdown--; r.countdown <= 0 {
+			r.spawnWave(tx)
+		}
+	}
+	r.updateBossBar(tx)
+}
+
+// updateRaiders removes raiders that died, left the world or moved too far
+// away from the Raid.
+func (r *Raid) updateRaiders(tx *world.Tx) {
+	centre := r.centre.Vec3Centre()
+	r.raiders = slices.DeleteFunc(r.raiders, func(h *world.EntityHandle) bool {
+		ent, ok := h.Entity(tx)
+		if !ok {
+			return true
+		}
+		e, ok := ent.(raiderEntity)
+		if !ok || e.Dead() {
+			return true
+		}
+		if e.Position().Sub(centre).Len() > raiderLeaveDistance {
+			e.raider().raid = nil
+			return true
+		}
+		return false
+	})
+}
+
+// spawnWave spawns the next wave of raiders at a position at the edge of the
+// village and sounds the raid horn for all players near the Raid.
+func (r *Raid) spawnWave(tx *world.Tx) {
+	r.wave++
+	r.countdown = raidCountdown
+	r.totalHealth = 0
+
+	bonusWave := r.wave > r.waves
+	index := min(r.wave, len(raidWaves[0].counts)-1)
+	if bonusWave {
+		index = min(r.waves, len(raidWaves[0].counts)-1)
+	}
+	difficulty, _ := world.DifficultyID(tx.World().Difficulty())
+	pos := r.spawnPosition(tx)
+
+	captain := false
+	for _, wave := range raidWaves {
+		n := wave.counts[index]
+		if bonus := wave.bonus(r.wave, difficulty, bonusWave); bonus > 0 {
+			n += rand.IntN(bonus + 1)
+		}
+		for range n {
+			opts := world.EntitySpawnOpts{
+				Position: pos.Add(mgl64.Vec3{rand.Float64()*4 - 2, 0, rand.Float64()*4 - 2}),
+				Rotation: cube.Rotation{rand.Float64()*360 - 180, 0},
+			}
+			isCaptain := wave.captain && !captain
+			captain = captain || isCaptain
+			e := tx.AddEntity(wave.spawn(opts, isCaptain)).(raiderEntity)
+			e.raider().raid = r
+			r.raiders = append(r.raiders, e.H())
+			r.totalHealth += e.MaxHealth()
+		}
+	}
+	for _, p := range r.nearbyPlayers(tx) {
+		tx.PlaySound(p.Position(), sound.RaidHorn{})
+	}
+}
+
+// spawnPosition finds a position on the surface around 32 blocks away from
+// the centre of the Raid to spawn a wave of raiders at. If no position could
+// be found, the centre of the Raid is returned.
+func (r *Raid) spawnPosition(tx *world.Tx) mgl64.Vec3 {
+	for range 20 {
+		angle := rand.Float64() * math.Pi * 2
+		x, z := r.centre[0]+int(math.Cos(angle)*32), r.centre[2]+int(math.Sin(angle)*32)
+		pos := cube.Pos{x, tx.HighestBlock(x, z) + 1, z}
+		if _, liquid := tx.Liquid(pos.Side(cube.FaceDown)); liquid || pos.OutOfBounds(tx.Range()) {
+			continue
+		}
+		return pos.Vec3Middle()
+	}
+	return r.centre.Vec3Middle()
+}
+
+// nearbyPlayers returns all players within the radius of the Raid.
+func (r *Raid) nearbyPlayers(tx *world.Tx) []world.Entity {
+	var players []world.Entity
+	centre := r.centre.Vec3Centre()
+	for p := range tx.Players() {
+		if p.Position().Sub(centre).Len() <= raidRadius {
+			players = append(players, p)
+		}
+	}
+	return players
+}
+
+// end ends the Raid with the status passed. If the raid was won, all heroes
+// of the raid that are still alive are given the Hero of the Village effect.
+func (r *Raid) end(tx *world.Tx, status raidStatus) {
+	r.status, r.statusTick = status, 0
+	for _, h := range r.raiders {
+		if ent, ok := h.Entity(tx); ok {
+			ent.(raiderEntity).raider().raid = nil
+		}
+	}
+	r.raiders = nil
+	switch status {
+	case raidStopped:
+		r.removeBossBar(tx)
+	case raidVictory:
+		for h := range r.heroes {
+			ent, ok := h.Entity(tx)
+			if !ok {
+				continue
+			}
+			if l, ok := ent.(Living); ok && !l.Dead() {
+				l.AddEffect(effect.New(effect.HeroOfTheVillage, r.omen, heroOfTheVillageDuration))
+			}
+		}
+		fallthrough
+	default:
+		r.updateBossBar(tx)
+	}
+}
+
+// bossBarViewer is a viewer of the boss bar of a Raid, such as a player.
+type bossBarViewer interface {
+	world.Entity
+	SendBossBar(bar bossbar.BossBar)
+	RemoveBossBar()
+}
+
+// updateBossBar shows the boss bar of the Raid to all players within its
+// radius and removes it for players that left it.
+func (r *Raid) updateBossBar(tx *world.Tx) {
+	bar := bossbar.New("Raid").WithColour(bossbar.Red())
+	switch r.status {
+	case raidVictory:
+		bar = bossbar.New("Raid - Victory").WithColour(bossbar.Red())
+	case raidDefeat:
+		bar = bossbar.New("Raid - Defeat").WithColour(bossbar.Red())
+	default:
+		if len(r.raiders) == 0 {
+			bar = bar.WithHealthPercentage(1 - float64(r.countdown)/raidCountdown)
+		} else {
+			bar = bar.WithHealthPercentage(r.raiderHealth(tx) / max(r.totalHealth, 1))
+		}
+	}
+	changed := bar != r.bar
+	r.bar = bar
+
+	nearby := map[*world.EntityHandle]struct{}{}
+	for _, p := range r.nearbyPlayers(tx) {
+		v, ok := p.(bossBarViewer)
+		if !ok {
+			continue
+		}
+		nearby[p.H()] = struct{}{}
+		if _, viewing := r.viewers[p.H()]; !viewing || changed {
+			v.SendBossBar(bar)
+		}
+	}
+	for h := range r.viewers {
+		if _, ok := nearby[h]; ok {
+			continue
+		}
+		if ent, ok := h.Entity(tx); ok {
+			ent.(bossBarViewer).RemoveBossBar()
+		}
+	}
+	r.viewers = nearby
+}
+
+// removeBossBar removes the boss bar of the Raid for all players viewing it.
+func (r *Raid) removeBossBar(tx *world.Tx) {
+	for h := range r.viewers {
+		if ent, ok := h.Entity(tx); ok {
+			ent.(bossBarViewer).RemoveBossBar()
+		}
+	}
+	r.viewers = nil
+}
+
+// raiderHealth returns the total health of all raiders of the current wave
+// that are still part of the Raid.
+func (r *Raid) raiderHealth(tx *world.Tx) float64 {
+	var health float64
+	for _, h := range r.raiders {
+		if ent, ok := h.Entity(tx); ok {
+			health += ent.(Living).Health()
+		}
+	}
+	return health
+}
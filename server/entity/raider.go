@@ -0,0 +1,259 @@
+package entity
+
+import (
+	"image/color"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// raiderRange is the range in blocks within which a raider finds its
+	// target.
+	raiderRange = 16.0
+	// raiderRevealTicks is the number of ticks that a raider stays revealed
+	// after a bell is rung near it.
+	raiderRevealTicks = 60
+	// captainBadOmenDuration is the duration of the Bad Omen effect given to
+	// a player that kills a captain outside a raid.
+	captainBadOmenDuration = time.Minute * 100
+)
+
+// Raider represents a Living entity that may take part in a Raid, such as a
+// pillager or a vindicator.
+type Raider interface {
+	Living
+	// Raid returns the Raid that the Raider takes part in. If the Raider is
+	// not part of an ongoing raid, false is returned.
+	Raid() (*Raid, bool)
+	// Captain checks if the Raider is a captain. Captains carry an ominous
+	// banner and give the player that kills them the Bad Omen effect.
+	Captain() bool
+}
+
+// raiderEntity is a Raider implemented in this package, which holds its raid
+// state in a raider.
+type raiderEntity interface {
+	Raider
+	Age() time.Duration
+	raider() *raider
+}
+
+// OminousBanner returns the ominous banner carried by raid captains. Killing a
+// captain makes it drop this banner.
+func OminousBanner() item.Stack {
+	return item.NewStack(block.Banner{Colour: item.ColourWhite(), Illager: true}, 1)
+}
+
+// raider holds the state shared by all raiders, such as the raid they take
+// part in and the target they are attacking. It is embedded in the behaviour
+// of every raider.
+type raider struct {
+	raid    *Raid
+	captain bool
+
+	target      *world.EntityHandle
+	dest        mgl64.Vec3
+	wanderTicks int
+	// revealTicks is the number of ticks left that the raider is revealed
+	// by a bell.
+	revealTicks int
+}
+
+// Raid returns the Raid that the raider takes part in, if it is still
+// ongoing.
+func (r *raider) Raid() (*Raid, bool) {
+	if r.raid == nil || !r.raid.Ongoing() {
+		return nil, false
+	}
+	return r.raid, true
+}
+
+// Captain checks if the raider is a captain.
+func (r *raider) Captain() bool {
+	return r.captain
+}
+
+// tickRaider leaves the raid of the raider once it is over and shows
+// particles around the raider while it is revealed by a bell.
+func (r *raider) tickRaider(e raiderEntity, tx *world.Tx) {
+	if r.raid != nil && !r.raid.Ongoing() {
+		r.raid = nil
+	}
+	if r.revealTicks > 0 {
+		if r.revealTicks--; r.revealTicks%5 == 0 {
+			tx.AddParticle(e.Position().Add(mgl64.Vec3{0, 2.2}), particle.Dust{Colour: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}})
+		}
+	}
+}
+
+// reveal reveals the raider for a few seconds if it takes part in a raid.
+func (r *raider) reveal() {
+	if _, ok := r.Raid(); ok {
+		r.revealTicks = raiderRevealTicks
+	}
+}
+
+// findTarget returns the target of the raider. If the raider has no valid
+// target, it looks for the nearest player, or villager if villagers is true,
+// within range once every second.
+func (r *raider) findTarget(e raiderEntity, tx *world.Tx, villagers bool) (Living, bool) {
+	pos := e.Position()
+	if r.target != nil {
+		if ent, ok := r.target.Entity(tx); ok {
+			if l, ok := ent.(Living); ok && raiderCanTarget(l) && ent.Position().Sub(pos).Len() <= raiderRange*1.5 {
+				return l, true
+			}
+		}
+		r.target = nil
+	}
+	if e.Age()%time.Second != 0 {
+		return nil, false
+	}
+	var (
+		nearest Living
+		dist    = raiderRange
+	)
+	consider := func(ent world.Entity) {
+		l, ok := ent.(Living)
+		if !ok || !raiderCanTarget(l) {
+			return
+		}
+		if d := ent.Position().Sub(pos).Len(); d <= dist {
+			nearest, dist = l, d
+		}
+	}
+	for ent := range tx.Players() {
+		consider(ent)
+	}
+	if villagers {
+		for ent := range tx.EntitiesWithin(cube.Box(-raiderRange, -raiderRange, -raiderRange, raiderRange, raiderRange, raiderRange).Translate(pos)) {
+			switch ent.(type) {
+			case *Villager, *WanderingTrader:
+				consider(ent)
+			}
+		}
+	}
+	if nearest == nil {
+		return nil, false
+	}
+	r.target = nearest.H()
+	return nearest, true
+}
+
+// raiderCanTarget checks if a raider may target the Living entity passed.
+// Entities in a game mode that does not allow taking damage are never
+// targeted.
+func raiderCanTarget(l Living) bool {
+	if g, ok := l.(interface{ GameMode() world.GameMode }); ok && !g.GameMode().AllowsTakingDamage() {
+		return false
+	}
+	if _, ok := l.(Raider); ok {
+		return false
+	}
+	return !l.Dead()
+}
+
+// retaliate makes the raider target the entity responsible for the damage
+// source passed, if any.
+func (r *raider) retaliate(src world.DamageSource) {
+	if l, ok := damageAttacker(src).(Living); ok && raiderCanTarget(l) {
+		r.target = l.H()
+	}
+}
+
+// damageAttacker returns the entity responsible for the damage source passed,
+// or nil if there is none.
+func damageAttacker(src world.DamageSource) world.Entity {
+	switch s := src.(type) {
+	case AttackDamageSource:
+		return s.Attacker
+	case ProjectileDamageSource:
+		return s.Owner
+	}
+	return nil
+}
+
+// move returns the velocity and rotation of a raider that walks towards its
+// target until it is within stopDist of it. Without a target, the raider
+// walks towards the centre of its raid or wanders around.
+func (r *raider) move(mc *MovementComputer, pos, vel mgl64.Vec3, rot cube.Rotation, speed, stopDist float64, target Living) (mgl64.Vec3, cube.Rotation) {
+	if target != nil {
+		delta := target.Position().Sub(pos)
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-delta[0], delta[2])), 0}
+		if dist := math.Hypot(delta[0], delta[2]); dist > stopDist && mc.OnGround() {
+			vel = mgl64.Vec3{delta[0] / dist * speed, vel[1], delta[2] / dist * speed}
+		}
+		return vel, rot
+	}
+	if !mc.OnGround() {
+		return vel, rot
+	}
+	if raid, ok := r.Raid(); ok && raid.Centre().Vec3Centre().Sub(pos).Len() > 12 {
+		r.dest = raid.Centre().Vec3Centre()
+	} else if r.wanderTicks--; r.wanderTicks <= 0 {
+		r.wanderTicks = 80 + rand.IntN(120)
+		r.dest = pos.Add(mgl64.Vec3{rand.Float64()*12 - 6, 0, rand.Float64()*12 - 6})
+	}
+	if dir := (mgl64.Vec3{r.dest[0] - pos[0], 0, r.dest[2] - pos[2]}); dir.Len() > 1 {
+		vel = dir.Normalize().Mul(speed).Add(mgl64.Vec3{0, vel[1]})
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-dir[0], dir[2])), 0}
+	}
+	return vel, rot
+}
+
+// die handles the death of the raider. A captain drops an ominous banner. If
+// the captain was not part of a raid, the player that killed it is given the
+// Bad Omen effect, or the level of its Bad Omen effect is increased. A player
+// that kills a raider during a raid becomes a hero of the raid.
+func (r *raider) die(e raiderEntity, src world.DamageSource, tx *world.Tx) {
+	killer := damageAttacker(src)
+	_, player := killer.(interface{ GameMode() world.GameMode })
+	raid, inRaid := r.Raid()
+	if inRaid && player {
+		raid.addHero(killer)
+	}
+	if !r.captain {
+		return
+	}
+	if tx.World().GameRule(world.GameRuleDoMobLoot) {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: e.Position().Add(mgl64.Vec3{0, 1})}, OminousBanner()))
+	}
+	l, ok := killer.(Living)
+	if inRaid || !player || !ok {
+		return
+	}
+	lvl := 1
+	if omen, ok := livingEffect(l, effect.BadOmen); ok {
+		lvl = min(omen.Level()+1, maxBadOmenLevel)
+		l.RemoveEffect(effect.BadOmen)
+	}
+	l.AddEffect(effect.New(effect.BadOmen, lvl, captainBadOmenDuration))
+}
+
+// raiderAttack hurts the target of a raider with a melee attack and knocks it
+// back.
+func raiderAttack(e Raider, target Living, dmg, knockBack float64) {
+	if _, vulnerable := target.Hurt(dmg, AttackDamageSource{Attacker: e}); vulnerable {
+		target.KnockBack(e.Position(), knockBack, 0.4)
+	}
+}
+
+// livingEffect returns the effect of the effect.Type passed applied to the
+// Living entity passed, if any.
+func livingEffect(l Living, t effect.Type) (effect.Effect, bool) {
+	for _, e := range l.Effects() {
+		if e.Type() == t {
+			return e, true
+		}
+	}
+	return effect.Effect{}, false
+}
@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewRavager creates a ravager.
+func NewRavager(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(RavagerType, ravagerConf)
+}
+
+var ravagerConf = RavagerBehaviourConfig{
+	Health:       100,
+	AttackDamage: 12,
+	KnockBack:    1.2,
+}
+
+// Ravager is a large hostile beast that rams players and villagers. Ravagers
+// take part in the later waves of raids, but are never the captain of a
+// wave. Ravager implements the Raider interface.
+type Ravager struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the RavagerBehaviour of the Ravager.
+func (r *Ravager) behaviour() *RavagerBehaviour {
+	return r.data.Data.(*RavagerBehaviour)
+}
+
+// newRavager returns a Ravager that wraps the *Ent passed.
+func newRavager(e *Ent) *Ravager {
+	r := &Ravager{Ent: e}
+	r.mobEntity = mobEntity{e: e, l: r}
+	return r
+}
+
+// raider returns the raid state of the Ravager.
+func (r *Ravager) raider() *raider {
+	return &r.behaviour().raider
+}
+
+// Raid returns the Raid that the Ravager takes part in, if any.
+func (r *Ravager) Raid() (*Raid, bool) {
+	return r.behaviour().Raid()
+}
+
+// Captain always returns false, as ravagers are never captains.
+func (r *Ravager) Captain() bool {
+	return false
+}
+
+// BellRung reveals the Ravager if it takes part in a raid.
+func (r *Ravager) BellRung(cube.Pos, *world.Tx) {
+	r.behaviour().reveal()
+}
+
+// afterHurt makes the Ravager target the entity that hurt it.
+func (r *Ravager) afterHurt(_ float64, src world.DamageSource) {
+	r.behaviour().retaliate(src)
+}
+
+// kill handles the death of the ravager as a raider and drops its loot and
+// experience.
+func (r *Ravager) kill(src world.DamageSource) {
+	r.behaviour().die(r, src, r.tx)
+	dropLoot(r, src, r.tx)
+	dropExperience(r.Position(), 20, r.tx)
+}
+
+// KnockBack knocks the Ravager back, away from the source passed. Ravagers
+// resist 75% of the knock back dealt to them.
+func (r *Ravager) KnockBack(src mgl64.Vec3, force, height float64) {
+	r.mobEntity.KnockBack(src, force*0.25, height*0.25)
+}
+
+// RavagerType is a world.EntityType implementation for Ravager.
+var RavagerType ravagerType
+
+type ravagerType struct{}
+
+func (ravagerType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newRavager(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (ravagerType) EncodeEntity() string { return "minecraft:ravager" }
+func (ravagerType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.975, 0, -0.975, 0.975, 2.2, 0.975)
+}
+
+func (ravagerType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := ravagerConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	data.Data = conf.New()
+}
+
+func (ravagerType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*RavagerBehaviour)
+	return map[string]any{"Health": float32(b.health.Health())}
+}
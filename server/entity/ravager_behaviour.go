@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// RavagerBehaviourConfig holds optional parameters for a
+// RavagerBehaviour.
+type RavagerBehaviourConfig struct {
+	// Health is the health that the ravager has when it is created.
+	Health float64
+	// AttackDamage is the damage dealt by the ravager when it rams its
+	// target.
+	AttackDamage float64
+	// KnockBack is the force with which the ravager knocks back its target.
+	KnockBack float64
+}
+
+func (conf RavagerBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a RavagerBehaviour using the parameters in conf.
+func (conf RavagerBehaviourConfig) New() *RavagerBehaviour {
+	return &RavagerBehaviour{
+		conf: conf,
+		mc:   &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:  newMob(conf.Health, 0.18),
+	}
+}
+
+// RavagerBehaviour implements the behaviour of a Ravager. A ravager wanders
+// around, or walks towards the centre of its raid, until it finds a target,
+// after which it charges at the target and rams it, knocking it back.
+type RavagerBehaviour struct {
+	raider
+
+	conf RavagerBehaviourConfig
+	mc   *MovementComputer
+	mob
+
+	attackCooldown int
+}
+
+// Tick makes the ravager move around or charge at and ram its target.
+func (b *RavagerBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	r := newRavager(e)
+	if !r.tickMob(tx) {
+		return nil
+	}
+	b.tickRaider(r, tx)
+	if b.attackCooldown > 0 {
+		b.attackCooldown--
+	}
+
+	pos, vel, rot := e.Position(), e.Velocity(), e.Rotation()
+	target, ok := b.findTarget(r, tx, true)
+	if ok {
+		if b.attackCooldown == 0 && target.Position().Sub(pos).Len() <= 3 {
+			b.attackCooldown = 20
+			raiderAttack(r, target, b.conf.AttackDamage, b.conf.KnockBack)
+		}
+	} else {
+		target = nil
+	}
+	vel, rot = b.move(b.mc, pos, vel, rot, b.speed, 2, target)
+
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
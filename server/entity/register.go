@@ -10,21 +10,44 @@ import (
 // DefaultRegistry is a world.EntityRegistry that registers all default entities
 // implemented by Dragonfly.
 var DefaultRegistry = conf.New([]world.EntityType{
+	AllayType,
 	AreaEffectCloudType,
+	ArmadilloType,
 	ArrowType,
+	AxolotlType,
+	BoggedType,
 	BottleOfEnchantingType,
+	BreezeType,
+	BreezeWindChargeType,
+	CamelType,
 	EggType,
 	EnderPearlType,
 	ExperienceOrbType,
 	FallingBlockType,
 	FireworkType,
+	FishingHookType,
+	FrogType,
+	GlowSquidType,
+	GoatType,
 	ItemType,
+	LeashKnotType,
 	LightningType,
 	LingeringPotionType,
+	PhantomType,
+	PiglinType,
+	PillagerType,
+	RavagerType,
+	SnifferType,
 	SnowballType,
 	SplashPotionType,
 	TNTType,
+	TadpoleType,
 	TextType,
+	TraderLlamaType,
+	VillagerType,
+	VindicatorType,
+	WanderingTraderType,
+	WitchType,
 })
 
 var conf = world.EntityRegistryConfig{
@@ -35,9 +58,17 @@ var conf = world.EntityRegistryConfig{
 	EnderPearl:         NewEnderPearl,
 	FallingBlock:       NewFallingBlock,
 	Lightning:          NewLightning,
+	LeashKnot:          NewLeashKnot,
+	Tadpole:            NewTadpole,
+	Sniffer:            NewBabySniffer,
+	WanderingTrader:    NewWanderingTrader,
+	TraderLlama:        NewTraderLlama,
 	Firework: func(opts world.EntitySpawnOpts, firework world.Item, owner world.Entity, sidewaysVelocityMultiplier, upwardsAcceleration float64, attached bool) *world.EntityHandle {
 		return newFirework(opts, firework.(item.Firework), owner, sidewaysVelocityMultiplier, upwardsAcceleration, attached)
 	},
+	FishingHook: func(opts world.EntitySpawnOpts, owner world.Entity, rod any) *world.EntityHandle {
+		return NewFishingHook(opts, owner, rod.(item.Stack))
+	},
 	Item: func(opts world.EntitySpawnOpts, it any) *world.EntityHandle {
 		return NewItem(opts, it.(item.Stack))
 	},
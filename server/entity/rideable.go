@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Rideable represents an entity that may be ridden by one or more Riders, such
+// as a camel. The first seat of a Rideable is the seat of the driver.
+type Rideable interface {
+	world.Entity
+	// SeatPosition returns the position in the world of the seat passed.
+	SeatPosition(seat int) mgl64.Vec3
+	// Riders returns the entity handles of the entities riding the Rideable,
+	// indexed by seat. Empty seats are nil.
+	Riders() []*world.EntityHandle
+	// AddRider seats the Rider passed on the Rideable and returns the seat it
+	// was given. If there were no free seats, false is returned.
+	AddRider(r Rider) (int, bool)
+	// RemoveRider removes the Rider passed from its seat on the Rideable.
+	RemoveRider(r Rider)
+}
+
+// Drivable represents a Rideable whose movement is controlled by the Rider in
+// its first seat.
+type Drivable interface {
+	Rideable
+	// Drive moves the Drivable to the position passed and turns it to the yaw
+	// passed. Drive is called when the driver passed moves.
+	Drive(driver Rider, pos mgl64.Vec3, yaw float64)
+}
+
+// RiderShield represents a Rideable that is tall enough to keep its riders out
+// of reach of some attackers.
+type RiderShield interface {
+	Rideable
+	// ShieldsRiders checks if the riders of the Rideable are out of reach of
+	// melee attacks by the attacker passed.
+	ShieldsRiders(attacker world.Entity) bool
+}
+
+// Rider represents an entity that is able to ride a Rideable.
+type Rider interface {
+	world.Entity
+	// Mount makes the Rider start riding the Rideable passed. If the Rider
+	// could not be seated, false is returned.
+	Mount(r Rideable) bool
+	// Dismount makes the Rider stop riding the Rideable it is currently
+	// riding, if any.
+	Dismount()
+	// Riding returns the entity handle of the Rideable that the Rider is
+	// currently riding and whether it is riding at all.
+	Riding() (*world.EntityHandle, bool)
+}
@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewSniffer creates an adult sniffer.
+func NewSniffer(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(SnifferType, snifferConf)
+}
+
+// NewBabySniffer creates a baby sniffer, also known as a snifflet, which grows
+// up into an adult after 40 minutes.
+func NewBabySniffer(opts world.EntitySpawnOpts) *world.EntityHandle {
+	conf := snifferConf
+	conf.Baby = true
+	return opts.New(SnifferType, conf)
+}
+
+var snifferConf = SnifferBehaviourConfig{
+	Health: 14,
+}
+
+// Sniffer is a passive mob that hatches from sniffer eggs. Adult sniffers
+// periodically sniff the air and dig up ancient seeds from dirt-like blocks.
+// Sniffers fed torchflower seeds breed, after which one of them lays a sniffer
+// egg. Sniffer implements the Living and Interactable interfaces.
+type Sniffer struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the SnifferBehaviour of the Sniffer.
+func (s *Sniffer) behaviour() *SnifferBehaviour {
+	return s.data.Data.(*SnifferBehaviour)
+}
+
+// newSniffer returns a Sniffer that wraps the *Ent passed.
+func newSniffer(e *Ent) *Sniffer {
+	s := &Sniffer{Ent: e}
+	s.mobEntity = mobEntity{e: e, l: s}
+	return s
+}
+
+// Baby checks if the Sniffer is a snifflet.
+func (s *Sniffer) Baby() bool {
+	return s.behaviour().age < 0
+}
+
+// Sniffing checks if the Sniffer is currently sniffing the air.
+func (s *Sniffer) Sniffing() bool {
+	return s.behaviour().state == snifferSniffing
+}
+
+// Searching checks if the Sniffer is currently searching for a block to dig
+// in.
+func (s *Sniffer) Searching() bool {
+	return s.behaviour().state == snifferSearching
+}
+
+// Digging checks if the Sniffer is currently digging.
+func (s *Sniffer) Digging() bool {
+	return s.behaviour().state == snifferDigging
+}
+
+// InLove checks if the Sniffer was fed torchflower seeds and is looking for
+// another sniffer to breed with.
+func (s *Sniffer) InLove() bool {
+	return s.behaviour().loveTicks > 0
+}
+
+// afterHurt makes the Sniffer stop digging.
+func (s *Sniffer) afterHurt(float64, world.DamageSource) {
+	s.behaviour().setState(s, snifferIdle, s.tx)
+}
+
+// kill drops the loot and experience of the sniffer.
+func (s *Sniffer) kill(src world.DamageSource) {
+	dropLoot(s, src, s.tx)
+	dropExperience(s.Position(), 1+rand.IntN(3), s.tx)
+}
+
+// Interact feeds the Sniffer torchflower seeds if the user is holding them,
+// making adults ready to breed and snifflets grow up faster.
+func (s *Sniffer) Interact(user item.User, tx *world.Tx, ctx *item.UseContext) bool {
+	held, _ := user.HeldItems()
+	if _, ok := held.Item().(item.TorchflowerSeeds); !ok || s.Dead() {
+		return false
+	}
+	return s.behaviour().feed(s, tx, ctx)
+}
+
+// SnifferType is a world.EntityType implementation for Sniffer.
+var SnifferType snifferType
+
+type snifferType struct{}
+
+func (snifferType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newSniffer(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (snifferType) EncodeEntity() string { return "minecraft:sniffer" }
+func (snifferType) BBox(e world.Entity) cube.BBox {
+	if s, ok := e.(*Sniffer); ok && s.Baby() {
+		return cube.Box(-0.475, 0, -0.475, 0.475, 0.875, 0.475)
+	}
+	return cube.Box(-0.95, 0, -0.95, 0.95, 1.75, 0.95)
+}
+
+func (snifferType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := snifferConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	b := conf.New()
+	b.age = int(nbtconv.Int32(m, "Age"))
+	b.digCooldown = int(nbtconv.Int32(m, "DigCooldown"))
+	b.loveTicks = int(nbtconv.Int32(m, "InLove"))
+	b.breedCooldown = int(nbtconv.Int32(m, "BreedCooldown"))
+	data.Data = b
+}
+
+func (snifferType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*SnifferBehaviour)
+	return map[string]any{
+		"Health":        float32(b.health.Health()),
+		"Age":           int32(b.age),
+		"DigCooldown":   int32(b.digCooldown),
+		"InLove":        int32(b.loveTicks),
+		"BreedCooldown": int32(b.breedCooldown),
+	}
+}
@@ -0,0 +1,336 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// snifferDigCooldown is the number of ticks after digging during which a
+	// sniffer does not dig again.
+	snifferDigCooldown = 9600
+	// snifferDigTicks is the number of ticks that it takes for a sniffer to
+	// dig up an item.
+	snifferDigTicks = 120
+	// snifferGrowUpTicks is the number of ticks that it takes for a snifflet
+	// to grow up.
+	snifferGrowUpTicks = 48000
+	// snifferLoveTicks is the number of ticks that a sniffer looks for another
+	// sniffer to breed with after being fed torchflower seeds.
+	snifferLoveTicks = 600
+	// snifferBreedCooldown is the number of ticks after breeding during which
+	// a sniffer cannot be fed torchflower seeds to breed.
+	snifferBreedCooldown = 6000
+	// snifferLootTable is the loot table used for items dug up by sniffers.
+	snifferLootTable = "gameplay/entities/sniffer_seeds.json"
+)
+
+// snifferState is the state that a sniffer is in while looking for seeds.
+type snifferState uint8
+
+const (
+	snifferIdle snifferState = iota
+	snifferSniffing
+	snifferSearching
+	snifferDigging
+)
+
+// SnifferBehaviourConfig holds optional parameters for a SnifferBehaviour.
+type SnifferBehaviourConfig struct {
+	// Health is the health that the sniffer has when it is created.
+	Health float64
+	// Baby specifies if the sniffer is created as a snifflet.
+	Baby bool
+}
+
+func (conf SnifferBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a SnifferBehaviour using the parameters in conf.
+func (conf SnifferBehaviourConfig) New() *SnifferBehaviour {
+	b := &SnifferBehaviour{
+		mc:  &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob: newMob(conf.Health, 0.1),
+	}
+	if conf.Baby {
+		b.age = -snifferGrowUpTicks
+	}
+	return b
+}
+
+// SnifferBehaviour implements the behaviour of a Sniffer. Sniffers slowly
+// wander around. Every now and then, an adult sniffer sniffs the air, searches
+// for a dirt-like block nearby that it has not dug in recently and digs in it,
+// producing an item from the sniffer digging loot table.
+type SnifferBehaviour struct {
+	mc *MovementComputer
+	mob
+
+	// state is the state of the sniffer in looking for seeds. stateTicks is
+	// the number of ticks left in the current state.
+	state       snifferState
+	stateTicks  int
+	digCooldown int
+	// target is the block that the sniffer is searching for or digging in.
+	// explored holds the blocks that the sniffer dug in most recently.
+	target   cube.Pos
+	explored []cube.Pos
+
+	dest        mgl64.Vec3
+	wanderTicks int
+
+	// age is negative while the sniffer is a snifflet and counts up to zero,
+	// at which point the sniffer grows up.
+	age           int
+	loveTicks     int
+	breedCooldown int
+}
+
+// Tick makes the sniffer wander around and dig for seeds.
+func (b *SnifferBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	s := newSniffer(e)
+	if !s.tickMob(tx) {
+		return nil
+	}
+	if b.loveTicks > 0 {
+		b.loveTicks--
+	}
+	if b.breedCooldown > 0 {
+		b.breedCooldown--
+	}
+	if b.digCooldown > 0 {
+		b.digCooldown--
+	}
+	if b.age < 0 {
+		if b.age++; b.age == 0 {
+			b.updateState(s, tx)
+		}
+	}
+
+	pos, vel := e.Position(), e.Velocity()
+	if dest, ok := b.destination(s, tx); ok && b.mc.OnGround() {
+		if dir := (mgl64.Vec3{dest[0] - pos[0], 0, dest[2] - pos[2]}); dir.Len() > mgl64.Epsilon {
+			vel = dir.Normalize().Mul(b.speed).Add(mgl64.Vec3{0, vel[1]})
+		}
+	}
+	rot := e.Rotation()
+	if math.Hypot(vel[0], vel[2]) > 0.01 {
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])), 0}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// destination returns the position that the sniffer should move to,
+// progressing its search for seeds. If the sniffer should stand still, false
+// is returned.
+func (b *SnifferBehaviour) destination(s *Sniffer, tx *world.Tx) (mgl64.Vec3, bool) {
+	pos := s.Position()
+	if b.state != snifferIdle {
+		b.stateTicks--
+	}
+	switch b.state {
+	case snifferSniffing:
+		if b.stateTicks <= 0 {
+			if target, ok := b.findDigSpot(s, tx); ok {
+				b.target = target
+				b.setState(s, snifferSearching, tx)
+			} else {
+				// Try again a little later.
+				b.digCooldown = 200
+				b.setState(s, snifferIdle, tx)
+			}
+		}
+		return mgl64.Vec3{}, false
+	case snifferSearching:
+		dest := b.target.Vec3Centre().Add(mgl64.Vec3{0, 0.5})
+		if math.Hypot(dest[0]-pos[0], dest[2]-pos[2]) < 1 {
+			b.setState(s, snifferDigging, tx)
+			return mgl64.Vec3{}, false
+		}
+		if b.stateTicks <= 0 {
+			b.setState(s, snifferIdle, tx)
+		}
+		return dest, true
+	case snifferDigging:
+		if b.stateTicks <= 0 {
+			b.dig(s, tx)
+		}
+		return mgl64.Vec3{}, false
+	}
+
+	if b.loveTicks > 0 {
+		if mate, ok := b.findMate(s, tx); ok {
+			if mate.Position().Sub(pos).Len() < 2.5 {
+				b.breed(s, mate, tx)
+			}
+			return mate.Position(), true
+		}
+	}
+	if b.age >= 0 && b.digCooldown == 0 && b.mc.OnGround() && rand.IntN(200) == 0 {
+		b.setState(s, snifferSniffing, tx)
+		return mgl64.Vec3{}, false
+	}
+	if b.wanderTicks--; b.wanderTicks <= 0 {
+		b.wanderTicks = 100 + rand.IntN(200)
+		b.dest = pos.Add(mgl64.Vec3{rand.Float64()*16 - 8, 0, rand.Float64()*16 - 8})
+	}
+	return b.dest, b.dest.Sub(pos).Len() > 1
+}
+
+// setState changes the state of the sniffer and sends it to viewers.
+func (b *SnifferBehaviour) setState(s *Sniffer, state snifferState, tx *world.Tx) {
+	if b.state == state {
+		return
+	}
+	b.state = state
+	switch state {
+	case snifferSniffing:
+		b.stateTicks = 40
+	case snifferSearching:
+		// Give up searching if the block could not be reached in time.
+		b.stateTicks = 400
+	case snifferDigging:
+		b.stateTicks = snifferDigTicks
+	}
+	b.updateState(s, tx)
+}
+
+// findDigSpot finds a random block within 8 blocks of the sniffer that it may
+// dig in and has not explored recently.
+func (b *SnifferBehaviour) findDigSpot(s *Sniffer, tx *world.Tx) (cube.Pos, bool) {
+	pos := cube.PosFromVec3(s.Position())
+	var spots []cube.Pos
+	for x := -8; x <= 8; x++ {
+		for z := -8; z <= 8; z++ {
+			for y := -2; y <= 1; y++ {
+				if p := pos.Add(cube.Pos{x, y, z}); b.canDig(p, tx) {
+					spots = append(spots, p)
+				}
+			}
+		}
+	}
+	if len(spots) == 0 {
+		return cube.Pos{}, false
+	}
+	return spots[rand.IntN(len(spots))], true
+}
+
+// canDig checks if the sniffer is able to dig in the block at the position
+// passed. Only dirt-like blocks with air above them that the sniffer has not
+// explored recently may be dug in.
+func (b *SnifferBehaviour) canDig(pos cube.Pos, tx *world.Tx) bool {
+	for _, p := range b.explored {
+		if p == pos {
+			return false
+		}
+	}
+	if _, air := tx.Block(pos.Side(cube.FaceUp)).(block.Air); !air {
+		return false
+	}
+	switch tx.Block(pos).(type) {
+	case block.Dirt, block.Grass, block.Podzol, block.RootedDirt, block.MossBlock, block.Mud, block.MuddyMangroveRoots:
+		return true
+	}
+	return false
+}
+
+// dig finishes digging in the target block of the sniffer, dropping an item
+// from the sniffer digging loot table on top of it.
+func (b *SnifferBehaviour) dig(s *Sniffer, tx *world.Tx) {
+	b.setState(s, snifferIdle, tx)
+	if !b.canDig(b.target, tx) {
+		return
+	}
+	if b.explored = append(b.explored, b.target); len(b.explored) > 20 {
+		b.explored = b.explored[1:]
+	}
+	b.digCooldown = snifferDigCooldown
+
+	stacks, _ := loot.Generate(snifferLootTable)
+	for _, stack := range stacks {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: b.target.Vec3Centre().Add(mgl64.Vec3{0, 0.6})}, stack))
+	}
+}
+
+// feed feeds the sniffer torchflower seeds. Snifflets grow up faster, while
+// adult sniffers start looking for a mate.
+func (b *SnifferBehaviour) feed(s *Sniffer, tx *world.Tx, ctx *item.UseContext) bool {
+	if b.age < 0 {
+		// Feeding a snifflet makes it grow up 10% faster.
+		b.age = min(b.age+snifferGrowUpTicks/10, 0)
+		if b.age == 0 {
+			b.updateState(s, tx)
+		}
+		ctx.SubtractFromCount(1)
+		return true
+	}
+	if b.loveTicks > 0 || b.breedCooldown > 0 {
+		return false
+	}
+	b.loveTicks = snifferLoveTicks
+	b.setState(s, snifferIdle, tx)
+	ctx.SubtractFromCount(1)
+	for _, v := range tx.Viewers(s.Position()) {
+		v.ViewEntityAction(s, LoveAction{})
+	}
+	return true
+}
+
+// findMate returns the nearest adult sniffer within 8 blocks that is also in
+// love.
+func (b *SnifferBehaviour) findMate(s *Sniffer, tx *world.Tx) (*Sniffer, bool) {
+	pos := s.Position()
+	var (
+		nearest *Sniffer
+		dist    = 8.0
+	)
+	for ent := range tx.EntitiesWithin(cube.Box(-8, -4, -8, 8, 4, 8).Translate(pos)) {
+		other, ok := ent.(*Sniffer)
+		if !ok || other.H() == s.H() || !other.InLove() || other.Baby() || other.Dead() {
+			continue
+		}
+		if d := ent.Position().Sub(pos).Len(); d <= dist {
+			nearest, dist = other, d
+		}
+	}
+	return nearest, nearest != nil
+}
+
+// breed makes the sniffer breed with the mate passed. The sniffer lays a
+// sniffer egg where it stands, or drops one if the egg cannot be placed there.
+// Both sniffers cannot breed again for five minutes.
+func (b *SnifferBehaviour) breed(s, mate *Sniffer, tx *world.Tx) {
+	other := mate.behaviour()
+	b.loveTicks, other.loveTicks = 0, 0
+	b.breedCooldown, other.breedCooldown = snifferBreedCooldown, snifferBreedCooldown
+
+	pos := cube.PosFromVec3(s.Position())
+	if _, air := tx.Block(pos).(block.Air); air {
+		tx.SetBlock(pos, block.SnifferEgg{}, nil)
+	} else {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: s.Position()}, item.NewStack(block.SnifferEgg{}, 1)))
+	}
+	if tx.World().GameRule(world.GameRuleDoMobLoot) {
+		for _, orb := range NewExperienceOrbs(s.Position(), 1+rand.IntN(7)) {
+			tx.AddEntity(orb)
+		}
+	}
+}
+
+// updateState sends the state of the sniffer to its viewers, updating whether
+// it is sniffing, searching or digging.
+func (b *SnifferBehaviour) updateState(s *Sniffer, tx *world.Tx) {
+	for _, v := range tx.Viewers(s.Position()) {
+		v.ViewEntityState(s)
+	}
+}
@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewTadpole creates a tadpole that has just hatched.
+func NewTadpole(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(TadpoleType, tadpoleConf)
+}
+
+var tadpoleConf = TadpoleBehaviourConfig{
+	Health: 6,
+}
+
+// Tadpole is a passive aquatic mob that hatches from frogspawn. After twenty
+// minutes, a tadpole grows up into a Frog of which the variant depends on the
+// biome that the tadpole is in. Tadpoles suffocate when out of water for too
+// long. Tadpole implements the Living, Interactable and AxolotlPrey
+// interfaces.
+type Tadpole struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the TadpoleBehaviour of the Tadpole.
+func (t *Tadpole) behaviour() *TadpoleBehaviour {
+	return t.data.Data.(*TadpoleBehaviour)
+}
+
+// newTadpole returns a Tadpole that wraps the *Ent passed.
+func newTadpole(e *Ent) *Tadpole {
+	t := &Tadpole{Ent: e}
+	t.mobEntity = mobEntity{e: e, l: t}
+	return t
+}
+
+// kill does nothing: tadpoles drop neither loot nor experience.
+func (t *Tadpole) kill(world.DamageSource) {}
+
+// GrowthProgress returns the progress of the Tadpole growing up into a frog,
+// ranging from 0 to 1.
+func (t *Tadpole) GrowthProgress() float64 {
+	return float64(t.behaviour().age) / tadpoleGrowTicks
+}
+
+// Interact makes the Tadpole grow up faster if the user feeds it a slime
+// ball.
+func (t *Tadpole) Interact(user item.User, tx *world.Tx, ctx *item.UseContext) bool {
+	held, _ := user.HeldItems()
+	if _, ok := held.Item().(item.Slimeball); !ok || t.Dead() {
+		return false
+	}
+	t.behaviour().age += tadpoleGrowTicks / 10
+	ctx.SubtractFromCount(1)
+	for _, v := range tx.Viewers(t.Position()) {
+		v.ViewEntityAction(t, LoveAction{})
+	}
+	return true
+}
+
+// AxolotlHostile returns false: Axolotls only hunt tadpoles if they have not
+// recently killed any prey.
+func (t *Tadpole) AxolotlHostile() bool {
+	return false
+}
+
+// TadpoleType is a world.EntityType implementation for Tadpole.
+var TadpoleType tadpoleType
+
+type tadpoleType struct{}
+
+func (tadpoleType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newTadpole(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (tadpoleType) EncodeEntity() string { return "minecraft:tadpole" }
+func (tadpoleType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.2, 0, -0.2, 0.2, 0.3, 0.2)
+}
+
+func (tadpoleType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := tadpoleConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	b := conf.New()
+	b.age = int(nbtconv.Int32(m, "Age"))
+	data.Data = b
+}
+
+func (tadpoleType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*TadpoleBehaviour)
+	return map[string]any{"Health": float32(b.health.Health()), "Age": int32(b.age)}
+}
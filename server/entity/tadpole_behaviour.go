@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// tadpoleGrowTicks is the number of ticks that it takes for a tadpole to
+	// grow up into a frog.
+	tadpoleGrowTicks = 24000
+	// tadpoleAirTicks is the number of ticks that a tadpole can survive out
+	// of water before it starts suffocating.
+	tadpoleAirTicks = 300
+)
+
+// TadpoleBehaviourConfig holds optional parameters for a TadpoleBehaviour.
+type TadpoleBehaviourConfig struct {
+	// Health is the health that the tadpole has when it is created.
+	Health float64
+}
+
+func (conf TadpoleBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a TadpoleBehaviour using the parameters in conf.
+func (conf TadpoleBehaviourConfig) New() *TadpoleBehaviour {
+	return &TadpoleBehaviour{
+		mc:  &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob: newMob(conf.Health, 0.1),
+	}
+}
+
+// TadpoleBehaviour implements the behaviour of a Tadpole. Tadpoles swim around
+// randomly in water and flop around on land, where they eventually suffocate.
+// Once a tadpole is old enough, it is replaced by a Frog.
+type TadpoleBehaviour struct {
+	mc *MovementComputer
+	mob
+
+	// age is the number of ticks that the tadpole has been alive for.
+	age int
+	// airTicks is the number of ticks that the tadpole has been out of water.
+	airTicks int
+
+	dest        mgl64.Vec3
+	wanderTicks int
+}
+
+// Tick makes the tadpole swim around, suffocate when out of water and grow up
+// into a frog once it is old enough.
+func (b *TadpoleBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	t := newTadpole(e)
+	if !t.tickMob(tx) {
+		return nil
+	}
+
+	pos, vel := e.Position(), e.Velocity()
+	if b.age++; b.age >= tadpoleGrowTicks {
+		b.growUp(t, tx)
+		return nil
+	}
+
+	swimming := inWater(pos, tx)
+	if swimming {
+		b.airTicks, b.mc.Gravity = 0, 0.005
+		if b.wanderTicks--; b.wanderTicks <= 0 || b.dest.Sub(pos).Len() < 0.5 {
+			b.wanderTicks = 40 + rand.IntN(60)
+			b.dest = pos.Add(mgl64.Vec3{rand.Float64()*8 - 4, rand.Float64()*4 - 2, rand.Float64()*8 - 4})
+		}
+		if !inWater(b.dest, tx) {
+			// Don't swim out of the water.
+			b.dest = pos
+		}
+		if dir := b.dest.Sub(pos); dir.Len() > mgl64.Epsilon {
+			vel = vel.Add(dir.Normalize().Mul(b.speed).Sub(vel).Mul(0.1))
+		}
+	} else {
+		b.mc.Gravity = 0.08
+		if b.airTicks++; b.airTicks > tadpoleAirTicks && b.airTicks%20 == 0 {
+			t.Hurt(2, DrowningDamageSource{})
+		}
+		if b.mc.OnGround() && rand.IntN(20) == 0 {
+			// Tadpoles flop around randomly when on land.
+			vel = mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.3, rand.Float64()*0.2 - 0.1}
+		}
+	}
+	rot := e.Rotation()
+	if math.Hypot(vel[0], vel[2]) > 0.01 {
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])), 0}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// growUp replaces the tadpole with a frog of which the variant depends on the
+// biome that the tadpole is in. The custom name of the tadpole is kept.
+func (b *TadpoleBehaviour) growUp(t *Tadpole, tx *world.Tx) {
+	pos := t.Position()
+	opts := world.EntitySpawnOpts{Position: pos, Rotation: t.Rotation(), NameTag: t.NameTag()}
+	tx.AddEntity(NewFrog(opts, frogVariantAt(cube.PosFromVec3(pos), tx)))
+	_ = t.Close()
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/trade"
+)
+
+// Trader represents an entity that customers may trade with, such as a
+// Villager.
+type Trader interface {
+	world.Entity
+	// TraderName returns the name displayed at the top of the trading window
+	// of the Trader. It may be a translation key.
+	TraderName() string
+	// TradeTier returns the current tier of the Trader, starting at 0, and the
+	// experience it has gained by trading.
+	TradeTier() (tier, experience int)
+	// TierExperience returns the experience required to unlock each tier of
+	// trades of the Trader.
+	TierExperience() []int
+	// Offers returns the offers of the Trader, with prices adjusted for the
+	// customer passed.
+	Offers(customer world.Entity) []trade.Offer
+	// Trade uses the offer at the index passed n times for the customer
+	// passed. False is returned if the offer could not be used, for example
+	// because it is out of stock.
+	Trade(customer world.Entity, offer, n int) bool
+	// StopTrading is called when the customer of the Trader closes the
+	// trading window.
+	StopTrading()
+}
+
+// TradeOpener represents an entity that can open the trading window of a
+// Trader, such as a player.
+type TradeOpener interface {
+	item.User
+	// OpenTrade opens the trading window of the Trader passed.
+	OpenTrade(t Trader)
+}
@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewTraderLlama creates a trader llama leashed to the trader passed. The
+// trader llama despawns together with the WanderingTrader it is leashed to.
+// If trader is nil, the trader llama is not leashed.
+func NewTraderLlama(opts world.EntitySpawnOpts, trader world.Entity) *world.EntityHandle {
+	conf := traderLlamaConf
+	conf.Health = float64(15 + rand.IntN(16))
+	if trader != nil {
+		conf.Holder = trader.H()
+	}
+	return opts.New(TraderLlamaType, conf)
+}
+
+var traderLlamaConf = TraderLlamaBehaviourConfig{
+	Health: 22,
+}
+
+// TraderLlama is a passive mob that accompanies a WanderingTrader, to which
+// it is leashed. Trader llamas despawn together with the wandering trader
+// they are leashed to. TraderLlama implements the Living interface and may be
+// leashed using a lead.
+type TraderLlama struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the TraderLlamaBehaviour of the TraderLlama.
+func (l *TraderLlama) behaviour() *TraderLlamaBehaviour {
+	return l.data.Data.(*TraderLlamaBehaviour)
+}
+
+// newTraderLlama returns a TraderLlama that wraps the *Ent passed.
+func newTraderLlama(e *Ent) *TraderLlama {
+	l := &TraderLlama{Ent: e}
+	l.mobEntity = mobEntity{e: e, l: l}
+	return l
+}
+
+// Variant returns the colour variant of the TraderLlama, from 0 to 3.
+func (l *TraderLlama) Variant() int32 {
+	return l.behaviour().variant
+}
+
+// kill drops the loot and experience of the trader llama.
+func (l *TraderLlama) kill(src world.DamageSource) {
+	dropLoot(l, src, l.tx)
+	dropExperience(l.Position(), 1+rand.IntN(3), l.tx)
+}
+
+// TraderLlamaType is a world.EntityType implementation for TraderLlama.
+var TraderLlamaType traderLlamaType
+
+type traderLlamaType struct{}
+
+func (traderLlamaType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newTraderLlama(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (traderLlamaType) EncodeEntity() string { return "minecraft:trader_llama" }
+func (traderLlamaType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.45, 0, -0.45, 0.45, 1.87, 0.45)
+}
+
+func (traderLlamaType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := traderLlamaConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	b := conf.New()
+	if _, ok := m["Variant"]; ok {
+		b.variant = nbtconv.Int32(m, "Variant")
+	}
+	b.despawnTicks = int64(nbtconv.Int32(m, "DespawnDelay"))
+	data.Data = b
+}
+
+func (traderLlamaType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*TraderLlamaBehaviour)
+	return map[string]any{
+		"Health":       float32(b.health.Health()),
+		"Variant":      b.variant,
+		"DespawnDelay": int32(b.despawnTicks),
+	}
+}
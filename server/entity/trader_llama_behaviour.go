@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// TraderLlamaBehaviourConfig holds optional parameters for a
+// TraderLlamaBehaviour.
+type TraderLlamaBehaviourConfig struct {
+	// Health is the health that the trader llama has when it is created.
+	Health float64
+	// Holder is the entity that the trader llama is leashed to when it is
+	// created. If nil, the trader llama is not leashed.
+	Holder *world.EntityHandle
+}
+
+func (conf TraderLlamaBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a TraderLlamaBehaviour using the parameters in conf.
+func (conf TraderLlamaBehaviourConfig) New() *TraderLlamaBehaviour {
+	return &TraderLlamaBehaviour{
+		Leash:   Leash{holder: conf.Holder},
+		mc:      &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:     newMob(conf.Health, 0.12),
+		variant: rand.Int32N(4),
+	}
+}
+
+// TraderLlamaBehaviour implements the behaviour of a TraderLlama. Trader
+// llamas follow the entity they are leashed to and wander around otherwise.
+// A trader llama leashed to a WanderingTrader despawns together with it.
+type TraderLlamaBehaviour struct {
+	Leash
+
+	mc *MovementComputer
+	mob
+
+	variant int32
+	// despawnTicks is the number of ticks until the trader llama despawns, or
+	// 0 if it never despawns. While leashed to a wandering trader, it is
+	// equal to the despawn delay of the wandering trader.
+	despawnTicks int64
+
+	dest        mgl64.Vec3
+	wanderTicks int
+}
+
+// Tick makes the trader llama follow its leash holder or wander around, and
+// despawns it together with the wandering trader it is leashed to.
+func (b *TraderLlamaBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	l := newTraderLlama(e)
+	if !l.tickMob(tx) {
+		return nil
+	}
+
+	var holder world.Entity
+	if b.holder != nil {
+		holder, _ = b.holder.Entity(tx)
+		if trader, ok := holder.(*WanderingTrader); ok {
+			b.despawnTicks = trader.behaviour().despawnTicks
+		}
+	}
+	if b.despawnTicks > 0 {
+		if b.despawnTicks--; b.despawnTicks == 0 {
+			_ = e.Close()
+			return nil
+		}
+	}
+	b.TickLeash(e, tx)
+
+	pos, vel, rot := e.Position(), e.Velocity(), e.Rotation()
+	if b.mc.OnGround() {
+		if holder != nil && b.holder != nil {
+			// Stay close to the leash holder.
+			b.dest = holder.Position()
+			if b.dest.Sub(pos).Len() < 3 {
+				b.dest = pos
+			}
+		} else if b.wanderTicks--; b.wanderTicks <= 0 {
+			b.wanderTicks = 80 + rand.IntN(120)
+			b.dest = pos.Add(mgl64.Vec3{rand.Float64()*12 - 6, 0, rand.Float64()*12 - 6})
+		}
+		if dir := (mgl64.Vec3{b.dest[0] - pos[0], 0, b.dest[2] - pos[2]}); dir.Len() > 1 {
+			vel = dir.Normalize().Mul(b.speed).Add(mgl64.Vec3{0, vel[1]})
+			rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-dir[0], dir[2])), 0}
+		}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
@@ -0,0 +1,504 @@
+package entity
+
+import (
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/trade"
+)
+
+// NewVillager creates a villager with the VillagerProfession passed. An
+// unemployed villager acquires a profession once it finds an unclaimed job
+// site block.
+func NewVillager(opts world.EntitySpawnOpts, profession VillagerProfession) *world.EntityHandle {
+	conf := villagerConf
+	conf.Profession = profession
+	return opts.New(VillagerType, conf)
+}
+
+var villagerConf = VillagerBehaviourConfig{
+	Health: 20,
+}
+
+// Villager is a passive mob that lives in villages. Villagers take on a
+// VillagerProfession by claiming a job site block, after which they offer
+// trades to players. Trading unlocks new tiers of trades, and the prices of a
+// villager depend on demand and on the reputation that a player has with the
+// villager. Villager implements the Living, Interactable and Trader
+// interfaces.
+type Villager struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the VillagerBehaviour of the Villager.
+func (v *Villager) behaviour() *VillagerBehaviour {
+	return v.data.Data.(*VillagerBehaviour)
+}
+
+// newVillager returns a Villager that wraps the *Ent passed.
+func newVillager(e *Ent) *Villager {
+	v := &Villager{Ent: e}
+	v.mobEntity = mobEntity{e: e, l: v}
+	return v
+}
+
+// Profession returns the VillagerProfession of the Villager.
+func (v *Villager) Profession() VillagerProfession {
+	return v.behaviour().profession
+}
+
+// SetProfession changes the VillagerProfession of the Villager. The trades of
+// the Villager are reset if the profession changes.
+func (v *Villager) SetProfession(p VillagerProfession) {
+	v.behaviour().setProfession(v, p)
+}
+
+// JobSite returns the position of the job site block claimed by the Villager.
+// False is returned if the Villager has not claimed a job site.
+func (v *Villager) JobSite() (cube.Pos, bool) {
+	b := v.behaviour()
+	return b.jobSite, b.hasJobSite
+}
+
+// Variant returns the VillagerProfession of the Villager as an int32, as it is
+// shown to viewers.
+func (v *Villager) Variant() int32 {
+	return int32(v.behaviour().profession.Uint8())
+}
+
+// MarkVariant returns the type of the Villager as an int32, which depends on
+// the biome it was spawned in and determines its clothing.
+func (v *Villager) MarkVariant() int32 {
+	return v.behaviour().variant
+}
+
+// Reputation returns the reputation of the entity passed with the Villager.
+// The reputation is based on the gossip that the Villager has heard about the
+// entity, and lowers or raises the prices of its offers.
+func (v *Villager) Reputation(e world.Entity) int {
+	return v.behaviour().reputation(e)
+}
+
+// TraderName returns the translation key of the name of the profession of the
+// Villager.
+func (v *Villager) TraderName() string {
+	return "entity.villager." + v.behaviour().profession.translation()
+}
+
+// TradeTier returns the current tier of the Villager, starting at 0 for a
+// novice, and the experience it has gained by trading.
+func (v *Villager) TradeTier() (tier, experience int) {
+	b := v.behaviour()
+	return b.tier, b.experience
+}
+
+// TierExperience returns the experience required to unlock each tier of
+// trades of the Villager.
+func (v *Villager) TierExperience() []int {
+	t, _ := v.behaviour().profession.table()
+	experience := make([]int, 0, len(t.Tiers))
+	for _, tier := range t.Tiers {
+		experience = append(experience, tier.TotalExpRequired)
+	}
+	return experience
+}
+
+// Offers returns the offers of the Villager, with prices adjusted for the
+// demand of each offer, the reputation of the customer passed with the
+// Villager and the level of the Hero of the Village effect of the customer.
+func (v *Villager) Offers(customer world.Entity) []trade.Offer {
+	b := v.behaviour()
+	reputation, heroLevel := b.reputation(customer), 0
+	if l, ok := customer.(interface {
+		Effect(e effect.Type) (effect.Effect, bool)
+	}); ok {
+		if e, ok := l.Effect(effect.HeroOfTheVillage); ok {
+			heroLevel = e.Level()
+		}
+	}
+	offers := make([]trade.Offer, 0, len(b.offers))
+	for _, o := range b.offers {
+		offers = append(offers, o.Adjusted(reputation, heroLevel))
+	}
+	return offers
+}
+
+// Trade uses the offer at the index passed n times for the customer passed.
+// The Villager gains experience and may unlock a new tier of trades, and the
+// customer is rewarded experience orbs if the offer rewards experience.
+func (v *Villager) Trade(customer world.Entity, offer, n int) bool {
+	return v.behaviour().trade(v, customer, offer, n)
+}
+
+// StopTrading makes the Villager stop trading with its current customer.
+func (v *Villager) StopTrading() {
+	v.behaviour().customer = nil
+}
+
+// afterHurt makes players that hurt the Villager lose reputation with it.
+func (v *Villager) afterHurt(_ float64, src world.DamageSource) {
+	if attacker, ok := villagerAttacker(src); ok {
+		v.behaviour().addGossip(attacker, gossipMinorNegative, 25)
+	}
+}
+
+// kill drops the loot of the villager. If the villager was killed by a player,
+// villagers nearby lose respect for that player.
+func (v *Villager) kill(src world.DamageSource) {
+	if attacker, ok := villagerAttacker(src); ok {
+		for e := range v.tx.EntitiesWithin(cube.Box(-16, -16, -16, 16, 16, 16).Translate(v.Position())) {
+			if other, ok := e.(*Villager); ok && !other.Dead() {
+				other.behaviour().addGossip(attacker, gossipMajorNegative, 25)
+			}
+		}
+	}
+	dropLoot(v, src, v.tx)
+}
+
+// Interact opens the trading window of the Villager for the user if the
+// Villager has a profession with trades and is not already trading with
+// another customer.
+func (v *Villager) Interact(user item.User, tx *world.Tx, _ *item.UseContext) bool {
+	opener, ok := user.(TradeOpener)
+	if !ok || v.Dead() {
+		return false
+	}
+	b := v.behaviour()
+	if len(b.offers) == 0 {
+		return false
+	}
+	if b.customer != nil && b.customer != user.H() {
+		if _, ok := b.customer.Entity(tx); ok {
+			return false
+		}
+	}
+	b.customer = user.H()
+	opener.OpenTrade(v)
+	return true
+}
+
+// VillagerProfession is the profession of a Villager. The profession of a
+// villager determines the job site block it works at and the trades that it
+// offers.
+type VillagerProfession struct {
+	villagerProfession
+}
+
+type villagerProfession uint8
+
+// ProfessionNone is the profession of an unemployed villager. Unemployed
+// villagers do not trade, but take on a profession when they claim a job site
+// block.
+func ProfessionNone() VillagerProfession {
+	return VillagerProfession{0}
+}
+
+// ProfessionFarmer is the profession of a villager working at a composter.
+func ProfessionFarmer() VillagerProfession {
+	return VillagerProfession{1}
+}
+
+// ProfessionFisherman is the profession of a villager working at a barrel.
+func ProfessionFisherman() VillagerProfession {
+	return VillagerProfession{2}
+}
+
+// ProfessionShepherd is the profession of a villager working at a loom.
+func ProfessionShepherd() VillagerProfession {
+	return VillagerProfession{3}
+}
+
+// ProfessionFletcher is the profession of a villager working at a fletching
+// table.
+func ProfessionFletcher() VillagerProfession {
+	return VillagerProfession{4}
+}
+
+// ProfessionLibrarian is the profession of a villager working at a lectern.
+func ProfessionLibrarian() VillagerProfession {
+	return VillagerProfession{5}
+}
+
+// ProfessionCartographer is the profession of a villager working at a
+// cartography table.
+func ProfessionCartographer() VillagerProfession {
+	return VillagerProfession{6}
+}
+
+// ProfessionCleric is the profession of a villager working at a brewing
+// stand.
+func ProfessionCleric() VillagerProfession {
+	return VillagerProfession{7}
+}
+
+// ProfessionArmourer is the profession of a villager working at a blast
+// furnace.
+func ProfessionArmourer() VillagerProfession {
+	return VillagerProfession{8}
+}
+
+// ProfessionWeaponsmith is the profession of a villager working at a
+// grindstone.
+func ProfessionWeaponsmith() VillagerProfession {
+	return VillagerProfession{9}
+}
+
+// ProfessionToolsmith is the profession of a villager working at a smithing
+// table.
+func ProfessionToolsmith() VillagerProfession {
+	return VillagerProfession{10}
+}
+
+// ProfessionButcher is the profession of a villager working at a smoker.
+func ProfessionButcher() VillagerProfession {
+	return VillagerProfession{11}
+}
+
+// ProfessionLeatherworker is the profession of a villager working at a
+// cauldron. Cauldrons are not implemented, so leatherworkers never take on
+// their profession by claiming a job site block.
+func ProfessionLeatherworker() VillagerProfession {
+	return VillagerProfession{12}
+}
+
+// ProfessionMason is the profession of a villager working at a stonecutter.
+func ProfessionMason() VillagerProfession {
+	return VillagerProfession{13}
+}
+
+// ProfessionNitwit is the profession of a nitwit. Nitwits never take on a
+// profession and do not trade.
+func ProfessionNitwit() VillagerProfession {
+	return VillagerProfession{14}
+}
+
+// VillagerProfessions returns all villager professions.
+func VillagerProfessions() []VillagerProfession {
+	return []VillagerProfession{
+		ProfessionNone(), ProfessionFarmer(), ProfessionFisherman(), ProfessionShepherd(), ProfessionFletcher(),
+		ProfessionLibrarian(), ProfessionCartographer(), ProfessionCleric(), ProfessionArmourer(),
+		ProfessionWeaponsmith(), ProfessionToolsmith(), ProfessionButcher(), ProfessionLeatherworker(),
+		ProfessionMason(), ProfessionNitwit(),
+	}
+}
+
+// Uint8 returns the villager profession as a uint8.
+func (p villagerProfession) Uint8() uint8 {
+	return uint8(p)
+}
+
+// String ...
+func (p villagerProfession) String() string {
+	switch p {
+	case 1:
+		return "farmer"
+	case 2:
+		return "fisherman"
+	case 3:
+		return "shepherd"
+	case 4:
+		return "fletcher"
+	case 5:
+		return "librarian"
+	case 6:
+		return "cartographer"
+	case 7:
+		return "cleric"
+	case 8:
+		return "armourer"
+	case 9:
+		return "weaponsmith"
+	case 10:
+		return "toolsmith"
+	case 11:
+		return "butcher"
+	case 12:
+		return "leatherworker"
+	case 13:
+		return "mason"
+	case 14:
+		return "nitwit"
+	}
+	return "none"
+}
+
+// TradeTable returns the path of the trade table of the profession, relative
+// to the trade_tables folder of the trade package. An empty string is
+// returned for professions that do not trade.
+func (p villagerProfession) TradeTable() string {
+	switch p {
+	case 0, 14:
+		return ""
+	case 8:
+		return "economy_trades/armorer_trades.json"
+	case 9:
+		return "economy_trades/weapon_smith_trades.json"
+	case 10:
+		return "economy_trades/tool_smith_trades.json"
+	case 12:
+		return "economy_trades/leather_worker_trades.json"
+	case 13:
+		return "economy_trades/stone_mason_trades.json"
+	}
+	return "economy_trades/" + p.String() + "_trades.json"
+}
+
+// JobSite checks if the block passed is the job site block of the profession.
+func (p villagerProfession) JobSite(b world.Block) bool {
+	prof, ok := professionByJobSite(b)
+	return ok && prof.villagerProfession == p
+}
+
+// table loads the trade table of the profession. False is returned if the
+// profession does not trade or if its table could not be loaded.
+func (p villagerProfession) table() (trade.Table, bool) {
+	path := p.TradeTable()
+	if path == "" {
+		return trade.Table{}, false
+	}
+	t, err := trade.LoadTable(path)
+	return t, err == nil
+}
+
+// translation returns the last part of the translation key of the name of the
+// profession.
+func (p villagerProfession) translation() string {
+	switch p {
+	case 8:
+		return "armor"
+	case 9:
+		return "weapon"
+	case 10:
+		return "tool"
+	case 12:
+		return "leather"
+	}
+	return p.String()
+}
+
+// professionByJobSite returns the VillagerProfession of which the block passed
+// is the job site block. False is returned if the block is not a job site.
+func professionByJobSite(b world.Block) (VillagerProfession, bool) {
+	switch b.(type) {
+	case block.Composter:
+		return ProfessionFarmer(), true
+	case block.Barrel:
+		return ProfessionFisherman(), true
+	case block.Loom:
+		return ProfessionShepherd(), true
+	case block.FletchingTable:
+		return ProfessionFletcher(), true
+	case block.Lectern:
+		return ProfessionLibrarian(), true
+	case block.CartographyTable:
+		return ProfessionCartographer(), true
+	case block.BrewingStand:
+		return ProfessionCleric(), true
+	case block.BlastFurnace:
+		return ProfessionArmourer(), true
+	case block.Grindstone:
+		return ProfessionWeaponsmith(), true
+	case block.SmithingTable:
+		return ProfessionToolsmith(), true
+	case block.Smoker:
+		return ProfessionButcher(), true
+	case block.Stonecutter:
+		return ProfessionMason(), true
+	}
+	return VillagerProfession{}, false
+}
+
+// villagerVariantAt returns the type of a villager spawned at the position
+// passed, based on the tags of the biome at that position.
+func villagerVariantAt(pos cube.Pos, tx *world.Tx) int32 {
+	tags := tx.Biome(pos).Tags()
+	switch {
+	case slices.Contains(tags, "frozen"):
+		return 4
+	case slices.Contains(tags, "desert"), slices.Contains(tags, "mesa"):
+		return 1
+	case slices.Contains(tags, "jungle"):
+		return 2
+	case slices.Contains(tags, "savanna"):
+		return 3
+	case slices.Contains(tags, "swamp"), slices.Contains(tags, "mangrove_swamp"):
+		return 5
+	case slices.Contains(tags, "taiga"):
+		return 6
+	}
+	return 0
+}
+
+// VillagerType is a world.EntityType implementation for Villager.
+var VillagerType villagerType
+
+type villagerType struct{}
+
+func (villagerType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newVillager(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (villagerType) EncodeEntity() string { return "minecraft:villager_v2" }
+func (villagerType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.95, 0.3)
+}
+
+func (villagerType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := villagerConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	if p := nbtconv.Int32(m, "Variant"); p > 0 && int(p) < len(VillagerProfessions()) {
+		conf.Profession = VillagerProfessions()[p]
+	}
+	b := conf.New()
+	if _, ok := m["MarkVariant"]; ok {
+		b.variant, b.variantSet = nbtconv.Int32(m, "MarkVariant"), true
+	}
+	b.tier, b.experience = int(nbtconv.Int32(m, "TradeTier")), int(nbtconv.Int32(m, "TradeExperience"))
+	if _, ok := m["JobSite"]; ok {
+		b.jobSite, b.hasJobSite = nbtconv.Pos(m, "JobSite"), true
+	}
+	b.restocks, b.lastRestock = int(nbtconv.Int32(m, "RestocksToday")), nbtconv.Int64(m, "LastRestock")
+	if offers, ok := m["Offers"].(map[string]any); ok {
+		b.offers = b.offers[:0]
+		for _, o := range nbtconv.Slice(offers, "Recipes") {
+			if o, ok := o.(map[string]any); ok {
+				b.offers = append(b.offers, trade.DecodeOffer(o))
+			}
+		}
+	}
+	b.gossip = decodeGossip(nbtconv.Slice(m, "Gossips"))
+	data.Data = b
+}
+
+func (villagerType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*VillagerBehaviour)
+	recipes := make([]any, 0, len(b.offers))
+	for _, o := range b.offers {
+		recipes = append(recipes, o.EncodeNBT())
+	}
+	m := map[string]any{
+		"Health":          float32(b.health.Health()),
+		"Variant":         int32(b.profession.Uint8()),
+		"TradeTier":       int32(b.tier),
+		"TradeExperience": int32(b.experience),
+		"RestocksToday":   int32(b.restocks),
+		"LastRestock":     b.lastRestock,
+		"Offers":          map[string]any{"Recipes": recipes},
+		"Gossips":         encodeGossip(b.gossip),
+	}
+	if b.variantSet {
+		m["MarkVariant"] = b.variant
+	}
+	if b.hasJobSite {
+		m["JobSite"] = nbtconv.PosToInt32Slice(b.jobSite)
+	}
+	return m
+}
@@ -0,0 +1,322 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/trade"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+)
+
+const (
+	// villagerJobSiteRange is the horizontal range in blocks within which a
+	// villager looks for a job site block to claim.
+	villagerJobSiteRange = 10
+	// villagerWorkStart and villagerWorkEnd are the times of the day between
+	// which villagers work at their job site and restock their offers.
+	villagerWorkStart, villagerWorkEnd = 2000, 9000
+	// villagerRestockCooldown is the minimum number of ticks between two
+	// restocks of a villager on the same day.
+	villagerRestockCooldown = 2400
+)
+
+// VillagerBehaviourConfig holds optional parameters for a VillagerBehaviour.
+type VillagerBehaviourConfig struct {
+	// Health is the health that the villager has when it is created.
+	Health float64
+	// Profession is the profession that the villager has when it is created.
+	Profession VillagerProfession
+}
+
+func (conf VillagerBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a VillagerBehaviour using the parameters in conf.
+func (conf VillagerBehaviourConfig) New() *VillagerBehaviour {
+	b := &VillagerBehaviour{
+		mc:     &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:    newMob(conf.Health, 0.1),
+		gossip: make(map[uuid.UUID]villagerGossip),
+	}
+	b.setProfession(nil, conf.Profession)
+	return b
+}
+
+// VillagerBehaviour implements the behaviour of a Villager. Villagers wander
+// around during the day and claim job site blocks, which gives unemployed
+// villagers a profession. Villagers with a profession work at their job site,
+// where they restock their offers up to twice a day.
+type VillagerBehaviour struct {
+	mc *MovementComputer
+	mob
+
+	profession VillagerProfession
+	// variant is the type of the villager, which depends on the biome that
+	// it spawned in. variantSet is false until the type was determined.
+	variant    int32
+	variantSet bool
+
+	// tier is the index of the highest tier of trades unlocked and
+	// experience is the experience gained by trading.
+	tier, experience int
+	offers           []trade.Offer
+	customer         *world.EntityHandle
+	gossip           map[uuid.UUID]villagerGossip
+
+	jobSite    cube.Pos
+	hasJobSite bool
+	// restocks is the number of times that the villager restocked on the day
+	// of lastRestock, the world time of the last restock.
+	restocks    int
+	lastRestock int64
+
+	dest        mgl64.Vec3
+	wanderTicks int
+}
+
+// Tick makes the villager look for a job site, work at its job site and
+// wander around.
+func (b *VillagerBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	v := newVillager(e)
+	if !v.tickMob(tx) {
+		return nil
+	}
+
+	pos, vel, rot := e.Position(), e.Velocity(), e.Rotation()
+	if !b.variantSet {
+		b.variant, b.variantSet = villagerVariantAt(cube.PosFromVec3(pos), tx), true
+		b.updateState(v, tx)
+	}
+	if v.Age()%(time.Second*5) == 0 {
+		b.updateJobSite(v, tx)
+	}
+	if v.Age()%(time.Minute*20) == 0 {
+		b.decayGossip()
+	}
+
+	if customer, ok := b.currentCustomer(v, tx); ok {
+		// Stand still and look at the customer while trading.
+		delta := customer.Position().Sub(pos)
+		vel = mgl64.Vec3{0, vel[1]}
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-delta[0], delta[2])), 0}
+	} else if b.mc.OnGround() {
+		if dest, ok := b.work(v, tx); ok {
+			b.dest = dest
+		} else if b.wanderTicks--; b.wanderTicks <= 0 {
+			b.wanderTicks = 100 + rand.IntN(140)
+			b.dest = pos
+			if t := tx.World().Time() % world.TimeFull; t < world.TimeSleep || t > world.TimeWake {
+				b.dest = pos.Add(mgl64.Vec3{rand.Float64()*12 - 6, 0, rand.Float64()*12 - 6})
+			}
+		}
+		if dir := (mgl64.Vec3{b.dest[0] - pos[0], 0, b.dest[2] - pos[2]}); dir.Len() > 1 {
+			vel = dir.Normalize().Mul(b.speed).Add(mgl64.Vec3{0, vel[1]})
+			rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-dir[0], dir[2])), 0}
+		}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// currentCustomer returns the entity that the villager is currently trading
+// with. If the customer left the world or moved too far away, the villager
+// stops trading.
+func (b *VillagerBehaviour) currentCustomer(v *Villager, tx *world.Tx) (world.Entity, bool) {
+	if b.customer == nil {
+		return nil, false
+	}
+	if customer, ok := b.customer.Entity(tx); ok && customer.Position().Sub(v.Position()).Len() <= 8 {
+		return customer, true
+	}
+	b.customer = nil
+	return nil, false
+}
+
+// work returns the position of the job site of the villager if the villager
+// should walk to it to restock its offers. The offers are restocked once the
+// villager reaches its job site.
+func (b *VillagerBehaviour) work(v *Villager, tx *world.Tx) (mgl64.Vec3, bool) {
+	now := int64(tx.World().Time())
+	if !b.hasJobSite || !b.needsRestock() || !b.allowedToRestock(now) {
+		return mgl64.Vec3{}, false
+	}
+	if t := now % world.TimeFull; t < villagerWorkStart || t > villagerWorkEnd {
+		return mgl64.Vec3{}, false
+	}
+	site := b.jobSite.Vec3Centre()
+	if site.Sub(v.Position()).Len() <= 2 {
+		b.restock(now)
+		return mgl64.Vec3{}, false
+	}
+	return site, true
+}
+
+// needsRestock checks if any of the offers of the villager has been used
+// since the villager last restocked.
+func (b *VillagerBehaviour) needsRestock() bool {
+	for _, o := range b.offers {
+		if o.Uses > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedToRestock checks if the villager may restock at the world time
+// passed. Villagers restock at most twice a day, with some time in between.
+func (b *VillagerBehaviour) allowedToRestock(now int64) bool {
+	if now/world.TimeFull != b.lastRestock/world.TimeFull {
+		b.restocks = 0
+	}
+	return b.restocks == 0 || (b.restocks < 2 && now > b.lastRestock+villagerRestockCooldown)
+}
+
+// restock restocks all offers of the villager, updating the demand of each
+// offer.
+func (b *VillagerBehaviour) restock(now int64) {
+	for i := range b.offers {
+		b.offers[i].Restock()
+	}
+	b.restocks++
+	b.lastRestock = now
+}
+
+// updateJobSite checks if the job site claimed by the villager still exists.
+// A villager without a job site looks for an unclaimed job site block nearby.
+// Unemployed villagers take on the profession of the job site they claim.
+func (b *VillagerBehaviour) updateJobSite(v *Villager, tx *world.Tx) {
+	if b.hasJobSite {
+		if b.profession.JobSite(tx.Block(b.jobSite)) {
+			return
+		}
+		b.hasJobSite = false
+		if b.experience == 0 {
+			// Villagers that never traded lose their profession together with
+			// their job site.
+			b.setProfession(v, ProfessionNone())
+		}
+	}
+	if b.profession == ProfessionNitwit() {
+		return
+	}
+	pos := cube.PosFromVec3(v.Position())
+	var (
+		site  cube.Pos
+		prof  VillagerProfession
+		found bool
+		dist  = math.MaxFloat64
+	)
+	for x := -villagerJobSiteRange; x <= villagerJobSiteRange; x++ {
+		for y := -4; y <= 4; y++ {
+			for z := -villagerJobSiteRange; z <= villagerJobSiteRange; z++ {
+				p := pos.Add(cube.Pos{x, y, z})
+				jp, ok := professionByJobSite(tx.Block(p))
+				if !ok || (b.profession != ProfessionNone() && jp != b.profession) {
+					continue
+				}
+				if d := p.Vec3Centre().Sub(v.Position()).Len(); d < dist && !jobSiteClaimed(v, p, tx) {
+					site, prof, found, dist = p, jp, true, d
+				}
+			}
+		}
+	}
+	if !found {
+		return
+	}
+	b.jobSite, b.hasJobSite = site, true
+	if b.profession == ProfessionNone() {
+		b.setProfession(v, prof)
+	}
+}
+
+// jobSiteClaimed checks if the job site at the position passed has been
+// claimed by a villager other than the one passed.
+func jobSiteClaimed(v *Villager, pos cube.Pos, tx *world.Tx) bool {
+	for e := range tx.EntitiesWithin(cube.Box(-48, -48, -48, 48, 48, 48).Translate(pos.Vec3())) {
+		if other, ok := e.(*Villager); ok && other.H() != v.H() {
+			if site, ok := other.JobSite(); ok && site == pos {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setProfession changes the profession of the villager. If the profession
+// changes, the experience of the villager is reset and it is given the
+// offers of the first tier of its new profession. v may be nil if the
+// villager is not yet in a world.
+func (b *VillagerBehaviour) setProfession(v *Villager, p VillagerProfession) {
+	if b.profession == p && (len(b.offers) > 0 || p.TradeTable() == "") {
+		return
+	}
+	b.profession, b.tier, b.experience, b.offers = p, 0, 0, nil
+	if t, ok := p.table(); ok {
+		b.offers = t.Offers(0)
+	}
+	if v != nil {
+		b.updateState(v, v.tx)
+	}
+}
+
+// trade uses the offer at the index passed n times for the customer passed.
+func (b *VillagerBehaviour) trade(v *Villager, customer world.Entity, index, n int) bool {
+	if index < 0 || index >= len(b.offers) || n < 1 {
+		return false
+	}
+	o := &b.offers[index]
+	if o.Uses+n > o.MaxUses {
+		return false
+	}
+	o.Uses += n
+	b.experience += o.TraderExp * n
+	b.addGossip(customer, gossipTrading, 2*n)
+
+	xp := 0
+	if o.RewardExp {
+		for range n {
+			xp += 3 + rand.IntN(4)
+		}
+	}
+	if b.levelUp(v) && o.RewardExp {
+		xp += 5
+	}
+	if xp > 0 {
+		for _, orb := range NewExperienceOrbs(v.Position().Add(mgl64.Vec3{0, 0.5}), xp) {
+			v.tx.AddEntity(orb)
+		}
+	}
+	return true
+}
+
+// levelUp unlocks the next tiers of trades of the villager for which it has
+// enough experience. True is returned if a new tier was unlocked.
+func (b *VillagerBehaviour) levelUp(v *Villager) bool {
+	t, ok := b.profession.table()
+	if !ok {
+		return false
+	}
+	tier := t.Tier(b.experience)
+	if tier <= b.tier {
+		return false
+	}
+	for b.tier < tier {
+		b.tier++
+		b.offers = append(b.offers, t.Offers(b.tier)...)
+	}
+	b.updateState(v, v.tx)
+	return true
+}
+
+// updateState sends the state of the villager to its viewers.
+func (b *VillagerBehaviour) updateState(v *Villager, tx *world.Tx) {
+	for _, viewer := range tx.Viewers(v.Position()) {
+		viewer.ViewEntityState(v)
+	}
+}
@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+// gossipType is a type of gossip that a villager may hear about an entity.
+// The gossip that a villager has heard about an entity determines the
+// reputation of the entity with the villager.
+type gossipType int
+
+const (
+	gossipMajorNegative gossipType = iota
+	gossipMinorNegative
+	gossipMinorPositive
+	gossipMajorPositive
+	gossipTrading
+)
+
+// gossipTypes holds, for every gossipType, the name it is saved under, the
+// weight of the gossip in the reputation of an entity, the maximum value of
+// the gossip and the amount that it decays by every twenty minutes.
+var gossipTypes = [...]struct {
+	name               string
+	weight, max, decay int
+}{
+	gossipMajorNegative: {name: "MajorNegative", weight: -5, max: 100, decay: 10},
+	gossipMinorNegative: {name: "MinorNegative", weight: -1, max: 200, decay: 20},
+	gossipMinorPositive: {name: "MinorPositive", weight: 1, max: 200, decay: 1},
+	gossipMajorPositive: {name: "MajorPositive", weight: 5, max: 20, decay: 0},
+	gossipTrading:       {name: "Trading", weight: 1, max: 25, decay: 2},
+}
+
+// villagerGossip holds the value of every gossipType that a villager has heard
+// about a single entity.
+type villagerGossip [len(gossipTypes)]int
+
+// reputation returns the reputation of the entity passed with the villager,
+// which is the weighted sum of all gossip heard about the entity.
+func (b *VillagerBehaviour) reputation(e world.Entity) int {
+	g, ok := b.gossip[e.H().UUID()]
+	if !ok {
+		return 0
+	}
+	rep := 0
+	for t, v := range g {
+		rep += v * gossipTypes[t].weight
+	}
+	return rep
+}
+
+// addGossip adds gossip of the gossipType passed about the entity passed,
+// without exceeding the maximum value of the gossipType.
+func (b *VillagerBehaviour) addGossip(e world.Entity, t gossipType, v int) {
+	id := e.H().UUID()
+	g := b.gossip[id]
+	g[t] = min(g[t]+v, gossipTypes[t].max)
+	b.gossip[id] = g
+}
+
+// decayGossip lowers the values of all gossip that the villager has heard.
+// Gossip about entities of which nothing is left is forgotten.
+func (b *VillagerBehaviour) decayGossip() {
+	for id, g := range b.gossip {
+		empty := true
+		for t := range g {
+			if g[t] = max(g[t]-gossipTypes[t].decay, 0); g[t] > 0 {
+				empty = false
+			}
+		}
+		if empty {
+			delete(b.gossip, id)
+			continue
+		}
+		b.gossip[id] = g
+	}
+}
+
+// villagerAttacker returns the player responsible for the damage source
+// passed. False is returned if the damage was not dealt by a player.
+func villagerAttacker(src world.DamageSource) (world.Entity, bool) {
+	var attacker world.Entity
+	switch s := src.(type) {
+	case AttackDamageSource:
+		attacker = s.Attacker
+	case ProjectileDamageSource:
+		attacker = s.Owner
+	}
+	if _, ok := attacker.(interface{ GameMode() world.GameMode }); !ok {
+		return nil, false
+	}
+	return attacker, true
+}
+
+// encodeGossip encodes the gossip passed into a slice that may be saved using
+// NBT.
+func encodeGossip(gossip map[uuid.UUID]villagerGossip) []any {
+	s := make([]any, 0, len(gossip))
+	for id, g := range gossip {
+		m := map[string]any{"UUID": id.String()}
+		for t, v := range g {
+			m[gossipTypes[t].name] = int32(v)
+		}
+		s = append(s, m)
+	}
+	return s
+}
+
+// decodeGossip decodes gossip encoded using encodeGossip.
+func decodeGossip(s []any) map[uuid.UUID]villagerGossip {
+	gossip := make(map[uuid.UUID]villagerGossip, len(s))
+	for _, v := range s {
+		m, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		id, err := uuid.Parse(nbtconv.String(m, "UUID"))
+		if err != nil {
+			continue
+		}
+		var g villagerGossip
+		for t := range g {
+			g[t] = int(nbtconv.Int32(m, gossipTypes[t].name))
+		}
+		gossip[id] = g
+	}
+	return gossip
+}
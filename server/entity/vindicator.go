@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewVindicator creates a vindicator. If captain is true, the vindicator carries an
+// ominous banner.
+func NewVindicator(opts world.EntitySpawnOpts, captain bool) *world.EntityHandle {
+	conf := vindicatorConf
+	conf.Captain = captain
+	return opts.New(VindicatorType, conf)
+}
+
+var vindicatorConf = VindicatorBehaviourConfig{
+	Health:       24,
+	AttackDamage: 5,
+}
+
+// Vindicator is a hostile illager that attacks players and villagers with an
+// iron axe. Vindicators take part in raids, where one of them may lead a wave
+// as its captain. Vindicator implements the Raider interface.
+type Vindicator struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the VindicatorBehaviour of the Vindicator.
+func (v *Vindicator) behaviour() *VindicatorBehaviour {
+	return v.data.Data.(*VindicatorBehaviour)
+}
+
+// newVindicator returns a Vindicator that wraps the *Ent passed.
+func newVindicator(e *Ent) *Vindicator {
+	v := &Vindicator{Ent: e}
+	v.mobEntity = mobEntity{e: e, l: v}
+	return v
+}
+
+// raider returns the raid state of the Vindicator.
+func (v *Vindicator) raider() *raider {
+	return &v.behaviour().raider
+}
+
+// Raid returns the Raid that the Vindicator takes part in, if any.
+func (v *Vindicator) Raid() (*Raid, bool) {
+	return v.behaviour().Raid()
+}
+
+// Captain checks if the Vindicator is a captain carrying an ominous banner.
+func (v *Vindicator) Captain() bool {
+	return v.behaviour().Captain()
+}
+
+// BellRung reveals the Vindicator if it takes part in a raid.
+func (v *Vindicator) BellRung(cube.Pos, *world.Tx) {
+	v.behaviour().reveal()
+}
+
+// HeldItems returns the iron axe held by the Vindicator.
+func (v *Vindicator) HeldItems() (mainHand, offHand item.Stack) {
+	return item.NewStack(item.Axe{Tier: item.ToolTierIron}, 1), item.Stack{}
+}
+
+// afterHurt makes the Vindicator target the entity that hurt it.
+func (v *Vindicator) afterHurt(_ float64, src world.DamageSource) {
+	v.behaviour().retaliate(src)
+}
+
+// kill handles the death of the vindicator as a raider and drops its loot and
+// experience.
+func (v *Vindicator) kill(src world.DamageSource) {
+	v.behaviour().die(v, src, v.tx)
+	dropLoot(v, src, v.tx)
+	dropExperience(v.Position(), 5, v.tx)
+}
+
+// VindicatorType is a world.EntityType implementation for Vindicator.
+var VindicatorType vindicatorType
+
+type vindicatorType struct{}
+
+func (vindicatorType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newVindicator(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (vindicatorType) EncodeEntity() string { return "minecraft:vindicator" }
+func (vindicatorType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.95, 0.3)
+}
+
+func (vindicatorType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := vindicatorConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	conf.Captain = nbtconv.Bool(m, "PatrolLeader")
+	data.Data = conf.New()
+}
+
+func (vindicatorType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*VindicatorBehaviour)
+	return map[string]any{"Health": float32(b.health.Health()), "PatrolLeader": boolByte(b.captain)}
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// VindicatorBehaviourConfig holds optional parameters for a
+// VindicatorBehaviour.
+type VindicatorBehaviourConfig struct {
+	// Health is the health that the vindicator has when it is created.
+	Health float64
+	// AttackDamage is the damage dealt by the vindicator when it hits its
+	// target with its axe.
+	AttackDamage float64
+	// Captain specifies if the vindicator is a captain carrying an ominous
+	// banner.
+	Captain bool
+}
+
+func (conf VindicatorBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a VindicatorBehaviour using the parameters in conf.
+func (conf VindicatorBehaviourConfig) New() *VindicatorBehaviour {
+	return &VindicatorBehaviour{
+		raider: raider{captain: conf.Captain},
+		conf:   conf,
+		mc:     &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:    newMob(conf.Health, 0.22),
+	}
+}
+
+// VindicatorBehaviour implements the behaviour of a Vindicator. A vindicator
+// wanders around, or walks towards the centre of its raid, until it finds a
+// target, after which it runs towards the target and attacks it with its axe.
+type VindicatorBehaviour struct {
+	raider
+
+	conf VindicatorBehaviourConfig
+	mc   *MovementComputer
+	mob
+
+	attackCooldown int
+}
+
+// Tick makes the vindicator move around or run towards and attack its target.
+func (b *VindicatorBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	v := newVindicator(e)
+	if !v.tickMob(tx) {
+		return nil
+	}
+	b.tickRaider(v, tx)
+	if b.attackCooldown > 0 {
+		b.attackCooldown--
+	}
+
+	pos, vel, rot := e.Position(), e.Velocity(), e.Rotation()
+	target, ok := b.findTarget(v, tx, true)
+	if ok {
+		if b.attackCooldown == 0 && target.Position().Sub(pos).Len() <= 2 {
+			b.attackCooldown = 20
+			raiderAttack(v, target, b.conf.AttackDamage, 0.4)
+		}
+	} else {
+		target = nil
+	}
+	vel, rot = b.move(b.mc, pos, vel, rot, b.speed, 1, target)
+
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
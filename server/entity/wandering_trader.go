@@ -0,0 +1,169 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/trade"
+)
+
+// NewWanderingTrader creates a wandering trader. If despawnDelay is larger
+// than 0, the wandering trader despawns once that time has passed.
+func NewWanderingTrader(opts world.EntitySpawnOpts, despawnDelay time.Duration) *world.EntityHandle {
+	conf := wanderingTraderConf
+	conf.DespawnDelay = despawnDelay
+	return opts.New(WanderingTraderType, conf)
+}
+
+var wanderingTraderConf = WanderingTraderBehaviourConfig{
+	Health: 20,
+}
+
+// wanderingTraderTradeTable is the path of the trade table used for the
+// offers of wandering traders.
+const wanderingTraderTradeTable = "wandering_trader_trades.json"
+
+// WanderingTrader is a passive mob that periodically spawns near players,
+// accompanied by two trader llamas. Wandering traders offer a random
+// selection of trades from their trade table and despawn after some time.
+// At night, wandering traders drink a potion of invisibility. WanderingTrader
+// implements the Living, Interactable and Trader interfaces.
+type WanderingTrader struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the WanderingTraderBehaviour of the WanderingTrader.
+func (w *WanderingTrader) behaviour() *WanderingTraderBehaviour {
+	return w.data.Data.(*WanderingTraderBehaviour)
+}
+
+// newWanderingTrader returns a WanderingTrader that wraps the *Ent passed.
+func newWanderingTrader(e *Ent) *WanderingTrader {
+	w := &WanderingTrader{Ent: e}
+	w.mobEntity = mobEntity{e: e, l: w}
+	return w
+}
+
+// DespawnDelay returns the time left until the WanderingTrader despawns. If
+// the WanderingTrader never despawns, DespawnDelay returns 0.
+func (w *WanderingTrader) DespawnDelay() time.Duration {
+	return time.Duration(w.behaviour().despawnTicks) * time.Second / 20
+}
+
+// TraderName returns the translation key of the name of the WanderingTrader.
+func (w *WanderingTrader) TraderName() string {
+	return "entity.wandering_trader.name"
+}
+
+// TradeTier always returns 0, as wandering traders do not unlock new tiers of
+// trades.
+func (w *WanderingTrader) TradeTier() (tier, experience int) {
+	return 0, 0
+}
+
+// TierExperience returns the experience required for the only tier of trades
+// of the WanderingTrader.
+func (w *WanderingTrader) TierExperience() []int {
+	return []int{0}
+}
+
+// Offers returns the offers of the WanderingTrader. Unlike those of villagers,
+// the prices of wandering traders do not depend on the customer or demand.
+func (w *WanderingTrader) Offers(world.Entity) []trade.Offer {
+	return slices.Clone(w.behaviour().offers)
+}
+
+// Trade uses the offer at the index passed n times for the customer passed.
+// The customer is rewarded experience orbs if the offer rewards experience.
+func (w *WanderingTrader) Trade(_ world.Entity, offer, n int) bool {
+	return w.behaviour().trade(w, offer, n)
+}
+
+// StopTrading makes the WanderingTrader stop trading with its current
+// customer.
+func (w *WanderingTrader) StopTrading() {
+	w.behaviour().customer = nil
+}
+
+// HeldItems returns the potion or milk bucket that the WanderingTrader is
+// drinking, if any.
+func (w *WanderingTrader) HeldItems() (mainHand, offHand item.Stack) {
+	return w.behaviour().drinking, item.Stack{}
+}
+
+// kill drops the loot of the wandering trader.
+func (w *WanderingTrader) kill(src world.DamageSource) {
+	dropLoot(w, src, w.tx)
+}
+
+// Interact opens the trading window of the WanderingTrader for the user if
+// the WanderingTrader is not already trading with another customer.
+func (w *WanderingTrader) Interact(user item.User, tx *world.Tx, _ *item.UseContext) bool {
+	opener, ok := user.(TradeOpener)
+	if !ok || w.Dead() {
+		return false
+	}
+	b := w.behaviour()
+	if len(b.offers) == 0 {
+		return false
+	}
+	if b.customer != nil && b.customer != user.H() {
+		if _, ok := b.customer.Entity(tx); ok {
+			return false
+		}
+	}
+	b.customer = user.H()
+	opener.OpenTrade(w)
+	return true
+}
+
+// WanderingTraderType is a world.EntityType implementation for
+// WanderingTrader.
+var WanderingTraderType wanderingTraderType
+
+type wanderingTraderType struct{}
+
+func (wanderingTraderType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newWanderingTrader(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (wanderingTraderType) EncodeEntity() string { return "minecraft:wandering_trader" }
+func (wanderingTraderType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.95, 0.3)
+}
+
+func (wanderingTraderType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := wanderingTraderConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	conf.DespawnDelay = time.Duration(nbtconv.Int32(m, "DespawnDelay")) * time.Second / 20
+	b := conf.New()
+	if offers, ok := m["Offers"].(map[string]any); ok {
+		b.offers = b.offers[:0]
+		for _, o := range nbtconv.Slice(offers, "Recipes") {
+			if o, ok := o.(map[string]any); ok {
+				b.offers = append(b.offers, trade.DecodeOffer(o))
+			}
+		}
+	}
+	data.Data = b
+}
+
+func (wanderingTraderType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*WanderingTraderBehaviour)
+	recipes := make([]any, 0, len(b.offers))
+	for _, o := range b.offers {
+		recipes = append(recipes, o.EncodeNBT())
+	}
+	return map[string]any{
+		"Health":       float32(b.health.Health()),
+		"DespawnDelay": int32(b.despawnTicks),
+		"Offers":       map[string]any{"Recipes": recipes},
+	}
+}
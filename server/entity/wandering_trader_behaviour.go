@@ -0,0 +1,181 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/trade"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// WanderingTraderBehaviourConfig holds optional parameters for a
+// WanderingTraderBehaviour.
+type WanderingTraderBehaviourConfig struct {
+	// Health is the health that the wandering trader has when it is created.
+	Health float64
+	// DespawnDelay is the time after which the wandering trader despawns. If
+	// 0, the wandering trader never despawns.
+	DespawnDelay time.Duration
+}
+
+func (conf WanderingTraderBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a WanderingTraderBehaviour using the parameters in conf.
+func (conf WanderingTraderBehaviourConfig) New() *WanderingTraderBehaviour {
+	b := &WanderingTraderBehaviour{
+		mc:           &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:          newMob(conf.Health, 0.1),
+		despawnTicks: int64(conf.DespawnDelay.Seconds() * 20),
+	}
+	if t, err := trade.LoadTable(wanderingTraderTradeTable); err == nil {
+		b.offers = t.Offers(0)
+	}
+	return b
+}
+
+// WanderingTraderBehaviour implements the behaviour of a WanderingTrader.
+// Wandering traders wander around until they despawn. They drink a potion of
+// invisibility at night and a bucket of milk once it is day again.
+type WanderingTraderBehaviour struct {
+	mc *MovementComputer
+	mob
+
+	offers   []trade.Offer
+	customer *world.EntityHandle
+
+	// despawnTicks is the number of ticks until the wandering trader
+	// despawns, or 0 if it never despawns.
+	despawnTicks int64
+	// drinking is the item that the wandering trader is currently drinking.
+	// drinkTicks is the number of ticks until it finishes drinking.
+	drinking   item.Stack
+	drinkTicks int
+
+	dest        mgl64.Vec3
+	wanderTicks int
+}
+
+// Tick makes the wandering trader wander around, drink potions and despawn
+// once its despawn delay has passed.
+func (b *WanderingTraderBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	w := newWanderingTrader(e)
+	if !w.tickMob(tx) {
+		return nil
+	}
+
+	pos, vel, rot := e.Position(), e.Velocity(), e.Rotation()
+	customer, trading := b.currentCustomer(w, tx)
+	if b.despawnTicks > 0 && !trading {
+		if b.despawnTicks--; b.despawnTicks == 0 {
+			_ = e.Close()
+			return nil
+		}
+	}
+	b.tickDrinking(w, tx)
+
+	if trading {
+		// Stand still and look at the customer while trading.
+		delta := customer.Position().Sub(pos)
+		vel = mgl64.Vec3{0, vel[1]}
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-delta[0], delta[2])), 0}
+	} else if b.mc.OnGround() {
+		if b.wanderTicks--; b.wanderTicks <= 0 {
+			b.wanderTicks = 80 + rand.IntN(120)
+			b.dest = pos.Add(mgl64.Vec3{rand.Float64()*12 - 6, 0, rand.Float64()*12 - 6})
+		}
+		if dir := (mgl64.Vec3{b.dest[0] - pos[0], 0, b.dest[2] - pos[2]}); dir.Len() > 1 {
+			vel = dir.Normalize().Mul(b.speed).Add(mgl64.Vec3{0, vel[1]})
+			rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-dir[0], dir[2])), 0}
+		}
+	}
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// currentCustomer returns the entity that the wandering trader is currently
+// trading with. If the customer left the world or moved too far away, the
+// wandering trader stops trading.
+func (b *WanderingTraderBehaviour) currentCustomer(w *WanderingTrader, tx *world.Tx) (world.Entity, bool) {
+	if b.customer == nil {
+		return nil, false
+	}
+	if customer, ok := b.customer.Entity(tx); ok && customer.Position().Sub(w.Position()).Len() <= 8 {
+		return customer, true
+	}
+	b.customer = nil
+	return nil, false
+}
+
+// tickDrinking makes the wandering trader start drinking a potion of
+// invisibility at night and a bucket of milk during the day if it is still
+// invisible. Once the wandering trader finishes drinking, the effects of the
+// item drunk are applied.
+func (b *WanderingTraderBehaviour) tickDrinking(w *WanderingTrader, tx *world.Tx) {
+	if !b.drinking.Empty() {
+		if b.drinkTicks--; b.drinkTicks > 0 {
+			return
+		}
+		switch it := b.drinking.Item().(type) {
+		case item.Potion:
+			for _, e := range it.Type.Effects() {
+				w.AddEffect(e)
+			}
+		case item.Bucket:
+			for _, e := range w.Effects() {
+				w.RemoveEffect(e.Type())
+			}
+		}
+		b.setDrinking(w, tx, item.Stack{})
+		return
+	}
+	_, invisible := w.Effect(effect.Invisibility)
+	t := tx.World().Time() % world.TimeFull
+	night := t >= world.TimeSleep && t <= world.TimeWake
+	switch {
+	case night && !invisible:
+		b.setDrinking(w, tx, item.NewStack(item.Potion{Type: potion.Invisibility()}, 1))
+	case !night && invisible:
+		b.setDrinking(w, tx, item.NewStack(item.Bucket{Content: item.MilkBucketContent()}, 1))
+	}
+}
+
+// setDrinking changes the item that the wandering trader is drinking and
+// shows it to viewers.
+func (b *WanderingTraderBehaviour) setDrinking(w *WanderingTrader, tx *world.Tx, s item.Stack) {
+	b.drinking, b.drinkTicks = s, 32
+	for _, viewer := range tx.Viewers(w.Position()) {
+		viewer.ViewEntityItems(w)
+	}
+}
+
+// trade uses the offer at the index passed n times.
+func (b *WanderingTraderBehaviour) trade(w *WanderingTrader, index, n int) bool {
+	if index < 0 || index >= len(b.offers) || n < 1 {
+		return false
+	}
+	o := &b.offers[index]
+	if o.Uses+n > o.MaxUses {
+		return false
+	}
+	o.Uses += n
+	if !o.RewardExp {
+		return true
+	}
+	xp := 0
+	for range n {
+		xp += 3 + rand.IntN(4)
+	}
+	for _, orb := range NewExperienceOrbs(w.Position().Add(mgl64.Vec3{0, 0.5}), xp) {
+		w.tx.AddEntity(orb)
+	}
+	return true
+}
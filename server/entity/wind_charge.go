@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// NewBreezeWindCharge creates a wind charge shot by a breeze. The wind charge
+// bursts when it hits a block or an entity, knocking back entities and
+// triggering blocks such as doors and levers around it.
+func NewBreezeWindCharge(opts world.EntitySpawnOpts, owner world.Entity) *world.EntityHandle {
+	conf := breezeWindChargeConf
+	conf.Owner = owner.H()
+	return opts.New(BreezeWindChargeType, conf)
+}
+
+var breezeWindChargeConf = ProjectileBehaviourConfig{
+	Damage:   -1,
+	Particle: particle.WindExplosion{},
+	Sound:    sound.WindChargeBurst{},
+	Hit:      burstWindCharge,
+	MaxAge:   time.Second * 10,
+}
+
+const (
+	// windChargeRadius is the radius in blocks of the burst of a wind charge.
+	windChargeRadius = 3.0
+	// windChargeDamage is the damage dealt to an entity directly hit by a wind
+	// charge.
+	windChargeDamage = 1.0
+)
+
+// burstWindCharge hurts the entity hit by a wind charge and makes it burst,
+// knocking back entities and triggering blocks around it.
+func burstWindCharge(e *Ent, tx *world.Tx, target trace.Result) {
+	owner, _ := e.Behaviour().(*ProjectileBehaviour).Owner().Entity(tx)
+	if r, ok := target.(trace.EntityResult); ok {
+		if l, ok := r.Entity().(Living); ok {
+			l.Hurt(windChargeDamage, ProjectileDamageSource{Projectile: e, Owner: owner})
+		}
+	}
+	pos := target.Position()
+	box := cube.Box(-windChargeRadius, -windChargeRadius, -windChargeRadius, windChargeRadius, windChargeRadius, windChargeRadius).Translate(pos)
+	for other := range tx.EntitiesWithin(box) {
+		l, ok := other.(Living)
+		if _, breeze := other.(*Breeze); !ok || breeze {
+			continue
+		}
+		if dist := other.Position().Sub(pos).Len(); dist <= windChargeRadius {
+			impact := 1 - dist/windChargeRadius
+			l.KnockBack(pos, 0.8*impact, 0.3+0.4*impact)
+		}
+	}
+	centre := cube.PosFromVec3(pos)
+	r := int(windChargeRadius)
+	for x := -r; x <= r; x++ {
+		for y := -r; y <= r; y++ {
+			for z := -r; z <= r; z++ {
+				bpos := centre.Add(cube.Pos{x, y, z})
+				if bpos.Vec3Centre().Sub(pos).Len() > windChargeRadius {
+					continue
+				}
+				if a, ok := tx.Block(bpos).(block.WindChargeAffected); ok {
+					a.WindChargeBurst(bpos, tx)
+				}
+			}
+		}
+	}
+}
+
+// BreezeWindChargeType is a world.EntityType implementation for wind charges
+// shot by breezes.
+var BreezeWindChargeType breezeWindChargeType
+
+type breezeWindChargeType struct{}
+
+func (breezeWindChargeType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (breezeWindChargeType) EncodeEntity() string {
+	return "minecraft:breeze_wind_charge_projectile"
+}
+func (breezeWindChargeType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.15625, 0, -0.15625, 0.15625, 0.3125, 0.15625)
+}
+
+func (breezeWindChargeType) DecodeNBT(_ map[string]any, data *world.EntityData) {
+	data.Data = breezeWindChargeConf.New()
+}
+func (breezeWindChargeType) EncodeNBT(*world.EntityData) map[string]any { return nil }
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewWitch creates a witch.
+func NewWitch(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(WitchType, witchConf)
+}
+
+var witchConf = WitchBehaviourConfig{
+	Health:        26,
+	ThrowCooldown: 60,
+}
+
+// Witch is a hostile mob that throws splash potions at players. Witches take
+// part in raids, but are never the captain of a wave. Witch implements the
+// Raider interface.
+type Witch struct {
+	*Ent
+	mobEntity
+}
+
+// behaviour returns the WitchBehaviour of the Witch.
+func (w *Witch) behaviour() *WitchBehaviour {
+	return w.data.Data.(*WitchBehaviour)
+}
+
+// newWitch returns a Witch that wraps the *Ent passed.
+func newWitch(e *Ent) *Witch {
+	w := &Witch{Ent: e}
+	w.mobEntity = mobEntity{e: e, l: w}
+	return w
+}
+
+// raider returns the raid state of the Witch.
+func (w *Witch) raider() *raider {
+	return &w.behaviour().raider
+}
+
+// Raid returns the Raid that the Witch takes part in, if any.
+func (w *Witch) Raid() (*Raid, bool) {
+	return w.behaviour().Raid()
+}
+
+// Captain always returns false, as witches are never captains.
+func (w *Witch) Captain() bool {
+	return false
+}
+
+// BellRung reveals the Witch if it takes part in a raid.
+func (w *Witch) BellRung(cube.Pos, *world.Tx) {
+	w.behaviour().reveal()
+}
+
+// afterHurt makes the Witch target the entity that hurt it.
+func (w *Witch) afterHurt(_ float64, src world.DamageSource) {
+	w.behaviour().retaliate(src)
+}
+
+// kill handles the death of the witch as a raider and drops its loot and
+// experience.
+func (w *Witch) kill(src world.DamageSource) {
+	w.behaviour().die(w, src, w.tx)
+	dropLoot(w, src, w.tx)
+	dropExperience(w.Position(), 5, w.tx)
+}
+
+// WitchType is a world.EntityType implementation for Witch.
+var WitchType witchType
+
+type witchType struct{}
+
+func (witchType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return newWitch(&Ent{tx: tx, handle: handle, data: data})
+}
+
+func (witchType) EncodeEntity() string { return "minecraft:witch" }
+func (witchType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.95, 0.3)
+}
+
+func (witchType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := witchConf
+	if health := nbtconv.Float32(m, "Health"); health > 0 {
+		conf.Health = float64(health)
+	}
+	data.Data = conf.New()
+}
+
+func (witchType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*WitchBehaviour)
+	return map[string]any{"Health": float32(b.health.Health())}
+}
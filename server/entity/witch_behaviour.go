@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// witchThrowRange is the distance from its target at which a witch stops
+// walking towards it and starts throwing potions.
+const witchThrowRange = 8.0
+
+// WitchBehaviourConfig holds optional parameters for a WitchBehaviour.
+type WitchBehaviourConfig struct {
+	// Health is the health that the witch has when it is created.
+	Health float64
+	// ThrowCooldown is the number of ticks between two potions thrown by the
+	// witch.
+	ThrowCooldown int
+}
+
+func (conf WitchBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a WitchBehaviour using the parameters in conf.
+func (conf WitchBehaviourConfig) New() *WitchBehaviour {
+	return &WitchBehaviour{
+		conf:          conf,
+		mc:            &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		mob:           newMob(conf.Health, 0.15),
+		throwCooldown: conf.ThrowCooldown,
+	}
+}
+
+// WitchBehaviour implements the behaviour of a Witch. A witch wanders
+// around, or walks towards the centre of its raid, until it finds a player to
+// target, after which it approaches the player and throws splash potions at
+// it.
+type WitchBehaviour struct {
+	raider
+
+	conf WitchBehaviourConfig
+	mc   *MovementComputer
+	mob
+
+	throwCooldown int
+}
+
+// Tick makes the witch move around or approach and throw potions at its
+// target.
+func (b *WitchBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	w := newWitch(e)
+	if !w.tickMob(tx) {
+		return nil
+	}
+	b.tickRaider(w, tx)
+
+	pos, vel, rot := e.Position(), e.Velocity(), e.Rotation()
+	target, ok := b.findTarget(w, tx, false)
+	if ok {
+		if b.throwCooldown--; b.throwCooldown <= 0 {
+			b.throwCooldown = b.conf.ThrowCooldown
+			b.throw(w, target, tx)
+		}
+	} else {
+		target = nil
+	}
+	vel, rot = b.move(b.mc, pos, vel, rot, b.speed, witchThrowRange, target)
+
+	m := b.mc.TickMovement(e, pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
+
+// throw makes the witch throw a splash potion at its target. Targets far
+// away are slowed down, while targets close by are poisoned or weakened if
+// they are not already. Otherwise, the witch throws a potion of harming.
+func (b *WitchBehaviour) throw(w *Witch, target Living, tx *world.Tx) {
+	pos := w.Position().Add(mgl64.Vec3{0, 1.5})
+	dir := target.Position().Add(mgl64.Vec3{0, target.H().Type().BBox(target).Height() / 2}).Sub(pos)
+	dist := dir.Len()
+	if dist > raiderRange {
+		return
+	}
+	dir[1] += math.Hypot(dir[0], dir[2]) * 0.2
+	if dir.Len() < mgl64.Epsilon {
+		return
+	}
+	dir = dir.Normalize()
+
+	t := potion.Harming()
+	switch {
+	case dist >= 8 && !witchAffected(target, effect.Slowness):
+		t = potion.Slowness()
+	case target.Health() >= 8 && !witchAffected(target, effect.Poison):
+		t = potion.Poison()
+	case dist <= 3 && !witchAffected(target, effect.Weakness) && rand.Float64() < 0.25:
+		t = potion.Weakness()
+	}
+	opts := world.EntitySpawnOpts{
+		Position: pos.Add(dir.Mul(0.5)),
+		Velocity: dir.Mul(0.75),
+		Rotation: w.Rotation(),
+	}
+	tx.AddEntity(NewSplashPotion(opts, t, w))
+	tx.PlaySound(pos, sound.ItemThrow{})
+}
+
+// witchAffected checks if the Living entity passed has an effect of the
+// effect.Type passed.
+func witchAffected(l Living, t effect.Type) bool {
+	_, ok := livingEffect(l, t)
+	return ok
+}
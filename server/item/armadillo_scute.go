@@ -0,0 +1,10 @@
+package item
+
+// ArmadilloScute is an item that armadillos shed periodically or drop when
+// brushed. It is used to craft and repair wolf armour.
+type ArmadilloScute struct{}
+
+// EncodeItem ...
+func (ArmadilloScute) EncodeItem() (name string, meta int16) {
+	return "minecraft:armadillo_scute", 0
+}
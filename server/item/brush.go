@@ -0,0 +1,47 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Brush is a tool used to brush suspicious blocks, uncovering the items buried inside of them.
+type Brush struct{}
+
+// UseOnBlock ...
+func (b Brush) UseOnBlock(pos cube.Pos, _ cube.Face, _ mgl64.Vec3, tx *world.Tx, user User, ctx *UseContext) bool {
+	if br, ok := tx.Block(pos).(brushable); ok {
+		if br.Brush(pos, tx, user) {
+			// The brush only takes damage once the item inside the block is uncovered.
+			ctx.DamageItem(1)
+		}
+		return true
+	}
+	return false
+}
+
+// brushable represents a block that may be brushed by using a brush on it.
+type brushable interface {
+	// Brush brushes the block at the position passed, advancing the progress of uncovering the item inside of
+	// it. The User passed is the user brushing the block. Brush returns true if the item was uncovered.
+	Brush(pos cube.Pos, tx *world.Tx, user User) bool
+}
+
+// DurabilityInfo ...
+func (b Brush) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability: 64,
+		BrokenItem:    simpleItem(Stack{}),
+	}
+}
+
+// MaxCount ...
+func (b Brush) MaxCount() int {
+	return 1
+}
+
+// EncodeItem ...
+func (b Brush) EncodeItem() (name string, meta int16) {
+	return "minecraft:brush", 0
+}
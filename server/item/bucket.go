@@ -5,6 +5,8 @@ import (
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
+	"maps"
+	"strings"
 	"time"
 )
 
@@ -12,6 +14,9 @@ import (
 type BucketContent struct {
 	liquid world.Liquid
 	milk   bool
+
+	entity     string
+	entityData map[string]any
 }
 
 // LiquidBucketContent returns a new BucketContent with the liquid passed in.
@@ -24,15 +29,31 @@ func MilkBucketContent() BucketContent {
 	return BucketContent{milk: true}
 }
 
+// EntityBucketContent returns a new BucketContent with the liquid passed in
+// and an entity, such as an axolotl, captured in it. entity is the save ID of
+// the entity and data is the NBT data that the entity is recreated from when
+// the bucket is emptied.
+func EntityBucketContent(l world.Liquid, entity string, data map[string]any) BucketContent {
+	return BucketContent{liquid: l, entity: entity, entityData: data}
+}
+
 // Liquid returns the world.Liquid that a Bucket with this BucketContent places.
 // If this BucketContent does not place a liquid block, false is returned.
 func (b BucketContent) Liquid() (world.Liquid, bool) {
 	return b.liquid, b.liquid != nil
 }
 
+// Entity returns the save ID and NBT data of the entity captured in a Bucket
+// with this BucketContent. If no entity is captured, false is returned.
+func (b BucketContent) Entity() (string, map[string]any, bool) {
+	return b.entity, b.entityData, b.entity != ""
+}
+
 // String converts the BucketContent to a string.
 func (b BucketContent) String() string {
-	if b.milk {
+	if b.entity != "" {
+		return strings.TrimPrefix(b.entity, "minecraft:")
+	} else if b.milk {
 		return "milk"
 	} else if b.liquid != nil {
 		return b.liquid.LiquidType()
@@ -116,12 +137,29 @@ func (b Bucket) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world
 	}
 
 	tx.PlaySound(pos.Vec3Centre(), sound.BucketEmpty{Liquid: b.Content.liquid})
+	if _, ok := tx.Liquid(pos); !ok {
+		pos = pos.Side(face)
+	}
+	b.releaseEntity(pos, tx)
 	ctx.NewItem = NewStack(Bucket{}, 1)
 	ctx.NewItemSurvivalOnly = true
 	ctx.SubtractFromCount(1)
 	return true
 }
 
+// releaseEntity adds the entity captured in the bucket, if any, to the world
+// at the position passed.
+func (b Bucket) releaseEntity(pos cube.Pos, tx *world.Tx) {
+	name, data, ok := b.Content.Entity()
+	if !ok {
+		return
+	}
+	if t, ok := tx.World().EntityRegistry().Lookup(name); ok {
+		opts := world.EntitySpawnOpts{Position: pos.Vec3Middle()}
+		tx.AddEntity(opts.NewFromNBT(t, maps.Clone(data)))
+	}
+}
+
 // fillFrom fills a bucket from the liquid at the position passed in the world. If there is no liquid or if
 // the liquid is no source, fillFrom returns false.
 func (b Bucket) fillFrom(pos cube.Pos, tx *world.Tx, ctx *UseContext) bool {
@@ -142,6 +180,22 @@ func (b Bucket) fillFrom(pos cube.Pos, tx *world.Tx, ctx *UseContext) bool {
 	return true
 }
 
+// DecodeNBT ...
+func (b Bucket) DecodeNBT(data map[string]any) any {
+	if b.Content.entity != "" {
+		b.Content.entityData, _ = data["BucketEntity"].(map[string]any)
+	}
+	return b
+}
+
+// EncodeNBT ...
+func (b Bucket) EncodeNBT() map[string]any {
+	if b.Content.entityData != nil {
+		return map[string]any{"BucketEntity": b.Content.entityData}
+	}
+	return nil
+}
+
 // EncodeItem ...
 func (b Bucket) EncodeItem() (name string, meta int16) {
 	if !b.Empty() {
@@ -0,0 +1,81 @@
+package item
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// ChorusFruit is a food item that grows on chorus plants in the End. Eating it teleports the consumer to a random
+// safe location nearby.
+type ChorusFruit struct{}
+
+// AlwaysConsumable ...
+func (ChorusFruit) AlwaysConsumable() bool {
+	return true
+}
+
+// ConsumeDuration ...
+func (ChorusFruit) ConsumeDuration() time.Duration {
+	return DefaultConsumeDuration
+}
+
+// Consume ...
+func (f ChorusFruit) Consume(tx *world.Tx, c Consumer) Stack {
+	c.Saturate(4, 2.4)
+	if t, ok := c.(interface{ Teleport(pos mgl64.Vec3) }); ok {
+		if pos, ok := chorusFruitDestination(tx, c.Position()); ok {
+			tx.PlaySound(c.Position(), sound.Teleport{})
+			t.Teleport(pos)
+			tx.PlaySound(pos, sound.Teleport{})
+		}
+	}
+	if cd, ok := c.(interface {
+		SetCooldown(it world.Item, cooldown time.Duration)
+	}); ok {
+		cd.SetCooldown(f, time.Second)
+	}
+	return Stack{}
+}
+
+// chorusFruitDestination attempts to find a safe position within 8 blocks of the position passed to teleport to. A
+// position is safe if the block below it is solid and there are two blocks of free space above it without any
+// liquid. If no safe position was found after 16 attempts, false is returned.
+func chorusFruitDestination(tx *world.Tx, from mgl64.Vec3) (mgl64.Vec3, bool) {
+	r := tx.Range()
+	for range 16 {
+		pos := cube.PosFromVec3(from).Add(cube.Pos{rand.IntN(17) - 8, rand.IntN(17) - 8, rand.IntN(17) - 8})
+		pos[1] = min(max(pos[1], r[0]+1), r[1]-1)
+		for pos[1] > r[0]+1 && !tx.Block(pos.Side(cube.FaceDown)).Model().FaceSolid(pos.Side(cube.FaceDown), cube.FaceUp, tx) {
+			pos = pos.Side(cube.FaceDown)
+		}
+		if chorusFruitPassable(tx, pos) && chorusFruitPassable(tx, pos.Side(cube.FaceUp)) &&
+			tx.Block(pos.Side(cube.FaceDown)).Model().FaceSolid(pos.Side(cube.FaceDown), cube.FaceUp, tx) {
+			return pos.Vec3Middle(), true
+		}
+	}
+	return mgl64.Vec3{}, false
+}
+
+// chorusFruitPassable checks if an entity could stand in the block at the position passed without colliding with it
+// or being inside a liquid.
+func chorusFruitPassable(tx *world.Tx, pos cube.Pos) bool {
+	if _, ok := tx.Liquid(pos); ok {
+		return false
+	}
+	return len(tx.Block(pos).Model().BBox(pos, tx)) == 0
+}
+
+// SmeltInfo ...
+func (ChorusFruit) SmeltInfo() SmeltInfo {
+	return newSmeltInfo(NewStack(PoppedChorusFruit{}, 1), 0.1)
+}
+
+// EncodeItem ...
+func (ChorusFruit) EncodeItem() (name string, meta int16) {
+	return "minecraft:chorus_fruit", 0
+}
@@ -0,0 +1,37 @@
+package item
+
+// DebugStick is an operator tool used to cycle through the states of blocks,
+// such as the height of snow layers or the age of crops. Using it on a block
+// changes the value of the selected property of the block, while using it
+// when sneaking selects the next property instead. The DebugStick only works
+// for operators in creative mode and is not available in the creative
+// inventory. It looks like a normal Stick to clients.
+type DebugStick struct {
+	// Property is the name of the block property that is currently selected.
+	// If a block does not have this property, its first property is used.
+	Property string
+}
+
+// MaxCount ...
+func (DebugStick) MaxCount() int {
+	return 1
+}
+
+// EncodeNBT ...
+func (d DebugStick) EncodeNBT() map[string]any {
+	if d.Property == "" {
+		return nil
+	}
+	return map[string]any{"DebugProperty": d.Property}
+}
+
+// DecodeNBT ...
+func (d DebugStick) DecodeNBT(data map[string]any) any {
+	d.Property, _ = data["DebugProperty"].(string)
+	return d
+}
+
+// EncodeItem ...
+func (DebugStick) EncodeItem() (name string, meta int16) {
+	return "minecraft:stick", 1
+}
@@ -4,7 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/world"
 	"maps"
 	"slices"
-	"sort"
+	"strings"
 )
 
 // Enchantment is an enchantment that can be applied to a Stack. It holds an EnchantmentType and level that influences
@@ -61,17 +61,61 @@ type Enchantable interface {
 }
 
 // RegisterEnchantment registers an enchantment with the ID passed. Once registered, enchantments may be received
-// by instantiating an EnchantmentType struct (e.g. enchantment.Protection{})
+// by instantiating an EnchantmentType struct (e.g. enchantment.Protection{}). The enchantment is also registered
+// under a name derived from its Name, such as "fire_aspect" for "Fire Aspect", by which it may be looked up using
+// EnchantmentByName. If another enchantment was already registered with the ID, it is replaced, along with all names
+// it was registered under.
 func RegisterEnchantment(id int, enchantment EnchantmentType) {
+	if old, ok := enchantmentsMap[id]; ok {
+		delete(enchantmentIDs, old)
+		delete(enchantmentNames, old)
+		maps.DeleteFunc(enchantmentsByName, func(_ string, e EnchantmentType) bool { return e == old })
+		enchantmentList = slices.DeleteFunc(enchantmentList, func(e EnchantmentType) bool { return e == old })
+	}
 	enchantmentsMap[id] = enchantment
 	enchantmentIDs[enchantment] = id
+	i, _ := slices.BinarySearchFunc(enchantmentList, id, func(e EnchantmentType, id int) int {
+		return enchantmentIDs[e] - id
+	})
+	enchantmentList = slices.Insert(enchantmentList, i, enchantment)
+	RegisterEnchantmentName(strings.ReplaceAll(strings.ToLower(enchantment.Name()), " ", "_"), enchantment)
+}
+
+// RegisterEnchantmentName registers an additional name for an enchantment, such as "vanishing_curse" for the
+// Curse of Vanishing, by which it may be looked up using EnchantmentByName. The first name registered for an
+// enchantment is the name returned by EnchantmentName.
+func RegisterEnchantmentName(name string, enchantment EnchantmentType) {
+	name = strings.ToLower(strings.TrimPrefix(name, "minecraft:"))
+	enchantmentsByName[name] = enchantment
+	if _, ok := enchantmentNames[enchantment]; !ok {
+		enchantmentNames[enchantment] = name
+	}
 }
 
 var (
-	enchantmentsMap = map[int]EnchantmentType{}
-	enchantmentIDs  = map[EnchantmentType]int{}
+	enchantmentsMap    = map[int]EnchantmentType{}
+	enchantmentIDs     = map[EnchantmentType]int{}
+	enchantmentsByName = map[string]EnchantmentType{}
+	enchantmentNames   = map[EnchantmentType]string{}
+	// enchantmentList holds all registered enchantments sorted by their IDs,
+	// so that Enchantments does not need to sort them on every call.
+	enchantmentList []EnchantmentType
 )
 
+// EnchantmentByName attempts to return an enchantment by a name it was registered with, such as "sharpness" or
+// "minecraft:fire_aspect". If found, the enchantment found is returned and the bool true.
+func EnchantmentByName(name string) (EnchantmentType, bool) {
+	e, ok := enchantmentsByName[strings.ToLower(strings.TrimPrefix(name, "minecraft:"))]
+	return e, ok
+}
+
+// EnchantmentName attempts to return the name the enchantment was first registered with, such as "fire_aspect".
+// If found, the name is returned and the bool true.
+func EnchantmentName(e EnchantmentType) (string, bool) {
+	name, ok := enchantmentNames[e]
+	return name, ok
+}
+
 // EnchantmentByID attempts to return an enchantment by the ID it was registered with. If found, the enchantment found
 // is returned and the bool true.
 func EnchantmentByID(id int) (EnchantmentType, bool) {
@@ -88,11 +132,5 @@ func EnchantmentID(e EnchantmentType) (int, bool) {
 
 // Enchantments returns a slice of all registered enchantments.
 func Enchantments() []EnchantmentType {
-	e := slices.Collect(maps.Values(enchantmentsMap))
-	sort.Slice(e, func(i, j int) bool {
-		id1, _ := EnchantmentID(e[i])
-		id2, _ := EnchantmentID(e[j])
-		return id1 < id2
-	})
-	return e
+	return slices.Clone(enchantmentList)
 }
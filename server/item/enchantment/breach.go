@@ -0,0 +1,50 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Breach is an enchantment applied to a mace that reduces the effectiveness of
+// the armour worn by the entity attacked.
+var Breach breach
+
+type breach struct{}
+
+// Name ...
+func (breach) Name() string {
+	return "Breach"
+}
+
+// MaxLevel ...
+func (breach) MaxLevel() int {
+	return 4
+}
+
+// Cost ...
+func (breach) Cost(level int) (int, int) {
+	minCost := 15 + (level-1)*9
+	return minCost, minCost + 50
+}
+
+// Rarity ...
+func (breach) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityRare
+}
+
+// Penetration returns the fraction of armour effectiveness that is ignored by
+// attacks with breach.
+func (breach) Penetration(level int) float64 {
+	return min(float64(level)*0.15, 1)
+}
+
+// CompatibleWithEnchantment ...
+func (breach) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	return t != Density
+}
+
+// CompatibleWithItem ...
+func (breach) CompatibleWithItem(i world.Item) bool {
+	_, ok := i.(item.Mace)
+	return ok
+}
@@ -17,6 +17,16 @@ type AffectedDamageSource interface {
 	AffectedByEnchantment(e item.EnchantmentType) bool
 }
 
+// ArmourPenetratingDamageSource represents a world.DamageSource that ignores
+// part of the armour of the entity it damages, for example an attack with a
+// mace enchanted with Breach.
+type ArmourPenetratingDamageSource interface {
+	world.DamageSource
+	// ArmourPenetration returns the fraction of armour effectiveness, in a
+	// range of [0, 1], that is ignored by the world.DamageSource.
+	ArmourPenetration() float64
+}
+
 // DamageModifier is an item.EnchantmentType that can reduce damage through a
 // modifier if an AffectedDamageSource returns true for it.
 type DamageModifier interface {
@@ -0,0 +1,50 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Density is an enchantment applied to a mace that increases the damage dealt
+// by smash attacks for every block fallen.
+var Density density
+
+type density struct{}
+
+// Name ...
+func (density) Name() string {
+	return "Density"
+}
+
+// MaxLevel ...
+func (density) MaxLevel() int {
+	return 5
+}
+
+// Cost ...
+func (density) Cost(level int) (int, int) {
+	minCost := 5 + (level-1)*8
+	return minCost, minCost + 20
+}
+
+// Rarity ...
+func (density) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityUncommon
+}
+
+// Addend returns the additional damage dealt by a smash attack after falling
+// for the distance passed.
+func (density) Addend(level int, fallDistance float64) float64 {
+	return float64(level) * 0.5 * fallDistance
+}
+
+// CompatibleWithEnchantment ...
+func (density) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	return t != Breach
+}
+
+// CompatibleWithItem ...
+func (density) CompatibleWithItem(i world.Item) bool {
+	_, ok := i.(item.Mace)
+	return ok
+}
@@ -32,9 +32,8 @@ func (depthStrider) Rarity() item.EnchantmentRarity {
 }
 
 // CompatibleWithEnchantment ...
-func (depthStrider) CompatibleWithEnchantment(item.EnchantmentType) bool {
-	// TODO: Frost Walker
-	return true
+func (depthStrider) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	return t != FrostWalker
 }
 
 // CompatibleWithItem ...
@@ -44,6 +44,9 @@ func (fireAspect) CompatibleWithEnchantment(item.EnchantmentType) bool {
 
 // CompatibleWithItem ...
 func (fireAspect) CompatibleWithItem(i world.Item) bool {
+	if _, ok := i.(item.Mace); ok {
+		return true
+	}
 	t, ok := i.(item.Tool)
 	return ok && t.ToolType() == item.TypeSword
 }
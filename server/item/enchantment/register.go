@@ -17,7 +17,7 @@ func init() {
 	// TODO: (11) Bane of Arthropods. (Requires arthropod mobs)
 	item.RegisterEnchantment(12, Knockback)
 	item.RegisterEnchantment(13, FireAspect)
-	// TODO: (14) Looting.
+	item.RegisterEnchantment(14, Looting)
 	item.RegisterEnchantment(15, Efficiency)
 	item.RegisterEnchantment(16, SilkTouch)
 	item.RegisterEnchantment(17, Unbreaking)
@@ -26,9 +26,9 @@ func init() {
 	item.RegisterEnchantment(20, Punch)
 	item.RegisterEnchantment(21, Flame)
 	item.RegisterEnchantment(22, Infinity)
-	// TODO: (23) Luck of the Sea.
-	// TODO: (24) Lure.
-	// TODO: (25) Frost Walker.
+	item.RegisterEnchantment(23, LuckOfTheSea)
+	item.RegisterEnchantment(24, Lure)
+	item.RegisterEnchantment(25, FrostWalker)
 	item.RegisterEnchantment(26, Mending)
 	// TODO: (27) Curse of Binding.
 	item.RegisterEnchantment(28, CurseOfVanishing)
@@ -41,4 +41,10 @@ func init() {
 	item.RegisterEnchantment(35, QuickCharge)
 	item.RegisterEnchantment(36, SoulSpeed)
 	item.RegisterEnchantment(37, SwiftSneak)
+	item.RegisterEnchantment(38, WindBurst)
+	item.RegisterEnchantment(39, Density)
+	item.RegisterEnchantment(40, Breach)
+
+	// Java Edition names of enchantments that differ from their Bedrock Edition names.
+	item.RegisterEnchantmentName("vanishing_curse", CurseOfVanishing)
 }
@@ -0,0 +1,51 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// WindBurst is an enchantment applied to a mace that launches the attacker
+// upwards after landing a smash attack, allowing for consecutive smashes.
+var WindBurst windBurst
+
+type windBurst struct{}
+
+// Name ...
+func (windBurst) Name() string {
+	return "Wind Burst"
+}
+
+// MaxLevel ...
+func (windBurst) MaxLevel() int {
+	return 3
+}
+
+// Cost ...
+func (windBurst) Cost(level int) (int, int) {
+	minCost := 15 + (level-1)*9
+	return minCost, minCost + 50
+}
+
+// Rarity ...
+func (windBurst) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityRare
+}
+
+// Velocity returns the upwards velocity the attacker is launched with after a
+// smash attack. Level 1 launches the attacker roughly 7 blocks into the air,
+// with every additional level adding about one block.
+func (windBurst) Velocity(level int) float64 {
+	return 1 + float64(level)*0.1
+}
+
+// CompatibleWithEnchantment ...
+func (windBurst) CompatibleWithEnchantment(item.EnchantmentType) bool {
+	return true
+}
+
+// CompatibleWithItem ...
+func (windBurst) CompatibleWithItem(i world.Item) bool {
+	_, ok := i.(item.Mace)
+	return ok
+}
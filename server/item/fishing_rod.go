@@ -0,0 +1,67 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// FishingRod is a tool used to catch fish, junk and treasure from water. Using
+// it casts a fishing hook, and using it again reels the hook back in.
+type FishingRod struct{}
+
+// fishingHook is a fishing hook cast by a FishingRod.
+type fishingHook interface {
+	world.Entity
+	// Owner returns the entity that cast the hook.
+	Owner() *world.EntityHandle
+	// Reel reels in the hook and returns the damage dealt to the FishingRod.
+	Reel() int
+}
+
+// MaxCount always returns 1.
+func (FishingRod) MaxCount() int {
+	return 1
+}
+
+// DurabilityInfo ...
+func (FishingRod) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability: 384,
+		BrokenItem:    simpleItem(Stack{}),
+	}
+}
+
+// EnchantmentValue ...
+func (FishingRod) EnchantmentValue() int {
+	return 1
+}
+
+// Use casts a fishing hook or reels in the hook that the user already cast.
+func (FishingRod) Use(tx *world.Tx, user User, ctx *UseContext) bool {
+	if hook, ok := castHook(tx, user); ok {
+		ctx.DamageItem(hook.Reel())
+		return true
+	}
+	rod, _ := user.HeldItems()
+	create := tx.World().EntityRegistry().Config().FishingHook
+	opts := world.EntitySpawnOpts{Position: eyePosition(user), Velocity: user.Rotation().Vec3().Mul(1.1)}
+	tx.AddEntity(create(opts, user, rod))
+	tx.PlaySound(user.Position(), sound.ItemThrow{})
+	return true
+}
+
+// castHook returns the fishing hook cast by the user passed, if it has one.
+func castHook(tx *world.Tx, user User) (fishingHook, bool) {
+	for e := range tx.EntitiesWithin(cube.Box(-33, -33, -33, 33, 33, 33).Translate(user.Position())) {
+		if hook, ok := e.(fishingHook); ok && hook.Owner() == user.H() {
+			return hook, true
+		}
+	}
+	return nil, false
+}
+
+// EncodeItem ...
+func (FishingRod) EncodeItem() (name string, meta int16) {
+	return "minecraft:fishing_rod", 0
+}
@@ -0,0 +1,36 @@
+package item
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// HoneyBottle is a drinkable item obtained by using a glass bottle on a full bee nest. Drinking it restores hunger and
+// cures poison, without removing any other effects.
+type HoneyBottle struct {
+	defaultFood
+}
+
+// MaxCount ...
+func (HoneyBottle) MaxCount() int {
+	return 16
+}
+
+// ConsumeDuration ...
+func (HoneyBottle) ConsumeDuration() time.Duration {
+	return time.Second * 2
+}
+
+// Consume ...
+func (HoneyBottle) Consume(_ *world.Tx, c Consumer) Stack {
+	c.Saturate(6, 1.2)
+	c.RemoveEffect(effect.Poison)
+	return NewStack(GlassBottle{}, 1)
+}
+
+// EncodeItem ...
+func (HoneyBottle) EncodeItem() (name string, meta int16) {
+	return "minecraft:honey_bottle", 0
+}
@@ -3,6 +3,7 @@ package item
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
 )
 
@@ -19,6 +20,7 @@ func (i InkSac) UseOnBlock(pos cube.Pos, _ cube.Face, _ mgl64.Vec3, tx *world.Tx
 	if in, ok := tx.Block(pos).(inkable); ok {
 		if res, ok := in.Ink(pos, user.Position(), i.Glowing); ok {
 			tx.SetBlock(pos, res, nil)
+			tx.PlaySound(pos.Vec3Centre(), sound.SignInked{Glowing: i.Glowing})
 			ctx.SubtractFromCount(1)
 			return true
 		}
@@ -128,7 +128,13 @@ func (a *Armour) DamageReduction(dmg float64, src world.DamageSource) float64 {
 		// armour point decreases as damage increases, with 1 point lost for every 2 HP of damage. The defense
 		// reduction is decreased by the toughness armor value. Effective armour points will at minimum be 20% of
 		// armour points.
-		dmg -= dmg * 0.04 * math.Max(defencePoints*0.2, defencePoints-dmg/(2+toughness/4))
+		// Armour penetration, for example from the Breach enchantment, reduces the effectiveness of the
+		// armour as a whole.
+		effectiveness := 1.0
+		if psrc, ok := src.(enchantment.ArmourPenetratingDamageSource); ok {
+			effectiveness -= math.Max(math.Min(psrc.ArmourPenetration(), 1), 0)
+		}
+		dmg -= dmg * 0.04 * math.Max(defencePoints*0.2, defencePoints-dmg/(2+toughness/4)) * effectiveness
 	}
 	return original - dmg
 }
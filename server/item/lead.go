@@ -0,0 +1,50 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Lead is an item used to leash entities, either to the player holding the
+// lead or to a fence.
+type Lead struct{}
+
+// Leashable represents an entity that may be leashed to another entity, such
+// as a player or a leash knot, using a Lead.
+type Leashable interface {
+	world.Entity
+	// Leash leashes the entity to the holder passed. False is returned if the
+	// entity cannot be leashed.
+	Leash(holder world.Entity) bool
+	// Unleash removes the leash of the entity, if it has one. If drop is
+	// true, a lead is dropped at the position of the entity.
+	Unleash(drop bool)
+	// LeashHolder returns the handle of the entity that holds the leash of
+	// the entity. False is returned if the entity is not leashed.
+	LeashHolder() (*world.EntityHandle, bool)
+}
+
+// UseOnEntity leashes the entity passed to the user. If the entity is already
+// leashed to the user, the leash is removed instead.
+func (Lead) UseOnEntity(e world.Entity, _ *world.Tx, user User, ctx *UseContext) bool {
+	l, ok := e.(Leashable)
+	if !ok {
+		return false
+	}
+	if holder, leashed := l.LeashHolder(); leashed {
+		if holder != user.H() {
+			return false
+		}
+		l.Unleash(true)
+		return true
+	}
+	if !l.Leash(user) {
+		return false
+	}
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (Lead) EncodeItem() (name string, meta int16) {
+	return "minecraft:lead", 0
+}
@@ -0,0 +1,65 @@
+package item
+
+// Mace is a heavy weapon that deals additional damage based on the distance
+// its wielder has fallen before landing a hit, known as a smash attack.
+type Mace struct{}
+
+// AttackDamage returns the base attack damage of the mace.
+func (Mace) AttackDamage() float64 {
+	return 6
+}
+
+// CanSmash checks if an attack with the mace after falling for the distance
+// passed results in a smash attack.
+func (Mace) CanSmash(fallDistance float64) bool {
+	return fallDistance > 1.5
+}
+
+// SmashDamage returns the additional damage dealt by a smash attack after
+// falling for the distance passed. The first three blocks fallen add 4 damage
+// each, the next five add 2 damage each and every block after that adds 1
+// damage.
+func (Mace) SmashDamage(fallDistance float64) float64 {
+	if fallDistance <= 1.5 {
+		return 0
+	}
+	dmg := 4 * min(fallDistance, 3)
+	if fallDistance > 3 {
+		dmg += 2 * min(fallDistance-3, 5)
+	}
+	if fallDistance > 8 {
+		dmg += fallDistance - 8
+	}
+	return dmg
+}
+
+// MaxCount always returns 1.
+func (Mace) MaxCount() int {
+	return 1
+}
+
+// EnchantmentValue ...
+func (Mace) EnchantmentValue() int {
+	return 15
+}
+
+// DurabilityInfo ...
+func (Mace) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability:    500,
+		BrokenItem:       simpleItem(Stack{}),
+		AttackDurability: 1,
+		BreakDurability:  2,
+	}
+}
+
+// RepairableBy ...
+func (Mace) RepairableBy(i Stack) bool {
+	_, ok := i.Item().(BreezeRod)
+	return ok
+}
+
+// EncodeItem ...
+func (Mace) EncodeItem() (name string, meta int16) {
+	return "minecraft:mace", 0
+}
@@ -0,0 +1,54 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// EmptyMap is an item that, when used, creates a FilledMap of the area around
+// the user.
+type EmptyMap struct{}
+
+// Use creates a new map centred around the position of the user and replaces
+// one empty map with a FilledMap displaying it.
+func (EmptyMap) Use(tx *world.Tx, user User, ctx *UseContext) bool {
+	id := tx.AddMap(world.NewMapData(cube.PosFromVec3(user.Position()), 0, tx.World().Dimension()))
+	ctx.SubtractFromCount(1)
+	ctx.NewItem = NewStack(FilledMap{ID: id}, 1)
+	return true
+}
+
+// EncodeItem ...
+func (EmptyMap) EncodeItem() (name string, meta int16) {
+	return "minecraft:empty_map", 0
+}
+
+// FilledMap is a map that displays an area of the world. The area is rendered
+// progressively as it is explored while holding the map, and the map may be
+// zoomed out, cloned or locked using a cartography table.
+type FilledMap struct {
+	// ID is the ID of the world.MapData displayed by the map.
+	ID int64
+}
+
+// Data returns the world.MapData displayed by the map. If the map has no data
+// in the world passed, false is returned.
+func (m FilledMap) Data(tx *world.Tx) (world.MapData, bool) {
+	return tx.Map(m.ID)
+}
+
+// EncodeNBT ...
+func (m FilledMap) EncodeNBT() map[string]any {
+	return map[string]any{"map_uuid": m.ID, "map_is_init": uint8(1)}
+}
+
+// DecodeNBT ...
+func (m FilledMap) DecodeNBT(data map[string]any) any {
+	m.ID, _ = data["map_uuid"].(int64)
+	return m
+}
+
+// EncodeItem ...
+func (FilledMap) EncodeItem() (name string, meta int16) {
+	return "minecraft:filled_map", 0
+}
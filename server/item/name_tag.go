@@ -0,0 +1,49 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NameTag is an item used to give entities a custom name. A name tag must be
+// renamed using an anvil before it can be used. Entities with a custom name
+// never despawn naturally. Entities named 'Dinnerbone' or 'Grumm' are rendered
+// upside down by the client.
+type NameTag struct{}
+
+// Nameable represents an entity that may be given a custom name using a
+// NameTag.
+type Nameable interface {
+	world.Entity
+	// NameTag returns the custom name of the entity, or an empty string if it
+	// has none.
+	NameTag() string
+	// SetNameTag sets the custom name of the entity.
+	SetNameTag(name string)
+}
+
+// UseOnEntity sets the custom name of the entity passed to the custom name of
+// the name tag. Only living entities may be renamed, with the exception of
+// players.
+func (NameTag) UseOnEntity(e world.Entity, _ *world.Tx, user User, ctx *UseContext) bool {
+	n, ok := e.(Nameable)
+	if !ok {
+		return false
+	}
+	_, living := e.(interface{ Health() float64 })
+	if _, player := e.(interface{ XUID() string }); player || !living {
+		return false
+	}
+	held, _ := user.HeldItems()
+	name := held.CustomName()
+	if name == "" || name == n.NameTag() {
+		return false
+	}
+	n.SetNameTag(name)
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (NameTag) EncodeItem() (name string, meta int16) {
+	return "minecraft:name_tag", 0
+}
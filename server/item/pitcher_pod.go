@@ -0,0 +1,9 @@
+package item
+
+// PitcherPod is an ancient seed that may be dug up by sniffers.
+type PitcherPod struct{}
+
+// EncodeItem ...
+func (PitcherPod) EncodeItem() (name string, meta int16) {
+	return "minecraft:pitcher_pod", 0
+}
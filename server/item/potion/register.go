@@ -0,0 +1,61 @@
+package potion
+
+import "strings"
+
+var (
+	// potionsByName holds the potions registered using Register, indexed by
+	// their names.
+	potionsByName = map[string]Potion{}
+	// potionNames holds the first name registered for every potion.
+	potionNames = map[Potion]string{}
+)
+
+func init() {
+	for name, p := range map[string]Potion{
+		"water": Water(), "mundane": Mundane(), "long_mundane": LongMundane(), "thick": Thick(),
+		"awkward": Awkward(), "night_vision": NightVision(), "long_night_vision": LongNightVision(),
+		"invisibility": Invisibility(), "long_invisibility": LongInvisibility(), "leaping": Leaping(),
+		"long_leaping": LongLeaping(), "strong_leaping": StrongLeaping(), "fire_resistance": FireResistance(),
+		"long_fire_resistance": LongFireResistance(), "swiftness": Swiftness(), "long_swiftness": LongSwiftness(),
+		"strong_swiftness": StrongSwiftness(), "slowness": Slowness(), "long_slowness": LongSlowness(),
+		"strong_slowness": StrongSlowness(), "water_breathing": WaterBreathing(),
+		"long_water_breathing": LongWaterBreathing(), "healing": Healing(), "strong_healing": StrongHealing(),
+		"harming": Harming(), "strong_harming": StrongHarming(), "poison": Poison(), "long_poison": LongPoison(),
+		"strong_poison": StrongPoison(), "regeneration": Regeneration(), "long_regeneration": LongRegeneration(),
+		"strong_regeneration": StrongRegeneration(), "strength": Strength(), "long_strength": LongStrength(),
+		"strong_strength": StrongStrength(), "weakness": Weakness(), "long_weakness": LongWeakness(),
+		"wither": Wither(), "turtle_master": TurtleMaster(), "long_turtle_master": LongTurtleMaster(),
+		"strong_turtle_master": StrongTurtleMaster(), "slow_falling": SlowFalling(),
+		"long_slow_falling": LongSlowFalling(),
+	} {
+		Register(name, p)
+	}
+}
+
+// Register registers a name for the Potion passed, such as "strong_healing",
+// by which it may be looked up using ByName. Potions may be registered under
+// more than one name, in which case the first name registered is returned by
+// Name. Register is not safe for concurrent use and should be called before
+// the server is started.
+func Register(name string, p Potion) {
+	name = strings.ToLower(strings.TrimPrefix(name, "minecraft:"))
+	potionsByName[name] = p
+	if _, ok := potionNames[p]; !ok {
+		potionNames[p] = name
+	}
+}
+
+// ByName returns the Potion registered with the name passed, such as
+// "strong_healing" or "minecraft:long_poison". False is returned if no potion
+// was registered with the name.
+func ByName(name string) (Potion, bool) {
+	p, ok := potionsByName[strings.ToLower(strings.TrimPrefix(name, "minecraft:"))]
+	return p, ok
+}
+
+// Name returns the name the Potion passed was first registered with. False is
+// returned if the potion was not registered.
+func Name(p Potion) (string, bool) {
+	name, ok := potionNames[p]
+	return name, ok
+}
@@ -118,3 +118,51 @@ func (r DecoratedPotRecipe) Match(input []Item) (output []item.Stack, ok bool) {
 func (r DecoratedPotRecipe) Block() string {
 	return r.block
 }
+
+// ShieldDecorationRecipe is a dynamic recipe for applying a banner to a shield. The output is the shield with the
+// base colour and patterns of the banner used.
+type ShieldDecorationRecipe struct {
+	block string
+}
+
+// NewShieldDecorationRecipe creates a new shield decoration recipe.
+func NewShieldDecorationRecipe() ShieldDecorationRecipe {
+	return ShieldDecorationRecipe{block: "crafting_table"}
+}
+
+// Match checks if the given input items match the shield decoration recipe. The recipe is shapeless and requires
+// exactly one undecorated shield and one banner in the crafting grid. All other slots must be empty.
+func (r ShieldDecorationRecipe) Match(input []Item) (output []item.Stack, ok bool) {
+	var (
+		shield      item.Stack
+		banner      world.Item
+		foundShield bool
+	)
+	for _, it := range input {
+		if it.Empty() {
+			continue
+		}
+		stack, ok := it.(item.Stack)
+		if !ok {
+			return nil, false
+		}
+		if s, ok := stack.Item().(item.Shield); ok && !foundShield && s.Banner == nil {
+			shield, foundShield = stack, true
+			continue
+		}
+		if name, _ := stack.Item().EncodeItem(); name == "minecraft:banner" && banner == nil {
+			banner = stack.Item()
+			continue
+		}
+		return nil, false
+	}
+	if !foundShield || banner == nil {
+		return nil, false
+	}
+	return []item.Stack{shield.WithItem(item.Shield{Banner: banner})}, true
+}
+
+// Block returns the block used to craft this recipe.
+func (r ShieldDecorationRecipe) Block() string {
+	return r.block
+}
@@ -0,0 +1,476 @@
+package recipe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// LoadDirectory registers the recipes in all JSON files in the directory
+// passed and its subdirectories, in addition to the vanilla recipes. Recipes
+// may be in either the Bedrock Edition behaviour pack format or the Java
+// Edition data pack format, as parsed by DecodeJSON. LoadDirectory must be
+// called after all items were registered, as recipes referencing unknown items
+// are rejected, and before players join the server, as recipes are only sent
+// to players when they join. LoadDirectory returns the number of recipes
+// registered and an error for every file that could not be loaded. A directory
+// that does not exist holds no recipes.
+func LoadDirectory(dir string) (int, []error) {
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	var n int
+	var errs []error
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".json" {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recipe %v: %w", path, err))
+			return nil
+		}
+		recipes, err := DecodeJSON(b)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recipe %v: %w", path, err))
+			return nil
+		}
+		for _, r := range recipes {
+			Register(r)
+		}
+		n += len(recipes)
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("load recipes: %w", err))
+	}
+	return n, errs
+}
+
+// DecodeJSON decodes a recipe from JSON data. The following recipe types are
+// supported:
+//
+//	Bedrock Edition: minecraft:recipe_shaped, minecraft:recipe_shapeless,
+//	minecraft:recipe_furnace, minecraft:recipe_smithing_transform and
+//	minecraft:recipe_smithing_trim
+//	Java Edition: minecraft:crafting_shaped, minecraft:crafting_shapeless,
+//	minecraft:smelting, minecraft:blasting, minecraft:smoking,
+//	minecraft:campfire_cooking, minecraft:stonecutting,
+//	minecraft:smithing_transform and minecraft:smithing_trim
+//
+// Bedrock Edition recipes produce one recipe for every block in their tags.
+// Stonecutter recipes are shapeless recipes with the stonecutter tag in
+// Bedrock Edition. Item names without a namespace are in the minecraft
+// namespace and tags may be written as "#minecraft:planks" or as an object
+// with a tag field. Ingredients without data match items with any metadata
+// value. Java Edition ingredients that accept one of several items are not
+// supported, as recipes cannot express them, and must be replaced with a tag.
+func DecodeJSON(b []byte) ([]Recipe, error) {
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	if _, ok := data["type"]; ok {
+		var r javaRecipe
+		if err := json.Unmarshal(b, &r); err != nil {
+			return nil, err
+		}
+		return r.recipes()
+	}
+	for k, raw := range data {
+		typ, ok := strings.CutPrefix(k, "minecraft:recipe_")
+		if !ok {
+			continue
+		}
+		var r bedrockRecipe
+		if err := json.Unmarshal(raw, &r); err != nil {
+			return nil, err
+		}
+		return r.recipes(typ)
+	}
+	return nil, fmt.Errorf("no recipe found")
+}
+
+// bedrockRecipe is the JSON layout of a recipe in a Bedrock Edition behaviour
+// pack. The fields present depend on the type of the recipe.
+type bedrockRecipe struct {
+	Tags        []string            `json:"tags"`
+	Priority    int32               `json:"priority"`
+	Pattern     []string            `json:"pattern"`
+	Key         map[string]jsonItem `json:"key"`
+	Ingredients []jsonItem          `json:"ingredients"`
+	Input       jsonItem            `json:"input"`
+	Output      jsonItem            `json:"output"`
+	Template    jsonItem            `json:"template"`
+	Base        jsonItem            `json:"base"`
+	Addition    jsonItem            `json:"addition"`
+	Result      jsonItems           `json:"result"`
+}
+
+// recipes returns the recipes of the type passed, one for every tag of the
+// bedrockRecipe.
+func (r bedrockRecipe) recipes(typ string) ([]Recipe, error) {
+	if len(r.Tags) == 0 {
+		return nil, fmt.Errorf("recipe has no tags")
+	}
+	build, err := r.builder(typ)
+	if err != nil {
+		return nil, err
+	}
+	recipes := make([]Recipe, 0, len(r.Tags))
+	for _, tag := range r.Tags {
+		recipes = append(recipes, build(tag))
+	}
+	return recipes, nil
+}
+
+// builder returns a function that creates the recipe of the type passed for
+// a block.
+func (r bedrockRecipe) builder(typ string) (func(block string) Recipe, error) {
+	switch typ {
+	case "shaped":
+		output, err := r.Result.outputs()
+		if err != nil {
+			return nil, err
+		}
+		input, shape, err := shapedInput(r.Pattern, r.Key)
+		if err != nil {
+			return nil, err
+		}
+		return func(block string) Recipe {
+			return Shaped{shape: shape, recipe: recipe{input: input, output: output, block: block, priority: uint32(r.Priority)}}
+		}, nil
+	case "shapeless":
+		output, err := r.Result.outputs()
+		if err != nil {
+			return nil, err
+		}
+		input, err := inputs(r.Ingredients)
+		if err != nil {
+			return nil, err
+		}
+		return func(block string) Recipe {
+			return Shapeless{recipe{input: input, output: output, block: block, priority: uint32(r.Priority)}}
+		}, nil
+	case "furnace":
+		input, err := r.Input.input()
+		if err != nil {
+			return nil, err
+		}
+		output, err := r.Output.output()
+		if err != nil {
+			return nil, err
+		}
+		return func(block string) Recipe {
+			return NewFurnace(input, output, block)
+		}, nil
+	case "smithing_transform":
+		input, err := inputs([]jsonItem{r.Base, r.Addition, r.Template})
+		if err != nil {
+			return nil, err
+		}
+		output, err := r.Result.outputs()
+		if err != nil {
+			return nil, err
+		}
+		return func(block string) Recipe {
+			return SmithingTransform{recipe{input: input, output: output, block: block, priority: uint32(r.Priority)}}
+		}, nil
+	case "smithing_trim":
+		input, err := inputs([]jsonItem{r.Base, r.Addition, r.Template})
+		if err != nil {
+			return nil, err
+		}
+		return func(block string) Recipe {
+			return SmithingTrim{recipe{input: input, block: block, priority: uint32(r.Priority)}}
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported recipe type minecraft:recipe_%v", typ)
+}
+
+// javaRecipe is the JSON layout of a recipe in a Java Edition data pack. The
+// fields present depend on the type of the recipe.
+type javaRecipe struct {
+	Type        string              `json:"type"`
+	Pattern     []string            `json:"pattern"`
+	Key         map[string]jsonItem `json:"key"`
+	Ingredients []jsonItem          `json:"ingredients"`
+	Ingredient  jsonItem            `json:"ingredient"`
+	Template    jsonItem            `json:"template"`
+	Base        jsonItem            `json:"base"`
+	Addition    jsonItem            `json:"addition"`
+	Result      jsonItems           `json:"result"`
+	// Count is the count of the result of stonecutting recipes in older
+	// versions, in which the result is only the name of an item.
+	Count int `json:"count"`
+}
+
+// javaCookingBlocks maps the Java Edition cooking recipe types to the blocks
+// that the recipes are registered for.
+var javaCookingBlocks = map[string][]string{
+	"smelting":         {"furnace"},
+	"blasting":         {"blast_furnace"},
+	"smoking":          {"smoker"},
+	"campfire_cooking": {"campfire", "soul_campfire"},
+}
+
+// recipes returns the recipes described by the javaRecipe.
+func (r javaRecipe) recipes() ([]Recipe, error) {
+	typ := strings.TrimPrefix(r.Type, "minecraft:")
+	switch typ {
+	case "crafting_shaped":
+		output, err := r.Result.outputs()
+		if err != nil {
+			return nil, err
+		}
+		input, shape, err := shapedInput(r.Pattern, r.Key)
+		if err != nil {
+			return nil, err
+		}
+		return []Recipe{NewShaped(input, output[0], shape, "crafting_table")}, nil
+	case "crafting_shapeless":
+		output, err := r.Result.outputs()
+		if err != nil {
+			return nil, err
+		}
+		input, err := inputs(r.Ingredients)
+		if err != nil {
+			return nil, err
+		}
+		return []Recipe{NewShapeless(input, output[0], "crafting_table")}, nil
+	case "smelting", "blasting", "smoking", "campfire_cooking":
+		input, err := r.Ingredient.input()
+		if err != nil {
+			return nil, err
+		}
+		output, err := r.Result.outputs()
+		if err != nil {
+			return nil, err
+		}
+		blocks := javaCookingBlocks[typ]
+		recipes := make([]Recipe, 0, len(blocks))
+		for _, block := range blocks {
+			recipes = append(recipes, NewFurnace(input, output[0], block))
+		}
+		return recipes, nil
+	case "stonecutting":
+		if len(r.Result) == 1 && r.Count > 0 {
+			r.Result[0].count = r.Count
+		}
+		output, err := r.Result.outputs()
+		if err != nil {
+			return nil, err
+		}
+		input, err := r.Ingredient.input()
+		if err != nil {
+			return nil, err
+		}
+		return []Recipe{NewShapeless([]Item{input}, output[0], "stonecutter")}, nil
+	case "smithing_transform":
+		input, err := inputs([]jsonItem{r.Base, r.Addition, r.Template})
+		if err != nil {
+			return nil, err
+		}
+		output, err := r.Result.outputs()
+		if err != nil {
+			return nil, err
+		}
+		return []Recipe{NewSmithingTransform(input[0], input[1], input[2], output[0], "smithing_table")}, nil
+	case "smithing_trim":
+		input, err := inputs([]jsonItem{r.Base, r.Addition, r.Template})
+		if err != nil {
+			return nil, err
+		}
+		return []Recipe{NewSmithingTrim(input[0], input[1], input[2], "smithing_table")}, nil
+	}
+	return nil, fmt.Errorf("unsupported recipe type %v", r.Type)
+}
+
+// shapedInput returns the input items and shape of a shaped recipe with the
+// pattern and key passed. Spaces in the pattern are empty slots.
+func shapedInput(pattern []string, key map[string]jsonItem) ([]Item, Shape, error) {
+	if len(pattern) == 0 || len(pattern) > 3 {
+		return nil, Shape{}, fmt.Errorf("pattern must have 1-3 rows, got %v", len(pattern))
+	}
+	width := 0
+	for _, row := range pattern {
+		width = max(width, len([]rune(row)))
+	}
+	if width == 0 || width > 3 {
+		return nil, Shape{}, fmt.Errorf("pattern must have 1-3 columns, got %v", width)
+	}
+	input := make([]Item, 0, width*len(pattern))
+	for _, row := range pattern {
+		runes := []rune(row)
+		for x := range width {
+			if x >= len(runes) || runes[x] == ' ' {
+				input = append(input, item.Stack{})
+				continue
+			}
+			k, ok := key[string(runes[x])]
+			if !ok {
+				return nil, Shape{}, fmt.Errorf("pattern key %q is not defined", runes[x])
+			}
+			it, err := k.input()
+			if err != nil {
+				return nil, Shape{}, err
+			}
+			input = append(input, it)
+		}
+	}
+	return input, NewShape(width, len(pattern)), nil
+}
+
+// inputs converts the items passed to recipe input items.
+func inputs(items []jsonItem) ([]Item, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("recipe has no ingredients")
+	}
+	input := make([]Item, 0, len(items))
+	for _, i := range items {
+		it, err := i.input()
+		if err != nil {
+			return nil, err
+		}
+		input = append(input, it)
+	}
+	return input, nil
+}
+
+// jsonItem is an item or tag in a JSON recipe. It may be decoded from the name
+// of an item, the name of a tag prefixed with '#' or an object with an item,
+// id or tag field and optional data and count fields.
+type jsonItem struct {
+	name, tag string
+	meta      int16
+	hasMeta   bool
+	count     int
+}
+
+// UnmarshalJSON ...
+func (i *jsonItem) UnmarshalJSON(b []byte) error {
+	*i = jsonItem{count: 1}
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		if tag, ok := strings.CutPrefix(s, "#"); ok {
+			i.tag = namespaced(tag)
+		} else {
+			i.name = namespaced(s)
+		}
+		return nil
+	}
+	var alternatives []jsonItem
+	if err := json.Unmarshal(b, &alternatives); err == nil {
+		if len(alternatives) != 1 {
+			return fmt.Errorf("ingredients with %v alternatives are not supported", len(alternatives))
+		}
+		*i = alternatives[0]
+		return nil
+	}
+	var v struct {
+		Item  string `json:"item"`
+		ID    string `json:"id"`
+		Tag   string `json:"tag"`
+		Data  *int16 `json:"data"`
+		Count *int   `json:"count"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch {
+	case v.Tag != "":
+		i.tag = namespaced(v.Tag)
+	case v.Item != "":
+		i.name = namespaced(v.Item)
+	case v.ID != "":
+		i.name = namespaced(v.ID)
+	default:
+		return fmt.Errorf("item has no item, id or tag")
+	}
+	if v.Data != nil {
+		i.meta, i.hasMeta = *v.Data, true
+	}
+	if v.Count != nil {
+		i.count = *v.Count
+	}
+	return nil
+}
+
+// input converts the jsonItem to a recipe input item.
+func (i jsonItem) input() (Item, error) {
+	if i.tag != "" {
+		return NewItemTag(i.tag, i.count), nil
+	}
+	it, ok := world.ItemByName(i.name, i.meta)
+	if !ok {
+		return nil, fmt.Errorf("unknown item %v:%v", i.name, i.meta)
+	}
+	st := item.NewStack(it, i.count)
+	if !i.hasMeta {
+		st = st.WithValue("variants", true)
+	}
+	return st, nil
+}
+
+// output converts the jsonItem to a recipe output item.
+func (i jsonItem) output() (item.Stack, error) {
+	if i.tag != "" {
+		return item.Stack{}, fmt.Errorf("recipe result may not be a tag")
+	}
+	it, ok := world.ItemByName(i.name, i.meta)
+	if !ok {
+		return item.Stack{}, fmt.Errorf("unknown item %v:%v", i.name, i.meta)
+	}
+	return item.NewStack(it, i.count), nil
+}
+
+// jsonItems is the result of a JSON recipe, which is either a single item or
+// a list of items.
+type jsonItems []jsonItem
+
+// UnmarshalJSON ...
+func (items *jsonItems) UnmarshalJSON(b []byte) error {
+	var list []json.RawMessage
+	if err := json.Unmarshal(b, &list); err != nil {
+		list = []json.RawMessage{b}
+	}
+	*items = make(jsonItems, len(list))
+	for n, raw := range list {
+		if err := json.Unmarshal(raw, &(*items)[n]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// outputs converts the jsonItems to recipe output items.
+func (items jsonItems) outputs() ([]item.Stack, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("recipe has no result")
+	}
+	output := make([]item.Stack, 0, len(items))
+	for _, i := range items {
+		st, err := i.output()
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, st)
+	}
+	return output, nil
+}
+
+// namespaced returns the name passed with the minecraft namespace if it does
+// not have a namespace yet.
+func namespaced(name string) string {
+	if !strings.Contains(name, ":") {
+		return "minecraft:" + name
+	}
+	return name
+}
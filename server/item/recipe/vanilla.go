@@ -207,4 +207,5 @@ func registerVanilla() {
 
 	// Register dynamic recipes
 	RegisterDynamic(NewDecoratedPotRecipe())
+	RegisterDynamic(NewShieldDecorationRecipe())
 }
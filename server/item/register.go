@@ -10,6 +10,7 @@ import (
 func init() {
 	world.RegisterItem(AmethystShard{})
 	world.RegisterItem(Apple{})
+	world.RegisterItem(ArmadilloScute{})
 	world.RegisterItem(Arrow{})
 	world.RegisterItem(BakedPotato{})
 	world.RegisterItem(Beef{Cooked: true})
@@ -27,11 +28,13 @@ func init() {
 	world.RegisterItem(Bow{})
 	world.RegisterItem(Bread{})
 	world.RegisterItem(Brick{})
+	world.RegisterItem(Brush{})
 	world.RegisterItem(Bucket{})
 	world.RegisterItem(CarrotOnAStick{})
 	world.RegisterItem(Charcoal{})
 	world.RegisterItem(Chicken{Cooked: true})
 	world.RegisterItem(Chicken{})
+	world.RegisterItem(ChorusFruit{})
 	world.RegisterItem(ClayBall{})
 	world.RegisterItem(Clock{})
 	world.RegisterItem(Coal{})
@@ -42,6 +45,7 @@ func init() {
 	world.RegisterItem(CopperIngot{})
 	world.RegisterItem(CopperNugget{})
 	world.RegisterItem(Crossbow{})
+	world.RegisterItem(DebugStick{})
 	world.RegisterItem(Diamond{})
 	world.RegisterItem(DiscFragment{})
 	world.RegisterItem(DragonBreath{})
@@ -50,13 +54,16 @@ func init() {
 	world.RegisterItem(Egg{})
 	world.RegisterItem(Elytra{})
 	world.RegisterItem(Emerald{})
+	world.RegisterItem(EmptyMap{})
 	world.RegisterItem(EnchantedApple{})
 	world.RegisterItem(EnchantedBook{})
 	world.RegisterItem(EnderPearl{})
 	world.RegisterItem(Feather{})
 	world.RegisterItem(FermentedSpiderEye{})
+	world.RegisterItem(FilledMap{})
 	world.RegisterItem(FireCharge{})
 	world.RegisterItem(Firework{})
+	world.RegisterItem(FishingRod{})
 	world.RegisterItem(FlintAndSteel{})
 	world.RegisterItem(Flint{})
 	world.RegisterItem(GhastTear{})
@@ -69,6 +76,7 @@ func init() {
 	world.RegisterItem(GoldenCarrot{})
 	world.RegisterItem(Gunpowder{})
 	world.RegisterItem(HeartOfTheSea{})
+	world.RegisterItem(HoneyBottle{})
 	world.RegisterItem(Honeycomb{})
 	world.RegisterItem(InkSac{Glowing: true})
 	world.RegisterItem(InkSac{})
@@ -76,11 +84,14 @@ func init() {
 	world.RegisterItem(IronNugget{})
 	world.RegisterItem(LapisLazuli{})
 	world.RegisterItem(Leather{})
+	world.RegisterItem(Lead{})
+	world.RegisterItem(Mace{})
 	world.RegisterItem(MagmaCream{})
 	world.RegisterItem(MelonSlice{})
 	world.RegisterItem(MushroomStew{})
 	world.RegisterItem(Mutton{Cooked: true})
 	world.RegisterItem(Mutton{})
+	world.RegisterItem(NameTag{})
 	world.RegisterItem(NautilusShell{})
 	world.RegisterItem(NetherBrick{})
 	world.RegisterItem(NetherQuartz{})
@@ -89,6 +100,7 @@ func init() {
 	world.RegisterItem(NetheriteScrap{})
 	world.RegisterItem(Paper{})
 	world.RegisterItem(PhantomMembrane{})
+	world.RegisterItem(PitcherPod{})
 	world.RegisterItem(PoisonousPotato{})
 	world.RegisterItem(PoppedChorusFruit{})
 	world.RegisterItem(Porkchop{Cooked: true})
@@ -109,10 +121,12 @@ func init() {
 	world.RegisterItem(Redstone{})
 	world.RegisterItem(ResinBrick{})
 	world.RegisterItem(RottenFlesh{})
+	world.RegisterItem(Saddle{})
 	world.RegisterItem(Salmon{Cooked: true})
 	world.RegisterItem(Salmon{})
 	world.RegisterItem(Scute{})
 	world.RegisterItem(Shears{})
+	world.RegisterItem(Shield{})
 	world.RegisterItem(ShulkerShell{})
 	world.RegisterItem(Slimeball{})
 	world.RegisterItem(Snowball{})
@@ -120,11 +134,13 @@ func init() {
 	world.RegisterItem(Spyglass{})
 	world.RegisterItem(Stick{})
 	world.RegisterItem(Sugar{})
+	world.RegisterItem(TorchflowerSeeds{})
 	world.RegisterItem(Totem{})
 	world.RegisterItem(TropicalFish{})
 	world.RegisterItem(TurtleShell{})
 	world.RegisterItem(WarpedFungusOnAStick{})
 	world.RegisterItem(Wheat{})
+	world.RegisterItem(WolfArmour{})
 	world.RegisterItem(BreezeRod{})
 	world.RegisterItem(WrittenBook{})
 	for _, t := range ArmourTiers() {
@@ -0,0 +1,15 @@
+package item
+
+// Saddle is an item that may be put on a mob to allow players to ride and
+// control it, such as a horse, pig or camel.
+type Saddle struct{}
+
+// MaxCount always returns 1.
+func (Saddle) MaxCount() int {
+	return 1
+}
+
+// EncodeItem ...
+func (Saddle) EncodeItem() (name string, meta int16) {
+	return "minecraft:saddle", 0
+}
@@ -0,0 +1,96 @@
+package item
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Shield is a defensive item that blocks incoming attacks and projectiles
+// while its holder is sneaking.
+type Shield struct {
+	// Banner is the banner applied to the shield, which holds the base colour
+	// and patterns displayed on the front of the shield. Banner is nil if no
+	// banner has been applied. If not nil, Banner must be a block.Banner.
+	Banner world.Item
+}
+
+// DisableDuration is the duration for which a shield is disabled after its
+// holder is hit by an attacker wielding an axe.
+const DisableDuration = time.Second * 5
+
+// BlockingDelay is the time a player has to be sneaking with a shield before
+// attacks are blocked.
+const BlockingDelay = time.Millisecond * 250
+
+// MaxCount always returns 1.
+func (Shield) MaxCount() int {
+	return 1
+}
+
+// OffHand ...
+func (Shield) OffHand() bool {
+	return true
+}
+
+// DurabilityInfo ...
+func (Shield) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability: 336,
+		BrokenItem:    simpleItem(Stack{}),
+	}
+}
+
+// BlockDurability returns the durability lost by the shield when it blocks
+// an attack dealing the amount of damage passed. Attacks dealing less than 3
+// damage do not damage the shield.
+func (Shield) BlockDurability(dmg float64) int {
+	if dmg < 3 {
+		return 0
+	}
+	return 1 + int(dmg)
+}
+
+// RepairableBy ...
+func (Shield) RepairableBy(i Stack) bool {
+	return toolTierRepairable(ToolTierWood)(i)
+}
+
+// FuelInfo ...
+func (Shield) FuelInfo() FuelInfo {
+	return newFuelInfo(time.Second * 15)
+}
+
+// EncodeNBT ...
+func (s Shield) EncodeNBT() map[string]any {
+	b, ok := s.Banner.(world.NBTer)
+	if !ok {
+		return nil
+	}
+	data := b.EncodeNBT()
+	return map[string]any{"Base": data["Base"], "Patterns": data["Patterns"]}
+}
+
+// DecodeNBT ...
+func (s Shield) DecodeNBT(data map[string]any) any {
+	base, ok := data["Base"].(int32)
+	if !ok {
+		return s
+	}
+	// Banner items use the (inverted) colour ID stored in the Base tag as
+	// their metadata value.
+	b, ok := world.ItemByName("minecraft:banner", int16(base))
+	if !ok {
+		return s
+	}
+	if n, ok := b.(world.NBTer); ok {
+		b = n.DecodeNBT(data).(world.Item)
+	}
+	s.Banner = b
+	return s
+}
+
+// EncodeItem ...
+func (Shield) EncodeItem() (name string, meta int16) {
+	return "minecraft:shield", 0
+}
@@ -2,6 +2,8 @@ package item
 
 import (
 	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
 )
 
 // Smeltable represents an item that can be input into a smelter, such as a blast furnace, furnace, or smoker, to cook and
@@ -11,6 +13,18 @@ type Smeltable interface {
 	SmeltInfo() SmeltInfo
 }
 
+// SmeltResult returns the SmeltInfo of the item passed if it can be smelted
+// in a furnace. False is returned if the item is not Smeltable or if smelting
+// it does not produce anything.
+func SmeltResult(i world.Item) (SmeltInfo, bool) {
+	smeltable, ok := i.(Smeltable)
+	if !ok {
+		return SmeltInfo{}, false
+	}
+	info := smeltable.SmeltInfo()
+	return info, !info.Product.Empty()
+}
+
 // Fuel represents an item that can be used as fuel in a smelter, such as a blast furnace, furnace, or smoker.
 type Fuel interface {
 	// FuelInfo returns information of the item related to its fuel capabilities.
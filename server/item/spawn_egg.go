@@ -0,0 +1,75 @@
+package item
+
+import (
+	"math/rand/v2"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// SpawnEgg is an item used to spawn an entity of a specific type. Using it on a
+// mob spawner changes the entity spawned by the spawner instead.
+type SpawnEgg struct {
+	// Type is the world.EntityType of the entity spawned by the spawn egg.
+	Type world.EntityType
+}
+
+// RegisterSpawnEggs registers a SpawnEgg for every world.EntityType in the
+// world.EntityRegistry passed that has a spawn egg item known by the client.
+// Spawn eggs that were already registered are skipped, so RegisterSpawnEggs
+// may be called for multiple registries.
+func RegisterSpawnEggs(reg world.EntityRegistry) {
+	for _, t := range reg.Types() {
+		egg := SpawnEgg{Type: t}
+		if _, _, ok := world.ItemRuntimeID(egg); !ok {
+			continue
+		}
+		if _, ok := world.ItemByName(egg.EncodeItem()); ok {
+			continue
+		}
+		world.RegisterItem(egg)
+	}
+}
+
+// spawnerEntitySetter represents a block, such as a mob spawner, of which the
+// entity spawned may be changed using a SpawnEgg.
+type spawnerEntitySetter interface {
+	// SetEntity returns the block with its spawned entity changed to the
+	// world.EntityType passed. If the entity was already set to this type,
+	// false is returned.
+	SetEntity(t world.EntityType) (world.Block, bool)
+}
+
+// UseOnBlock either changes the entity of a mob spawner clicked, or spawns a
+// new entity on the side of the block clicked.
+func (s SpawnEgg) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, _ User, ctx *UseContext) bool {
+	if s.Type == nil {
+		return false
+	}
+	if setter, ok := tx.Block(pos).(spawnerEntitySetter); ok {
+		if b, ok := setter.SetEntity(s.Type); ok {
+			tx.SetBlock(pos, b, nil)
+			ctx.SubtractFromCount(1)
+		}
+		return true
+	}
+	opts := world.EntitySpawnOpts{
+		Position: pos.Side(face).Vec3Middle(),
+		Rotation: cube.Rotation{rand.Float64()*360 - 180, 0},
+	}
+	tx.AddEntity(opts.NewFromNBT(s.Type, nil))
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (s SpawnEgg) EncodeItem() (name string, meta int16) {
+	if s.Type == nil {
+		return "minecraft:spawn_egg", 0
+	}
+	// Some entities, such as villagers, have a versioned identifier while
+	// their spawn egg does not.
+	return strings.TrimSuffix(s.Type.EncodeEntity(), "_v2") + "_spawn_egg", 0
+}
@@ -0,0 +1,10 @@
+package item
+
+// TorchflowerSeeds are seeds dug up by sniffers. They are used to breed
+// sniffers.
+type TorchflowerSeeds struct{}
+
+// EncodeItem ...
+func (TorchflowerSeeds) EncodeItem() (name string, meta int16) {
+	return "minecraft:torchflower_seeds", 0
+}
@@ -0,0 +1,29 @@
+package item
+
+// WolfArmour is a piece of armour crafted from armadillo scutes that may be
+// put on tamed wolves to protect them from damage.
+type WolfArmour struct{}
+
+// DurabilityInfo ...
+func (WolfArmour) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability: 64,
+		BrokenItem:    simpleItem(Stack{}),
+	}
+}
+
+// RepairableBy ...
+func (WolfArmour) RepairableBy(i Stack) bool {
+	_, ok := i.Item().(ArmadilloScute)
+	return ok
+}
+
+// MaxCount always returns 1.
+func (WolfArmour) MaxCount() int {
+	return 1
+}
+
+// EncodeItem ...
+func (WolfArmour) EncodeItem() (name string, meta int16) {
+	return "minecraft:wolf_armor", 0
+}
@@ -0,0 +1,96 @@
+// Package metrics exposes counters of the loot and block systems of the server
+// through expvar, so that operators can monitor them. Metrics are not
+// collected until Enable is called. Once enabled, the metrics are published
+// under the "dragonfly" expvar variable, which is served as JSON at
+// /debug/vars by any HTTP server that serves expvar.Handler, for example:
+//
+//	metrics.Enable()
+//	go http.ListenAndServe("localhost:8080", expvar.Handler())
+package metrics
+
+import (
+	"expvar"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	enabled atomic.Bool
+	publish sync.Once
+
+	// lootRolls holds the number of times each loot table was rolled, keyed
+	// by the path of the table.
+	lootRolls = new(expvar.Map)
+	// lootItemsNotFound holds the number of times a loot table referenced an
+	// item that is not registered, keyed by the name of the item.
+	lootItemsNotFound = new(expvar.Map)
+	// randomTicks holds the total number of random block ticks performed,
+	// keyed by the name of the world.
+	randomTicks = new(expvar.Map)
+	// blockEntities holds the number of block entities in the chunks ticked
+	// during the last tick, keyed by the name of the world.
+	blockEntities = new(expvar.Map)
+)
+
+// Enable enables the collection of metrics and publishes them under the
+// "dragonfly" expvar variable. Calling Enable more than once has no
+// additional effect.
+func Enable() {
+	publish.Do(func() {
+		m := new(expvar.Map)
+		m.Set("loot_rolls", lootRolls)
+		m.Set("loot_items_not_found", lootItemsNotFound)
+		m.Set("random_ticks", randomTicks)
+		m.Set("block_entities", blockEntities)
+		expvar.Publish("dragonfly", m)
+	})
+	enabled.Store(true)
+}
+
+// Disable stops the collection of metrics. Metrics already collected remain
+// published.
+func Disable() {
+	enabled.Store(false)
+}
+
+// Enabled checks if metrics are currently being collected.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// LootRoll records a roll of the loot table at the path passed.
+func LootRoll(path string) {
+	if enabled.Load() {
+		lootRolls.Add(path, 1)
+	}
+}
+
+// LootItemNotFound records a loot table referencing an item with the name
+// passed that is not registered.
+func LootItemNotFound(name string) {
+	if enabled.Load() {
+		lootItemsNotFound.Add(name, 1)
+	}
+}
+
+// RandomTicks records n random block ticks performed in the world with the
+// name passed.
+func RandomTicks(world string, n int) {
+	if enabled.Load() && n > 0 {
+		randomTicks.Add(world, int64(n))
+	}
+}
+
+// BlockEntities records the number of block entities in the chunks ticked in
+// the world with the name passed during the current tick.
+func BlockEntities(world string, n int) {
+	if !enabled.Load() {
+		return
+	}
+	v, ok := blockEntities.Get(world).(*expvar.Int)
+	if !ok {
+		v = new(expvar.Int)
+		blockEntities.Set(world, v)
+	}
+	v.Set(int64(n))
+}
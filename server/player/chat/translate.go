@@ -20,6 +20,10 @@ var MessageNoSleep = Translate(str("%tile.bed.noSleep"), 0, `You can only sleep
 var MessageBedIsOccupied = Translate(str("%tile.bed.occupied"), 0, `This bed is occupied`).Enc("<grey>%v</grey>")
 var MessageSleeping = Translate(str("%chat.type.sleeping"), 2, `%v is sleeping in a bed. To skip to dawn, %v more users need to sleep in beds at the same time.`)
 var MessageBedNotValid = Translate(str("%tile.bed.notValid"), 0, `Your home bed was missing or obstructed`)
+var MessageStructureSaved = Translate(str("%structure_block.save_success"), 1, `Structure saved as '%v'`)
+var MessageStructureSaveFailed = Translate(str("%structure_block.save_failure"), 1, `Unable to save structure '%v'`).Enc("<red>%v</red>")
+var MessageStructureLoaded = Translate(str("%structure_block.load_success"), 1, `Structure loaded from '%v'`)
+var MessageStructureNotFound = Translate(str("%structure_block.load_not_found"), 1, `Structure '%v' is not available`).Enc("<red>%v</red>")
 
 type str string
 
@@ -44,7 +44,9 @@ type Config struct {
 	EnderChestInventory    *inventory.Inventory
 	FireTicks              int64
 	FallDistance           float64
+	TimeSinceRestTicks     int64
 	Effects                []effect.Effect
+	Statistics             map[Statistic]int64
 }
 
 // Apply applies fields from a Config to a world.EntityData, filling out empty
@@ -84,6 +86,8 @@ func (cfg Config) Apply(data *world.EntityData) {
 		nameTag:             conf.Name,
 		fireTicks:           conf.FireTicks,
 		fallDistance:        conf.FallDistance,
+		timeSinceRest:       conf.TimeSinceRestTicks,
+		statistics:          conf.Statistics,
 	}
 	pdata.hunger.foodLevel, pdata.hunger.foodTick, pdata.hunger.exhaustionLevel, pdata.hunger.saturationLevel = conf.Food, conf.FoodTick, conf.Exhaustion, conf.Saturation
 	pdata.experience.Add(conf.Experience)
@@ -119,6 +123,9 @@ func fillDefaults(conf Config) Config {
 	if conf.MaxHealth == 0 {
 		conf.MaxHealth, conf.Health = 20, 20
 	}
+	if conf.Statistics == nil {
+		conf.Statistics = map[Statistic]int64{}
+	}
 	if conf.GameMode == nil {
 		conf.GameMode = world.GameModeSurvival
 	}
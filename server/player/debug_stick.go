@@ -0,0 +1,99 @@
+package player
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// useDebugStick uses the item.DebugStick passed on the block at the position passed. If the player is sneaking,
+// the next property of the block is selected. Otherwise, the selected property of the block is changed to its
+// next value. The result is shown to the player as a tip.
+func (p *Player) useDebugStick(pos cube.Pos, stick item.DebugStick, held, left item.Stack) {
+	b := p.tx.Block(pos)
+	name, properties := b.EncodeBlock()
+	if len(properties) == 0 {
+		p.SendTip(fmt.Sprintf("%v has no properties", name))
+		return
+	}
+	properties = maps.Clone(properties)
+	keys := slices.Sorted(maps.Keys(properties))
+	property := stick.Property
+	if _, ok := properties[property]; !ok {
+		property = keys[0]
+	}
+	if p.Sneaking() {
+		property = keys[(slices.Index(keys, property)+1)%len(keys)]
+		p.SetHeldItems(held.WithItem(item.DebugStick{Property: property}), left)
+		p.SendTip(fmt.Sprintf("Selected %q (%v)", property, properties[property]))
+		return
+	}
+
+	values := debugStickValues(name, property, properties)
+	next := values[(slices.Index(values, properties[property])+1)%len(values)]
+	properties[property] = next
+	nb, ok := world.BlockByName(name, properties)
+	if !ok {
+		return
+	}
+	if nbter, ok := b.(world.NBTer); ok {
+		if data := nbter.EncodeNBT(); data != nil {
+			nb = nb.(world.NBTer).DecodeNBT(data).(world.Block)
+		}
+	}
+	p.tx.SetBlock(pos, nb, &world.SetOpts{DisableBlockUpdates: true})
+	p.SwingArm()
+	if property != stick.Property {
+		p.SetHeldItems(held.WithItem(item.DebugStick{Property: property}), left)
+	}
+	p.SendTip(fmt.Sprintf("%q to %v", property, next))
+}
+
+// debugStickValues returns the sorted values that the property passed may have for the block with the name
+// passed, while all other properties keep the values in the properties passed.
+func debugStickValues(name, property string, properties map[string]any) []any {
+	var values []any
+	for _, b := range world.Blocks() {
+		n, props := b.EncodeBlock()
+		if n != name || len(props) != len(properties) {
+			continue
+		}
+		matches := true
+		for k, v := range properties {
+			if other, ok := props[k]; !ok || (k != property && other != v) {
+				matches = false
+				break
+			}
+		}
+		if matches && !slices.Contains(values, props[property]) {
+			values = append(values, props[property])
+		}
+	}
+	slices.SortFunc(values, compareStateValues)
+	return values
+}
+
+// compareStateValues compares two values of the same block property.
+func compareStateValues(a, b any) int {
+	switch a := a.(type) {
+	case uint8:
+		return cmp.Compare(a, b.(uint8))
+	case int32:
+		return cmp.Compare(a, b.(int32))
+	case string:
+		return cmp.Compare(a, b.(string))
+	case bool:
+		if a == b.(bool) {
+			return 0
+		} else if a {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
@@ -4,6 +4,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/event"
@@ -127,6 +128,18 @@ type Handler interface {
 	// HandleLecternPageTurn handles the player turning a page in a lectern. ctx.Cancel() may be called to cancel the
 	// page turn. The page number may be changed by assigning to *page.
 	HandleLecternPageTurn(ctx *Context, pos cube.Pos, oldPage int, newPage *int)
+	// HandleContainerLoot handles the player opening a container, such as a chest, at a position that holds a
+	// loot table that has not yet been generated. It is also called when the player breaks such a container, brushes
+	// suspicious sand or gravel or uses a decorated pot holding a loot table. The stacks generated from the loot
+	// table are passed and may be changed. Alternatively, the path and seed of the loot table may be changed by
+	// assigning to *table and *seed, in which case the stacks are generated again from the new loot table.
+	// Suspicious blocks and decorated pots only hold the first stack. ctx.Cancel() may be called to prevent the
+	// container from being filled, leaving the loot table pending for the next player that opens it.
+	HandleContainerLoot(ctx *Context, pos cube.Pos, table *string, seed *int64, stacks *[]item.Stack)
+	// HandleStructureBlockEdit handles the player changing the settings of a structure block. The structure block
+	// with the new settings is passed and may be changed. trigger is true if the player also pressed the button
+	// to save or load the structure. ctx.Cancel() may be called to cancel the edit.
+	HandleStructureBlockEdit(ctx *Context, pos cube.Pos, b *block.StructureBlock, trigger bool)
 	// HandleItemDamage handles the event wherein the item either held by the player or as armour takes
 	// damage through usage.
 	// The type of the item may be checked to determine whether it was armour or a tool used. The damage to
@@ -164,40 +177,42 @@ type NopHandler struct{}
 // Compile time check to make sure NopHandler implements Handler.
 var _ Handler = NopHandler{}
 
-func (NopHandler) HandleItemDrop(*Context, item.Stack)                                     {}
-func (NopHandler) HandleHeldSlotChange(*Context, int, int)                                 {}
-func (NopHandler) HandleMove(*Context, mgl64.Vec3, cube.Rotation)                          {}
-func (NopHandler) HandleJump(*Player)                                                      {}
-func (NopHandler) HandleTeleport(*Context, mgl64.Vec3)                                     {}
-func (NopHandler) HandleChangeWorld(*Player, *world.World, *world.World)                   {}
-func (NopHandler) HandleToggleSprint(*Context, bool)                                       {}
-func (NopHandler) HandleToggleSneak(*Context, bool)                                        {}
-func (NopHandler) HandleCommandExecution(*Context, cmd.Command, []string)                  {}
-func (NopHandler) HandleTransfer(*Context, *net.UDPAddr)                                   {}
-func (NopHandler) HandleChat(*Context, *string)                                            {}
-func (NopHandler) HandleSkinChange(*Context, *skin.Skin)                                   {}
-func (NopHandler) HandleFireExtinguish(*Context, cube.Pos)                                 {}
-func (NopHandler) HandleStartBreak(*Context, cube.Pos)                                     {}
-func (NopHandler) HandleBlockBreak(*Context, cube.Pos, *[]item.Stack, *int)                {}
-func (NopHandler) HandleBlockPlace(*Context, cube.Pos, world.Block)                        {}
-func (NopHandler) HandleBlockPick(*Context, cube.Pos, world.Block)                         {}
-func (NopHandler) HandleSignEdit(*Context, cube.Pos, bool, string, string)                 {}
-func (NopHandler) HandleSleep(*Context, *bool)                                             {}
-func (NopHandler) HandleLecternPageTurn(*Context, cube.Pos, int, *int)                     {}
-func (NopHandler) HandleItemPickup(*Context, *item.Stack)                                  {}
-func (NopHandler) HandleItemUse(*Context)                                                  {}
-func (NopHandler) HandleItemUseOnBlock(*Context, cube.Pos, cube.Face, mgl64.Vec3)          {}
-func (NopHandler) HandleItemUseOnEntity(*Context, world.Entity)                            {}
-func (NopHandler) HandleItemRelease(ctx *Context, item item.Stack, dur time.Duration)      {}
-func (NopHandler) HandleItemConsume(*Context, item.Stack)                                  {}
-func (NopHandler) HandleItemDamage(*Context, item.Stack, *int)                             {}
-func (NopHandler) HandleAttackEntity(*Context, world.Entity, *float64, *float64, *bool)    {}
-func (NopHandler) HandleExperienceGain(*Context, *int)                                     {}
-func (NopHandler) HandlePunchAir(*Context)                                                 {}
-func (NopHandler) HandleHurt(*Context, *float64, bool, *time.Duration, world.DamageSource) {}
-func (NopHandler) HandleHeal(*Context, *float64, world.HealingSource)                      {}
-func (NopHandler) HandleFoodLoss(*Context, int, *int)                                      {}
-func (NopHandler) HandleDeath(*Player, world.DamageSource, *bool)                          {}
-func (NopHandler) HandleRespawn(*Player, *mgl64.Vec3, **world.World)                       {}
-func (NopHandler) HandleQuit(*Player)                                                      {}
-func (NopHandler) HandleDiagnostics(*Player, session.Diagnostics)                          {}
+func (NopHandler) HandleItemDrop(*Context, item.Stack)                                      {}
+func (NopHandler) HandleHeldSlotChange(*Context, int, int)                                  {}
+func (NopHandler) HandleMove(*Context, mgl64.Vec3, cube.Rotation)                           {}
+func (NopHandler) HandleJump(*Player)                                                       {}
+func (NopHandler) HandleTeleport(*Context, mgl64.Vec3)                                      {}
+func (NopHandler) HandleChangeWorld(*Player, *world.World, *world.World)                    {}
+func (NopHandler) HandleToggleSprint(*Context, bool)                                        {}
+func (NopHandler) HandleToggleSneak(*Context, bool)                                         {}
+func (NopHandler) HandleCommandExecution(*Context, cmd.Command, []string)                   {}
+func (NopHandler) HandleTransfer(*Context, *net.UDPAddr)                                    {}
+func (NopHandler) HandleChat(*Context, *string)                                             {}
+func (NopHandler) HandleSkinChange(*Context, *skin.Skin)                                    {}
+func (NopHandler) HandleFireExtinguish(*Context, cube.Pos)                                  {}
+func (NopHandler) HandleStartBreak(*Context, cube.Pos)                                      {}
+func (NopHandler) HandleBlockBreak(*Context, cube.Pos, *[]item.Stack, *int)                 {}
+func (NopHandler) HandleBlockPlace(*Context, cube.Pos, world.Block)                         {}
+func (NopHandler) HandleBlockPick(*Context, cube.Pos, world.Block)                          {}
+func (NopHandler) HandleSignEdit(*Context, cube.Pos, bool, string, string)                  {}
+func (NopHandler) HandleSleep(*Context, *bool)                                              {}
+func (NopHandler) HandleLecternPageTurn(*Context, cube.Pos, int, *int)                      {}
+func (NopHandler) HandleContainerLoot(*Context, cube.Pos, *string, *int64, *[]item.Stack)   {}
+func (NopHandler) HandleStructureBlockEdit(*Context, cube.Pos, *block.StructureBlock, bool) {}
+func (NopHandler) HandleItemPickup(*Context, *item.Stack)                                   {}
+func (NopHandler) HandleItemUse(*Context)                                                   {}
+func (NopHandler) HandleItemUseOnBlock(*Context, cube.Pos, cube.Face, mgl64.Vec3)           {}
+func (NopHandler) HandleItemUseOnEntity(*Context, world.Entity)                             {}
+func (NopHandler) HandleItemRelease(ctx *Context, item item.Stack, dur time.Duration)       {}
+func (NopHandler) HandleItemConsume(*Context, item.Stack)                                   {}
+func (NopHandler) HandleItemDamage(*Context, item.Stack, *int)                              {}
+func (NopHandler) HandleAttackEntity(*Context, world.Entity, *float64, *float64, *bool)     {}
+func (NopHandler) HandleExperienceGain(*Context, *int)                                      {}
+func (NopHandler) HandlePunchAir(*Context)                                                  {}
+func (NopHandler) HandleHurt(*Context, *float64, bool, *time.Duration, world.DamageSource)  {}
+func (NopHandler) HandleHeal(*Context, *float64, world.HealingSource)                       {}
+func (NopHandler) HandleFoodLoss(*Context, int, *int)                                       {}
+func (NopHandler) HandleDeath(*Player, world.DamageSource, *bool)                           {}
+func (NopHandler) HandleRespawn(*Player, *mgl64.Vec3, **world.World)                        {}
+func (NopHandler) HandleQuit(*Player)                                                       {}
+func (NopHandler) HandleDiagnostics(*Player, session.Diagnostics)                           {}
@@ -31,6 +31,7 @@ import (
 	"github.com/df-mc/dragonfly/server/player/title"
 	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
 	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
@@ -55,16 +56,24 @@ type playerData struct {
 	heldSlot                     *uint32
 
 	sneaking, sprinting, swimming, gliding, crawling, flying,
-	invisible, immobile, onGround, usingItem bool
+	invisible, immobile, onGround, usingItem, blocking bool
 
 	sleeping bool
 	sleepPos cube.Pos
 
-	usingSince time.Time
+	// riding is the entity handle of the entity.Rideable that the player is
+	// riding, if any. seat is the seat that the player occupies on it.
+	riding *world.EntityHandle
+	seat   int
+
+	usingSince    time.Time
+	sneakingSince time.Time
 
 	glideTicks          int64
 	fireTicks           int64
+	timeSinceRest       int64
 	fallDistance        float64
+	stepDistance        float64
 
 	breathing         bool
 	airSupplyTicks    int
@@ -90,6 +99,8 @@ type playerData struct {
 
 	enchantSeed int64
 
+	statistics map[Statistic]int64
+
 	mc *entity.MovementComputer
 
 	collidedVertically, collidedHorizontally bool
@@ -590,6 +601,9 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 	if _, ok := p.Effect(effect.FireResistance); (ok && src.Fire()) || p.Dead() || !p.GameMode().AllowsTakingDamage() || p.InSpawn() || dmg < 0 {
 		return 0, false
 	}
+	if p.blockWithShield(dmg, src) || p.shieldedByVehicle(src) {
+		return 0, false
+	}
 	totalDamage := p.FinalDamageFrom(dmg, src)
 	damageLeft := totalDamage
 
@@ -736,6 +750,9 @@ func (p *Player) knockBack(src mgl64.Vec3, force, height float64) {
 	}
 	velocity[1] = height
 
+	if p.blocking {
+		velocity = velocity.Mul(0.5)
+	}
 	p.SetVelocity(velocity.Mul(1 - p.Armour().KnockBackResistance()))
 }
 
@@ -810,6 +827,11 @@ func (*Player) BeaconAffected() bool {
 	return true
 }
 
+// ConduitAffected ...
+func (*Player) ConduitAffected() bool {
+	return true
+}
+
 // Exhaust exhausts the player by the amount of points passed if the player is in survival mode. If the total
 // exhaustion level exceeds 4, a saturation point, or food point, if saturation is 0, will be subtracted.
 func (p *Player) Exhaust(points float64) {
@@ -866,6 +888,7 @@ func (p *Player) kill(src world.DamageSource) {
 	p.Handler().HandleDeath(p, src, &keepInv)
 	p.StopSneaking()
 	p.StopSprinting()
+	p.Dismount()
 
 	pos := p.Position()
 	if !keepInv {
@@ -876,6 +899,7 @@ func (p *Player) kill(src world.DamageSource) {
 	}
 
 	p.deathPos, p.deathDimension = &pos, p.tx.World().Dimension()
+	p.timeSinceRest = 0
 
 	// Wait a little before removing the entity. The client displays a death
 	// animation while the player is dying.
@@ -906,8 +930,10 @@ func finishDying(_ *world.Tx, e world.Entity) {
 // dropItems drops all items and experience of the Player on the ground in random directions.
 func (p *Player) dropItems() {
 	pos := p.Position()
-	for _, orb := range entity.NewExperienceOrbs(pos, int(math.Min(float64(p.experience.Level()*7), 100))) {
-		p.tx.AddEntity(orb)
+	if xp := int(math.Min(float64(p.experience.Level()*7), 100)); !block.AbsorbDeathExperience(p.tx, pos, xp) {
+		for _, orb := range entity.NewExperienceOrbs(pos, xp) {
+			p.tx.AddEntity(orb)
+		}
 	}
 	p.experience.Reset()
 	p.session().SendExperience(p.ExperienceLevel(), p.ExperienceProgress())
@@ -1039,6 +1065,12 @@ func (p *Player) Sprinting() bool {
 	return p.sprinting
 }
 
+// ScaresArmadillos checks if the player scares armadillos near it. Players
+// that are sprinting or riding an entity scare armadillos.
+func (p *Player) ScaresArmadillos() bool {
+	return p.Sprinting() || p.riding != nil
+}
+
 // StopSprinting makes a player stop sprinting, setting back the speed of the player to its original value.
 func (p *Player) StopSprinting() {
 	if !p.sprinting {
@@ -1068,6 +1100,7 @@ func (p *Player) StartSneaking() {
 		p.StopSprinting()
 	}
 	p.sneaking = true
+	p.sneakingSince = time.Now()
 	p.updateState()
 }
 
@@ -1076,6 +1109,108 @@ func (p *Player) Sneaking() bool {
 	return p.sneaking
 }
 
+// Blocking checks if the player is currently blocking with a shield. A player
+// blocks attacks coming from the front when it has been sneaking for at least
+// item.BlockingDelay while holding a shield that is not disabled.
+func (p *Player) Blocking() bool {
+	return p.blocking
+}
+
+// heldShield returns the item.Shield held by the player, checking the main
+// hand first and the off hand second. The bool returned is true if the shield
+// was held in the main hand.
+func (p *Player) heldShield() (item.Stack, bool, bool) {
+	main, off := p.HeldItems()
+	if _, ok := main.Item().(item.Shield); ok {
+		return main, true, true
+	}
+	if _, ok := off.Item().(item.Shield); ok {
+		return off, false, true
+	}
+	return item.Stack{}, false, false
+}
+
+// updateBlocking updates the blocking state of the player depending on its
+// sneaking state, the items it holds and the shield cooldown.
+func (p *Player) updateBlocking() {
+	_, _, ok := p.heldShield()
+	blocking := ok && p.sneaking && time.Since(p.sneakingSince) >= item.BlockingDelay && !p.HasCooldown(item.Shield{})
+	if blocking != p.blocking {
+		p.blocking = blocking
+		p.updateState()
+	}
+}
+
+// blockWithShield attempts to block damage from the world.DamageSource passed
+// using a shield held by the player. Only attacks and projectiles coming from
+// in front of the player may be blocked. True is returned if the damage was
+// blocked.
+func (p *Player) blockWithShield(dmg float64, src world.DamageSource) bool {
+	if !p.Blocking() {
+		return false
+	}
+	var origin world.Entity
+	switch s := src.(type) {
+	case entity.AttackDamageSource:
+		origin = s.Attacker
+	case entity.ProjectileDamageSource:
+		origin = s.Projectile
+	}
+	if origin == nil {
+		return false
+	}
+	dir := origin.Position().Sub(p.Position())
+	dir[1] = 0
+	if dir.LenSqr() > 0 && dir.Normalize().Dot(cube.Rotation{p.Rotation().Yaw()}.Vec3()) < 0 {
+		return false
+	}
+
+	p.tx.PlaySound(p.Position(), sound.ShieldBlock{})
+	if shield, mainHand, ok := p.heldShield(); ok {
+		if d := (item.Shield{}).BlockDurability(dmg); d > 0 {
+			main, off := p.HeldItems()
+			if mainHand {
+				p.SetHeldItems(p.damageItem(shield, d), off)
+			} else {
+				p.SetHeldItems(main, p.damageItem(shield, d))
+			}
+		}
+	}
+	if a, ok := src.(entity.AttackDamageSource); ok {
+		// Blocked attacks still knock the player back, albeit with reduced
+		// force.
+		p.knockBack(a.Attacker.Position(), 0.45, 0.3608)
+		if h, ok := a.Attacker.(item.User); ok {
+			held, _ := h.HeldItems()
+			if t, ok := held.Item().(item.Tool); ok && t.ToolType() == item.TypeAxe {
+				// Axes disable the shield for a while.
+				p.SetCooldown(item.Shield{}, item.DisableDuration)
+				p.updateBlocking()
+			}
+		}
+	}
+	return true
+}
+
+// shieldedByVehicle checks if the player is riding an entity.RiderShield that
+// keeps it out of reach of the attacker of the world.DamageSource passed.
+// Other players are always able to reach riders.
+func (p *Player) shieldedByVehicle(src world.DamageSource) bool {
+	a, ok := src.(entity.AttackDamageSource)
+	if !ok || a.Attacker == nil {
+		return false
+	}
+	if _, ok := a.Attacker.(*Player); ok {
+		return false
+	}
+	r, ok := p.vehicle()
+	if !ok {
+		return false
+	}
+	shield, ok := r.(entity.RiderShield)
+	return ok && shield.ShieldsRiders(a.Attacker)
+}
+
 // StopSneaking makes a player stop sneaking if it currently is. If the player is not sneaking, StopSneaking
 // will not do anything.
 func (p *Player) StopSneaking() {
@@ -1086,7 +1221,7 @@ func (p *Player) StopSneaking() {
 	if p.Handler().HandleToggleSneak(ctx, false); ctx.Cancelled() {
 		return
 	}
-	p.sneaking = false
+	p.sneaking, p.blocking = false, false
 	p.updateState()
 }
 
@@ -1154,8 +1289,10 @@ func (p *Player) Crawling() bool {
 }
 
 // StartGliding makes the player start gliding if it is not currently doing so.
+// The player must be wearing an elytra that is not broken and may not be
+// flying or submerged in water.
 func (p *Player) StartGliding() {
-	if p.gliding {
+	if p.gliding || p.Flying() || p.insideOfWater() {
 		return
 	}
 	chest := p.Armour().Chestplate()
@@ -1213,6 +1350,12 @@ func (p *Player) Jump() {
 	}
 
 	p.Handler().HandleJump(p)
+	if r, ok := p.vehicle(); ok {
+		if d, ok := r.(interface{ Dash() }); ok && p.seat == 0 {
+			d.Dash()
+		}
+		return
+	}
 	if p.OnGround() {
 		jumpVel := 0.42
 		if e, ok := p.Effect(effect.JumpBoost); ok {
@@ -1288,6 +1431,69 @@ func (p *Player) Wake() {
 	}
 }
 
+// Mount makes the player start riding the entity.Rideable passed. If the
+// player is already riding an entity or no seat is free, Mount returns false.
+func (p *Player) Mount(r entity.Rideable) bool {
+	if p.Dead() || p.riding != nil || p.sleeping {
+		return false
+	}
+	seat, ok := r.AddRider(p)
+	if !ok {
+		return false
+	}
+	p.riding, p.seat = r.H(), seat
+	p.data.Pos = r.SeatPosition(seat)
+	for _, v := range p.viewers() {
+		v.ViewEntityAction(p, entity.MountAction{Vehicle: r, Driver: seat == 0})
+	}
+	p.updateState()
+	return true
+}
+
+// Dismount makes the player stop riding the entity.Rideable it is currently
+// riding, if any.
+func (p *Player) Dismount() {
+	if p.riding == nil {
+		return
+	}
+	h := p.riding
+	p.riding = nil
+	if ent, ok := h.Entity(p.tx); ok {
+		if r, ok := ent.(entity.Rideable); ok {
+			r.RemoveRider(p)
+		}
+		for _, v := range p.viewers() {
+			v.ViewEntityAction(p, entity.DismountAction{Vehicle: ent})
+		}
+	}
+	p.updateState()
+}
+
+// Riding returns the entity handle of the entity.Rideable that the player is
+// currently riding, and whether the player is riding at all.
+func (p *Player) Riding() (*world.EntityHandle, bool) {
+	return p.riding, p.riding != nil
+}
+
+// Driving checks if the player is riding an entity.Rideable in the seat that
+// controls its movement.
+func (p *Player) Driving() bool {
+	return p.riding != nil && p.seat == 0
+}
+
+// vehicle returns the entity.Rideable that the player is currently riding.
+func (p *Player) vehicle() (entity.Rideable, bool) {
+	if p.riding == nil {
+		return nil, false
+	}
+	ent, ok := p.riding.Entity(p.tx)
+	if !ok {
+		return nil, false
+	}
+	r, ok := ent.(entity.Rideable)
+	return r, ok
+}
+
 // Sleeping returns true if the player is currently sleeping, along with the position of the bed the player is sleeping
 // on.
 func (p *Player) Sleeping() (cube.Pos, bool) {
@@ -1297,6 +1503,106 @@ func (p *Player) Sleeping() (cube.Pos, bool) {
 	return p.sleepPos, true
 }
 
+// TimeSinceRest returns the time that has passed since the player last slept
+// in a bed. Phantoms may spawn above players that have not slept for three
+// in-game days.
+func (p *Player) TimeSinceRest() time.Duration {
+	return time.Duration(p.timeSinceRest) * time.Second / 20
+}
+
+// insomniaTicks is the number of ticks that a player must go without sleeping
+// before phantoms may spawn above it. This is equal to three in-game days.
+const insomniaTicks = world.TimeFull * 3
+
+// tickInsomnia updates the time since the player last slept. Once every
+// minute, a squadron of phantoms may spawn above the player at night if it has
+// not slept for at least three in-game days. The longer the player goes
+// without sleep, the more likely phantoms are to spawn.
+func (p *Player) tickInsomnia(tx *world.Tx, current int64) {
+	if p.sleeping {
+		p.timeSinceRest = 0
+		return
+	}
+	p.timeSinceRest++
+
+	w := tx.World()
+	if current%1200 != 0 || p.timeSinceRest < insomniaTicks || !p.GameMode().AllowsTakingDamage() {
+		return
+	}
+	if !w.Insomnia() || w.Dimension() != world.Overworld || w.Difficulty() == world.DifficultyPeaceful {
+		return
+	}
+	pos := cube.PosFromVec3(p.Position())
+	if t := w.Time() % world.TimeFull; (t < world.TimeSleep || t > world.TimeWake) && !tx.ThunderingAt(pos) {
+		return
+	}
+	if tx.HighestLightBlocker(pos[0], pos[2]) > pos[1] || rand.Int64N(p.timeSinceRest) < insomniaTicks {
+		// Phantoms only spawn above players that can see the sky.
+		return
+	}
+	spawnPos := p.Position().Add(mgl64.Vec3{rand.Float64()*20 - 10, 20 + rand.Float64()*15, rand.Float64()*20 - 10})
+	if _, ok := tx.Block(cube.PosFromVec3(spawnPos)).(block.Air); !ok {
+		return
+	}
+	difficulty, _ := world.DifficultyID(w.Difficulty())
+	for range 1 + rand.IntN(difficulty+1) {
+		tx.AddEntity(entity.NewPhantom(world.EntitySpawnOpts{Position: spawnPos}, p))
+	}
+}
+
+// tickGlowSquidSpawning spawns a group of two to four glow squids in dark water
+// near the player once every twenty seconds, if a random position near the
+// player allows it and there are fewer than five glow squids around.
+func (p *Player) tickGlowSquidSpawning(tx *world.Tx, current int64) {
+	if current%400 != 0 || tx.World().Dimension() != world.Overworld {
+		return
+	}
+	pos := cube.PosFromVec3(p.Position())
+	r := tx.Range()
+	spawnPos := pos.Add(cube.Pos{rand.IntN(49) - 24, 0, rand.IntN(49) - 24})
+	spawnPos[1] = r[0] + rand.IntN(max(31-r[0], 1))
+	if spawnPos.Vec3Centre().Sub(p.Position()).Len() < 8 || !entity.GlowSquidCanSpawn(spawnPos, tx) {
+		return
+	}
+	n := 0
+	for e := range tx.EntitiesWithin(cube.Box(-48, -48, -48, 48, 48, 48).Translate(spawnPos.Vec3Centre())) {
+		if _, ok := e.(*entity.GlowSquid); ok {
+			n++
+		}
+	}
+	for range min(2+rand.IntN(3), 5-n) {
+		at := spawnPos.Add(cube.Pos{rand.IntN(5) - 2, rand.IntN(3) - 1, rand.IntN(5) - 2})
+		if entity.GlowSquidCanSpawn(at, tx) {
+			tx.AddEntity(entity.NewGlowSquid(world.EntitySpawnOpts{Position: at.Vec3Middle()}))
+		}
+	}
+}
+
+// tickBoggedSpawning spawns a bogged in a dark spot of a swamp near the player
+// once every ten seconds, if a random position near the player allows it and
+// there are fewer than four bogged around.
+func (p *Player) tickBoggedSpawning(tx *world.Tx, current int64) {
+	if current%200 != 0 || tx.World().Dimension() != world.Overworld || tx.World().Difficulty() == world.DifficultyPeaceful {
+		return
+	}
+	pos := cube.PosFromVec3(p.Position())
+	spawnPos := pos.Add(cube.Pos{rand.IntN(49) - 24, 0, rand.IntN(49) - 24})
+	spawnPos[1] = tx.HighestBlock(spawnPos[0], spawnPos[2]) + 1
+	if spawnPos.Vec3Centre().Sub(p.Position()).Len() < 24 || !entity.BoggedCanSpawn(spawnPos, tx) {
+		return
+	}
+	n := 0
+	for e := range tx.EntitiesWithin(cube.Box(-48, -48, -48, 48, 48, 48).Translate(spawnPos.Vec3Centre())) {
+		if _, ok := e.(*entity.Bogged); ok {
+			n++
+		}
+	}
+	if n >= 4 {
+		return
+	}
+	tx.AddEntity(entity.NewBogged(world.EntitySpawnOpts{Position: spawnPos.Vec3Middle(), Rotation: cube.Rotation{rand.Float64()*360 - 180, 0}}))
+}
+
 // SendSleepingIndicator displays a notification to the player on the amount of sleeping players in the world.
 func (p *Player) SendSleepingIndicator(sleeping, max int) {
 	p.session().ViewSleepingPlayers(sleeping, max)
@@ -1712,9 +2018,9 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 			return
 		}
 	}
-	if _, ok := p.tx.Block(pos).(block.Air); ok || !p.canReach(pos.Vec3Centre()) || p.permissionLevel == 0 {
-		// The client used its item on a block that does not exist server-side or one it couldn't reach. Stop trying
-		// to use the item immediately.
+	if _, ok := p.tx.Block(pos).(block.Air); ok || !p.canReach(pos.Vec3Centre()) || !p.tx.World().Border().ContainsBlock(pos) || p.permissionLevel == 0 {
+		// The client used its item on a block that does not exist server-side, one it couldn't reach or one outside
+		// the world border. Stop trying to use the item immediately.
 		p.resendNearbyBlocks(pos, face)
 		return
 	}
@@ -1724,6 +2030,10 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 		return
 	}
 	i, left := p.HeldItems()
+	if stick, ok := i.Item().(item.DebugStick); ok && p.permissionLevel >= 2 && p.GameMode().CreativeInventory() {
+		p.useDebugStick(pos, stick, i, left)
+		return
+	}
 	b := p.tx.Block(pos)
 	if act, ok := b.(block.Activatable); ok {
 		// If a player is sneaking, it will not activate the block clicked, unless it is not holding any
@@ -1782,12 +2092,15 @@ func (p *Player) UseItemOnEntity(e world.Entity) bool {
 		return false
 	}
 	i, left := p.HeldItems()
-	usable, ok := i.Item().(item.UsableOnEntity)
-	if !ok {
+	useCtx := p.useContext()
+	if in, ok := e.(entity.Interactable); ok && in.Interact(p, p.tx, useCtx) {
+		p.SwingArm()
+		p.SetHeldItems(p.subtractItem(i, useCtx.CountSub), left)
+		p.addNewItem(useCtx)
 		return true
 	}
-	useCtx := p.useContext()
-	if !usable.UseOnEntity(e, p.tx, p, useCtx) {
+	usable, ok := i.Item().(item.UsableOnEntity)
+	if !ok || !usable.UseOnEntity(e, p.tx, p, useCtx) {
 		return true
 	}
 	p.SwingArm()
@@ -1822,6 +2135,10 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 	)
 
 	i, _ := p.HeldItems()
+	fallDistance := p.FallDistance()
+	mace, isMace := i.Item().(item.Mace)
+	smash := isMace && mace.CanSmash(fallDistance) && !p.Flying() && !p.Gliding()
+
 	if k, ok := i.Enchantment(enchantment.Knockback); ok {
 		inc := enchantment.Knockback.Force(k.Level())
 		force += inc
@@ -1852,8 +2169,18 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 		}
 	}
 	// Removed critical hit damage multiplier.
+	if smash {
+		dmg += mace.SmashDamage(fallDistance)
+		if d, ok := i.Enchantment(enchantment.Density); ok {
+			dmg += enchantment.Density.Addend(d.Level(), fallDistance)
+		}
+	}
+	src := entity.AttackDamageSource{Attacker: p}
+	if b, ok := i.Enchantment(enchantment.Breach); ok {
+		src.Penetration = enchantment.Breach.Penetration(b.Level())
+	}
 
-	n, vulnerable := living.Hurt(dmg, entity.AttackDamageSource{Attacker: p})
+	n, vulnerable := living.Hurt(dmg, src)
 	i, left := p.HeldItems()
 
 	p.tx.PlaySound(entity.EyePosition(e), sound.Attack{Damage: !mgl64.FloatEqual(n, 0)})
@@ -1865,6 +2192,20 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 	p.Exhaust(0.1)
 
 	living.KnockBack(p.Position(), force, height)
+	if living.Dead() {
+		p.supportKill(living)
+	}
+
+	if smash {
+		// A smash attack cancels out any fall damage the attacker would
+		// otherwise take upon landing.
+		p.ResetFallDistance()
+		if w, ok := i.Enchantment(enchantment.WindBurst); ok {
+			vel := p.Velocity()
+			vel[1] = enchantment.WindBurst.Velocity(w.Level())
+			p.SetVelocity(vel)
+		}
+	}
 
 	if f, ok := i.Enchantment(enchantment.FireAspect); ok {
 		if flammable, ok := living.(entity.Flammable); ok {
@@ -1878,6 +2219,16 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 	return true
 }
 
+// supportKill notifies entity.KillSupporter entities within 20 blocks of the
+// victim passed that the player killed it.
+func (p *Player) supportKill(victim world.Entity) {
+	for e := range p.tx.EntitiesWithin(cube.Box(-20, -20, -20, 20, 20, 20).Translate(victim.Position())) {
+		if s, ok := e.(entity.KillSupporter); ok {
+			s.SupportKill(p, victim, p.tx)
+		}
+	}
+}
+
 // StartBreaking makes the player start breaking the block at the position passed using the item currently
 // held in its main hand.
 // If no block is present at the position, or if the block is out of range, StartBreaking will return
@@ -2043,7 +2394,7 @@ func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreBBox bool) bool {
 			return false
 		}
 	}
-	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() || p.permissionLevel == 0 {
+	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() || !p.tx.World().Border().ContainsBlock(pos) || p.permissionLevel == 0 {
 		p.resendNearbyBlocks(pos, cube.Faces()...)
 		return false
 	}
@@ -2064,6 +2415,7 @@ func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreBBox bool) bool {
 	}
 	p.tx.SetBlock(pos, b, nil)
 	p.tx.PlaySound(pos.Vec3(), sound.BlockPlace{Block: b})
+	p.tx.EmitVibration(world.Vibration{Type: world.VibrationBlockPlace, Pos: pos.Vec3Centre(), Source: p})
 	p.SwingArm()
 	return true
 }
@@ -2106,7 +2458,7 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 		// Don't do anything if the position broken is already air.
 		return
 	}
-	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() {
+	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() || !p.tx.World().Border().ContainsBlock(pos) {
 		p.resendNearbyBlocks(pos)
 		return
 	}
@@ -2115,10 +2467,10 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 		return
 	}
 	held, _ := p.HeldItems()
-	drops := p.drops(held, b)
+	drops := p.drops(held, b, pos)
 
 	xp := 0
-	if breakable, ok := b.(block.Breakable); ok && !p.GameMode().CreativeInventory() {
+	if breakable, ok := b.(block.Breakable); ok && !p.GameMode().CreativeInventory() && p.tx.World().GameRule(world.GameRuleDoTileDrops) {
 		if _, hasSilkTouch := held.Enchantment(enchantment.SilkTouch); !hasSilkTouch {
 			xp = breakable.BreakInfo().XPDrops.RandomValue()
 		}
@@ -2134,6 +2486,7 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 	p.SwingArm()
 	p.tx.SetBlock(pos, nil, nil)
 	p.tx.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: b})
+	p.tx.EmitVibration(world.Vibration{Type: world.VibrationBlockDestroy, Pos: pos.Vec3Centre(), Source: p})
 
 	if breakable, ok := b.(block.Breakable); ok {
 		info := breakable.BreakInfo()
@@ -2150,6 +2503,7 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 	}
 
 	p.Exhaust(0.005)
+	p.AddStatistic(StatBlocksMined, 1)
 	if block.BreaksInstantly(b, held) {
 		return
 	}
@@ -2158,8 +2512,12 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 	}
 }
 
-// drops returns the drops that the player can get from the block passed using the item held.
-func (p *Player) drops(held item.Stack, b world.Block) []item.Stack {
+// drops returns the drops that the player can get from the block passed at the position passed using the
+// item held. No drops are returned if the doTileDrops game rule is disabled.
+func (p *Player) drops(held item.Stack, b world.Block, pos cube.Pos) []item.Stack {
+	if !p.tx.World().GameRule(world.GameRuleDoTileDrops) {
+		return nil
+	}
 	t, ok := held.Item().(item.Tool)
 	if !ok {
 		t = item.ToolNone{}
@@ -2167,7 +2525,7 @@ func (p *Player) drops(held item.Stack, b world.Block) []item.Stack {
 	var drops []item.Stack
 	if breakable, ok := b.(block.Breakable); ok && !p.GameMode().CreativeInventory() {
 		if breakable.BreakInfo().Harvestable(t) {
-			drops = breakable.BreakInfo().Drops(t, held.Enchantments())
+			drops = block.Drops(b, loot.Context{Tx: p.tx, Pos: pos, Player: p, Entity: p, Tool: held})
 		}
 	} else if it, ok := b.(world.Item); ok && !p.GameMode().CreativeInventory() {
 		drops = []item.Stack{item.NewStack(it, 1)}
@@ -2229,13 +2587,16 @@ func (p *Player) PickBlock(pos cube.Pos) {
 }
 
 // Teleport teleports the player to a target position in the world. Unlike Move, it immediately changes the
-// position of the player, rather than showing an animation.
+// position of the player, rather than showing an animation. Positions outside the world border are moved to
+// the nearest position inside it.
 func (p *Player) Teleport(pos mgl64.Vec3) {
+	pos = p.tx.World().Border().Clamp(pos)
 	ctx := event.C(p)
 	if p.Handler().HandleTeleport(ctx, pos); ctx.Cancelled() {
 		return
 	}
 	p.Wake()
+	p.Dismount()
 	p.teleport(pos)
 }
 
@@ -2284,6 +2645,14 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 
 	p.data.Pos = res
 	p.data.Rot = resRot
+	if r, ok := p.vehicle(); ok {
+		// The movement of riders is bound to the entity they are riding.
+		p.fallDistance = 0
+		if d, ok := r.(entity.Drivable); ok && p.seat == 0 {
+			d.Drive(p, res.Sub(r.SeatPosition(0).Sub(r.Position())), resRot.Yaw())
+		}
+		return
+	}
 	if deltaPos.Len() <= 3 {
 		// Only update velocity if the player is not moving too fast to prevent potential OOMs.
 		p.data.Vel = deltaPos
@@ -2314,6 +2683,19 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 
 	p.onGround = p.checkOnGround(deltaPos)
 	p.updateFallState(deltaPos[1])
+	if p.onGround && !p.flying && !p.Swimming() {
+		p.AddStatistic(StatDistanceWalked, int64(math.Round(horizontalVel.Len()*100)))
+		if p.stepDistance += horizontalVel.Len(); p.stepDistance >= 1 {
+			p.stepDistance = 0
+			if !p.Sneaking() {
+				p.tx.EmitVibration(world.Vibration{Type: world.VibrationStep, Pos: res, Source: p})
+			}
+		}
+	}
+	p.freezeWater()
+	if p.gliding && (p.onGround || p.insideOfWater()) {
+		p.StopGliding()
+	}
 
 	if p.Swimming() {
 		p.Exhaust(0.01 * horizontalVel.Len())
@@ -2524,6 +2906,28 @@ func (p *Player) OpenBlockContainer(pos cube.Pos, tx *world.Tx) {
 	}
 }
 
+// PopulateLoot calls the Handler of the Player with the loot generated for the container at the position passed,
+// which the Player is opening, breaking or brushing. False is returned if the Handler cancelled the filling of the
+// container. StatLootChestsOpened is only increased for containers with an inventory, such as chests.
+func (p *Player) PopulateLoot(pos cube.Pos, table *string, seed *int64, stacks *[]item.Stack, tx *world.Tx) bool {
+	ctx := event.C(p)
+	if p.Handler().HandleContainerLoot(ctx, pos, table, seed, stacks); ctx.Cancelled() {
+		return false
+	}
+	if _, ok := tx.Block(pos).(block.Container); ok {
+		p.AddStatistic(StatLootChestsOpened, 1)
+	}
+	return true
+}
+
+// OpenTrade opens the trading window of the entity.Trader passed, showing its offers with prices adjusted for
+// the Player. OpenTrade does nothing if the player has no session connected to it.
+func (p *Player) OpenTrade(t entity.Trader) {
+	if p.session() != session.Nop {
+		p.session().OpenTrade(t, p, p.tx)
+	}
+}
+
 // HideEntity hides a world.Entity from the Player so that it can under no circumstance see it. Hidden entities can be
 // made visible again through a call to ShowEntity.
 func (p *Player) HideEntity(e world.Entity) {
@@ -2580,6 +2984,13 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 
 	p.tickFood()
 	p.tickAirSupply()
+	p.tickInsomnia(tx, current)
+	p.tickGlowSquidSpawning(tx, current)
+	p.tickBoggedSpawning(tx, current)
+	if current%20 == 0 {
+		entity.TriggerRaid(p, tx)
+	}
+	entity.TickRaids(tx, current)
 
 	if p.Position()[1] < float64(p.tx.Range()[0]) {
 		p.Hurt(4, entity.VoidDamageSource{})
@@ -2587,6 +2998,10 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 	if p.insideOfSolid() {
 		p.Hurt(1, entity.SuffocationDamageSource{})
 	}
+	border := tx.World().Border()
+	if dmg := border.Damage(p.Position()); dmg > 0 && current%10 == 0 {
+		p.Hurt(dmg, entity.BorderDamageSource{})
+	}
 
 	if p.OnFireDuration() > 0 {
 		p.fireTicks -= 1
@@ -2622,7 +3037,10 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 			delete(p.cooldowns, it)
 		}
 	}
+	p.updateBlocking()
+	p.updateHeldMaps(tx)
 
+	p.session().SendBorder(border, p.Position())
 	p.session().SendDebugShapes(tx.World().Dimension())
 	p.session().SendHudUpdates()
 
@@ -2632,7 +3050,14 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 	p.prevWorld = tx.World()
 
 	if p.session() == session.Nop && !p.Immobile() {
-		m := p.mc.TickMovement(p, p.Position(), p.Velocity(), p.Rotation(), p.tx)
+		var m *entity.Movement
+		if p.gliding {
+			// Gliding movement computes its own gravity and drag, so only
+			// collisions are handled by the movement computer.
+			m = (&entity.MovementComputer{}).TickMovement(p, p.Position(), glideVelocity(p.Velocity(), p.Rotation()), p.Rotation(), p.tx)
+		} else {
+			m = p.mc.TickMovement(p, p.Position(), p.Velocity(), p.Rotation(), p.tx)
+		}
 		m.Send()
 
 		p.data.Vel = m.Velocity()
@@ -2642,6 +3067,44 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 	}
 }
 
+// updateHeldMaps renders the filled maps held by the player around its position
+// and sends the changes to the player.
+func (p *Player) updateHeldMaps(tx *world.Tx) {
+	main, off := p.HeldItems()
+	for _, held := range []item.Stack{main, off} {
+		if m, ok := held.Item().(item.FilledMap); ok {
+			p.session().SendMapUpdate(m.ID, tx, p)
+		}
+	}
+}
+
+// glideVelocity computes the velocity of a gliding entity after one tick,
+// based on its current velocity and rotation. Looking down converts height into
+// speed, while looking up converts speed back into height.
+func glideVelocity(vel mgl64.Vec3, rot cube.Rotation) mgl64.Vec3 {
+	look := rot.Vec3()
+	pitch := mgl64.DegToRad(rot.Pitch())
+	lookHorizontal := math.Sqrt(look[0]*look[0] + look[2]*look[2])
+	velHorizontal := math.Sqrt(vel[0]*vel[0] + vel[2]*vel[2])
+	lift := math.Cos(pitch)
+	lift = lift * lift * math.Min(1, look.Len()/0.4)
+
+	vel[1] += 0.08 * (-1 + lift*0.75)
+	if vel[1] < 0 && lookHorizontal > 0 {
+		k := vel[1] * -0.1 * lift
+		vel = vel.Add(mgl64.Vec3{look[0] * k / lookHorizontal, k, look[2] * k / lookHorizontal})
+	}
+	if pitch < 0 && lookHorizontal > 0 {
+		k := velHorizontal * -math.Sin(pitch) * 0.04
+		vel = vel.Add(mgl64.Vec3{-look[0] * k / lookHorizontal, k * 3.2, -look[2] * k / lookHorizontal})
+	}
+	if lookHorizontal > 0 {
+		vel[0] += (look[0]/lookHorizontal*velHorizontal - vel[0]) * 0.1
+		vel[2] += (look[2]/lookHorizontal*velHorizontal - vel[2]) * 0.1
+	}
+	return mgl64.Vec3{vel[0] * 0.99, vel[1] * 0.98, vel[2] * 0.99}
+}
+
 // tickAirSupply tick's the player's air supply, consuming it when underwater, and replenishing it when out of water.
 func (p *Player) tickAirSupply() {
 	if !p.canBreathe() {
@@ -2865,6 +3328,33 @@ func (p *Player) checkEntityInsiders(entityBBox cube.BBox) {
 	}
 }
 
+// freezeWater turns still water below the player into frosted ice if the player
+// is on the ground and wearing boots enchanted with Frost Walker.
+func (p *Player) freezeWater() {
+	e, ok := p.Armour().Boots().Enchantment(enchantment.FrostWalker)
+	if !ok || !p.onGround {
+		return
+	}
+	r := enchantment.FrostWalker.Radius(e.Level())
+	below := cube.PosFromVec3(p.Position()).Side(cube.FaceDown)
+	for x := -r; x <= r; x++ {
+		for z := -r; z <= r; z++ {
+			if x*x+z*z > r*r {
+				continue
+			}
+			pos := below.Add(cube.Pos{x, 0, z})
+			if w, ok := p.tx.Block(pos).(block.Water); !ok || w.Depth != 8 || w.Falling {
+				continue
+			}
+			if _, ok := p.tx.Block(pos.Side(cube.FaceUp)).(block.Air); !ok {
+				continue
+			}
+			p.tx.SetBlock(pos, block.FrostedIce{}, nil)
+			p.tx.ScheduleBlockUpdate(pos, block.FrostedIce{}, time.Second*3+time.Duration(rand.IntN(60))*time.Second/20)
+		}
+	}
+}
+
 // checkOnGround checks if the player is currently considered to be on the ground.
 func (p *Player) checkOnGround(deltaPos mgl64.Vec3) bool {
 	box := Type.BBox(p).Translate(p.Position()).Extend(mgl64.Vec3{0, -0.05}).Extend(deltaPos.Mul(-1.0))
@@ -3004,6 +3494,45 @@ func (p *Player) TurnLecternPage(pos cube.Pos, page int) error {
 	return nil
 }
 
+// EditStructureBlock edits the structure block at the cube.Pos passed by changing its settings to those of the
+// block.StructureBlock passed. If trigger is true, the structure block saves or loads its structure afterwards,
+// depending on its mode, and the player is sent a message with the result. If no structure block is present, or if
+// the player is not an operator, an error is returned.
+func (p *Player) EditStructureBlock(pos cube.Pos, b block.StructureBlock, trigger bool) error {
+	if _, ok := p.tx.Block(pos).(block.StructureBlock); !ok {
+		return fmt.Errorf("edit structure block: no structure block at position %v", pos)
+	}
+	if p.permissionLevel < 2 {
+		p.resendNearbyBlock(pos)
+		return fmt.Errorf("edit structure block: player is not an operator")
+	}
+
+	ctx := event.C(p)
+	if p.Handler().HandleStructureBlockEdit(ctx, pos, &b, trigger); ctx.Cancelled() {
+		p.resendNearbyBlock(pos)
+		return nil
+	}
+	p.tx.SetBlock(pos, b, nil)
+	if !trigger {
+		return nil
+	}
+	switch b.Mode {
+	case block.StructureBlockSave:
+		if err := b.Save(pos, p.tx); err != nil {
+			p.Messaget(chat.MessageStructureSaveFailed, b.Name)
+			return nil
+		}
+		p.Messaget(chat.MessageStructureSaved, b.Name)
+	case block.StructureBlockLoad:
+		if err := b.Load(pos, p.tx); err != nil {
+			p.Messaget(chat.MessageStructureNotFound, b.Name)
+			return nil
+		}
+		p.Messaget(chat.MessageStructureLoaded, b.Name)
+	}
+	return nil
+}
+
 // updateState updates the state of the player to all viewers of the player.
 func (p *Player) updateState() {
 	for _, v := range p.viewers() {
@@ -3179,6 +3708,7 @@ func (p *Player) close(msg string) {
 func (p *Player) quit(msg string) {
 	p.h.HandleQuit(p)
 	p.h = NopHandler{}
+	p.Dismount()
 
 	if s := p.s; s != nil {
 		s.Disconnect(msg)
@@ -3224,7 +3754,9 @@ func (p *Player) Data() Config {
 		EnderChestInventory: p.enderChest,
 		FireTicks:           p.fireTicks,
 		FallDistance:        p.fallDistance,
+		TimeSinceRestTicks:  p.timeSinceRest,
 		Effects:             p.Effects(),
+		Statistics:          p.Statistics(),
 	}
 }
 
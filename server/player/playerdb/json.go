@@ -35,6 +35,8 @@ func (p *Provider) fromJson(d jsonData, lookupWorld func(world.Dimension) *world
 		Effects:             dataToEffects(d.Effects),
 		FireTicks:           d.FireTicks,
 		FallDistance:        d.FallDistance,
+		TimeSinceRestTicks:  d.TimeSinceRestTicks,
+		Statistics:          make(map[player.Statistic]int64, len(d.Statistics)),
 		Inventory:           inventory.New(36, nil),
 		EnderChestInventory: inventory.New(27, nil),
 		OffHand:             inventory.New(1, nil),
@@ -54,6 +56,9 @@ func (p *Provider) fromJson(d jsonData, lookupWorld func(world.Dimension) *world
 	for slot, stack := range echest {
 		_ = conf.EnderChestInventory.SetItem(slot, stack)
 	}
+	for s, v := range d.Statistics {
+		conf.Statistics[player.Statistic(s)] = v
+	}
 	return conf, lookupWorld(dim)
 }
 
@@ -61,27 +66,32 @@ func (p *Provider) toJson(d player.Config, w *world.World) jsonData {
 	dim, _ := world.DimensionID(w.Dimension())
 	mode, _ := world.GameModeID(d.GameMode)
 	offHand, _ := d.OffHand.Item(0)
+	stats := make(map[string]int64, len(d.Statistics))
+	for s, v := range d.Statistics {
+		stats[string(s)] = v
+	}
 	return jsonData{
-		UUID:            d.UUID.String(),
-		Username:        d.Name,
-		Position:        d.Position,
-		Velocity:        d.Velocity,
-		Yaw:             d.Rotation.Yaw(),
-		Pitch:           d.Rotation.Pitch(),
-		Health:          d.Health,
-		MaxHealth:       d.MaxHealth,
-		Hunger:          d.Food,
-		FoodTick:        d.FoodTick,
-		ExhaustionLevel: d.Exhaustion,
-		SaturationLevel: d.Saturation,
-		Experience:      d.Experience,
-		AirSupply:       d.AirSupply,
-		MaxAirSupply:    d.MaxAirSupply,
-		EnchantmentSeed: d.EnchantmentSeed,
-		GameMode:        uint8(mode),
-		Effects:         effectsToData(d.Effects),
-		FireTicks:       d.FireTicks,
-		FallDistance:    d.FallDistance,
+		UUID:               d.UUID.String(),
+		Username:           d.Name,
+		Position:           d.Position,
+		Velocity:           d.Velocity,
+		Yaw:                d.Rotation.Yaw(),
+		Pitch:              d.Rotation.Pitch(),
+		Health:             d.Health,
+		MaxHealth:          d.MaxHealth,
+		Hunger:             d.Food,
+		FoodTick:           d.FoodTick,
+		ExhaustionLevel:    d.Exhaustion,
+		SaturationLevel:    d.Saturation,
+		Experience:         d.Experience,
+		AirSupply:          d.AirSupply,
+		MaxAirSupply:       d.MaxAirSupply,
+		EnchantmentSeed:    d.EnchantmentSeed,
+		GameMode:           uint8(mode),
+		Effects:            effectsToData(d.Effects),
+		FireTicks:          d.FireTicks,
+		FallDistance:       d.FallDistance,
+		TimeSinceRestTicks: d.TimeSinceRestTicks,
 		Inventory: invToData(InventoryData{
 			Items:        d.Inventory.Slots(),
 			Boots:        d.Armour.Boots(),
@@ -93,6 +103,7 @@ func (p *Provider) toJson(d player.Config, w *world.World) jsonData {
 		}),
 		EnderChestInventory: encodeItems(d.EnderChestInventory.Slots()),
 		Dimension:           uint8(dim),
+		Statistics:          stats,
 	}
 }
 
@@ -115,7 +126,9 @@ type jsonData struct {
 	Effects                          []jsonEffect
 	FireTicks                        int64
 	FallDistance                     float64
+	TimeSinceRestTicks               int64
 	Dimension                        uint8
+	Statistics                       map[string]int64
 }
 
 type jsonInventoryData struct {
@@ -0,0 +1,146 @@
+package scoreboard
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Objective is a named set of scores, such as the number of blocks mined by
+// every player on a server. Objectives may be used to build leaderboards,
+// which may be shown to players using Objective.Sidebar. Objective is safe for
+// concurrent use. Objective implements the json.Marshaler and
+// json.Unmarshaler interfaces, so that its scores may be saved and loaded.
+type Objective struct {
+	name        string
+	displayName string
+
+	mu     sync.RWMutex
+	scores map[string]int64
+}
+
+// NewObjective creates a new Objective with the name and display name passed.
+// The display name is shown as the title of the sidebar of the Objective.
+func NewObjective(name, displayName string) *Objective {
+	return &Objective{name: name, displayName: displayName, scores: map[string]int64{}}
+}
+
+// Name returns the name of the Objective.
+func (o *Objective) Name() string {
+	return o.name
+}
+
+// DisplayName returns the display name of the Objective.
+func (o *Objective) DisplayName() string {
+	return o.displayName
+}
+
+// Score returns the score of the entry passed, such as the name of a player.
+// False is returned if the entry has no score.
+func (o *Objective) Score(entry string) (int64, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	score, ok := o.scores[entry]
+	return score, ok
+}
+
+// Set sets the score of the entry passed.
+func (o *Objective) Set(entry string, score int64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.scores[entry] = score
+}
+
+// Add adds n to the score of the entry passed and returns the new score.
+// Entries without a score start with a score of 0.
+func (o *Objective) Add(entry string, n int64) int64 {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.scores[entry] += n
+	return o.scores[entry]
+}
+
+// Remove removes the score of the entry passed.
+func (o *Objective) Remove(entry string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.scores, entry)
+}
+
+// Scores returns the scores of all entries of the Objective.
+func (o *Objective) Scores() map[string]int64 {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return maps.Clone(o.scores)
+}
+
+// Score is the score of an entry of an Objective.
+type Score struct {
+	// Entry is the entry that holds the score, such as the name of a player.
+	Entry string
+	// Value is the value of the score.
+	Value int64
+}
+
+// Top returns up to n scores of the Objective, sorted from highest to lowest.
+// Scores that are equal are sorted by their entries.
+func (o *Objective) Top(n int) []Score {
+	o.mu.RLock()
+	scores := make([]Score, 0, len(o.scores))
+	for entry, value := range o.scores {
+		scores = append(scores, Score{Entry: entry, Value: value})
+	}
+	o.mu.RUnlock()
+
+	slices.SortFunc(scores, func(a, b Score) int {
+		if c := cmp.Compare(b.Value, a.Value); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Entry, b.Entry)
+	})
+	return scores[:min(n, len(scores))]
+}
+
+// Sidebar returns a Scoreboard with the display name of the Objective as its
+// title and a line for each of the top n scores, up to the maximum of 15
+// lines of a Scoreboard. The Scoreboard may be sent to a player using
+// Player.SendScoreboard to show the leaderboard of the Objective. The
+// Scoreboard is not updated when scores change, so it must be created and
+// sent again to show new scores.
+func (o *Objective) Sidebar(n int) *Scoreboard {
+	board := New(o.displayName)
+	for i, s := range o.Top(min(n, 15)) {
+		board.Set(i, fmt.Sprintf("%v: %v", s.Entry, s.Value))
+	}
+	return board
+}
+
+// objectiveData is the data of an Objective as it is encoded to JSON.
+type objectiveData struct {
+	Name        string           `json:"name"`
+	DisplayName string           `json:"display_name"`
+	Scores      map[string]int64 `json:"scores"`
+}
+
+// MarshalJSON ...
+func (o *Objective) MarshalJSON() ([]byte, error) {
+	return json.Marshal(objectiveData{Name: o.name, DisplayName: o.displayName, Scores: o.Scores()})
+}
+
+// UnmarshalJSON ...
+func (o *Objective) UnmarshalJSON(b []byte) error {
+	var data objectiveData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	if data.Scores == nil {
+		data.Scores = map[string]int64{}
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.name, o.displayName, o.scores = data.Name, data.DisplayName, data.Scores
+	return nil
+}
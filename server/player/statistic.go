@@ -0,0 +1,42 @@
+package player
+
+import (
+	"maps"
+)
+
+// Statistic is a statistic tracked for a Player, such as the number of blocks
+// that the Player mined. Statistics are saved with the data of the Player.
+// Plugins may track statistics of their own by using a Statistic not defined
+// in this package with Player.AddStatistic.
+type Statistic string
+
+const (
+	// StatBlocksMined is the number of blocks that the Player broke.
+	StatBlocksMined Statistic = "blocks_mined"
+	// StatLootChestsOpened is the number of containers with a loot table,
+	// such as dungeon chests, that the Player opened first.
+	StatLootChestsOpened Statistic = "loot_chests_opened"
+	// StatDistanceWalked is the distance in centimetres that the Player
+	// walked or sprinted on the ground.
+	StatDistanceWalked Statistic = "distance_walked"
+)
+
+// Statistic returns the current value of the Statistic passed for the Player.
+func (p *Player) Statistic(s Statistic) int64 {
+	return p.statistics[s]
+}
+
+// Statistics returns all statistics of the Player with their values.
+func (p *Player) Statistics() map[Statistic]int64 {
+	return maps.Clone(p.statistics)
+}
+
+// AddStatistic adds n to the value of the Statistic passed for the Player.
+func (p *Player) AddStatistic(s Statistic, n int64) {
+	p.statistics[s] += n
+}
+
+// SetStatistic sets the value of the Statistic passed for the Player to n.
+func (p *Player) SetStatistic(s Statistic, n int64) {
+	p.statistics[s] = n
+}
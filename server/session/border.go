@@ -0,0 +1,92 @@
+package session
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/player/debug"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// borderRenderDistance is the distance in blocks from a side of the world
+// border within which the side is rendered to a player.
+const borderRenderDistance = 8
+
+var (
+	// borderColour is the colour of the world border while it is not being
+	// resized.
+	borderColour = color.RGBA{R: 0x20, G: 0xa0, B: 0xff, A: 0xff}
+	// shrinkingBorderColour and growingBorderColour are the colours of the
+	// world border while it is shrinking and growing respectively.
+	shrinkingBorderColour = color.RGBA{R: 0xff, G: 0x30, B: 0x20, A: 0xff}
+	growingBorderColour   = color.RGBA{R: 0x40, G: 0xff, B: 0x80, A: 0xff}
+)
+
+// SendBorder renders the sides of the world.Border passed that are close to
+// the position of the player passed. Because the client has no world border of
+// its own, the sides are drawn as a grid of debug lines, which is only updated
+// when the border changes or the player moves to a different block.
+func (s *Session) SendBorder(b world.Border, pos mgl64.Vec3) {
+	if s == Nop {
+		return
+	}
+	anchor := cube.PosFromVec3(pos)
+	if b == s.border && anchor == s.borderAnchor {
+		return
+	}
+	s.border, s.borderAnchor = b, anchor
+
+	var segments [][2]mgl64.Vec3
+	if b.Enabled() {
+		half, y := b.Size/2, float64(anchor[1])
+		// side adds the lines of a side of the border at the coordinate c on
+		// one horizontal axis, spanning from lo to hi on the other axis.
+		// vec turns a coordinate on the axis of the side and one along it
+		// into a position.
+		side := func(c, lo, hi, along float64, vec func(c, along, y float64) mgl64.Vec3) {
+			from, to := math.Max(along-borderRenderDistance, lo), math.Min(along+borderRenderDistance, hi)
+			if from > to {
+				return
+			}
+			for dy := -3.0; dy <= 4; dy++ {
+				segments = append(segments, [2]mgl64.Vec3{vec(c, from, y+dy), vec(c, to, y+dy)})
+			}
+			for a := math.Ceil(from/2) * 2; a <= to; a += 2 {
+				segments = append(segments, [2]mgl64.Vec3{vec(c, a, y-3), vec(c, a, y+4)})
+			}
+		}
+		xSide := func(c, along, y float64) mgl64.Vec3 { return mgl64.Vec3{c, y, along} }
+		zSide := func(c, along, y float64) mgl64.Vec3 { return mgl64.Vec3{along, y, c} }
+		for _, x := range []float64{b.Centre[0] - half, b.Centre[0] + half} {
+			if math.Abs(pos[0]-x) <= borderRenderDistance {
+				side(x, b.Centre[1]-half, b.Centre[1]+half, pos[2], xSide)
+			}
+		}
+		for _, z := range []float64{b.Centre[1] - half, b.Centre[1] + half} {
+			if math.Abs(pos[2]-z) <= borderRenderDistance {
+				side(z, b.Centre[0]-half, b.Centre[0]+half, pos[0], zSide)
+			}
+		}
+	}
+
+	colour := borderColour
+	if b.ResizeTicks > 0 && b.TargetSize < b.Size {
+		colour = shrinkingBorderColour
+	} else if b.ResizeTicks > 0 && b.TargetSize > b.Size {
+		colour = growingBorderColour
+	}
+	for i, seg := range segments {
+		if i == len(s.borderLines) {
+			s.borderLines = append(s.borderLines, &debug.Line{})
+		}
+		l := s.borderLines[i]
+		l.Position, l.EndPosition, l.Colour = seg[0], seg[1], colour
+		s.AddDebugShape(l)
+	}
+	for _, l := range s.borderLines[len(segments):] {
+		s.RemoveDebugShape(l)
+	}
+	s.borderLines = s.borderLines[:len(segments)]
+}
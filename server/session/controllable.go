@@ -1,6 +1,7 @@
 package session
 
 import (
+	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/entity/effect"
@@ -46,6 +47,8 @@ type Controllable interface {
 	Sleep(pos cube.Pos)
 	Wake()
 
+	Dismount()
+
 	Chat(msg ...any)
 	ExecuteCommand(commandLine string)
 	GameMode() world.GameMode
@@ -108,6 +111,7 @@ type Controllable interface {
 	OpenSign(pos cube.Pos, frontSide bool)
 	EditSign(pos cube.Pos, frontText, backText string) error
 	TurnLecternPage(pos cube.Pos, page int) error
+	EditStructureBlock(pos cube.Pos, b block.StructureBlock, trigger bool) error
 
 	EnderChestInventory() *inventory.Inventory
 	MoveItemsToInventory()
@@ -59,6 +59,46 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if gl, ok := e.(glider); ok && gl.Gliding() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagGliding)
 	}
+	if bl, ok := e.(blocker); ok && bl.Blocking() {
+		m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagBlocking&63)
+	}
+	if d, ok := e.(dancer); ok && d.Dancing() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagDancing)
+	}
+	if r, ok := e.(rammer); ok && r.Ramming() {
+		m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagRamAttack&63)
+	}
+	if pd, ok := e.(deadPlayer); ok && pd.PlayingDead() {
+		m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagPlayingDead&63)
+	}
+	if r, ok := e.(rider); ok {
+		if _, riding := r.Riding(); riding {
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagRiding)
+		}
+	}
+	if si, ok := e.(sitter); ok && si.Sitting() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSitting)
+	}
+	if sa, ok := e.(saddled); ok && sa.Saddled() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSaddled)
+	}
+	if sh, ok := e.(sheared); ok && sh.Sheared() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSheared)
+	}
+	if da, ok := e.(dasher); ok && da.DashCoolingDown() {
+		m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagHasDashTimeout&63)
+	}
+	if sn, ok := e.(sniffer); ok {
+		if sn.Sniffing() {
+			m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagSniffing&63)
+		}
+		if sn.Searching() {
+			m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagSearching&63)
+		}
+		if sn.Digging() {
+			m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagDigging&63)
+		}
+	}
 	if bb, ok := e.(baby); ok && bb.Baby() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagBaby)
 	}
@@ -100,6 +140,12 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	} else if o, ok := e.(owned); ok && o.Owner() != nil {
 		m[protocol.EntityDataKeyOwner] = int64(s.handleRuntimeID(o.Owner()))
 	}
+	if l, ok := e.(leashable); ok {
+		if holder, ok := l.LeashHolder(); ok {
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagLeashed)
+			m[protocol.EntityDataKeyLeashHolder] = int64(s.handleRuntimeID(holder))
+		}
+	}
 	if sc, ok := e.(scaled); ok {
 		m[protocol.EntityDataKeyScale] = float32(sc.Scale())
 	}
@@ -179,6 +225,9 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if mv, ok := e.(markVariable); ok {
 		m[protocol.EntityDataKeyMarkVariant] = mv.MarkVariant()
 	}
+	if h, ok := e.(horned); ok {
+		m[protocol.EntityDataKeyGoatHornCount] = int32(h.HornCount())
+	}
 }
 
 type sneaker interface {
@@ -201,6 +250,10 @@ type glider interface {
 	Gliding() bool
 }
 
+type blocker interface {
+	Blocking() bool
+}
+
 type baby interface {
 	Baby() bool
 }
@@ -223,6 +276,10 @@ type scaled interface {
 	Scale() float64
 }
 
+type leashable interface {
+	LeashHolder() (*world.EntityHandle, bool)
+}
+
 type owned interface {
 	Owner() *world.EntityHandle
 }
@@ -297,3 +354,50 @@ type variable interface {
 type markVariable interface {
 	MarkVariant() int32
 }
+
+type dancer interface {
+	Dancing() bool
+}
+
+type rammer interface {
+	Ramming() bool
+}
+
+type horned interface {
+	HornCount() int
+}
+
+type deadPlayer interface {
+	PlayingDead() bool
+}
+
+type rider interface {
+	Riding() (*world.EntityHandle, bool)
+}
+
+type driver interface {
+	rider
+	Driving() bool
+}
+
+type sitter interface {
+	Sitting() bool
+}
+
+type saddled interface {
+	Saddled() bool
+}
+
+type sheared interface {
+	Sheared() bool
+}
+
+type dasher interface {
+	DashCoolingDown() bool
+}
+
+type sniffer interface {
+	Sniffing() bool
+	Searching() bool
+	Digging() bool
+}
@@ -0,0 +1,80 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+)
+
+const (
+	// cartographyInputSlot is the slot index of the input item in the cartography table.
+	cartographyInputSlot = 0x0c
+	// cartographyAdditionalSlot is the slot index of the additional item in the cartography table.
+	cartographyAdditionalSlot = 0x0d
+)
+
+// handleCartography handles a CraftRecipe stack request action made using a cartography table. The result depends
+// on the items put in the table: A filled map is zoomed out with paper, cloned with an empty map and locked with a
+// glass pane. Paper on its own is turned into an empty map.
+func (h *ItemStackRequestHandler) handleCartography(s *Session, tx *world.Tx) error {
+	input, _ := h.itemInSlot(protocol.StackRequestSlotInfo{
+		Container: protocol.FullContainerName{ContainerID: protocol.ContainerCartographyInput},
+		Slot:      cartographyInputSlot,
+	}, s, tx)
+	additional, _ := h.itemInSlot(protocol.StackRequestSlotInfo{
+		Container: protocol.FullContainerName{ContainerID: protocol.ContainerCartographyAdditional},
+		Slot:      cartographyAdditionalSlot,
+	}, s, tx)
+	if input.Empty() {
+		return fmt.Errorf("no item in input slot")
+	}
+
+	var result item.Stack
+	switch it := input.Item().(type) {
+	case item.Paper:
+		if !additional.Empty() {
+			return fmt.Errorf("paper cannot be combined with %v", additional)
+		}
+		result = item.NewStack(item.EmptyMap{}, 1)
+	case item.FilledMap:
+		data, ok := it.Data(tx)
+		if !ok {
+			return fmt.Errorf("map %v does not exist", it.ID)
+		}
+		switch additional.Item().(type) {
+		case item.Paper:
+			if data.Locked || data.Scale >= world.MaxMapScale {
+				return fmt.Errorf("map %v cannot be zoomed out any further", it.ID)
+			}
+			zoomed := world.NewMapData(data.Centre, data.Scale+1, data.Dimension)
+			result = input.Grow(1 - input.Count()).WithItem(item.FilledMap{ID: tx.AddMap(zoomed)})
+		case item.EmptyMap:
+			result = input.Grow(2 - input.Count())
+		case block.GlassPane:
+			if data.Locked {
+				return fmt.Errorf("map %v is already locked", it.ID)
+			}
+			locked := data.Clone()
+			locked.Locked = true
+			result = input.Grow(1 - input.Count()).WithItem(item.FilledMap{ID: tx.AddMap(locked)})
+		default:
+			return fmt.Errorf("filled map cannot be combined with %v", additional)
+		}
+	default:
+		return fmt.Errorf("input item %v cannot be used in a cartography table", input)
+	}
+
+	h.setItemInSlot(protocol.StackRequestSlotInfo{
+		Container: protocol.FullContainerName{ContainerID: protocol.ContainerCartographyInput},
+		Slot:      cartographyInputSlot,
+	}, input.Grow(-1), s, tx)
+	if !additional.Empty() {
+		h.setItemInSlot(protocol.StackRequestSlotInfo{
+			Container: protocol.FullContainerName{ContainerID: protocol.ContainerCartographyAdditional},
+			Slot:      cartographyAdditionalSlot,
+		}, additional.Grow(-1), s, tx)
+	}
+	return h.createResults(s, tx, result)
+}
@@ -87,6 +87,10 @@ func (h *ItemStackRequestHandler) handleRequest(req protocol.ItemStackRequest, s
 		case *protocol.BeaconPaymentStackRequestAction:
 			err = h.handleBeaconPayment(a, s, tx)
 		case *protocol.CraftRecipeStackRequestAction:
+			if s.openedTrader.Load() != nil {
+				err = h.handleTrade(a, s, tx, c)
+				break
+			}
 			if s.containerOpened.Load() {
 				var special bool
 				switch tx.Block(*s.openedPos.Load()).(type) {
@@ -96,6 +100,8 @@ func (h *ItemStackRequestHandler) handleRequest(req protocol.ItemStackRequest, s
 					err, special = h.handleStonecutting(a, s, tx), true
 				case block.EnchantingTable:
 					err, special = h.handleEnchant(a, s, tx, c), true
+				case block.CartographyTable:
+					err, special = h.handleCartography(s, tx), true
 				}
 				if special {
 					// This was a "special action" and was handled, so we can move onto the next action.
@@ -0,0 +1,25 @@
+package session
+
+import (
+	"image"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// MapInfoRequestHandler handles the MapInfoRequest packet.
+type MapInfoRequestHandler struct{}
+
+// Handle ...
+func (h *MapInfoRequestHandler) Handle(p packet.Packet, s *Session, tx *world.Tx, c Controllable) error {
+	pk := p.(*packet.MapInfoRequest)
+
+	m, ok := tx.Map(pk.MapID)
+	if !ok {
+		// The map may have been created in a different world, or the item was
+		// created without ever being used. Either way, there is nothing to show.
+		return nil
+	}
+	s.sendMapData(pk.MapID, m, image.Rect(0, 0, world.MapSize, world.MapSize), tx, c, packet.MapUpdateFlagInitialisation)
+	return nil
+}
@@ -0,0 +1,58 @@
+package session
+
+import (
+	"fmt"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/structure"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// StructureBlockUpdateHandler handles the StructureBlockUpdate packet, sent when a player changes the settings of a
+// structure block or presses the button to save or load its structure.
+type StructureBlockUpdateHandler struct{}
+
+// Handle ...
+func (StructureBlockUpdateHandler) Handle(p packet.Packet, _ *Session, tx *world.Tx, c Controllable) error {
+	pk := p.(*packet.StructureBlockUpdate)
+	pos := blockPosFromProtocol(pk.Position)
+	if !canReach(c, pos.Vec3Middle()) {
+		return fmt.Errorf("block at %v is not within reach", pos)
+	}
+	if !c.GameMode().CreativeInventory() {
+		return fmt.Errorf("structure block can only be edited in creative mode")
+	}
+	b, ok := tx.Block(pos).(block.StructureBlock)
+	if !ok {
+		return fmt.Errorf("block at %v is not a structure block", pos)
+	}
+	if pk.StructureBlockType < int32(block.StructureBlockData) || pk.StructureBlockType > int32(block.StructureBlockCorner) {
+		return fmt.Errorf("invalid structure block type %v", pk.StructureBlockType)
+	}
+	if pk.Settings.Rotation > byte(structure.Rotation270) {
+		return fmt.Errorf("invalid structure rotation %v", pk.Settings.Rotation)
+	}
+	b.Mode = block.StructureBlockMode(pk.StructureBlockType)
+	b.Name, b.DataField, b.ShowBoundingBox = pk.StructureName, pk.DataField, pk.ShowBoundingBox
+	b.Offset = blockPosFromProtocol(pk.Settings.Offset)
+	b.Size = [3]int(blockPosFromProtocol(pk.Settings.Size))
+	b.Rotation, b.Mirror = structureTransform(pk.Settings)
+	return c.EditStructureBlock(pos, b, pk.ShouldTrigger)
+}
+
+// structureTransform returns the rotation and mirror held by the protocol.StructureSettings passed. Mirroring
+// along both axes is the same as rotating by 180 degrees, so it is returned as such.
+func structureTransform(settings protocol.StructureSettings) (structure.Rotation, structure.Mirror) {
+	rotation := structure.Rotation(settings.Rotation % 4)
+	switch settings.Mirror {
+	case protocol.StructureMirrorXAxis:
+		return rotation, structure.MirrorX
+	case protocol.StructureMirrorZAxis:
+		return rotation, structure.MirrorZ
+	case protocol.StructureMirrorBothAxes:
+		return (rotation + structure.Rotation180) % 4, structure.MirrorNone
+	}
+	return rotation, structure.MirrorNone
+}
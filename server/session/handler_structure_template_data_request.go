@@ -0,0 +1,44 @@
+package session
+
+import (
+	"fmt"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/structure"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// StructureTemplateDataRequestHandler handles the StructureTemplateDataRequest packet, sent when a player presses
+// the button to export the area of a structure block to a .mcstructure file.
+type StructureTemplateDataRequestHandler struct{}
+
+// Handle ...
+func (StructureTemplateDataRequestHandler) Handle(p packet.Packet, s *Session, tx *world.Tx, c Controllable) error {
+	pk := p.(*packet.StructureTemplateDataRequest)
+	pos := blockPosFromProtocol(pk.Position)
+	if !canReach(c, pos.Vec3Middle()) {
+		return fmt.Errorf("block at %v is not within reach", pos)
+	}
+	if !c.GameMode().CreativeInventory() {
+		return fmt.Errorf("structure can only be exported in creative mode")
+	}
+	if _, ok := tx.Block(pos).(block.StructureBlock); !ok {
+		return fmt.Errorf("block at %v is not a structure block", pos)
+	}
+	resp := &packet.StructureTemplateDataResponse{StructureName: pk.StructureName, ResponseType: packet.StructureTemplateResponseExport}
+	if pk.RequestType != packet.StructureTemplateRequestExportFromSave {
+		s.writePacket(resp)
+		return nil
+	}
+	size := [3]int(blockPosFromProtocol(pk.Settings.Size))
+	if size[0] <= 0 || size[1] <= 0 || size[2] <= 0 || size[1] > tx.Range().Height() {
+		s.writePacket(resp)
+		return nil
+	}
+	rotation, mirror := structureTransform(pk.Settings)
+	st := structure.Capture(tx, pos.Add(blockPosFromProtocol(pk.Settings.Offset)), size).Mirror(mirror).Rotate(rotation)
+	resp.Success, resp.StructureTemplate = true, st.EncodeNBT()
+	s.writePacket(resp)
+	return nil
+}
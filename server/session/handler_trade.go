@@ -0,0 +1,65 @@
+package session
+
+import (
+	"fmt"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+)
+
+// tradeIngredientSlots holds the slots of the first and second item wanted by a trader in the trading window.
+var tradeIngredientSlots = [...]protocol.StackRequestSlotInfo{
+	{Container: protocol.FullContainerName{ContainerID: protocol.ContainerTradeTwoIngredientOne}, Slot: 0x04},
+	{Container: protocol.FullContainerName{ContainerID: protocol.ContainerTradeTwoIngredientTwo}, Slot: 0x05},
+}
+
+// tradeNetworkID returns the recipe network ID of the trade offer at the index passed. Trade offers are numbered
+// after the recipes sent to the client, so that they never overlap.
+func tradeNetworkID(s *Session, index int) uint32 {
+	return uint32(len(s.recipes) + 1 + index)
+}
+
+// handleTrade handles a CraftRecipe stack request action made using the trading window of a trader.
+func (h *ItemStackRequestHandler) handleTrade(a *protocol.CraftRecipeStackRequestAction, s *Session, tx *world.Tx, c Controllable) error {
+	ent, ok := s.openedTrader.Load().Entity(tx)
+	if !ok {
+		return fmt.Errorf("trader is no longer in the world")
+	}
+	t := ent.(entity.Trader)
+
+	offers := t.Offers(c)
+	index := int(a.RecipeNetworkID) - int(tradeNetworkID(s, 0))
+	if index < 0 || index >= len(offers) {
+		return fmt.Errorf("trade with network id %v does not exist", a.RecipeNetworkID)
+	}
+	timesTraded := int(a.NumberOfCrafts)
+	if timesTraded < 1 {
+		return fmt.Errorf("times traded must be at least 1")
+	}
+
+	offer := offers[index]
+	if len(offer.Input) > len(tradeIngredientSlots) {
+		return fmt.Errorf("trade with network id %v wants too many items", a.RecipeNetworkID)
+	}
+	inputs := make([]item.Stack, len(offer.Input))
+	for i, wanted := range offer.Input {
+		inputs[i], _ = h.itemInSlot(tradeIngredientSlots[i], s, tx)
+		if !inputs[i].Comparable(wanted) {
+			return fmt.Errorf("input item %v is not the same as wanted item %v", inputs[i], wanted)
+		}
+		if inputs[i].Count() < wanted.Count()*timesTraded {
+			return fmt.Errorf("input item count is less than price of trade")
+		}
+	}
+	if !t.Trade(c, index, timesTraded) {
+		return fmt.Errorf("trade with network id %v could not be used", a.RecipeNetworkID)
+	}
+	for i, wanted := range offer.Input {
+		h.setItemInSlot(tradeIngredientSlots[i], inputs[i].Grow(-wanted.Count()*timesTraded), s, tx)
+	}
+	// Send the offers again so that the client shows the new uses and tier of the trader.
+	defer s.sendTrade(byte(s.openedWindowID.Load()), t, c)
+	return h.createResults(s, tx, repeatStacks([]item.Stack{offer.Output}, timesTraded)...)
+}
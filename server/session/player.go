@@ -3,6 +3,7 @@ package session
 import (
 	"encoding/json"
 	"fmt"
+	"image"
 	"image/color"
 	"maps"
 	"math"
@@ -12,6 +13,7 @@ import (
 	_ "unsafe" // Imported for compiler directives.
 
 	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
@@ -72,15 +74,30 @@ func (s *Session) closeCurrentContainer(tx *world.Tx) {
 	}
 	s.closeWindow()
 
+	if h := s.openedTrader.Swap(nil); h != nil {
+		if ent, ok := h.Entity(tx); ok {
+			ent.(entity.Trader).StopTrading()
+		}
+		return
+	}
 	pos := *s.openedPos.Load()
 	b := tx.Block(pos)
 	if container, ok := b.(block.Container); ok {
 		container.RemoveViewer(s, tx, pos)
+		s.emitContainerVibration(world.VibrationContainerClose, pos, tx)
 	} else if enderChest, ok := b.(block.EnderChest); ok {
 		enderChest.RemoveViewer(tx, pos)
+		s.emitContainerVibration(world.VibrationContainerClose, pos, tx)
 	}
 }
 
+// emitContainerVibration emits a vibration of the type passed at the
+// container at the position passed, caused by the entity of the Session.
+func (s *Session) emitContainerVibration(t world.VibrationType, pos cube.Pos, tx *world.Tx) {
+	e, _ := s.ent.Entity(tx)
+	tx.EmitVibration(world.Vibration{Type: t, Pos: pos.Vec3Centre(), Source: e})
+}
+
 // SendRespawn spawns the Controllable entity of the session client-side in the world, provided it has died.
 func (s *Session) SendRespawn(pos mgl64.Vec3, c Controllable) {
 	s.writePacket(&packet.Respawn{
@@ -284,6 +301,11 @@ func (s *Session) invByID(id int32, tx *world.Tx) (*inventory.Inventory, bool) {
 			return nil, false
 		}
 		switch id {
+		case protocol.ContainerTradeIngredientOne, protocol.ContainerTradeIngredientTwo,
+			protocol.ContainerTradeTwoIngredientOne, protocol.ContainerTradeTwoIngredientTwo:
+			if s.openedTrader.Load() != nil {
+				return s.ui, true
+			}
 		case protocol.ContainerLevelEntity:
 			return s.openedWindow.Load(), true
 		case protocol.ContainerBarrel:
@@ -310,6 +332,10 @@ func (s *Session) invByID(id int32, tx *world.Tx) (*inventory.Inventory, bool) {
 			if _, loom := tx.Block(*s.openedPos.Load()).(block.Loom); loom {
 				return s.ui, true
 			}
+		case protocol.ContainerCartographyInput, protocol.ContainerCartographyAdditional:
+			if _, ok := tx.Block(*s.openedPos.Load()).(block.CartographyTable); ok {
+				return s.ui, true
+			}
 		case protocol.ContainerStonecutterInput:
 			if _, ok := tx.Block(*s.openedPos.Load()).(block.Stonecutter); ok {
 				return s.ui, true
@@ -850,6 +876,94 @@ func (s *Session) SendHudUpdates() {
 	}
 }
 
+// SendMapUpdate updates the map with the ID passed around the position of the Controllable, which is holding the map,
+// and sends the pixels that changed along with the marker of the Controllable.
+func (s *Session) SendMapUpdate(id int64, tx *world.Tx, c Controllable) {
+	if s == Nop {
+		return
+	}
+	m, ok := tx.Map(id)
+	if !ok {
+		return
+	}
+	area := tx.UpdateMap(id, c.Position())
+	s.sendMapData(id, m, area, tx, c, 0)
+}
+
+// sendMapData sends the pixels within the area passed of the map with the ID passed, together with a marker for the
+// Controllable passed. Additional update flags may be passed to be sent along.
+func (s *Session) sendMapData(id int64, m world.MapData, area image.Rectangle, tx *world.Tx, c Controllable, flags uint32) {
+	dim, _ := world.DimensionID(m.Dimension)
+	pk := &packet.ClientBoundMapItemData{
+		MapID:          id,
+		UpdateFlags:    flags | packet.MapUpdateFlagDecoration,
+		Dimension:      byte(dim),
+		LockedMap:      m.Locked,
+		Origin:         protocol.BlockPos{int32(m.Centre.X()), int32(m.Centre.Y()), int32(m.Centre.Z())},
+		Scale:          m.Scale,
+		MapsIncludedIn: []int64{id},
+	}
+	if flags&packet.MapUpdateFlagInitialisation == 0 {
+		pk.MapsIncludedIn = nil
+	}
+	if m.Dimension == tx.World().Dimension() {
+		pk.Decorations = []protocol.MapDecoration{mapMarker(m, c.Position(), c.Rotation().Yaw())}
+	}
+	for _, marker := range m.Markers {
+		if d, ok := fixedMapMarker(m, marker); ok {
+			pk.Decorations = append(pk.Decorations, d)
+		}
+	}
+	if !area.Empty() {
+		pk.UpdateFlags |= packet.MapUpdateFlagTexture
+		pk.XOffset, pk.YOffset = int32(area.Min.X), int32(area.Min.Y)
+		pk.Width, pk.Height = int32(area.Dx()), int32(area.Dy())
+		pk.Pixels = make([]color.RGBA, 0, area.Dx()*area.Dy())
+		for y := area.Min.Y; y < area.Max.Y; y++ {
+			pk.Pixels = append(pk.Pixels, m.Pixels[y*world.MapSize+area.Min.X:y*world.MapSize+area.Max.X]...)
+		}
+	}
+	s.writePacket(pk)
+}
+
+// mapMarker returns the map decoration that marks a player at the position and with the yaw passed. If the
+// position is not displayed on the map, an off-map marker is placed at the edge of the map instead.
+func mapMarker(m world.MapData, pos mgl64.Vec3, yaw float64) protocol.MapDecoration {
+	p := m.PixelPos(pos).Sub(mgl64.Vec2{world.MapSize / 2, world.MapSize / 2}).Mul(2)
+	d := protocol.MapDecoration{Type: protocol.MapDecorationTypeMarkerWhite, Colour: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}}
+	if p[0] < -128 || p[0] > 127 || p[1] < -128 || p[1] > 127 {
+		d.Type = protocol.MapDecorationTypeSquareWhite
+	} else {
+		if yaw < 0 {
+			yaw -= 11.25
+		} else {
+			yaw += 11.25
+		}
+		d.Rotation = byte(int(yaw*16/360) & 15)
+	}
+	d.X, d.Y = byte(int8(mgl64.Clamp(p[0], -128, 127))), byte(int8(mgl64.Clamp(p[1], -128, 127)))
+	return d
+}
+
+// fixedMapMarker returns the map decoration displaying the world.MapMarker passed. If the position of the
+// marker is not displayed on the map, false is returned.
+func fixedMapMarker(m world.MapData, marker world.MapMarker) (protocol.MapDecoration, bool) {
+	p := m.PixelPos(marker.Pos.Vec3Centre()).Sub(mgl64.Vec2{world.MapSize / 2, world.MapSize / 2}).Mul(2)
+	if p[0] < -128 || p[0] > 127 || p[1] < -128 || p[1] > 127 {
+		return protocol.MapDecoration{}, false
+	}
+	d := protocol.MapDecoration{X: byte(int8(p[0])), Y: byte(int8(p[1])), Colour: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}}
+	switch marker.Type {
+	case world.MapMarkerTreasure:
+		d.Type, d.Colour = protocol.MapDecorationTypeCrossWhite, color.RGBA{R: 0xb0, G: 0x2e, B: 0x26, A: 0xff}
+	case world.MapMarkerMonument:
+		d.Type = protocol.MapDecorationTypeMonument
+	case world.MapMarkerMansion:
+		d.Type = protocol.MapDecorationTypeMansion
+	}
+	return d, true
+}
+
 // AddDebugShape adds a debug shape to be rendered to the player. If the shape already exists, it will be
 // updated with the new information.
 func (s *Session) AddDebugShape(shape debug.Shape) {
@@ -77,6 +77,7 @@ type Session struct {
 	openedContainerID              atomic.Uint32
 	openedWindow                   atomic.Pointer[inventory.Inventory]
 	openedPos                      atomic.Pointer[cube.Pos]
+	openedTrader                   atomic.Pointer[world.EntityHandle]
 	swingingArm                    atomic.Bool
 	changingSlot                   atomic.Bool
 	changingDimension              atomic.Bool
@@ -100,6 +101,12 @@ type Session struct {
 	debugShapesAdd    chan debug.Shape
 	debugShapesRemove chan int
 
+	// border and borderAnchor are the world.Border and block position of the
+	// player that the lines in borderLines were last rendered for.
+	border       world.Border
+	borderAnchor cube.Pos
+	borderLines  []*debug.Line
+
 	closeBackground chan struct{}
 }
 
@@ -507,39 +514,42 @@ func (s *Session) handlePacket(pk packet.Packet, tx *world.Tx, c Controllable) (
 // registerHandlers registers all packet handlers found in the packetHandler package.
 func (s *Session) registerHandlers() {
 	s.handlers = map[uint32]packetHandler{
-		packet.IDActorEvent:                nil,
-		packet.IDAdventureSettings:         nil, // Deprecated, the client still sends this though.
-		packet.IDAnimate:                   nil,
-		packet.IDAnvilDamage:               nil,
-		packet.IDBlockActorData:            &BlockActorDataHandler{},
-		packet.IDBlockPickRequest:          &BlockPickRequestHandler{},
-		packet.IDBookEdit:                  &BookEditHandler{},
-		packet.IDBossEvent:                 nil,
-		packet.IDClientCacheBlobStatus:     &ClientCacheBlobStatusHandler{},
-		packet.IDCommandRequest:            &CommandRequestHandler{},
-		packet.IDContainerClose:            &ContainerCloseHandler{},
-		packet.IDEmote:                     &EmoteHandler{},
-		packet.IDEmoteList:                 nil,
-		packet.IDFilterText:                nil,
-		packet.IDInteract:                  &InteractHandler{},
-		packet.IDInventoryTransaction:      &InventoryTransactionHandler{},
-		packet.IDItemStackRequest:          &ItemStackRequestHandler{changes: map[byte]map[byte]changeInfo{}, responseChanges: map[int32]map[*inventory.Inventory]map[byte]responseChange{}},
-		packet.IDLecternUpdate:             &LecternUpdateHandler{},
-		packet.IDMobEquipment:              &MobEquipmentHandler{},
-		packet.IDModalFormResponse:         &ModalFormResponseHandler{forms: make(map[uint32]form.Form)},
-		packet.IDMovePlayer:                nil,
-		packet.IDNPCRequest:                &NPCRequestHandler{},
-		packet.IDPlayerAction:              &PlayerActionHandler{},
-		packet.IDPlayerAuthInput:           &PlayerAuthInputHandler{},
-		packet.IDPlayerSkin:                &PlayerSkinHandler{},
-		packet.IDRequestAbility:            &RequestAbilityHandler{},
-		packet.IDRequestChunkRadius:        &RequestChunkRadiusHandler{},
-		packet.IDRespawn:                   &RespawnHandler{},
-		packet.IDSetPlayerInventoryOptions: nil,
-		packet.IDSubChunkRequest:           &SubChunkRequestHandler{},
-		packet.IDText:                      &TextHandler{},
-		packet.IDServerBoundLoadingScreen:  &ServerBoundLoadingScreenHandler{},
-		packet.IDServerBoundDiagnostics:    &ServerBoundDiagnosticsHandler{},
+		packet.IDActorEvent:                   nil,
+		packet.IDAdventureSettings:            nil, // Deprecated, the client still sends this though.
+		packet.IDAnimate:                      nil,
+		packet.IDAnvilDamage:                  nil,
+		packet.IDBlockActorData:               &BlockActorDataHandler{},
+		packet.IDBlockPickRequest:             &BlockPickRequestHandler{},
+		packet.IDBookEdit:                     &BookEditHandler{},
+		packet.IDBossEvent:                    nil,
+		packet.IDClientCacheBlobStatus:        &ClientCacheBlobStatusHandler{},
+		packet.IDCommandRequest:               &CommandRequestHandler{},
+		packet.IDContainerClose:               &ContainerCloseHandler{},
+		packet.IDEmote:                        &EmoteHandler{},
+		packet.IDEmoteList:                    nil,
+		packet.IDFilterText:                   nil,
+		packet.IDInteract:                     &InteractHandler{},
+		packet.IDInventoryTransaction:         &InventoryTransactionHandler{},
+		packet.IDItemStackRequest:             &ItemStackRequestHandler{changes: map[byte]map[byte]changeInfo{}, responseChanges: map[int32]map[*inventory.Inventory]map[byte]responseChange{}},
+		packet.IDLecternUpdate:                &LecternUpdateHandler{},
+		packet.IDMapInfoRequest:               &MapInfoRequestHandler{},
+		packet.IDMobEquipment:                 &MobEquipmentHandler{},
+		packet.IDModalFormResponse:            &ModalFormResponseHandler{forms: make(map[uint32]form.Form)},
+		packet.IDMovePlayer:                   nil,
+		packet.IDNPCRequest:                   &NPCRequestHandler{},
+		packet.IDPlayerAction:                 &PlayerActionHandler{},
+		packet.IDPlayerAuthInput:              &PlayerAuthInputHandler{},
+		packet.IDPlayerSkin:                   &PlayerSkinHandler{},
+		packet.IDRequestAbility:               &RequestAbilityHandler{},
+		packet.IDRequestChunkRadius:           &RequestChunkRadiusHandler{},
+		packet.IDRespawn:                      &RespawnHandler{},
+		packet.IDSetPlayerInventoryOptions:    nil,
+		packet.IDStructureBlockUpdate:         &StructureBlockUpdateHandler{},
+		packet.IDStructureTemplateDataRequest: &StructureTemplateDataRequestHandler{},
+		packet.IDSubChunkRequest:              &SubChunkRequestHandler{},
+		packet.IDText:                         &TextHandler{},
+		packet.IDServerBoundLoadingScreen:     &ServerBoundLoadingScreenHandler{},
+		packet.IDServerBoundDiagnostics:       &ServerBoundDiagnosticsHandler{},
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"image/color"
 	"math/rand/v2"
+	"strconv"
 	"strings"
 	"time"
 
@@ -99,6 +100,7 @@ func (s *Session) ViewEntity(e world.Entity) {
 			UUID:            v.UUID(),
 			Username:        v.Name(),
 			Yaw:             float32(yaw),
+			EntityLinks:     s.entityLinks(e),
 			AbilityData: protocol.AbilityData{
 				EntityUniqueID: int64(runtimeID),
 				Layers: []protocol.AbilityLayer{{
@@ -152,9 +154,54 @@ func (s *Session) ViewEntity(e world.Entity) {
 		Pitch:           float32(pitch),
 		Yaw:             float32(yaw),
 		HeadYaw:         float32(yaw),
+		EntityLinks:     s.entityLinks(e),
 	})
 }
 
+// entityLinks returns the links between the world.Entity passed and the
+// entities riding it or ridden by it that are currently visible to the
+// Session.
+func (s *Session) entityLinks(e world.Entity) []protocol.EntityLink {
+	s.entityMutex.RLock()
+	defer s.entityMutex.RUnlock()
+
+	var links []protocol.EntityLink
+	if r, ok := e.(entity.Rideable); ok {
+		for seat, h := range r.Riders() {
+			if id, ok := s.entityRuntimeIDs[h]; ok {
+				links = append(links, protocol.EntityLink{
+					RiddenEntityUniqueID: int64(s.entityRuntimeIDs[e.H()]),
+					RiderEntityUniqueID:  int64(id),
+					Type:                 linkType(seat == 0),
+					RiderInitiated:       true,
+				})
+			}
+		}
+	}
+	if r, ok := e.(driver); ok {
+		if h, riding := r.Riding(); riding {
+			if id, ok := s.entityRuntimeIDs[h]; ok {
+				links = append(links, protocol.EntityLink{
+					RiddenEntityUniqueID: int64(id),
+					RiderEntityUniqueID:  int64(s.entityRuntimeIDs[e.H()]),
+					Type:                 linkType(r.Driving()),
+					RiderInitiated:       true,
+				})
+			}
+		}
+	}
+	return links
+}
+
+// linkType returns the type of protocol.EntityLink of a rider, depending on
+// whether it drives the entity it is riding.
+func linkType(driver bool) byte {
+	if driver {
+		return protocol.EntityLinkRider
+	}
+	return protocol.EntityLinkPassenger
+}
+
 // ViewEntityGameMode ...
 func (s *Session) ViewEntityGameMode(e world.Entity) {
 	if s.entityHidden(e) {
@@ -309,6 +356,16 @@ func (s *Session) ViewEntityArmour(e world.Entity) {
 		Armour() *inventory.Armour
 	})
 	if !ok {
+		if r, ok := e.(entity.Raider); ok && r.Captain() {
+			// Raid captains wear an ominous banner on their head.
+			s.writePacket(&packet.MobArmourEquipment{
+				EntityRuntimeID: runtimeID,
+				Helmet:          instanceFromItem(entity.OminousBanner()),
+				Chestplate:      instanceFromItem(item.Stack{}),
+				Leggings:        instanceFromItem(item.Stack{}),
+				Boots:           instanceFromItem(item.Stack{}),
+			})
+		}
 		return
 	}
 
@@ -381,6 +438,11 @@ func (s *Session) ViewParticle(pos mgl64.Vec3, p world.Particle) {
 			EventType: packet.LevelEventParticlesExplosion,
 			Position:  vec64To32(pos),
 		})
+	case particle.WindExplosion:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventParticlesBreezeWindExplosion,
+			Position:  vec64To32(pos),
+		})
 	case particle.BoneMeal:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventParticleCropGrowth,
@@ -529,9 +591,13 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 			pk.SoundType = tierToSoundEvent(i.Tier)
 		case item.Elytra:
 			pk.SoundType = packet.SoundEventEquipElytra
+		case item.Saddle:
+			pk.SoundType = packet.SoundEventSaddle
 		default:
 			pk.SoundType = packet.SoundEventEquipGeneric
 		}
+	case sound.BellRing:
+		pk.SoundType = packet.SoundEventBell
 	case sound.Note:
 		pk.SoundType = packet.SoundEventNote
 		pk.ExtraData = (so.Instrument.Int32() << 8) | int32(so.Pitch)
@@ -556,6 +622,18 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 			EventType: packet.LevelEventWaxOn,
 			Position:  vec64To32(pos),
 		})
+	case sound.SignInked:
+		name := "sign.ink_sac.use"
+		if so.Glowing {
+			name = "sign.glow_ink_sac.use"
+		}
+		s.writePacket(&packet.PlaySound{
+			SoundName: name,
+			Position:  vec64To32(pos),
+			Volume:    1,
+			Pitch:     1,
+		})
+		return
 	case sound.WaxedSignFailedInteraction:
 		pk.SoundType = packet.SoundEventWaxedSignInteractFail
 	case sound.WaxRemoved:
@@ -618,6 +696,46 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventLargeBlast
 	case sound.FireworkBlast:
 		pk.SoundType = packet.SoundEventBlast
+	case sound.LeashKnotPlace:
+		pk.SoundType = packet.SoundEventPlaceLeashKnot
+	case sound.LeashKnotBreak:
+		pk.SoundType = packet.SoundEventBreakLeashKnot
+	case sound.GoatPreRam:
+		pk.SoundType, pk.EntityType = packet.SoundEventPreRam, "minecraft:goat"
+		if so.Screaming {
+			pk.SoundType = packet.SoundEventPreRamScreamer
+		}
+	case sound.GoatRamImpact:
+		pk.SoundType, pk.EntityType = packet.SoundEventRamImpact, "minecraft:goat"
+		if so.Screaming {
+			pk.SoundType = packet.SoundEventRamImpactScreamer
+		}
+	case sound.GoatHornBreak:
+		pk.SoundType = packet.SoundEventHornBreak
+	case sound.ArmadilloBrush:
+		pk.SoundType = packet.SoundEventArmadilloBrush
+	case sound.ArmadilloScuteDrop:
+		pk.SoundType = packet.SoundEventArmadilloScuteDrop
+	case sound.Shear:
+		pk.SoundType = packet.SoundEventShear
+	case sound.RaidHorn:
+		pk.SoundType = packet.SoundEventRaidHorn
+	case sound.WindChargeBurst:
+		pk.SoundType = packet.SoundEventBreezeWindChargeBurst
+	case sound.SnifferEggCrack:
+		pk.SoundType = packet.SoundEventSnifferEggCrack
+	case sound.SnifferEggHatch:
+		pk.SoundType = packet.SoundEventSnifferEggHatched
+	case sound.SculkCatalystBloom:
+		pk.SoundType = packet.SoundEventSculkCatalystBloom
+	case sound.SculkSpread:
+		pk.SoundType = packet.SoundEventSculkSpread
+	case sound.SculkSensorActivate:
+		pk.SoundType = packet.SoundEventSculkSensorPowerOn
+	case sound.SculkSensorDeactivate:
+		pk.SoundType = packet.SoundEventSculkSensorPowerOff
+	case sound.SculkShriek:
+		pk.SoundType = packet.SoundEventSculkShriekerShriek
 	case sound.FireworkTwinkle:
 		pk.SoundType = packet.SoundEventTwinkle
 	case sound.FurnaceCrackle:
@@ -844,6 +962,8 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventComposterReady
 	case sound.LecternBookPlace:
 		pk.SoundType = packet.SoundEventLecternBookPlace
+	case sound.ShieldBlock:
+		pk.SoundType = packet.SoundEventShieldBlock
 	case sound.Totem:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventSoundTotemUsed,
@@ -1031,6 +1151,11 @@ func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 			EventType:       packet.ActorEventShake,
 			EventData:       int32(act.Duration.Milliseconds() / 50),
 		})
+	case entity.FishingHookBiteAction:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventFishhookHookTime,
+		})
 	case entity.FireworkExplosionAction:
 		s.writePacket(&packet.ActorEvent{
 			EntityRuntimeID: s.entityRuntimeID(e),
@@ -1062,6 +1187,25 @@ func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 			EntityRuntimeID: s.entityRuntimeID(e),
 			EventType:       packet.ActorEventTalismanActivate,
 		})
+	case entity.LoveAction:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventLoveHearts,
+		})
+	case entity.MountAction:
+		s.writePacket(&packet.SetActorLink{EntityLink: protocol.EntityLink{
+			RiddenEntityUniqueID: int64(s.entityRuntimeID(act.Vehicle)),
+			RiderEntityUniqueID:  int64(s.entityRuntimeID(e)),
+			Type:                 linkType(act.Driver),
+			RiderInitiated:       true,
+		}})
+	case entity.DismountAction:
+		s.writePacket(&packet.SetActorLink{EntityLink: protocol.EntityLink{
+			RiddenEntityUniqueID: int64(s.entityRuntimeID(act.Vehicle)),
+			RiderEntityUniqueID:  int64(s.entityRuntimeID(e)),
+			Type:                 protocol.EntityLinkRemove,
+			RiderInitiated:       true,
+		}})
 	}
 }
 
@@ -1123,14 +1267,19 @@ func (s *Session) OpenBlockContainer(pos cube.Pos, tx *world.Tx) {
 		containerType = protocol.ContainerTypeLectern
 	case block.Loom:
 		containerType = protocol.ContainerTypeLoom
+	case block.CartographyTable:
+		containerType = protocol.ContainerTypeCartography
 	case block.Grindstone:
 		containerType = protocol.ContainerTypeGrindstone
 	case block.Stonecutter:
 		containerType = protocol.ContainerTypeStonecutter
 	case block.SmithingTable:
 		containerType = protocol.ContainerTypeSmithingTable
+	case block.StructureBlock:
+		containerType = protocol.ContainerTypeStructureEditor
 	case block.EnderChest:
 		b.AddViewer(tx, pos)
+		s.emitContainerVibration(world.VibrationContainerOpen, pos, tx)
 
 		s.openedWindow.Store(s.enderChest)
 		defer s.sendInv(s.enderChest, uint32(nextID))
@@ -1145,10 +1294,58 @@ func (s *Session) OpenBlockContainer(pos cube.Pos, tx *world.Tx) {
 	})
 }
 
+// OpenTrade opens the trading window of the entity.Trader passed for the Controllable of the Session.
+func (s *Session) OpenTrade(t entity.Trader, c Controllable, tx *world.Tx) {
+	s.closeCurrentContainer(tx)
+
+	pos := cube.PosFromVec3(t.Position())
+	s.containerOpened.Store(true)
+	s.openedWindow.Store(inventory.New(1, nil))
+	s.openedPos.Store(&pos)
+	s.openedTrader.Store(t.H())
+	s.openedContainerID.Store(uint32(protocol.ContainerTypeTrade))
+	s.sendTrade(s.nextWindowID(), t, c)
+}
+
+// sendTrade sends the tier and offers of the entity.Trader passed to the client, adjusted for the
+// Controllable of the Session.
+func (s *Session) sendTrade(windowID byte, t entity.Trader, c Controllable) {
+	offers := t.Offers(c)
+	recipes := make([]any, 0, len(offers))
+	for i, o := range offers {
+		m := o.EncodeNBT()
+		m["netId"] = int32(tradeNetworkID(s, i))
+		recipes = append(recipes, m)
+	}
+	tierExp := t.TierExperience()
+	requirements := make([]any, 0, len(tierExp))
+	for i, exp := range tierExp {
+		requirements = append(requirements, map[string]any{strconv.Itoa(i): int32(exp)})
+	}
+	tier, _ := t.TradeTier()
+	serialisedOffers, _ := nbt.MarshalEncoding(map[string]any{
+		"Recipes":             recipes,
+		"TierExpRequirements": requirements,
+	}, nbt.NetworkLittleEndian)
+	s.writePacket(&packet.UpdateTrade{
+		WindowID:          windowID,
+		WindowType:        protocol.ContainerTypeTrade,
+		Size:              int32(len(offers)),
+		TradeTier:         int32(tier),
+		VillagerUniqueID:  int64(s.entityRuntimeID(t)),
+		EntityUniqueID:    selfEntityRuntimeID,
+		DisplayName:       t.TraderName(),
+		NewTradeUI:        true,
+		DemandBasedPrices: true,
+		SerialisedOffers:  serialisedOffers,
+	})
+}
+
 // openNormalContainer opens a normal container that can hold items in it server-side.
 func (s *Session) openNormalContainer(b block.Container, pos cube.Pos, tx *world.Tx) {
 	b.AddViewer(s, tx, pos) // Paired chests might update the block here.
 	b = tx.Block(pos).(block.Container)
+	s.emitContainerVibration(world.VibrationContainerOpen, pos, tx)
 
 	nextID := s.nextWindowID()
 	s.containerOpened.Store(true)
@@ -1168,6 +1365,8 @@ func (s *Session) openNormalContainer(b block.Container, pos cube.Pos, tx *world
 		containerType = protocol.ContainerTypeSmoker
 	case block.Hopper:
 		containerType = protocol.ContainerTypeHopper
+	case block.Dispenser:
+		containerType = protocol.ContainerTypeDispenser
 	}
 
 	s.writePacket(&packet.ContainerOpen{
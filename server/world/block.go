@@ -8,6 +8,7 @@ import (
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/segmentio/fasthash/fnv1"
 	"image"
+	"maps"
 	"math"
 	"math/bits"
 	"math/rand/v2"
@@ -159,12 +160,19 @@ func finaliseBlock(rid uint32, b Block) {
 	if _, ok := b.(RandomTicker); ok {
 		randomTickBlocks[rid] = true
 	}
+	if f, ok := randomTickFuncs[i]; ok {
+		randomTickBlocks[rid] = true
+		randomTickFuncsByRID[rid] = f
+	}
 	if _, ok := b.(Liquid); ok {
 		liquidBlocks[rid] = true
 	}
 	if _, ok := b.(LiquidDisplacer); ok {
 		liquidDisplacingBlocks[rid] = true
 	}
+	if _, ok := b.(LightningConductor); ok {
+		conductorBlocks[rid] = true
+	}
 }
 
 // BlockHash returns a unique identifier of the block including the block states. This function is used internally
@@ -228,6 +236,14 @@ func BlockByName(name string, properties map[string]any) (Block, bool) {
 	return blocks[rid], true
 }
 
+// BlockProperties returns the properties of the first state registered for the block with the name passed. The
+// values of the map returned hold the types that BlockByName expects for the properties. If no block with the
+// name is registered, false is returned.
+func BlockProperties(name string) (map[string]any, bool) {
+	properties, ok := blockProperties[name]
+	return maps.Clone(properties), ok
+}
+
 // Blocks returns a slice of all registered blocks.
 func Blocks() []Block {
 	return slices.Clone(blocks)
@@ -251,6 +267,34 @@ type RandomTicker interface {
 	RandomTick(pos cube.Pos, tx *Tx, r *rand.Rand)
 }
 
+// LightningConductor represents a block that attracts lightning striking near
+// it, such as a lightning rod. Lightning striking within 64 blocks of a
+// LightningConductor that is the highest block in its column strikes the
+// LightningConductor instead.
+type LightningConductor interface {
+	// StruckByLightning is called when the block at the position passed is
+	// struck by lightning.
+	StruckByLightning(pos cube.Pos, tx *Tx)
+}
+
+// RandomTickFunc is a function called when a block registered using RegisterRandomTicker is ticked randomly.
+type RandomTickFunc func(pos cube.Pos, tx *Tx, r *rand.Rand)
+
+// RegisterRandomTicker registers a RandomTickFunc that is called every time the block state passed receives a
+// random tick, in addition to the RandomTick method of the block if it implements RandomTicker. This allows
+// blocks that do not implement RandomTicker themselves, such as vanilla blocks of which the implementation cannot
+// be changed, to grow, spread or otherwise change over time. Multiple functions may be registered for the same
+// block state.
+// RegisterRandomTicker panics if it is called after the block registry was finalised.
+func RegisterRandomTicker(b Block, f RandomTickFunc) {
+	if bitSize > 0 {
+		panic(fmt.Errorf("tried to register a random ticker after the block registry was finalised"))
+	}
+	name, properties := b.EncodeBlock()
+	h := stateHash{name: name, properties: hashProperties(properties)}
+	randomTickFuncs[h] = append(randomTickFuncs[h], f)
+}
+
 // ScheduledTicker represents a block that executes an action when it has a block update scheduled, such as
 // when a block adjacent to it is broken.
 type ScheduledTicker interface {
@@ -32,12 +32,20 @@ var (
 	// randomTickBlocks holds a list of RandomTicker implementations for blocks registered that implement the RandomTicker interface.
 	// These are indexed by their runtime IDs. Blocks that do not implement RandomTicker have a false value in this slice.
 	randomTickBlocks []bool
+	// randomTickFuncs holds the RandomTickFunc functions registered using RegisterRandomTicker, indexed by the
+	// stateHash of the block they were registered for. After the block registry is finalised, they are indexed by
+	// runtime ID in randomTickFuncsByRID.
+	randomTickFuncs      = map[stateHash][]RandomTickFunc{}
+	randomTickFuncsByRID = map[uint32][]RandomTickFunc{}
 	// liquidBlocks holds a list of Liquid implementations for blocks registered that implement the Liquid interface.
 	// These are indexed by their runtime IDs. Blocks that do not implement Liquid have a false value in this slice.
 	liquidBlocks []bool
 	// liquidDisplacingBlocks holds a list of LiquidDisplacer implementations for blocks registered that implement the LiquidDisplacer interface.
 	// These are indexed by their runtime IDs. Blocks that do not implement LiquidDisplacer have a false value in this slice.
 	liquidDisplacingBlocks []bool
+	// conductorBlocks holds a list of LightningConductor implementations for blocks registered that implement the
+	// LightningConductor interface. These are indexed by their runtime IDs.
+	conductorBlocks []bool
 	// airRID is the runtime ID of an air block.
 	airRID uint32
 )
@@ -92,6 +100,7 @@ func registerBlockState(s blockState) {
 	randomTickBlocks = slices.Insert(randomTickBlocks, int(rid), false)
 	liquidBlocks = slices.Insert(liquidBlocks, int(rid), false)
 	liquidDisplacingBlocks = slices.Insert(liquidDisplacingBlocks, int(rid), false)
+	conductorBlocks = slices.Insert(conductorBlocks, int(rid), false)
 	chunk.FilteringBlocks = slices.Insert(chunk.FilteringBlocks, int(rid), 15)
 	chunk.LightBlocks = slices.Insert(chunk.LightBlocks, int(rid), 0)
 	stateRuntimeIDs[h] = rid
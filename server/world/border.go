@@ -0,0 +1,141 @@
+package world
+
+import (
+	"math"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Border is the border of a World. It is a square around a centre on the X
+// and Z axes. Entities outside the border take damage, and players cannot
+// place or break blocks outside it, nor be teleported beyond it.
+// The zero value of Border is a World without a border.
+type Border struct {
+	// Centre is the centre of the border on the X and Z axes.
+	Centre mgl64.Vec2
+	// Size is the length in blocks of the sides of the border. If Size is 0,
+	// the World has no border.
+	Size float64
+	// TargetSize is the size that the border is gradually resized to over
+	// the next ResizeTicks ticks. It is ignored if ResizeTicks is 0.
+	TargetSize float64
+	// ResizeTicks is the number of ticks left until the border reaches its
+	// TargetSize.
+	ResizeTicks int64
+	// DamagePerBlock is the damage dealt to an entity outside the border for
+	// every block that it is beyond the SafeZone.
+	DamagePerBlock float64
+	// SafeZone is the distance in blocks outside the border within which
+	// entities do not take damage.
+	SafeZone float64
+}
+
+// Enabled checks if the Border limits the World, which is the case if its
+// Size is bigger than 0.
+func (b Border) Enabled() bool {
+	return b.Size > 0
+}
+
+// Resize returns the Border, gradually resized to the size passed over the
+// duration passed. If the duration is 0, the Border is resized immediately.
+func (b Border) Resize(size float64, d time.Duration) Border {
+	b.TargetSize, b.ResizeTicks = size, d.Milliseconds()/50
+	if b.ResizeTicks <= 0 {
+		b.Size, b.TargetSize, b.ResizeTicks = size, 0, 0
+	}
+	return b
+}
+
+// Distance returns the horizontal distance from the position passed to the
+// nearest side of the Border. The distance is positive if the position is
+// inside the Border and negative if it is outside of it. If the Border is not
+// Enabled, math.Inf(1) is returned.
+func (b Border) Distance(pos mgl64.Vec3) float64 {
+	if !b.Enabled() {
+		return math.Inf(1)
+	}
+	half := b.Size / 2
+	return min(half-math.Abs(pos[0]-b.Centre[0]), half-math.Abs(pos[2]-b.Centre[1]))
+}
+
+// Contains checks if the position passed lies within the Border.
+func (b Border) Contains(pos mgl64.Vec3) bool {
+	return b.Distance(pos) >= 0
+}
+
+// ContainsBlock checks if the block at the position passed lies within the
+// Border.
+func (b Border) ContainsBlock(pos cube.Pos) bool {
+	return b.Contains(pos.Vec3Centre())
+}
+
+// Clamp returns the position passed, moved horizontally to lie within the
+// Border if it was outside of it.
+func (b Border) Clamp(pos mgl64.Vec3) mgl64.Vec3 {
+	if !b.Enabled() {
+		return pos
+	}
+	// Keep the position slightly away from the edge, so that it is not
+	// considered outside the Border due to rounding errors.
+	half := b.Size/2 - 0.5
+	pos[0] = mgl64.Clamp(pos[0], b.Centre[0]-half, b.Centre[0]+half)
+	pos[2] = mgl64.Clamp(pos[2], b.Centre[1]-half, b.Centre[1]+half)
+	return pos
+}
+
+// Damage returns the damage dealt to an entity at the position passed, or 0
+// if the entity is not outside the SafeZone of the Border.
+func (b Border) Damage(pos mgl64.Vec3) float64 {
+	outside := -b.Distance(pos) - b.SafeZone
+	if outside <= 0 || b.DamagePerBlock <= 0 {
+		return 0
+	}
+	return max(1, math.Floor(outside*b.DamagePerBlock))
+}
+
+// tick moves the size of the Border one tick closer to its TargetSize.
+func (b Border) tick() Border {
+	if b.ResizeTicks <= 0 {
+		return b
+	}
+	b.Size += (b.TargetSize - b.Size) / float64(b.ResizeTicks)
+	if b.ResizeTicks--; b.ResizeTicks == 0 {
+		b.Size, b.TargetSize = b.TargetSize, 0
+	}
+	return b
+}
+
+// Border returns the current Border of the World.
+func (w *World) Border() Border {
+	if w == nil {
+		return Border{}
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.Border
+}
+
+// SetBorder changes the Border of the World to the one passed. Passing the
+// zero value of Border removes the border of the World.
+func (w *World) SetBorder(b Border) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.Border = b
+}
+
+// ResizeBorder gradually resizes the Border of the World to the size passed
+// over the duration passed. If the duration is 0, the Border is resized
+// immediately.
+func (w *World) ResizeBorder(size float64, d time.Duration) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.Border = w.set.Border.Resize(size, d)
+}
@@ -10,6 +10,7 @@ type Column struct {
 	BlockEntities   []BlockEntity
 	Tick            int64
 	ScheduledBlocks []ScheduledBlockUpdate
+	InhabitedTime   int64
 }
 
 type BlockEntity struct {
@@ -99,6 +99,30 @@ func NewEntity(t EntityType, conf EntityConfig) *EntityHandle {
 	return opts.New(t, conf)
 }
 
+// NewFromNBT creates an EntityHandle of the EntityType passed, configured by
+// decoding the NBT data passed using EntityType.DecodeNBT. Passing a nil map
+// creates an entity with the default properties of the EntityType. The
+// EntityHandle may be added to a world by calling Tx.AddEntity().
+func (opts EntitySpawnOpts) NewFromNBT(t EntityType, data map[string]any) *EntityHandle {
+	if data == nil {
+		data = map[string]any{}
+	}
+	return opts.New(t, nbtEntityConfig{t: t, data: data})
+}
+
+// nbtEntityConfig is an EntityConfig that configures an entity by decoding
+// NBT data using its EntityType.
+type nbtEntityConfig struct {
+	t    EntityType
+	data map[string]any
+}
+
+// Apply decodes the NBT data of the nbtEntityConfig into the EntityData
+// passed.
+func (conf nbtEntityConfig) Apply(data *EntityData) {
+	conf.t.DecodeNBT(conf.data, data)
+}
+
 // entityFromData reads an entity from the decoded NBT data passed and returns
 // an EntityHandle.
 func entityFromData(t EntityType, id int64, data map[string]any) *EntityHandle {
@@ -369,11 +393,17 @@ type EntityRegistryConfig struct {
 	Arrow              func(opts EntitySpawnOpts, damage float64, owner Entity, critical, disallowPickup, obtainArrowOnPickup bool, punchLevel int, tip any) *EntityHandle
 	Egg                func(opts EntitySpawnOpts, owner Entity) *EntityHandle
 	EnderPearl         func(opts EntitySpawnOpts, owner Entity) *EntityHandle
+	FishingHook        func(opts EntitySpawnOpts, owner Entity, rod any) *EntityHandle
 	Firework           func(opts EntitySpawnOpts, firework Item, owner Entity, sidewaysVelocityMultiplier, upwardsAcceleration float64, attached bool) *EntityHandle
 	LingeringPotion    func(opts EntitySpawnOpts, t any, owner Entity) *EntityHandle
 	Snowball           func(opts EntitySpawnOpts, owner Entity) *EntityHandle
 	SplashPotion       func(opts EntitySpawnOpts, t any, owner Entity) *EntityHandle
 	Lightning          func(opts EntitySpawnOpts) *EntityHandle
+	LeashKnot          func(opts EntitySpawnOpts) *EntityHandle
+	Tadpole            func(opts EntitySpawnOpts) *EntityHandle
+	Sniffer            func(opts EntitySpawnOpts) *EntityHandle
+	WanderingTrader    func(opts EntitySpawnOpts, despawnDelay time.Duration) *EntityHandle
+	TraderLlama        func(opts EntitySpawnOpts, trader Entity) *EntityHandle
 }
 
 // New creates an EntityRegistry using conf and the EntityTypes passed.
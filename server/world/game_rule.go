@@ -0,0 +1,103 @@
+package world
+
+import (
+	"slices"
+	"strings"
+)
+
+// GameRule is a boolean rule that changes the behaviour of a World. The value
+// of a GameRule is stored in the Settings of the World, so that it persists
+// when the World is saved.
+type GameRule struct {
+	name string
+	get  func(w *World) bool
+	set  func(w *World, v bool)
+}
+
+// Name returns the name of the GameRule as it is used in the /gamerule
+// command, for example 'doTileDrops'.
+func (r GameRule) Name() string {
+	return r.name
+}
+
+var (
+	// GameRuleDoDaylightCycle specifies if the time of the World advances
+	// every tick.
+	GameRuleDoDaylightCycle = GameRule{name: "doDaylightCycle", get: (*World).TimeCycle, set: (*World).enableTimeCycle}
+	// GameRuleDoWeatherCycle specifies if the weather of the World changes
+	// over time.
+	GameRuleDoWeatherCycle = settingsRule(func(s *Settings) *bool { return &s.WeatherCycle }).named("doWeatherCycle")
+	// GameRuleDoInsomnia specifies if phantoms spawn above players that have
+	// not slept for a while.
+	GameRuleDoInsomnia = GameRule{name: "doInsomnia", get: (*World).Insomnia, set: (*World).SetInsomnia}
+	// GameRuleDoMobLoot specifies if mobs drop items and experience when they
+	// are killed.
+	GameRuleDoMobLoot = settingsRule(func(s *Settings) *bool { return &s.MobLoot }).named("doMobLoot")
+	// GameRuleDoTileDrops specifies if blocks drop items when they are broken.
+	GameRuleDoTileDrops = settingsRule(func(s *Settings) *bool { return &s.TileDrops }).named("doTileDrops")
+	// GameRuleDoEntityDrops specifies if entities that are not mobs, such as
+	// item frames and falling blocks, drop items when they are destroyed.
+	GameRuleDoEntityDrops = settingsRule(func(s *Settings) *bool { return &s.EntityDrops }).named("doEntityDrops")
+)
+
+// GameRules returns a list of all game rules, sorted by name.
+func GameRules() []GameRule {
+	rules := []GameRule{
+		GameRuleDoDaylightCycle, GameRuleDoWeatherCycle, GameRuleDoInsomnia,
+		GameRuleDoMobLoot, GameRuleDoTileDrops, GameRuleDoEntityDrops,
+	}
+	slices.SortFunc(rules, func(a, b GameRule) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return rules
+}
+
+// GameRuleByName looks up a GameRule by its name. The name is matched case
+// insensitively. If no GameRule with the name exists, false is returned.
+func GameRuleByName(name string) (GameRule, bool) {
+	for _, r := range GameRules() {
+		if strings.EqualFold(r.name, name) {
+			return r, true
+		}
+	}
+	return GameRule{}, false
+}
+
+// GameRule returns the current value of the GameRule passed in the World.
+func (w *World) GameRule(r GameRule) bool {
+	if w == nil {
+		return false
+	}
+	return r.get(w)
+}
+
+// SetGameRule changes the value of the GameRule passed in the World.
+func (w *World) SetGameRule(r GameRule, v bool) {
+	if w == nil {
+		return
+	}
+	r.set(w, v)
+}
+
+// settingsRule returns a GameRule of which the value is stored in the field of
+// the Settings returned by field.
+func settingsRule(field func(s *Settings) *bool) GameRule {
+	return GameRule{
+		get: func(w *World) bool {
+			w.set.Lock()
+			defer w.set.Unlock()
+			return *field(w.set)
+		},
+		set: func(w *World, v bool) {
+			w.set.Lock()
+			defer w.set.Unlock()
+			*field(w.set) = v
+		},
+	}
+}
+
+// named returns a copy of the GameRule with the name passed.
+func (r GameRule) named(name string) GameRule {
+	r.name = name
+	return r
+}
@@ -1,6 +1,7 @@
 package world
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world/chunk"
 )
 
@@ -12,6 +13,31 @@ type Generator interface {
 	GenerateChunk(pos ChunkPos, chunk *chunk.Chunk)
 }
 
+// BlockEntityGenerator is a Generator that may also place blocks holding block
+// entity data, such as chests with a loot table or mob spawners. If the
+// Generator of a World implements BlockEntityGenerator, GenerateBlockEntities
+// is called instead of GenerateChunk.
+type BlockEntityGenerator interface {
+	Generator
+	// GenerateBlockEntities generates a chunk at the chunk position passed,
+	// similarly to GenerateChunk, and returns the blocks with block entity data
+	// placed in the chunk, indexed by their absolute position. The blocks
+	// returned must also be set in the chunk passed.
+	GenerateBlockEntities(pos ChunkPos, chunk *chunk.Chunk) map[cube.Pos]Block
+}
+
+// StructureLocator is a Generator that is able to locate the structures it
+// generates, for example to point exploration maps to them. If the Generator of
+// a World implements StructureLocator, Tx.LocateStructure uses it.
+type StructureLocator interface {
+	Generator
+	// LocateStructure returns the position of the structure with the name
+	// passed, such as "buriedtreasure", that is closest to the position passed.
+	// The Tx passed may be used to look up the biomes of the area searched.
+	// False is returned if no such structure was found.
+	LocateStructure(tx *Tx, structure string, pos cube.Pos) (cube.Pos, bool)
+}
+
 // NopGenerator is the default generator a world. It places no blocks in the world which results in a void
 // world.
 type NopGenerator struct{}
@@ -0,0 +1,390 @@
+package generator
+
+import (
+	"maps"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+const (
+	// BastionTreasureLootTable is the loot table used to fill the chest in the
+	// treasure room of bastion remnants.
+	BastionTreasureLootTable = "chests/bastion_treasure.json"
+	// BastionBridgeLootTable is the loot table used to fill the chests of
+	// bridge bastion remnants.
+	BastionBridgeLootTable = "chests/bastion_bridge.json"
+	// BastionHoglinStableLootTable is the loot table used to fill the chests
+	// of hoglin stable bastion remnants.
+	BastionHoglinStableLootTable = "chests/bastion_hoglin_stable.json"
+	// BastionOtherLootTable is the loot table used to fill the chests of
+	// housing unit bastion remnants.
+	BastionOtherLootTable = "chests/bastion_other.json"
+)
+
+const (
+	// bastionCellSize is the size in chunks of the cells that the world is
+	// divided into. Every cell holds at most one bastion remnant.
+	bastionCellSize = 20
+	// bastionMargin is the minimum distance in blocks between the centre of a
+	// bastion remnant and the edges of its cell. It is larger than half the
+	// size of any bastion template, so that bastions never cross into other
+	// cells.
+	bastionMargin = 32
+)
+
+// BastionDecorator is a world.Generator that generates bastion remnants in
+// chunks generated by another world.Generator. Every bastion is one of four
+// types: a treasure room, a bridge, hoglin stables or housing units, each
+// built from a template of blackstone with piles of gold blocks and chests
+// filled using the loot table of the type.
+// Because piglins and hoglins are not spawned during world generation, the
+// positions at which they should spawn may be obtained by calling Spawns once
+// a chunk has been generated.
+// A BastionDecorator may be constructed by calling NewBastionDecorator.
+type BastionDecorator struct {
+	g    world.Generator
+	seed uint64
+}
+
+// NewBastionDecorator creates a BastionDecorator that generates bastion
+// remnants in the chunks generated by the world.Generator passed. The seed
+// passed is used to place bastions deterministically, so that a bastion
+// spanning multiple chunks is generated the same way regardless of the order
+// in which the chunks are generated.
+func NewBastionDecorator(g world.Generator, seed int64) BastionDecorator {
+	return BastionDecorator{g: g, seed: uint64(seed)}
+}
+
+// GenerateChunk generates the chunk using the underlying world.Generator and
+// generates the part of a bastion remnant in it, if any. Because the chests
+// and spawners of bastions require block entity data, GenerateBlockEntities
+// should be used instead where possible.
+func (d BastionDecorator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	d.GenerateBlockEntities(pos, c)
+}
+
+// GenerateBlockEntities generates the chunk using the underlying
+// world.Generator and generates the part of a bastion remnant in it, if any.
+// The chests and spawners placed are returned along with any block entities
+// generated by the underlying world.Generator.
+func (d BastionDecorator) GenerateBlockEntities(pos world.ChunkPos, c *chunk.Chunk) map[cube.Pos]world.Block {
+	blockEntities := map[cube.Pos]world.Block{}
+	if g, ok := d.g.(world.BlockEntityGenerator); ok {
+		maps.Copy(blockEntities, g.GenerateBlockEntities(pos, c))
+	} else {
+		d.g.GenerateChunk(pos, c)
+	}
+
+	b, ok := d.layout(floorDiv(pos[0], bastionCellSize), floorDiv(pos[1], bastionCellSize))
+	if ok && boxCrossesChunk(b.min, b.max, pos) {
+		placePieces(pos, c, []piece{{min: b.min, max: b.max, at: b.block}}, blockEntities)
+	}
+	seedLoot(blockEntities, d.seed)
+	return blockEntities
+}
+
+// LocateStructure locates structures using the underlying world.Generator.
+func (d BastionDecorator) LocateStructure(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	return locateStructure(d.g, tx, structure, pos)
+}
+
+// Spawns returns the positions in the chunk passed at which piglins, piglin
+// brutes and hoglins of a bastion remnant should be spawned, mapped to the
+// identifiers of the entities, such as "minecraft:piglin". ra is the range of
+// the world the chunk is in. Spawns is meant to be called once after the chunk
+// was first generated, for example to spawn the entities using a
+// world.EntityType registered by the server.
+func (d BastionDecorator) Spawns(pos world.ChunkPos, ra cube.Range) map[cube.Pos]string {
+	b, ok := d.layout(floorDiv(pos[0], bastionCellSize), floorDiv(pos[1], bastionCellSize))
+	if !ok || !boxCrossesChunk(b.min, b.max, pos) {
+		return nil
+	}
+	spawns := map[cube.Pos]string{}
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	for x := max(b.min[0], baseX); x <= min(b.max[0], baseX+15); x++ {
+		for z := max(b.min[2], baseZ); z <= min(b.max[2], baseZ+15); z++ {
+			for y := b.min[1]; y <= b.max[1]; y++ {
+				p := cube.Pos{x, y, z}
+				switch b.char(p) {
+				case 'p':
+					spawns[p.Add(cube.Pos{0, ra.Min()})] = "minecraft:piglin"
+				case 'P':
+					spawns[p.Add(cube.Pos{0, ra.Min()})] = "minecraft:piglin_brute"
+				case 'h':
+					spawns[p.Add(cube.Pos{0, ra.Min()})] = "minecraft:hoglin"
+				}
+			}
+		}
+	}
+	return spawns
+}
+
+// layout returns the bastion remnant in the cell passed. If the cell has no
+// bastion, false is returned.
+func (d BastionDecorator) layout(cellX, cellZ int32) (bastion, bool) {
+	r := rand.New(rand.NewPCG(d.seed^0x6a09e667f3bcc909, uint64(uint32(cellX))<<32|uint64(uint32(cellZ))))
+	// Roughly one in two cells holds a bastion.
+	if r.IntN(2) != 0 {
+		return bastion{}, false
+	}
+	size := bastionCellSize * 16
+	centre := cube.Pos{int(cellX)*size + bastionMargin + r.IntN(size-bastionMargin*2), 33 + r.IntN(8), int(cellZ)*size + bastionMargin + r.IntN(size-bastionMargin*2)}
+	b := bastion{bastionType: bastionTypes[r.IntN(len(bastionTypes))], facing: cube.Directions()[r.IntN(4)]}
+
+	t := b.template
+	sizeX, sizeZ := len(t[0][0]), len(t[0])
+	if b.facing == cube.East || b.facing == cube.West {
+		sizeX, sizeZ = sizeZ, sizeX
+	}
+	b.min = cube.Pos{centre[0] - sizeX/2, centre[1], centre[2] - sizeZ/2}
+	b.max = b.min.Add(cube.Pos{sizeX - 1, len(t) - 1, sizeZ - 1})
+	return b, true
+}
+
+// bastion is a single bastion remnant placed in the world.
+type bastion struct {
+	bastionType
+	// facing is the direction that the entrance of the bastion faces.
+	facing cube.Direction
+	// min and max are the corners of the box that holds the bastion. The Y
+	// coordinates are relative to the bottom of the world.
+	min, max cube.Pos
+}
+
+// char returns the template character of the bastion at the position passed,
+// which lies within the box of the bastion.
+func (b bastion) char(pos cube.Pos) rune {
+	width, length := len(b.template[0][0]), len(b.template[0])
+	x, z := pos[0]-b.min[0], pos[2]-b.min[2]
+	tx, tz := x, z
+	switch b.facing {
+	case cube.North:
+		tx, tz = width-1-x, length-1-z
+	case cube.East:
+		tx, tz = width-1-z, x
+	case cube.West:
+		tx, tz = z, length-1-x
+	}
+	return rune(b.template[pos[1]-b.min[1]][tz][tx])
+}
+
+// block returns the block of the bastion at the position passed. Directional
+// blocks in templates face south, and are rotated to match the direction the
+// bastion faces. nil is returned for positions that are left untouched.
+func (b bastion) block(pos cube.Pos) world.Block {
+	switch b.char(pos) {
+	case '#':
+		return block.PolishedBlackstoneBrick{Cracked: positionHash(pos)%5 == 0}
+	case 'b':
+		return block.Blackstone{Type: block.NormalBlackstone()}
+	case 'g':
+		return block.Blackstone{Type: block.GildedBlackstone()}
+	case 'B':
+		return block.Basalt{Polished: true, Axis: cube.Y}
+	case 'G':
+		return block.Gold{}
+	case 'M':
+		return block.Magma{}
+	case 'L':
+		return block.Lantern{Hanging: true, Type: block.NormalFire()}
+	case '|':
+		return block.IronChain{Axis: cube.Y}
+	case 'S':
+		return block.NewSpawner("minecraft:magma_cube")
+	case 'C':
+		chest := block.NewChest()
+		chest.Facing, chest.LootTable = rotateDirection(cube.South, b.facing), b.loot
+		return chest
+	case ' ', 'p', 'P', 'h':
+		return block.Air{}
+	}
+	return nil
+}
+
+// bastionType is one of the types of bastion remnants.
+type bastionType struct {
+	template bastionTemplate
+	// loot is the loot table used to fill the chests of the bastion.
+	loot string
+}
+
+// bastionTemplate is a template of a bastion remnant. Its layers are listed
+// from the bottom up and every layer lists its rows from north to south.
+// Every character in a row represents a block, as returned by bastion.block,
+// with '.' leaving the terrain untouched. The characters 'p', 'P' and 'h' mark
+// the positions at which piglins, piglin brutes and hoglins spawn. The
+// entrance of a bastion is on its south side.
+type bastionTemplate [][]string
+
+// bastionTypes holds the four types of bastion remnants.
+var bastionTypes = []bastionType{
+	{template: bastionTreasure, loot: BastionTreasureLootTable},
+	{template: bastionBridge, loot: BastionBridgeLootTable},
+	{template: bastionHoglinStables, loot: BastionHoglinStableLootTable},
+	{template: bastionHousing, loot: BastionOtherLootTable},
+}
+
+var (
+	// bastionTreasure is a treasure room with a pile of gold blocks in its
+	// centre, topped by a chest and guarded by magma cube spawners and piglin
+	// brutes.
+	bastionTreasure = bastionTemplate{
+		{
+			"###############", "###############", "###############", "###############", "###############",
+			"#####MMMMM#####", "#####MMMMM#####", "#####MMMMM#####", "#####MMMMM#####", "#####MMMMM#####",
+			"###############", "###############", "###############", "###############", "###############",
+		},
+		{
+			"###############", "#             #", "# S         S #", "#             #", "#             #",
+			"#             #", "#     GGG     #", "#     GGG     #", "#     GGG     #", "#             #",
+			"#  P       P  #", "#             #", "#             #", "#             #", "######   ######",
+		},
+		{
+			"###############", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#      G      #", "#     GGG     #", "#      G      #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "######   ######",
+		},
+		{
+			"###############", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "#      G      #", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "######   ######",
+		},
+		{
+			"###############", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "#      C      #", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "###############",
+		},
+		{
+			"##g########g###", "g             g", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "g             g", "###g#######g###",
+		},
+		{
+			"###############", "#             #", "#             #", "#             #", "#   L     L   #",
+			"#             #", "#             #", "#             #", "#             #", "#             #",
+			"#   L     L   #", "#             #", "#             #", "#             #", "###############",
+		},
+		{
+			"###############", "#             #", "#             #", "#             #", "#   |     |   #",
+			"#             #", "#             #", "#             #", "#             #", "#             #",
+			"#   |     |   #", "#             #", "#             #", "#             #", "###############",
+		},
+		{
+			"###############", "###############", "###############", "###############", "###############",
+			"###############", "###############", "###############", "###############", "###############",
+			"###############", "###############", "###############", "###############", "###############",
+		},
+	}
+	// bastionBridge is a gatehouse holding chests and gold, reached over a
+	// bridge of blackstone lined with basalt.
+	bastionBridge = bastionTemplate{
+		{
+			"#######", "#######", "#######", "#######", "#######", "#######", "#######",
+			".#####.", ".#####.", ".#####.", ".#####.", ".#####.", ".#####.",
+			".#####.", ".#####.", ".#####.", ".#####.", ".#####.", ".#####.",
+			"#######", "#######", "#######", "#######", "#######", "#######",
+		},
+		{
+			"#######", "#C G C#", "#     #", "#  p  #", "#     #", "#     #", "##   ##",
+			".B   B.", ".B   B.", ".B   B.", ".B   B.", ".B   B.", ".B p B.",
+			".B   B.", ".B   B.", ".B   B.", ".B   B.", ".B   B.", ".B   B.",
+			"B     B", "B  p  B", "B     B", "B     B", "B     B", "BB   BB",
+		},
+		{
+			"#######", "#  G  #", "#     #", "#     #", "#     #", "#     #", "##   ##",
+			".     .", ".     .", ".     .", ".     .", ".     .", ".     .",
+			".     .", ".     .", ".     .", ".     .", ".     .", ".     .",
+			"B     B", "       ", "       ", "       ", "       ", "B     B",
+		},
+		{
+			"#######", "#     #", "#     #", "#     #", "#     #", "#     #", "##   ##",
+			".     .", ".     .", ".     .", ".     .", ".     .", ".     .",
+			".     .", ".     .", ".     .", ".     .", ".     .", ".     .",
+			"B     B", "       ", "       ", "       ", "       ", "B     B",
+		},
+		{
+			"#######", "#  L  #", "#     #", "#     #", "#     #", "#     #", "#######",
+			".......", ".......", ".......", ".......", ".......", ".......",
+			".......", ".......", ".......", ".......", ".......", ".......",
+			"g.....g", ".......", ".......", ".......", ".......", "g.....g",
+		},
+		{
+			"###g###", "###|###", "#######", "#######", "#######", "#######", "###g###",
+			".......", ".......", ".......", ".......", ".......", ".......",
+			".......", ".......", ".......", ".......", ".......", ".......",
+			".......", ".......", ".......", ".......", ".......", ".......",
+		},
+	}
+	// bastionHoglinStables is a building with stalls holding hoglins along its
+	// northern wall and a chest near its entrance.
+	bastionHoglinStables = bastionTemplate{
+		{
+			"###############", "###############", "###############", "###############", "###############",
+			"###############", "###############", "###############", "###############", "###############",
+			"###############", "###############", "###############", "###############", "###############",
+		},
+		{
+			"###############", "#    B    B   #", "#    B    B   #", "# h  B h  B h #", "#    B    B   #",
+			"#    B    B   #", "#             #", "#             #", "#             #", "#      p      #",
+			"#             #", "#  G       G  #", "#             #", "#C           C#", "######   ######",
+		},
+		{
+			"###############", "#    B    B   #", "#    B    B   #", "#    B    B   #", "#    B    B   #",
+			"#    B    B   #", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "######   ######",
+		},
+		{
+			"###############", "#    B    B   #", "#    B    B   #", "#    B    B   #", "#    B    B   #",
+			"#    B    B   #", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "######   ######",
+		},
+		{
+			"#######g#######", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "#  L       L  #", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "#######g#######",
+		},
+		{
+			"bbbbbbbbbbbbbbb", "bbbbbbbbbbbbbbb", "bbbbbbbbbbbbbbb", "bbbbbbbbbbbbbbb", "bbbbbbbbbbbbbbb",
+			"bbbbbbbbbbbbbbb", "bbbbbbbbbbbbbbb", "bbb|bbbbbbb|bbb", "bbbbbbbbbbbbbbb", "bbbbbbbbbbbbbbb",
+			"bbbbbbbbbbbbbbb", "bbbbbbbbbbbbbbb", "bbbbbbbbbbbbbbb", "bbbbbbbbbbbbbbb", "bbbbbbbbbbbbbbb",
+		},
+	}
+	// bastionHousing is a block of housing units for piglins, split into two
+	// halves with chests in their corners.
+	bastionHousing = bastionTemplate{
+		{
+			"###############", "###############", "###############", "###############", "###############",
+			"###############", "###############", "###############", "###############", "###############",
+			"###############", "###############", "###############", "###############", "###############",
+		},
+		{
+			"###############", "#C           C#", "#             #", "#             #", "#      p      #",
+			"#             #", "#             #", "######   ######", "#             #", "#  p       p  #",
+			"#             #", "#             #", "#             #", "#C    G       #", "######   ######",
+		},
+		{
+			"###############", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "######   ######", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "######   ######",
+		},
+		{
+			"###############", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "###############", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "######   ######",
+		},
+		{
+			"###g#####g#####", "#             #", "#             #", "#   L     L   #", "#             #",
+			"#             #", "#             #", "###############", "#             #", "#             #",
+			"#   L     L   #", "#             #", "#             #", "#             #", "###g#####g#####",
+		},
+		{
+			"###############", "###############", "###############", "####|#####|####", "###############",
+			"###############", "###############", "###############", "###############", "###############",
+			"####|#####|####", "###############", "###############", "###############", "###############",
+		},
+	}
+)
@@ -0,0 +1,279 @@
+package generator
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// CaveDecorator is a world.Generator that decorates the caves in chunks
+// generated by another world.Generator. Cave walls and ceilings are covered in
+// patches of glow lichen, and some chunks have their caves turned into
+// dripstone caves or lush caves. Lush caves are covered in moss, have pools of
+// water lined with clay, cave vines with glow berries, spore blossoms and
+// hanging roots, and have an azalea tree growing on the surface above them,
+// the roots of which reach down into the cave.
+// A CaveDecorator may be constructed by calling NewCaveDecorator.
+type CaveDecorator struct {
+	g    world.Generator
+	seed uint64
+
+	air, lichenUp, lichenDown, dripstone, moss, mossCarpet, sporeBlossom, hangingRoots uint32
+	clay, water, grass, rootedDirt, log                                                uint32
+	// walls holds the runtime IDs of blocks that cave decorations may be
+	// placed on or may replace.
+	walls map[uint32]struct{}
+}
+
+// NewCaveDecorator creates a CaveDecorator that decorates the caves of the
+// chunks generated by the world.Generator passed. The seed passed is used to
+// place decorations deterministically, so that the same chunk is always
+// decorated the same way.
+func NewCaveDecorator(g world.Generator, seed int64) CaveDecorator {
+	d := CaveDecorator{
+		g:            g,
+		seed:         uint64(seed),
+		air:          world.BlockRuntimeID(block.Air{}),
+		lichenUp:     world.BlockRuntimeID(block.GlowLichen{Up: true}),
+		lichenDown:   world.BlockRuntimeID(block.GlowLichen{Down: true}),
+		dripstone:    world.BlockRuntimeID(block.Dripstone{}),
+		moss:         world.BlockRuntimeID(block.MossBlock{}),
+		mossCarpet:   world.BlockRuntimeID(block.MossCarpet{}),
+		sporeBlossom: world.BlockRuntimeID(block.SporeBlossom{}),
+		hangingRoots: world.BlockRuntimeID(block.HangingRoots{}),
+		clay:         world.BlockRuntimeID(block.Clay{}),
+		water:        world.BlockRuntimeID(block.Water{Still: true, Depth: 8}),
+		grass:        world.BlockRuntimeID(block.Grass{}),
+		rootedDirt:   world.BlockRuntimeID(block.RootedDirt{}),
+		log:          world.BlockRuntimeID(block.Log{Wood: block.OakWood(), Axis: cube.Y}),
+		walls:        map[uint32]struct{}{},
+	}
+	for _, b := range []world.Block{
+		block.Stone{}, block.Granite{}, block.Diorite{}, block.Andesite{}, block.Tuff{}, block.Dirt{}, block.Gravel{},
+		block.Deepslate{Type: block.NormalDeepslate(), Axis: cube.Y},
+	} {
+		d.walls[world.BlockRuntimeID(b)] = struct{}{}
+	}
+	return d
+}
+
+// caveType is the type of decoration placed in the caves of a chunk.
+type caveType int
+
+const (
+	caveNormal caveType = iota
+	caveDripstone
+	caveLush
+)
+
+// GenerateChunk generates the chunk using the underlying world.Generator and
+// decorates any caves found in it afterwards.
+func (d CaveDecorator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	d.g.GenerateChunk(pos, c)
+	d.decorateChunk(pos, c)
+}
+
+// GenerateBlockEntities generates the chunk using the underlying
+// world.Generator, returning any block entities it generated, and decorates
+// any caves found in it afterwards.
+func (d CaveDecorator) GenerateBlockEntities(pos world.ChunkPos, c *chunk.Chunk) map[cube.Pos]world.Block {
+	g, ok := d.g.(world.BlockEntityGenerator)
+	if !ok {
+		d.GenerateChunk(pos, c)
+		return nil
+	}
+	blockEntities := g.GenerateBlockEntities(pos, c)
+	d.decorateChunk(pos, c)
+	return blockEntities
+}
+
+// LocateStructure locates structures using the underlying world.Generator.
+func (d CaveDecorator) LocateStructure(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	return locateStructure(d.g, tx, structure, pos)
+}
+
+// decorateChunk decorates all caves found in the chunk passed.
+func (d CaveDecorator) decorateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	r := rand.New(rand.NewPCG(d.seed, uint64(uint32(pos[0]))<<32|uint64(uint32(pos[1]))))
+	t := caveNormal
+	switch r.IntN(8) {
+	case 0:
+		t = caveDripstone
+	case 1:
+		t = caveLush
+	}
+	// pool is the centre of the columns of the chunk in which the floors of
+	// lush caves are turned into pools of water.
+	var pool [2]int
+	if t == caveLush {
+		pool = [2]int{4 + r.IntN(8), 4 + r.IntN(8)}
+	}
+
+	minY := int16(c.Range().Min())
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			top := c.HighestBlock(x, z)
+			// Only blocks below the surface are considered, so that the
+			// decorations end up in caves rather than on the surface.
+			for y := minY + 1; y < top-1; y++ {
+				if c.Block(x, y, z, 0) != d.air {
+					continue
+				}
+				if t == caveLush {
+					d.decorateLush(c, x, y, z, pool, r)
+					continue
+				}
+				d.decorate(c, x, y, z, t, r)
+			}
+		}
+	}
+	if t == caveLush {
+		d.azaleaTree(c, r)
+	}
+}
+
+// decorate decorates the air block at the position passed, depending on the
+// blocks directly above and below it and the caveType passed.
+func (d CaveDecorator) decorate(c *chunk.Chunk, x uint8, y int16, z uint8, t caveType, r *rand.Rand) {
+	floor, ceiling := d.wall(c.Block(x, y-1, z, 0)), d.wall(c.Block(x, y+1, z, 0))
+	switch t {
+	case caveDripstone:
+		if floor && r.IntN(3) == 0 {
+			c.SetBlock(x, y-1, z, 0, d.dripstone)
+		}
+		if ceiling && r.IntN(3) == 0 {
+			c.SetBlock(x, y+1, z, 0, d.dripstone)
+		}
+	}
+	// Glow lichen grows in patches on the ceilings and floors of all other
+	// caves.
+	if ceiling && r.IntN(24) == 0 {
+		c.SetBlock(x, y, z, 0, d.lichenUp)
+	} else if floor && r.IntN(48) == 0 {
+		c.SetBlock(x, y, z, 0, d.lichenDown)
+	}
+}
+
+// decorateLush decorates the air block at the position passed in a lush cave,
+// depending on the blocks directly above and below it. Floors within a few
+// blocks of the pool column passed are turned into a pool of water lined with
+// clay.
+func (d CaveDecorator) decorateLush(c *chunk.Chunk, x uint8, y int16, z uint8, pool [2]int, r *rand.Rand) {
+	floor, ceiling := d.wall(c.Block(x, y-1, z, 0)), d.wall(c.Block(x, y+1, z, 0))
+	if floor {
+		dx, dz := int(x)-pool[0], int(z)-pool[1]
+		switch dist := dx*dx + dz*dz; {
+		case dist <= 9 && d.contained(c, x, y-1, z):
+			c.SetBlock(x, y-1, z, 0, d.water)
+			c.SetBlock(x, y-2, z, 0, d.clay)
+		case dist <= 16:
+			c.SetBlock(x, y-1, z, 0, d.clay)
+		default:
+			c.SetBlock(x, y-1, z, 0, d.moss)
+			if r.IntN(3) == 0 {
+				c.SetBlock(x, y, z, 0, d.mossCarpet)
+				return
+			}
+		}
+	}
+	if !ceiling {
+		return
+	}
+	switch r.IntN(40) {
+	case 0:
+		c.SetBlock(x, y, z, 0, d.sporeBlossom)
+		return
+	case 1, 2, 3, 4:
+		c.SetBlock(x, y, z, 0, d.hangingRoots)
+		return
+	case 5, 6, 7, 8:
+		// Cave vines hang down from the ceiling into the air below, some of
+		// them carrying glow berries.
+		age, length := r.IntN(25), 1+r.IntN(6)
+		for i := int16(0); i < int16(length) && c.Block(x, y-i, z, 0) == d.air; i++ {
+			berries := r.IntN(9) == 0
+			head := i == int16(length)-1 || c.Block(x, y-i-1, z, 0) != d.air
+			c.SetBlock(x, y-i, z, 0, world.BlockRuntimeID(block.CaveVines{Age: age, Berries: berries, Head: berries && head}))
+		}
+	}
+	c.SetBlock(x, y+1, z, 0, d.moss)
+}
+
+// contained checks if water placed at the position passed would be contained
+// by the blocks around and below it, so that it does not flow away.
+func (d CaveDecorator) contained(c *chunk.Chunk, x uint8, y int16, z uint8) bool {
+	if !d.wall(c.Block(x, y-1, z, 0)) {
+		return false
+	}
+	for _, n := range [][2]int{{int(x) - 1, int(z)}, {int(x) + 1, int(z)}, {int(x), int(z) - 1}, {int(x), int(z) + 1}} {
+		if n[0] < 0 || n[0] > 15 || n[1] < 0 || n[1] > 15 {
+			return false
+		}
+		switch rid := c.Block(uint8(n[0]), y, uint8(n[1]), 0); {
+		case d.wall(rid), rid == d.moss, rid == d.clay, rid == d.water:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// azaleaTree grows an azalea tree on the grass at the surface of the chunk
+// passed. Rooted dirt reaches down from the tree into the lush cave below,
+// from the ceiling of which hanging roots grow.
+func (d CaveDecorator) azaleaTree(c *chunk.Chunk, r *rand.Rand) {
+	x, z := uint8(2+r.IntN(12)), uint8(2+r.IntN(12))
+	top := c.HighestBlock(x, z)
+	if c.Block(x, top, z, 0) != d.grass {
+		return
+	}
+	// Look for the ceiling of the cave below the tree. If there is none close
+	// to the surface, the roots only reach a few blocks deep.
+	depth := int16(3)
+	for y := top - 1; y > top-48 && y > int16(c.Range().Min()); y-- {
+		if rid := c.Block(x, y, z, 0); rid == d.air {
+			depth = top - y
+			c.SetBlock(x, y, z, 0, d.hangingRoots)
+			break
+		} else if !d.wall(rid) && rid != d.moss {
+			break
+		}
+	}
+	for y := top; y > top-depth; y-- {
+		c.SetBlock(x, y, z, 0, d.rootedDirt)
+	}
+
+	height := int16(4 + r.IntN(2))
+	for y := top + 1; y <= top+height; y++ {
+		c.SetBlock(x, y, z, 0, d.log)
+	}
+	for dy := int16(-1); dy <= 1; dy++ {
+		radius := 2
+		if dy == 1 {
+			radius = 1
+		}
+		for dx := -radius; dx <= radius; dx++ {
+			for dz := -radius; dz <= radius; dz++ {
+				if abs(dx) == radius && abs(dz) == radius && r.IntN(2) == 0 {
+					// Leave out some of the corners to round off the canopy.
+					continue
+				}
+				lx, ly, lz := uint8(int(x)+dx), top+height+dy, uint8(int(z)+dz)
+				if c.Block(lx, ly, lz, 0) != d.air {
+					continue
+				}
+				c.SetBlock(lx, ly, lz, 0, world.BlockRuntimeID(block.AzaleaLeaves{Flowering: r.IntN(4) == 0}))
+			}
+		}
+	}
+}
+
+// wall checks if the runtime ID passed is that of a block that cave
+// decorations may be placed on.
+func (d CaveDecorator) wall(rid uint32) bool {
+	_, ok := d.walls[rid]
+	return ok
+}
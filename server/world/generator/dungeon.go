@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"maps"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// DungeonLootTable is the loot table used to fill the chests placed in
+// dungeons. The loot is generated when a chest is first opened.
+const DungeonLootTable = "chests/simple_dungeon.json"
+
+// DungeonDecorator is a world.Generator that generates dungeons, also known as
+// monster rooms, underground in chunks generated by another world.Generator.
+// A dungeon is a room of cobblestone and mossy cobblestone with a mob spawner
+// in its centre and up to two chests holding loot.
+// A DungeonDecorator may be constructed by calling NewDungeonDecorator.
+type DungeonDecorator struct {
+	g    world.Generator
+	seed uint64
+	// spawners holds the entity identifiers of the mobs spawners in dungeons
+	// may spawn. Each dungeon picks one of them at random.
+	spawners []string
+
+	air, cobblestone, mossyCobblestone uint32
+}
+
+// NewDungeonDecorator creates a DungeonDecorator that generates dungeons in
+// the chunks generated by the world.Generator passed. The seed passed is used
+// to place dungeons deterministically. spawners holds the identifiers of the
+// entities, such as "minecraft:zombie", that the spawner of a dungeon may
+// spawn. Identifiers may be repeated to make them more likely to be picked. If
+// no identifiers are passed, dungeons spawn zombies, skeletons and spiders
+// like in vanilla.
+func NewDungeonDecorator(g world.Generator, seed int64, spawners ...string) DungeonDecorator {
+	if len(spawners) == 0 {
+		spawners = []string{"minecraft:zombie", "minecraft:zombie", "minecraft:skeleton", "minecraft:spider"}
+	}
+	return DungeonDecorator{
+		g:                g,
+		seed:             uint64(seed),
+		spawners:         spawners,
+		air:              world.BlockRuntimeID(block.Air{}),
+		cobblestone:      world.BlockRuntimeID(block.Cobblestone{}),
+		mossyCobblestone: world.BlockRuntimeID(block.Cobblestone{Mossy: true}),
+	}
+}
+
+// GenerateChunk generates the chunk using the underlying world.Generator and
+// attempts to generate a dungeon in it. Because the chests and spawner of the
+// dungeon require block entity data, GenerateBlockEntities should be used
+// instead where possible.
+func (d DungeonDecorator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	d.GenerateBlockEntities(pos, c)
+}
+
+// GenerateBlockEntities generates the chunk using the underlying
+// world.Generator and attempts to generate a dungeon in it. The chests and
+// spawner of the dungeon are returned along with any block entities generated
+// by the underlying world.Generator.
+func (d DungeonDecorator) GenerateBlockEntities(pos world.ChunkPos, c *chunk.Chunk) map[cube.Pos]world.Block {
+	blockEntities := map[cube.Pos]world.Block{}
+	if g, ok := d.g.(world.BlockEntityGenerator); ok {
+		maps.Copy(blockEntities, g.GenerateBlockEntities(pos, c))
+	} else {
+		d.g.GenerateChunk(pos, c)
+	}
+
+	r := rand.New(rand.NewPCG(d.seed^0x9e3779b97f4a7c15, uint64(uint32(pos[0]))<<32|uint64(uint32(pos[1]))))
+	// Roughly one in eight chunks attempts to generate a dungeon. Attempts
+	// fail if there is no room for one at the position picked.
+	if r.IntN(8) != 0 {
+		return blockEntities
+	}
+	rx, rz := 2+r.IntN(2), 2+r.IntN(2)
+	x, z := 4+r.IntN(8), 4+r.IntN(8)
+	top := c.HighestBlock(uint8(x), uint8(z))
+	minY := int16(c.Range().Min()) + 6
+	if top-10 <= minY {
+		return blockEntities
+	}
+	y := minY + int16(r.IntN(int(top-10-minY)))
+	if !d.fits(c, x, y, z, rx, rz) {
+		return blockEntities
+	}
+	d.build(c, x, y, z, rx, rz, r)
+
+	base := cube.Pos{int(pos[0]) << 4, 0, int(pos[1]) << 4}
+	spawner := block.NewSpawner(d.spawners[r.IntN(len(d.spawners))])
+	c.SetBlock(uint8(x), y, uint8(z), 0, world.BlockRuntimeID(spawner))
+	blockEntities[base.Add(cube.Pos{x, int(y), z})] = spawner
+
+	for range 2 {
+		cx, cz, facing, ok := d.chestPosition(c, x, y, z, rx, rz, r)
+		if !ok {
+			continue
+		}
+		chest := block.NewChest()
+		chest.Facing, chest.LootTable = facing, DungeonLootTable
+		c.SetBlock(uint8(cx), y, uint8(cz), 0, world.BlockRuntimeID(chest))
+		blockEntities[base.Add(cube.Pos{cx, int(y), cz})] = chest
+	}
+	seedLoot(blockEntities, d.seed)
+	return blockEntities
+}
+
+// LocateStructure locates structures using the underlying world.Generator.
+func (d DungeonDecorator) LocateStructure(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	return locateStructure(d.g, tx, structure, pos)
+}
+
+// fits checks if a dungeon with the centre and radii passed fits at that
+// position. A dungeon fits if its floor and ceiling are fully embedded in
+// blocks, and if at least one and at most five of its walls are open, so that
+// it connects to a cave without being inside one.
+func (d DungeonDecorator) fits(c *chunk.Chunk, x int, y int16, z, rx, rz int) bool {
+	openings := 0
+	for dx := -rx - 1; dx <= rx+1; dx++ {
+		for dz := -rz - 1; dz <= rz+1; dz++ {
+			bx, bz := uint8(x+dx), uint8(z+dz)
+			if c.Block(bx, y-1, bz, 0) == d.air || c.Block(bx, y+4, bz, 0) == d.air {
+				return false
+			}
+			if (dx == -rx-1 || dx == rx+1 || dz == -rz-1 || dz == rz+1) && c.Block(bx, y, bz, 0) == d.air && c.Block(bx, y+1, bz, 0) == d.air {
+				openings++
+			}
+		}
+	}
+	return openings >= 1 && openings <= 5
+}
+
+// build places the floor, walls and air inside of a dungeon with the centre
+// and radii passed. Walls are only placed where there was a block already, so
+// that the openings into caves are kept.
+func (d DungeonDecorator) build(c *chunk.Chunk, x int, y int16, z, rx, rz int, r *rand.Rand) {
+	for dx := -rx - 1; dx <= rx+1; dx++ {
+		for dz := -rz - 1; dz <= rz+1; dz++ {
+			bx, bz := uint8(x+dx), uint8(z+dz)
+			wall := dx == -rx-1 || dx == rx+1 || dz == -rz-1 || dz == rz+1
+			for dy := int16(-1); dy <= 4; dy++ {
+				switch {
+				case dy == -1:
+					if r.IntN(4) == 0 {
+						c.SetBlock(bx, y+dy, bz, 0, d.cobblestone)
+					} else {
+						c.SetBlock(bx, y+dy, bz, 0, d.mossyCobblestone)
+					}
+				case dy == 4 || wall:
+					if c.Block(bx, y+dy, bz, 0) != d.air {
+						c.SetBlock(bx, y+dy, bz, 0, d.cobblestone)
+					}
+				default:
+					c.SetBlock(bx, y+dy, bz, 0, d.air)
+				}
+			}
+		}
+	}
+}
+
+// chestPosition attempts to find a position inside the dungeon passed that is
+// next to exactly one wall, so that a chest may be placed there facing away
+// from it. If no position was found after three attempts, false is returned.
+func (d DungeonDecorator) chestPosition(c *chunk.Chunk, x int, y int16, z, rx, rz int, r *rand.Rand) (int, int, cube.Direction, bool) {
+	for range 3 {
+		cx, cz := x+r.IntN(rx*2+1)-rx, z+r.IntN(rz*2+1)-rz
+		if c.Block(uint8(cx), y, uint8(cz), 0) != d.air {
+			continue
+		}
+		walls, facing := 0, cube.North
+		for _, dir := range cube.Directions() {
+			side := cube.Pos{cx, 0, cz}.Side(dir.Face())
+			if c.Block(uint8(side[0]), y, uint8(side[2]), 0) == d.cobblestone {
+				walls, facing = walls+1, dir.Opposite()
+			}
+		}
+		if walls == 1 {
+			return cx, cz, facing, true
+		}
+	}
+	return 0, 0, 0, false
+}
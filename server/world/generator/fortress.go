@@ -0,0 +1,360 @@
+package generator
+
+import (
+	"maps"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// FortressLootTable is the loot table used to fill the chests placed in nether
+// fortresses. The loot is generated when a chest is first opened.
+const FortressLootTable = "chests/nether_bridge.json"
+
+const (
+	// fortressCellSize is the size in chunks of the cells that the world is
+	// divided into. Every cell holds at most one nether fortress.
+	fortressCellSize = 16
+	// fortressMargin is the minimum distance in blocks between the centre of a
+	// fortress and the edges of its cell. Fortresses never reach further than
+	// this from their centre, so that they never cross into other cells.
+	fortressMargin = 48
+)
+
+// fortressMobs holds the entities that may spawn within the bounds of nether
+// fortresses, along with their weights.
+var fortressMobs = []struct {
+	id     string
+	weight int
+}{
+	{id: "minecraft:blaze", weight: 10},
+	{id: "minecraft:wither_skeleton", weight: 8},
+	{id: "minecraft:skeleton", weight: 2},
+	{id: "minecraft:magma_cube", weight: 3},
+}
+
+// NetherFortressDecorator is a world.Generator that generates nether
+// fortresses in chunks generated by another world.Generator. A fortress
+// consists of bridges of nether bricks running from a central crossing to
+// rooms holding blaze spawners, nether wart growing on soul sand and chests
+// holding loot.
+// A NetherFortressDecorator may be constructed by calling
+// NewNetherFortressDecorator.
+type NetherFortressDecorator struct {
+	g    world.Generator
+	seed uint64
+}
+
+// NewNetherFortressDecorator creates a NetherFortressDecorator that generates
+// nether fortresses in the chunks generated by the world.Generator passed. The
+// seed passed is used to lay out fortresses deterministically, so that a
+// fortress spanning multiple chunks is generated the same way regardless of
+// the order in which the chunks are generated.
+func NewNetherFortressDecorator(g world.Generator, seed int64) NetherFortressDecorator {
+	return NetherFortressDecorator{g: g, seed: uint64(seed)}
+}
+
+// fortress is a single nether fortress.
+type fortress struct {
+	// min and max are the corners of the box that holds all pieces of the
+	// fortress.
+	min, max cube.Pos
+	pieces   []piece
+}
+
+// fortressRoom is the type of room placed at the end of a bridge of a nether
+// fortress.
+type fortressRoom int
+
+const (
+	fortressBlazeRoom fortressRoom = iota
+	fortressWartRoom
+	fortressHall
+)
+
+// GenerateChunk generates the chunk using the underlying world.Generator and
+// generates the parts of any nether fortress that crosses it. Because the
+// chests and spawners of fortresses require block entity data,
+// GenerateBlockEntities should be used instead where possible.
+func (d NetherFortressDecorator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	d.GenerateBlockEntities(pos, c)
+}
+
+// GenerateBlockEntities generates the chunk using the underlying
+// world.Generator and generates the parts of any nether fortress that crosses
+// it. The chests and spawners placed are returned along with any block
+// entities generated by the underlying world.Generator.
+func (d NetherFortressDecorator) GenerateBlockEntities(pos world.ChunkPos, c *chunk.Chunk) map[cube.Pos]world.Block {
+	blockEntities := map[cube.Pos]world.Block{}
+	if g, ok := d.g.(world.BlockEntityGenerator); ok {
+		maps.Copy(blockEntities, g.GenerateBlockEntities(pos, c))
+	} else {
+		d.g.GenerateChunk(pos, c)
+	}
+
+	f, ok := d.layout(floorDiv(pos[0], fortressCellSize), floorDiv(pos[1], fortressCellSize))
+	if ok && boxCrossesChunk(f.min, f.max, pos) {
+		placePieces(pos, c, f.pieces, blockEntities)
+	}
+	seedLoot(blockEntities, d.seed)
+	return blockEntities
+}
+
+// LocateStructure locates structures using the underlying world.Generator.
+func (d NetherFortressDecorator) LocateStructure(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	return locateStructure(d.g, tx, structure, pos)
+}
+
+// FortressMob returns the identifier of a random entity, such as
+// "minecraft:blaze", that may spawn at the position passed if it lies within
+// the bounds of a nether fortress. If the position is not part of a fortress,
+// false is returned. ra is the range of the world the fortress is generated
+// in. Entities returned should only spawn on top of nether bricks, which is
+// left for the caller to check.
+func (d NetherFortressDecorator) FortressMob(pos cube.Pos, ra cube.Range, r *rand.Rand) (string, bool) {
+	if !d.InFortress(pos, ra) {
+		return "", false
+	}
+	total := 0
+	for _, m := range fortressMobs {
+		total += m.weight
+	}
+	n := r.IntN(total)
+	for _, m := range fortressMobs {
+		if n -= m.weight; n < 0 {
+			return m.id, true
+		}
+	}
+	return "", false
+}
+
+// InFortress checks if the position passed lies within the bounds of a nether
+// fortress. ra is the range of the world the fortress is generated in.
+func (d NetherFortressDecorator) InFortress(pos cube.Pos, ra cube.Range) bool {
+	f, ok := d.layout(floorDiv(int32(pos[0]>>4), fortressCellSize), floorDiv(int32(pos[2]>>4), fortressCellSize))
+	if !ok {
+		return false
+	}
+	y := pos[1] - ra.Min()
+	return pos[0] >= f.min[0] && pos[0] <= f.max[0] && pos[2] >= f.min[2] && pos[2] <= f.max[2] && y >= f.min[1] && y <= f.max[1]
+}
+
+// layout returns the nether fortress in the cell passed. If the cell has no
+// fortress, false is returned.
+func (d NetherFortressDecorator) layout(cellX, cellZ int32) (fortress, bool) {
+	r := rand.New(rand.NewPCG(d.seed^0xd1b54a32d192ed03, uint64(uint32(cellX))<<32|uint64(uint32(cellZ))))
+	// Roughly one in two cells holds a fortress.
+	if r.IntN(2) != 0 {
+		return fortress{}, false
+	}
+	size := fortressCellSize * 16
+	centre := cube.Pos{int(cellX)*size + fortressMargin + r.IntN(size-fortressMargin*2), 48 + r.IntN(16), int(cellZ)*size + fortressMargin + r.IntN(size-fortressMargin*2)}
+
+	// Every fortress has at least one blaze room and one nether wart room.
+	rooms := []fortressRoom{fortressBlazeRoom, fortressWartRoom, fortressHall, fortressBlazeRoom}
+	r.Shuffle(len(rooms), func(i, j int) {
+		rooms[i], rooms[j] = rooms[j], rooms[i]
+	})
+
+	f := fortress{pieces: []piece{d.platform(centre, 3, nil)}}
+	var bridges []piece
+	for i, dir := range cube.Directions() {
+		n := 8 + r.IntN(24)
+		roomCentre := step(centre, dir, 3+n+4)
+		switch rooms[i] {
+		case fortressBlazeRoom:
+			f.pieces = append(f.pieces, d.blazeRoom(roomCentre))
+		case fortressWartRoom:
+			f.pieces = append(f.pieces, d.wartRoom(roomCentre))
+		case fortressHall:
+			f.pieces = append(f.pieces, d.hall(roomCentre, dir, r))
+		}
+		bridges = append(bridges, d.bridge(step(centre, dir, 3), dir, n))
+	}
+	// Bridges are added after the rooms, so that they open up the walls of
+	// the rooms they lead to.
+	f.pieces = append(f.pieces, bridges...)
+
+	f.min, f.max = f.pieces[0].min, f.pieces[0].max
+	for _, p := range f.pieces[1:] {
+		f.min = cube.Pos{min(f.min[0], p.min[0]), min(f.min[1], p.min[1]), min(f.min[2], p.min[2])}
+		f.max = cube.Pos{max(f.max[0], p.max[0]), max(f.max[1], p.max[1]), max(f.max[2], p.max[2])}
+	}
+	return f, true
+}
+
+// bricks returns the nether bricks placed at the position passed. Some of the
+// bricks are cracked.
+func (NetherFortressDecorator) bricks(pos cube.Pos) world.Block {
+	if positionHash(pos)%8 == 0 {
+		return block.NetherBricks{Type: block.CrackedNetherBricks()}
+	}
+	return block.NetherBricks{Type: block.NormalNetherBricks()}
+}
+
+// platform returns a piece holding an open platform of nether bricks centred
+// around the position passed, surrounded by fences with an opening in the
+// middle of each side. The corners of the platform are supported by pillars
+// reaching down to the bottom of the world. inside, if not nil, is called for
+// positions on the platform, relative to the centre.
+func (d NetherFortressDecorator) platform(centre cube.Pos, radius int, inside func(rel cube.Pos) world.Block) piece {
+	return piece{
+		min: cube.Pos{centre[0] - radius, 0, centre[2] - radius},
+		max: centre.Add(cube.Pos{radius, 4, radius}),
+		at: func(pos cube.Pos) world.Block {
+			rel := pos.Sub(centre)
+			x, y, z := abs(rel[0]), rel[1], abs(rel[2])
+			switch {
+			case y < -1:
+				if x == radius && z == radius {
+					return d.bricks(pos)
+				}
+				return nil
+			case y == -1:
+				return d.bricks(pos)
+			}
+			if inside != nil {
+				if b := inside(rel); b != nil {
+					return b
+				}
+			}
+			if y <= 1 && (x == radius || z == radius) && x > 1 && z > 1 {
+				return block.NetherBrickFence{}
+			}
+			return block.Air{}
+		},
+	}
+}
+
+// enclosed returns a piece holding a room of nether bricks centred around the
+// position passed, with fence windows in its walls and a doorway in the
+// middle of each wall. inside is called for positions inside the room,
+// relative to the centre.
+func (d NetherFortressDecorator) enclosed(centre cube.Pos, radius int, inside func(rel cube.Pos) world.Block) piece {
+	return piece{
+		min: centre.Sub(cube.Pos{radius, 1, radius}),
+		max: centre.Add(cube.Pos{radius, 5, radius}),
+		at: func(pos cube.Pos) world.Block {
+			rel := pos.Sub(centre)
+			x, y, z := abs(rel[0]), rel[1], abs(rel[2])
+			switch {
+			case y == -1 || y == 5:
+				return d.bricks(pos)
+			case x == radius || z == radius:
+				if x <= 1 || z <= 1 {
+					if y <= 2 {
+						return block.Air{}
+					}
+					return d.bricks(pos)
+				}
+				if y == 2 && (x+z)%2 == 0 {
+					return block.NetherBrickFence{}
+				}
+				return d.bricks(pos)
+			}
+			if b := inside(rel); b != nil {
+				return b
+			}
+			return block.Air{}
+		},
+	}
+}
+
+// blazeRoom returns an open platform with a blaze spawner on a raised block of
+// nether bricks in its centre.
+func (d NetherFortressDecorator) blazeRoom(centre cube.Pos) piece {
+	return d.platform(centre, 4, func(rel cube.Pos) world.Block {
+		switch {
+		case rel == cube.Pos{0, 1, 0}:
+			return block.NewSpawner("minecraft:blaze")
+		case rel[1] == 0 && abs(rel[0]) <= 1 && abs(rel[2]) <= 1:
+			return d.bricks(centre.Add(rel))
+		}
+		return nil
+	})
+}
+
+// wartRoom returns an enclosed room with nether wart growing on patches of
+// soul sand along two of its walls.
+func (d NetherFortressDecorator) wartRoom(centre cube.Pos) piece {
+	p := d.enclosed(centre, 4, func(rel cube.Pos) world.Block {
+		if rel[1] == 0 && abs(rel[0]) >= 2 && abs(rel[2]) >= 2 {
+			return block.NetherWart{Age: int(positionHash(centre.Add(rel)) % 4)}
+		}
+		return nil
+	})
+	return p.withFloor(func(rel cube.Pos) world.Block {
+		if abs(rel[0]) >= 2 && abs(rel[0]) <= 3 && abs(rel[2]) >= 2 && abs(rel[2]) <= 3 {
+			return block.SoulSand{}
+		}
+		return nil
+	}, centre)
+}
+
+// hall returns an enclosed room with one or two chests holding fortress loot
+// in the corners at its back. dir is the direction of the bridge leading to
+// the hall, so that the entrance lies on the opposite side.
+func (d NetherFortressDecorator) hall(centre cube.Pos, dir cube.Direction, r *rand.Rand) piece {
+	back := step(cube.Pos{}, dir, 3)
+	chests := 1 + r.IntN(2)
+	return d.enclosed(centre, 4, func(rel cube.Pos) world.Block {
+		if rel[1] != 0 || abs(rel[0]) != 3 || abs(rel[2]) != 3 {
+			return nil
+		}
+		along, across := rel[0], rel[2]
+		if back[0] == 0 {
+			along, across = rel[2], rel[0]
+		}
+		if along != back[0]+back[2] || (chests == 1 && across < 0) {
+			return nil
+		}
+		chest := block.NewChest()
+		chest.Facing, chest.LootTable = dir.Opposite(), FortressLootTable
+		return chest
+	})
+}
+
+// bridge returns a piece holding a bridge of nether bricks starting at the
+// position passed and running in the direction passed for a length of n
+// blocks. The bridge is lined with fences and supported by pillars reaching
+// down to the bottom of the world.
+func (d NetherFortressDecorator) bridge(start cube.Pos, dir cube.Direction, n int) piece {
+	end := step(start, dir, n)
+	axisX := dir.Face().Axis() == cube.X
+	lo, hi := cube.Pos{min(start[0], end[0]), 0, min(start[2], end[2])}, cube.Pos{max(start[0], end[0]), start[1] + 3, max(start[2], end[2])}
+	if axisX {
+		lo, hi = lo.Sub(cube.Pos{0, 0, 2}), hi.Add(cube.Pos{0, 0, 2})
+	} else {
+		lo, hi = lo.Sub(cube.Pos{2, 0, 0}), hi.Add(cube.Pos{2, 0, 0})
+	}
+	return piece{min: lo, max: hi, at: func(pos cube.Pos) world.Block {
+		rel := pos.Sub(start)
+		along, across := abs(rel[0]), abs(rel[2])
+		if !axisX {
+			along, across = abs(rel[2]), abs(rel[0])
+		}
+		switch {
+		case along == 0:
+			// The start of the bridge lies at the edge of the central
+			// platform, which already has an opening there.
+			return nil
+		case along == n && (across == 2 || rel[1] < 0):
+			// The end of the bridge lies within the wall of a room, so only
+			// its doorway is opened up.
+			return nil
+		case rel[1] < -1:
+			if across == 2 && along%6 == 0 {
+				return d.bricks(pos)
+			}
+			return nil
+		case rel[1] == -1:
+			return d.bricks(pos)
+		case across == 2 && rel[1] <= 1:
+			return block.NetherBrickFence{}
+		}
+		return block.Air{}
+	}}
+}
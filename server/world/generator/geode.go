@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"maps"
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+const (
+	// geodeCellSize is the size in chunks of the cells that the world is
+	// divided into. Every cell holds at most one geode.
+	geodeCellSize = 3
+	// geodeMargin is the maximum distance in blocks from the centre of a geode
+	// to its outer shell.
+	geodeMargin = 9
+)
+
+// GeodeDecorator is a world.Generator that generates amethyst geodes
+// underground in chunks generated by another world.Generator. A geode is a
+// hollow blob with an outer shell of smooth basalt, a middle layer of calcite
+// and an inner layer of amethyst blocks and budding amethyst, on which
+// amethyst buds and clusters grow.
+// A GeodeDecorator may be constructed by calling NewGeodeDecorator.
+type GeodeDecorator struct {
+	g    world.Generator
+	seed uint64
+}
+
+// NewGeodeDecorator creates a GeodeDecorator that generates geodes in the
+// chunks generated by the world.Generator passed. The seed passed is used to
+// place geodes deterministically.
+func NewGeodeDecorator(g world.Generator, seed int64) GeodeDecorator {
+	return GeodeDecorator{g: g, seed: uint64(seed)}
+}
+
+// GenerateChunk generates the chunk using the underlying world.Generator and
+// generates the parts of any geodes that cross it.
+func (d GeodeDecorator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	d.GenerateBlockEntities(pos, c)
+}
+
+// GenerateBlockEntities generates the chunk using the underlying
+// world.Generator and generates the parts of any geodes that cross it. Geodes
+// hold no block entities, so only the block entities generated by the
+// underlying world.Generator are returned.
+func (d GeodeDecorator) GenerateBlockEntities(pos world.ChunkPos, c *chunk.Chunk) map[cube.Pos]world.Block {
+	blockEntities := map[cube.Pos]world.Block{}
+	if g, ok := d.g.(world.BlockEntityGenerator); ok {
+		maps.Copy(blockEntities, g.GenerateBlockEntities(pos, c))
+	} else {
+		d.g.GenerateChunk(pos, c)
+	}
+	// Geodes never extend beyond the cell that holds them, so only the geode
+	// of the cell of this chunk needs to be placed.
+	if g, ok := d.layout(floorDiv(pos[0], geodeCellSize), floorDiv(pos[1], geodeCellSize)); ok {
+		placePieces(pos, c, []piece{g.piece()}, blockEntities)
+	}
+	return blockEntities
+}
+
+// LocateStructure locates structures using the underlying world.Generator.
+func (d GeodeDecorator) LocateStructure(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	return locateStructure(d.g, tx, structure, pos)
+}
+
+// layout returns the geode in the cell passed. If the cell has no geode, false
+// is returned.
+func (d GeodeDecorator) layout(cellX, cellZ int32) (geode, bool) {
+	r := rand.New(rand.NewPCG(d.seed^0x1f83d9abfb41bd6b, uint64(uint32(cellX))<<32|uint64(uint32(cellZ))))
+	// Roughly one in three cells holds a geode.
+	if r.IntN(3) != 0 {
+		return geode{}, false
+	}
+	size := geodeCellSize * 16
+	centre := cube.Pos{
+		int(cellX)*size + geodeMargin + r.IntN(size-geodeMargin*2),
+		geodeMargin + 6 + r.IntN(48),
+		int(cellZ)*size + geodeMargin + r.IntN(size-geodeMargin*2),
+	}
+	g := geode{centre: centre}
+	// The shape of a geode is formed by a few points around its centre, which
+	// makes it a slightly irregular blob rather than a sphere.
+	for range 3 + r.IntN(2) {
+		g.points = append(g.points, centre.Add(cube.Pos{r.IntN(5) - 2, r.IntN(5) - 2, r.IntN(5) - 2}))
+	}
+	return g, true
+}
+
+// geode is an amethyst geode. Its positions are relative to the bottom of the
+// world, like those of a piece.
+type geode struct {
+	centre cube.Pos
+	points []cube.Pos
+}
+
+// Layers of a geode, from the outside in, as returned by geode.layer.
+const (
+	geodeOutside = iota
+	geodeShell
+	geodeCalcite
+	geodeAmethyst
+	geodeBudding
+	geodeInterior
+)
+
+// geodeRadii holds the radii of the layers of a geode if all of its points
+// lie in its centre, from the shell to the interior.
+var geodeRadii = [...]float64{6.2, 5.3, 4.5, 3.5}
+
+// piece returns the piece that places the geode.
+func (g geode) piece() piece {
+	return piece{
+		min: g.centre.Sub(cube.Pos{geodeMargin, geodeMargin, geodeMargin}),
+		max: g.centre.Add(cube.Pos{geodeMargin, geodeMargin, geodeMargin}),
+		at:  g.block,
+	}
+}
+
+// layer returns the layer of the geode that the position passed is in.
+func (g geode) layer(pos cube.Pos) int {
+	var v float64
+	for _, p := range g.points {
+		dx, dy, dz := float64(pos[0]-p[0]), float64(pos[1]-p[1]), float64(pos[2]-p[2])
+		v += 1 / math.Max(math.Sqrt(dx*dx+dy*dy+dz*dz), 0.5)
+	}
+	v /= float64(len(g.points))
+	for i := len(geodeRadii) - 1; i >= 0; i-- {
+		if v >= 1/geodeRadii[i] {
+			if i == geodeAmethyst-1 && positionHash(pos)%12 == 0 {
+				return geodeBudding
+			}
+			if i == len(geodeRadii)-1 {
+				return geodeInterior
+			}
+			return i + 1
+		}
+	}
+	return geodeOutside
+}
+
+// block returns the block of the geode at the position passed, or nil if the
+// position is outside the geode.
+func (g geode) block(pos cube.Pos) world.Block {
+	switch g.layer(pos) {
+	case geodeShell:
+		return block.SmoothBasalt{}
+	case geodeCalcite:
+		return block.Calcite{}
+	case geodeAmethyst:
+		return block.Amethyst{}
+	case geodeBudding:
+		return block.BuddingAmethyst{}
+	case geodeInterior:
+		// Buds and clusters grow on some of the sides of budding amethyst
+		// that face the hollow interior.
+		for _, face := range cube.Faces() {
+			if g.layer(pos.Side(face)) != geodeBudding || positionHash(pos)%3 != 0 {
+				continue
+			}
+			growth := block.AmethystGrowths()[positionHash(pos)>>8%4]
+			return block.AmethystCluster{Growth: growth, Facing: face.Opposite()}
+		}
+		return block.Air{}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+)
+
+// seedLoot sets the loot table seed of every block with a loot table in the
+// map of block entities passed to loot.PositionSeed for the seed passed and the
+// position of the block. Like in vanilla, this makes a container generate the
+// same loot every time a world with the same seed is generated. Blocks that
+// already have a loot table seed, such as those generated by an underlying
+// generator, are left untouched.
+func seedLoot(blockEntities map[cube.Pos]world.Block, seed uint64) {
+	for pos, b := range blockEntities {
+		s := loot.PositionSeed(int64(seed), pos)
+		switch b := b.(type) {
+		case block.Chest:
+			if b.LootTable != "" && b.LootTableSeed == 0 {
+				b.LootTableSeed = s
+				blockEntities[pos] = b
+			}
+		case block.Barrel:
+			if b.LootTable != "" && b.LootTableSeed == 0 {
+				b.LootTableSeed = s
+				blockEntities[pos] = b
+			}
+		case block.Dispenser:
+			if b.LootTable != "" && b.LootTableSeed == 0 {
+				b.LootTableSeed = s
+				blockEntities[pos] = b
+			}
+		case block.SuspiciousSand:
+			if b.LootTable != "" && b.LootTableSeed == 0 {
+				b.LootTableSeed = s
+				blockEntities[pos] = b
+			}
+		case block.SuspiciousGravel:
+			if b.LootTable != "" && b.LootTableSeed == 0 {
+				b.LootTableSeed = s
+				blockEntities[pos] = b
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+)
+
+func TestStructureLootTables(t *testing.T) {
+	paths := []string{
+		DungeonLootTable,
+		MineshaftLootTable,
+		StrongholdCorridorLootTable, StrongholdCrossingLootTable, StrongholdLibraryLootTable,
+		FortressLootTable,
+		BastionTreasureLootTable, BastionBridgeLootTable, BastionHoglinStableLootTable, BastionOtherLootTable,
+		DesertPyramidLootTable, DesertPyramidArchaeologyLootTable, JungleTempleLootTable, JungleTempleDispenserLootTable,
+		ShipwreckMapLootTable, ShipwreckSupplyLootTable, ShipwreckTreasureLootTable,
+		OceanRuinSmallLootTable, OceanRuinBigLootTable,
+		OceanRuinWarmArchaeologyLootTable, OceanRuinColdArchaeologyLootTable,
+		BuriedTreasureLootTable,
+	}
+	for _, b := range villageBuildings {
+		if b.loot == "" {
+			continue
+		}
+		for _, name := range []string{"desert", "plains", "savanna", "snowy", "taiga"} {
+			paths = append(paths, strings.ReplaceAll(b.loot, "%v", name))
+		}
+	}
+	for _, path := range paths {
+		if _, err := loot.LoadTable(path); err != nil {
+			t.Errorf("load %v: %v", path, err)
+		}
+	}
+}
+
+func TestSeedLoot(t *testing.T) {
+	chest, seeded, empty := block.NewChest(), block.NewChest(), block.NewChest()
+	chest.LootTable = DungeonLootTable
+	seeded.LootTable, seeded.LootTableSeed = DungeonLootTable, 5
+	a, b, c := cube.Pos{1, 2, 3}, cube.Pos{4, 5, 6}, cube.Pos{7, 8, 9}
+	blockEntities := map[cube.Pos]world.Block{a: chest, b: seeded, c: empty}
+
+	seedLoot(blockEntities, 42)
+	if got, want := blockEntities[a].(block.Chest).LootTableSeed, loot.PositionSeed(42, a); got != want {
+		t.Errorf("chest with loot table: got seed %v, want %v", got, want)
+	}
+	if got := blockEntities[b].(block.Chest).LootTableSeed; got != 5 {
+		t.Errorf("chest with loot table seed: got seed %v, want 5", got)
+	}
+	if got := blockEntities[c].(block.Chest).LootTableSeed; got != 0 {
+		t.Errorf("chest without loot table: got seed %v, want 0", got)
+	}
+}
@@ -0,0 +1,282 @@
+package generator
+
+import (
+	"maps"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// MineshaftLootTable is the loot table used to fill the chests placed in
+// mineshafts. The loot is generated when a chest is first opened.
+const MineshaftLootTable = "chests/abandoned_mineshaft.json"
+
+const (
+	// mineshaftCellSize is the size in chunks of the cells that the world is
+	// divided into. Every cell holds at most one mineshaft.
+	mineshaftCellSize = 8
+	// mineshaftReach is the maximum distance in blocks from the centre of a
+	// mineshaft that its corridors may reach. It must be smaller than the size
+	// of a cell in blocks, so that only neighbouring cells have to be checked
+	// when generating a chunk.
+	mineshaftReach = 80
+)
+
+// MineshaftDecorator is a world.Generator that generates abandoned mineshafts
+// underground in chunks generated by another world.Generator. Mineshafts
+// consist of corridors supported by oak planks and fences, with rails,
+// cobwebs, chests holding loot and the occasional cave spider spawner.
+// Because minecarts are not implemented, loot is placed in chests rather than
+// in minecarts with chests.
+// A MineshaftDecorator may be constructed by calling NewMineshaftDecorator.
+type MineshaftDecorator struct {
+	g    world.Generator
+	seed uint64
+
+	air, planks, fence, web, railX, railZ uint32
+}
+
+// NewMineshaftDecorator creates a MineshaftDecorator that generates mineshafts
+// in the chunks generated by the world.Generator passed. The seed passed is
+// used to lay out mineshafts deterministically, so that a mineshaft spanning
+// multiple chunks is generated the same way regardless of the order in which
+// the chunks are generated.
+func NewMineshaftDecorator(g world.Generator, seed int64) MineshaftDecorator {
+	return MineshaftDecorator{
+		g:      g,
+		seed:   uint64(seed),
+		air:    world.BlockRuntimeID(block.Air{}),
+		planks: world.BlockRuntimeID(block.Planks{Wood: block.OakWood()}),
+		fence:  world.BlockRuntimeID(block.WoodFence{Wood: block.OakWood()}),
+		web:    world.BlockRuntimeID(block.Web{}),
+		railX:  world.BlockRuntimeID(block.Rail{Axis: cube.X}),
+		railZ:  world.BlockRuntimeID(block.Rail{Axis: cube.Z}),
+	}
+}
+
+// corridor is a straight, three blocks wide and three blocks high corridor of
+// a mineshaft.
+type corridor struct {
+	// start and end are the positions of the floor at both ends of the centre
+	// line of the corridor.
+	start, end cube.Pos
+	axis       cube.Axis
+	// chest is the offset from start of a chest placed at the side of the
+	// corridor, or -1 if the corridor has no chest. chestSide is the side of
+	// the corridor, -1 or 1, that the chest is placed on.
+	chest, chestSide int
+	// spawner is the offset from start of a cave spider spawner placed in the
+	// corridor, or -1 if the corridor has no spawner.
+	spawner int
+}
+
+// GenerateChunk generates the chunk using the underlying world.Generator and
+// generates the parts of any mineshafts that cross it. Because the chests and
+// spawners of mineshafts require block entity data, GenerateBlockEntities
+// should be used instead where possible.
+func (d MineshaftDecorator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	d.GenerateBlockEntities(pos, c)
+}
+
+// GenerateBlockEntities generates the chunk using the underlying
+// world.Generator and generates the parts of any mineshafts that cross it. The
+// chests and spawners placed are returned along with any block entities
+// generated by the underlying world.Generator.
+func (d MineshaftDecorator) GenerateBlockEntities(pos world.ChunkPos, c *chunk.Chunk) map[cube.Pos]world.Block {
+	blockEntities := map[cube.Pos]world.Block{}
+	if g, ok := d.g.(world.BlockEntityGenerator); ok {
+		maps.Copy(blockEntities, g.GenerateBlockEntities(pos, c))
+	} else {
+		d.g.GenerateChunk(pos, c)
+	}
+
+	cellX, cellZ := floorDiv(pos[0], mineshaftCellSize), floorDiv(pos[1], mineshaftCellSize)
+	for x := cellX - 1; x <= cellX+1; x++ {
+		for z := cellZ - 1; z <= cellZ+1; z++ {
+			for _, cor := range d.layout(x, z, c.Range()) {
+				d.carve(pos, c, cor, blockEntities)
+			}
+		}
+	}
+	for p, b := range blockEntities {
+		// Corridors may cross each other, so a chest or spawner placed by one
+		// corridor may have been carved away by another.
+		if c.Block(uint8(p[0]&15), int16(p[1]), uint8(p[2]&15), 0) != world.BlockRuntimeID(b) {
+			delete(blockEntities, p)
+		}
+	}
+	seedLoot(blockEntities, d.seed)
+	return blockEntities
+}
+
+// LocateStructure locates structures using the underlying world.Generator.
+func (d MineshaftDecorator) LocateStructure(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	return locateStructure(d.g, tx, structure, pos)
+}
+
+// layout returns the corridors of the mineshaft in the cell passed. If the
+// cell has no mineshaft, nil is returned.
+func (d MineshaftDecorator) layout(cellX, cellZ int32, ra cube.Range) []corridor {
+	r := rand.New(rand.NewPCG(d.seed^0x5851f42d4c957f2d, uint64(uint32(cellX))<<32|uint64(uint32(cellZ))))
+	// Roughly one in four cells holds a mineshaft.
+	if r.IntN(4) != 0 {
+		return nil
+	}
+	size := mineshaftCellSize * 16
+	centre := cube.Pos{int(cellX)*size + 16 + r.IntN(size-32), ra.Min() + 10 + r.IntN(30), int(cellZ)*size + 16 + r.IntN(size-32)}
+
+	var corridors []corridor
+	for _, dir := range cube.Directions() {
+		d.branch(r, centre, centre, dir, 0, &corridors)
+	}
+	return corridors
+}
+
+// branch adds a corridor starting at the position passed and running in the
+// direction passed, after which it attempts to continue with up to three new
+// corridors from the end of the corridor.
+func (d MineshaftDecorator) branch(r *rand.Rand, centre, start cube.Pos, dir cube.Direction, depth int, corridors *[]corridor) {
+	if depth > 4 || len(*corridors) >= 32 {
+		return
+	}
+	length := 8 + r.IntN(24)
+	end := start
+	for i := 0; i < length; i++ {
+		next := end.Side(dir.Face())
+		if abs(next[0]-centre[0]) > mineshaftReach || abs(next[2]-centre[2]) > mineshaftReach {
+			break
+		}
+		end = next
+	}
+	if end == start {
+		return
+	}
+	cor := corridor{start: start, end: end, axis: dir.Face().Axis(), chest: -1, spawner: -1}
+	n := abs(end[0]-start[0]) + abs(end[2]-start[2])
+	if r.IntN(3) == 0 {
+		cor.chest, cor.chestSide = r.IntN(n+1), r.IntN(2)*2-1
+	}
+	if r.IntN(16) == 0 {
+		cor.spawner = r.IntN(n + 1)
+	}
+	*corridors = append(*corridors, cor)
+
+	if r.IntN(2) == 0 {
+		d.branch(r, centre, end, dir, depth+1, corridors)
+	}
+	if r.IntN(2) == 0 {
+		d.branch(r, centre, end, dir.RotateLeft(), depth+1, corridors)
+	}
+	if r.IntN(2) == 0 {
+		d.branch(r, centre, end, dir.RotateRight(), depth+1, corridors)
+	}
+}
+
+// carve carves the part of the corridor passed that lies within the chunk at
+// the position passed, adding any chests and spawners placed to the map of
+// block entities passed.
+func (d MineshaftDecorator) carve(pos world.ChunkPos, c *chunk.Chunk, cor corridor, blockEntities map[cube.Pos]world.Block) {
+	base := cube.Pos{int(pos[0]) << 4, 0, int(pos[1]) << 4}
+	start, end := cor.start, cor.end
+	if start[0] > end[0] || start[2] > end[2] {
+		start, end = end, start
+	}
+	// Quick check if the corridor, including its sides, crosses the chunk at
+	// all.
+	if end[0]+1 < base[0] || start[0]-1 > base[0]+15 || end[2]+1 < base[2] || start[2]-1 > base[2]+15 {
+		return
+	}
+	// side is the offset perpendicular to the corridor.
+	side := cube.Pos{0, 0, 1}
+	rail := d.railX
+	if cor.axis == cube.Z {
+		side, rail = cube.Pos{1, 0, 0}, d.railZ
+	}
+	n := abs(end[0]-start[0]) + abs(end[2]-start[2])
+	for i := 0; i <= n; i++ {
+		centre := start.Add(cube.Pos{(end[0] - start[0]) / max(n, 1) * i, 0, (end[2] - start[2]) / max(n, 1) * i})
+		// offset is the distance from the original start of the corridor, used
+		// to place supports, chests and spawners consistently.
+		offset := abs(centre[0]-cor.start[0]) + abs(centre[2]-cor.start[2])
+		support := offset%4 == 2
+		for s := -1; s <= 1; s++ {
+			p := centre.Add(cube.Pos{side[0] * s, 0, side[2] * s})
+			if p[0] < base[0] || p[0] > base[0]+15 || p[2] < base[2] || p[2] > base[2]+15 {
+				continue
+			}
+			x, y, z := uint8(p[0]-base[0]), int16(p[1]), uint8(p[2]-base[2])
+			if c.Block(x, y-1, z, 0) == d.air {
+				// Bridge gaps in the floor with planks.
+				c.SetBlock(x, y-1, z, 0, d.planks)
+			}
+			for dy := int16(0); dy < 3; dy++ {
+				c.SetBlock(x, y+dy, z, 0, d.air)
+			}
+			h := positionHash(p)
+			switch {
+			case support && s != 0:
+				c.SetBlock(x, y, z, 0, d.fence)
+				c.SetBlock(x, y+1, z, 0, d.fence)
+				c.SetBlock(x, y+2, z, 0, d.planks)
+			case support:
+				c.SetBlock(x, y+2, z, 0, d.planks)
+			case s != 0 && h%12 == 0:
+				c.SetBlock(x, y+2, z, 0, d.web)
+			}
+			switch {
+			case s == 0 && offset == cor.spawner:
+				spawner := block.NewSpawner("minecraft:cave_spider")
+				c.SetBlock(x, y, z, 0, world.BlockRuntimeID(spawner))
+				blockEntities[p] = spawner
+				c.SetBlock(x, y+1, z, 0, d.web)
+			case s == 0 && h%8 < 5:
+				c.SetBlock(x, y, z, 0, rail)
+			case s == cor.chestSide && offset == cor.chest && !support:
+				chest := block.NewChest()
+				chest.LootTable = MineshaftLootTable
+				// The chest faces the centre of the corridor.
+				switch {
+				case side[0]*s > 0:
+					chest.Facing = cube.West
+				case side[0]*s < 0:
+					chest.Facing = cube.East
+				case side[2]*s > 0:
+					chest.Facing = cube.North
+				default:
+					chest.Facing = cube.South
+				}
+				c.SetBlock(x, y, z, 0, world.BlockRuntimeID(chest))
+				blockEntities[p] = chest
+			}
+		}
+	}
+}
+
+// positionHash returns a pseudo-random value derived from the position
+// passed, used to place details that must not depend on the order in which
+// chunks are generated.
+func positionHash(pos cube.Pos) uint64 {
+	h := uint64(pos[0])*0x9e3779b97f4a7c15 ^ uint64(pos[1])*0xbf58476d1ce4e5b9 ^ uint64(pos[2])*0x94d049bb133111eb
+	h ^= h >> 31
+	h *= 0xbf58476d1ce4e5b9
+	return h ^ h>>29
+}
+
+// floorDiv divides a by b, rounding towards negative infinity.
+func floorDiv(a, b int32) int32 {
+	if a < 0 {
+		return (a - b + 1) / b
+	}
+	return a / b
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,248 @@
+package generator
+
+import (
+	"maps"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+const (
+	// OceanRuinSmallLootTable is the loot table used to fill the chests of
+	// small ocean ruins.
+	OceanRuinSmallLootTable = "chests/underwater_ruin_small.json"
+	// OceanRuinBigLootTable is the loot table used to fill the chests of big
+	// ocean ruins.
+	OceanRuinBigLootTable = "chests/underwater_ruin_big.json"
+	// OceanRuinWarmArchaeologyLootTable is the loot table used to generate the
+	// items hidden in the suspicious sand of warm ocean ruins.
+	OceanRuinWarmArchaeologyLootTable = "archaeology/ocean_ruin_warm.json"
+	// OceanRuinColdArchaeologyLootTable is the loot table used to generate the
+	// items hidden in the suspicious gravel of cold ocean ruins.
+	OceanRuinColdArchaeologyLootTable = "archaeology/ocean_ruin_cold.json"
+)
+
+// oceanRuinCellSize is the size in chunks of the cells that the world is
+// divided into. Every cell holds at most one ocean ruin.
+const oceanRuinCellSize = 6
+
+// OceanRuinDecorator is a world.Generator that generates the ruins of
+// buildings on the ocean floor of chunks generated by another world.Generator.
+// Ruins in warm and lukewarm oceans are built from sandstone and hide
+// suspicious sand in their floor, while ruins in other oceans are built from
+// stone bricks and hide suspicious gravel. Every ruin holds one or more chests.
+// The positions at which drowned should be spawned are returned by Spawns.
+// An OceanRuinDecorator may be constructed by calling NewOceanRuinDecorator.
+type OceanRuinDecorator struct {
+	g    world.Generator
+	seed uint64
+}
+
+// NewOceanRuinDecorator creates an OceanRuinDecorator that generates ocean
+// ruins in the chunks generated by the world.Generator passed. The seed passed
+// is used to place ruins deterministically.
+func NewOceanRuinDecorator(g world.Generator, seed int64) OceanRuinDecorator {
+	return OceanRuinDecorator{g: g, seed: uint64(seed)}
+}
+
+// GenerateChunk generates the chunk using the underlying world.Generator and
+// generates an ocean ruin in it, if any. Because the chests and suspicious
+// blocks of ocean ruins require block entity data, GenerateBlockEntities
+// should be used instead where possible.
+func (d OceanRuinDecorator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	d.GenerateBlockEntities(pos, c)
+}
+
+// GenerateBlockEntities generates the chunk using the underlying
+// world.Generator and generates an ocean ruin in it, if any. The chests and
+// suspicious blocks placed are returned along with any block entities
+// generated by the underlying world.Generator.
+func (d OceanRuinDecorator) GenerateBlockEntities(pos world.ChunkPos, c *chunk.Chunk) map[cube.Pos]world.Block {
+	blockEntities := map[cube.Pos]world.Block{}
+	if g, ok := d.g.(world.BlockEntityGenerator); ok {
+		maps.Copy(blockEntities, g.GenerateBlockEntities(pos, c))
+	} else {
+		d.g.GenerateChunk(pos, c)
+	}
+
+	ruin, facing, ok := d.layout(pos)
+	if !ok {
+		return blockEntities
+	}
+	floor, surface, ok := seafloor(c, 8, 8)
+	if !ok || surface-floor < 4 || floor-1 <= int16(c.Range().Min()) {
+		return blockEntities
+	}
+	b, _ := world.BiomeByID(int(c.Biome(8, floor, 8)))
+	if b == nil || !strings.Contains(b.String(), "ocean") {
+		return blockEntities
+	}
+	warm := strings.Contains(b.String(), "warm")
+	placeTemplate(pos, c, ruin.layers, floor-1, facing, func(char rune, p cube.Pos) world.Block {
+		return ruin.block(char, p, facing, warm)
+	}, blockEntities)
+	seedLoot(blockEntities, d.seed)
+	return blockEntities
+}
+
+// LocateStructure locates structures using the underlying world.Generator.
+func (d OceanRuinDecorator) LocateStructure(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	return locateStructure(d.g, tx, structure, pos)
+}
+
+// Spawns returns the positions in the chunk passed at which drowned should be
+// spawned in an ocean ruin, mapped to the identifier "minecraft:drowned".
+// Because ruins are placed on the ocean floor, the positions are found by
+// looking up the floor of the ruin using the world.Tx passed. Spawns is meant
+// to be called once after the chunk was first generated, for example to spawn
+// the entities using a world.EntityType registered by the server.
+func (d OceanRuinDecorator) Spawns(pos world.ChunkPos, tx *world.Tx) map[cube.Pos]string {
+	ruin, facing, ok := d.layout(pos)
+	if !ok {
+		return nil
+	}
+	spawns := map[cube.Pos]string{}
+	for _, layer := range ruin.layers {
+		for rz, row := range layer {
+			for rx, char := range row {
+				if char != 'd' {
+					continue
+				}
+				x, z := templateColumn(pos, ruin.layers, rx, rz, facing)
+				y := tx.HighestBlock(x, z)
+				for ; y > tx.Range().Min(); y-- {
+					if _, ok := tx.Block(cube.Pos{x, y, z}).(world.Liquid); !ok {
+						break
+					}
+				}
+				// Only spawn drowned if the ruin was actually generated on
+				// the floor below.
+				switch tx.Block(cube.Pos{x, y, z}).(type) {
+				case block.StoneBricks, block.Sandstone:
+					spawns[cube.Pos{x, y + 1, z}] = "minecraft:drowned"
+				}
+			}
+		}
+	}
+	return spawns
+}
+
+// layout returns the ocean ruin generated in the chunk passed and the
+// direction that it faces. If no ruin is generated in the chunk, false is
+// returned.
+func (d OceanRuinDecorator) layout(pos world.ChunkPos) (oceanRuin, cube.Direction, bool) {
+	cellX, cellZ := floorDiv(pos[0], oceanRuinCellSize), floorDiv(pos[1], oceanRuinCellSize)
+	r := rand.New(rand.NewPCG(d.seed^0x510e527fade682d1, uint64(uint32(cellX))<<32|uint64(uint32(cellZ))))
+	// Roughly two in three cells hold a ruin, if they lie in an ocean.
+	if r.IntN(3) == 0 {
+		return oceanRuin{}, 0, false
+	}
+	x, z := cellX*oceanRuinCellSize+r.Int32N(oceanRuinCellSize), cellZ*oceanRuinCellSize+r.Int32N(oceanRuinCellSize)
+	if pos != (world.ChunkPos{x, z}) {
+		return oceanRuin{}, 0, false
+	}
+	ruin := smallOceanRuin
+	if r.IntN(3) == 0 {
+		ruin = bigOceanRuin
+	}
+	return ruin, cube.Directions()[r.IntN(4)], true
+}
+
+// oceanRuin is a template of an ocean ruin. Its layers are listed from the
+// bottom up, and every layer lists its rows from north to south. The bottom
+// layer is placed below the top block of the ocean floor.
+// '#' is a wall and 'w' is a wall that may have eroded away. 'F' is the floor
+// of the ruin, 'g' is sand or gravel buried below the floor and 's' is
+// suspicious sand or gravel. 'C' is a chest and 'd' marks a position at which
+// drowned are spawned. ' ' and 'd' are water, and '.' leaves the terrain
+// untouched.
+type oceanRuin struct {
+	layers [][]string
+	// loot is the loot table used to fill the chests of the ruin.
+	loot string
+}
+
+// block returns the block represented by the template character passed at the
+// position passed, for a ruin facing the direction passed. warm specifies if
+// the ruin lies in a warm ocean and is built from sandstone.
+func (o oceanRuin) block(char rune, pos cube.Pos, facing cube.Direction, warm bool) world.Block {
+	switch char {
+	case 'w', '#':
+		if char == 'w' && positionHash(pos)%3 == 0 {
+			break
+		}
+		if warm {
+			if positionHash(pos)%4 == 0 {
+				return block.Sandstone{Type: block.CutSandstone()}
+			}
+			return block.Sandstone{Type: block.NormalSandstone()}
+		}
+		switch positionHash(pos) % 4 {
+		case 0:
+			return block.StoneBricks{Type: block.MossyStoneBricks()}
+		case 1:
+			return block.StoneBricks{Type: block.CrackedStoneBricks()}
+		}
+		return block.StoneBricks{Type: block.NormalStoneBricks()}
+	case 'F':
+		if warm {
+			return block.Sandstone{Type: block.NormalSandstone()}
+		}
+		return block.StoneBricks{Type: block.NormalStoneBricks()}
+	case 'g':
+		if warm {
+			return block.Sand{}
+		}
+		return block.Gravel{}
+	case 's':
+		if warm {
+			return block.SuspiciousSand{LootTable: OceanRuinWarmArchaeologyLootTable}
+		}
+		return block.SuspiciousGravel{LootTable: OceanRuinColdArchaeologyLootTable}
+	case 'C':
+		chest := block.NewChest()
+		chest.Facing, chest.LootTable = rotateDirection(cube.South, facing), o.loot
+		return chest
+	case '.':
+		return nil
+	}
+	return block.Water{Still: true, Depth: 8}
+}
+
+var (
+	// smallOceanRuin is a single room with a chest.
+	smallOceanRuin = oceanRuin{loot: OceanRuinSmallLootTable, layers: [][]string{
+		{"........", ".gggggg.", ".ggggsg.", ".gggggg.", ".gsgggg.", ".gggggg.", ".gggggg.", "........"},
+		{"########", "#FFFFFF#", "#FFFFFF#", "#FFFFFF#", "#FFFFFF#", "#FFFFFF#", "#FFFFFF#", "###FF###"},
+		{"########", "#      #", "#  C   #", "#      #", "#   d  #", "#      #", "#      #", "##    ##"},
+		{"wwwwwwww", "w      w", "w      w", "w      w", "w      w", "w      w", "w      w", "ww    ww"},
+		{"ww....ww", "w......w", "........", "........", "........", "........", "w......w", "ww....ww"},
+	}}
+	// bigOceanRuin is a building with three rooms and two chests.
+	bigOceanRuin = oceanRuin{loot: OceanRuinBigLootTable, layers: [][]string{
+		{
+			"............", ".gggggggggg.", ".ggsggggggg.", ".gggggggsgg.", ".gggggggggg.", ".gggggggggg.",
+			".gggsgggggg.", ".gggggggggg.", ".ggggggggsg.", ".gggggggggg.", ".gggggggggg.", "............",
+		},
+		{
+			"############", "#FFFFFFFFFF#", "#FFFFFFFFFF#", "#FFFFFFFFFF#", "#FFFFFFFFFF#", "#FFFFFFFFFF#",
+			"#FFFFFFFFFF#", "#FFFFFFFFFF#", "#FFFFFFFFFF#", "#FFFFFFFFFF#", "#FFFFFFFFFF#", "#####FF#####",
+		},
+		{
+			"############", "#    #     #", "# C  #  d  #", "#    #     #", "#          #", "#    #     #",
+			"######## ###", "#          #", "#  d       #", "#        C #", "#          #", "#####  #####",
+		},
+		{
+			"wwwwwwwwwwww", "w    w     w", "w    w     w", "w    w     w", "w          w", "w    w     w",
+			"wwwwwwww www", "w          w", "w          w", "w          w", "w          w", "wwwww  wwwww",
+		},
+		{
+			"wwww....wwww", "w....w.....w", "w..........w", "w..........w", "............", "............",
+			"ww.......www", "............", "............", "w..........w", "w..........w", "www......www",
+		},
+	}}
+)
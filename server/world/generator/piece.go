@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// piece is a part of a structure, such as a room or corridor, that occupies a
+// box in the world. The Y coordinates of a piece are relative to the bottom of
+// the world and are only made absolute when the piece is placed in a chunk.
+type piece struct {
+	// min and max are the corners of the box that holds the piece.
+	min, max cube.Pos
+	// at returns the block placed at the position passed, which lies within
+	// the box of the piece. If nil is returned, the block is left untouched.
+	at func(pos cube.Pos) world.Block
+}
+
+// withFloor returns the piece with the blocks in its floor, one block below
+// the centre passed, replaced by the ones returned by floor, if not nil.
+func (p piece) withFloor(floor func(rel cube.Pos) world.Block, centre cube.Pos) piece {
+	at := p.at
+	p.at = func(pos cube.Pos) world.Block {
+		if rel := pos.Sub(centre); rel[1] == -1 {
+			if b := floor(rel); b != nil {
+				return b
+			}
+		}
+		return at(pos)
+	}
+	return p
+}
+
+// placePieces places the parts of the pieces passed that lie within the chunk
+// at the position passed. Chests and spawners placed are added to the map of
+// block entities passed. Pieces are placed in order, so later pieces may
+// replace the blocks of earlier ones.
+func placePieces(pos world.ChunkPos, c *chunk.Chunk, pieces []piece, blockEntities map[cube.Pos]world.Block) {
+	base := cube.Pos{int(pos[0]) << 4, 0, int(pos[1]) << 4}
+	minY := c.Range().Min()
+	for _, p := range pieces {
+		if !boxCrossesChunk(p.min, p.max, pos) {
+			continue
+		}
+		for x := max(p.min[0], base[0]); x <= min(p.max[0], base[0]+15); x++ {
+			for z := max(p.min[2], base[2]); z <= min(p.max[2], base[2]+15); z++ {
+				for y := p.min[1]; y <= p.max[1]; y++ {
+					b := p.at(cube.Pos{x, y, z})
+					if b == nil {
+						continue
+					}
+					c.SetBlock(uint8(x-base[0]), int16(y+minY), uint8(z-base[2]), 0, world.BlockRuntimeID(b))
+					worldPos := cube.Pos{x, y + minY, z}
+					switch b.(type) {
+					case block.Chest, block.MobSpawner:
+						blockEntities[worldPos] = b
+					default:
+						// Pieces may overlap each other, so a chest placed by
+						// one may be replaced by another.
+						delete(blockEntities, worldPos)
+					}
+				}
+			}
+		}
+	}
+}
+
+// boxCrossesChunk checks if the box with the corners passed crosses the chunk
+// at the position passed.
+func boxCrossesChunk(minPos, maxPos cube.Pos, pos world.ChunkPos) bool {
+	x, z := int(pos[0])<<4, int(pos[1])<<4
+	return maxPos[0] >= x && minPos[0] <= x+15 && maxPos[2] >= z && minPos[2] <= z+15
+}
+
+// step returns the position n blocks away from the position passed in the
+// direction passed.
+func step(pos cube.Pos, dir cube.Direction, n int) cube.Pos {
+	for range n {
+		pos = pos.Side(dir.Face())
+	}
+	return pos
+}
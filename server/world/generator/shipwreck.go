@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"maps"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+const (
+	// ShipwreckMapLootTable is the loot table used to fill the map chests of
+	// shipwrecks, which hold a treasure map pointing to buried treasure.
+	ShipwreckMapLootTable = "chests/shipwreck.json"
+	// ShipwreckSupplyLootTable is the loot table used to fill the supply
+	// chests of shipwrecks.
+	ShipwreckSupplyLootTable = "chests/shipwrecksupply.json"
+	// ShipwreckTreasureLootTable is the loot table used to fill the treasure
+	// chests of shipwrecks.
+	ShipwreckTreasureLootTable = "chests/shipwrecktreasure.json"
+)
+
+// shipwreckCellSize is the size in chunks of the cells that the world is
+// divided into. Every cell holds at most one shipwreck.
+const shipwreckCellSize = 8
+
+// ShipwreckDecorator is a world.Generator that generates sunken ships on the
+// ocean floor of chunks generated by another world.Generator. Every shipwreck
+// holds a map chest with a treasure map, a supply chest and a treasure chest.
+// A ShipwreckDecorator may be constructed by calling NewShipwreckDecorator.
+type ShipwreckDecorator struct {
+	g    world.Generator
+	seed uint64
+}
+
+// NewShipwreckDecorator creates a ShipwreckDecorator that generates
+// shipwrecks in the chunks generated by the world.Generator passed. The seed
+// passed is used to place shipwrecks deterministically.
+func NewShipwreckDecorator(g world.Generator, seed int64) ShipwreckDecorator {
+	return ShipwreckDecorator{g: g, seed: uint64(seed)}
+}
+
+// GenerateChunk generates the chunk using the underlying world.Generator and
+// generates a shipwreck in it, if any. Because the chests of shipwrecks
+// require block entity data, GenerateBlockEntities should be used instead
+// where possible.
+func (d ShipwreckDecorator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	d.GenerateBlockEntities(pos, c)
+}
+
+// GenerateBlockEntities generates the chunk using the underlying
+// world.Generator and generates a shipwreck in it, if any. The chests placed
+// are returned along with any block entities generated by the underlying
+// world.Generator.
+func (d ShipwreckDecorator) GenerateBlockEntities(pos world.ChunkPos, c *chunk.Chunk) map[cube.Pos]world.Block {
+	blockEntities := map[cube.Pos]world.Block{}
+	if g, ok := d.g.(world.BlockEntityGenerator); ok {
+		maps.Copy(blockEntities, g.GenerateBlockEntities(pos, c))
+	} else {
+		d.g.GenerateChunk(pos, c)
+	}
+
+	cellX, cellZ := floorDiv(pos[0], shipwreckCellSize), floorDiv(pos[1], shipwreckCellSize)
+	r := rand.New(rand.NewPCG(d.seed^0xa54ff53a5f1d36f1, uint64(uint32(cellX))<<32|uint64(uint32(cellZ))))
+	// Roughly one in two cells holds a shipwreck, if it lies in an ocean.
+	if r.IntN(2) != 0 {
+		return blockEntities
+	}
+	x, z := cellX*shipwreckCellSize+r.Int32N(shipwreckCellSize), cellZ*shipwreckCellSize+r.Int32N(shipwreckCellSize)
+	if pos != (world.ChunkPos{x, z}) {
+		return blockEntities
+	}
+	floor, surface, ok := seafloor(c, 8, 8)
+	if !ok || surface-floor < 3 {
+		return blockEntities
+	}
+	if b, _ := world.BiomeByID(int(c.Biome(8, floor, 8))); b == nil || !strings.Contains(b.String(), "ocean") {
+		return blockEntities
+	}
+	wood := []block.WoodType{block.OakWood(), block.SpruceWood(), block.DarkOakWood(), block.BirchWood(), block.JungleWood()}[r.IntN(5)]
+	facing := cube.Directions()[r.IntN(4)]
+	placeTemplate(pos, c, shipwreck, floor, facing, func(char rune, p cube.Pos) world.Block {
+		return shipwreckBlock(char, p, facing, wood, int16(p[1]) <= surface)
+	}, blockEntities)
+	seedLoot(blockEntities, d.seed)
+	return blockEntities
+}
+
+// LocateStructure locates structures using the underlying world.Generator.
+func (d ShipwreckDecorator) LocateStructure(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	return locateStructure(d.g, tx, structure, pos)
+}
+
+// shipwreckBlock returns the block represented by the shipwreck template
+// character passed at the position passed, for a ship facing the direction
+// passed and built from the wood passed. Some of the planks of the ship are
+// left out so that it appears wrecked. submerged specifies if the position is
+// below the surface of the water.
+func shipwreckBlock(char rune, pos cube.Pos, facing cube.Direction, wood block.WoodType, submerged bool) world.Block {
+	switch char {
+	case 'p', 'P':
+		if char == 'P' && positionHash(pos)%5 == 0 {
+			break
+		}
+		return block.Planks{Wood: wood}
+	case 'f':
+		if positionHash(pos)%4 == 0 {
+			break
+		}
+		return block.WoodFence{Wood: wood}
+	case 'L':
+		return block.Log{Wood: wood, Axis: cube.Y}
+	case 'M', 'S', 'T':
+		chest := block.NewChest()
+		chest.Facing, chest.LootTable = rotateDirection(cube.South, facing), ShipwreckTreasureLootTable
+		switch char {
+		case 'M':
+			chest.LootTable = ShipwreckMapLootTable
+		case 'S':
+			chest.LootTable = ShipwreckSupplyLootTable
+		}
+		return chest
+	case '.':
+		return nil
+	}
+	if submerged {
+		return block.Water{Still: true, Depth: 8}
+	}
+	return block.Air{}
+}
+
+// seafloor returns the Y of the highest solid block below the water at the x
+// and z passed in the chunk passed, together with the Y of the surface of the
+// water. If the column is not covered by water, false is returned.
+func seafloor(c *chunk.Chunk, x, z uint8) (floor, surface int16, ok bool) {
+	surface = c.HighestBlock(x, z)
+	if _, ok := blockByRuntimeID(c.Block(x, surface, z, 0)).(block.Water); !ok {
+		return 0, 0, false
+	}
+	for floor = surface; floor > int16(c.Range().Min()); floor-- {
+		if solidRuntimeID(c.Block(x, floor, z, 0)) {
+			return floor, surface, true
+		}
+	}
+	return 0, 0, false
+}
+
+// shipwreck is the template of a sunken ship. Its layers are listed from the
+// bottom up, and every layer lists its rows from the bow in the north to the
+// stern in the south. The bottom layer replaces the top block of the ocean
+// floor. 'p' and 'P' are planks, of which 'P' may be missing, 'f' is a fence
+// that may be missing and 'L' is the log of the broken mast. 'S', 'T' and 'M'
+// are the supply, treasure and map chests respectively. ' ' is water, or air
+// above the surface of the water, and '.' leaves the terrain untouched.
+var shipwreck = [][]string{
+	{
+		".......", "...p...", "..ppp..", "..ppp..", "..ppp..", "..ppp..", "..ppp..", "..ppp..",
+		"..ppp..", "..ppp..", "..ppp..", "..ppp..", "..ppp..", "..ppp..", "...p...", ".......",
+	},
+	{
+		"...p...", "..pSp..", ".p   p.", ".p   p.", ".P   P.", ".p   p.", ".p T p.", ".P   P.",
+		".p   p.", ".P   p.", ".p   P.", ".p   p.", ".p M p.", ".p   p.", "..ppp..", ".......",
+	},
+	{
+		"...P...", "..PpP..", ".pPPPp.", ".pPpPp.", ".pp pp.", ".pPpPp.", ".pPPpP.", ".PpPPp.",
+		".pPLPp.", ".ppPpP.", ".PpPPp.", ".pPpPp.", ".pPpPp.", ".pp pp.", "..pPp..", ".......",
+	},
+	{
+		".......", "..fff..", ".f   f.", ".f   f.", ".f   f.", ".f   f.", ".f   f.", ".f   f.",
+		".f L f.", ".f   f.", ".f   f.", ".f   f.", ".f   f.", ".f   f.", "..fff..", ".......",
+	},
+	{
+		".......", ".......", ".......", ".......", ".......", ".......", ".......", ".......",
+		"...L...", ".......", ".......", ".......", ".......", ".......", ".......", ".......",
+	},
+	{
+		".......", ".......", ".......", ".......", ".......", ".......", ".......", ".......",
+		"...L...", ".......", ".......", ".......", ".......", ".......", ".......", ".......",
+	},
+}
@@ -0,0 +1,306 @@
+package generator
+
+import (
+	"maps"
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+const (
+	// StrongholdCorridorLootTable is the loot table used to fill chests in the
+	// corridors of strongholds.
+	StrongholdCorridorLootTable = "chests/stronghold_corridor.json"
+	// StrongholdCrossingLootTable is the loot table used to fill chests in the
+	// crossing rooms of strongholds.
+	StrongholdCrossingLootTable = "chests/stronghold_crossing.json"
+	// StrongholdLibraryLootTable is the loot table used to fill chests in the
+	// libraries of strongholds.
+	StrongholdLibraryLootTable = "chests/stronghold_library.json"
+)
+
+// strongholdRings holds the amount of strongholds in each of the rings
+// around the origin of the world, from the innermost ring outwards.
+var strongholdRings = [...]int{3, 6, 10, 15, 21, 28, 36, 9}
+
+// StrongholdDecorator is a world.Generator that generates strongholds
+// underground in chunks generated by another world.Generator. Strongholds are
+// placed in rings around the origin of the world, at positions determined by
+// the seed, and consist of a portal room with end portal frames, a crossing
+// room, a library and corridors connecting them.
+// A StrongholdDecorator may be constructed by calling NewStrongholdDecorator.
+type StrongholdDecorator struct {
+	g           world.Generator
+	seed        uint64
+	strongholds []stronghold
+}
+
+// stronghold is a single stronghold, consisting of rooms and corridors.
+type stronghold struct {
+	// min and max are the corners of the box that holds all pieces of the
+	// stronghold.
+	min, max cube.Pos
+	pieces   []piece
+}
+
+// NewStrongholdDecorator creates a StrongholdDecorator that generates
+// strongholds in the chunks generated by the world.Generator passed. The seed
+// passed determines the positions and layouts of the strongholds, so that a
+// stronghold spanning multiple chunks is generated the same way regardless of
+// the order in which the chunks are generated.
+func NewStrongholdDecorator(g world.Generator, seed int64) StrongholdDecorator {
+	d := StrongholdDecorator{g: g, seed: uint64(seed)}
+	rng := rand.New(rand.NewPCG(uint64(seed), 0x14057b7ef767814f))
+	angle := rng.Float64() * math.Pi * 2
+	for ring, n := range strongholdRings {
+		for i := 0; i < n; i++ {
+			// Strongholds are spread evenly around each ring, with some random
+			// variation in the distance from the origin.
+			dist := float64(128+ring*192) + (rng.Float64()-0.5)*80
+			a := angle + float64(i)*math.Pi*2/float64(n)
+			// The Y coordinate is relative to the bottom of the world and is
+			// only made absolute when generating a chunk.
+			centre := cube.Pos{int(math.Cos(a)*dist) << 4, 40 + rng.IntN(20), int(math.Sin(a)*dist) << 4}
+			d.strongholds = append(d.strongholds, d.layout(centre, rng))
+		}
+		angle += rng.Float64() * math.Pi
+	}
+	return d
+}
+
+// GenerateChunk generates the chunk using the underlying world.Generator and
+// generates the parts of any strongholds that cross it. Because chests and
+// spawners in strongholds require block entity data, GenerateBlockEntities
+// should be used instead where possible.
+func (d StrongholdDecorator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	d.GenerateBlockEntities(pos, c)
+}
+
+// GenerateBlockEntities generates the chunk using the underlying
+// world.Generator and generates the parts of any strongholds that cross it.
+// Chests and spawners placed are returned along with any block entities
+// generated by the underlying world.Generator.
+func (d StrongholdDecorator) GenerateBlockEntities(pos world.ChunkPos, c *chunk.Chunk) map[cube.Pos]world.Block {
+	blockEntities := map[cube.Pos]world.Block{}
+	if g, ok := d.g.(world.BlockEntityGenerator); ok {
+		maps.Copy(blockEntities, g.GenerateBlockEntities(pos, c))
+	} else {
+		d.g.GenerateChunk(pos, c)
+	}
+
+	for _, s := range d.strongholds {
+		if boxCrossesChunk(s.min, s.max, pos) {
+			placePieces(pos, c, s.pieces, blockEntities)
+		}
+	}
+	seedLoot(blockEntities, d.seed)
+	return blockEntities
+}
+
+// LocateStructure locates structures using the underlying world.Generator.
+func (d StrongholdDecorator) LocateStructure(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	return locateStructure(d.g, tx, structure, pos)
+}
+
+// layout lays out a stronghold with its portal room centred around the
+// position passed. From the portal room, a corridor leads to a crossing room,
+// which connects to a library and two dead-end corridors.
+func (d StrongholdDecorator) layout(centre cube.Pos, r *rand.Rand) stronghold {
+	var s stronghold
+	dir := cube.Directions()[r.IntN(4)]
+
+	s.pieces = append(s.pieces, d.portalRoom(centre, dir.Opposite()))
+	l1 := 6 + r.IntN(12)
+	crossing := step(centre, dir, 5+l1+4)
+	s.pieces = append(s.pieces, d.crossingRoom(crossing))
+
+	side := dir.RotateLeft()
+	if r.IntN(2) == 0 {
+		side = dir.RotateRight()
+	}
+	l2 := 6 + r.IntN(12)
+	library := step(crossing, side, 4+l2+6)
+	s.pieces = append(s.pieces, d.library(library))
+
+	// Corridors are added after the rooms, so that they open up the walls of
+	// the rooms they connect.
+	s.pieces = append(s.pieces,
+		d.corridor(step(centre, dir, 5), dir, l1+1, false, r),
+		d.corridor(step(crossing, side, 4), side, l2+1, false, r),
+		d.corridor(step(crossing, dir, 4), dir, 6+r.IntN(10), true, r),
+		d.corridor(step(crossing, side.Opposite(), 4), side.Opposite(), 6+r.IntN(10), true, r),
+	)
+
+	s.min, s.max = s.pieces[0].min, s.pieces[0].max
+	for _, p := range s.pieces[1:] {
+		s.min = cube.Pos{min(s.min[0], p.min[0]), min(s.min[1], p.min[1]), min(s.min[2], p.min[2])}
+		s.max = cube.Pos{max(s.max[0], p.max[0]), max(s.max[1], p.max[1]), max(s.max[2], p.max[2])}
+	}
+	return s
+}
+
+// bricks returns the stone bricks placed at the position passed. Most are
+// normal stone bricks, with some mossy and cracked ones in between.
+func (StrongholdDecorator) bricks(pos cube.Pos) world.Block {
+	switch h := positionHash(pos) % 10; {
+	case h < 6:
+		return block.StoneBricks{Type: block.NormalStoneBricks()}
+	case h < 8:
+		return block.StoneBricks{Type: block.MossyStoneBricks()}
+	}
+	return block.StoneBricks{Type: block.CrackedStoneBricks()}
+}
+
+// room returns a piece holding a room with walls of stone bricks, centred
+// around the position passed. The room has a size of 2*radius+1 blocks
+// horizontally, including its walls, and height blocks of air inside. inside
+// is called for positions inside the room, relative to the centre.
+func (d StrongholdDecorator) room(centre cube.Pos, radius, height int, inside func(rel cube.Pos) world.Block) piece {
+	return piece{
+		min: centre.Sub(cube.Pos{radius, 1, radius}),
+		max: centre.Add(cube.Pos{radius, height, radius}),
+		at: func(pos cube.Pos) world.Block {
+			rel := pos.Sub(centre)
+			if abs(rel[0]) == radius || abs(rel[2]) == radius || rel[1] == -1 || rel[1] == height {
+				return d.bricks(pos)
+			}
+			if b := inside(rel); b != nil {
+				return b
+			}
+			return block.Air{}
+		},
+	}
+}
+
+// portalRoom returns the room holding the end portal frames. Its silverfish
+// spawner is placed at the side opposite the entrance, which faces the
+// direction passed.
+func (d StrongholdDecorator) portalRoom(centre cube.Pos, back cube.Direction) piece {
+	spawner := step(cube.Pos{}, back, 4)
+	return d.room(centre, 5, 7, func(rel cube.Pos) world.Block {
+		x, y, z := rel[0], rel[1], rel[2]
+		switch {
+		case y == 0 && rel == spawner:
+			return block.NewSpawner("minecraft:silverfish")
+		case y != 0:
+			return nil
+		case abs(x) <= 1 && abs(z) <= 1:
+			return block.Air{}
+		case abs(x) == 2 && abs(z) <= 1, abs(z) == 2 && abs(x) <= 1:
+			// The frames face the centre of the portal and hold an eye with
+			// a chance of one in ten.
+			frame := block.EndPortalFrame{Eye: positionHash(centre.Add(rel))%10 == 0}
+			switch {
+			case x == -2:
+				frame.Facing = cube.East
+			case x == 2:
+				frame.Facing = cube.West
+			case z == -2:
+				frame.Facing = cube.South
+			default:
+				frame.Facing = cube.North
+			}
+			return frame
+		}
+		return nil
+	}).withFloor(func(rel cube.Pos) world.Block {
+		if abs(rel[0]) <= 1 && abs(rel[2]) <= 1 {
+			// Lava flows below the portal.
+			return block.Lava{Depth: 8, Still: true}
+		}
+		return nil
+	}, centre)
+}
+
+// crossingRoom returns a room where corridors of the stronghold meet, with a
+// chest in one of its corners.
+func (d StrongholdDecorator) crossingRoom(centre cube.Pos) piece {
+	return d.room(centre, 4, 5, func(rel cube.Pos) world.Block {
+		if rel == (cube.Pos{3, 0, 3}) {
+			chest := block.NewChest()
+			chest.Facing, chest.LootTable = cube.North, StrongholdCrossingLootTable
+			return chest
+		}
+		if rel == (cube.Pos{-3, 0, -3}) {
+			return block.Torch{Facing: cube.FaceDown, Type: block.NormalFire()}
+		}
+		return nil
+	})
+}
+
+// library returns a library room with bookshelves along its walls and a chest
+// holding library loot.
+func (d StrongholdDecorator) library(centre cube.Pos) piece {
+	return d.room(centre, 6, 7, func(rel cube.Pos) world.Block {
+		x, y, z := rel[0], rel[1], rel[2]
+		switch {
+		case rel == cube.Pos{4, 0, -4}:
+			chest := block.NewChest()
+			chest.Facing, chest.LootTable = cube.South, StrongholdLibraryLootTable
+			return chest
+		case y <= 3 && (abs(x) == 5 || abs(z) == 5) && abs(x) > 1 && abs(z) > 1:
+			// Bookshelves line the walls, except in the middle of each wall
+			// where corridors may enter the room.
+			return block.Bookshelf{}
+		case y <= 2 && abs(x) == 2 && abs(z) <= 3 && z != 0:
+			return block.Bookshelf{}
+		case y == 6 && positionHash(centre.Add(rel))%6 == 0:
+			return block.Web{}
+		}
+		return nil
+	})
+}
+
+// corridor returns a corridor starting at the position passed and running in
+// the direction passed for a length of n blocks. A dead-end corridor is closed
+// at its end and holds a chest.
+func (d StrongholdDecorator) corridor(start cube.Pos, dir cube.Direction, n int, deadEnd bool, r *rand.Rand) piece {
+	end := step(start, dir, n)
+	axisX := dir.Face().Axis() == cube.X
+	lo, hi := cube.Pos{min(start[0], end[0]), start[1], min(start[2], end[2])}, cube.Pos{max(start[0], end[0]), start[1], max(start[2], end[2])}
+	if axisX {
+		lo, hi = lo.Sub(cube.Pos{0, 1, 2}), hi.Add(cube.Pos{0, 3, 2})
+	} else {
+		lo, hi = lo.Sub(cube.Pos{2, 1, 0}), hi.Add(cube.Pos{2, 3, 0})
+	}
+	chestAt, chestSide := -1, 1
+	if deadEnd || r.IntN(2) == 0 {
+		chestAt, chestSide = 2+r.IntN(max(n-3, 1)), r.IntN(2)*2-1
+	}
+	return piece{min: lo, max: hi, at: func(pos cube.Pos) world.Block {
+		rel := pos.Sub(start)
+		along, across := abs(rel[0]), rel[2]
+		if !axisX {
+			along, across = abs(rel[2]), rel[0]
+		}
+		if abs(across) == 2 || rel[1] == -1 || rel[1] == 3 || (deadEnd && along == n) {
+			if along == 0 {
+				// The start of the corridor lies within the wall of a room,
+				// so the blocks around the opening are left untouched.
+				return nil
+			}
+			return d.bricks(pos)
+		}
+		if rel[1] == 0 && along == chestAt && across == chestSide {
+			chest := block.NewChest()
+			chest.LootTable = StrongholdCorridorLootTable
+			// The chest faces the middle of the corridor.
+			switch {
+			case axisX && across > 0:
+				chest.Facing = cube.North
+			case axisX:
+				chest.Facing = cube.South
+			case across > 0:
+				chest.Facing = cube.West
+			default:
+				chest.Facing = cube.East
+			}
+			return chest
+		}
+		return block.Air{}
+	}}
+}
@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// rotateTemplate returns the offset within the rotated box of a template of
+// the width and length passed at which the character at tx and tz of the
+// template is placed. Templates face south, so a template facing south is not
+// rotated.
+func rotateTemplate(tx, tz, width, length int, facing cube.Direction) (x, z int) {
+	switch facing {
+	case cube.North:
+		return width - 1 - tx, length - 1 - tz
+	case cube.East:
+		return tz, width - 1 - tx
+	case cube.West:
+		return length - 1 - tz, tx
+	}
+	return tx, tz
+}
+
+// templateSize returns the size on the X and Z axes of a template with the
+// layers passed when rotated to face the direction passed.
+func templateSize(layers [][]string, facing cube.Direction) (sizeX, sizeZ int) {
+	width, length := len(layers[0][0]), len(layers[0])
+	if facing == cube.East || facing == cube.West {
+		return length, width
+	}
+	return width, length
+}
+
+// placeTemplate places the layers passed in the middle of the chunk passed,
+// with the bottom layer at the Y passed and the template facing the direction
+// passed. at returns the block placed for a character of the template at a
+// position in the world. If at returns nil, the block is left untouched.
+// Blocks that hold block entity data are added to the map of block entities
+// passed.
+func placeTemplate(pos world.ChunkPos, c *chunk.Chunk, layers [][]string, y int16, facing cube.Direction, at func(char rune, pos cube.Pos) world.Block, blockEntities map[cube.Pos]world.Block) {
+	width, length := len(layers[0][0]), len(layers[0])
+	sizeX, sizeZ := templateSize(layers, facing)
+	offX, offZ := (16-sizeX)/2, (16-sizeZ)/2
+	base := cube.Pos{int(pos[0]) << 4, 0, int(pos[1]) << 4}
+	for ly, layer := range layers {
+		by := y + int16(ly)
+		if by < int16(c.Range().Min()) || by > int16(c.Range().Max()) {
+			continue
+		}
+		for tz, row := range layer {
+			for tx, char := range row {
+				x, z := rotateTemplate(tx, tz, width, length, facing)
+				bx, bz := uint8(offX+x), uint8(offZ+z)
+				p := base.Add(cube.Pos{int(bx), int(by), int(bz)})
+				b := at(char, p)
+				if b == nil {
+					continue
+				}
+				c.SetBlock(bx, by, bz, 0, world.BlockRuntimeID(b))
+				switch b.(type) {
+				case block.Chest, block.Dispenser, block.SuspiciousSand, block.SuspiciousGravel, block.MobSpawner:
+					blockEntities[p] = b
+				}
+			}
+		}
+	}
+}
+
+// templateColumn returns the X and Z of the column in the world that holds
+// the characters at tx and tz of a template with the layers passed, placed by
+// placeTemplate in the chunk at the position passed.
+func templateColumn(pos world.ChunkPos, layers [][]string, tx, tz int, facing cube.Direction) (int, int) {
+	sizeX, sizeZ := templateSize(layers, facing)
+	x, z := rotateTemplate(tx, tz, len(layers[0][0]), len(layers[0]), facing)
+	return int(pos[0])<<4 + (16-sizeX)/2 + x, int(pos[1])<<4 + (16-sizeZ)/2 + z
+}
@@ -0,0 +1,363 @@
+package generator
+
+import (
+	"maps"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+const (
+	// DesertPyramidLootTable is the loot table used to fill the chests placed
+	// in desert pyramids.
+	DesertPyramidLootTable = "chests/desert_pyramid.json"
+	// DesertPyramidArchaeologyLootTable is the loot table used to generate the
+	// items hidden in the suspicious sand of desert pyramids.
+	DesertPyramidArchaeologyLootTable = "archaeology/desert_pyramid.json"
+	// JungleTempleLootTable is the loot table used to fill the chests placed
+	// in jungle temples.
+	JungleTempleLootTable = "chests/jungle_temple.json"
+	// JungleTempleDispenserLootTable is the loot table used to fill the
+	// dispensers placed in jungle temples.
+	JungleTempleDispenserLootTable = "chests/jungle_temple_dispenser.json"
+)
+
+// templeCellSize is the size in chunks of the cells that the world is divided
+// into. Every cell holds at most one temple.
+const templeCellSize = 16
+
+// TempleDecorator is a world.Generator that generates desert pyramids in
+// deserts and jungle temples in jungles on the surface of chunks generated by
+// another world.Generator.
+// Desert pyramids hide a chamber with four chests and a TNT trap below their
+// floor, surrounded by suspicious sand holding archaeology loot. Jungle temples
+// have a basement with a dispenser trap, a wall of levers and a hidden chest.
+// Because redstone is not implemented, the traps and levers are not wired up.
+// A TempleDecorator may be constructed by calling NewTempleDecorator.
+type TempleDecorator struct {
+	g    world.Generator
+	seed uint64
+}
+
+// NewTempleDecorator creates a TempleDecorator that generates temples in the
+// chunks generated by the world.Generator passed. The seed passed is used to
+// place temples deterministically.
+func NewTempleDecorator(g world.Generator, seed int64) TempleDecorator {
+	return TempleDecorator{g: g, seed: uint64(seed)}
+}
+
+// GenerateChunk generates the chunk using the underlying world.Generator and
+// generates a temple in it, if any. Because the chests, dispensers and
+// suspicious sand of temples require block entity data, GenerateBlockEntities
+// should be used instead where possible.
+func (d TempleDecorator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	d.GenerateBlockEntities(pos, c)
+}
+
+// GenerateBlockEntities generates the chunk using the underlying
+// world.Generator and generates a temple in it, if any. The chests, dispensers
+// and suspicious sand placed are returned along with any block entities
+// generated by the underlying world.Generator.
+func (d TempleDecorator) GenerateBlockEntities(pos world.ChunkPos, c *chunk.Chunk) map[cube.Pos]world.Block {
+	blockEntities := map[cube.Pos]world.Block{}
+	if g, ok := d.g.(world.BlockEntityGenerator); ok {
+		maps.Copy(blockEntities, g.GenerateBlockEntities(pos, c))
+	} else {
+		d.g.GenerateChunk(pos, c)
+	}
+
+	cellX, cellZ := floorDiv(pos[0], templeCellSize), floorDiv(pos[1], templeCellSize)
+	r := rand.New(rand.NewPCG(d.seed^0xbb67ae8584caa73b, uint64(uint32(cellX))<<32|uint64(uint32(cellZ))))
+	// Roughly one in two cells holds a temple, if the biome allows it.
+	if r.IntN(2) != 0 {
+		return blockEntities
+	}
+	x, z := cellX*templeCellSize+1+r.Int32N(templeCellSize-2), cellZ*templeCellSize+1+r.Int32N(templeCellSize-2)
+	if pos != (world.ChunkPos{x, z}) {
+		return blockEntities
+	}
+	b, _ := world.BiomeByID(int(c.Biome(8, c.HighestBlock(8, 8), 8)))
+	if b == nil {
+		return blockEntities
+	}
+	facing := cube.Directions()[r.IntN(4)]
+	switch name := b.String(); {
+	case strings.Contains(name, "desert"):
+		d.place(pos, c, desertPyramid, facing, blockEntities)
+	case strings.Contains(name, "jungle"):
+		d.place(pos, c, jungleTemple, facing, blockEntities)
+	}
+	seedLoot(blockEntities, d.seed)
+	return blockEntities
+}
+
+// LocateStructure locates structures using the underlying world.Generator.
+func (d TempleDecorator) LocateStructure(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	return locateStructure(d.g, tx, structure, pos)
+}
+
+// place places the temple passed in the middle of the chunk passed, with its
+// ground layer replacing the top block of the terrain and its entrance facing
+// the direction passed. Chests, dispensers and suspicious sand placed are
+// added to the map of block entities passed.
+func (d TempleDecorator) place(pos world.ChunkPos, c *chunk.Chunk, t temple, facing cube.Direction, blockEntities map[cube.Pos]world.Block) {
+	width, length := len(t.layers[0][0]), len(t.layers[0])
+	sizeX, sizeZ := templateSize(t.layers, facing)
+	offX, offZ := (16-sizeX)/2, (16-sizeZ)/2
+	y := c.HighestBlock(uint8(offX+sizeX/2), uint8(offZ+sizeZ/2))
+	if y-int16(t.depth) <= int16(c.Range().Min()) {
+		return
+	}
+	if _, ok := blockByRuntimeID(c.Block(uint8(offX+sizeX/2), y, uint8(offZ+sizeZ/2), 0)).(world.Liquid); ok {
+		return
+	}
+	base := cube.Pos{int(pos[0]) << 4, 0, int(pos[1]) << 4}
+	for ly, layer := range t.layers {
+		for tz, row := range layer {
+			for tx, char := range row {
+				x, z := rotateTemplate(tx, tz, width, length, facing)
+				bx, bz, by := uint8(offX+x), uint8(offZ+z), y+int16(ly-t.depth)
+				p := base.Add(cube.Pos{int(bx), int(by), int(bz)})
+				b := t.block(char, p, facing)
+				if b == nil {
+					continue
+				}
+				rid := world.BlockRuntimeID(b)
+				c.SetBlock(bx, by, bz, 0, rid)
+				switch b.(type) {
+				case block.Chest, block.Dispenser, block.SuspiciousSand:
+					blockEntities[p] = b
+				}
+				if ly == t.depth && solidRuntimeID(rid) && (ly == 0 || t.layers[ly-1][tz][tx] == '.') {
+					// Extend the ground layer down to the terrain where nothing
+					// is built below it, so that the temple does not float on
+					// uneven terrain.
+					for fy := by - 1; fy > by-8 && !solidRuntimeID(c.Block(bx, fy, bz, 0)); fy-- {
+						c.SetBlock(bx, fy, bz, 0, rid)
+					}
+				}
+			}
+		}
+	}
+}
+
+// temple is a template of a temple. Its layers are listed from the bottom up,
+// and every layer lists its rows from north to south. Every character in a
+// row represents a block, as returned by temple.block. The entrance of a
+// temple is on its south side.
+type temple struct {
+	layers [][]string
+	// depth is the number of layers below the ground layer of the temple,
+	// which replaces the top block of the terrain.
+	depth int
+	// loot is the loot table used to fill the chests of the temple.
+	loot string
+}
+
+// block returns the block represented by the template character passed at the
+// position passed, for a temple facing the direction passed. Directional
+// blocks are rotated to match the facing direction. nil is returned for
+// characters that leave the terrain untouched.
+func (t temple) block(char rune, pos cube.Pos, facing cube.Direction) world.Block {
+	switch char {
+	case '#':
+		return block.Sandstone{Type: block.NormalSandstone()}
+	case 'c':
+		return block.Sandstone{Type: block.ChiseledSandstone()}
+	case 'u':
+		return block.Sandstone{Type: block.CutSandstone()}
+	case 'O':
+		return block.StainedTerracotta{Colour: item.ColourOrange()}
+	case 'B':
+		return block.StainedTerracotta{Colour: item.ColourBlue()}
+	case 'T':
+		return block.TNT{}
+	case 's':
+		return block.SuspiciousSand{LootTable: DesertPyramidArchaeologyLootTable}
+	case 'k':
+		return block.Cobblestone{Mossy: positionHash(pos)%3 == 0}
+	case 'x':
+		return block.StoneBricks{Type: block.ChiseledStoneBricks()}
+	case 'D':
+		dispenser := block.NewDispenser()
+		dispenser.Facing, dispenser.LootTable = rotateDirection(cube.South, facing).Face(), JungleTempleDispenserLootTable
+		return dispenser
+	case 'l':
+		// Levers are attached to the wall north of them.
+		return block.Lever{Facing: rotateDirection(cube.North, facing).Face()}
+	case '^', 'v', '<', '>':
+		dir := cube.South
+		switch char {
+		case '^':
+			dir = cube.North
+		case '<':
+			dir = cube.West
+		case '>':
+			dir = cube.East
+		}
+		chest := block.NewChest()
+		chest.Facing, chest.LootTable = rotateDirection(dir, facing), t.loot
+		return chest
+	case ' ':
+		return block.Air{}
+	}
+	return nil
+}
+
+var (
+	// desertPyramid is a desert pyramid with a hidden chamber holding four
+	// chests around a TNT trap below its floor. The characters '^', 'v', '<'
+	// and '>' represent chests facing north, south, west and east
+	// respectively.
+	desertPyramid = temple{depth: 5, loot: DesertPyramidLootTable, layers: [][]string{
+		{
+			"...............", "...............", "...............", "...#########...", "...#########...",
+			"...#########...", "...###TTT###...", "...###TTT###...", "...###TTT###...", "...#########...",
+			"...#########...", "...#########...", "...............", "...............", "...............",
+		},
+		{
+			"...............", "...............", "...............", "...####s####...", "...#########...",
+			"...##  v  ##...", "...##     ##...", "...s#>   <#s...", "...##     ##...", "...##  ^  ##...",
+			"...#########...", "...####s####...", "...............", "...............", "...............",
+		},
+		{
+			"...............", "...............", "...............", "...####s####...", "...#c#####c#...",
+			"...##     ##...", "...##     ##...", "...s#     #s...", "...##     ##...", "...##     ##...",
+			"...#c#####c#...", "...####s####...", "...............", "...............", "...............",
+		},
+		{
+			"...............", "...............", "...............", "...#########...", "...#########...",
+			"...##     ##...", "...##     ##...", "...##     ##...", "...##     ##...", "...##     ##...",
+			"...#########...", "...#########...", "...............", "...............", "...............",
+		},
+		{
+			"...............", "...............", "...............", "...#########...", "...#########...",
+			"...#########...", "...#########...", "...#### ####...", "...#########...", "...#########...",
+			"...#########...", "...#########...", "...............", "...............", "...............",
+		},
+		{
+			"###############", "###############", "###############", "###############", "###############",
+			"#######O#######", "######O#O######", "#####O#B#O#####", "######O#O######", "#######O#######",
+			"###############", "###############", "###############", "###############", "###############",
+		},
+		{
+			"###############", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "######   ######",
+		},
+		{
+			"###c#######c###", "#             #", "#             #", "c             c", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "c             c", "#             #", "#             #", "###c##   ##c###",
+		},
+		{
+			"###############", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "######   ######",
+		},
+		{
+			"###############", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "#             #",
+			"#             #", "#             #", "#             #", "#             #", "######uuu######",
+		},
+		{
+			"...............", ".#############.", ".#############.", ".#############.", ".#############.",
+			".#############.", ".#############.", ".#############.", ".#############.", ".#############.",
+			".#############.", ".#############.", ".#############.", ".#############.", "...............",
+		},
+		{
+			"...............", "...............", "..###########..", "..###########..", "..###########..",
+			"..###########..", "..###########..", "..###########..", "..###########..", "..###########..",
+			"..###########..", "..###########..", "..###########..", "...............", "...............",
+		},
+		{
+			"...............", "...............", "...............", "...#########...", "...#########...",
+			"...#########...", "...#########...", "...#########...", "...#########...", "...#########...",
+			"...#########...", "...#########...", "...............", "...............", "...............",
+		},
+		{
+			"...............", "...............", "...............", "...............", "....#######....",
+			"....#######....", "....#######....", "....#######....", "....#######....", "....#######....",
+			"....#######....", "...............", "...............", "...............", "...............",
+		},
+		{
+			"...............", "...............", "...............", "...............", "...............",
+			".....#####.....", ".....#####.....", ".....#####.....", ".....#####.....", ".....#####.....",
+			"...............", "...............", "...............", "...............", "...............",
+		},
+		{
+			"...............", "...............", "...............", "...............", "...............",
+			"...............", "......###......", "......###......", "......###......", "...............",
+			"...............", "...............", "...............", "...............", "...............",
+		},
+		{
+			"...............", "...............", "...............", "...............", "...............",
+			"...............", "...............", ".......c.......", "...............", "...............",
+			"...............", "...............", "...............", "...............", "...............",
+		},
+	}}
+	// jungleTemple is a jungle temple with a basement holding a dispenser
+	// trap, a wall of levers and a chest hidden behind a wall.
+	jungleTemple = temple{depth: 3, loot: JungleTempleLootTable, layers: [][]string{
+		{
+			"kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk",
+			"kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk",
+			"kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk",
+		},
+		{
+			"kkkkkkkkkkkk", "k lll  k   k", "k      k v k", "k      k   k", "k      kkkkk",
+			"kDkkkk     k", "k v        k", "k          k", "k          k", "k          k",
+			"k          k", "k          k", "k          k", "k          k", "kkkkkkkkkkkk",
+		},
+		{
+			"kkkkkkkkkkkk", "k      k   k", "k      k   k", "k      k   k", "k      kkkkk",
+			"kkkkkk     k", "k          k", "k          k", "k          k", "k          k",
+			"k          k", "k          k", "k          k", "k          k", "kkkkkkkkkkkk",
+		},
+		{
+			"kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk",
+			"kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk",
+			"kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkk kk", "kkkkkkkkkkkk", "kkkkkkkkkkkk",
+		},
+		{
+			"kkkkkkkkkkkk", "k          k", "k          k", "k          k", "k          k",
+			"k          k", "k          k", "k          k", "k          k", "k          k",
+			"k          k", "k          k", "k          k", "k          k", "kkkkk  kkkkk",
+		},
+		{
+			"kkkkkkkkkkkk", "k          k", "k          k", "k          k", "x          x",
+			"k          k", "k          k", "k          k", "k          k", "k          k",
+			"x          x", "k          k", "k          k", "k          k", "kkkkk  kkkkk",
+		},
+		{
+			"kkkkkkkkkkkk", "k          k", "k          k", "k          k", "k          k",
+			"k          k", "k          k", "k          k", "k          k", "k          k",
+			"k          k", "k          k", "k          k", "k          k", "kkkkkkkkkkkk",
+		},
+		{
+			"kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk",
+			"kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk",
+			"kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk", "kkkkkkkkkkkk",
+		},
+		{
+			"............", "............", "............", "..kkkkkkkk..", "..k      k..",
+			"..k      k..", "..k      k..", "..k      k..", "..k      k..", "..k      k..",
+			"..k      k..", "..kkk  kkk..", "............", "............", "............",
+		},
+		{
+			"............", "............", "............", "..kkkkkkkk..", "..k      k..",
+			"..k      k..", "..k      k..", "..k      k..", "..k      k..", "..k      k..",
+			"..k      k..", "..kkkkkkkk..", "............", "............", "............",
+		},
+		{
+			"............", "............", "............", "..kkkkkkkk..", "..kkkkkkkk..",
+			"..kkkkkkkk..", "..kkkkkkkk..", "..kkkkkkkk..", "..kkkkkkkk..", "..kkkkkkkk..",
+			"..kkkkkkkk..", "..kkkkkkkk..", "............", "............", "............",
+		},
+	}}
+)
@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"maps"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// BuriedTreasureLootTable is the loot table used to fill the chests of buried
+// treasure.
+const BuriedTreasureLootTable = "chests/buriedtreasure.json"
+
+const (
+	// treasureCellSize is the size in chunks of the cells that the world is
+	// divided into. Every cell holds at most one buried treasure.
+	treasureCellSize = 8
+	// treasureSearchRadius is the radius in cells that is searched when
+	// locating buried treasure.
+	treasureSearchRadius = 4
+)
+
+// BuriedTreasureDecorator is a world.Generator that buries chests filled with
+// treasure below the beaches of chunks generated by another world.Generator.
+// BuriedTreasureDecorator implements world.StructureLocator, so that treasure
+// maps found in shipwrecks and ocean ruins point to the buried treasure.
+// A BuriedTreasureDecorator may be constructed by calling
+// NewBuriedTreasureDecorator.
+type BuriedTreasureDecorator struct {
+	g    world.Generator
+	seed uint64
+}
+
+// NewBuriedTreasureDecorator creates a BuriedTreasureDecorator that buries
+// treasure in the chunks generated by the world.Generator passed. The seed
+// passed is used to place treasure deterministically.
+func NewBuriedTreasureDecorator(g world.Generator, seed int64) BuriedTreasureDecorator {
+	return BuriedTreasureDecorator{g: g, seed: uint64(seed)}
+}
+
+// GenerateChunk generates the chunk using the underlying world.Generator and
+// buries treasure in it, if any. Because the chests of buried treasure require
+// block entity data, GenerateBlockEntities should be used instead where
+// possible.
+func (d BuriedTreasureDecorator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	d.GenerateBlockEntities(pos, c)
+}
+
+// GenerateBlockEntities generates the chunk using the underlying
+// world.Generator and buries treasure in it, if any. The chest placed is
+// returned along with any block entities generated by the underlying
+// world.Generator.
+func (d BuriedTreasureDecorator) GenerateBlockEntities(pos world.ChunkPos, c *chunk.Chunk) map[cube.Pos]world.Block {
+	blockEntities := map[cube.Pos]world.Block{}
+	if g, ok := d.g.(world.BlockEntityGenerator); ok {
+		maps.Copy(blockEntities, g.GenerateBlockEntities(pos, c))
+	} else {
+		d.g.GenerateChunk(pos, c)
+	}
+	if d.candidate(floorDiv(pos[0], treasureCellSize), floorDiv(pos[1], treasureCellSize)) != pos {
+		return blockEntities
+	}
+	// Buried treasure is always placed in the same column of its chunk.
+	y := c.HighestBlock(9, 9)
+	for y > int16(c.Range().Min()) && !solidRuntimeID(c.Block(9, y, 9, 0)) {
+		y--
+	}
+	if b, _ := world.BiomeByID(int(c.Biome(9, y, 9))); y-3 <= int16(c.Range().Min()) || !beach(b) {
+		return blockEntities
+	}
+	chest := block.NewChest()
+	chest.LootTable = BuriedTreasureLootTable
+	c.SetBlock(9, y-3, 9, 0, world.BlockRuntimeID(chest))
+	blockEntities[cube.Pos{int(pos[0])<<4 + 9, int(y - 3), int(pos[1])<<4 + 9}] = chest
+	seedLoot(blockEntities, d.seed)
+	return blockEntities
+}
+
+// LocateStructure locates the buried treasure closest to the position passed
+// if the structure passed is "buriedtreasure". Other structures are located
+// using the underlying world.Generator. Because buried treasure is only found
+// below beaches, the biomes of candidate chunks are looked up using the
+// world.Tx passed, which may load or generate these chunks.
+func (d BuriedTreasureDecorator) LocateStructure(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	if structure != "buriedtreasure" {
+		return locateStructure(d.g, tx, structure, pos)
+	}
+	cellX, cellZ := floorDiv(int32(pos[0]>>4), treasureCellSize), floorDiv(int32(pos[2]>>4), treasureCellSize)
+	for radius := int32(0); radius <= treasureSearchRadius; radius++ {
+		found, closest := false, cube.Pos{}
+		for x := cellX - radius; x <= cellX+radius; x++ {
+			for z := cellZ - radius; z <= cellZ+radius; z++ {
+				if max(abs(int(x-cellX)), abs(int(z-cellZ))) != int(radius) {
+					// Only the cells on the edge of the ring are searched.
+					continue
+				}
+				chunkPos := d.candidate(x, z)
+				treasure := cube.Pos{int(chunkPos[0])<<4 + 9, 0, int(chunkPos[1])<<4 + 9}
+				treasure[1] = tx.HighestBlock(treasure[0], treasure[2])
+				if !beach(tx.Biome(treasure)) {
+					continue
+				}
+				if !found || distanceSquared(pos, treasure) < distanceSquared(pos, closest) {
+					found, closest = true, treasure
+				}
+			}
+		}
+		if found {
+			return closest, true
+		}
+	}
+	return cube.Pos{}, false
+}
+
+// candidate returns the position of the chunk in the cell passed that holds
+// buried treasure if it is located in a beach.
+func (d BuriedTreasureDecorator) candidate(cellX, cellZ int32) world.ChunkPos {
+	r := rand.New(rand.NewPCG(d.seed^0x3c6ef372fe94f82b, uint64(uint32(cellX))<<32|uint64(uint32(cellZ))))
+	return world.ChunkPos{cellX*treasureCellSize + r.Int32N(treasureCellSize), cellZ*treasureCellSize + r.Int32N(treasureCellSize)}
+}
+
+// beach checks if the biome passed is a beach.
+func beach(b world.Biome) bool {
+	return b != nil && strings.Contains(b.String(), "beach")
+}
+
+// distanceSquared returns the squared horizontal distance between the two
+// positions passed.
+func distanceSquared(a, b cube.Pos) int {
+	dx, dz := a[0]-b[0], a[2]-b[2]
+	return dx*dx + dz*dz
+}
+
+// locateStructure locates a structure using the world.Generator passed if it
+// implements world.StructureLocator. Decorators use it to forward calls to
+// LocateStructure to the world.Generator they decorate.
+func locateStructure(g world.Generator, tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	if l, ok := g.(world.StructureLocator); ok {
+		return l.LocateStructure(tx, structure, pos)
+	}
+	return cube.Pos{}, false
+}
@@ -0,0 +1,351 @@
+package generator
+
+import (
+	"maps"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+const (
+	// villageCellSize is the size in chunks of the cells that the world is
+	// divided into. Every cell holds at most one village.
+	villageCellSize = 12
+	// villageRadius is the radius in chunks around the centre of a village
+	// that the village spans.
+	villageRadius = 2
+)
+
+// VillageDecorator is a world.Generator that generates villages on the
+// surface of chunks generated by another world.Generator. Villages consist of
+// a well in the centre, roads leading away from it and houses, farms and
+// buildings with job site blocks along the roads. The materials used depend on
+// the biome the village is generated in, and chests in the buildings are
+// filled using the vanilla loot table of the building.
+// A VillageDecorator may be constructed by calling NewVillageDecorator.
+type VillageDecorator struct {
+	g    world.Generator
+	seed uint64
+}
+
+// NewVillageDecorator creates a VillageDecorator that generates villages in
+// the chunks generated by the world.Generator passed. The seed passed is used
+// to lay out villages deterministically.
+func NewVillageDecorator(g world.Generator, seed int64) VillageDecorator {
+	return VillageDecorator{g: g, seed: uint64(seed)}
+}
+
+// GenerateChunk generates the chunk using the underlying world.Generator and
+// generates the part of a village in it, if any. Because chests in villages
+// require block entity data, GenerateBlockEntities should be used instead
+// where possible.
+func (d VillageDecorator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	d.GenerateBlockEntities(pos, c)
+}
+
+// GenerateBlockEntities generates the chunk using the underlying
+// world.Generator and generates the part of a village in it, if any. Chests
+// placed are returned along with any block entities generated by the
+// underlying world.Generator.
+func (d VillageDecorator) GenerateBlockEntities(pos world.ChunkPos, c *chunk.Chunk) map[cube.Pos]world.Block {
+	blockEntities := map[cube.Pos]world.Block{}
+	if g, ok := d.g.(world.BlockEntityGenerator); ok {
+		maps.Copy(blockEntities, g.GenerateBlockEntities(pos, c))
+	} else {
+		d.g.GenerateChunk(pos, c)
+	}
+
+	cellX, cellZ := floorDiv(pos[0], villageCellSize), floorDiv(pos[1], villageCellSize)
+	r := rand.New(rand.NewPCG(d.seed^0x2545f4914f6cdd1d, uint64(uint32(cellX))<<32|uint64(uint32(cellZ))))
+	// Roughly one in three cells holds a village.
+	if r.IntN(3) != 0 {
+		return blockEntities
+	}
+	centreX := cellX*villageCellSize + villageRadius + r.Int32N(villageCellSize-villageRadius*2)
+	centreZ := cellZ*villageCellSize + villageRadius + r.Int32N(villageCellSize-villageRadius*2)
+	dx, dz := pos[0]-centreX, pos[1]-centreZ
+	if abs(int(dx)) > villageRadius || abs(int(dz)) > villageRadius {
+		return blockEntities
+	}
+	// Every chunk of the village gets its own random source, so that the
+	// buildings do not depend on the order in which chunks are generated.
+	r = rand.New(rand.NewPCG(d.seed^0x2545f4914f6cdd1d, uint64(uint32(pos[0]))<<32|uint64(uint32(pos[1]))))
+
+	v := villageVariantAt(c)
+	switch {
+	case dx == 0 && dz == 0:
+		d.place(pos, c, villageWell, cube.South, v, villageBuilding{}, blockEntities)
+	case dx == 0:
+		d.road(c, cube.Z, v)
+	case dz == 0:
+		d.road(c, cube.X, v)
+	case r.IntN(4) != 0:
+		// Buildings face the nearest road.
+		facing := cube.South
+		switch {
+		case abs(int(dx)) < abs(int(dz)) && dx > 0:
+			facing = cube.West
+		case abs(int(dx)) < abs(int(dz)):
+			facing = cube.East
+		case dz > 0:
+			facing = cube.North
+		}
+		b := villageBuildings[r.IntN(len(villageBuildings))]
+		d.place(pos, c, b.template, facing, v, b, blockEntities)
+	}
+	seedLoot(blockEntities, d.seed)
+	return blockEntities
+}
+
+// LocateStructure locates structures using the underlying world.Generator.
+func (d VillageDecorator) LocateStructure(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	return locateStructure(d.g, tx, structure, pos)
+}
+
+// road places a three blocks wide road of dirt paths through the middle of
+// the chunk passed, running along the axis passed.
+func (d VillageDecorator) road(c *chunk.Chunk, axis cube.Axis, v villageVariant) {
+	grass, dirt, sand, path := world.BlockRuntimeID(block.Grass{}), world.BlockRuntimeID(block.Dirt{}), world.BlockRuntimeID(block.Sand{}), world.BlockRuntimeID(v.road)
+	for i := uint8(0); i < 16; i++ {
+		for w := uint8(7); w <= 9; w++ {
+			x, z := i, w
+			if axis == cube.Z {
+				x, z = w, i
+			}
+			y := c.HighestBlock(x, z)
+			if rid := c.Block(x, y, z, 0); rid == grass || rid == dirt || rid == sand {
+				c.SetBlock(x, y, z, 0, path)
+			}
+		}
+	}
+}
+
+// place places the villageTemplate passed in the middle of the chunk passed,
+// on top of the terrain, with its entrance facing the direction passed. Any
+// chests placed are added to the map of block entities passed.
+func (d VillageDecorator) place(pos world.ChunkPos, c *chunk.Chunk, t villageTemplate, facing cube.Direction, v villageVariant, b villageBuilding, blockEntities map[cube.Pos]world.Block) {
+	width, length := len(t[0][0]), len(t[0])
+	sizeX, sizeZ := width, length
+	if facing == cube.East || facing == cube.West {
+		sizeX, sizeZ = length, width
+	}
+	offX, offZ := (16-sizeX)/2, (16-sizeZ)/2
+	y := c.HighestBlock(uint8(offX+sizeX/2), uint8(offZ+sizeZ/2))
+	if y <= int16(c.Range().Min()) {
+		return
+	}
+	if _, ok := blockByRuntimeID(c.Block(uint8(offX+sizeX/2), y, uint8(offZ+sizeZ/2), 0)).(world.Liquid); ok {
+		// Don't build villages on water.
+		return
+	}
+	base := cube.Pos{int(pos[0]) << 4, 0, int(pos[1]) << 4}
+	for ly, layer := range t {
+		for tz, row := range layer {
+			for tx, char := range row {
+				x, z := tx, tz
+				switch facing {
+				case cube.North:
+					x, z = width-1-tx, length-1-tz
+				case cube.East:
+					x, z = tz, width-1-tx
+				case cube.West:
+					x, z = length-1-tz, tx
+				}
+				bx, bz, by := uint8(offX+x), uint8(offZ+z), y+int16(ly)
+				bl := v.block(char, facing, b)
+				if bl == nil {
+					continue
+				}
+				c.SetBlock(bx, by, bz, 0, world.BlockRuntimeID(bl))
+				if chest, ok := bl.(block.Chest); ok {
+					blockEntities[base.Add(cube.Pos{int(bx), int(by), int(bz)})] = chest
+				}
+				if ly == 0 && char == '#' {
+					// Extend the foundation down to the ground, so that the
+					// building does not float on uneven terrain.
+					for fy := by - 1; fy > by-8 && !solidRuntimeID(c.Block(bx, fy, bz, 0)); fy-- {
+						c.SetBlock(bx, fy, bz, 0, world.BlockRuntimeID(v.foundation))
+					}
+				}
+			}
+		}
+	}
+}
+
+// blockByRuntimeID returns the block with the runtime ID passed, or air if no
+// such block exists.
+func blockByRuntimeID(rid uint32) world.Block {
+	b, ok := world.BlockByRuntimeID(rid)
+	if !ok {
+		return block.Air{}
+	}
+	return b
+}
+
+// solidRuntimeID checks if the block with the runtime ID passed is neither air
+// nor a liquid.
+func solidRuntimeID(rid uint32) bool {
+	switch blockByRuntimeID(rid).(type) {
+	case block.Air, world.Liquid:
+		return false
+	}
+	return true
+}
+
+// villageVariant holds the materials used to build a village in a specific
+// kind of biome.
+type villageVariant struct {
+	name                          string
+	foundation, planks, log, road world.Block
+}
+
+// villageVariantAt returns the villageVariant that suits the biome in the
+// centre of the chunk passed.
+func villageVariantAt(c *chunk.Chunk) villageVariant {
+	b, _ := world.BiomeByID(int(c.Biome(8, c.HighestBlock(8, 8), 8)))
+	name := ""
+	if b != nil {
+		name = b.String()
+	}
+	cobblestone := block.Cobblestone{}
+	switch {
+	case strings.Contains(name, "desert"):
+		sandstone := block.Sandstone{Type: block.NormalSandstone()}
+		return villageVariant{name: "desert", foundation: sandstone, planks: block.Sandstone{Type: block.SmoothSandstone()}, log: block.Sandstone{Type: block.CutSandstone()}, road: sandstone}
+	case strings.Contains(name, "savanna"):
+		return villageVariant{name: "savanna", foundation: cobblestone, planks: block.Planks{Wood: block.AcaciaWood()}, log: block.Log{Wood: block.AcaciaWood(), Axis: cube.Y}, road: block.DirtPath{}}
+	case strings.Contains(name, "snowy"), strings.Contains(name, "frozen"), strings.Contains(name, "ice"):
+		return villageVariant{name: "snowy", foundation: cobblestone, planks: block.Planks{Wood: block.SpruceWood()}, log: block.Log{Wood: block.SpruceWood(), Stripped: true, Axis: cube.Y}, road: block.DirtPath{}}
+	case strings.Contains(name, "taiga"):
+		return villageVariant{name: "taiga", foundation: cobblestone, planks: block.Planks{Wood: block.SpruceWood()}, log: block.Log{Wood: block.SpruceWood(), Axis: cube.Y}, road: block.DirtPath{}}
+	}
+	return villageVariant{name: "plains", foundation: cobblestone, planks: block.Planks{Wood: block.OakWood()}, log: block.Log{Wood: block.OakWood(), Axis: cube.Y}, road: block.DirtPath{}}
+}
+
+// block returns the block represented by the template character passed for
+// a building facing the direction passed. Directional blocks in templates face
+// south, and are rotated to match the facing direction. nil is returned for
+// characters that leave the terrain untouched.
+func (v villageVariant) block(char rune, facing cube.Direction, b villageBuilding) world.Block {
+	switch char {
+	case '#':
+		return v.foundation
+	case 'P':
+		return v.planks
+	case 'L':
+		return v.log
+	case 'W':
+		return block.GlassPane{}
+	case 'f':
+		return block.WoodFence{Wood: block.OakWood()}
+	case ' ':
+		return block.Air{}
+	case 'T':
+		return block.Torch{Facing: cube.FaceDown, Type: block.NormalFire()}
+	case 'F':
+		return block.Farmland{Hydration: 7}
+	case '~':
+		return block.Water{Depth: 8, Still: true}
+	case 'w':
+		return block.WheatSeeds{}
+	case 'J':
+		if b.jobSite == nil {
+			return block.Air{}
+		}
+		return b.jobSite(rotateDirection(cube.South, facing))
+	case 'C':
+		if b.loot == "" {
+			return block.Air{}
+		}
+		chest := block.NewChest()
+		chest.Facing = rotateDirection(cube.South, facing)
+		chest.LootTable = strings.ReplaceAll(b.loot, "%v", v.name)
+		return chest
+	}
+	return nil
+}
+
+// rotateDirection rotates a direction in a template, which faces south, to
+// match a building facing the direction passed.
+func rotateDirection(d, facing cube.Direction) cube.Direction {
+	switch facing {
+	case cube.North:
+		return d.Opposite()
+	case cube.East:
+		return d.RotateLeft()
+	case cube.West:
+		return d.RotateRight()
+	}
+	return d
+}
+
+// villageBuilding is a kind of building that may be generated in a village.
+type villageBuilding struct {
+	template villageTemplate
+	// jobSite returns the job site block placed in the building, facing the
+	// direction passed. It is nil for buildings without a job site block.
+	jobSite func(facing cube.Direction) world.Block
+	// loot is the loot table of the chest placed in the building. "%v" is
+	// replaced with the name of the villageVariant. If empty, no chest is
+	// placed.
+	loot string
+}
+
+// villageTemplate is a template of a village building. Its layers are listed
+// from the bottom up, with the bottom layer replacing the top block of the
+// terrain, and every layer lists its rows from north to south. Every
+// character in a row represents a block, as returned by villageVariant.block.
+// The entrance of a building is on its south side.
+type villageTemplate [][]string
+
+// villageBuildings holds all buildings, other than the well, that may be
+// generated in a village.
+var villageBuildings = []villageBuilding{
+	{template: villageHouse, loot: "chests/village/village_%v_house.json"},
+	{template: villageHouse, loot: "chests/village/village_%v_house.json"},
+	{template: villageFarm},
+	{template: villageFarm},
+	{template: villageHouse, jobSite: func(d cube.Direction) world.Block { return block.NewBlastFurnace(d) }, loot: "chests/village/village_armorer.json"},
+	{template: villageHouse, jobSite: func(d cube.Direction) world.Block { return block.NewSmoker(d) }, loot: "chests/village/village_butcher.json"},
+	{template: villageHouse, jobSite: func(cube.Direction) world.Block { return block.CartographyTable{} }, loot: "chests/village/village_cartographer.json"},
+	{template: villageHouse, jobSite: func(cube.Direction) world.Block { return block.NewBrewingStand() }, loot: "chests/village/village_temple.json"},
+	{template: villageHouse, jobSite: func(cube.Direction) world.Block { return block.FletchingTable{} }, loot: "chests/village/village_fletcher.json"},
+	{template: villageHouse, jobSite: func(d cube.Direction) world.Block { return block.Stonecutter{Facing: d} }, loot: "chests/village/village_mason.json"},
+	{template: villageHouse, jobSite: func(d cube.Direction) world.Block { return block.Loom{Facing: d} }, loot: "chests/village/village_shepherd.json"},
+	{template: villageHouse, jobSite: func(cube.Direction) world.Block { return block.SmithingTable{} }, loot: "chests/village/village_toolsmith.json"},
+	{template: villageHouse, jobSite: func(d cube.Direction) world.Block {
+		return block.Grindstone{Attach: block.StandingGrindstoneAttachment(), Facing: d}
+	}, loot: "chests/village/village_weaponsmith.json"},
+	{template: villageHouse, jobSite: func(d cube.Direction) world.Block { return block.Lectern{Facing: d} }},
+	{template: villageHouse, jobSite: func(cube.Direction) world.Block { return block.Composter{} }},
+}
+
+var (
+	// villageWell is the well in the centre of every village.
+	villageWell = villageTemplate{
+		{"#####", "#~~~#", "#~~~#", "#~~~#", "#####"},
+		{"f   f", "     ", "     ", "     ", "f   f"},
+		{"f   f", "     ", "     ", "     ", "f   f"},
+		{"#####", "#####", "#####", "#####", "#####"},
+	}
+	// villageHouse is a small house. Houses with a job site block use the
+	// same template.
+	villageHouse = villageTemplate{
+		{"#######", "#######", "#######", "#######", "#######", "#######", "#######"},
+		{"LPPPPPL", "PC   TP", "P     P", "P     P", "P    JP", "P     P", "LPP PPL"},
+		{"LPPPPPL", "P     P", "W     W", "P     P", "W     W", "P     P", "LPP PPL"},
+		{"LPPPPPL", "P     P", "P     P", "P     P", "P     P", "P     P", "LPPPPPL"},
+		{"PPPPPPP", "PPPPPPP", "PPPPPPP", "PPPPPPP", "PPPPPPP", "PPPPPPP", "PPPPPPP"},
+		{".......", ".PPPPP.", ".PPPPP.", ".PPPPP.", ".PPPPP.", ".PPPPP.", "......."},
+	}
+	// villageFarm is a farm plot with wheat growing around a channel of
+	// water.
+	villageFarm = villageTemplate{
+		{"LLLLLLLLL", "LFFF~FFFL", "LFFF~FFFL", "LFFF~FFFL", "LFFF~FFFL", "LFFF~FFFL", "LFFF~FFFL", "LFFF~FFFL", "LLLLLLLLL"},
+		{".........", ".www www.", ".www www.", ".www www.", ".www www.", ".www www.", ".www www.", ".www www.", "........."},
+	}
+)
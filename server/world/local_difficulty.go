@@ -0,0 +1,115 @@
+package world
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// LocalDifficulty is the difficulty at a specific position in a World. It
+// increases with the Difficulty of the World, the total time that has passed
+// in the World, the time that players have spent in the chunk of the position
+// and the MoonPhase. A LocalDifficulty may be obtained using
+// Tx.LocalDifficulty.
+type LocalDifficulty struct {
+	// Difficulty is the Difficulty of the World that the LocalDifficulty was
+	// computed for.
+	Difficulty Difficulty
+	// Value is the local difficulty. It is 0 for DifficultyPeaceful and
+	// ranges from 0.75 on DifficultyEasy up to 6.75 on DifficultyHard.
+	Value float64
+}
+
+// newLocalDifficulty computes a LocalDifficulty for a World with the
+// Difficulty passed at the World time passed, in a chunk that players have
+// spent the inhabited time passed in, in ticks.
+func newLocalDifficulty(diff Difficulty, worldTime, inhabited int64) LocalDifficulty {
+	id, ok := DifficultyID(diff)
+	if !ok {
+		id = 2
+	}
+	if id == 0 {
+		return LocalDifficulty{Difficulty: diff}
+	}
+	// The world factor grows over the first 21 hours of play after the first
+	// hour, up to 0.25.
+	world := mgl64.Clamp(float64(worldTime-72000)/1440000, 0, 1) * 0.25
+
+	// The chunk factor grows over the first 50 hours that players spend in a
+	// chunk, and is raised further on nights with a bright moon.
+	chunkFactor := 0.75
+	if diff == DifficultyHard {
+		chunkFactor = 1
+	}
+	chunk := mgl64.Clamp(float64(inhabited)/3600000, 0, 1) * chunkFactor
+	chunk += mgl64.Clamp(moonPhaseAt(worldTime).Brightness()*0.25, 0, world)
+	if diff == DifficultyEasy {
+		chunk *= 0.5
+	}
+	return LocalDifficulty{Difficulty: diff, Value: float64(id) * (0.75 + world + chunk)}
+}
+
+// Clamped returns the LocalDifficulty scaled to the range 0-1. It is 0 if the
+// local difficulty is lower than 2 and 1 if it is higher than 4. Vanilla uses
+// this value to scale most effects of the local difficulty.
+func (d LocalDifficulty) Clamped() float64 {
+	return mgl64.Clamp((d.Value-2)/2, 0, 1)
+}
+
+// Harder checks if the local difficulty is higher than the value passed.
+func (d LocalDifficulty) Harder(v float64) bool {
+	return d.Value > v
+}
+
+// ArmourChance returns the chance that a mob spawns wearing armour, in the
+// range 0-1.
+func (d LocalDifficulty) ArmourChance() float64 {
+	return 0.15 * d.Clamped()
+}
+
+// EnchantmentChance returns the chance that the equipment a mob spawns with
+// is enchanted, in the range 0-1. The chance applies to every item
+// separately.
+func (d LocalDifficulty) EnchantmentChance() float64 {
+	return 0.5 * d.Clamped()
+}
+
+// EnchantmentLevel returns the level of the enchanting table used to enchant
+// equipment that a mob spawns with, excluding a random bonus of up to 18
+// levels.
+func (d LocalDifficulty) EnchantmentLevel() int {
+	return 5 + int(d.Clamped()*18)
+}
+
+// PickUpLootChance returns the chance that a mob spawns with the ability to
+// pick up items, in the range 0-1.
+func (d LocalDifficulty) PickUpLootChance() float64 {
+	return 0.55 * d.Clamped()
+}
+
+// SpiderEffectChance returns the chance that a spider spawns with a random
+// status effect, in the range 0-1. Spiders only spawn with effects on
+// DifficultyHard.
+func (d LocalDifficulty) SpiderEffectChance() float64 {
+	if d.Difficulty != DifficultyHard {
+		return 0
+	}
+	return 0.1 * d.Clamped()
+}
+
+// LocalDifficulty returns the LocalDifficulty at the position passed.
+func (tx *Tx) LocalDifficulty(pos cube.Pos) LocalDifficulty {
+	w := tx.World()
+	inhabited := w.chunk(chunkPosFromBlockPos(pos)).inhabitedTime
+
+	w.set.Lock()
+	defer w.set.Unlock()
+	return newLocalDifficulty(w.set.Difficulty, w.set.Time, inhabited)
+}
+
+// InhabitedTime returns the total time that players have spent within
+// simulation distance of the chunk that the position passed is in.
+func (tx *Tx) InhabitedTime(pos cube.Pos) time.Duration {
+	return time.Duration(tx.World().chunk(chunkPosFromBlockPos(pos)).inhabitedTime) * time.Second / 20
+}
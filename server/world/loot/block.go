@@ -0,0 +1,71 @@
+package loot
+
+import (
+	"io/fs"
+	"strings"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+var (
+	blockTablesMu sync.RWMutex
+	blockTables   = map[string]string{}
+)
+
+// RegisterBlockTable assigns the loot table at the path passed to a block,
+// such as "minecraft:diamond_ore". The path is relative to the loot_tables
+// folder, for example "blocks/diamond_ore.json". Blocks with a table assigned
+// generate their drops from the table when broken instead of from the Drops
+// function of their BreakInfo. Passing an empty path removes the assignment.
+func RegisterBlockTable(name, path string) {
+	blockTablesMu.Lock()
+	defer blockTablesMu.Unlock()
+	if path == "" {
+		delete(blockTables, name)
+		return
+	}
+	blockTables[name] = path
+}
+
+// BlockTable returns the path of the loot table that the drops of the block
+// with the name passed are generated from. This is the table assigned using
+// RegisterBlockTable or, if none was assigned, a table at "blocks/<name>.json"
+// if it exists, such as a table at "blocks/diamond_ore.json" in the directory
+// set using SetSource for "minecraft:diamond_ore". Blocks outside the
+// minecraft namespace use "blocks/<namespace>/<name>.json". No block tables
+// are embedded, so blocks keep their hardcoded drops unless a table is added.
+func BlockTable(name string) (string, bool) {
+	blockTablesMu.RLock()
+	path, ok := blockTables[name]
+	blockTablesMu.RUnlock()
+	if ok {
+		return tablePath(path), true
+	}
+	path = "blocks/" + strings.ReplaceAll(strings.TrimPrefix(name, "minecraft:"), ":", "/") + ".json"
+	return path, tableExists(path)
+}
+
+// GenerateBlockDrops generates the drops of the block with the name passed
+// using the table returned by BlockTable and the Context passed, which
+// describes how the block was broken. The Context should hold the tool used,
+// so that match_tool conditions, such as those checking for Silk Touch, are
+// met. False is returned if the block has no table or if the table could not
+// be loaded.
+func GenerateBlockDrops(name string, ctx Context) ([]item.Stack, bool) {
+	path, ok := BlockTable(name)
+	if !ok {
+		return nil, false
+	}
+	return GenerateWithContext(path, ctx)
+}
+
+// tableExists checks if a loot table exists at the path passed.
+func tableExists(path string) bool {
+	path = tablePath(path)
+	if _, loaded, ok := cachedTable(path); loaded {
+		return ok
+	}
+	_, err := fs.Stat(lootFS, "loot_tables/"+path)
+	return err == nil
+}
@@ -0,0 +1,261 @@
+package loot
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Condition is a condition that must be met for a Pool or Entry to generate
+// loot. The fields used depend on the type of the condition held in the
+// Condition field. Conditions of a type not supported and not registered
+// using RegisterCondition are always met.
+type Condition struct {
+	Condition string `json:"condition"`
+	// Chance is the chance in a range of [0, 1] that random_chance and
+	// random_chance_with_looting conditions are met. LootingMultiplier is
+	// added to the chance of a random_chance_with_looting condition for every
+	// level of Looting of the tool.
+	Chance            float64 `json:"chance"`
+	LootingMultiplier float64 `json:"looting_multiplier"`
+	// Item and Enchantments are the item and enchantments that the tool must
+	// have for a match_tool condition to be met. Predicate holds the same in
+	// the format used by Java Edition.
+	Item         string                 `json:"item"`
+	Enchantments []EnchantmentPredicate `json:"enchantments"`
+	Predicate    *ToolPredicate         `json:"predicate"`
+	// Raining and Thundering are the weather that a weather_check condition
+	// requires. A nil value matches any weather.
+	Raining    *bool `json:"raining"`
+	Thundering *bool `json:"thundering"`
+	// Value is the range of time of day that a time_check condition requires.
+	// If Period is non-zero, the time is taken modulo Period, so that a
+	// Period of 24000 checks the time within the current day.
+	Value  Value `json:"value"`
+	Period int   `json:"period"`
+	// Entity and Properties are the entity and the properties it must have
+	// for an entity_properties condition to be met. Only the entity "this",
+	// which is the entity dropping the loot, is supported.
+	Entity     string                  `json:"entity"`
+	Properties EntityPropertyPredicate `json:"properties"`
+	// Term is the condition that an inverted condition is met if it is not.
+	// Terms are the conditions of which any_of and all_of conditions require
+	// any and all, respectively, to be met.
+	Term  *Condition  `json:"term"`
+	Terms []Condition `json:"terms"`
+	// Data holds the JSON that the Condition was decoded from, so that
+	// conditions registered using RegisterCondition may decode their own
+	// fields.
+	Data json.RawMessage `json:"-"`
+}
+
+var (
+	customConditionsMu sync.RWMutex
+	customConditions   = map[string]func(c Condition, ctx Context) bool{}
+)
+
+// RegisterCondition registers a condition that pools, entries and functions
+// of loot tables may be gated by, such as a condition checking the rank of the
+// player. The function registered is called with the Condition as found in the
+// loot table and the Context that loot is generated with, and returns if the
+// condition is met. Fields of the condition in JSON that Condition has no
+// field for may be decoded from Condition.Data. Conditions implemented by the
+// loot package, such as random_chance, cannot be replaced. Registering a
+// condition with the same name as a condition registered earlier replaces it.
+func RegisterCondition(name string, fn func(c Condition, ctx Context) bool) {
+	customConditionsMu.Lock()
+	defer customConditionsMu.Unlock()
+	customConditions[name] = fn
+}
+
+// registeredCondition returns the condition registered using
+// RegisterCondition with the name passed.
+func registeredCondition(name string) (func(c Condition, ctx Context) bool, bool) {
+	customConditionsMu.RLock()
+	defer customConditionsMu.RUnlock()
+	fn, ok := customConditions[name]
+	return fn, ok
+}
+
+// UnmarshalJSON decodes the Condition and keeps the JSON it was decoded from
+// in Data.
+func (c *Condition) UnmarshalJSON(data []byte) error {
+	// condition has the same fields as Condition, without its UnmarshalJSON
+	// method, so that decoding it does not recurse.
+	type condition Condition
+	if err := json.Unmarshal(data, (*condition)(c)); err != nil {
+		return err
+	}
+	c.Data = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// EntityPropertyPredicate holds the properties that an entity must have for an
+// entity_properties condition to be met. A nil value matches any entity.
+type EntityPropertyPredicate struct {
+	OnFire *bool `json:"on_fire"`
+}
+
+// ToolPredicate is the predicate of a match_tool condition in the format used
+// by Java Edition. The tool matches if it is one of the Items, if any, and has
+// all Enchantments.
+type ToolPredicate struct {
+	Items        []string               `json:"items"`
+	Enchantments []EnchantmentPredicate `json:"enchantments"`
+}
+
+// EnchantmentPredicate is an enchantment that the tool of a match_tool
+// condition must have, with a level within Levels. A Levels maximum of 0 means
+// there is no maximum level.
+type EnchantmentPredicate struct {
+	Enchantment string `json:"enchantment"`
+	Levels      Value  `json:"levels"`
+}
+
+// conditionsMet checks if all conditions passed are met for the context
+// passed. Conditions are evaluated in order and evaluation stops at the first
+// condition that is not met, like in vanilla.
+func conditionsMet(conditions []Condition, ctx *context) bool {
+	for i := range conditions {
+		if !conditions[i].met(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+// met checks if the Condition is met for the context passed.
+func (c *Condition) met(ctx *context) bool {
+	switch c.Condition {
+	case "random_chance":
+		return ctx.r.Float64() < c.Chance
+	case "random_chance_with_looting":
+		return ctx.r.Float64() < c.Chance+float64(toolEnchantmentLevel(ctx.Tool, "looting"))*c.LootingMultiplier
+	case "killed_by_player", "killed_by_player_or_pets":
+		return ctx.Player != nil
+	case "match_tool":
+		return c.matchTool(ctx.Tool)
+	case "survives_explosion":
+		return ctx.ExplosionRadius <= 0 || ctx.r.Float64() < 1/ctx.ExplosionRadius
+	case "weather_check":
+		raining, thundering := false, false
+		if ctx.Tx != nil {
+			raining, thundering = ctx.Tx.Raining(), ctx.Tx.Thundering()
+		}
+		return (c.Raining == nil || *c.Raining == raining) && (c.Thundering == nil || *c.Thundering == thundering)
+	case "entity_properties":
+		if c.Entity != "this" {
+			return true
+		}
+		return c.Properties.match(ctx.Entity)
+	case "inverted":
+		return c.Term == nil || !c.Term.met(ctx)
+	case "any_of":
+		for i := range c.Terms {
+			if c.Terms[i].met(ctx) {
+				return true
+			}
+		}
+		return false
+	case "all_of":
+		return conditionsMet(c.Terms, ctx)
+	case "time_check":
+		if ctx.Tx == nil {
+			return false
+		}
+		t := ctx.Tx.World().Time()
+		if c.Period > 0 {
+			t %= c.Period
+		}
+		return t >= c.Value.Min && t <= c.Value.Max
+	}
+	if fn, ok := registeredCondition(c.Condition); ok {
+		return fn(*c, ctx.Context)
+	}
+	return true
+}
+
+// match checks if the entity passed has the properties of the
+// EntityPropertyPredicate. A nil entity only matches if no properties are set.
+func (p EntityPropertyPredicate) match(e world.Entity) bool {
+	if p.OnFire != nil {
+		onFire := false
+		if f, ok := e.(interface{ OnFireDuration() time.Duration }); ok {
+			onFire = f.OnFireDuration() > 0
+		}
+		if onFire != *p.OnFire {
+			return false
+		}
+	}
+	return true
+}
+
+// matchTool checks if the tool passed matches the item and enchantments of a
+// match_tool Condition.
+func (c *Condition) matchTool(tool item.Stack) bool {
+	if c.Item != "" && !toolIs(tool, c.Item) {
+		return false
+	}
+	if !toolHasEnchantments(tool, c.Enchantments) {
+		return false
+	}
+	if p := c.Predicate; p != nil {
+		if len(p.Items) > 0 && !anyTool(tool, p.Items) {
+			return false
+		}
+		return toolHasEnchantments(tool, p.Enchantments)
+	}
+	return true
+}
+
+// anyTool checks if the tool passed is any of the items with the names passed.
+func anyTool(tool item.Stack, names []string) bool {
+	for _, name := range names {
+		if toolIs(tool, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// toolIs checks if the tool passed is the item with the name passed. Names
+// without a namespace are in the minecraft namespace.
+func toolIs(tool item.Stack, name string) bool {
+	if tool.Empty() {
+		return false
+	}
+	if !strings.Contains(name, ":") {
+		name = "minecraft:" + name
+	}
+	toolName, _ := tool.Item().EncodeItem()
+	return toolName == name
+}
+
+// toolHasEnchantments checks if the tool passed has all enchantments passed
+// with a level in the range required.
+func toolHasEnchantments(tool item.Stack, enchantments []EnchantmentPredicate) bool {
+	for _, e := range enchantments {
+		lvl := toolEnchantmentLevel(tool, e.Enchantment)
+		if lvl == 0 || lvl < e.Levels.Min || (e.Levels.Max > 0 && lvl > e.Levels.Max) {
+			return false
+		}
+	}
+	return true
+}
+
+// toolEnchantmentLevel returns the level of the enchantment with the name
+// passed on the tool passed, or 0 if the tool does not have the enchantment.
+func toolEnchantmentLevel(tool item.Stack, name string) int {
+	t, ok := item.EnchantmentByName(name)
+	if !ok || tool.Empty() {
+		return 0
+	}
+	if e, ok := tool.Enchantment(t); ok {
+		return e.Level()
+	}
+	return 0
+}
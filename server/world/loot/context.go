@@ -0,0 +1,147 @@
+package loot
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Context holds the circumstances under which loot is generated, such as the
+// player that opened a container or killed an entity and the tool that was
+// used. The functions and conditions of loot tables are evaluated against the
+// Context, so that, for example, entries with a killed_by_player condition are
+// only generated if Player is not nil. The zero value of Context describes
+// loot generated without a world, a player or a tool.
+type Context struct {
+	// Tx and Pos are the transaction and position that the loot is generated
+	// at. Tx may be nil, in which case functions and conditions that depend on
+	// the world, such as exploration_map and weather_check, are not applied or
+	// not met respectively.
+	Tx  *world.Tx
+	Pos cube.Pos
+	// Seed is the seed used for all randomness of the generation, so that the
+	// same loot is generated every time for the same seed. A Seed of 0
+	// generates random loot. PositionSeed may be used to get a different seed
+	// for every position in a world.
+	Seed int64
+	// Rand, if not nil, is used for all randomness of the generation instead
+	// of a source created from Seed. Rand is not safe for concurrent use, so
+	// it must not be shared between goroutines generating loot.
+	Rand *rand.Rand
+	// Player is the player that caused the loot to be generated, such as the
+	// player that opened a container or broke a block. For the drops of an
+	// entity, Player is the player that killed the entity, or nil if the
+	// entity was not killed by a player.
+	Player world.Entity
+	// Entity is the entity that drops the loot, such as an entity that was
+	// killed. It is used for entity_properties conditions, for example to
+	// smelt the drops of entities that died while on fire.
+	Entity world.Entity
+	// Tool is the item used to break the block or kill the entity that drops
+	// the loot. Its enchantments are used for the match_tool and
+	// random_chance_with_looting conditions.
+	Tool item.Stack
+	// Luck is the luck of the Player. Luck adds rolls to pools with bonus
+	// rolls and changes the weight of entries with a quality, so that a
+	// positive Luck makes entries with a positive quality more likely.
+	Luck float64
+	// ExplosionRadius is the radius of the explosion that caused the loot to
+	// be dropped, or 0 if the loot was not dropped by an explosion.
+	ExplosionRadius float64
+}
+
+// maxTableDepth is the maximum depth of loot tables nested using entries of
+// the type "loot_table", including the table that loot is generated from.
+const maxTableDepth = 16
+
+// context holds the Context and the source of randomness of a single
+// generation of loot.
+type context struct {
+	Context
+	r *rand.Rand
+	// tables holds the paths of the loot tables currently being generated,
+	// starting with the outermost table. It is used to detect cycles in
+	// nested tables. The path of the outermost table is unknown if the loot
+	// is generated from a LootTable directly.
+	tables []string
+}
+
+// newContext returns a new context for a single generation of loot using the
+// Context passed.
+func newContext(ctx Context) *context {
+	r := ctx.Rand
+	if r == nil && ctx.Seed != 0 {
+		r = rand.New(rand.NewPCG(uint64(ctx.Seed), uint64(ctx.Seed)))
+	} else if r == nil {
+		r = newRand()
+	}
+	return &context{Context: ctx, r: r}
+}
+
+// PositionSeed returns a seed for Context.Seed derived from the seed of a
+// world and a position in it, so that, for example, every chest of a world
+// generates different loot, while the same chest generates the same loot
+// every time it is generated in a world with the same seed. The seed returned
+// is never 0.
+func PositionSeed(worldSeed int64, pos cube.Pos) int64 {
+	h := uint64(worldSeed)
+	for _, v := range pos {
+		h = mix64(h ^ uint64(int64(v)))
+	}
+	return int64(max(h, 1))
+}
+
+// mix64 is the finaliser of SplitMix64, which spreads every bit of the input
+// over all bits of the output.
+func mix64(h uint64) uint64 {
+	h += 0x9e3779b97f4a7c15
+	h = (h ^ (h >> 30)) * 0xbf58476d1ce4e5b9
+	h = (h ^ (h >> 27)) * 0x94d049bb133111eb
+	return h ^ (h >> 31)
+}
+
+// appendNestedLoot generates the loot table referenced by the name of an entry
+// of the type "loot_table" and appends the stacks generated to the slice
+// passed. Nothing is generated if the table is already being generated, which
+// would otherwise recurse infinitely, or if the tables are nested too deeply.
+func (ctx *context) appendNestedLoot(name string, stacks []item.Stack) []item.Stack {
+	path := tablePath(name)
+	if slices.Contains(ctx.tables, path) {
+		fmt.Printf("[Loot System] Loot table '%s' references itself through %v\n", path, ctx.tables)
+		return stacks
+	}
+	if len(ctx.tables) >= maxTableDepth {
+		fmt.Printf("[Loot System] Loot table '%s' is nested deeper than %v tables\n", path, maxTableDepth)
+		return stacks
+	}
+	t, err := LoadTable(path)
+	if err != nil {
+		fmt.Printf("[Loot System] Error loading table '%s': %v\n", path, err)
+		return stacks
+	}
+	ctx.tables = append(ctx.tables, path)
+	stacks = t.appendLoot(ctx, stacks)
+	ctx.tables = ctx.tables[:len(ctx.tables)-1]
+	return stacks
+}
+
+// tablePath returns the path relative to the loot_tables folder of the table
+// referenced by an entry of the type "loot_table". Bedrock Edition names
+// tables by their path including the loot_tables folder, such as
+// "loot_tables/chests/simple_dungeon.json", while Java Edition uses names such
+// as "minecraft:chests/simple_dungeon".
+func tablePath(name string) string {
+	if _, after, ok := strings.Cut(name, ":"); ok {
+		name = after
+	}
+	name = strings.TrimPrefix(name, "loot_tables/")
+	if !strings.HasSuffix(name, ".json") {
+		name += ".json"
+	}
+	return name
+}
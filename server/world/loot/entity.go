@@ -0,0 +1,64 @@
+package loot
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+// entityTablesData holds the default assignment of loot tables to entity
+// types, mapping entity identifiers to paths relative to the loot_tables
+// folder.
+//
+//go:embed entity_tables.json
+var entityTablesData []byte
+
+var (
+	entityTablesMu sync.RWMutex
+	entityTables   = map[string]string{}
+)
+
+func init() {
+	if err := json.Unmarshal(entityTablesData, &entityTables); err != nil {
+		panic("loot: decode entity_tables.json: " + err.Error())
+	}
+}
+
+// RegisterEntityTable assigns the loot table at the path passed to an entity
+// type, such as "minecraft:zombie". The path is relative to the loot_tables
+// folder, for example "entities/zombie.json". Entities of the type generate
+// their drops from this table when they die, replacing any table previously
+// assigned to the type. Passing an empty path removes the assignment, so that
+// the entity no longer drops items from a loot table.
+func RegisterEntityTable(entityType, path string) {
+	entityTablesMu.Lock()
+	defer entityTablesMu.Unlock()
+	if path == "" {
+		delete(entityTables, entityType)
+		return
+	}
+	entityTables[entityType] = path
+}
+
+// EntityTable returns the path of the loot table assigned to the entity type
+// passed. If no table was assigned, false is returned.
+func EntityTable(entityType string) (string, bool) {
+	entityTablesMu.RLock()
+	defer entityTablesMu.RUnlock()
+	path, ok := entityTables[entityType]
+	return path, ok
+}
+
+// GenerateEntityDrops generates the drops of an entity of the type passed
+// using the loot table assigned to it through RegisterEntityTable and the
+// Context passed, which describes how the entity died. False is returned
+// if no table was assigned to the type or if the table could not be loaded.
+func GenerateEntityDrops(entityType string, ctx Context) ([]item.Stack, bool) {
+	path, ok := EntityTable(entityType)
+	if !ok {
+		return nil, false
+	}
+	return GenerateWithContext(path, ctx)
+}
@@ -0,0 +1,97 @@
+package loot
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// StructureLocator locates the structure with the name passed, such as
+// "buriedtreasure" or "mansion", that is closest to the position passed. False
+// is returned if no such structure was found.
+type StructureLocator func(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool)
+
+var (
+	structureLocatorMu sync.RWMutex
+	structureLocator   StructureLocator
+)
+
+// SetStructureLocator sets the StructureLocator that maps created by the
+// exploration_map function use to find the structure they point to. Because
+// the positions of structures depend on how a server generates or builds its
+// worlds, the locator may, for example, look structures up in a list of
+// hand-placed structures. If the locator passed does not find a structure,
+// or if no locator is set, the structure is located using the generator of
+// the world through Tx.LocateStructure. Passing nil removes the locator.
+func SetStructureLocator(l StructureLocator) {
+	structureLocatorMu.Lock()
+	defer structureLocatorMu.Unlock()
+	structureLocator = l
+}
+
+// locateStructure locates the structure with the name passed using the
+// StructureLocator set using SetStructureLocator, falling back to the
+// generator of the world.
+func locateStructure(tx *world.Tx, structure string, pos cube.Pos) (cube.Pos, bool) {
+	structureLocatorMu.RLock()
+	l := structureLocator
+	structureLocatorMu.RUnlock()
+	if l != nil {
+		if found, ok := l(tx, structure, pos); ok {
+			return found, true
+		}
+	}
+	return tx.LocateStructure(structure, pos)
+}
+
+// explorationMapDestinations maps the structure tags that Java Edition uses as
+// the destinations of exploration maps to the names of the structures.
+var explorationMapDestinations = map[string]string{
+	"on_treasure_maps":          "buriedtreasure",
+	"on_ocean_explorer_maps":    "monument",
+	"on_woodland_explorer_maps": "mansion",
+}
+
+// explorationMapMarkers maps the destinations and decorations of exploration
+// maps to the marker that the destination is marked with.
+var explorationMapMarkers = map[string]world.MapMarkerType{
+	"buriedtreasure": world.MapMarkerTreasure,
+	"red_x":          world.MapMarkerTreasure,
+	"monument":       world.MapMarkerMonument,
+	"mansion":        world.MapMarkerMansion,
+}
+
+// explorationMap turns the stack passed into a filled map pointing to the
+// closest structure of the destination of the exploration_map Function
+// passed. If no such structure could be located, the stack is returned
+// unchanged. Destinations without a marker of their own are marked with a red
+// cross, unless the Function has a decoration.
+func explorationMap(s item.Stack, f Function, ctx *context) item.Stack {
+	destination := strings.TrimPrefix(strings.TrimPrefix(f.Destination, "#"), "minecraft:")
+	if name, ok := explorationMapDestinations[destination]; ok {
+		destination = name
+	}
+	if destination == "" {
+		return s
+	}
+	marker, ok := explorationMapMarkers[strings.TrimPrefix(f.Decoration, "minecraft:")]
+	if !ok {
+		// The marker of the destination is also used for unknown decorations,
+		// as the map is still useful without the exact marker.
+		marker = explorationMapMarkers[destination]
+	}
+	pos, ok := locateStructure(ctx.Tx, destination, ctx.Pos)
+	if !ok {
+		return s
+	}
+	scale := uint8(1)
+	if f.Zoom > 0 {
+		scale = uint8(min(f.Zoom, world.MaxMapScale))
+	}
+	m := world.NewMapData(pos, scale, ctx.Tx.World().Dimension())
+	m.Markers = []world.MapMarker{{Pos: pos, Type: marker}}
+	return item.NewStack(item.FilledMap{ID: ctx.Tx.AddMap(m)}, s.Count())
+}
@@ -0,0 +1,49 @@
+package loot
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+var (
+	customFunctionsMu sync.RWMutex
+	customFunctions   = map[string]func(s item.Stack, f Function, ctx Context) item.Stack{}
+)
+
+// RegisterFunction registers a function that loot tables may apply to the
+// stacks they generate, such as "set_soulbound". The function registered is
+// called with the stack generated, the Function as found in the loot table and
+// the Context that loot is generated with, and returns the stack that the
+// loot table generates instead. Fields of the function in JSON that Function
+// has no field for may be decoded from Function.Data. Functions implemented by
+// the loot package, such as set_count, cannot be replaced. Registering a
+// function with the same name as a function registered earlier replaces it.
+func RegisterFunction(name string, fn func(s item.Stack, f Function, ctx Context) item.Stack) {
+	customFunctionsMu.Lock()
+	defer customFunctionsMu.Unlock()
+	customFunctions[name] = fn
+}
+
+// registeredFunction returns the function registered using RegisterFunction
+// with the name passed.
+func registeredFunction(name string) (func(s item.Stack, f Function, ctx Context) item.Stack, bool) {
+	customFunctionsMu.RLock()
+	defer customFunctionsMu.RUnlock()
+	fn, ok := customFunctions[name]
+	return fn, ok
+}
+
+// UnmarshalJSON decodes the Function and keeps the JSON it was decoded from
+// in Data.
+func (f *Function) UnmarshalJSON(data []byte) error {
+	// function has the same fields as Function, without its UnmarshalJSON
+	// method, so that decoding it does not recurse.
+	type function Function
+	if err := json.Unmarshal(data, (*function)(f)); err != nil {
+		return err
+	}
+	f.Data = append(json.RawMessage(nil), data...)
+	return nil
+}
@@ -0,0 +1,205 @@
+package loot
+
+import (
+	"encoding/json"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// UnmarshalJSON decodes the LootTable. Loot tables in the format of Java
+// Edition, such as the tables of vanilla data packs, are translated to the
+// format of Bedrock Edition first, so that they may be used unchanged.
+func (t *LootTable) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if translateJava(v) {
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return err
+		}
+	}
+	// lootTable has the same fields as LootTable, without its UnmarshalJSON
+	// method, so that decoding it does not recurse.
+	type lootTable LootTable
+	return json.Unmarshal(data, (*lootTable)(t))
+}
+
+// javaNames maps the names of functions, conditions and entry types of Java
+// Edition, without namespace, that differ from those of Bedrock Edition to
+// the names of Bedrock Edition.
+var javaNames = map[string]string{
+	"alternative":              "any_of",
+	"enchanted_count_increase": "looting_enchant",
+}
+
+// translateJava translates the decoded JSON of a loot table passed from the
+// format of Java Edition to the format of Bedrock Edition in place. It
+// returns true if anything was translated. JSON already in the format of
+// Bedrock Edition is left unchanged.
+func translateJava(v any) (changed bool) {
+	switch v := v.(type) {
+	case []any:
+		for _, e := range v {
+			changed = translateJava(e) || changed
+		}
+	case map[string]any:
+		for _, k := range []string{"type", "function", "condition"} {
+			name, ok := v[k].(string)
+			if !ok || !strings.HasPrefix(name, "minecraft:") {
+				continue
+			}
+			name = strings.TrimPrefix(name, "minecraft:")
+			if n, ok := javaNames[name]; ok {
+				name = n
+			}
+			v[k], changed = name, true
+		}
+		changed = translateJavaObject(v) || changed
+		for _, e := range v {
+			changed = translateJava(e) || changed
+		}
+	}
+	return changed
+}
+
+// translateJavaObject translates the fields of a single entry, function or
+// condition passed that are named or structured differently in Java Edition.
+func translateJavaObject(v map[string]any) bool {
+	rename := func(from, to string) bool {
+		val, ok := v[from]
+		if !ok {
+			return false
+		}
+		delete(v, from)
+		v[to] = val
+		return true
+	}
+	switch {
+	case v["type"] == "loot_table":
+		// Nested tables are referred to by value since 1.21.
+		if _, ok := v["value"].(string); ok {
+			return rename("value", "name")
+		}
+	case v["function"] == "enchant_randomly":
+		return rename("options", "enchantments")
+	case v["function"] == "looting_enchant":
+		// enchanted_count_increase names the enchantment, which is always
+		// Looting in vanilla tables.
+		delete(v, "enchantment")
+	case v["condition"] == "random_chance":
+		// The chance may be a number provider since 1.21.
+		if chance, ok := v["chance"].(map[string]any); ok {
+			v["chance"] = chance["value"]
+			return true
+		}
+	case v["condition"] == "random_chance_with_enchanted_bonus":
+		// The chance of vanilla tables increases linearly with the level of
+		// Looting.
+		v["condition"], v["chance"] = "random_chance_with_looting", v["unenchanted_chance"]
+		if bonus, ok := v["enchanted_chance"].(map[string]any); ok {
+			v["looting_multiplier"] = bonus["per_level_above_first"]
+		}
+		return true
+	case v["condition"] == "entity_properties":
+		if flags, ok := nested(v, "predicate", "flags"); ok {
+			delete(v, "predicate")
+			v["properties"] = map[string]any{"on_fire": flags["is_on_fire"]}
+			return true
+		}
+	case v["condition"] == "match_tool":
+		return translateJavaToolPredicate(v)
+	}
+	return false
+}
+
+// translateJavaToolPredicate translates the predicate of a match_tool
+// condition passed. Java Edition allows a single item instead of a list of
+// items and, since 1.21, holds enchantments in a list of item predicates.
+func translateJavaToolPredicate(v map[string]any) bool {
+	p, ok := v["predicate"].(map[string]any)
+	if !ok {
+		return false
+	}
+	changed := false
+	if name, ok := p["items"].(string); ok {
+		p["items"], changed = []any{name}, true
+	}
+	if predicates, ok := p["predicates"].(map[string]any); ok {
+		enchantments, _ := predicates["minecraft:enchantments"].([]any)
+		for _, e := range enchantments {
+			if e, ok := e.(map[string]any); ok {
+				if name, ok := e["enchantments"].(string); ok {
+					e["enchantment"] = name
+				}
+			}
+		}
+		delete(p, "predicates")
+		p["enchantments"], changed = enchantments, true
+	}
+	return changed
+}
+
+// nested returns the object found by following the keys passed from the
+// object passed.
+func nested(v map[string]any, keys ...string) (map[string]any, bool) {
+	for _, k := range keys {
+		var ok bool
+		if v, ok = v[k].(map[string]any); !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// javaItemNames maps the names of items in Java Edition to the names of the
+// same items in Bedrock Edition. They are only used for items of which the
+// name is not registered, so that names that refer to different items in the
+// two editions are not translated.
+var javaItemNames = map[string]string{
+	"minecraft:cobweb":            "minecraft:web",
+	"minecraft:dead_bush":         "minecraft:deadbush",
+	"minecraft:end_stone_bricks":  "minecraft:end_bricks",
+	"minecraft:grass":             "minecraft:short_grass",
+	"minecraft:jack_o_lantern":    "minecraft:lit_pumpkin",
+	"minecraft:lily_pad":          "minecraft:waterlily",
+	"minecraft:magma_block":       "minecraft:magma",
+	"minecraft:nether_quartz_ore": "minecraft:quartz_ore",
+	"minecraft:note_block":        "minecraft:noteblock",
+	"minecraft:powered_rail":      "minecraft:golden_rail",
+	"minecraft:red_nether_bricks": "minecraft:red_nether_brick",
+	"minecraft:scute":             "minecraft:turtle_scute",
+	"minecraft:slime_block":       "minecraft:slime",
+	"minecraft:spawner":           "minecraft:mob_spawner",
+	"minecraft:terracotta":        "minecraft:hardened_clay",
+}
+
+// javaItem returns the item with the name passed in Java Edition.
+func javaItem(name string) (world.Item, bool) {
+	if n, ok := javaItemNames[name]; ok {
+		return world.ItemByName(n, 0)
+	}
+	return nil, false
+}
+
+// dataPackRoots returns the folders holding the loot tables of the data packs
+// found in the fs.FS passed. A data pack holds the loot tables of every
+// namespace in data/<namespace>/loot_table, or data/<namespace>/loot_tables
+// before 1.21. The namespaces are dropped, like the namespaces of nested
+// tables.
+func dataPackRoots(fsys fs.FS) []string {
+	var roots []string
+	for _, pattern := range []string{"data/*/loot_table", "data/*/loot_tables"} {
+		matches, _ := fs.Glob(fsys, pattern)
+		for _, m := range matches {
+			if info, err := fs.Stat(fsys, m); err == nil && info.IsDir() {
+				roots = append(roots, path.Clean(m))
+			}
+		}
+	}
+	return roots
+}
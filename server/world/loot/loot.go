@@ -4,12 +4,19 @@ import (
 	"embed"
 	"encoding/json"
 	"fmt"
-	"math/rand"
+	"io/fs"
+	"math"
+	"math/rand/v2"
+	"slices"
 	"strings"
+	"sync"
 
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
-	"github.com/df-mc/dragonfly/server/item/enchantment"
+	// Loot tables refer to the enchantments of the enchantment package by name.
+	_ "github.com/df-mc/dragonfly/server/item/enchantment"
 	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/metrics"
 	"github.com/df-mc/dragonfly/server/world"
 )
 
@@ -20,35 +27,170 @@ var lootFS embed.FS //
 func Generate(path string) ([]item.Stack, bool) {
 	// We no longer prefix with "server/world/loot/".
 	// The path passed should be relative to the loot_tables folder (e.g., "chests/dungeon.json").
+	return GenerateWithContext(path, Context{})
+}
+
+// GenerateAt loads a loot table from the embedded filesystem and generates
+// items for a container at the position passed. Unlike Generate, GenerateAt
+// also applies functions that depend on the world, such as exploration_map.
+func GenerateAt(path string, tx *world.Tx, pos cube.Pos) ([]item.Stack, bool) {
+	return GenerateWithContext(path, Context{Tx: tx, Pos: pos})
+}
+
+// GenerateAtSeed generates items in the same way as GenerateAt, but uses the
+// seed passed for all randomness, so that the same items are generated every
+// time for the same seed, such as the loot table seed of a container. A seed
+// of 0 generates random loot, like GenerateAt.
+func GenerateAtSeed(path string, tx *world.Tx, pos cube.Pos, seed int64) ([]item.Stack, bool) {
+	return GenerateWithContext(path, Context{Tx: tx, Pos: pos, Seed: seed})
+}
+
+// GenerateWithContext loads a loot table from the embedded filesystem and
+// generates items using the Context passed. Pools and entries with
+// conditions that are not met for the Context do not generate items.
+func GenerateWithContext(path string, ctx Context) ([]item.Stack, bool) {
+	path = tablePath(path)
 	t, err := LoadTable(path)
 	if err != nil {
 		fmt.Printf("[Loot System] Error loading table '%s': %v\n", path, err)
 		return nil, false
 	}
-	return t.Generate(), true
+	metrics.LootRoll(path)
+	c := newContext(ctx)
+	c.tables = []string{path}
+	return t.generate(c), true
 }
 
-// LoadTable reads the JSON data directly from the embedded memory.
+// LoadTable reads the JSON data directly from the embedded memory. The path
+// passed is relative to the loot_tables folder, although paths including the
+// folder, such as those stored in the LootTable tag of chests, and Java
+// Edition names such as "minecraft:chests/simple_dungeon" are accepted too.
+// Every embedded table is only
+// decoded once, after which the decoded table is shared by all calls. Once
+// Reload has been called, LoadTable returns the tables loaded by Reload
+// instead.
 func LoadTable(path string) (LootTable, error) {
+	path = tablePath(path)
+	if t, loaded, ok := cachedTable(path); loaded {
+		if !ok {
+			return LootTable{}, fmt.Errorf("loot table %v does not exist", path)
+		}
+		return t, nil
+	}
+	embeddedMu.RLock()
+	res, ok := embeddedTables[path]
+	embeddedMu.RUnlock()
+	if ok {
+		return res.t, res.err
+	}
 	// b, err := os.ReadFile(path) is replaced by:
-	b, err := lootFS.ReadFile(path)
-	if err != nil {
-		return LootTable{}, err
+	b, err := lootFS.ReadFile("loot_tables/" + path)
+	if err == nil {
+		var t LootTable
+		err = json.Unmarshal(b, &t)
+		res.t = t
 	}
-	var t LootTable
-	err = json.Unmarshal(b, &t)
-	return t, err
+	res.err = err
+
+	embeddedMu.Lock()
+	defer embeddedMu.Unlock()
+	embeddedTables[path] = res
+	return res.t, res.err
+}
+
+var (
+	embeddedMu sync.RWMutex
+	// embeddedTables holds the tables decoded from the embedded filesystem
+	// by LoadTable, along with the error returned when decoding them, keyed
+	// by their paths.
+	embeddedTables = map[string]struct {
+		t   LootTable
+		err error
+	}{}
+)
+
+// Tables returns the paths of all loot tables, relative to the loot_tables
+// folder, such as "chests/simple_dungeon.json". Once Reload has been called,
+// the paths of the tables loaded by Reload are returned.
+func Tables() []string {
+	if paths := cachedPaths(); paths != nil {
+		return paths
+	}
+	var paths []string
+	_ = fs.WalkDir(lootFS, "loot_tables", func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			paths = append(paths, strings.TrimPrefix(path, "loot_tables/"))
+		}
+		return nil
+	})
+	return paths
 }
 
 // Generate processes the entire LootTable and returns a slice of all stacks generated.
 func (t LootTable) Generate() []item.Stack {
-	var stacks []item.Stack
+	return t.GenerateWithContext(Context{})
+}
+
+// GenerateWithContext processes the entire LootTable using the Context passed
+// and returns a slice of all stacks generated.
+func (t LootTable) GenerateWithContext(ctx Context) []item.Stack {
+	return t.generate(newContext(ctx))
+}
+
+// GenerateRand processes the entire LootTable using the *rand.Rand passed for
+// all randomness and returns a slice of all stacks generated. The *rand.Rand
+// is not safe for concurrent use, so it must not be shared between goroutines.
+// GenerateRand is the same as calling GenerateWithContext with a Context with
+// only Rand set.
+func (t LootTable) GenerateRand(r *rand.Rand) []item.Stack {
+	return t.GenerateWithContext(Context{Rand: r})
+}
+
+// generate processes the entire LootTable using the context passed.
+func (t LootTable) generate(ctx *context) []item.Stack {
+	// Every roll of an item entry generates at most one stack, so the maximum
+	// number of rolls of all pools is enough to never grow the slice, unless
+	// entries of nested loot tables are rolled.
+	n := 0
 	for _, p := range t.Pools {
-		rolls := RollValue(p.Rolls)
-		for i := 0; i < rolls; i++ {
-			if s, ok := p.rollEntry(); ok {
-				stacks = append(stacks, s)
+		n += max(p.Rolls.Min, p.Rolls.Max) + max(int(math.Floor(max(p.BonusRolls.Min, p.BonusRolls.Max)*ctx.Luck)), 0)
+	}
+	return t.appendLoot(ctx, make([]item.Stack, 0, n))
+}
+
+// appendLoot processes the entire LootTable using the context passed and
+// appends all stacks generated to the slice passed.
+func (t LootTable) appendLoot(ctx *context, stacks []item.Stack) []item.Stack {
+	// Pools rarely have more than a few dozen entries, so entries avoids
+	// allocating a buffer for the entries that may be picked.
+	var entries [32]*Entry
+	buf := entries[:0]
+	for i := range t.Pools {
+		p := &t.Pools[i]
+		if !conditionsMet(p.Conditions, ctx) {
+			continue
+		}
+		conditional := p.conditionalEntries()
+		rolls := p.Rolls.Roll(ctx.r) + p.bonusRolls(ctx)
+		// buf holds the entries that may be picked. Pools without conditional
+		// or composite entries always expand to the same entries, so they
+		// are only expanded once.
+		var total int
+		if !conditional {
+			buf = p.expandEntries(ctx, buf[:0])
+			total = totalWeight(buf, ctx.Luck)
+		}
+		for range rolls {
+			if conditional {
+				// Conditions of entries are evaluated again for every roll,
+				// as they may depend on chance.
+				buf = p.expandEntries(ctx, buf[:0])
+				total = totalWeight(buf, ctx.Luck)
 			}
+			if total <= 0 {
+				continue
+			}
+			stacks = rollEntry(ctx, total, buf, stacks)
 		}
 	}
 	return stacks
@@ -63,21 +205,106 @@ type LootTable struct {
 type Pool struct {
 	Rolls   Value   `json:"rolls"`
 	Entries []Entry `json:"entries"`
+	// BonusRolls is the number of rolls added to the Pool for every point of
+	// luck of the Context that loot is generated with. Fractions of rolls are
+	// rounded down after multiplying with the luck.
+	BonusRolls FloatValue `json:"bonus_rolls,omitempty"`
+	// Conditions must all be met for the Pool to be rolled.
+	Conditions []Condition `json:"conditions,omitempty"`
 }
 
 type Entry struct {
-	Type      string     `json:"type"`
-	Name      string     `json:"name"`
-	Weight    int        `json:"weight"`
+	// Type is the type of the Entry. Entries of the type "item" generate the
+	// item with the Name, entries of the type "loot_table" generate the loot
+	// table with the Name and entries of the type "empty" generate nothing.
+	// Entries of the types "group", "alternatives" and "sequence" hold
+	// Children.
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+	// Quality is added to the weight of the Entry for every point of luck of
+	// the Context that loot is generated with.
+	Quality   int        `json:"quality,omitempty"`
 	Functions []Function `json:"functions,omitempty"`
+	// Conditions must all be met for the Entry to be picked. Entries with
+	// conditions that are not met are left out when picking an entry, so
+	// that the other entries are picked instead.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Children are the entries of an Entry of the type "group",
+	// "alternatives" or "sequence". Like in vanilla, these entries are not
+	// picked themselves, but add their children to the entries that may be
+	// picked: A group adds all its children, alternatives add the first child
+	// with its conditions met and a sequence adds its children up to the
+	// first child with conditions that are not met.
+	Children []Entry `json:"children,omitempty"`
 }
 
 type Function struct {
-	Function string          `json:"function"`
-	Count    Value           `json:"count"`
-	Levels   Value           `json:"levels"`
-	ID       string          `json:"id"`
+	Function string `json:"function"`
+	Count    Value  `json:"count"`
+	Levels   Value  `json:"levels"`
+	// Treasure specifies if the enchant_with_levels and enchant_randomly
+	// functions may select treasure enchantments, such as Mending.
+	Treasure bool `json:"treasure"`
+	// Enchantments holds the enchantments that the enchant_randomly function
+	// picks from, such as "mending". If empty, all enchantments are picked
+	// from.
+	Enchantments EnchantOptions `json:"enchantments"`
+	ID           string         `json:"id"`
+	// Enchants holds the enchantments applied by the specific_enchants
+	// function and stored on books by the enchant_book function.
 	Enchants []EnchantConfig `json:"enchants"`
+	// Destination is the structure that a map created by the exploration_map
+	// function points to, such as "buriedtreasure". Decoration is the marker
+	// that the structure is marked with, such as "red_x", and Zoom is the
+	// scale of the map. The marker matching the Destination is used if
+	// Decoration is empty, and a Zoom of 0 creates a map with a scale of 1.
+	Destination string `json:"destination"`
+	Decoration  string `json:"decoration"`
+	Zoom        int    `json:"zoom"`
+	// Name is the custom name set by the set_name function and Lore holds the
+	// lines of lore set by the set_lore function.
+	Name Text   `json:"name"`
+	Lore []Text `json:"lore"`
+	// Damage is the fraction of the maximum durability, in a range of [0, 1],
+	// that the set_damage function leaves on a durable item, so that a Damage
+	// of 1 leaves the item undamaged. If Add is true, Damage is added to the
+	// fraction of durability that the item already has. Add also makes the
+	// set_count function add to the count instead of setting it.
+	Damage FloatValue `json:"damage"`
+	Add    bool       `json:"add"`
+	// Durability is the absolute durability that the set_durability function
+	// leaves on a durable item.
+	Durability Value `json:"durability"`
+	// Limit is the maximum count of a stack that the looting_enchant function
+	// may grow the stack to. A Limit of 0 means there is no limit.
+	Limit int `json:"limit"`
+	// Enchantment is the enchantment of the tool, such as "fortune", of which
+	// the level increases the count of a stack with the apply_bonus function.
+	// Fortune is used if Enchantment is empty. Formula is the formula used to
+	// increase the count: "ore_drops", "binomial_with_bonus_count" or
+	// "uniform_bonus_count", using the Parameters of the formula.
+	Enchantment string          `json:"enchantment"`
+	Formula     string          `json:"formula"`
+	Parameters  BonusParameters `json:"parameters"`
+	// Conditions must all be met for the Function to be applied.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Data holds the JSON that the Function was decoded from, so that
+	// functions registered using RegisterFunction may decode their own
+	// fields.
+	Data json.RawMessage `json:"-"`
+}
+
+// BonusParameters holds the parameters of the formula of an apply_bonus
+// function. Extra and Probability are used by the binomial_with_bonus_count
+// formula, which rolls the level of the enchantment plus Extra times and adds
+// 1 to the count for every roll below Probability. BonusMultiplier is used by
+// the uniform_bonus_count formula, which adds a random number from 0 to the
+// level multiplied by BonusMultiplier to the count.
+type BonusParameters struct {
+	Extra           int     `json:"extra"`
+	Probability     float64 `json:"probability"`
+	BonusMultiplier int     `json:"bonusMultiplier"`
 }
 
 type EnchantConfig struct {
@@ -85,231 +312,601 @@ type EnchantConfig struct {
 	Level Value  `json:"level"`
 }
 
+// EnchantOptions holds the enchantments that the enchant_randomly function
+// picks from. In JSON, EnchantOptions may be a single name or an array of
+// names, as in Java Edition, or an array of objects holding the name and the
+// range of levels of each enchantment, as in Bedrock Edition. Tags of Java
+// Edition, such as "#minecraft:on_random_loot", are left out.
+type EnchantOptions []EnchantOption
+
+// EnchantOption is an enchantment that the enchant_randomly function may pick,
+// with the range of levels it may be picked with. A Level maximum of 0 means
+// any level of the enchantment may be picked.
+type EnchantOption struct {
+	Name  string
+	Level Value
+}
+
+func (o *EnchantOptions) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		data, _ = json.Marshal([]string{name})
+	}
+	var options []json.RawMessage
+	if err := json.Unmarshal(data, &options); err != nil {
+		return err
+	}
+	*o = (*o)[:0]
+	for _, opt := range options {
+		var e struct {
+			Name string `json:"name"`
+			Min  int    `json:"min"`
+			Max  int    `json:"max"`
+		}
+		if err := json.Unmarshal(opt, &e.Name); err != nil {
+			if err := json.Unmarshal(opt, &e); err != nil {
+				return err
+			}
+		}
+		if !strings.HasPrefix(e.Name, "#") {
+			*o = append(*o, EnchantOption{Name: e.Name, Level: Value{Min: e.Min, Max: e.Max}})
+		}
+	}
+	return nil
+}
+
 type Value struct {
 	Min, Max int
+	// N and P make the Value a binomial distribution if P is non-zero, as
+	// used by Java Edition: The Value is the number of successes of N trials
+	// that each succeed with a chance of P. Min and Max are then 0 and N.
+	N int
+	P float64
 }
 
 func (v *Value) UnmarshalJSON(data []byte) error {
-	var i int
-	if err := json.Unmarshal(data, &i); err == nil {
-		v.Min, v.Max = i, i
+	var b struct {
+		Type string  `json:"type"`
+		N    int     `json:"n"`
+		P    float64 `json:"p"`
+	}
+	if err := json.Unmarshal(data, &b); err == nil && strings.TrimPrefix(b.Type, "minecraft:") == "binomial" {
+		*v = Value{Max: b.N, N: b.N, P: b.P}
+		return nil
+	}
+	var f FloatValue
+	if err := f.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	// Java Edition writes whole numbers such as rolls as floats, for
+	// example 1.0, so fractions are dropped.
+	v.Min, v.Max = int(f.Min), int(f.Max)
+	return nil
+}
+
+// FloatValue is a range of floating point numbers, such as the bonus rolls of
+// a Pool. Like Value, it may be written as a single number, as an array
+// holding a minimum and maximum or as an object holding a minimum and maximum.
+type FloatValue struct {
+	Min, Max float64
+}
+
+func (v *FloatValue) UnmarshalJSON(data []byte) error {
+	var f float64
+	if err := json.Unmarshal(data, &f); err == nil {
+		v.Min, v.Max = f, f
+		return nil
+	}
+	// Bedrock Edition writes ranges such as the levels of specific_enchants
+	// as an array holding the minimum and maximum.
+	var a []float64
+	if err := json.Unmarshal(data, &a); err == nil {
+		if len(a) == 2 {
+			v.Min, v.Max = a[0], a[1]
+		} else if len(a) == 1 {
+			v.Min, v.Max = a[0], a[0]
+		}
 		return nil
 	}
 	var m struct {
-		Min int `json:"min"`
-		Max int `json:"max"`
+		Min float64 `json:"min"`
+		Max float64 `json:"max"`
+		// RangeMin and RangeMax are used instead of Min and Max by the
+		// conditions of Bedrock Edition.
+		RangeMin float64 `json:"range_min"`
+		RangeMax float64 `json:"range_max"`
+		// Value is the value of a constant number provider of Java Edition.
+		Value *float64 `json:"value"`
 	}
 	if err := json.Unmarshal(data, &m); err == nil {
-		v.Min, v.Max = m.Min, m.Max
+		v.Min, v.Max = max(m.Min, m.RangeMin), max(m.Max, m.RangeMax)
+		if m.Value != nil {
+			v.Min, v.Max = *m.Value, *m.Value
+		}
 		return nil
 	}
 	return nil
 }
 
+// Roll returns a random number between the minimum and maximum of the
+// FloatValue using the *rand.Rand passed.
+func (v FloatValue) Roll(r *rand.Rand) float64 {
+	if v.Max <= v.Min {
+		return v.Min
+	}
+	return v.Min + r.Float64()*(v.Max-v.Min)
+}
+
 // --- Logic ---
 
-func (p *Pool) rollEntry() (item.Stack, bool) {
-	totalWeight := 0
-	for _, e := range p.Entries {
-		if e.Weight == 0 {
-			e.Weight = 1
+// conditionalEntries checks if any of the entries of the Pool has conditions
+// or children, in which case the entries that may be picked must be expanded
+// again for every roll.
+func (p *Pool) conditionalEntries() bool {
+	for i := range p.Entries {
+		if len(p.Entries[i].Conditions) > 0 || len(p.Entries[i].Children) > 0 {
+			return true
 		}
-		totalWeight += e.Weight
 	}
-	if totalWeight <= 0 {
-		return item.Stack{}, false
+	return false
+}
+
+// expandEntries appends the entries of the Pool that may be picked for the
+// context passed to the slice passed and returns it. Entries with conditions
+// that are not met are left out and composite entries are replaced with the
+// children they expand to.
+func (p *Pool) expandEntries(ctx *context, entries []*Entry) []*Entry {
+	for i := range p.Entries {
+		entries, _ = p.Entries[i].expand(ctx, entries)
+	}
+	return entries
+}
+
+// expand appends the entries that the Entry expands to for the context passed
+// to the slice passed. An Entry with conditions that are not met expands to no
+// entries and false is returned. Entries of the type "group" expand to all
+// their children, entries of the type "alternatives" expand to their first
+// child that expands successfully and entries of the type "sequence" expand
+// to their children up to the first child that does not expand successfully.
+// Other entries expand to themselves.
+func (e *Entry) expand(ctx *context, entries []*Entry) ([]*Entry, bool) {
+	if !conditionsMet(e.Conditions, ctx) {
+		return entries, false
+	}
+	switch e.Type {
+	case "group":
+		for i := range e.Children {
+			entries, _ = e.Children[i].expand(ctx, entries)
+		}
+		return entries, true
+	case "alternatives":
+		for i := range e.Children {
+			var ok bool
+			if entries, ok = e.Children[i].expand(ctx, entries); ok {
+				return entries, true
+			}
+		}
+		return entries, false
+	case "sequence":
+		for i := range e.Children {
+			var ok bool
+			if entries, ok = e.Children[i].expand(ctx, entries); !ok {
+				return entries, false
+			}
+		}
+		return entries, true
+	}
+	return append(entries, e), true
+}
+
+// bonusRolls returns the number of rolls added to the Pool for the luck of
+// the context passed. It may be negative if the luck is negative.
+func (p *Pool) bonusRolls(ctx *context) int {
+	if ctx.Luck == 0 {
+		return 0
+	}
+	return int(math.Floor(p.BonusRolls.Roll(ctx.r) * ctx.Luck))
+}
+
+// totalWeight returns the sum of the weights of the entries passed for the
+// luck passed.
+func totalWeight(entries []*Entry, luck float64) int {
+	totalWeight := 0
+	for _, e := range entries {
+		totalWeight += e.weight(luck)
+	}
+	return totalWeight
+}
+
+// weight returns the weight of the Entry for the luck passed. Entries without
+// a weight have a weight of 1. The quality of the Entry, multiplied by the
+// luck, is added to the weight, which is never lower than 0.
+func (e *Entry) weight(luck float64) int {
+	w := max(e.Weight, 1)
+	if e.Quality == 0 || luck == 0 {
+		return w
 	}
+	return max(int(math.Floor(float64(w)+float64(e.Quality)*luck)), 0)
+}
 
-	r := rand.Intn(totalWeight)
+// rollEntry picks a random entry of the entries passed, with the chance of
+// every entry proportional to its weight, and appends the stacks it generates
+// to the slice passed. totalWeight must be the result of totalWeight for the
+// same entries and luck and be positive.
+func rollEntry(ctx *context, totalWeight int, entries []*Entry, stacks []item.Stack) []item.Stack {
+	r := ctx.r.IntN(totalWeight)
 	current := 0
 
-	for _, e := range p.Entries {
-		current += e.Weight
+	for _, e := range entries {
+		current += e.weight(ctx.Luck)
 		if r < current {
-			if e.Type != "item" {
-				return item.Stack{}, false
+			switch e.Type {
+			case "item":
+				if s, ok := e.itemStack(ctx); ok && !s.Empty() {
+					stacks = append(stacks, s)
+				}
+			case "loot_table":
+				stacks = ctx.appendNestedLoot(e.Name, stacks)
+			case "empty":
+				// Empty entries generate nothing, but still take part in
+				// the weight of the pool, lowering the chance of the other
+				// entries being picked.
+			default:
+				// Entries of unknown types generate nothing. They are
+				// reported by Validate and Reload instead.
 			}
+			return stacks
+		}
+	}
+	return stacks
+}
 
-			name := strings.TrimPrefix(e.Name, "minecraft:")
-			it, ok := world.ItemByName("minecraft:"+name, 0)
-			if !ok {
-				fmt.Printf("[Loot System] Item not found: %s\n", name)
-				return item.Stack{}, false
+// itemStack returns the stack generated by an Entry of the type "item", with
+// all functions of the Entry applied. False is returned if the item of the
+// Entry does not exist, which Validate and Reload report as an error.
+func (e *Entry) itemStack(ctx *context) (item.Stack, bool) {
+	it, ok := entryItem(e)
+	if !ok {
+		metrics.LootItemNotFound(e.Name)
+		return item.Stack{}, false
+	}
+
+	// Conditions of functions are evaluated once, so that set_count is
+	// applied in the same way as the other functions. Entries rarely have
+	// more than a few functions, so buf avoids allocating.
+	var buf [8]bool
+	applied := buf[:0]
+	count := 1
+	for _, f := range e.Functions {
+		ok := conditionsMet(f.Conditions, ctx)
+		if applied = append(applied, ok); ok && f.Function == "set_count" {
+			if f.Add {
+				count += f.Count.Roll(ctx.r)
+			} else {
+				count = f.Count.Roll(ctx.r)
 			}
+		}
+	}
 
-			count := 1
-			for _, f := range e.Functions {
-				if f.Function == "set_count" {
-					count = RollValue(f.Count)
+	s := item.NewStack(it, count)
+
+	for i, f := range e.Functions {
+		if !applied[i] {
+			continue
+		}
+		switch f.Function {
+		case "set_count":
+			// The count was already set when creating the stack.
+		case "enchant_randomly":
+			s = applyRandomEnchant(s, f.Enchantments, f.Treasure, ctx.r)
+		case "enchant_with_levels":
+			s = applyEnchantWithLevels(s, f.Levels.Roll(ctx.r), f.Treasure, ctx.r)
+		case "specific_enchants":
+			for _, spec := range f.Enchants {
+				if enc, ok := item.EnchantmentByName(spec.ID); ok {
+					s = s.WithEnchantments(item.NewEnchantment(enc, spec.Level.Roll(ctx.r)))
 				}
 			}
-
-			s := item.NewStack(it, count)
-
-			for _, f := range e.Functions {
-				switch f.Function {
-				case "enchant_randomly":
-					s = applyRandomEnchant(s)
-				case "enchant_with_levels":
-					s = applyEnchantWithLevels(s, RollValue(f.Levels))
-				case "specific_enchants":
-					for _, spec := range f.Enchants {
-						if enc, ok := enchantmentByName(spec.ID); ok {
-							s = s.WithEnchantments(item.NewEnchantment(enc, RollValue(spec.Level)))
-						}
-					}
-				case "set_potion":
-					if pot, ok := potionByName(f.ID); ok {
-						if _, ok := s.Item().(item.Potion); ok {
-							s = item.NewStack(item.Potion{Type: pot}, s.Count())
-						} else if _, ok := s.Item().(item.SplashPotion); ok {
-							s = item.NewStack(item.SplashPotion{Type: pot}, s.Count())
-						}
-					}
+		case "set_potion":
+			if pot, ok := potion.ByName(f.ID); ok {
+				s = setPotion(s, pot)
+			}
+		case "enchant_book":
+			s = enchantBook(s, f.Enchants, ctx.r)
+		case "exploration_map":
+			if ctx.Tx != nil {
+				s = explorationMap(s, f, ctx)
+			}
+		case "set_damage":
+			if maxDurability := s.MaxDurability(); maxDurability > 0 {
+				fraction := f.Damage.Roll(ctx.r)
+				if f.Add {
+					fraction += float64(s.Durability()) / float64(maxDurability)
 				}
+				s = setDurability(s, int(math.Floor(fraction*float64(maxDurability))))
+			}
+		case "set_durability":
+			s = setDurability(s, f.Durability.Roll(ctx.r))
+		case "looting_enchant":
+			// Count is rolled once for every level of Looting of the tool
+			// that killed the entity dropping the loot.
+			n := 0
+			for range toolEnchantmentLevel(ctx.Tool, "looting") {
+				n += f.Count.Roll(ctx.r)
+			}
+			if f.Limit > 0 {
+				n = min(n, f.Limit-s.Count())
+			}
+			if n > 0 {
+				s = s.Grow(n)
+			}
+		case "apply_bonus":
+			s = applyBonus(s, f, toolEnchantmentLevel(ctx.Tool, bonusEnchantment(f)), ctx.r)
+		case "furnace_smelt":
+			if info, ok := item.SmeltResult(s.Item()); ok {
+				s = item.NewStack(info.Product.Item(), s.Count()*info.Product.Count())
+			}
+		case "set_name":
+			s = s.WithCustomName(string(f.Name))
+		case "set_lore":
+			lore := make([]string, len(f.Lore))
+			for i, line := range f.Lore {
+				lore[i] = string(line)
+			}
+			s = s.WithLore(lore...)
+		default:
+			if fn, ok := registeredFunction(f.Function); ok {
+				s = fn(s, f, ctx.Context)
 			}
-			return s, true
 		}
 	}
-	return item.Stack{}, false
+	return s, true
 }
 
+// RollValue returns a random number between the minimum and maximum of the
+// Value passed, both inclusive. RollValue is safe for concurrent use. Like
+// loot generation, it rolls using a *rand.Rand created from the source set
+// using SetRandSource. Value.Roll may be used instead to roll using the
+// *rand.Rand of a Context or another seeded *rand.Rand, so that the result is
+// reproducible.
 func RollValue(v Value) int {
+	return v.Roll(newRand())
+}
+
+// Roll returns a random number between the minimum and maximum of the Value,
+// both inclusive, using the *rand.Rand passed.
+func (v Value) Roll(r *rand.Rand) int {
+	if v.P != 0 {
+		n := 0
+		for range v.N {
+			if r.Float64() < v.P {
+				n++
+			}
+		}
+		return n
+	}
 	if v.Max <= v.Min {
 		return v.Min
 	}
-	return rand.Intn(v.Max-v.Min+1) + v.Min
-}
-
-// --- Registries ---
-
-func enchantmentByName(name string) (item.EnchantmentType, bool) {
-	name = strings.ToLower(strings.TrimPrefix(name, "minecraft:"))
-	m := map[string]item.EnchantmentType{
-		"protection":            enchantment.Protection,
-		"fire_protection":       enchantment.FireProtection,
-		"feather_falling":       enchantment.FeatherFalling,
-		"blast_protection":      enchantment.BlastProtection,
-		"projectile_protection": enchantment.ProjectileProtection,
-		"thorns":                enchantment.Thorns,
-		"respiration":           enchantment.Respiration,
-		"depth_strider":         enchantment.DepthStrider,
-		"aqua_affinity":         enchantment.AquaAffinity,
-		"sharpness":             enchantment.Sharpness,
-		"knockback":             enchantment.Knockback,
-		"fire_aspect":           enchantment.FireAspect,
-		"efficiency":            enchantment.Efficiency,
-		"silk_touch":            enchantment.SilkTouch,
-		"unbreaking":            enchantment.Unbreaking,
-		"fortune":               enchantment.Fortune,
-		"power":                 enchantment.Power,
-		"punch":                 enchantment.Punch,
-		"flame":                 enchantment.Flame,
-		"infinity":              enchantment.Infinity,
-		"mending":               enchantment.Mending,
-		"curse_of_vanishing":    enchantment.CurseOfVanishing,
-		"multishot":             enchantment.Multishot,
-		"quick_charge":          enchantment.QuickCharge,
-		"soul_speed":            enchantment.SoulSpeed,
-		"swift_sneak":           enchantment.SwiftSneak,
-	}
-	e, ok := m[name]
-	return e, ok
-}
-
-func getAllEnchantments() []item.EnchantmentType {
-	return []item.EnchantmentType{
-		enchantment.Protection, enchantment.FireProtection, enchantment.FeatherFalling,
-		enchantment.BlastProtection, enchantment.ProjectileProtection, enchantment.Thorns,
-		enchantment.Respiration, enchantment.DepthStrider, enchantment.AquaAffinity,
-		enchantment.Sharpness, enchantment.Knockback, enchantment.FireAspect,
-		enchantment.Efficiency, enchantment.SilkTouch, enchantment.Unbreaking,
-		enchantment.Fortune, enchantment.Power, enchantment.Punch,
-		enchantment.Flame, enchantment.Infinity, enchantment.Mending,
-		enchantment.CurseOfVanishing, enchantment.Multishot, enchantment.QuickCharge,
-		enchantment.SoulSpeed, enchantment.SwiftSneak,
-	}
-}
-
-func potionByName(name string) (potion.Potion, bool) {
-	name = strings.ToLower(strings.TrimPrefix(name, "minecraft:"))
-	m := map[string]potion.Potion{
-		"water":                potion.Water(),
-		"mundane":              potion.Mundane(),
-		"long_mundane":         potion.LongMundane(),
-		"thick":                potion.Thick(),
-		"awkward":              potion.Awkward(),
-		"night_vision":         potion.NightVision(),
-		"long_night_vision":    potion.LongNightVision(),
-		"invisibility":         potion.Invisibility(),
-		"long_invisibility":    potion.LongInvisibility(),
-		"leaping":              potion.Leaping(),
-		"long_leaping":         potion.LongLeaping(),
-		"strong_leaping":       potion.StrongLeaping(),
-		"fire_resistance":      potion.FireResistance(),
-		"long_fire_resistance": potion.LongFireResistance(),
-		"swiftness":            potion.Swiftness(),
-		"long_swiftness":       potion.LongSwiftness(),
-		"strong_swiftness":     potion.StrongSwiftness(),
-		"slowness":             potion.Slowness(),
-		"long_slowness":        potion.LongSlowness(),
-		"strong_slowness":      potion.StrongSlowness(),
-		"water_breathing":      potion.WaterBreathing(),
-		"long_water_breathing": potion.LongWaterBreathing(),
-		"healing":              potion.Healing(),
-		"strong_healing":       potion.StrongHealing(),
-		"harming":              potion.Harming(),
-		"strong_harming":       potion.StrongHarming(),
-		"poison":               potion.Poison(),
-		"long_poison":          potion.LongPoison(),
-		"strong_poison":        potion.StrongPoison(),
-		"regeneration":         potion.Regeneration(),
-		"long_regeneration":    potion.LongRegeneration(),
-		"strong_regeneration":  potion.StrongRegeneration(),
-		"strength":             potion.Strength(),
-		"long_strength":        potion.LongStrength(),
-		"strong_strength":      potion.StrongStrength(),
-		"weakness":             potion.Weakness(),
-		"long_weakness":        potion.LongWeakness(),
-		"wither":               potion.Wither(),
-		"turtle_master":        potion.TurtleMaster(),
-		"long_turtle_master":   potion.LongTurtleMaster(),
-		"strong_turtle_master": potion.StrongTurtleMaster(),
-		"slow_falling":         potion.SlowFalling(),
-		"long_slow_falling":    potion.LongSlowFalling(),
-	}
-	p, ok := m[name]
-	return p, ok
+	return r.IntN(v.Max-v.Min+1) + v.Min
 }
 
 // --- Application Helpers ---
 
-func applyRandomEnchant(s item.Stack) item.Stack {
-	var valid []item.EnchantmentType
-	for _, enc := range getAllEnchantments() {
-		if enc.CompatibleWithItem(s.Item()) {
-			valid = append(valid, enc)
+// applyRandomEnchant enchants the stack passed with a random enchantment at a
+// random level. If options is not empty, the enchantment is picked from the
+// options, with a level in the range of the option. Otherwise, it is picked
+// from all enchantments, leaving out treasure enchantments unless treasure is
+// true. Books may receive any enchantment and are turned into enchanted books,
+// while other items only receive enchantments that apply to them.
+func applyRandomEnchant(s item.Stack, options []EnchantOption, treasure bool, r *rand.Rand) item.Stack {
+	_, book := s.Item().(item.Book)
+	if _, ok := s.Item().(item.EnchantedBook); ok {
+		book = true
+	}
+	type candidate struct {
+		enc   item.EnchantmentType
+		level Value
+	}
+	var valid []candidate
+	if len(options) > 0 {
+		for _, opt := range options {
+			if enc, ok := item.EnchantmentByName(opt.Name); ok && (book || enc.CompatibleWithItem(s.Item())) {
+				valid = append(valid, candidate{enc: enc, level: opt.Level})
+			}
+		}
+	} else {
+		for _, enc := range item.Enchantments() {
+			if t, ok := enc.(treasureEnchantment); ok && t.Treasure() && !treasure {
+				continue
+			}
+			if book || enc.CompatibleWithItem(s.Item()) {
+				valid = append(valid, candidate{enc: enc})
+			}
 		}
 	}
-	if len(valid) > 0 {
-		e := valid[rand.Intn(len(valid))]
-		return s.WithEnchantments(item.NewEnchantment(e, 1))
+	if len(valid) == 0 {
+		return s
 	}
-	return s
+	c := valid[r.IntN(len(valid))]
+	lvl := r.IntN(c.enc.MaxLevel()) + 1
+	if c.level.Max > 0 {
+		lvl = max(c.level.Roll(r), 1)
+	}
+	return s.WithEnchantments(item.NewEnchantment(c.enc, lvl))
 }
 
-func applyEnchantWithLevels(s item.Stack, levels int) item.Stack {
-	for _, enc := range getAllEnchantments() {
-		if enc.CompatibleWithItem(s.Item()) {
-			if levels > 0 {
-				max := enc.MaxLevel()
-				lvl := 1
-				if levels > 15 && max > 1 {
-					lvl = rand.Intn(max) + 1
-				}
-				return s.WithEnchantments(item.NewEnchantment(enc, lvl))
+// bonusEnchantment returns the name of the enchantment of which the level
+// increases the count of a stack with the apply_bonus function passed.
+func bonusEnchantment(f Function) string {
+	if f.Enchantment == "" {
+		return "fortune"
+	}
+	return strings.TrimPrefix(f.Enchantment, "minecraft:")
+}
+
+// applyBonus increases the count of the stack passed using the formula of the
+// apply_bonus function passed and the level of its enchantment on the tool.
+// The ore_drops formula multiplies the count by a random number from 1 to the
+// level plus 1, with a chance of 2 in level+2 of not increasing it at all, like
+// the drops of ores mined with Fortune.
+func applyBonus(s item.Stack, f Function, level int, r *rand.Rand) item.Stack {
+	if level <= 0 {
+		return s
+	}
+	n := 0
+	switch strings.TrimPrefix(f.Formula, "minecraft:") {
+	case "ore_drops":
+		n = s.Count() * max(r.IntN(level+2)-1, 0)
+	case "binomial_with_bonus_count":
+		for range level + f.Parameters.Extra {
+			if r.Float64() < f.Parameters.Probability {
+				n++
 			}
 		}
+	case "uniform_bonus_count":
+		n = r.IntN(level*f.Parameters.BonusMultiplier + 1)
+	}
+	if n > 0 {
+		s = s.Grow(n)
+	}
+	return s
+}
+
+// setDurability sets the durability of the stack passed if it is durable. The
+// durability is clamped so that items generated are never broken.
+func setDurability(s item.Stack, durability int) item.Stack {
+	maxDurability := s.MaxDurability()
+	if maxDurability <= 0 {
+		return s
+	}
+	return s.WithDurability(min(max(durability, 1), maxDurability))
+}
+
+// setPotion sets the potion of the potion, splash potion, lingering potion or
+// tipped arrow passed. Other items are returned unchanged.
+func setPotion(s item.Stack, pot potion.Potion) item.Stack {
+	var it world.Item
+	switch s.Item().(type) {
+	case item.Potion:
+		it = item.Potion{Type: pot}
+	case item.SplashPotion:
+		it = item.SplashPotion{Type: pot}
+	case item.LingeringPotion:
+		it = item.LingeringPotion{Type: pot}
+	case item.Arrow:
+		it = item.Arrow{Tip: pot}
+	default:
+		return s
+	}
+	return item.NewStack(it, s.Count())
+}
+
+// enchantBook adds the enchantments passed to the book or enchanted book
+// passed as stored enchantments, turning a book into an enchanted book. Unlike
+// specific_enchants, the enchantments are stored regardless of the items they
+// apply to. If no enchantments are passed, a random enchantment with a random
+// level is stored instead. Other items are returned unchanged.
+func enchantBook(s item.Stack, enchants []EnchantConfig, r *rand.Rand) item.Stack {
+	switch s.Item().(type) {
+	case item.Book, item.EnchantedBook:
+	default:
+		return s
+	}
+	if len(enchants) == 0 {
+		if all := item.Enchantments(); len(all) > 0 {
+			enc := all[r.IntN(len(all))]
+			return s.WithEnchantments(item.NewEnchantment(enc, r.IntN(enc.MaxLevel())+1))
+		}
+		return s
+	}
+	for _, spec := range enchants {
+		if enc, ok := item.EnchantmentByName(spec.ID); ok {
+			s = s.WithEnchantments(item.NewEnchantment(enc, max(spec.Level.Roll(r), 1)))
+		}
 	}
 	return s
 }
+
+// treasureEnchantment is an enchantment that may be a treasure enchantment,
+// such as Mending, which is only selected by enchant_with_levels if the
+// function allows treasure enchantments.
+type treasureEnchantment interface {
+	item.EnchantmentType
+	Treasure() bool
+}
+
+// applyEnchantWithLevels enchants the stack passed like an enchanting table
+// with the number of levels passed would. The enchantability of the item and
+// a random bonus modify the levels, after which one enchantment is picked
+// from all enchantments with a cost range covering the modified levels,
+// weighted by their rarity. More enchantments that do not conflict with the
+// ones picked are added with a chance that halves with every enchantment.
+// Books are turned into enchanted books. Items that cannot be enchanted are
+// returned unchanged.
+func applyEnchantWithLevels(s item.Stack, levels int, treasure bool, r *rand.Rand) item.Stack {
+	enchantable, ok := s.Item().(item.Enchantable)
+	if !ok || enchantable.EnchantmentValue() <= 0 || levels <= 0 {
+		return s
+	}
+	value := enchantable.EnchantmentValue()
+	levels += 1 + r.IntN(value/4+1) + r.IntN(value/4+1)
+	bonus := (r.Float64() + r.Float64() - 1) * 0.15
+	levels = max(int(math.Round(float64(levels)+float64(levels)*bonus)), 1)
+
+	_, book := s.Item().(item.Book)
+	var available []item.Enchantment
+	for _, enc := range item.Enchantments() {
+		if t, ok := enc.(treasureEnchantment); ok && t.Treasure() && !treasure {
+			continue
+		}
+		if !book && !enc.CompatibleWithItem(s.Item()) {
+			continue
+		}
+		for lvl := enc.MaxLevel(); lvl > 0; lvl-- {
+			if minCost, maxCost := enc.Cost(lvl); levels >= minCost && levels <= maxCost {
+				available = append(available, item.NewEnchantment(enc, lvl))
+				break
+			}
+		}
+	}
+	var selected []item.Enchantment
+	for len(available) > 0 {
+		if len(selected) > 0 {
+			if r.IntN(50) > levels {
+				break
+			}
+			last := selected[len(selected)-1].Type()
+			available = slices.DeleteFunc(available, func(e item.Enchantment) bool {
+				return !last.CompatibleWithEnchantment(e.Type())
+			})
+			if len(available) == 0 {
+				break
+			}
+			levels /= 2
+		}
+		i := weightedEnchantment(available, r)
+		selected = append(selected, available[i])
+		available = slices.Delete(available, i, i+1)
+	}
+	if len(selected) == 0 {
+		return s
+	}
+	return s.WithEnchantments(selected...)
+}
+
+// weightedEnchantment returns the index of a random enchantment of the
+// enchantments passed, weighted by the rarity of the enchantments.
+func weightedEnchantment(enchants []item.Enchantment, r *rand.Rand) int {
+	total := 0
+	for _, e := range enchants {
+		total += e.Type().Rarity().Weight()
+	}
+	n := r.IntN(total)
+	for i, e := range enchants {
+		if n -= e.Type().Rarity().Weight(); n < 0 {
+			return i
+		}
+	}
+	return len(enchants) - 1
+}
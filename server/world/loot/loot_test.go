@@ -0,0 +1,436 @@
+package loot_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	// block is imported so that the loot tables may refer to block items.
+	_ "github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestLoadTablePaths(t *testing.T) {
+	want, err := loot.LoadTable("chests/simple_dungeon.json")
+	if err != nil {
+		t.Fatalf("load chests/simple_dungeon.json: %v", err)
+	}
+	for _, path := range []string{
+		"loot_tables/chests/simple_dungeon.json",
+		"minecraft:chests/simple_dungeon",
+	} {
+		got, err := loot.LoadTable(path)
+		if err != nil {
+			t.Errorf("load %v: %v", path, err)
+			continue
+		}
+		if len(got.Pools) != len(want.Pools) {
+			t.Errorf("load %v: got %v pools, want %v", path, len(got.Pools), len(want.Pools))
+		}
+	}
+	if _, err := loot.LoadTable("loot_tables/chests/does_not_exist.json"); err == nil {
+		t.Errorf("load of missing table did not return an error")
+	}
+}
+
+func TestNestedTables(t *testing.T) {
+	const (
+		diamond = `{"rolls": 1, "entries": [{"type": "item", "name": "minecraft:diamond"}]}`
+		nested  = `{"rolls": 1, "entries": [{"type": "loot_table", "name": %q}]}`
+	)
+	tables := map[string]string{
+		"test/inner.json":    `{"pools": [` + diamond + `]}`,
+		"test/outer.json":    `{"pools": [` + fmt.Sprintf(nested, "loot_tables/test/inner.json") + `]}`,
+		"test/java.json":     `{"pools": [` + fmt.Sprintf(nested, "minecraft:test/inner") + `]}`,
+		"test/missing.json":  `{"pools": [` + diamond + `, ` + fmt.Sprintf(nested, "test/missing_inner.json") + `]}`,
+		"test/self.json":     `{"pools": [` + diamond + `, ` + fmt.Sprintf(nested, "test/self.json") + `]}`,
+		"test/cycle_a.json":  `{"pools": [` + diamond + `, ` + fmt.Sprintf(nested, "test/cycle_b.json") + `]}`,
+		"test/cycle_b.json":  `{"pools": [` + diamond + `, ` + fmt.Sprintf(nested, "test/cycle_a.json") + `]}`,
+		"test/depth_20.json": `{"pools": [` + diamond + `]}`,
+	}
+	for i := range 20 {
+		tables[fmt.Sprintf("test/depth_%v.json", i)] = `{"pools": [` + diamond + `, ` + fmt.Sprintf(nested, fmt.Sprintf("test/depth_%v.json", i+1)) + `]}`
+	}
+	useTables(t, tables)
+
+	for _, test := range []struct {
+		path string
+		want int
+	}{
+		{path: "test/outer.json", want: 1},
+		{path: "test/java.json", want: 1},
+		{path: "test/missing.json", want: 1},
+		// Tables that are already being generated are not generated again.
+		{path: "test/self.json", want: 1},
+		{path: "test/cycle_a.json", want: 2},
+		// Tables are nested at most 16 deep, including the outermost table.
+		{path: "test/depth_19.json", want: 2},
+		{path: "test/depth_0.json", want: 16},
+	} {
+		stacks, ok := loot.GenerateWithContext(test.path, loot.Context{Seed: 1})
+		if !ok {
+			t.Errorf("%v: table not found", test.path)
+			continue
+		}
+		if len(stacks) != test.want {
+			t.Errorf("%v: got %v stacks, want %v", test.path, len(stacks), test.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// table returns a loot table with a single pool holding the entry passed.
+	table := func(entry string) string {
+		return `{"pools": [{"rolls": 1, "entries": [` + entry + `]}]}`
+	}
+	tests := []struct {
+		path, data string
+		want       []string
+	}{
+		{path: "test/valid.json", data: table(`{"type": "item", "name": "minecraft:diamond", "functions": [{"function": "set_count", "count": {"min": 1, "max": 3}}]}`)},
+		{path: "test/unknown_item.json", data: table(`{"type": "item", "name": "minecraft:does_not_exist"}`), want: []string{"unknown item minecraft:does_not_exist"}},
+		{path: "test/unknown_type.json", data: table(`{"type": "does_not_exist", "name": "minecraft:diamond"}`), want: []string{"unknown entry type does_not_exist"}},
+		{path: "test/unknown_table.json", data: table(`{"type": "loot_table", "name": "test/does_not_exist.json"}`), want: []string{"unknown loot table test/does_not_exist.json"}},
+		{path: "test/unknown_enchantment.json", data: table(`{"type": "item", "name": "minecraft:book", "functions": [{"function": "specific_enchants", "enchants": [{"id": "does_not_exist", "level": 1}]}]}`), want: []string{"unknown enchantment does_not_exist"}},
+		{path: "test/invalid_range.json", data: table(`{"type": "item", "name": "minecraft:diamond", "functions": [{"function": "set_count", "count": {"min": 3, "max": 1}}]}`), want: []string{"set_count count max 1 is lower than min 3"}},
+		{path: "test/nested_invalid.json", data: table(`{"type": "group", "children": [{"type": "item", "name": "minecraft:diamond"}, {"type": "item", "name": "minecraft:does_not_exist"}]}`), want: []string{"unknown item minecraft:does_not_exist"}},
+		// Tables that could not be decoded are not loaded at all.
+		{path: "test/invalid_json.json", data: `{"pools": [`, want: []string{"does not exist"}},
+	}
+	tables := map[string]string{}
+	for _, test := range tests {
+		tables[test.path] = test.data
+	}
+	useTables(t, tables)
+
+	for _, test := range tests {
+		errs := loot.Validate(test.path)
+		if len(errs) != len(test.want) {
+			t.Errorf("%v: got errors %v, want %v", test.path, errs, test.want)
+			continue
+		}
+		for i, err := range errs {
+			if !strings.Contains(err.Error(), test.want[i]) {
+				t.Errorf("%v: got error %q, want %q", test.path, err, test.want[i])
+			}
+		}
+		// Invalid entries are skipped when generating loot.
+		_, _ = loot.GenerateWithContext(test.path, loot.Context{Seed: 1})
+	}
+}
+
+func TestReloadErrors(t *testing.T) {
+	useTables(t, map[string]string{
+		"test/invalid_json.json": `{"pools": [`,
+		"test/unknown_item.json": `{"pools": [{"rolls": 1, "entries": [{"type": "item", "name": "minecraft:does_not_exist"}]}]}`,
+	})
+	_, errs := loot.Reload()
+	var got []string
+	for _, err := range errs {
+		if strings.HasPrefix(err.Error(), "test/") {
+			got = append(got, err.Error())
+		}
+	}
+	slices.Sort(got)
+	if len(got) != 2 || !strings.HasPrefix(got[0], "test/invalid_json.json: ") || !strings.Contains(got[1], "unknown item minecraft:does_not_exist") {
+		t.Errorf("got errors %v, want an error decoding test/invalid_json.json and an unknown item in test/unknown_item.json", got)
+	}
+	if _, err := loot.LoadTable("test/invalid_json.json"); err == nil {
+		t.Errorf("table that could not be decoded was loaded")
+	}
+	if _, err := loot.LoadTable("test/unknown_item.json"); err != nil {
+		t.Errorf("table with an unknown item was not loaded: %v", err)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	useTables(t, map[string]string{
+		"test/simulate.json": `{"pools": [
+			{"rolls": 1, "entries": [
+				{"type": "item", "name": "minecraft:diamond", "weight": 1, "functions": [{"function": "set_count", "count": {"min": 1, "max": 3}}]},
+				{"type": "empty", "weight": 1}
+			]},
+			{"rolls": 1, "entries": [
+				{"type": "item", "name": "minecraft:diamond_sword", "functions": [{"function": "specific_enchants", "enchants": [{"id": "sharpness", "level": 2}]}]}
+			]}
+		]}`,
+		"test/invalid_json.json": `{"pools": [`,
+	})
+
+	const n = 10000
+	r := loot.Simulate("test/simulate.json", n)
+	if r.Rolls != n || r.Empty != 0 {
+		t.Fatalf("got %v rolls and %v empty, want %v rolls and 0 empty", r.Rolls, r.Empty, n)
+	}
+	if len(r.Items) != 2 {
+		t.Fatalf("got items %v, want minecraft:diamond and minecraft:diamond_sword", slices.Sorted(maps.Keys(r.Items)))
+	}
+	diamond := r.Items["minecraft:diamond"]
+	if diamond == nil || diamond.MinCount != 1 || diamond.MaxCount != 3 {
+		t.Errorf("got diamond report %+v, want counts from 1 to 3", diamond)
+	}
+	// Half of the rolls generate 1 to 3 diamonds, so 1 diamond on average.
+	if f := r.Frequency("minecraft:diamond"); f < 0.45 || f > 0.55 {
+		t.Errorf("got diamond frequency %v, want about 0.5", f)
+	}
+	if avg := r.AverageCount("minecraft:diamond"); avg < 0.9 || avg > 1.1 {
+		t.Errorf("got average diamond count %v, want about 1", avg)
+	}
+	sword := r.Items["minecraft:diamond_sword"]
+	if r.Frequency("minecraft:diamond_sword") != 1 || sword.Stacks != n || sword.Enchantments["sharpness"][2] != n {
+		t.Errorf("got sword report %+v, want a sword with sharpness 2 in every roll", sword)
+	}
+	if r.Frequency("minecraft:stone") != 0 || r.AverageCount("minecraft:stone") != 0 {
+		t.Errorf("item that was never generated has a frequency or average count")
+	}
+
+	// The same seed results in the same Report, while a different seed does not.
+	first := loot.SimulateWithContext("test/simulate.json", 100, loot.Context{Seed: 1}).String()
+	if second := loot.SimulateWithContext("test/simulate.json", 100, loot.Context{Seed: 1}).String(); first != second {
+		t.Errorf("same seed resulted in different reports:\n%v\n%v", first, second)
+	}
+	if other := loot.SimulateWithContext("test/simulate.json", 100, loot.Context{Seed: 2}).String(); first == other {
+		t.Errorf("different seeds resulted in the same report:\n%v", first)
+	}
+
+	for _, path := range []string{"test/invalid_json.json", "test/does_not_exist.json"} {
+		if r := loot.Simulate(path, n); r.Rolls != 0 || r.Empty != 0 || len(r.Items) != 0 || r.Path != path {
+			t.Errorf("%v: got report %+v for a table that could not be loaded, want an empty report", path, r)
+		}
+	}
+}
+
+// useTables writes the loot tables passed, keyed by their paths, to a
+// directory that is loaded using loot.SetSource until the test ends.
+func useTables(t *testing.T, tables map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	for path, data := range tables {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Some of the embedded tables refer to items that are not implemented,
+	// so the errors returned by Reload are not checked.
+	loot.SetSource(dir)
+	_, _ = loot.Reload()
+	t.Cleanup(func() {
+		loot.SetSource("")
+		_, _ = loot.Reload()
+	})
+}
+
+func TestConditions(t *testing.T) {
+	loot.RegisterCondition("test:never", func(loot.Condition, loot.Context) bool { return false })
+
+	sword := item.NewStack(item.Sword{Tier: item.ToolTierDiamond}, 1)
+	lootingSword := sword.WithEnchantments(item.NewEnchantment(enchantment.Looting, 1))
+	player, burning := testEntity{}, testEntity{fire: time.Second}
+	for _, test := range []struct {
+		name       string
+		conditions string
+		ctx        loot.Context
+		want       bool
+	}{
+		{name: "none", conditions: `[]`, want: true},
+		{name: "unknown", conditions: `[{"condition": "test:unknown"}]`, want: true},
+		{name: "registered", conditions: `[{"condition": "test:never"}]`, want: false},
+		{name: "killed_by_player", conditions: `[{"condition": "killed_by_player"}]`, ctx: loot.Context{Player: player}, want: true},
+		{name: "killed_by_player without player", conditions: `[{"condition": "killed_by_player"}]`, want: false},
+		{name: "random_chance 0", conditions: `[{"condition": "random_chance", "chance": 0}]`, want: false},
+		{name: "random_chance 1", conditions: `[{"condition": "random_chance", "chance": 1}]`, want: true},
+		{name: "random_chance_with_looting", conditions: `[{"condition": "random_chance_with_looting", "chance": 0, "looting_multiplier": 1}]`, ctx: loot.Context{Tool: lootingSword}, want: true},
+		{name: "random_chance_with_looting without looting", conditions: `[{"condition": "random_chance_with_looting", "chance": 0, "looting_multiplier": 1}]`, ctx: loot.Context{Tool: sword}, want: false},
+		{name: "match_tool", conditions: `[{"condition": "match_tool", "item": "minecraft:diamond_sword"}]`, ctx: loot.Context{Tool: sword}, want: true},
+		{name: "match_tool without tool", conditions: `[{"condition": "match_tool", "item": "minecraft:diamond_sword"}]`, want: false},
+		{name: "match_tool predicate", conditions: `[{"condition": "match_tool", "predicate": {"items": ["minecraft:iron_sword"]}}]`, ctx: loot.Context{Tool: sword}, want: false},
+		{name: "entity_properties", conditions: `[{"condition": "entity_properties", "entity": "this", "properties": {"on_fire": true}}]`, ctx: loot.Context{Entity: burning}, want: true},
+		{name: "entity_properties not on fire", conditions: `[{"condition": "entity_properties", "entity": "this", "properties": {"on_fire": true}}]`, ctx: loot.Context{Entity: player}, want: false},
+		{name: "survives_explosion", conditions: `[{"condition": "survives_explosion"}]`, want: true},
+		{name: "weather_check", conditions: `[{"condition": "weather_check", "raining": false}]`, want: true},
+		{name: "weather_check raining", conditions: `[{"condition": "weather_check", "raining": true}]`, want: false},
+		{name: "time_check without world", conditions: `[{"condition": "time_check", "value": {"min": 0, "max": 24000}}]`, want: false},
+		{name: "inverted", conditions: `[{"condition": "inverted", "term": {"condition": "killed_by_player"}}]`, want: true},
+		{name: "any_of", conditions: `[{"condition": "any_of", "terms": [{"condition": "random_chance", "chance": 0}, {"condition": "killed_by_player"}]}]`, ctx: loot.Context{Player: player}, want: true},
+		{name: "all_of", conditions: `[{"condition": "all_of", "terms": [{"condition": "random_chance", "chance": 1}, {"condition": "killed_by_player"}]}]`, want: false},
+		{name: "all conditions", conditions: `[{"condition": "random_chance", "chance": 1}, {"condition": "killed_by_player"}]`, want: false},
+	} {
+		// Conditions are checked in the same way for pools and entries.
+		for _, format := range []string{
+			`{"pools": [{"rolls": 1, "entries": [{"type": "item", "name": "minecraft:diamond", "conditions": %v}]}]}`,
+			`{"pools": [{"rolls": 1, "conditions": %v, "entries": [{"type": "item", "name": "minecraft:diamond"}]}]}`,
+		} {
+			tbl := decodeTable(t, fmt.Sprintf(format, test.conditions))
+			ctx := test.ctx
+			ctx.Seed = 1
+			if got := len(tbl.GenerateWithContext(ctx)) > 0; got != test.want {
+				t.Errorf("%v: generated loot: got %v, want %v", test.name, got, test.want)
+			}
+		}
+	}
+}
+
+// decodeTable decodes a LootTable from the JSON passed.
+func decodeTable(t *testing.T, data string) loot.LootTable {
+	t.Helper()
+	var tbl loot.LootTable
+	if err := json.Unmarshal([]byte(data), &tbl); err != nil {
+		t.Fatalf("decode %v: %v", data, err)
+	}
+	return tbl
+}
+
+// testEntity is a world.Entity used as the player or the entity of a
+// loot.Context.
+type testEntity struct {
+	fire time.Duration
+}
+
+func (testEntity) Close() error                    { return nil }
+func (testEntity) H() *world.EntityHandle          { return nil }
+func (testEntity) Position() mgl64.Vec3            { return mgl64.Vec3{} }
+func (testEntity) Rotation() cube.Rotation         { return cube.Rotation{} }
+func (e testEntity) OnFireDuration() time.Duration { return e.fire }
+
+func TestGenerateSeed(t *testing.T) {
+	seeds := []int64{1, 2, 3, -4}
+	for _, test := range []struct{ path string }{
+		{path: "gameplay/fishing/fish.json"},
+		{path: "chests/simple_dungeon.json"},
+		{path: "chests/bastion_treasure.json"},
+	} {
+		t.Run(test.path, func(t *testing.T) {
+			tbl, err := loot.LoadTable(test.path)
+			if err != nil {
+				t.Fatalf("load %v: %v", test.path, err)
+			}
+			distinct := false
+			first := tbl.GenerateWithContext(loot.Context{Seed: seeds[0]})
+			for _, seed := range seeds {
+				want := tbl.GenerateWithContext(loot.Context{Seed: seed})
+				if got := tbl.GenerateWithContext(loot.Context{Seed: seed}); !equalStacks(got, want) {
+					t.Errorf("seed %v: got %v, want %v", seed, got, want)
+				}
+				if got, _ := loot.GenerateWithContext(test.path, loot.Context{Seed: seed}); !equalStacks(got, want) {
+					t.Errorf("seed %v: loot.GenerateWithContext: got %v, want %v", seed, got, want)
+				}
+				distinct = distinct || !equalStacks(want, first)
+			}
+			if !distinct {
+				t.Errorf("seeds %v all generated %v", seeds, first)
+			}
+		})
+	}
+}
+
+func TestSetRandSource(t *testing.T) {
+	t.Cleanup(func() { loot.SetRandSource(nil) })
+	for _, test := range []struct {
+		path string
+		seed uint64
+	}{
+		{path: "gameplay/fishing/fish.json", seed: 1},
+		{path: "chests/simple_dungeon.json", seed: 2},
+		{path: "chests/bastion_treasure.json", seed: 3},
+	} {
+		t.Run(test.path, func(t *testing.T) {
+			tbl, err := loot.LoadTable(test.path)
+			if err != nil {
+				t.Fatalf("load %v: %v", test.path, err)
+			}
+			loot.SetRandSource(func() rand.Source { return rand.NewPCG(test.seed, test.seed) })
+			want := tbl.GenerateRand(rand.New(rand.NewPCG(test.seed, test.seed)))
+			if got := tbl.Generate(); !equalStacks(got, want) {
+				t.Errorf("Generate: got %v, want %v", got, want)
+			}
+			if got, _ := loot.Generate(test.path); !equalStacks(got, want) {
+				t.Errorf("loot.Generate: got %v, want %v", got, want)
+			}
+			v := loot.Value{Min: 0, Max: 1 << 30}
+			if got, want := loot.RollValue(v), v.Roll(rand.New(rand.NewPCG(test.seed, test.seed))); got != want {
+				t.Errorf("RollValue: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// equalStacks checks if the stacks passed hold the same items in the same
+// order.
+func equalStacks(a, b []item.Stack) bool {
+	return slices.EqualFunc(a, b, item.Stack.Equal)
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	for _, bench := range []struct{ name, path string }{
+		{name: "small", path: "gameplay/fishing/fish.json"},
+		{name: "large", path: "chests/bastion_treasure.json"},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			t, err := loot.LoadTable(bench.path)
+			if err != nil {
+				b.Fatalf("load %v: %v", bench.path, err)
+			}
+			r := rand.New(rand.NewPCG(1, 2))
+			b.ReportAllocs()
+			for b.Loop() {
+				t.GenerateRand(r)
+			}
+		})
+	}
+}
+
+func BenchmarkLoadTable(b *testing.B) {
+	const path = "chests/bastion_treasure.json"
+	b.Run("parse", func(b *testing.B) {
+		data, err := os.ReadFile("loot_tables/" + path)
+		if err != nil {
+			b.Fatalf("read %v: %v", path, err)
+		}
+		b.ReportAllocs()
+		for b.Loop() {
+			var t loot.LootTable
+			if err := json.Unmarshal(data, &t); err != nil {
+				b.Fatalf("decode %v: %v", path, err)
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := loot.LoadTable(path); err != nil {
+				b.Fatalf("load %v: %v", path, err)
+			}
+		}
+	})
+}
+
+func BenchmarkLoadTableParallel(b *testing.B) {
+	paths := loot.Tables()
+	for _, path := range paths {
+		// Tables that fail to decode are cached along with their error, so
+		// the error is not checked here.
+		_, _ = loot.LoadTable(path)
+	}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			_, _ = loot.LoadTable(paths[i%len(paths)])
+		}
+	})
+}
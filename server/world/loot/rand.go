@@ -0,0 +1,33 @@
+package loot
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// randSource holds the function set using SetRandSource. It is nil if no
+// function was set, in which case every generation uses a randomly seeded PCG.
+var randSource atomic.Pointer[func() rand.Source]
+
+// SetRandSource sets the function used to create the source of randomness for
+// every generation of loot. Every generation creates its own *rand.Rand from
+// a new source, so that loot generated concurrently does not contend for a
+// shared source. Passing a function returning sources with a fixed seed makes
+// the loot generated reproducible, for example in tests. Passing nil restores
+// the default of a randomly seeded source for every generation.
+func SetRandSource(f func() rand.Source) {
+	if f == nil {
+		randSource.Store(nil)
+		return
+	}
+	randSource.Store(&f)
+}
+
+// newRand returns a new *rand.Rand for a single generation of loot, using the
+// function set using SetRandSource if present.
+func newRand() *rand.Rand {
+	if f := randSource.Load(); f != nil {
+		return rand.New((*f)())
+	}
+	return rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+}
@@ -0,0 +1,380 @@
+package loot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+var (
+	tablesMu sync.RWMutex
+	// tables holds the loot tables loaded by the last call to Reload, keyed by
+	// their paths. It is nil until Reload is first called, in which case loot
+	// tables are read from the embedded filesystem directly.
+	tables map[string]LootTable
+	// tablesDir is the directory set using SetSource.
+	tablesDir string
+)
+
+// SetSource sets a directory on disk holding loot tables that are loaded in
+// addition to the embedded loot tables the next time Reload is called. Paths
+// in the directory mirror those of the embedded tables, so a file at
+// "chests/simple_dungeon.json" in the directory replaces the embedded table
+// with the same path. Passing an empty directory only loads the embedded
+// tables.
+func SetSource(dir string) {
+	tablesMu.Lock()
+	defer tablesMu.Unlock()
+	tablesDir = dir
+}
+
+// Reload reloads and validates all loot tables, both embedded and in the
+// directory set using SetSource. Tables loaded replace the tables used to
+// generate loot, so that changes to tables on disk take effect without
+// restarting the server. The directory may also be a data pack of Java
+// Edition, in which case the tables in its loot table folders are loaded and
+// translated to the format of Bedrock Edition. If the directory holds an
+// entity_tables.json file, the tables it assigns to entity types are
+// registered using RegisterEntityTable. Tables are parsed and validated in
+// parallel. Reload returns the number of tables loaded and an error for every
+// table that could not be loaded or that references items, enchantments or
+// potions that do not exist. Tables that could not be loaded are left out,
+// while tables with invalid references are still loaded and skip the invalid
+// entries when generating loot. Calling Reload once when the server starts
+// means no table needs to be parsed when loot is first generated from it.
+func Reload() (int, []error) {
+	tablesMu.RLock()
+	dir := tablesDir
+	tablesMu.RUnlock()
+
+	loaded := map[string]LootTable{}
+	errs := loadTables(lootFS, "loot_tables", loaded)
+	if dir != "" {
+		// A directory that does not exist holds no tables, so that a
+		// directory set in a config does not need to be created.
+		if _, err := os.Stat(dir); !errors.Is(err, fs.ErrNotExist) {
+			fsys := os.DirFS(dir)
+			// A directory holding a data pack of Java Edition only has
+			// loot tables in its loot table folders.
+			roots := dataPackRoots(fsys)
+			if len(roots) == 0 {
+				roots = []string{"."}
+			}
+			for _, root := range roots {
+				errs = append(errs, loadTables(fsys, root, loaded)...)
+			}
+			errs = append(errs, loadEntityTables(fsys, loaded)...)
+		}
+	}
+	paths := slices.Sorted(maps.Keys(loaded))
+	errs = append(errs, slices.Concat(parallel(len(paths), func(i int) []error {
+		return validateTable(paths[i], loaded[paths[i]], func(path string) bool {
+			_, ok := loaded[path]
+			return ok
+		})
+	})...)...)
+
+	tablesMu.Lock()
+	defer tablesMu.Unlock()
+	tables = loaded
+	return len(loaded), errs
+}
+
+// loadTables decodes all JSON files found under the root of the fs.FS passed
+// in parallel and adds them to the map passed, keyed by their path relative to
+// the root.
+func loadTables(fsys fs.FS, root string, m map[string]LootTable) (errs []error) {
+	var paths []string
+	err := fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".json") && path != entityTablesFile {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	type result struct {
+		t   LootTable
+		err error
+	}
+	results := parallel(len(paths), func(i int) result {
+		b, err := fs.ReadFile(fsys, paths[i])
+		if err != nil {
+			return result{err: err}
+		}
+		var t LootTable
+		if err := json.Unmarshal(b, &t); err != nil {
+			return result{err: err}
+		}
+		return result{t: t}
+	})
+	for i, res := range results {
+		rel := strings.TrimPrefix(strings.TrimPrefix(paths[i], root), "/")
+		if res.err != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", rel, res.err))
+			continue
+		}
+		m[rel] = res.t
+	}
+	return errs
+}
+
+// entityTablesFile is the file in the directory set using SetSource that
+// assigns loot tables to entity types, in the same format as
+// entity_tables.json.
+const entityTablesFile = "entity_tables.json"
+
+// loadEntityTables assigns the loot tables in the entityTablesFile of the
+// fs.FS passed to entity types using RegisterEntityTable, if the file exists.
+// An empty path removes the table of an entity type. Errors are returned for
+// tables assigned that are not found in the map of tables passed.
+func loadEntityTables(fsys fs.FS, m map[string]LootTable) (errs []error) {
+	b, err := fs.ReadFile(fsys, entityTablesFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return []error{err}
+	}
+	var assigned map[string]string
+	if err := json.Unmarshal(b, &assigned); err != nil {
+		return []error{fmt.Errorf("%v: %w", entityTablesFile, err)}
+	}
+	for _, entityType := range slices.Sorted(maps.Keys(assigned)) {
+		path := assigned[entityType]
+		if _, ok := m[path]; !ok && path != "" {
+			errs = append(errs, fmt.Errorf("%v: loot table of %v not found: %v", entityTablesFile, entityType, path))
+			continue
+		}
+		RegisterEntityTable(entityType, path)
+	}
+	return errs
+}
+
+// parallel calls f for all indices lower than n, spread over as many
+// goroutines as there are CPUs available, and returns the results of the
+// calls in order of their indices.
+func parallel[T any](n int, f func(i int) T) []T {
+	results := make([]T, n)
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < n; i = int(next.Add(1) - 1) {
+				results[i] = f(i)
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Validate loads the loot table at the path passed, relative to the
+// loot_tables folder, and checks it for problems that would otherwise only
+// show when loot is generated from it. An error is returned for every item,
+// enchantment, potion and nested loot table referenced by the table that does
+// not exist and for every range with a maximum lower than its minimum. If the
+// table could not be loaded, only the error loading it is returned. Validate
+// does not validate nested tables themselves.
+func Validate(path string) []error {
+	t, err := LoadTable(path)
+	if err != nil {
+		return []error{fmt.Errorf("%v: %w", path, err)}
+	}
+	return validateTable(path, t, func(path string) bool {
+		_, err := LoadTable(path)
+		return err == nil
+	})
+}
+
+// validateTable checks if all items, enchantments, potions and nested loot
+// tables referenced by the LootTable passed exist and if all of its ranges are
+// valid. exists is called to check if a nested table exists.
+func validateTable(path string, t LootTable, exists func(path string) bool) (errs []error) {
+	// checkRange adds an error if the maximum of the range passed is lower
+	// than its minimum.
+	checkRange := func(pool int, name string, minimum, maximum float64) {
+		if maximum < minimum {
+			errs = append(errs, fmt.Errorf("%v: pool %v: %v max %v is lower than min %v", path, pool, name, maximum, minimum))
+		}
+	}
+	// validateEntries validates the entries of the pool with the index passed,
+	// including the children of composite entries.
+	var validateEntries func(i int, entries []Entry)
+	validateEntries = func(i int, entries []Entry) {
+		for _, e := range entries {
+			errs = append(errs, validateConditions(path, i, e.Conditions)...)
+			switch e.Type {
+			case "item":
+			case "loot_table":
+				if !exists(tablePath(e.Name)) {
+					errs = append(errs, fmt.Errorf("%v: pool %v: unknown loot table %v", path, i, e.Name))
+				}
+				continue
+			case "empty":
+				continue
+			case "group", "alternatives", "sequence":
+				validateEntries(i, e.Children)
+				continue
+			default:
+				errs = append(errs, fmt.Errorf("%v: pool %v: unknown entry type %v", path, i, e.Type))
+				continue
+			}
+			if _, ok := entryItem(&e); !ok {
+				errs = append(errs, fmt.Errorf("%v: pool %v: unknown item %v", path, i, e.Name))
+			}
+			for _, f := range e.Functions {
+				errs = append(errs, validateConditions(path, i, f.Conditions)...)
+				switch f.Function {
+				case "set_count", "looting_enchant":
+					checkRange(i, f.Function+" count", float64(f.Count.Min), float64(f.Count.Max))
+				case "enchant_with_levels":
+					checkRange(i, "enchant_with_levels levels", float64(f.Levels.Min), float64(f.Levels.Max))
+				case "set_damage":
+					checkRange(i, "set_damage damage", f.Damage.Min, f.Damage.Max)
+				case "set_durability":
+					checkRange(i, "set_durability durability", float64(f.Durability.Min), float64(f.Durability.Max))
+				case "specific_enchants", "enchant_book":
+					for _, spec := range f.Enchants {
+						if _, ok := item.EnchantmentByName(spec.ID); !ok {
+							errs = append(errs, fmt.Errorf("%v: pool %v: unknown enchantment %v", path, i, spec.ID))
+						}
+						checkRange(i, f.Function+" level", float64(spec.Level.Min), float64(spec.Level.Max))
+					}
+				case "enchant_randomly":
+					for _, opt := range f.Enchantments {
+						if _, ok := item.EnchantmentByName(opt.Name); !ok {
+							errs = append(errs, fmt.Errorf("%v: pool %v: unknown enchantment %v", path, i, opt.Name))
+						}
+						checkRange(i, "enchant_randomly level", float64(opt.Level.Min), float64(opt.Level.Max))
+					}
+				case "apply_bonus":
+					if _, ok := item.EnchantmentByName(bonusEnchantment(f)); !ok {
+						errs = append(errs, fmt.Errorf("%v: pool %v: unknown enchantment %v", path, i, f.Enchantment))
+					}
+					switch strings.TrimPrefix(f.Formula, "minecraft:") {
+					case "ore_drops", "binomial_with_bonus_count", "uniform_bonus_count":
+					default:
+						errs = append(errs, fmt.Errorf("%v: pool %v: unknown apply_bonus formula %v", path, i, f.Formula))
+					}
+				case "set_potion":
+					if _, ok := potion.ByName(f.ID); !ok {
+						errs = append(errs, fmt.Errorf("%v: pool %v: unknown potion %v", path, i, f.ID))
+					}
+				}
+			}
+		}
+	}
+	for i, p := range t.Pools {
+		checkRange(i, "rolls", float64(p.Rolls.Min), float64(p.Rolls.Max))
+		checkRange(i, "bonus_rolls", p.BonusRolls.Min, p.BonusRolls.Max)
+		errs = append(errs, validateConditions(path, i, p.Conditions)...)
+		validateEntries(i, p.Entries)
+
+	}
+	return errs
+}
+
+// validateConditions checks if all items and enchantments referenced by the
+// match_tool conditions passed, including those nested in inverted, any_of and
+// all_of conditions, exist.
+func validateConditions(path string, pool int, conditions []Condition) (errs []error) {
+	for _, c := range conditions {
+		if c.Term != nil {
+			errs = append(errs, validateConditions(path, pool, []Condition{*c.Term})...)
+		}
+		errs = append(errs, validateConditions(path, pool, c.Terms)...)
+		if c.Condition != "match_tool" {
+			continue
+		}
+		names, enchantments := []string{c.Item}, c.Enchantments
+		if c.Predicate != nil {
+			names, enchantments = append(names, c.Predicate.Items...), slices.Concat(enchantments, c.Predicate.Enchantments)
+		}
+		for _, name := range names {
+			if name == "" {
+				continue
+			}
+			if _, ok := entryItem(&Entry{Name: name}); !ok {
+				errs = append(errs, fmt.Errorf("%v: pool %v: unknown item %v in condition", path, pool, name))
+			}
+		}
+		for _, e := range enchantments {
+			if _, ok := item.EnchantmentByName(e.Enchantment); !ok {
+				errs = append(errs, fmt.Errorf("%v: pool %v: unknown enchantment %v in condition", path, pool, e.Enchantment))
+			}
+		}
+	}
+	return errs
+}
+
+// entryItem returns the item that an Entry of the type "item" generates. Names
+// without a namespace are in the minecraft namespace, while names with another
+// namespace refer to custom items, such as blocks loaded from behaviour packs.
+// Names of items that are named differently in Java Edition are translated.
+func entryItem(e *Entry) (world.Item, bool) {
+	name := e.Name
+	if !strings.Contains(name, ":") {
+		name = "minecraft:" + name
+	}
+	if n, ok := legacyItemNames[name]; ok {
+		name = n
+	}
+	if it, ok := world.ItemByName(name, 0); ok {
+		return it, true
+	}
+	return javaItem(name)
+}
+
+// legacyItemNames maps names of items used by loot tables that differ from
+// the names the items are registered with.
+var legacyItemNames = map[string]string{
+	// Maps without data are named empty_map in Bedrock Edition.
+	"minecraft:map":       "minecraft:empty_map",
+	"minecraft:fish":      "minecraft:cod",
+	"minecraft:clownfish": "minecraft:tropical_fish",
+	// Java Edition names tipped arrows separately from arrows.
+	"minecraft:tipped_arrow": "minecraft:arrow",
+}
+
+// cachedTable returns the LootTable at the path passed from the tables loaded
+// using Reload. loaded is false if Reload was not yet called, in which case the
+// table should be read from the embedded filesystem instead. ok is false if no
+// table with the path was loaded.
+func cachedTable(path string) (t LootTable, loaded, ok bool) {
+	tablesMu.RLock()
+	defer tablesMu.RUnlock()
+	if tables == nil {
+		return LootTable{}, false, false
+	}
+	t, ok = tables[path]
+	return t, true, ok
+}
+
+// cachedPaths returns the paths of all tables loaded using Reload, or nil if
+// Reload was not yet called.
+func cachedPaths() []string {
+	tablesMu.RLock()
+	defer tablesMu.RUnlock()
+	if tables == nil {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(tables))
+}
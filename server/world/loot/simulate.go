@@ -0,0 +1,156 @@
+package loot
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+// Report holds statistics of the loot generated from a loot table many times
+// by Simulate, such as how often every item is generated and in what amounts.
+type Report struct {
+	// Path is the path of the loot table that the loot was generated from.
+	Path string
+	// Rolls is the number of times loot was generated from the table. It is 0
+	// if the table could not be loaded.
+	Rolls int
+	// Empty is the number of times that no items were generated at all.
+	Empty int
+	// Items holds the statistics of every item generated, keyed by the name of
+	// the item, such as "minecraft:diamond". Variants of an item, such as the
+	// colours of dye, share their statistics.
+	Items map[string]*ItemReport
+}
+
+// ItemReport holds statistics of a single item generated by Simulate.
+type ItemReport struct {
+	// Rolls is the number of times that the item was generated at least once.
+	Rolls int
+	// Stacks is the number of stacks of the item generated, and Count the
+	// total count of those stacks.
+	Stacks, Count int
+	// MinCount and MaxCount are the lowest and highest total count of the
+	// item generated at once, out of the times that the item was generated.
+	MinCount, MaxCount int
+	// Enchantments holds the number of stacks of the item generated with an
+	// enchantment, keyed by the name of the enchantment and its level.
+	Enchantments map[string]map[int]int
+}
+
+// Frequency returns the fraction of the rolls of the Report that generated the
+// item with the name passed, from 0-1.
+func (r Report) Frequency(name string) float64 {
+	it, ok := r.Items[name]
+	if !ok || r.Rolls == 0 {
+		return 0
+	}
+	return float64(it.Rolls) / float64(r.Rolls)
+}
+
+// AverageCount returns the average count of the item with the name passed
+// generated per roll of the Report, including rolls that did not generate the
+// item.
+func (r Report) AverageCount(name string) float64 {
+	it, ok := r.Items[name]
+	if !ok || r.Rolls == 0 {
+		return 0
+	}
+	return float64(it.Count) / float64(r.Rolls)
+}
+
+// String formats the Report as a table with a line for every item, sorted by
+// name, holding its frequency, range of counts, average count and the
+// enchantments it was generated with.
+func (r Report) String() string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%v: %v rolls, %v empty\n", r.Path, r.Rolls, r.Empty)
+	for _, name := range slices.Sorted(maps.Keys(r.Items)) {
+		it := r.Items[name]
+		_, _ = fmt.Fprintf(&sb, "  %v: %.2f%%, count %v-%v, average %.2f", name, r.Frequency(name)*100, it.MinCount, it.MaxCount, r.AverageCount(name))
+		for _, enc := range slices.Sorted(maps.Keys(it.Enchantments)) {
+			levels := it.Enchantments[enc]
+			for _, lvl := range slices.Sorted(maps.Keys(levels)) {
+				_, _ = fmt.Fprintf(&sb, ", %v %v: %.2f%%", enc, lvl, float64(levels[lvl])/float64(it.Stacks)*100)
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// Simulate generates loot from the loot table at the path passed n times and
+// returns statistics of the loot generated. It may be used to balance custom
+// loot tables or to check the loot generated by tables in tests. Simulate is
+// the same as calling SimulateWithContext with an empty Context.
+func Simulate(path string, n int) Report {
+	return SimulateWithContext(path, n, Context{})
+}
+
+// SimulateWithContext generates loot from the loot table at the path passed n
+// times using the Context passed and returns statistics of the loot
+// generated. If the Context has a Seed or Rand, the source of randomness is
+// shared by all rolls, so that the same Report is returned every time for the
+// same seed, rather than generating the same loot n times. Unlike
+// GenerateWithContext, the rolls are not recorded in metrics.
+func SimulateWithContext(path string, n int, ctx Context) Report {
+	r := Report{Path: path, Items: map[string]*ItemReport{}}
+	t, err := LoadTable(path)
+	if err != nil {
+		fmt.Printf("[Loot System] Error loading table '%s': %v\n", path, err)
+		return r
+	}
+	ctx.Rand = newContext(ctx).r
+
+	counts := map[string]int{}
+	for range n {
+		c := newContext(ctx)
+		c.tables = []string{path}
+		stacks := t.generate(c)
+		if len(stacks) == 0 {
+			r.Empty++
+		}
+		clear(counts)
+		for _, s := range stacks {
+			name := itemName(s)
+			counts[name] += s.Count()
+			r.Items[name] = addStack(r.Items[name], s)
+		}
+		for name, count := range counts {
+			it := r.Items[name]
+			if it.Rolls == 0 || count < it.MinCount {
+				it.MinCount = count
+			}
+			it.MaxCount = max(it.MaxCount, count)
+			it.Rolls++
+		}
+	}
+	r.Rolls = n
+	return r
+}
+
+// addStack adds the stack passed to the ItemReport passed, creating it if it
+// is nil, and returns it.
+func addStack(it *ItemReport, s item.Stack) *ItemReport {
+	if it == nil {
+		it = &ItemReport{Enchantments: map[string]map[int]int{}}
+	}
+	it.Stacks++
+	it.Count += s.Count()
+	for _, e := range s.Enchantments() {
+		name, _ := item.EnchantmentName(e.Type())
+		if it.Enchantments[name] == nil {
+			it.Enchantments[name] = map[int]int{}
+		}
+		it.Enchantments[name][e.Level()]++
+	}
+	return it
+}
+
+// itemName returns the name of the item of the stack passed.
+func itemName(s item.Stack) string {
+	name, _ := s.Item().EncodeItem()
+	return name
+}
@@ -0,0 +1,102 @@
+package loot
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/sandertv/gophertunnel/minecraft/text"
+)
+
+// Text is text set on an item by a loot table function, such as the name set
+// by set_name. In JSON, Text may be a string or a text component of Java
+// Edition. Strings may hold formatting codes prefixed with either '§' or '&',
+// such as "&6Golden Sword", and colour tags accepted by text.Colourf, such as
+// "<gold>Golden Sword</gold>". Text components may set a colour using the
+// names of Java Edition, make the text bold or italic and hold more text
+// components in their extra field.
+type Text string
+
+// UnmarshalJSON ...
+func (t *Text) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*t = Text(colour(s))
+		return nil
+	}
+	var components []textComponent
+	if err := json.Unmarshal(data, &components); err == nil {
+		*t = Text(colour(componentsString(components)))
+		return nil
+	}
+	var c textComponent
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+	*t = Text(colour(c.String()))
+	return nil
+}
+
+// textComponent is a text component of Java Edition.
+type textComponent struct {
+	Text   string          `json:"text"`
+	Color  string          `json:"color"`
+	Bold   bool            `json:"bold"`
+	Italic bool            `json:"italic"`
+	Extra  []textComponent `json:"extra"`
+}
+
+// javaColours maps the names of colours of Java Edition that differ from the
+// colour tags of text.Colourf to those tags.
+var javaColours = map[string]string{
+	"gray":         "grey",
+	"dark_gray":    "dark-grey",
+	"light_purple": "purple",
+}
+
+// String converts the textComponent to a string with colour tags that may be
+// passed to colour.
+func (c textComponent) String() string {
+	s := c.Text + componentsString(c.Extra)
+	if c.Italic {
+		s = "<i>" + s + "</i>"
+	}
+	if c.Bold {
+		s = "<b>" + s + "</b>"
+	}
+	if c.Color != "" {
+		tag, ok := javaColours[c.Color]
+		if !ok {
+			tag = strings.ReplaceAll(c.Color, "_", "-")
+		}
+		s = "<" + tag + ">" + s + "</" + tag + ">"
+	}
+	return s
+}
+
+// componentsString converts all text components passed to strings and joins
+// them.
+func componentsString(components []textComponent) string {
+	var b strings.Builder
+	for _, c := range components {
+		b.WriteString(c.String())
+	}
+	return b.String()
+}
+
+// formattingCodes holds all characters that may follow '§' in a formatting
+// code of Bedrock Edition.
+const formattingCodes = "0123456789abcdefghijklmnopqrstu"
+
+// colour replaces formatting codes prefixed with '&' with codes prefixed with
+// '§' and converts the colour tags in the string passed to formatting codes.
+func colour(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '&' && i+1 < len(s) && strings.IndexByte(formattingCodes, s[i+1]) != -1 {
+			b.WriteString("§")
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return text.Colourf("%s", b.String())
+}
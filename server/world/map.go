@@ -0,0 +1,258 @@
+package world
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// MapSize is the width and height in pixels of a map.
+	MapSize = 128
+	// MaxMapScale is the highest scale that a map may be zoomed out to.
+	MaxMapScale = 4
+)
+
+// MapData holds the data of a filled map, such as the area of the World that
+// it displays and the colours of its pixels.
+type MapData struct {
+	// Centre is the centre of the area displayed by the map. Only the X and Z
+	// components of the position are used.
+	Centre cube.Pos
+	// Scale is the zoom level of the map, ranging from 0 to MaxMapScale. Every
+	// pixel of the map covers 2^Scale blocks in both directions.
+	Scale uint8
+	// Dimension is the Dimension that the map displays.
+	Dimension Dimension
+	// Locked specifies if the map was locked using a cartography table. Locked
+	// maps are no longer updated when the area they display changes.
+	Locked bool
+	// Pixels holds the colours of all pixels of the map, indexed as
+	// Pixels[y*MapSize+x]. Pixels that have not yet been explored are fully
+	// transparent.
+	Pixels []color.RGBA
+	// Markers holds the fixed markers displayed on the map, such as the cross
+	// marking the buried treasure of a treasure map.
+	Markers []MapMarker
+}
+
+// MapMarker is a fixed marker displayed on a map at a specific position.
+type MapMarker struct {
+	// Pos is the position marked. Only the X and Z components of the position
+	// are used.
+	Pos cube.Pos
+	// Type is the type of the marker, which determines the icon displayed.
+	Type MapMarkerType
+}
+
+// MapMarkerType is the type of MapMarker, which determines the icon that it
+// is displayed with.
+type MapMarkerType uint8
+
+const (
+	// MapMarkerTreasure is a red cross marking buried treasure.
+	MapMarkerTreasure MapMarkerType = iota
+	// MapMarkerMonument is a marker pointing to an ocean monument.
+	MapMarkerMonument
+	// MapMarkerMansion is a marker pointing to a woodland mansion.
+	MapMarkerMansion
+)
+
+// NewMapData returns a MapData with an unexplored area around the position
+// passed. The centre of the map is aligned to a grid with cells the size of
+// the area displayed by the map, so that maps of the same scale never overlap.
+func NewMapData(pos cube.Pos, scale uint8, dim Dimension) MapData {
+	scale = min(scale, MaxMapScale)
+	size := MapSize << scale
+	align := func(v int) int {
+		return floorDiv(v+MapSize/2, size)*size + size/2 - MapSize/2
+	}
+	return MapData{
+		Centre:    cube.Pos{align(pos.X()), 0, align(pos.Z())},
+		Scale:     scale,
+		Dimension: dim,
+		Pixels:    make([]color.RGBA, MapSize*MapSize),
+	}
+}
+
+// Clone returns a deep copy of the MapData.
+func (m MapData) Clone() MapData {
+	m.Pixels = append([]color.RGBA(nil), m.Pixels...)
+	m.Markers = append([]MapMarker(nil), m.Markers...)
+	return m
+}
+
+// PixelPos converts a world position to a position on the map, relative to the
+// top left corner of the map. The position returned may be outside the map
+// if the world position is not displayed on it.
+func (m MapData) PixelPos(pos mgl64.Vec3) mgl64.Vec2 {
+	scale := float64(int(1) << m.Scale)
+	return mgl64.Vec2{
+		(pos[0]-float64(m.Centre.X()))/scale + MapSize/2,
+		(pos[2]-float64(m.Centre.Z()))/scale + MapSize/2,
+	}
+}
+
+// MapProvider is implemented by a Provider that is able to store MapData. If
+// the Provider of a World does not implement MapProvider, maps are only kept
+// in memory.
+type MapProvider interface {
+	// LoadMap loads the MapData with the ID passed. If no map with the ID
+	// exists, errors.Is(err, leveldb.ErrNotFound) equals true.
+	LoadMap(id int64) (MapData, error)
+	// StoreMap stores MapData under the ID passed.
+	StoreMap(id int64, m MapData) error
+}
+
+// mapEntry holds MapData loaded in a World together with a flag indicating if
+// it needs to be saved.
+type mapEntry struct {
+	data     MapData
+	modified bool
+}
+
+// AddMap adds the MapData passed to the World and returns the newly allocated
+// ID under which it may be found.
+func (tx *Tx) AddMap(m MapData) int64 {
+	w := tx.World()
+	for {
+		id := w.r.Int64()
+		if _, ok := w.mapData(id); id == 0 || ok {
+			continue
+		}
+		if m.Pixels == nil {
+			m.Pixels = make([]color.RGBA, MapSize*MapSize)
+		}
+		w.maps[id] = &mapEntry{data: m, modified: true}
+		return id
+	}
+}
+
+// Map looks up the MapData with the ID passed. If no map with this ID exists,
+// false is returned. The Pixels of the MapData returned are updated in place
+// by UpdateMap and must not be modified. SetMap should be used with a copy
+// obtained using MapData.Clone instead.
+func (tx *Tx) Map(id int64) (MapData, bool) {
+	e, ok := tx.World().mapData(id)
+	if !ok {
+		return MapData{}, false
+	}
+	return e.data, true
+}
+
+// SetMap overwrites the MapData stored under the ID passed.
+func (tx *Tx) SetMap(id int64, m MapData) {
+	tx.World().maps[id] = &mapEntry{data: m, modified: true}
+}
+
+// UpdateMap renders the part of the map with the ID passed around the
+// position of a holder. To spread the cost of rendering over time, only a
+// sixteenth of the columns is rendered every tick. The area of pixels that
+// changed is returned. If the map does not exist, is locked or displays a
+// different Dimension, an empty area is returned.
+func (tx *Tx) UpdateMap(id int64, pos mgl64.Vec3) image.Rectangle {
+	w := tx.World()
+	e, ok := w.mapData(id)
+	if !ok || e.data.Locked || e.data.Dimension != w.Dimension() {
+		return image.Rectangle{}
+	}
+	m, scale := e.data, 1<<e.data.Scale
+	centre := m.PixelPos(pos)
+	radius := max(MapSize/scale, 1)
+	stripe := int(w.set.CurrentTick % 16)
+
+	var changed image.Rectangle
+	for px := max(int(centre[0])-radius, 0); px < min(int(centre[0])+radius, MapSize); px++ {
+		if px%16 != stripe {
+			continue
+		}
+		x := m.Centre.X() + (px-MapSize/2)*scale
+		prevHeight, first := 0, true
+		for py := max(int(centre[1])-radius-1, -1); py < min(int(centre[1])+radius, MapSize); py++ {
+			dx, dz := float64(px)-centre[0], float64(py)-centre[1]
+			z := m.Centre.Z() + (py-MapSize/2)*scale
+
+			c, height, ok := w.mapColour(x, z)
+			if !ok {
+				first = true
+				continue
+			}
+			if !first && py >= 0 && dx*dx+dz*dz <= float64(radius*radius) {
+				c = shadeMapColour(c, height-prevHeight)
+				if i := py*MapSize + px; m.Pixels[i] != c {
+					m.Pixels[i], e.modified = c, true
+					changed = changed.Union(image.Rect(px, py, px+1, py+1))
+				}
+			}
+			prevHeight, first = height, false
+		}
+	}
+	return changed
+}
+
+// mapData returns the mapEntry with the ID passed, loading it from the
+// Provider if it was not yet loaded.
+func (w *World) mapData(id int64) (*mapEntry, bool) {
+	if e, ok := w.maps[id]; ok {
+		return e, true
+	}
+	p, ok := w.conf.Provider.(MapProvider)
+	if !ok {
+		return nil, false
+	}
+	m, err := p.LoadMap(id)
+	if err != nil {
+		return nil, false
+	}
+	if len(m.Pixels) != MapSize*MapSize {
+		m.Pixels = make([]color.RGBA, MapSize*MapSize)
+	}
+	e := &mapEntry{data: m}
+	w.maps[id] = e
+	return e, true
+}
+
+// saveMaps stores all modified maps in the World using the Provider, if it
+// implements MapProvider.
+func (w *World) saveMaps() {
+	p, ok := w.conf.Provider.(MapProvider)
+	if !ok {
+		return
+	}
+	for id, e := range w.maps {
+		if !e.modified {
+			continue
+		}
+		if err := p.StoreMap(id, e.data); err != nil {
+			w.conf.Log.Error("save map: "+err.Error(), "ID", id)
+			continue
+		}
+		e.modified = false
+	}
+}
+
+// mapColour returns the colour of the highest block at the x and z passed,
+// together with its height. If the chunk at that position is not loaded,
+// false is returned.
+func (w *World) mapColour(x, z int) (color.RGBA, int, bool) {
+	c, ok := w.chunks[ChunkPos{int32(x >> 4), int32(z >> 4)}]
+	if !ok {
+		return color.RGBA{}, 0, false
+	}
+	for y := int(c.HighestBlock(uint8(x), uint8(z))); y >= w.ra[0]; y-- {
+		if col, ok := blockMapColour(w.blockInChunk(c, cube.Pos{x, y, z})); ok {
+			return col, y, true
+		}
+	}
+	return color.RGBA{A: 0xff}, w.ra[0], true
+}
+
+// floorDiv divides a by b, rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	if a < 0 {
+		return -((-a + b - 1) / b)
+	}
+	return a / b
+}
@@ -0,0 +1,80 @@
+package world
+
+import (
+	"image/color"
+	"strings"
+)
+
+// mapColours holds the base map colours of blocks, matched against parts of
+// the names of the blocks. Entries are checked in order, so that more specific
+// names are matched before more general ones.
+var mapColours = []struct {
+	names  []string
+	colour color.RGBA
+}{
+	{names: []string{"water", "kelp", "seagrass"}, colour: color.RGBA{R: 64, G: 64, B: 255, A: 255}},
+	{names: []string{"lava", "fire", "tnt", "redstone_block"}, colour: color.RGBA{R: 255, A: 255}},
+	{names: []string{"ice"}, colour: color.RGBA{R: 160, G: 160, B: 255, A: 255}},
+	{names: []string{"snow", "powder_snow"}, colour: color.RGBA{R: 255, G: 255, B: 255, A: 255}},
+	{names: []string{"grass_block", "slime"}, colour: color.RGBA{R: 127, G: 178, B: 56, A: 255}},
+	{names: []string{"leaves", "vine", "grass", "fern", "sapling", "flower", "bush", "cactus", "lily_pad", "bamboo", "wheat", "carrots", "potatoes", "beetroot", "azalea"}, colour: color.RGBA{G: 124, A: 255}},
+	{names: []string{"red_sand", "terracotta", "acacia"}, colour: color.RGBA{R: 216, G: 127, B: 51, A: 255}},
+	{names: []string{"sand", "birch", "glowstone", "end_stone", "bone_block"}, colour: color.RGBA{R: 247, G: 233, B: 163, A: 255}},
+	{names: []string{"crimson", "nether_wart", "netherrack", "nether_brick", "magma"}, colour: color.RGBA{R: 112, G: 2, A: 255}},
+	{names: []string{"warped"}, colour: color.RGBA{R: 22, G: 126, B: 134, A: 255}},
+	{names: []string{"dirt", "farmland", "grass_path", "dirt_path", "jungle", "granite", "mud"}, colour: color.RGBA{R: 151, G: 109, B: 77, A: 255}},
+	{names: []string{"podzol", "spruce", "mangrove", "soul"}, colour: color.RGBA{R: 129, G: 86, B: 49, A: 255}},
+	{names: []string{"dark_oak", "brown_mushroom"}, colour: color.RGBA{R: 102, G: 76, B: 51, A: 255}},
+	{names: []string{"oak", "planks", "log", "wood", "chest", "crafting_table", "bookshelf", "fence", "door"}, colour: color.RGBA{R: 143, G: 119, B: 72, A: 255}},
+	{names: []string{"clay"}, colour: color.RGBA{R: 164, G: 168, B: 184, A: 255}},
+	{names: []string{"deepslate", "basalt", "blackstone", "obsidian", "coal_block"}, colour: color.RGBA{R: 25, G: 25, B: 25, A: 255}},
+	{names: []string{"diamond_block", "prismarine"}, colour: color.RGBA{R: 92, G: 219, B: 213, A: 255}},
+	{names: []string{"gold_block"}, colour: color.RGBA{R: 250, G: 238, B: 77, A: 255}},
+	{names: []string{"emerald_block"}, colour: color.RGBA{G: 217, B: 58, A: 255}},
+	{names: []string{"iron_block", "anvil"}, colour: color.RGBA{R: 167, G: 167, B: 167, A: 255}},
+	{names: []string{"quartz", "diorite", "calcite"}, colour: color.RGBA{R: 255, G: 252, B: 245, A: 255}},
+	{names: []string{"mycelium", "amethyst"}, colour: color.RGBA{R: 127, G: 63, B: 178, A: 255}},
+	{names: []string{"pumpkin", "honey"}, colour: color.RGBA{R: 216, G: 127, B: 51, A: 255}},
+	{names: []string{"melon", "moss"}, colour: color.RGBA{R: 127, G: 204, B: 25, A: 255}},
+}
+
+// defaultMapColour is the colour used on maps for blocks that do not have a
+// more specific colour, which is the colour of stone.
+var defaultMapColour = color.RGBA{R: 112, G: 112, B: 112, A: 255}
+
+// blockMapColour returns the base colour of a Block as it is displayed on a
+// map. If the Block is not visible on maps, such as air, false is returned.
+func blockMapColour(b Block) (color.RGBA, bool) {
+	name, _ := b.EncodeBlock()
+	name = strings.TrimPrefix(name, "minecraft:")
+	switch name {
+	case "air", "structure_void", "barrier", "light_block", "glass", "glass_pane":
+		return color.RGBA{}, false
+	}
+	for _, c := range mapColours {
+		for _, n := range c.names {
+			if strings.Contains(name, n) {
+				return c.colour, true
+			}
+		}
+	}
+	return defaultMapColour, true
+}
+
+// shadeMapColour shades a map colour based on the height difference between
+// the block it represents and the block north of it, making slopes visible on
+// the map.
+func shadeMapColour(c color.RGBA, diff int) color.RGBA {
+	mul := uint32(220)
+	if diff > 0 {
+		mul = 255
+	} else if diff < 0 {
+		mul = 180
+	}
+	return color.RGBA{
+		R: uint8(uint32(c.R) * mul / 255),
+		G: uint8(uint32(c.G) * mul / 255),
+		B: uint8(uint32(c.B) * mul / 255),
+		A: c.A,
+	}
+}
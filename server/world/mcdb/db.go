@@ -177,6 +177,10 @@ func (db *DB) column(k dbKey) (*chunk.Column, error) {
 	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
 		return nil, fmt.Errorf("read scheduled updates: %w", err)
 	}
+	col.InhabitedTime, err = db.inhabitedTime(k)
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		return nil, fmt.Errorf("read inhabited time: %w", err)
+	}
 	return col, nil
 }
 
@@ -336,7 +340,7 @@ func (db *DB) StoreColumn(pos world.ChunkPos, dim world.Dimension, col *chunk.Co
 
 func (db *DB) storeColumn(k dbKey, col *chunk.Column) error {
 	data := chunk.Encode(col.Chunk, chunk.DiskEncoding)
-	n := 7 + len(data.SubChunks) + len(col.Entities)
+	n := 8 + len(data.SubChunks) + len(col.Entities)
 	batch := leveldb.MakeBatch(n)
 
 	db.storeVersion(batch, k, chunkVersion)
@@ -346,6 +350,7 @@ func (db *DB) storeColumn(k dbKey, col *chunk.Column) error {
 	db.storeEntities(batch, k, col.Entities)
 	db.storeBlockEntities(batch, k, col.BlockEntities)
 	db.storeScheduledUpdates(batch, k, col.Tick, col.ScheduledBlocks)
+	db.storeInhabitedTime(batch, k, col.InhabitedTime)
 
 	return db.ldb.Write(batch, nil)
 }
@@ -461,6 +466,25 @@ func (db *DB) storeScheduledUpdates(batch *leveldb.Batch, k dbKey, tick int64, u
 	batch.Put(k.Sum(keyPendingScheduledTicks), b)
 }
 
+func (db *DB) inhabitedTime(k dbKey) (int64, error) {
+	p, err := db.ldb.Get(k.Sum(keyInhabitedTime), nil)
+	if err != nil {
+		return 0, err
+	}
+	if n := len(p); n != 8 {
+		return 0, fmt.Errorf("expected 8 inhabited time bytes, got %v", n)
+	}
+	return int64(binary.LittleEndian.Uint64(p)), nil
+}
+
+func (db *DB) storeInhabitedTime(batch *leveldb.Batch, k dbKey, t int64) {
+	if t == 0 {
+		batch.Delete(k.Sum(keyInhabitedTime))
+		return
+	}
+	batch.Put(k.Sum(keyInhabitedTime), binary.LittleEndian.AppendUint64(nil, uint64(t)))
+}
+
 type scheduledUpdates struct {
 	CurrentTick int32            `nbt:"currentTick"`
 	TickList    []map[string]any `nbt:"tickList"`
@@ -35,6 +35,9 @@ const (
 	// keyChecksum holds a list of checksums of some sort. It's not clear of what data this checksum is composed or what
 	// these checksums are used for.
 	keyChecksums = ';' // 3b
+	// keyInhabitedTime holds a single LE int64 with the number of ticks that players have spent in the chunk. It is
+	// not written by vanilla, which ignores it.
+	keyInhabitedTime = 'i' // 69
 
 	keyEntityIdentifiers = "digp"
 
@@ -49,6 +52,7 @@ const (
 	keyBiomeData          = "BiomeData"
 	keyScoreboard         = "scoreboard"
 	keyLocalPlayer        = "~local_player"
+	keyMap                = "map_"
 )
 
 const (
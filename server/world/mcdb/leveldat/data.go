@@ -3,6 +3,7 @@ package leveldat
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"math"
 	"time"
@@ -149,6 +150,13 @@ type Data struct {
 	TNTExplosionDropDecay          bool           `nbt:"tntexplosiondropdecay"`
 	HasUncompleteWorldFileOnDisk   bool           `nbt:"HasUncompleteWorldFileOnDisk"`
 	PlayerHasDied                  bool           `nbt:"PlayerHasDied"`
+	BorderCentreX                  float64        `nbt:"BorderCenterX"`
+	BorderCentreZ                  float64        `nbt:"BorderCenterZ"`
+	BorderSize                     float64        `nbt:"BorderSize"`
+	BorderTargetSize               float64        `nbt:"BorderSizeLerpTarget"`
+	BorderResizeTicks              int64          `nbt:"BorderSizeLerpTime"`
+	BorderDamagePerBlock           float64        `nbt:"BorderDamagePerBlock"`
+	BorderSafeZone                 float64        `nbt:"BorderSafeZone"`
 }
 
 // FillDefault fills out d with all the default level.dat values.
@@ -221,6 +229,8 @@ func (d *Data) FillDefault() {
 	d.TNTExplodes = true
 	d.WorldVersion = 1
 	d.XBLBroadcastIntent = 3
+	d.BorderDamagePerBlock = 0.2
+	d.BorderSafeZone = 5
 }
 
 // Settings returns a world.Settings value based on the properties stored in d.
@@ -242,6 +252,18 @@ func (d *Data) Settings() *world.Settings {
 		DefaultGameMode: mode,
 		Difficulty:      difficulty,
 		TickRange:       d.ServerChunkTickRange,
+		Insomnia:        d.DoInsomnia,
+		MobLoot:         d.DoMobLoot,
+		TileDrops:       d.DoTileDrops,
+		EntityDrops:     d.DoEntityDrops,
+		Border: world.Border{
+			Centre:         mgl64.Vec2{d.BorderCentreX, d.BorderCentreZ},
+			Size:           d.BorderSize,
+			TargetSize:     d.BorderTargetSize,
+			ResizeTicks:    d.BorderResizeTicks,
+			DamagePerBlock: d.BorderDamagePerBlock,
+			SafeZone:       d.BorderSafeZone,
+		},
 	}
 }
 
@@ -263,8 +285,13 @@ func (d *Data) PutSettings(s *world.Settings) {
 	}
 	d.CurrentTick = s.CurrentTick
 	d.ServerChunkTickRange = s.TickRange
+	d.DoInsomnia = s.Insomnia
+	d.DoMobLoot, d.DoTileDrops, d.DoEntityDrops = s.MobLoot, s.TileDrops, s.EntityDrops
 	mode, _ := world.GameModeID(s.DefaultGameMode)
 	d.GameType = int32(mode)
 	difficulty, _ := world.DifficultyID(s.Difficulty)
 	d.Difficulty = int32(difficulty)
+	d.BorderCentreX, d.BorderCentreZ = s.Border.Centre[0], s.Border.Centre[1]
+	d.BorderSize, d.BorderTargetSize, d.BorderResizeTicks = s.Border.Size, s.Border.TargetSize, s.Border.ResizeTicks
+	d.BorderDamagePerBlock, d.BorderSafeZone = s.Border.DamagePerBlock, s.Border.SafeZone
 }
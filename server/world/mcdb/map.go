@@ -0,0 +1,154 @@
+package mcdb
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// Compile time check to make sure DB implements world.MapProvider.
+var _ world.MapProvider = (*DB)(nil)
+
+// mapData holds the fields of a map as it is stored in the database.
+type mapData struct {
+	MapID          int64  `nbt:"mapId"`
+	ParentMapID    int64  `nbt:"parentMapId"`
+	Dimension      byte   `nbt:"dimension"`
+	FullyExplored  byte   `nbt:"fullyExplored"`
+	Locked         byte   `nbt:"mapLocked"`
+	Scale          byte   `nbt:"scale"`
+	UnlimitedTrack byte   `nbt:"unlimitedTracking"`
+	Height         int16  `nbt:"height"`
+	Width          int16  `nbt:"width"`
+	XCentre        int32  `nbt:"xCenter"`
+	ZCentre        int32  `nbt:"zCenter"`
+	Colours        []byte `nbt:"colors"`
+	Decorations    []any  `nbt:"decorations"`
+}
+
+// LoadMap loads the world.MapData stored under the ID passed. If no map with
+// this ID exists, errors.Is(err, leveldb.ErrNotFound) equals true.
+func (db *DB) LoadMap(id int64) (world.MapData, error) {
+	b, err := db.ldb.Get(mapKey(id), nil)
+	if err != nil {
+		return world.MapData{}, fmt.Errorf("load map %v: %w", id, err)
+	}
+	var d mapData
+	if err := nbt.UnmarshalEncoding(b, &d, nbt.LittleEndian); err != nil {
+		return world.MapData{}, fmt.Errorf("decode map %v: %w", id, err)
+	}
+	dim, ok := world.DimensionByID(int(d.Dimension))
+	if !ok {
+		dim = world.Overworld
+	}
+	m := world.MapData{
+		Centre:    cube.Pos{int(d.XCentre), 0, int(d.ZCentre)},
+		Scale:     min(d.Scale, world.MaxMapScale),
+		Dimension: dim,
+		Locked:    d.Locked == 1,
+		Pixels:    make([]color.RGBA, world.MapSize*world.MapSize),
+	}
+	for _, v := range d.Decorations {
+		if marker, ok := decodeMapMarker(v); ok {
+			m.Markers = append(m.Markers, marker)
+		}
+	}
+	for i := range min(len(d.Colours)/4, len(m.Pixels)) {
+		m.Pixels[i] = color.RGBA{R: d.Colours[i*4], G: d.Colours[i*4+1], B: d.Colours[i*4+2], A: d.Colours[i*4+3]}
+	}
+	return m, nil
+}
+
+// StoreMap stores the world.MapData passed under the ID passed.
+func (db *DB) StoreMap(id int64, m world.MapData) error {
+	dim, _ := world.DimensionID(m.Dimension)
+	d := mapData{
+		MapID:       id,
+		ParentMapID: -1,
+		Dimension:   byte(dim),
+		Locked:      boolByte(m.Locked),
+		Scale:       m.Scale,
+		Height:      world.MapSize,
+		Width:       world.MapSize,
+		XCentre:     int32(m.Centre.X()),
+		ZCentre:     int32(m.Centre.Z()),
+		Colours:     make([]byte, 0, len(m.Pixels)*4),
+		Decorations: []any{},
+	}
+	for _, marker := range m.Markers {
+		d.Decorations = append(d.Decorations, encodeMapMarker(marker))
+	}
+	for _, c := range m.Pixels {
+		d.Colours = append(d.Colours, c.R, c.G, c.B, c.A)
+	}
+	b, err := nbt.MarshalEncoding(d, nbt.LittleEndian)
+	if err != nil {
+		return fmt.Errorf("encode map %v: %w", id, err)
+	}
+	if err := db.ldb.Put(mapKey(id), b, nil); err != nil {
+		return fmt.Errorf("store map %v: %w", id, err)
+	}
+	return nil
+}
+
+// mapMarkerTypes maps the types of world.MapMarker to the decoration types
+// under which they are stored in the database.
+var mapMarkerTypes = map[world.MapMarkerType]int32{
+	world.MapMarkerTreasure: 4,
+	world.MapMarkerMansion:  14,
+	world.MapMarkerMonument: 15,
+}
+
+// encodeMapMarker encodes a world.MapMarker to the decoration stored in the
+// database.
+func encodeMapMarker(marker world.MapMarker) map[string]any {
+	return map[string]any{
+		"data": map[string]any{"type": mapMarkerTypes[marker.Type], "rot": int32(8)},
+		"key": map[string]any{
+			"type":   int32(1),
+			"blockX": int32(marker.Pos.X()),
+			"blockY": int32(marker.Pos.Y()),
+			"blockZ": int32(marker.Pos.Z()),
+		},
+	}
+}
+
+// decodeMapMarker decodes a decoration stored in the database to a
+// world.MapMarker. Decorations that do not mark a fixed block position, such
+// as those of players, are not decoded and false is returned for them.
+func decodeMapMarker(v any) (world.MapMarker, bool) {
+	m, _ := v.(map[string]any)
+	data, _ := m["data"].(map[string]any)
+	key, _ := m["key"].(map[string]any)
+	if keyType, _ := key["type"].(int32); keyType != 1 {
+		return world.MapMarker{}, false
+	}
+	t, _ := data["type"].(int32)
+	for markerType, id := range mapMarkerTypes {
+		if id == t {
+			x, _ := key["blockX"].(int32)
+			y, _ := key["blockY"].(int32)
+			z, _ := key["blockZ"].(int32)
+			return world.MapMarker{Pos: cube.Pos{int(x), int(y), int(z)}, Type: markerType}, true
+		}
+	}
+	return world.MapMarker{}, false
+}
+
+// mapKey returns the database key under which the map with the ID passed is
+// stored.
+func mapKey(id int64) []byte {
+	return []byte(keyMap + strconv.FormatInt(id, 10))
+}
+
+// boolByte returns 1 if the bool passed is true, or 0 if it is false.
+func boolByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,60 @@
+package world
+
+// MoonPhase is a phase of the moon. The phase of the moon changes every day,
+// cycling through eight phases starting at a full moon.
+type MoonPhase int
+
+const (
+	// FullMoon is the phase during which the moon is fully lit. It is the
+	// phase of the moon on the first night of a World.
+	FullMoon MoonPhase = iota
+	// WaningGibbous is the phase during which three quarters of the moon are
+	// lit, after a full moon.
+	WaningGibbous
+	// ThirdQuarter is the phase during which the left half of the moon is
+	// lit.
+	ThirdQuarter
+	// WaningCrescent is the phase during which a quarter of the moon is lit,
+	// before a new moon.
+	WaningCrescent
+	// NewMoon is the phase during which the moon is not lit at all.
+	NewMoon
+	// WaxingCrescent is the phase during which a quarter of the moon is lit,
+	// after a new moon.
+	WaxingCrescent
+	// FirstQuarter is the phase during which the right half of the moon is
+	// lit.
+	FirstQuarter
+	// WaxingGibbous is the phase during which three quarters of the moon are
+	// lit, before a full moon.
+	WaxingGibbous
+)
+
+// Brightness returns the fraction of the moon that is lit during the
+// MoonPhase: 1 for a FullMoon and 0 for a NewMoon. It influences, for example,
+// the local difficulty at night.
+func (m MoonPhase) Brightness() float64 {
+	return [...]float64{1, 0.75, 0.5, 0.25, 0, 0.25, 0.5, 0.75}[m&7]
+}
+
+// String returns the name of the MoonPhase in snake case, such as
+// "waning_gibbous".
+func (m MoonPhase) String() string {
+	return [...]string{"full_moon", "waning_gibbous", "third_quarter", "waning_crescent", "new_moon", "waxing_crescent", "first_quarter", "waxing_gibbous"}[m&7]
+}
+
+// moonPhaseAt returns the MoonPhase at the World time passed.
+func moonPhaseAt(time int64) MoonPhase {
+	return MoonPhase(((time/TimeFull)%8 + 8) % 8)
+}
+
+// MoonPhase returns the current MoonPhase of the World. The phase of the moon
+// changes at the start of every day.
+func (w *World) MoonPhase() MoonPhase {
+	if w == nil {
+		return FullMoon
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return moonPhaseAt(w.set.Time)
+}
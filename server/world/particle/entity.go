@@ -5,6 +5,9 @@ import "image/color"
 // HugeExplosion is a particle shown when TNT or a creeper explodes.
 type HugeExplosion struct{ particle }
 
+// WindExplosion is a particle shown when a wind charge bursts.
+type WindExplosion struct{ particle }
+
 // EndermanTeleport is a particle that shows up when an enderman teleports.
 type EndermanTeleport struct{ particle }
 
@@ -44,6 +44,20 @@ type Settings struct {
 	// TickRange is the radius in chunks around a Viewer that has its blocks and entities ticked when the world is
 	// ticked. If set to 0, blocks and entities will never be ticked.
 	TickRange int32
+	// Insomnia specifies if phantoms spawn at night above players that have not
+	// slept for a while.
+	Insomnia bool
+	// MobLoot specifies if mobs drop items and experience when they are
+	// killed.
+	MobLoot bool
+	// TileDrops specifies if blocks drop items when they are broken.
+	TileDrops bool
+	// EntityDrops specifies if entities that are not mobs, such as item
+	// frames and falling blocks, drop items when they are destroyed.
+	EntityDrops bool
+	// Border is the border of the World. Its size changes every tick while it
+	// is being resized.
+	Border Border
 }
 
 // defaultSettings returns the default Settings for a new World.
@@ -54,6 +68,11 @@ func defaultSettings() *Settings {
 		Difficulty:      DifficultyNormal,
 		TimeCycle:       true,
 		WeatherCycle:    true,
+		Insomnia:        true,
+		MobLoot:         true,
+		TileDrops:       true,
+		EntityDrops:     true,
 		TickRange:       6,
+		Border:          Border{DamagePerBlock: 0.2, SafeZone: 5},
 	}
 }
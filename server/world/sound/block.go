@@ -129,6 +129,9 @@ type TNT struct{ sound }
 // FireExtinguish is a sound played when a fire is extinguished.
 type FireExtinguish struct{ sound }
 
+// BellRing is a sound played when a bell is rung.
+type BellRing struct{ sound }
+
 // Note is a sound played by note blocks.
 type Note struct {
 	sound
@@ -191,6 +194,13 @@ type LecternBookPlace struct{ sound }
 // SignWaxed is a sound played when a sign is waxed.
 type SignWaxed struct{ sound }
 
+// SignInked is a sound played when the text of a sign is inked using an ink sac.
+type SignInked struct {
+	sound
+	// Glowing specifies if a glow ink sac was used, making the text of the sign glow.
+	Glowing bool
+}
+
 // WaxedSignFailedInteraction is a sound played when a player tries to interact with a waxed sign.
 type WaxedSignFailedInteraction struct{ sound }
 
@@ -210,6 +220,28 @@ type DecoratedPotInserted struct {
 // DecoratedPotInsertFailed is a sound played when an item fails to be inserted into a decorated pot.
 type DecoratedPotInsertFailed struct{ sound }
 
+// SnifferEggCrack is a sound played when a sniffer egg cracks.
+type SnifferEggCrack struct{ sound }
+
+// SnifferEggHatch is a sound played when a sniffer egg hatches.
+type SnifferEggHatch struct{ sound }
+
+// SculkCatalystBloom is a sound played when a sculk catalyst blooms after absorbing the experience of a mob that
+// died near it.
+type SculkCatalystBloom struct{ sound }
+
+// SculkSpread is a sound played when sculk spreads to a block.
+type SculkSpread struct{ sound }
+
+// SculkSensorActivate is a sound played when a sculk sensor detects a vibration and becomes active.
+type SculkSensorActivate struct{ sound }
+
+// SculkSensorDeactivate is a sound played when an active sculk sensor stops being active.
+type SculkSensorDeactivate struct{ sound }
+
+// SculkShriek is a sound played when a sculk shrieker shrieks.
+type SculkShriek struct{ sound }
+
 // sound implements the world.Sound interface.
 type sound struct{}
 
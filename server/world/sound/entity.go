@@ -58,3 +58,45 @@ type FireworkBlast struct{ sound }
 
 // FireworkTwinkle is a sound played when a firework explodes and should twinkle.
 type FireworkTwinkle struct{ sound }
+
+// LeashKnotPlace is a sound played when an entity is leashed, or when a leash
+// knot is attached to a fence.
+type LeashKnotPlace struct{ sound }
+
+// LeashKnotBreak is a sound played when a leash knot is removed from a fence.
+type LeashKnotBreak struct{ sound }
+
+// GoatPreRam is a sound played when a goat lowers its head, about to ram.
+type GoatPreRam struct {
+	// Screaming specifies if the goat is a screaming goat.
+	Screaming bool
+
+	sound
+}
+
+// GoatRamImpact is a sound played when a ramming goat hits an entity or block.
+type GoatRamImpact struct {
+	// Screaming specifies if the goat is a screaming goat.
+	Screaming bool
+
+	sound
+}
+
+// GoatHornBreak is a sound played when a goat loses one of its horns.
+type GoatHornBreak struct{ sound }
+
+// ArmadilloBrush is a sound played when an armadillo is brushed.
+type ArmadilloBrush struct{ sound }
+
+// ArmadilloScuteDrop is a sound played when an armadillo sheds a scute.
+type ArmadilloScuteDrop struct{ sound }
+
+// WindChargeBurst is a sound played when a wind charge bursts.
+type WindChargeBurst struct{ sound }
+
+// Shear is a sound played when an entity, such as a bogged, is sheared.
+type Shear struct{ sound }
+
+// RaidHorn is a sound played when a new wave of raiders is about to spawn
+// during a raid.
+type RaidHorn struct{ sound }
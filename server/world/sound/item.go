@@ -96,3 +96,6 @@ type FireCharge struct{ sound }
 
 // Totem is a sound played when a player uses a totem.
 type Totem struct{ sound }
+
+// ShieldBlock is a sound played when a shield blocks an attack.
+type ShieldBlock struct{ sound }
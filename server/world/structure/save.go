@@ -0,0 +1,203 @@
+package structure
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/worldupgrader/blockupgrader"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+var (
+	dirMu sync.RWMutex
+	// dir is the directory set using SetDirectory.
+	dir string
+)
+
+// SetDirectory sets the directory on disk that structures are saved to and
+// loaded from using Save and Load. Structures are not saved to disk until a
+// directory is set.
+func SetDirectory(d string) {
+	dirMu.Lock()
+	defer dirMu.Unlock()
+	dir = d
+}
+
+// Save saves the structure passed to the directory set using SetDirectory
+// under the name passed. Names have the form "namespace:name", and names
+// without a namespace are saved in the "mystructure" namespace, like in
+// Bedrock Edition. A structure saved as "village:house" is written to the file
+// "village/house.mcstructure" in the directory.
+func Save(name string, s Structure) error {
+	path, err := structurePath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("save structure: %w", err)
+	}
+	return s.WriteFile(path)
+}
+
+// Load loads the structure with the name passed from the directory set using
+// SetDirectory. See Save for the form of the name.
+func Load(name string) (Structure, error) {
+	path, err := structurePath(name)
+	if err != nil {
+		return Structure{}, err
+	}
+	return ReadFile(path)
+}
+
+// structurePath returns the path of the file of the structure with the name
+// passed in the directory set using SetDirectory.
+func structurePath(name string) (string, error) {
+	dirMu.RLock()
+	d := dir
+	dirMu.RUnlock()
+	if d == "" {
+		return "", fmt.Errorf("structure %v: no structure directory set", name)
+	}
+	namespace, name, ok := strings.Cut(name, ":")
+	if !ok {
+		namespace, name = "mystructure", namespace
+	}
+	for _, part := range []string{namespace, name} {
+		if part == "" || part == "." || part == ".." || strings.ContainsAny(part, `/\:`) {
+			return "", fmt.Errorf("structure %v:%v: invalid name", namespace, name)
+		}
+	}
+	return filepath.Join(d, namespace, name+".mcstructure"), nil
+}
+
+// Capture creates a Structure from the blocks in the world.Tx passed, in the
+// area with its origin at the position passed that spans the size passed.
+// Structure void blocks in the area are saved as positions that leave the
+// blocks in the world untouched when the structure is placed. Block entity
+// data, such as the contents of chests, is saved along with the blocks.
+func Capture(tx *world.Tx, pos cube.Pos, size [3]int) Structure {
+	s := Structure{size: size, data: map[int]map[string]any{}}
+	volume := size[0] * size[1] * size[2]
+	s.indices = [2][]int32{make([]int32, volume), make([]int32, volume)}
+
+	palette := map[uint32]int32{}
+	index := func(b world.Block) int32 {
+		rid := world.BlockRuntimeID(b)
+		if i, ok := palette[rid]; ok {
+			return i
+		}
+		name, properties := b.EncodeBlock()
+		if name == "minecraft:structure_void" {
+			palette[rid] = -1
+			return -1
+		}
+		i := int32(len(s.states))
+		s.states = append(s.states, blockupgrader.BlockState{Name: name, Properties: properties, Version: chunk.CurrentBlockVersion})
+		palette[rid] = i
+		return i
+	}
+	for x := range size[0] {
+		for y := range size[1] {
+			for z := range size[2] {
+				i := (x*size[1]+y)*size[2] + z
+				p := pos.Add(cube.Pos{x, y, z})
+				b := tx.Block(p)
+				s.indices[0][i], s.indices[1][i] = index(b), -1
+				if liq, ok := tx.Liquid(p); ok && world.BlockRuntimeID(liq) != world.BlockRuntimeID(b) {
+					s.indices[1][i] = index(liq)
+				}
+				if nbter, ok := b.(world.NBTer); ok {
+					if data := nbter.EncodeNBT(); data != nil {
+						s.data[i] = blockEntityData(data)
+					}
+				}
+			}
+		}
+	}
+	s.resolvePalette()
+	return s
+}
+
+// WriteFile writes the structure to the file at the path passed in the
+// .mcstructure format. Any rotation and mirror applied to the structure are
+// applied to the blocks written.
+func (s Structure) WriteFile(path string) error {
+	var buf bytes.Buffer
+	if err := s.Write(&buf); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("write structure: %w", err)
+	}
+	return nil
+}
+
+// Write writes the structure to the io.Writer passed in the .mcstructure
+// format. Any rotation and mirror applied to the structure are applied to the
+// blocks written.
+func (s Structure) Write(w io.Writer) error {
+	if err := nbt.NewEncoderWithEncoding(w, nbt.LittleEndian).Encode(s.EncodeNBT()); err != nil {
+		return fmt.Errorf("encode structure: %w", err)
+	}
+	return nil
+}
+
+// EncodeNBT encodes the structure to its NBT representation, as used in
+// .mcstructure files. Any rotation and mirror applied to the structure are
+// applied to the blocks encoded.
+func (s Structure) EncodeNBT() map[string]any {
+	size := s.Dimensions()
+	volume := size[0] * size[1] * size[2]
+	indices := [2][]int32{make([]int32, volume), make([]int32, volume)}
+	positionData := map[string]any{}
+	for x := range size[0] {
+		for y := range size[1] {
+			for z := range size[2] {
+				i := (x*size[1]+y)*size[2] + z
+				srcX, srcZ := s.source(x, z)
+				src := (srcX*s.size[1]+y)*s.size[2] + srcZ
+				indices[0][i], indices[1][i] = s.indices[0][src], -1
+				if s.indices[1] != nil {
+					indices[1][i] = s.indices[1][src]
+				}
+				if data, ok := s.data[src]; ok {
+					positionData[strconv.Itoa(i)] = map[string]any{"block_entity_data": maps.Clone(data)}
+				}
+			}
+		}
+	}
+	t := transform{rotation: s.rotation, mirror: s.mirror}
+	palette := make([]any, 0, len(s.states))
+	for _, state := range s.states {
+		properties := t.properties(state.Name, state.Properties)
+		if properties == nil {
+			properties = map[string]any{}
+		}
+		palette = append(palette, map[string]any{"name": state.Name, "states": properties, "version": state.Version})
+	}
+	return map[string]any{
+		"format_version":         int32(1),
+		"size":                   []int32{int32(size[0]), int32(size[1]), int32(size[2])},
+		"structure_world_origin": []int32{0, 0, 0},
+		"structure": map[string]any{
+			"block_indices": [][]int32{indices[0], indices[1]},
+			"entities":      []any{},
+			"palette": map[string]any{
+				"default": map[string]any{
+					"block_palette":       palette,
+					"block_position_data": positionData,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,276 @@
+// Package structure implements structure templates that may be loaded from
+// Bedrock Edition .mcstructure files and placed in a world, optionally rotated
+// and mirrored.
+package structure
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+	"github.com/df-mc/worldupgrader/blockupgrader"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// Structure is a template of blocks that may be placed in a world using
+// world.Tx.BuildStructure or Structure.Place. A Structure implements the
+// world.Structure interface. The blocks of a Structure may be rotated and
+// mirrored using Structure.Rotate and Structure.Mirror.
+// Entities saved in a structure are not placed. Positions marked as structure
+// void, and blocks that are not registered, leave the blocks in the world
+// untouched.
+// A Structure may be obtained by calling Read or ReadFile.
+type Structure struct {
+	size [3]int
+	// states holds the block states of the palette of the structure, upgraded
+	// to the current version.
+	states []blockupgrader.BlockState
+	// indices holds the indices into the palette for the blocks in the first
+	// and second layer. An index of -1 is structure void.
+	indices [2][]int32
+	// data holds the block entity data of blocks in the structure by their
+	// index into indices.
+	data map[int]map[string]any
+
+	rotation Rotation
+	mirror   Mirror
+	// palette holds the blocks of the palette with the rotation and mirror
+	// of the structure applied.
+	palette []world.Block
+}
+
+// ReadFile reads a structure from the .mcstructure file at the path passed.
+func ReadFile(path string) (Structure, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Structure{}, fmt.Errorf("read structure: %w", err)
+	}
+	return Read(bytes.NewReader(b))
+}
+
+// Read reads a structure in the .mcstructure format from the io.Reader passed.
+// Loot tables referenced by containers in the structure are resolved through
+// the loot package, so that the containers are filled with loot when first
+// opened. References to loot tables that do not exist are removed.
+func Read(r io.Reader) (Structure, error) {
+	var m mcstructure
+	if err := nbt.NewDecoderWithEncoding(r, nbt.LittleEndian).Decode(&m); err != nil {
+		return Structure{}, fmt.Errorf("decode structure: %w", err)
+	}
+	if len(m.Size) != 3 || m.Size[0] <= 0 || m.Size[1] <= 0 || m.Size[2] <= 0 {
+		return Structure{}, fmt.Errorf("decode structure: invalid size %v", m.Size)
+	}
+	s := Structure{size: [3]int{int(m.Size[0]), int(m.Size[1]), int(m.Size[2])}, data: map[int]map[string]any{}}
+	volume := s.size[0] * s.size[1] * s.size[2]
+	if len(m.Structure.BlockIndices) == 0 {
+		return Structure{}, fmt.Errorf("decode structure: structure holds no blocks")
+	}
+	for layer, indices := range m.Structure.BlockIndices {
+		if layer > 1 {
+			break
+		}
+		if len(indices) != volume {
+			return Structure{}, fmt.Errorf("decode structure: layer %v holds %v blocks, expected %v", layer, len(indices), volume)
+		}
+		s.indices[layer] = indices
+	}
+	p := m.Structure.Palette["default"]
+	for _, state := range p.BlockPalette {
+		s.states = append(s.states, blockupgrader.Upgrade(blockupgrader.BlockState{
+			Name:       state.Name,
+			Properties: state.States,
+			Version:    state.Version,
+		}))
+	}
+	for _, indices := range s.indices {
+		for _, index := range indices {
+			if index < -1 || int(index) >= len(s.states) {
+				return Structure{}, fmt.Errorf("decode structure: palette index %v out of range", index)
+			}
+		}
+	}
+	for k, v := range p.BlockPositionData {
+		i, err := strconv.Atoi(k)
+		if err != nil || i < 0 || i >= volume {
+			continue
+		}
+		if data, ok := v["block_entity_data"].(map[string]any); ok {
+			s.data[i] = blockEntityData(data)
+		}
+	}
+	s.resolvePalette()
+	return s, nil
+}
+
+// mcstructure is the NBT layout of a .mcstructure file.
+type mcstructure struct {
+	FormatVersion int32   `nbt:"format_version"`
+	Size          []int32 `nbt:"size"`
+	Origin        []int32 `nbt:"structure_world_origin"`
+	Structure     struct {
+		BlockIndices [][]int32        `nbt:"block_indices"`
+		Entities     []map[string]any `nbt:"entities"`
+		Palette      map[string]struct {
+			BlockPalette []struct {
+				Name    string         `nbt:"name"`
+				States  map[string]any `nbt:"states"`
+				Version int32          `nbt:"version"`
+			} `nbt:"block_palette"`
+			BlockPositionData map[string]map[string]any `nbt:"block_position_data"`
+		} `nbt:"palette"`
+	} `nbt:"structure"`
+}
+
+// Dimensions returns the width, height and length of the structure, taking
+// its rotation into account.
+func (s Structure) Dimensions() [3]int {
+	if s.rotation%2 == 1 {
+		return [3]int{s.size[2], s.size[1], s.size[0]}
+	}
+	return s.size
+}
+
+// Rotate returns the structure rotated clockwise by the Rotation passed, in
+// addition to any rotation already applied. The facing of blocks such as
+// stairs and chests is rotated along with the structure.
+func (s Structure) Rotate(r Rotation) Structure {
+	s.rotation = (s.rotation + r) % 4
+	s.resolvePalette()
+	return s
+}
+
+// Mirror returns the structure mirrored using the Mirror passed. The structure
+// is mirrored before it is rotated, and only one Mirror is applied at a time:
+// Mirror replaces any Mirror previously applied.
+func (s Structure) Mirror(m Mirror) Structure {
+	s.mirror = m
+	s.resolvePalette()
+	return s
+}
+
+// At returns the block and liquid at the position passed, relative to the
+// origin of the structure.
+func (s Structure) At(x, y, z int, _ func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	x, z = s.source(x, z)
+	i := (x*s.size[1]+y)*s.size[2] + z
+	var b world.Block
+	if index := s.indices[0][i]; index != -1 {
+		b = s.palette[index]
+		if data, ok := s.data[i]; ok && b != nil {
+			if nbter, ok := b.(world.NBTer); ok {
+				b = nbter.DecodeNBT(maps.Clone(data)).(world.Block)
+			}
+		}
+	}
+	if s.indices[1] == nil {
+		return b, nil
+	}
+	if index := s.indices[1][i]; index != -1 {
+		if liq, ok := s.palette[index].(world.Liquid); ok {
+			return b, liq
+		}
+	}
+	return b, nil
+}
+
+// Place places the structure in the world.Tx passed, with its origin at the
+// position passed.
+func (s Structure) Place(tx *world.Tx, pos cube.Pos) {
+	tx.BuildStructure(pos, s)
+}
+
+// resolvePalette looks up the blocks of the palette of the structure, after
+// applying the rotation and mirror of the structure to their states.
+func (s *Structure) resolvePalette() {
+	s.palette = make([]world.Block, len(s.states))
+	t := transform{rotation: s.rotation, mirror: s.mirror}
+	for i, state := range s.states {
+		b, ok := world.BlockByName(state.Name, t.properties(state.Name, state.Properties))
+		if !ok {
+			// The transformed state might not exist for some blocks, in which
+			// case the original state is used.
+			b, _ = world.BlockByName(state.Name, state.Properties)
+		}
+		s.palette[i] = b
+	}
+}
+
+// blockEntityData prepares the block entity data passed for use in a
+// structure. Loot table references are resolved and data that only makes sense
+// at the original position of the block, such as the pairing of chests, is
+// removed.
+func blockEntityData(data map[string]any) map[string]any {
+	for _, k := range []string{"x", "y", "z", "pairlead", "pairx", "pairz"} {
+		delete(data, k)
+	}
+	if path, ok := data["LootTable"].(string); ok {
+		if path, ok = resolveLootTable(path); ok {
+			data["LootTable"] = path
+		} else {
+			delete(data, "LootTable")
+		}
+	}
+	return data
+}
+
+// resolveLootTable resolves the loot table path passed, as found in structure
+// files, to a path relative to the loot tables of the loot package. False is
+// returned if the loot table does not exist.
+func resolveLootTable(path string) (string, bool) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "minecraft:"), "loot_tables/")
+	if !strings.HasSuffix(path, ".json") {
+		path += ".json"
+	}
+	if _, err := loot.LoadTable(path); err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// Filter returns the structure with all blocks for which keep returns false
+// replaced with structure void, so that placing the structure leaves the
+// blocks in the world at their positions untouched. Liquids at the positions
+// of blocks removed are removed too. Filter may be used to only copy part of
+// an area, for example leaving out air:
+//
+//	s = s.Filter(func(b world.Block) bool {
+//		_, air := b.(block.Air)
+//		return !air
+//	})
+func (s Structure) Filter(keep func(b world.Block) bool) Structure {
+	kept := make([]bool, len(s.palette))
+	for i, b := range s.palette {
+		kept[i] = b != nil && keep(b)
+	}
+	indices := [2][]int32{slices.Clone(s.indices[0]), slices.Clone(s.indices[1])}
+	for i, index := range s.indices[0] {
+		if index == -1 || kept[index] {
+			continue
+		}
+		indices[0][i] = -1
+		if indices[1] != nil {
+			indices[1][i] = -1
+		}
+	}
+	s.indices = indices
+	return s
+}
+
+// BlockCount returns the number of positions in the structure that hold a
+// block, not counting structure void.
+func (s Structure) BlockCount() (n int) {
+	for _, index := range s.indices[0] {
+		if index != -1 {
+			n++
+		}
+	}
+	return n
+}
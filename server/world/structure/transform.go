@@ -0,0 +1,154 @@
+package structure
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// Rotation is a clockwise rotation of a Structure around the Y axis, in steps
+// of 90 degrees.
+type Rotation uint8
+
+const (
+	// Rotation0 leaves a Structure unrotated.
+	Rotation0 Rotation = iota
+	// Rotation90 rotates a Structure by 90 degrees clockwise.
+	Rotation90
+	// Rotation180 rotates a Structure by 180 degrees.
+	Rotation180
+	// Rotation270 rotates a Structure by 270 degrees clockwise.
+	Rotation270
+)
+
+// Mirror is a mirroring of a Structure along one of the horizontal axes.
+type Mirror uint8
+
+const (
+	// MirrorNone leaves a Structure unmirrored.
+	MirrorNone Mirror = iota
+	// MirrorX mirrors a Structure along the X axis, swapping its east and west
+	// sides.
+	MirrorX
+	// MirrorZ mirrors a Structure along the Z axis, swapping its north and
+	// south sides.
+	MirrorZ
+)
+
+// source returns the X and Z of the position in the unrotated and unmirrored
+// structure that ends up at the X and Z passed.
+func (s Structure) source(x, z int) (int, int) {
+	w, l := s.size[0], s.size[2]
+	switch s.rotation {
+	case Rotation90:
+		x, z = z, l-1-x
+	case Rotation180:
+		x, z = w-1-x, l-1-z
+	case Rotation270:
+		x, z = w-1-z, x
+	}
+	switch s.mirror {
+	case MirrorX:
+		x = w - 1 - x
+	case MirrorZ:
+		z = l - 1 - z
+	}
+	return x, z
+}
+
+// transform is a rotation and mirror applied to the block states of a
+// Structure.
+type transform struct {
+	rotation Rotation
+	mirror   Mirror
+}
+
+var (
+	// intFaceProperties maps the names of block properties holding a direction
+	// as an integer to the faces represented by their values.
+	intFaceProperties = map[string][]cube.Face{
+		"facing_direction": {cube.FaceDown, cube.FaceUp, cube.FaceNorth, cube.FaceSouth, cube.FaceWest, cube.FaceEast},
+		"weirdo_direction": {cube.FaceEast, cube.FaceWest, cube.FaceSouth, cube.FaceNorth},
+		"direction":        {cube.FaceSouth, cube.FaceWest, cube.FaceNorth, cube.FaceEast},
+	}
+	// stringFaceProperties holds the names of block properties holding a
+	// direction as the name of a face.
+	stringFaceProperties = []string{"minecraft:cardinal_direction", "minecraft:block_face", "minecraft:facing_direction"}
+)
+
+// properties returns the block properties passed, of the block with the name
+// passed, with the transform applied to any directions and axes they hold.
+func (t transform) properties(name string, properties map[string]any) map[string]any {
+	if t == (transform{}) {
+		return properties
+	}
+	properties = maps.Clone(properties)
+	for k, faces := range intFaceProperties {
+		if k == "direction" && strings.HasSuffix(name, "trapdoor") {
+			// Trapdoors use a different order of directions than other blocks
+			// with the direction property.
+			faces = intFaceProperties["weirdo_direction"]
+		}
+		v, ok := properties[k].(int32)
+		if !ok || int(v) >= len(faces) || v < 0 {
+			continue
+		}
+		f := t.face(faces[v])
+		for i, face := range faces {
+			if face == f {
+				properties[k] = int32(i)
+			}
+		}
+	}
+	for _, k := range stringFaceProperties {
+		v, ok := properties[k].(string)
+		if !ok {
+			continue
+		}
+		for _, f := range cube.Faces() {
+			if f.String() == v {
+				properties[k] = t.face(f).String()
+				break
+			}
+		}
+	}
+	if v, ok := properties["ground_sign_direction"].(int32); ok {
+		properties["ground_sign_direction"] = t.signDirection(v)
+	}
+	if v, ok := properties["pillar_axis"].(string); ok && t.rotation%2 == 1 {
+		switch v {
+		case "x":
+			properties["pillar_axis"] = "z"
+		case "z":
+			properties["pillar_axis"] = "x"
+		}
+	}
+	return properties
+}
+
+// face returns the face passed with the transform applied.
+func (t transform) face(f cube.Face) cube.Face {
+	if f.Axis() == cube.Y {
+		return f
+	}
+	if (t.mirror == MirrorX && f.Axis() == cube.X) || (t.mirror == MirrorZ && f.Axis() == cube.Z) {
+		f = f.Opposite()
+	}
+	for range t.rotation {
+		f = f.RotateRight()
+	}
+	return f
+}
+
+// signDirection returns the ground sign direction passed, which is a value in
+// the range 0-15 going clockwise from south, with the transform applied.
+func (t transform) signDirection(d int32) int32 {
+	switch t.mirror {
+	case MirrorX:
+		d = (16 - d) % 16
+	case MirrorZ:
+		d = (24 - d) % 16
+	}
+	return (d + int32(t.rotation)*4) % 16
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/internal/sliceutil"
+	"github.com/df-mc/dragonfly/server/metrics"
 )
 
 // ticker implements World ticking methods.
@@ -58,6 +59,7 @@ func (t ticker) tick(tx *Tx) {
 		if w.set.WeatherCycle {
 			w.advanceWeather()
 		}
+		w.set.Border = w.set.Border.tick()
 	}
 
 	rain, thunder, tick, tim, cycle := w.set.Raining, w.set.Thundering && w.set.Raining, w.set.CurrentTick, int(w.set.Time), w.set.TimeCycle
@@ -87,6 +89,7 @@ func (t ticker) tick(tx *Tx) {
 	if thunder {
 		w.tickLightning(tx)
 	}
+	w.traders.tick(tx)
 
 	t.tickEntities(tx, tick)
 	w.scheduledUpdates.tick(tx, tick)
@@ -95,11 +98,22 @@ func (t ticker) tick(tx *Tx) {
 }
 
 // performNeighbourUpdates performs all block updates that came as a result of a neighbouring block being changed.
+// Blocks changed by the updates are batched, so that a block changed more
+// than once during the updates, such as in large cascades of blocks losing
+// their support, only updates its neighbours once.
 func (t ticker) performNeighbourUpdates(tx *Tx) {
 	updates := slices.Clone(tx.World().neighbourUpdates)
 	clear(tx.World().neighbourUpdates)
 	tx.World().neighbourUpdates = tx.World().neighbourUpdates[:0]
+	clear(tx.World().queuedUpdates)
 
+	tx.BatchBlockUpdates(func() {
+		t.performUpdates(tx, updates)
+	})
+}
+
+// performUpdates performs the neighbour updates passed.
+func (t ticker) performUpdates(tx *Tx, updates []neighbourUpdate) {
 	for _, update := range updates {
 		pos, changedNeighbour := update.pos, update.neighbour
 		if ticker, ok := tx.Block(pos).(NeighbourUpdateTicker); ok {
@@ -121,6 +135,7 @@ func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
 		g             randUint4
 		blockEntities []cube.Pos
 		randomBlocks  []cube.Pos
+		precipitation []cube.Pos
 	)
 	if r == 0 {
 		// NOP if the simulation distance is 0.
@@ -142,8 +157,14 @@ func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
 			continue
 		}
 		blockEntities = append(blockEntities, slices.Collect(maps.Keys(c.BlockEntities))...)
+		c.inhabitedTime, c.modified = c.inhabitedTime+1, true
 
 		cx, cz := int(pos[0]<<4), int(pos[1]<<4)
+		if tx.World().r.IntN(16) == 0 {
+			// Every chunk has a 1/16 chance every tick of having a random column
+			// be affected by precipitation, freezing water or placing snow.
+			precipitation = append(precipitation, cube.Pos{cx + int(g.uint4(tx.World().r)), 0, cz + int(g.uint4(tx.World().r))})
+		}
 
 		// We generate up to j random positions for every sub chunk.
 		for j := 0; j < tx.World().conf.RandomTickSpeed; j++ {
@@ -169,10 +190,23 @@ func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
 		}
 	}
 
+	if metrics.Enabled() {
+		name := tx.World().Name()
+		metrics.RandomTicks(name, len(randomBlocks))
+		metrics.BlockEntities(name, len(blockEntities))
+	}
+
+	for _, pos := range precipitation {
+		tx.World().tickPrecipitation(tx, pos[0], pos[2])
+	}
 	for _, pos := range randomBlocks {
-		if rb, ok := tx.Block(pos).(RandomTicker); ok {
+		b := tx.Block(pos)
+		if rb, ok := b.(RandomTicker); ok {
 			rb.RandomTick(pos, tx, tx.World().r)
 		}
+		for _, f := range randomTickFuncsByRID[BlockRuntimeID(b)] {
+			f(pos, tx, tx.World().r)
+		}
 	}
 	for _, pos := range blockEntities {
 		if tb, ok := tx.Block(pos).(TickerBlock); ok {
@@ -0,0 +1,124 @@
+package trade
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+)
+
+// treasureEnchantment is an enchantment that cannot be obtained from an
+// enchanting table.
+type treasureEnchantment interface {
+	Treasure() bool
+}
+
+// enchantWithLevels enchants the stack passed as if it were enchanted in an
+// enchanting table using the number of levels passed. Treasure enchantments
+// are only selected if treasure is true.
+func enchantWithLevels(s item.Stack, levels int, treasure bool) item.Stack {
+	value := 1
+	if e, ok := s.Item().(item.Enchantable); ok {
+		value = e.EnchantmentValue()
+	}
+	cost := levels + 1 + rand.IntN(value/4+1) + rand.IntN(value/4+1)
+	cost = max(int(math.Round(float64(cost)*(1+(rand.Float64()+rand.Float64()-1)*0.15))), 1)
+
+	var available []item.Enchantment
+	for _, t := range item.Enchantments() {
+		if tr, ok := t.(treasureEnchantment); (ok && tr.Treasure() && !treasure) || !t.CompatibleWithItem(s.Item()) {
+			continue
+		}
+		for lvl := t.MaxLevel(); lvl > 0; lvl-- {
+			if minCost, maxCost := t.Cost(lvl); cost >= minCost && cost <= maxCost {
+				available = append(available, item.NewEnchantment(t, lvl))
+				break
+			}
+		}
+	}
+	for len(available) > 0 {
+		e := weightedEnchantment(available)
+		s = s.WithEnchantments(e)
+		available = slices.DeleteFunc(available, func(other item.Enchantment) bool {
+			return other.Type() == e.Type() || !e.Type().CompatibleWithEnchantment(other.Type())
+		})
+		if rand.IntN(50) > cost {
+			break
+		}
+		cost /= 2
+	}
+	return s
+}
+
+// weightedEnchantment returns a random enchantment from the enchantments
+// passed, favouring enchantments with a higher rarity weight.
+func weightedEnchantment(enchants []item.Enchantment) item.Enchantment {
+	total := 0
+	for _, e := range enchants {
+		total += e.Type().Rarity().Weight()
+	}
+	r := rand.IntN(total)
+	for _, e := range enchants {
+		if r -= e.Type().Rarity().Weight(); r < 0 {
+			return e
+		}
+	}
+	return enchants[len(enchants)-1]
+}
+
+// untradeableEnchantments holds enchantments that are never put on enchanted
+// books sold by traders.
+var untradeableEnchantments = []item.EnchantmentType{enchantment.SoulSpeed, enchantment.SwiftSneak, enchantment.WindBurst}
+
+// enchantBookForTrading turns the stack passed into an enchanted book with a
+// random enchantment of a random level. The price of the book in emeralds is
+// returned too, which is computed using the costs in the Function passed and
+// doubled for treasure enchantments.
+func enchantBookForTrading(s item.Stack, f Function) (item.Stack, int) {
+	types := slices.DeleteFunc(item.Enchantments(), func(t item.EnchantmentType) bool {
+		return slices.Contains(untradeableEnchantments, t)
+	})
+	if len(types) == 0 {
+		return s, 0
+	}
+	t := types[rand.IntN(len(types))]
+	lvl := 1 + rand.IntN(t.MaxLevel())
+
+	cost := f.BaseCost + rand.IntN(f.BaseRandomCost+lvl*f.PerLevelRandomCost+1) + f.PerLevelCost*lvl
+	if tr, ok := t.(treasureEnchantment); ok && tr.Treasure() {
+		cost *= 2
+	}
+	return item.NewStack(item.EnchantedBook{}, s.Count()).WithEnchantments(item.NewEnchantment(t, lvl)), cost
+}
+
+// randomDye dyes the leather armour passed in a random colour. Other items
+// are returned unchanged.
+func randomDye(s item.Stack) item.Stack {
+	colours := item.Colours()
+	tier := item.ArmourTierLeather{Colour: colours[rand.IntN(len(colours))].RGBA()}
+	switch it := s.Item().(type) {
+	case item.Helmet:
+		if _, ok := it.Tier.(item.ArmourTierLeather); ok {
+			it.Tier = tier
+			return s.WithItem(it)
+		}
+	case item.Chestplate:
+		if _, ok := it.Tier.(item.ArmourTierLeather); ok {
+			it.Tier = tier
+			return s.WithItem(it)
+		}
+	case item.Leggings:
+		if _, ok := it.Tier.(item.ArmourTierLeather); ok {
+			it.Tier = tier
+			return s.WithItem(it)
+		}
+	case item.Boots:
+		if _, ok := it.Tier.(item.ArmourTierLeather); ok {
+			it.Tier = tier
+			return s.WithItem(it)
+		}
+	}
+	return s
+}
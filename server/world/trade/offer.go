@@ -0,0 +1,129 @@
+package trade
+
+import (
+	"math"
+
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+// Offer is a trade offered by a trader, generated from a Trade in a Table.
+type Offer struct {
+	// Input holds the one or two stacks that the trader wants in exchange for
+	// Output. The count of the first stack is the base price of the offer,
+	// before it is adjusted using Price.
+	Input []item.Stack
+	// Output is the stack that the trader gives.
+	Output item.Stack
+	// PriceMultiplier is the multiplier used to adjust the price of the offer
+	// for demand and the reputation of the customer.
+	PriceMultiplier float64
+	// Tier is the tier of the Table that the offer was generated from.
+	Tier int
+	// TraderExp is the experience that the trader gains every time the offer
+	// is used.
+	TraderExp int
+	// MaxUses is the number of times the offer may be used before the trader
+	// has to restock. Uses is the number of times it was used since.
+	MaxUses, Uses int
+	// Demand is the demand for the offer. It grows when the offer is used
+	// often and raises the price of the offer.
+	Demand int
+	// RewardExp specifies if the customer is rewarded experience when using
+	// the offer.
+	RewardExp bool
+}
+
+// OutOfStock checks if the Offer has been used the maximum number of times
+// since the trader last restocked.
+func (o Offer) OutOfStock() bool {
+	return o.Uses >= o.MaxUses
+}
+
+// Price returns the count of the first Input of the Offer, adjusted for the
+// demand of the offer and the reputation of the customer. heroLevel is the
+// level of the Hero of the Village effect that the customer has, or 0 if it
+// does not have the effect. The price is always at least 1.
+func (o Offer) Price(reputation, heroLevel int) int {
+	if len(o.Input) == 0 {
+		return 0
+	}
+	base := o.Input[0].Count()
+	diff := max(int(math.Floor(float64(base*o.Demand)*o.PriceMultiplier)), 0)
+	diff -= int(math.Floor(float64(reputation) * o.PriceMultiplier))
+	if heroLevel > 0 {
+		diff -= max(int(math.Floor((0.3+0.0625*float64(heroLevel-1))*float64(base))), 1)
+	}
+	return min(max(base+diff, 1), o.Input[0].MaxCount())
+}
+
+// Adjusted returns a copy of the Offer with the count of its first Input set
+// to the price returned by Price.
+func (o Offer) Adjusted(reputation, heroLevel int) Offer {
+	if len(o.Input) == 0 {
+		return o
+	}
+	input := make([]item.Stack, len(o.Input))
+	copy(input, o.Input)
+	input[0] = input[0].Grow(o.Price(reputation, heroLevel) - input[0].Count())
+	o.Input = input
+	return o
+}
+
+// Restock resets the uses of the Offer and updates its demand. The demand
+// grows if the offer was used more than half of its maximum uses and shrinks
+// otherwise, but never drops below 0.
+func (o *Offer) Restock() {
+	o.Demand = max(o.Demand+o.Uses-(o.MaxUses-o.Uses), 0)
+	o.Uses = 0
+}
+
+// EncodeNBT encodes the Offer into a map in the format that offers are stored
+// in by Bedrock Edition.
+func (o Offer) EncodeNBT() map[string]any {
+	m := map[string]any{
+		"sell":             nbtconv.WriteItem(o.Output, true),
+		"priceMultiplierA": float32(o.PriceMultiplier),
+		"tier":             int32(o.Tier),
+		"traderExp":        int32(o.TraderExp),
+		"maxUses":          int32(o.MaxUses),
+		"uses":             int32(o.Uses),
+		"demand":           int32(o.Demand),
+		"rewardExp":        boolByte(o.RewardExp),
+	}
+	if len(o.Input) > 0 {
+		m["buyA"], m["buyCountA"] = nbtconv.WriteItem(o.Input[0], true), int32(o.Input[0].Count())
+	}
+	if len(o.Input) > 1 {
+		m["buyB"], m["buyCountB"] = nbtconv.WriteItem(o.Input[1], true), int32(o.Input[1].Count())
+	}
+	return m
+}
+
+// DecodeOffer decodes an Offer from a map encoded using Offer.EncodeNBT.
+func DecodeOffer(m map[string]any) Offer {
+	o := Offer{
+		Output:          nbtconv.MapItem(m, "sell"),
+		PriceMultiplier: float64(nbtconv.Float32(m, "priceMultiplierA")),
+		Tier:            int(nbtconv.Int32(m, "tier")),
+		TraderExp:       int(nbtconv.Int32(m, "traderExp")),
+		MaxUses:         int(nbtconv.Int32(m, "maxUses")),
+		Uses:            int(nbtconv.Int32(m, "uses")),
+		Demand:          int(nbtconv.Int32(m, "demand")),
+		RewardExp:       nbtconv.Bool(m, "rewardExp"),
+	}
+	for _, k := range []string{"buyA", "buyB"} {
+		if s := nbtconv.MapItem(m, k); !s.Empty() {
+			o.Input = append(o.Input, s)
+		}
+	}
+	return o
+}
+
+// boolByte returns 1 if the bool passed is true, or 0 if it is false.
+func boolByte(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,241 @@
+package trade
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/loot"
+)
+
+//go:embed trade_tables/*
+var tradeFS embed.FS
+
+var (
+	tablesMu sync.RWMutex
+	tables   = map[string]Table{}
+)
+
+// Register registers a Table under the path passed, such as
+// "economy_trades/farmer_trades.json". Tables registered this way take
+// precedence over the tables embedded in the package, so that the trades of
+// any trader may be replaced without touching the entity code.
+func Register(path string, t Table) {
+	tablesMu.Lock()
+	defer tablesMu.Unlock()
+	tables[path] = t
+}
+
+// LoadTable returns the Table registered under the path passed. If no table
+// was registered using Register, the table is read from the embedded
+// trade_tables folder, with the path being relative to that folder.
+func LoadTable(path string) (Table, error) {
+	tablesMu.RLock()
+	t, ok := tables[path]
+	tablesMu.RUnlock()
+	if ok {
+		return t, nil
+	}
+	b, err := tradeFS.ReadFile("trade_tables/" + path)
+	if err != nil {
+		return Table{}, err
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return Table{}, fmt.Errorf("decode trade table %v: %w", path, err)
+	}
+	tablesMu.Lock()
+	tables[path] = t
+	tablesMu.Unlock()
+	return t, nil
+}
+
+// Table is a trade table in the format used by the trade tables of Bedrock
+// Edition. A Table holds tiers of trades that a trader unlocks as it gains
+// experience by trading.
+type Table struct {
+	Tiers []Tier `json:"tiers"`
+}
+
+// Tier is a tier of trades in a Table. A trader unlocks the trades of a tier
+// once it has at least TotalExpRequired experience.
+type Tier struct {
+	// TotalExpRequired is the experience that a trader needs to unlock the
+	// trades in the tier.
+	TotalExpRequired int `json:"total_exp_required"`
+	// Groups holds groups of trades of which a random selection is offered.
+	Groups []Group `json:"groups"`
+	// Trades holds trades that are always offered once the tier is unlocked.
+	Trades []Trade `json:"trades"`
+}
+
+// Group is a group of trades in a Tier, of which NumToSelect random trades
+// are offered. If NumToSelect is 0, all trades in the group are offered.
+type Group struct {
+	NumToSelect int     `json:"num_to_select"`
+	Trades      []Trade `json:"trades"`
+}
+
+// Trade is a single trade in a Table, from which an Offer is generated.
+type Trade struct {
+	// Wants holds the one or two items that the trader wants in exchange for
+	// the items in Gives.
+	Wants []Item `json:"wants"`
+	// Gives holds the item that the trader gives.
+	Gives []Item `json:"gives"`
+	// TraderExp is the experience that the trader gains every time the trade
+	// is used.
+	TraderExp int `json:"trader_exp"`
+	// MaxUses is the number of times the trade may be used before the trader
+	// has to restock.
+	MaxUses int `json:"max_uses"`
+	// RewardExp specifies if the customer is rewarded experience when using
+	// the trade.
+	RewardExp bool `json:"reward_exp"`
+}
+
+// Item is an item wanted or given in a Trade.
+type Item struct {
+	// Item is the name of the item, such as "minecraft:emerald".
+	Item string `json:"item"`
+	// Quantity is the count of the item. A random count between the minimum
+	// and maximum is picked when an Offer is generated. The count is 1 if
+	// Quantity is not set.
+	Quantity loot.Value `json:"quantity"`
+	// PriceMultiplier is the multiplier used to adjust the price of the item
+	// for demand and the reputation of the customer.
+	PriceMultiplier float64 `json:"price_multiplier"`
+	// Functions holds functions applied to the item when an Offer is
+	// generated.
+	Functions []Function `json:"functions"`
+}
+
+// Function is a function applied to an Item when an Offer is generated.
+// Supported functions are enchant_with_levels, enchant_book_for_trading and
+// random_dye.
+type Function struct {
+	Function string `json:"function"`
+	// Levels and Treasure are used by enchant_with_levels.
+	Levels   loot.Value `json:"levels"`
+	Treasure bool       `json:"treasure"`
+	// BaseCost, BaseRandomCost, PerLevelRandomCost and PerLevelCost are used
+	// by enchant_book_for_trading to compute the price of the book.
+	BaseCost           int `json:"base_cost"`
+	BaseRandomCost     int `json:"base_random_cost"`
+	PerLevelRandomCost int `json:"per_level_random_cost"`
+	PerLevelCost       int `json:"per_level_cost"`
+}
+
+// Tier returns the index of the highest tier that a trader with the
+// experience passed has unlocked.
+func (t Table) Tier(experience int) int {
+	tier := 0
+	for i, ti := range t.Tiers {
+		if experience >= ti.TotalExpRequired {
+			tier = i
+		}
+	}
+	return tier
+}
+
+// Offers generates the offers of the tier passed. Trades in a Group of the
+// tier are picked at random.
+func (t Table) Offers(tier int) []Offer {
+	if tier < 0 || tier >= len(t.Tiers) {
+		return nil
+	}
+	ti := t.Tiers[tier]
+	offers := make([]Offer, 0, len(ti.Trades))
+	for _, tr := range ti.Trades {
+		if o, ok := tr.Offer(tier); ok {
+			offers = append(offers, o)
+		}
+	}
+	for _, g := range ti.Groups {
+		trades := g.Trades
+		if g.NumToSelect > 0 && g.NumToSelect < len(trades) {
+			trades = make([]Trade, len(g.Trades))
+			for i, j := range rand.Perm(len(g.Trades)) {
+				trades[i] = g.Trades[j]
+			}
+			trades = trades[:g.NumToSelect]
+		}
+		for _, tr := range trades {
+			if o, ok := tr.Offer(tier); ok {
+				offers = append(offers, o)
+			}
+		}
+	}
+	return offers
+}
+
+// Offer generates an Offer of the tier passed from the Trade. False is
+// returned if the Trade holds unknown items.
+func (tr Trade) Offer(tier int) (Offer, bool) {
+	if len(tr.Wants) == 0 || len(tr.Gives) == 0 {
+		return Offer{}, false
+	}
+	o := Offer{
+		PriceMultiplier: tr.Wants[0].PriceMultiplier,
+		Tier:            tier,
+		TraderExp:       tr.TraderExp,
+		MaxUses:         tr.MaxUses,
+		RewardExp:       tr.RewardExp,
+	}
+	// The price of an enchanted book depends on the enchantment on it, so the
+	// output is generated before the input.
+	price := 0
+	output, ok := tr.Gives[0].stack(&price)
+	if !ok {
+		return Offer{}, false
+	}
+	o.Output = output
+	for i, want := range tr.Wants {
+		if i >= 2 {
+			break
+		}
+		s, ok := want.stack(nil)
+		if !ok {
+			return Offer{}, false
+		}
+		if i == 0 && price > 0 {
+			s = s.Grow(min(price, s.MaxCount()) - s.Count())
+		}
+		o.Input = append(o.Input, s)
+	}
+	return o, true
+}
+
+// stack creates an item.Stack from the Item and applies its functions. If the
+// price passed is non-nil, it is set to the price of an enchanted book created
+// using the enchant_book_for_trading function.
+func (i Item) stack(price *int) (item.Stack, bool) {
+	name := i.Item
+	if !strings.Contains(name, ":") {
+		name = "minecraft:" + name
+	}
+	it, ok := world.ItemByName(name, 0)
+	if !ok {
+		return item.Stack{}, false
+	}
+	s := item.NewStack(it, max(loot.RollValue(i.Quantity), 1))
+	for _, f := range i.Functions {
+		switch f.Function {
+		case "enchant_with_levels":
+			s = enchantWithLevels(s, loot.RollValue(f.Levels), f.Treasure)
+		case "enchant_book_for_trading":
+			var cost int
+			s, cost = enchantBookForTrading(s, f)
+			if price != nil {
+				*price = cost
+			}
+		case "random_dye":
+			s = randomDye(s)
+		}
+	}
+	return s, true
+}
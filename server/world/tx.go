@@ -43,6 +43,25 @@ func (tx *Tx) SetBlock(pos cube.Pos, b Block, opts *SetOpts) {
 	tx.World().setBlock(pos, b, opts)
 }
 
+// BatchBlockUpdates calls the function passed and defers the neighbour
+// updates caused by SetBlock calls made within it until it returns. A position
+// changed more than once within f only updates its neighbours once, after f
+// returns, which prevents update storms when many blocks are changed, for
+// example when a block supporting many others is removed. Blocks are still
+// changed immediately, so Block returns the new blocks within f. Calls to
+// BatchBlockUpdates may be nested, in which case the updates are performed
+// when the outermost call returns.
+func (tx *Tx) BatchBlockUpdates(f func()) {
+	w := tx.World()
+	w.batchDepth++
+	defer func() {
+		if w.batchDepth--; w.batchDepth == 0 {
+			w.flushBatchedChanges()
+		}
+	}()
+	f()
+}
+
 // Block reads a block from the position passed. If a chunk is not yet loaded
 // at that position, the chunk is loaded, or generated if it could not be found
 // in the world save, and the block returned.
@@ -117,6 +136,12 @@ func (tx *Tx) SkyLight(pos cube.Pos) uint8 {
 	return tx.World().skyLight(pos)
 }
 
+// BlockLight returns the light level emitted by blocks, such as torches, at
+// the position passed. Unlike Light, this value is not influenced by skylight.
+func (tx *Tx) BlockLight(pos cube.Pos) uint8 {
+	return tx.World().blockLight(pos)
+}
+
 // SetBiome sets the Biome at the position passed. If a chunk is not yet loaded
 // at that position, the chunk is first loaded or generated if it could not be
 // found in the world save.
@@ -189,6 +214,13 @@ func (tx *Tx) PlaySound(pos mgl64.Vec3, s Sound) {
 	tx.World().playSound(tx, pos, s)
 }
 
+// EmitVibration emits a Vibration in the World. VibrationListener blocks in
+// loaded chunks within range of the position of the Vibration detect it,
+// unless a VibrationOccluder such as wool is in between.
+func (tx *Tx) EmitVibration(v Vibration) {
+	tx.World().emitVibration(tx, v)
+}
+
 // AddEntity adds an EntityHandle to a World. The Entity will be visible to all
 // viewers of the World that have the chunk at the EntityHandle's position. If
 // the chunk that the EntityHandle is in is not yet loaded, it will first be
@@ -277,6 +309,18 @@ func (tx *Tx) BroadcastSleepingReminder(sleeper Sleeper) {
 	}
 }
 
+// LocateStructure looks up the position of the structure with the name
+// passed, such as "buriedtreasure", that is closest to the position passed.
+// False is returned if the Generator of the World does not implement
+// StructureLocator or if no such structure was found.
+func (tx *Tx) LocateStructure(structure string, pos cube.Pos) (cube.Pos, bool) {
+	l, ok := tx.World().conf.Generator.(StructureLocator)
+	if !ok {
+		return cube.Pos{}, false
+	}
+	return l.LocateStructure(tx, structure, pos)
+}
+
 // World returns the World of the Tx. It panics if the transaction was already
 // marked complete.
 func (tx *Tx) World() *World {
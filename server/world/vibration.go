@@ -0,0 +1,140 @@
+package world
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// VibrationType is the type of a Vibration. Every type has a frequency from
+// 1-15, which sculk sensors output as a comparator signal after detecting it
+// and which calibrated sculk sensors may be tuned to.
+type VibrationType struct {
+	name      string
+	frequency int
+}
+
+// Name returns the name of the VibrationType, for example 'block_place'.
+func (t VibrationType) Name() string {
+	return t.name
+}
+
+// Frequency returns the frequency of the VibrationType, from 1-15.
+func (t VibrationType) Frequency() int {
+	return t.frequency
+}
+
+var (
+	// VibrationStep is emitted by an entity stepping on a block while not
+	// sneaking.
+	VibrationStep = VibrationType{name: "step", frequency: 1}
+	// VibrationProjectileLand is emitted by a projectile landing on a block.
+	VibrationProjectileLand = VibrationType{name: "projectile_land", frequency: 2}
+	// VibrationContainerClose is emitted by an entity closing a container.
+	VibrationContainerClose = VibrationType{name: "container_close", frequency: 9}
+	// VibrationContainerOpen is emitted by an entity opening a container.
+	VibrationContainerOpen = VibrationType{name: "container_open", frequency: 10}
+	// VibrationBlockDestroy is emitted by a block being broken.
+	VibrationBlockDestroy = VibrationType{name: "block_destroy", frequency: 12}
+	// VibrationBlockPlace is emitted by a block being placed.
+	VibrationBlockPlace = VibrationType{name: "block_place", frequency: 13}
+)
+
+// Vibration is a vibration emitted in a World using Tx.EmitVibration. It is
+// detected by VibrationListeners within range of it.
+type Vibration struct {
+	// Type is the VibrationType of the vibration, which holds its frequency.
+	Type VibrationType
+	// Pos is the position at which the vibration was emitted.
+	Pos mgl64.Vec3
+	// Source is the entity that caused the vibration, such as the player that
+	// placed a block or the owner of a projectile. It is nil if the vibration
+	// was not caused by an entity.
+	Source Entity
+}
+
+// VibrationListener is an implementation of NBTer with additional methods
+// that are called for vibrations emitted near the block. Like TickerBlocks,
+// blocks register as a listener by implementing this interface, after which
+// every loaded block of the type detects vibrations.
+type VibrationListener interface {
+	NBTer
+	// VibrationRange returns the distance in blocks, up to 16, within which the
+	// block detects vibrations.
+	VibrationRange() int
+	// ListenVibration is called when a Vibration is emitted within range of the
+	// block at the position passed, unless a VibrationOccluder is in between.
+	ListenVibration(pos cube.Pos, tx *Tx, v Vibration)
+}
+
+// VibrationOccluder represents a block that vibrations cannot travel
+// through, such as wool.
+type VibrationOccluder interface {
+	// OccludesVibrations returns true if vibrations cannot travel through the
+	// block.
+	OccludesVibrations() bool
+}
+
+// maxVibrationRange is the maximum VibrationRange of a VibrationListener.
+const maxVibrationRange = 16
+
+// emitVibration notifies all VibrationListeners in loaded chunks that are in
+// range of the Vibration passed, from closest to furthest.
+func (w *World) emitVibration(tx *Tx, v Vibration) {
+	var listeners []cube.Pos
+	low := chunkPosFromVec3(v.Pos.Sub(mgl64.Vec3{maxVibrationRange, 0, maxVibrationRange}))
+	high := chunkPosFromVec3(v.Pos.Add(mgl64.Vec3{maxVibrationRange, 0, maxVibrationRange}))
+	for x := low[0]; x <= high[0]; x++ {
+		for z := low[1]; z <= high[1]; z++ {
+			c, ok := w.chunks[ChunkPos{x, z}]
+			if !ok {
+				continue
+			}
+			for pos, b := range c.BlockEntities {
+				if _, ok := b.(VibrationListener); ok {
+					listeners = append(listeners, pos)
+				}
+			}
+		}
+	}
+	slices.SortFunc(listeners, func(a, b cube.Pos) int {
+		return cmp.Compare(a.Vec3Centre().Sub(v.Pos).LenSqr(), b.Vec3Centre().Sub(v.Pos).LenSqr())
+	})
+	for _, pos := range listeners {
+		// Listeners notified earlier may have changed the blocks around, so
+		// the block is looked up again.
+		l, ok := tx.Block(pos).(VibrationListener)
+		if !ok {
+			continue
+		}
+		centre := pos.Vec3Centre()
+		if centre.Sub(v.Pos).Len() > float64(min(l.VibrationRange(), maxVibrationRange)) || vibrationOccluded(tx, v.Pos, pos) {
+			continue
+		}
+		l.ListenVibration(pos, tx, v)
+	}
+}
+
+// vibrationOccluded checks if a VibrationOccluder is found on the line from
+// the position passed to the centre of the listener at the position passed.
+// The blocks at both ends of the line are not checked.
+func vibrationOccluded(tx *Tx, from mgl64.Vec3, listener cube.Pos) bool {
+	const step = 0.125
+
+	start, dir := cube.PosFromVec3(from), listener.Vec3Centre().Sub(from)
+	n := int(dir.Len() / step)
+	last := start
+	for i := 1; i < n; i++ {
+		pos := cube.PosFromVec3(from.Add(dir.Mul(float64(i) / float64(n))))
+		if pos == last || pos == start || pos == listener {
+			continue
+		}
+		last = pos
+		if o, ok := tx.Block(pos).(VibrationOccluder); ok && o.OccludesVibrations() {
+			return true
+		}
+	}
+	return false
+}
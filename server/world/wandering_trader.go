@@ -0,0 +1,114 @@
+package world
+
+import (
+	"slices"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+const (
+	// wanderingTraderSpawnInterval is the number of ticks between two attempts
+	// to spawn a wandering trader.
+	wanderingTraderSpawnInterval = 24000
+	// wanderingTraderDespawnDelay is the time after which a wandering trader
+	// spawned by a wanderingTraderSpawner despawns again.
+	wanderingTraderDespawnDelay = time.Minute * 40
+	// wanderingTraderSpawnRadius is the horizontal distance in blocks from a
+	// player within which wandering traders are spawned.
+	wanderingTraderSpawnRadius = 48
+)
+
+// wanderingTraderSpawner periodically attempts to spawn a wandering trader,
+// along with two trader llamas, near a random player in the World. The chance
+// that an attempt succeeds starts at 25% and grows by 25% with every failed
+// attempt, up to 75%.
+type wanderingTraderSpawner struct {
+	// ticks is the number of ticks passed since the last attempt to spawn a
+	// wandering trader.
+	ticks int64
+	// failures is the number of attempts in a row that failed to spawn a
+	// wandering trader.
+	failures int
+}
+
+// tick attempts to spawn a wandering trader once every spawn interval.
+func (s *wanderingTraderSpawner) tick(tx *Tx) {
+	if s.ticks++; s.ticks < wanderingTraderSpawnInterval {
+		return
+	}
+	s.ticks = 0
+
+	w := tx.World()
+	chance := min(25*(s.failures+1), 75)
+	if w.r.IntN(100) >= chance || !s.spawn(tx) {
+		s.failures++
+		return
+	}
+	s.failures = 0
+}
+
+// spawn spawns a wandering trader and two trader llamas leashed to it near a
+// random player in the World. False is returned if no trader was spawned.
+func (s *wanderingTraderSpawner) spawn(tx *Tx) bool {
+	w := tx.World()
+	conf := w.conf.Entities.conf
+	if w.Dimension() != Overworld || conf.WanderingTrader == nil {
+		return false
+	}
+	players := slices.Collect(tx.Players())
+	if len(players) == 0 || w.r.IntN(10) != 0 {
+		return false
+	}
+	pos, ok := s.spawnPosition(tx, cube.PosFromVec3(players[w.r.IntN(len(players))].Position()), wanderingTraderSpawnRadius)
+	if !ok {
+		return false
+	}
+	trader := tx.AddEntity(conf.WanderingTrader(EntitySpawnOpts{Position: pos.Vec3Middle()}, wanderingTraderDespawnDelay))
+	if conf.TraderLlama == nil {
+		return true
+	}
+	for range 2 {
+		if llamaPos, ok := s.spawnPosition(tx, pos, 4); ok {
+			tx.AddEntity(conf.TraderLlama(EntitySpawnOpts{Position: llamaPos.Vec3Middle()}, trader))
+		}
+	}
+	return true
+}
+
+// spawnPosition looks for a position on the surface within the horizontal
+// radius passed around pos where an entity can be spawned. False is returned
+// if no such position was found after ten attempts.
+func (s *wanderingTraderSpawner) spawnPosition(tx *Tx, pos cube.Pos, radius int) (cube.Pos, bool) {
+	w := tx.World()
+	for range 10 {
+		x, z := pos[0]+w.r.IntN(radius*2+1)-radius, pos[2]+w.r.IntN(radius*2+1)-radius
+		candidate := cube.Pos{x, tx.HighestBlock(x, z) + 1, z}
+		if s.canSpawnAt(tx, candidate) {
+			return candidate, true
+		}
+	}
+	return cube.Pos{}, false
+}
+
+// canSpawnAt checks if an entity two blocks high can be spawned at the
+// position passed: It must stand on a solid block and have room to stand
+// without being in a liquid.
+func (s *wanderingTraderSpawner) canSpawnAt(tx *Tx, pos cube.Pos) bool {
+	if pos.OutOfBounds(tx.Range()) || pos.Side(cube.FaceUp).OutOfBounds(tx.Range()) {
+		return false
+	}
+	below := pos.Side(cube.FaceDown)
+	if !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+		return false
+	}
+	for _, p := range []cube.Pos{pos, pos.Side(cube.FaceUp)} {
+		if len(tx.Block(p).Model().BBox(p, tx)) != 0 {
+			return false
+		}
+		if _, ok := tx.Liquid(p); ok {
+			return false
+		}
+	}
+	return true
+}
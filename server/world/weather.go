@@ -1,9 +1,11 @@
 package world
 
 import (
+	"math"
 	"time"
 
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/go-gl/mathgl/mgl64"
 )
 
@@ -119,6 +121,16 @@ func (w weather) StartThundering(dur time.Duration) {
 	w.setRaining(true, dur)
 }
 
+// ClearWeather makes it stop raining and thundering in the World. The
+// time.Duration passed determines how long the weather will stay clear before
+// the weather cycle may start rain or thunder again.
+func (w weather) ClearWeather(dur time.Duration) {
+	w.w.set.Lock()
+	defer w.w.set.Unlock()
+	w.setRaining(false, dur)
+	w.setThunder(false, dur)
+}
+
 // StopThundering makes it stop thundering in the current world.
 func (w weather) StopThundering() {
 	w.w.set.Lock()
@@ -185,6 +197,80 @@ func (w weather) enableWeatherCycle(v bool) {
 	w.w.set.WeatherCycle = v
 }
 
+// snowAccumulationHeight is the maximum number of snow layers that
+// accumulate on the ground during snowfall.
+const snowAccumulationHeight = 4
+
+// tickPrecipitation forms ice on top of water and, if it is snowing, snow
+// layers on the ground at the top of the column at the x and z passed. Both
+// only happen if the temperature at that position, which depends on the biome
+// and altitude, is low enough and if no bright light source is nearby. Snow
+// layers that are already on the ground grow while it keeps snowing.
+func (w weather) tickPrecipitation(tx *Tx, x, z int) {
+	if w.w == nil || !w.w.Dimension().WeatherCycle() {
+		return
+	}
+	top := cube.Pos{x, w.w.highestBlock(x, z), z}
+	if top.OutOfBounds(w.w.Range()) || w.w.temperature(top) >= 0.15 || w.w.blockLight(top.Side(cube.FaceUp)) >= 10 {
+		return
+	}
+	if w.shouldFreeze(tx, top) {
+		if ice, ok := BlockByName("minecraft:ice", nil); ok {
+			w.w.setBlock(top, ice, nil)
+		}
+		return
+	}
+	above := top.Side(cube.FaceUp)
+	if !w.snowingAt(above) || above.OutOfBounds(w.w.Range()) {
+		return
+	}
+	if name, properties := tx.Block(top).EncodeBlock(); name == "minecraft:snow_layer" {
+		// Snow keeps accumulating on existing snow layers for as long as it
+		// snows, up to snowAccumulationHeight layers.
+		if height, _ := properties["height"].(int32); height+1 < snowAccumulationHeight {
+			properties["height"] = height + 1
+			if snow, ok := BlockByName(name, properties); ok {
+				w.w.setBlock(top, snow, nil)
+			}
+		}
+		return
+	}
+	if name, _ := tx.Block(above).EncodeBlock(); name != "minecraft:air" {
+		return
+	}
+	below := tx.Block(top)
+	if name, _ := below.EncodeBlock(); name == "minecraft:ice" || name == "minecraft:packed_ice" || name == "minecraft:barrier" {
+		return
+	}
+	if !below.Model().FaceSolid(top, cube.FaceUp, tx) {
+		return
+	}
+	if snow, ok := BlockByName("minecraft:snow_layer", map[string]any{"height": int32(0), "covered_bit": uint8(0)}); ok {
+		w.w.setBlock(above, snow, nil)
+	}
+}
+
+// shouldFreeze checks if the block at the position passed is a water source
+// block that borders at least one block that is not water, and may thus be
+// frozen into ice.
+func (w weather) shouldFreeze(tx *Tx, pos cube.Pos) bool {
+	if !isWaterSource(tx.Block(pos)) {
+		return false
+	}
+	for _, face := range cube.HorizontalFaces() {
+		if !isWaterSource(tx.Block(pos.Side(face))) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWaterSource checks if the Block passed is a still water source block.
+func isWaterSource(b Block) bool {
+	l, ok := b.(Liquid)
+	return ok && l.LiquidType() == "water" && l.LiquidDepth() == 8 && !l.LiquidFalling()
+}
+
 // tickLightning iterates over all loaded chunks in the World, striking
 // lightning in each one with a 1/100,000 chance.
 func (w weather) tickLightning(tx *Tx) {
@@ -216,12 +302,17 @@ func (w weather) strikeLightning(tx *Tx, c ChunkPos) {
 }
 
 // lightningPosition finds a random position in the ChunkPos to strike
-// lightning and adjusts the position to any of the living entities found in or
-// above the position if any are found.
+// lightning and adjusts the position to the nearest LightningConductor, or to
+// any of the living entities found in or above the position if any are found.
 func (w weather) lightningPosition(tx *Tx, c ChunkPos) mgl64.Vec3 {
 	v := w.w.r.Int32()
 	x, z := float64(c[0]<<4+(v&0xf)), float64(c[1]<<4+((v>>8)&0xf))
 
+	if pos, ok := w.nearestConductor(tx, cube.Pos{int(x), 0, int(z)}); ok {
+		// Lightning conductors, such as lightning rods, take priority over
+		// any entities near the lightning strike.
+		return pos.Side(cube.FaceUp).Vec3Middle()
+	}
 	vec := w.adjustPositionToEntities(tx, mgl64.Vec3{x, float64(tx.HighestBlock(int(x), int(z)) + 1), z})
 	if pos := cube.PosFromVec3(vec); len(tx.Block(pos).Model().BBox(pos, tx)) != 0 {
 		// If lightning is about to strike inside a block that is not fully
@@ -232,6 +323,67 @@ func (w weather) lightningPosition(tx *Tx, c ChunkPos) mgl64.Vec3 {
 	return vec
 }
 
+// lightningConductorRange is the horizontal distance in blocks within which a
+// LightningConductor attracts lightning.
+const lightningConductorRange = 64
+
+// nearestConductor returns the position of the LightningConductor nearest to
+// the X and Z of the cube.Pos passed, within lightningConductorRange blocks.
+// Only conductors that are the highest block in their column attract
+// lightning. Sub chunks are only searched if their palette holds a
+// LightningConductor.
+func (w weather) nearestConductor(tx *Tx, pos cube.Pos) (cube.Pos, bool) {
+	var (
+		nearest cube.Pos
+		found   bool
+		dist    = math.Inf(1)
+		r       = cube.Pos{lightningConductorRange, 0, lightningConductorRange}
+	)
+	minChunk, maxChunk := chunkPosFromBlockPos(pos.Sub(r)), chunkPosFromBlockPos(pos.Add(r))
+	for cx := minChunk[0]; cx <= maxChunk[0]; cx++ {
+		for cz := minChunk[1]; cz <= maxChunk[1]; cz++ {
+			c, ok := w.w.chunks[ChunkPos{cx, cz}]
+			if !ok {
+				continue
+			}
+			for i, sub := range c.Sub() {
+				if sub.Empty() || !paletteHasConductor(sub.Layer(0).Palette()) {
+					continue
+				}
+				subY := (i + (tx.Range().Min() >> 4)) << 4
+				for x := byte(0); x < 16; x++ {
+					for y := byte(0); y < 16; y++ {
+						for z := byte(0); z < 16; z++ {
+							if !conductorBlocks[sub.Layer(0).At(x, y, z)] {
+								continue
+							}
+							p := cube.Pos{int(cx)<<4 + int(x), subY + int(y), int(cz)<<4 + int(z)}
+							if tx.HighestBlock(p[0], p[2]) != p[1] {
+								continue
+							}
+							if d := math.Hypot(float64(p[0]-pos[0]), float64(p[2]-pos[2])); d <= lightningConductorRange && d < dist {
+								nearest, found, dist = p, true, d
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return nearest, found
+}
+
+// paletteHasConductor checks if any of the blocks in the chunk.Palette passed
+// is a LightningConductor.
+func paletteHasConductor(p *chunk.Palette) bool {
+	for i := 0; i < p.Len(); i++ {
+		if conductorBlocks[p.Value(uint16(i))] {
+			return true
+		}
+	}
+	return false
+}
+
 // adjustPositionToEntities adjusts the mgl64.Vec3 passed to the position of
 // any Entity found in the 3x3 column upwards from the mgl64.Vec3. If multiple
 // entities are found, the position of one of the entities is selected
@@ -246,7 +398,7 @@ func (w weather) adjustPositionToEntities(tx *Tx, vec mgl64.Vec3) mgl64.Vec3 {
 			// block at its position is eligible to be struck by lightning. We
 			// first save all entity positions where this is the case.
 			pos := cube.PosFromVec3(e.Position())
-			if tx.HighestBlock(pos[0], pos[1]) < pos[2] {
+			if tx.HighestBlock(pos[0], pos[2]) < pos[1] {
 				list = append(list, e.Position())
 			}
 		}
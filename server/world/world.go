@@ -44,6 +44,7 @@ type World struct {
 	handler atomic.Pointer[Handler]
 
 	weather
+	traders wanderingTraderSpawner
 
 	closing chan struct{}
 	running sync.WaitGroup
@@ -59,12 +60,26 @@ type World struct {
 
 	r *rand.Rand
 
+	// maps holds all maps that were created in or loaded by the World,
+	// indexed by their ID.
+	maps map[int64]*mapEntry
+
 	// scheduledUpdates is a map of tick time values indexed by the block
 	// position at which an update is scheduled. If the current tick exceeds the
 	// tick value passed, the block update will be performed and the entry will
 	// be removed from the map.
 	scheduledUpdates *scheduledTickQueue
 	neighbourUpdates []neighbourUpdate
+	// queuedUpdates holds the entries of neighbourUpdates, so that the same
+	// update is not queued more than once per tick.
+	queuedUpdates map[neighbourUpdate]struct{}
+
+	// batchDepth is the number of nested Tx.BatchBlockUpdates calls currently
+	// running. While positive, positions changed are collected in
+	// batchedChanges instead of updating their neighbours directly.
+	batchDepth     int
+	batchedChanges []cube.Pos
+	batchedSet     map[cube.Pos]struct{}
 
 	viewerMu sync.Mutex
 	viewers  map[*Loader]Viewer
@@ -313,6 +328,34 @@ func (w *World) setBlock(pos cube.Pos, b Block, opts *SetOpts) {
 	}
 
 	if !opts.DisableBlockUpdates {
+		if w.batchDepth > 0 {
+			w.batchChange(pos)
+			return
+		}
+		w.doBlockUpdatesAround(pos)
+	}
+}
+
+// batchChange records a position changed during a Tx.BatchBlockUpdates call.
+// Every position is only recorded once per batch.
+func (w *World) batchChange(pos cube.Pos) {
+	if w.batchedSet == nil {
+		w.batchedSet = make(map[cube.Pos]struct{})
+	}
+	if _, ok := w.batchedSet[pos]; ok {
+		return
+	}
+	w.batchedSet[pos] = struct{}{}
+	w.batchedChanges = append(w.batchedChanges, pos)
+}
+
+// flushBatchedChanges updates the neighbours of all positions changed during
+// a Tx.BatchBlockUpdates call, in the order in which they were first changed.
+func (w *World) flushBatchedChanges() {
+	changes := w.batchedChanges
+	w.batchedChanges = nil
+	clear(w.batchedSet)
+	for _, pos := range changes {
 		w.doBlockUpdatesAround(pos)
 	}
 }
@@ -563,6 +606,15 @@ func (w *World) light(pos cube.Pos) uint8 {
 	return w.chunk(chunkPosFromBlockPos(pos)).Light(uint8(pos[0]), int16(pos[1]), uint8(pos[2]))
 }
 
+// blockLight returns the light level emitted by blocks, such as torches, at
+// the position passed. Unlike light, skylight does not influence this value.
+func (w *World) blockLight(pos cube.Pos) uint8 {
+	if pos.OutOfBounds(w.ra) {
+		return 0
+	}
+	return w.chunk(chunkPosFromBlockPos(pos)).SubChunk(int16(pos[1])).BlockLight(uint8(pos[0]&0xf), uint8(pos[1]&0xf), uint8(pos[2]&0xf))
+}
+
 // skyLight returns the skylight level at the position passed. This light level
 // is not influenced by blocks that emit light, such as torches. The light
 // value, similarly to light, is a value in the range 0-15, where 0 means no
@@ -644,6 +696,28 @@ func (w *World) enableTimeCycle(v bool) {
 	}
 }
 
+// Insomnia checks if phantoms spawn at night above players in the World that
+// have not slept for a while.
+func (w *World) Insomnia() bool {
+	if w == nil {
+		return false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.Insomnia
+}
+
+// SetInsomnia changes if phantoms spawn at night above players in the World
+// that have not slept for a while.
+func (w *World) SetInsomnia(v bool) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.Insomnia = v
+}
+
 // temperature returns the temperature in the World at a specific position.
 // Higher altitudes and different biomes influence the temperature returned.
 func (w *World) temperature(pos cube.Pos) float64 {
@@ -936,8 +1010,18 @@ type neighbourUpdate struct {
 
 // updateNeighbour ticks the position passed as a result of the neighbour
 // passed being updated.
+// Updates already queued for the same position and neighbour are not queued
+// again, as they would have the same result.
 func (w *World) updateNeighbour(pos, changedNeighbour cube.Pos) {
-	w.neighbourUpdates = append(w.neighbourUpdates, neighbourUpdate{pos: pos, neighbour: changedNeighbour})
+	update := neighbourUpdate{pos: pos, neighbour: changedNeighbour}
+	if w.queuedUpdates == nil {
+		w.queuedUpdates = make(map[neighbourUpdate]struct{})
+	}
+	if _, ok := w.queuedUpdates[update]; ok {
+		return
+	}
+	w.queuedUpdates[update] = struct{}{}
+	w.neighbourUpdates = append(w.neighbourUpdates, update)
 }
 
 // Handle changes the current Handler of the world. As a result, events called
@@ -992,6 +1076,7 @@ func (w *World) save(f func(*Tx, ChunkPos, *Column)) ExecFunc {
 		for pos, c := range w.chunks {
 			f(tx, pos, c)
 		}
+		w.saveMaps()
 		w.conf.Log.Debug("Updating level.dat values...")
 		w.conf.Provider.SaveSettings(w.set)
 	}
@@ -1172,6 +1257,10 @@ func (w *World) loadChunk(pos ChunkPos) (*Column, error) {
 		col := newColumn(chunk.New(airRID, w.Range()))
 		w.chunks[pos] = col
 
+		if g, ok := w.conf.Generator.(BlockEntityGenerator); ok {
+			maps.Copy(col.BlockEntities, g.GenerateBlockEntities(pos, col.Chunk))
+			return col, nil
+		}
 		w.conf.Generator.GenerateChunk(pos, col.Chunk)
 		return col, nil
 	default:
@@ -1259,6 +1348,10 @@ type Column struct {
 
 	viewers []Viewer
 	loaders []*Loader
+
+	// inhabitedTime is the number of ticks that players have spent within
+	// simulation distance of the Column.
+	inhabitedTime int64
 }
 
 // newColumn returns a new Column wrapper around the chunk.Chunk passed.
@@ -1276,6 +1369,7 @@ func (w *World) columnTo(col *Column, pos ChunkPos) *chunk.Column {
 		BlockEntities:   make([]chunk.BlockEntity, 0, len(col.BlockEntities)),
 		ScheduledBlocks: make([]chunk.ScheduledBlockUpdate, 0, len(scheduled)),
 		Tick:            w.scheduledUpdates.currentTick,
+		InhabitedTime:   col.inhabitedTime,
 	}
 	for _, e := range col.Entities {
 		data := e.encodeNBT()
@@ -1299,6 +1393,7 @@ func (w *World) columnFrom(c *chunk.Column, _ ChunkPos) *Column {
 		Chunk:         c.Chunk,
 		Entities:      make([]*EntityHandle, 0, len(c.Entities)),
 		BlockEntities: make(map[cube.Pos]Block, len(c.BlockEntities)),
+		inhabitedTime: c.InhabitedTime,
 	}
 	for _, e := range c.Entities {
 		eid, ok := e.Data["identifier"].(string)